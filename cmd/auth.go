@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/auth/jwt/decode"
+	"github.com/supabase/cli/internal/auth/jwt/sign"
+	"github.com/supabase/cli/internal/auth/users/create"
+	"github.com/supabase/cli/internal/auth/users/delete"
+	"github.com/supabase/cli/internal/auth/users/invite"
+	"github.com/supabase/cli/internal/auth/users/list"
+	"github.com/supabase/cli/internal/auth/users/update"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/auth"
+)
+
+var (
+	authCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "auth",
+		Short:   "Manage Supabase Auth",
+	}
+
+	authUsersCmd = &cobra.Command{
+		Use:   "users",
+		Short: "Manage users of Supabase Auth",
+	}
+
+	usersOutput = utils.EnumFlag{
+		Allowed: []string{utils.OutputPretty, utils.OutputCsv, utils.OutputJson, utils.OutputYaml},
+		Value:   utils.OutputPretty,
+	}
+	usersFilter       list.ListFilter
+	usersCreatedAfter string
+
+	authUsersListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List users",
+		Example: `  supabase auth users list --email '*@example.com' --provider github
+  supabase auth users list --all --created-after 2024-01-01 -o csv > users.csv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(usersCreatedAfter) > 0 {
+				t, err := parseDate(usersCreatedAfter)
+				if err != nil {
+					return err
+				}
+				usersFilter.CreatedAfter = t
+			}
+			return list.Run(cmd.Context(), flags.ProjectRef, usersFilter, usersOutput.Value)
+		},
+	}
+
+	createUser         auth.CreateUserRequest
+	userMetadata       string
+	authUsersCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Create a user",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := parseJSONFlag(userMetadata, &createUser.UserMetadata); err != nil {
+				return err
+			}
+			return create.Run(cmd.Context(), flags.ProjectRef, createUser, usersOutput.Value)
+		},
+	}
+
+	updateUser         auth.UpdateUserRequest
+	authUsersUpdateCmd = &cobra.Command{
+		Use:   "update <user-id>",
+		Short: "Update a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			updateUser.Id = args[0]
+			if err := parseJSONFlag(userMetadata, &updateUser.UserMetadata); err != nil {
+				return err
+			}
+			return update.Run(cmd.Context(), flags.ProjectRef, updateUser, usersOutput.Value)
+		},
+	}
+
+	softDelete         bool
+	authUsersDeleteCmd = &cobra.Command{
+		Use:   "delete <user-id> ...",
+		Short: "Delete one or more users",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return delete.Run(cmd.Context(), flags.ProjectRef, args, softDelete)
+		},
+	}
+
+	inviteUser         auth.InviteUserRequest
+	authUsersInviteCmd = &cobra.Command{
+		Use:   "invite <email>",
+		Short: "Invite a user by email",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inviteUser.Email = args[0]
+			if err := parseJSONFlag(userMetadata, &inviteUser.Data); err != nil {
+				return err
+			}
+			return invite.Run(cmd.Context(), flags.ProjectRef, inviteUser)
+		},
+	}
+)
+
+var (
+	authJwtCmd = &cobra.Command{
+		Use:   "jwt",
+		Short: "Sign and inspect JWTs for testing RLS policies",
+	}
+
+	jwtSecret  string
+	signParams sign.SignParams
+
+	authJwtSignCmd = &cobra.Command{
+		Use:   "sign",
+		Short: "Mint a JWT signed with the project JWT secret",
+		Example: `  supabase auth jwt sign --role authenticated --sub 5f0c6d8e-8b8a-4d36-9d8a-1f2b3c4d5e6f
+  supabase auth jwt sign --claims '{"email":"user@example.com","app_metadata":{"tenant":"acme"}}' --expires-in 5m`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			signParams.Secret = jwtSecret
+			return sign.Run(cmd.Context(), flags.ProjectRef, signParams)
+		},
+	}
+
+	verifyJwt bool
+
+	authJwtDecodeCmd = &cobra.Command{
+		Use:   "decode <token>",
+		Short: "Decode a JWT and optionally verify its signature",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return decode.Run(cmd.Context(), args[0], flags.ProjectRef, verifyJwt, jwtSecret)
+		},
+	}
+)
+
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return t, errors.Errorf("invalid date %q: must be YYYY-MM-DD or RFC3339", value)
+	}
+	return t, nil
+}
+
+func parseJSONFlag(value string, dst *map[string]any) error {
+	if len(value) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(value), dst); err != nil {
+		return errors.Errorf("failed to parse metadata: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	authFlags := authCmd.PersistentFlags()
+	authFlags.Bool("linked", false, "Manages Auth of the linked project.")
+	authFlags.Bool("local", true, "Manages Auth of the local stack.")
+	authCmd.MarkFlagsMutuallyExclusive("linked", "local")
+	authUsersCmd.PersistentFlags().VarP(&usersOutput, "output", "o", "Output format of users.")
+	listFlags := authUsersListCmd.Flags()
+	listFlags.StringVar(&usersFilter.EmailPattern, "email", "", "Only list users with emails matching this glob pattern.")
+	listFlags.StringVar(&usersFilter.Provider, "provider", "", "Only list users signed up with this provider.")
+	listFlags.StringVar(&usersCreatedAfter, "created-after", "", "Only list users created after this date.")
+	listFlags.UintVar(&usersFilter.Page, "page", 1, "Page number to list.")
+	listFlags.UintVar(&usersFilter.PerPage, "per-page", auth.PAGE_LIMIT, "Number of users per page.")
+	listFlags.BoolVar(&usersFilter.All, "all", false, "List users from all pages.")
+	authUsersListCmd.MarkFlagsMutuallyExclusive("page", "all")
+	authUsersCmd.AddCommand(authUsersListCmd)
+	createFlags := authUsersCreateCmd.Flags()
+	createFlags.StringVar(&createUser.Email, "email", "", "Email address of the user.")
+	createFlags.StringVar(&createUser.Phone, "phone", "", "Phone number of the user.")
+	createFlags.StringVar(&createUser.Password, "password", "", "Password of the user.")
+	createFlags.BoolVar(&createUser.EmailConfirm, "email-confirm", true, "Mark the email address as confirmed.")
+	createFlags.BoolVar(&createUser.PhoneConfirm, "phone-confirm", true, "Mark the phone number as confirmed.")
+	createFlags.StringVar(&userMetadata, "user-metadata", "", "User metadata as a JSON object.")
+	authUsersCmd.AddCommand(authUsersCreateCmd)
+	updateFlags := authUsersUpdateCmd.Flags()
+	updateFlags.StringVar(&updateUser.Email, "email", "", "New email address of the user.")
+	updateFlags.StringVar(&updateUser.Phone, "phone", "", "New phone number of the user.")
+	updateFlags.StringVar(&updateUser.Password, "password", "", "New password of the user.")
+	updateFlags.BoolVar(&updateUser.EmailConfirm, "email-confirm", false, "Mark the email address as confirmed.")
+	updateFlags.StringVar(&updateUser.BanDuration, "ban-duration", "", "Ban the user for a duration, ie. 24h, or none to lift the ban.")
+	updateFlags.StringVar(&userMetadata, "user-metadata", "", "User metadata as a JSON object.")
+	authUsersCmd.AddCommand(authUsersUpdateCmd)
+	authUsersDeleteCmd.Flags().BoolVar(&softDelete, "soft", false, "Soft delete the users instead of removing them permanently.")
+	authUsersCmd.AddCommand(authUsersDeleteCmd)
+	inviteFlags := authUsersInviteCmd.Flags()
+	inviteFlags.StringVar(&inviteUser.RedirectTo, "redirect-to", "", "URL to redirect to after accepting the invite.")
+	inviteFlags.StringVar(&userMetadata, "data", "", "User metadata as a JSON object.")
+	authUsersCmd.AddCommand(authUsersInviteCmd)
+	authCmd.AddCommand(authUsersCmd)
+	authJwtCmd.PersistentFlags().StringVar(&jwtSecret, "secret", "", "JWT secret to use instead of the local or linked project secret.")
+	jwtSignFlags := authJwtSignCmd.Flags()
+	jwtSignFlags.StringVar(&signParams.Role, "role", "authenticated", "Postgres role of the token.")
+	jwtSignFlags.StringVar(&signParams.Subject, "sub", "", "User ID to set as the subject claim.")
+	jwtSignFlags.StringVar(&signParams.Claims, "claims", "", "Extra claims as a JSON object.")
+	jwtSignFlags.DurationVar(&signParams.ExpiresIn, "expires-in", time.Hour, "Duration until the token expires.")
+	authJwtCmd.AddCommand(authJwtSignCmd)
+	authJwtDecodeCmd.Flags().BoolVar(&verifyJwt, "verify", false, "Verify the signature with the JWT secret.")
+	authJwtCmd.AddCommand(authJwtDecodeCmd)
+	authCmd.AddCommand(authJwtCmd)
+	rootCmd.AddCommand(authCmd)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/backup/create"
+	"github.com/supabase/cli/internal/backup/restore"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	backupCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "backup",
+		Short:   "Manage full project snapshots of database, storage, and config",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			cmd.SetContext(ctx)
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+	}
+
+	backupFile string
+
+	backupCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Archive database, storage objects, and config into a single file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return create.Run(cmd.Context(), flags.ProjectRef, flags.DbConfig, backupFile, afero.NewOsFs())
+		},
+	}
+
+	backupRestoreCmd = &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Restore database, storage objects, and config from a backup file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return restore.Run(cmd.Context(), args[0], flags.ProjectRef, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	backupFlags := backupCmd.PersistentFlags()
+	backupFlags.String("db-url", "", "Connects to the database specified by the connection string (must be percent-encoded).")
+	backupFlags.Bool("linked", false, "Uses the database and storage of the linked project.")
+	backupFlags.Bool("local", true, "Uses the database and storage of the local project.")
+	backupCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	backupFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", backupFlags.Lookup("password")))
+	backupCreateCmd.Flags().StringVarP(&backupFile, "file", "f", "", "Path to save the backup archive. Defaults to a timestamped file in the current directory.")
+	backupCmd.AddCommand(backupCreateCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+	rootCmd.AddCommand(backupCmd)
+}
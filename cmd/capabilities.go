@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+type commandInfo struct {
+	Path         string   `json:"path"`
+	Group        string   `json:"group,omitempty"`
+	Experimental bool     `json:"experimental,omitempty"`
+	Flags        []string `json:"flags,omitempty"`
+}
+
+type capabilities struct {
+	Version             string          `json:"version"`
+	ConfigSchemaVersion int             `json:"config_schema_version"`
+	Commands            []commandInfo   `json:"commands"`
+	Features            map[string]bool `json:"features"`
+	OutputFormats       []string        `json:"output_formats"`
+	ContainerRuntimes   []string        `json:"container_runtimes"`
+}
+
+var showCapabilities bool
+
+// Describes the command tree without any network calls so IDE plugins can adapt to the installed version.
+func printCapabilities(root *cobra.Command) error {
+	result := capabilities{
+		Version:             utils.Version,
+		ConfigSchemaVersion: config.SchemaVersion,
+		Commands:            listCommands(root),
+		Features: map[string]bool{
+			"experimental": viper.GetBool("EXPERIMENTAL"),
+			"offline":      utils.IsOffline(),
+		},
+		OutputFormats:     utils.OutputFormat.Allowed,
+		ContainerRuntimes: utils.ContainerRuntime.Allowed,
+	}
+	return utils.EncodeOutput(utils.OutputJson, os.Stdout, result)
+}
+
+func listCommands(parent *cobra.Command) []commandInfo {
+	var result []commandInfo
+	for _, cmd := range parent.Commands() {
+		if !cmd.IsAvailableCommand() {
+			continue
+		}
+		info := commandInfo{
+			Path:         cmd.CommandPath(),
+			Group:        cmd.GroupID,
+			Experimental: IsExperimental(cmd),
+		}
+		cmd.NonInheritedFlags().VisitAll(func(f *pflag.Flag) {
+			if !f.Hidden {
+				info.Flags = append(info.Flags, f.Name)
+			}
+		})
+		sort.Strings(info.Flags)
+		result = append(result, info)
+		result = append(result, listCommands(cmd)...)
+	}
+	return result
+}
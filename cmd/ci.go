@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	ciInit "github.com/supabase/cli/internal/ci/init"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	ciCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "ci",
+		Short:   "Manage continuous integration workflows",
+	}
+
+	ciProvider = utils.EnumFlag{
+		Allowed: []string{ciInit.ProviderGithub, ciInit.ProviderGitlab},
+		Value:   ciInit.ProviderGithub,
+	}
+	ciBranch    string
+	ciTypesPath string
+	ciForce     bool
+
+	ciInitCmd = &cobra.Command{
+		Use:   "init",
+		Short: "Generate a CI workflow for your project",
+		Long:  "Generate a CI workflow that checks generated types, creates preview branches for pull requests, and deploys migrations and functions on merge.",
+		Example: `  supabase ci init
+  supabase ci init --provider gitlab --types-path src/lib/database.types.ts`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ciInit.Run(cmd.Context(), ciProvider.Value, ciBranch, ciTypesPath, ciForce, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	initFlags := ciInitCmd.Flags()
+	initFlags.Var(&ciProvider, "provider", "CI provider to generate the workflow for.")
+	initFlags.StringVar(&ciBranch, "branch", "main", "Production branch to deploy from.")
+	initFlags.StringVar(&ciTypesPath, "types-path", "database.types.ts", "Path to the generated types checked into your repository.")
+	initFlags.BoolVar(&ciForce, "force", false, "Overwrite the existing workflow.")
+	ciCmd.AddCommand(ciInitCmd)
+	rootCmd.AddCommand(ciCmd)
+}
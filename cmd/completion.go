@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/completion"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+// Registers dynamic completions after all commands are added to the tree.
+func registerCompletions() {
+	var walk func(cmd *cobra.Command, f func(*cobra.Command))
+	walk = func(cmd *cobra.Command, f func(*cobra.Command)) {
+		f(cmd)
+		for _, child := range cmd.Commands() {
+			walk(child, f)
+		}
+	}
+	walk(rootCmd, func(cmd *cobra.Command) {
+		// Inherited flags are already registered by the parent, so errors can be ignored
+		if cmd.Flag("project-ref") != nil {
+			_ = cmd.RegisterFlagCompletionFunc("project-ref", completeProjectRefs)
+		}
+	})
+	functionsDeployCmd.ValidArgsFunction = completeLocalFunctions
+	functionsDeleteCmd.ValidArgsFunction = completeRemoteFunctions
+	functionsDownloadCmd.ValidArgsFunction = completeRemoteFunctions
+	functionsInvokeCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if invokeRemote {
+			return completeRemoteFunctions(cmd, args, toComplete)
+		}
+		return completeLocalFunctions(cmd, args, toComplete)
+	}
+	for _, child := range storageCmd.Commands() {
+		walk(child, func(cmd *cobra.Command) {
+			if cmd.ValidArgsFunction == nil && cmd.Runnable() {
+				cmd.ValidArgsFunction = completeStoragePath
+			}
+		})
+	}
+}
+
+// Dynamic completions run without the pre-run hook of root command, so they locate the
+// project directory and credentials on their own. Failures are only logged in debug mode.
+func debugCompletion(err error) ([]string, cobra.ShellCompDirective) {
+	fmt.Fprintln(utils.GetDebugLogger(), err)
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeProjectRefs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	refs, err := completion.ListProjects(cmd.Context(), afero.NewOsFs())
+	if err != nil {
+		return debugCompletion(err)
+	}
+	return refs, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeLocalFunctions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	fsys := afero.NewOsFs()
+	if err := utils.ChangeWorkDir(fsys); err != nil {
+		return debugCompletion(err)
+	}
+	slugs, err := completion.ListLocalFunctions(fsys)
+	if err != nil {
+		return debugCompletion(err)
+	}
+	return excludeArgs(slugs, args), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeRemoteFunctions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	fsys := afero.NewOsFs()
+	projectRef, err := resolveProjectRef(fsys)
+	if err != nil {
+		return debugCompletion(err)
+	}
+	slugs, err := completion.ListFunctions(cmd.Context(), projectRef, fsys)
+	if err != nil {
+		return debugCompletion(err)
+	}
+	return slugs, cobra.ShellCompDirectiveNoFileComp
+}
+
+// Completes bucket names of storage paths, falling back to local files for other paths.
+func completeStoragePath(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix := client.STORAGE_SCHEME + ":///"
+	if !strings.HasPrefix(prefix, toComplete) && (!strings.HasPrefix(toComplete, prefix) || strings.Contains(toComplete[len(prefix):], "/")) {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	fsys := afero.NewOsFs()
+	var projectRef string
+	if local, _ := cmd.Flags().GetBool("local"); !local {
+		var err error
+		if projectRef, err = resolveProjectRef(fsys); err != nil {
+			return debugCompletion(err)
+		}
+	} else if err := utils.ChangeWorkDir(fsys); err != nil {
+		return debugCompletion(err)
+	}
+	buckets, err := completion.ListBuckets(cmd.Context(), projectRef, fsys)
+	if err != nil {
+		return debugCompletion(err)
+	}
+	result := make([]string, len(buckets))
+	for i, b := range buckets {
+		result[i] = prefix + b + "/"
+	}
+	return result, cobra.ShellCompDirectiveNoSpace
+}
+
+func resolveProjectRef(fsys afero.Fs) (string, error) {
+	if len(flags.ProjectRef) > 0 {
+		return flags.ProjectRef, nil
+	}
+	if err := utils.ChangeWorkDir(fsys); err != nil {
+		return "", err
+	}
+	return flags.LoadProjectRef(fsys)
+}
+
+func excludeArgs(values, args []string) []string {
+	return slices.DeleteFunc(values, func(v string) bool {
+		return slices.Contains(args, v)
+	})
+}
@@ -3,7 +3,10 @@ package cmd
 import (
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/config/diff"
+	"github.com/supabase/cli/internal/config/pull"
 	"github.com/supabase/cli/internal/config/push"
+	"github.com/supabase/cli/internal/config/validate"
 	"github.com/supabase/cli/internal/utils/flags"
 )
 
@@ -21,10 +24,38 @@ var (
 			return push.Run(cmd.Context(), flags.ProjectRef, afero.NewOsFs())
 		},
 	}
+
+	configPullCmd = &cobra.Command{
+		Use:   "pull",
+		Short: "Pulls remote config of the linked project as TOML",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pull.Run(cmd.Context(), flags.ProjectRef, afero.NewOsFs())
+		},
+	}
+
+	configDiffCmd = &cobra.Command{
+		Use:   "diff",
+		Short: "Diffs local config.toml against the linked project",
+		Long:  "Diffs local config.toml against the linked project. Exits with non-zero status when remote settings have drifted.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diff.Run(cmd.Context(), flags.ProjectRef, afero.NewOsFs())
+		},
+	}
+
+	configValidateCmd = &cobra.Command{
+		Use:   "validate",
+		Short: "Validate local config.toml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return validate.Run(cmd.Context(), afero.NewOsFs())
+		},
+	}
 )
 
 func init() {
 	configCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	configCmd.AddCommand(configPushCmd)
+	configCmd.AddCommand(configPullCmd)
+	configCmd.AddCommand(configDiffCmd)
+	configCmd.AddCommand(configValidateCmd)
 	rootCmd.AddCommand(configCmd)
 }
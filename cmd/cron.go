@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/cron/create"
+	"github.com/supabase/cli/internal/cron/delete"
+	"github.com/supabase/cli/internal/cron/list"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	cronCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "cron",
+		Short:   "Manage pg_cron jobs",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			cmd.SetContext(ctx)
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+	}
+
+	cronListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List cron jobs with their last run status",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context(), flags.DbConfig)
+		},
+	}
+
+	cronSchedule    string
+	cronCommand     string
+	cronToMigration bool
+
+	cronCreateCmd = &cobra.Command{
+		Use:     "create <job name>",
+		Short:   "Schedule a cron job",
+		Long:    "Schedule a cron job. Scheduling a job with an existing name replaces its schedule and command.",
+		Example: `  supabase cron create cleanup --schedule '0 3 * * *' --command 'delete from logs where created_at < now() - interval ''7 days'''`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return create.Run(cmd.Context(), args[0], cronSchedule, cronCommand, cronToMigration, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	cronDeleteCmd = &cobra.Command{
+		Use:   "delete <job name>",
+		Short: "Unschedule a cron job",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return delete.Run(cmd.Context(), args[0], flags.DbConfig)
+		},
+	}
+)
+
+func init() {
+	cronFlags := cronCmd.PersistentFlags()
+	cronFlags.String("db-url", "", "Manages cron jobs of the database specified by the connection string (must be percent-encoded).")
+	cronFlags.Bool("linked", false, "Manages cron jobs of the linked project.")
+	cronFlags.Bool("local", true, "Manages cron jobs of the local database.")
+	cronCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	cronFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", cronFlags.Lookup("password")))
+	cronCmd.AddCommand(cronListCmd)
+	createFlags := cronCreateCmd.Flags()
+	createFlags.StringVar(&cronSchedule, "schedule", "", "Cron expression, ie. '*/5 * * * *' or '30 seconds'.")
+	createFlags.StringVar(&cronCommand, "command", "", "SQL command to run on schedule.")
+	createFlags.BoolVar(&cronToMigration, "migration", false, "Write the job to a new migration file instead of scheduling it directly.")
+	cobra.CheckErr(cronCreateCmd.MarkFlagRequired("schedule"))
+	cobra.CheckErr(cronCreateCmd.MarkFlagRequired("command"))
+	cronCmd.AddCommand(cronCreateCmd)
+	cronCmd.AddCommand(cronDeleteCmd)
+	rootCmd.AddCommand(cronCmd)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/daemon"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	socketPath string
+
+	daemonCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "daemon",
+		Short:   "Serve CLI operations over a local socket for editor integrations",
+		Long: `Serve CLI operations over a local socket for editor integrations.
+
+Clients send newline delimited JSON-RPC 2.0 requests. Supported methods are
+ping, status, gen.types, migration.list, and storage.ls.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return daemon.Run(ctx, socketPath, flags.ProjectRef, flags.DbConfig, afero.NewOsFs())
+		},
+		Example: `  supabase daemon --local
+  echo '{"jsonrpc":"2.0","id":1,"method":"migration.list"}' | nc -U supabase/.temp/daemon.sock`,
+	}
+)
+
+func init() {
+	daemonFlags := daemonCmd.Flags()
+	daemonFlags.StringVar(&socketPath, "socket", filepath.Join(utils.TempDir, "daemon.sock"), "Path to the unix socket to listen on.")
+	daemonFlags.String("db-url", "", "Serves requests against the database specified by the connection string (must be percent-encoded).")
+	daemonFlags.Bool("linked", false, "Serves requests against the linked project.")
+	daemonFlags.Bool("local", true, "Serves requests against the local database.")
+	daemonCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	rootCmd.AddCommand(daemonCmd)
+}
@@ -4,24 +4,53 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
+	"time"
 
+	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/db/apply"
+	"github.com/supabase/cli/internal/db/baseline"
 	"github.com/supabase/cli/internal/db/branch/create"
 	"github.com/supabase/cli/internal/db/branch/delete"
 	"github.com/supabase/cli/internal/db/branch/list"
 	"github.com/supabase/cli/internal/db/branch/switch_"
 	"github.com/supabase/cli/internal/db/diff"
 	"github.com/supabase/cli/internal/db/dump"
+	"github.com/supabase/cli/internal/db/extensions"
+	extensionDisable "github.com/supabase/cli/internal/db/extensions/disable"
+	extensionEnable "github.com/supabase/cli/internal/db/extensions/enable"
+	extensionList "github.com/supabase/cli/internal/db/extensions/list"
+	"github.com/supabase/cli/internal/db/fake"
 	"github.com/supabase/cli/internal/db/lint"
+	"github.com/supabase/cli/internal/db/policies/audit"
+	"github.com/supabase/cli/internal/db/psql"
 	"github.com/supabase/cli/internal/db/pull"
 	"github.com/supabase/cli/internal/db/push"
+	"github.com/supabase/cli/internal/db/query"
 	"github.com/supabase/cli/internal/db/remote/changes"
 	"github.com/supabase/cli/internal/db/remote/commit"
+	replicateCreate "github.com/supabase/cli/internal/db/replicate/create"
+	replicateList "github.com/supabase/cli/internal/db/replicate/list"
+	replicateRemove "github.com/supabase/cli/internal/db/replicate/remove"
 	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/db/restore"
+	"github.com/supabase/cli/internal/db/roles"
+	roleAlter "github.com/supabase/cli/internal/db/roles/alter"
+	roleCreate "github.com/supabase/cli/internal/db/roles/create"
+	roleDrop "github.com/supabase/cli/internal/db/roles/drop"
+	roleList "github.com/supabase/cli/internal/db/roles/list"
+	"github.com/supabase/cli/internal/db/snapshot"
 	"github.com/supabase/cli/internal/db/start"
 	"github.com/supabase/cli/internal/db/test"
+	dbUrl "github.com/supabase/cli/internal/db/url"
+	"github.com/supabase/cli/internal/db/watch"
+	webhookCreate "github.com/supabase/cli/internal/db/webhooks/create"
+	webhookList "github.com/supabase/cli/internal/db/webhooks/list"
+	"github.com/supabase/cli/internal/render"
+	storageApply "github.com/supabase/cli/internal/storage/apply"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 )
@@ -84,25 +113,79 @@ var (
 		},
 	}
 
+	dbSnapshotCmd = &cobra.Command{
+		Use:   "snapshot",
+		Short: "Manage snapshots of the local database",
+		Long:  "Manage snapshots of the local database. Each snapshot is a template copy of the postgres database that can be restored in seconds.",
+	}
+
+	dbSnapshotCreateCmd = &cobra.Command{
+		Use:   "create <name>",
+		Short: "Save the current state of the local database",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return snapshot.Create(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+
+	dbSnapshotListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List snapshots of the local database",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return snapshot.List(cmd.Context(), afero.NewOsFs())
+		},
+	}
+
+	dbSnapshotRestoreCmd = &cobra.Command{
+		Use:   "restore <name>",
+		Short: "Replace the local database with a snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return snapshot.Restore(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+
+	dbSnapshotDeleteCmd = &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a snapshot of the local database",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return snapshot.Delete(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+
 	useMigra    bool
 	usePgAdmin  bool
 	usePgSchema bool
-	schema      []string
-	file        string
+	diffEngine  = utils.EnumFlag{
+		Allowed: diff.Engines,
+		Value:   diff.EngineMigra,
+	}
+	schema []string
+	file   string
 
 	dbDiffCmd = &cobra.Command{
 		Use:   "diff",
 		Short: "Diffs the local database for schema changes",
-		RunE: func(cmd *cobra.Command, args []string) error {
+		PreRun: func(cmd *cobra.Command, args []string) {
+			// Map deprecated engine flags to --use
 			if usePgAdmin {
-				return diff.RunPgAdmin(cmd.Context(), schema, file, flags.DbConfig, afero.NewOsFs())
-			}
-			differ := diff.DiffSchemaMigra
-			if usePgSchema {
-				differ = diff.DiffPgSchema
-				fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "--use-pg-schema flag is experimental and may not include all entities, such as RLS policies, enums, and grants.")
+				diffEngine.Value = diff.EnginePgAdmin
+			} else if usePgSchema {
+				diffEngine.Value = diff.EnginePgSchemaDiff
 			}
-			return diff.Run(cmd.Context(), schema, file, flags.DbConfig, differ, afero.NewOsFs())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diff.RunEngine(cmd.Context(), diffEngine.Value, schema, file, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	dbApplyCmd = &cobra.Command{
+		Use:   "apply",
+		Short: "Applies declarative schema files to the database",
+		Long:  "Diffs the schema declared in supabase/schemas/*.sql against the target database, then applies the changes or saves them as a new migration file.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return apply.Run(cmd.Context(), schema, file, dryRun, flags.DbConfig, diff.DiffSchemaMigra, afero.NewOsFs())
 		},
 	}
 
@@ -111,17 +194,34 @@ var (
 	roleOnly     bool
 	keepComments bool
 	excludeTable []string
+	dumpArchive  dump.ArchiveOptions
+	// Only rows changed since the last incremental dump are exported
+	dumpIncremental bool
+	dumpWatermark   dump.IncrementalOptions
+	dumpCompress    uint
+	dumpFormat      = utils.EnumFlag{
+		Allowed: []string{dump.FormatPlain, dump.FormatCustom, dump.FormatDirectory},
+		Value:   dump.FormatPlain,
+	}
 
 	dbDumpCmd = &cobra.Command{
 		Use:   "dump",
 		Short: "Dumps data or schemas from the remote database",
 		PreRun: func(cmd *cobra.Command, args []string) {
-			if useCopy || len(excludeTable) > 0 {
+			// Archives always use copy and exclude table data instead of tables
+			if useCopy || dumpIncremental || (len(excludeTable) > 0 && dumpFormat.Value == dump.FormatPlain) {
 				cobra.CheckErr(cmd.MarkFlagRequired("data-only"))
 			}
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return dump.Run(cmd.Context(), file, flags.DbConfig, schema, excludeTable, dataOnly, roleOnly, keepComments, useCopy, dryRun, afero.NewOsFs())
+			dumpArchive.Format = dumpFormat.Value
+			if cmd.Flags().Changed("compress") {
+				dumpArchive.Compress = &dumpCompress
+			}
+			if dumpIncremental {
+				return dump.RunIncremental(cmd.Context(), file, flags.DbConfig, schema, excludeTable, dumpWatermark, dryRun, afero.NewOsFs())
+			}
+			return dump.Run(cmd.Context(), file, flags.DbConfig, schema, excludeTable, dataOnly, roleOnly, keepComments, useCopy, dryRun, dumpArchive, afero.NewOsFs())
 		},
 		PostRun: func(cmd *cobra.Command, args []string) {
 			if len(file) > 0 {
@@ -131,15 +231,53 @@ var (
 	}
 
 	dryRun       bool
+	pushPlan     bool
 	includeAll   bool
 	includeRoles bool
 	includeSeed  bool
+	// Pushing only roles or seed is allowed by excluding migrations
+	includeMigrations bool
+	pushFromVersion   string
+	pushToVersion     string
+	noTransaction     []string
+	// Buckets are applied through Storage API which is not reachable from a connection string
+	includeBuckets bool
 
 	dbPushCmd = &cobra.Command{
 		Use:   "push",
 		Short: "Push new migrations to the remote database",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if pushPlan && !dryRun {
+				return errors.New("--plan flag requires --dry-run")
+			}
+			if !includeMigrations && (len(pushFromVersion) > 0 || len(pushToVersion) > 0) {
+				return errors.New("--from and --to flags require --include-migrations")
+			}
+			if !includeMigrations && len(noTransaction) > 0 {
+				return errors.New("--no-transaction flag requires --include-migrations")
+			}
+			if includeBuckets && cmd.Flags().Changed("db-url") {
+				return errors.New("--include-buckets flag cannot be used with --db-url")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return push.Run(cmd.Context(), dryRun, includeAll, includeRoles, includeSeed, flags.DbConfig, afero.NewOsFs())
+			if pushPlan {
+				return push.RunPlan(cmd.Context(), includeAll, flags.DbConfig, afero.NewOsFs())
+			}
+			payload := push.Payload{
+				Migrations:  includeMigrations,
+				Roles:       includeRoles,
+				Seed:        includeSeed,
+				FromVersion: pushFromVersion,
+				ToVersion:   pushToVersion,
+				// Concurrent index builds cannot run inside a transaction block
+				NoTransaction: noTransaction,
+			}
+			if err := push.Run(cmd.Context(), dryRun, includeAll, payload, flags.DbConfig, afero.NewOsFs()); err != nil || !includeBuckets {
+				return err
+			}
+			return storageApply.Run(cmd.Context(), flags.ProjectRef, dryRun, false, afero.NewOsFs())
 		},
 	}
 
@@ -154,7 +292,21 @@ var (
 			return pull.Run(cmd.Context(), schema, flags.DbConfig, name, afero.NewOsFs())
 		},
 		PostRun: func(cmd *cobra.Command, args []string) {
-			fmt.Println("Finished " + utils.Aqua("supabase db pull") + ".")
+			fmt.Println(render.T("finished", utils.Aqua("supabase db pull")))
+		},
+	}
+
+	dbBaselineCmd = &cobra.Command{
+		Use:   "baseline [migration name]",
+		Short: "Baseline migrations from an existing remote database",
+		Long:  "Dumps the current schema of an existing remote database as the first migration, then marks it as applied in the remote migration history. Requires both the local migrations directory and the remote migration history to be empty.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := "baseline"
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return baseline.Run(cmd.Context(), schema, flags.DbConfig, name, afero.NewOsFs())
 		},
 	}
 
@@ -165,7 +317,7 @@ var (
 	}
 
 	dbRemoteChangesCmd = &cobra.Command{
-		Deprecated: "use \"db diff --use-migra --linked\" instead.\n",
+		Deprecated: "use \"db diff --linked\" instead.\n",
 		Use:        "changes",
 		Short:      "Show changes on the remote database",
 		Long:       "Show changes on the remote database since last migration.",
@@ -183,7 +335,8 @@ var (
 		},
 	}
 
-	noSeed bool
+	noSeed   bool
+	keepData bool
 
 	dbResetCmd = &cobra.Command{
 		Use:   "reset",
@@ -192,7 +345,24 @@ var (
 			if noSeed {
 				utils.Config.Db.Seed.Enabled = false
 			}
-			return reset.Run(cmd.Context(), migrationVersion, flags.DbConfig, afero.NewOsFs())
+			return reset.Run(cmd.Context(), migrationVersion, keepData, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	dumpFiles restore.DumpFiles
+	backupAt  string
+
+	dbRestoreCmd = &cobra.Command{
+		Use:   "restore",
+		Short: "Restores a database from dump files or a platform backup",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(backupAt) > 0 {
+				if len(flags.ProjectRef) == 0 {
+					return errors.New(utils.ErrNotLinked)
+				}
+				return restore.RunBackup(cmd.Context(), flags.ProjectRef, backupAt)
+			}
+			return restore.Run(cmd.Context(), dumpFiles, flags.DbConfig, afero.NewOsFs())
 		},
 	}
 
@@ -222,6 +392,281 @@ var (
 		},
 	}
 
+	dbRolesCmd = &cobra.Command{
+		Use:   "roles",
+		Short: "Manage Postgres roles",
+	}
+
+	roleTemplate = utils.EnumFlag{
+		Allowed: []string{roles.TemplateReadOnly, roles.TemplateReadWrite},
+	}
+	rolePassword    string
+	roleSchemas     []string
+	roleAlterParams roleAlter.AlterParams
+	roleConnLimit   int
+
+	dbRolesListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List database roles",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return roleList.Run(cmd.Context(), flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	dbRolesCreateCmd = &cobra.Command{
+		Use:   "create <role name>",
+		Short: "Create a login role and print its connection string",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return roleCreate.Run(cmd.Context(), args[0], rolePassword, roleTemplate.Value, roleSchemas, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	dbRolesAlterCmd = &cobra.Command{
+		Use:   "alter <role name>",
+		Short: "Alter password, limits or grants of a role",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			roleAlterParams.Password = rolePassword
+			roleAlterParams.Template = roleTemplate.Value
+			roleAlterParams.Schemas = roleSchemas
+			if cmd.Flags().Changed("connection-limit") {
+				roleAlterParams.ConnectionLimit = &roleConnLimit
+			}
+			return roleAlter.Run(cmd.Context(), args[0], roleAlterParams, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	dbRolesDropCmd = &cobra.Command{
+		Use:   "drop <role name>",
+		Short: "Drop a role after reassigning its owned objects",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return roleDrop.Run(cmd.Context(), args[0], flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	dbExtensionsCmd = &cobra.Command{
+		Use:   "extensions",
+		Short: "Manage Postgres extensions",
+	}
+
+	installedOnly    bool
+	asMigration      bool
+	extensionSchema  string
+	extensionVersion string
+	dropCascade      bool
+
+	dbExtensionsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List installed and available extensions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return extensionList.Run(cmd.Context(), installedOnly, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	dbExtensionsEnableCmd = &cobra.Command{
+		Use:   "enable <extension name>",
+		Short: "Enable an extension",
+		Args:  cobra.ExactArgs(1),
+		Example: `  supabase db extensions enable pg_trgm
+  supabase db extensions enable vector --schema extensions --as-migration`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return extensionEnable.Run(cmd.Context(), args[0], extensionSchema, extensionVersion, asMigration, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	dbExtensionsDisableCmd = &cobra.Command{
+		Use:   "disable <extension name>",
+		Short: "Disable an extension",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return extensionDisable.Run(cmd.Context(), args[0], dropCascade, asMigration, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	dbPoliciesCmd = &cobra.Command{
+		Use:   "policies",
+		Short: "Inspect row level security policies",
+	}
+
+	failOnMissing bool
+
+	dbPoliciesAuditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: "Report tables in exposed schemas that are not covered by RLS policies",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return audit.Run(cmd.Context(), schema, failOnMissing, flags.DbConfig)
+		},
+	}
+
+	psqlCommand string
+	psqlFile    string
+
+	dbPsqlCmd = &cobra.Command{
+		Use:   "psql [flags] [-- psql args]",
+		Short: "Open an interactive SQL shell to the local or linked database",
+		Long:  "Open psql connected to the local or linked database. Falls back to a built-in SQL shell when psql is not installed.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return psql.Run(cmd.Context(), psqlCommand, psqlFile, args, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	queryFile   string
+	queryParams []string
+	queryLimit  uint
+	queryOutput = utils.EnumFlag{
+		Allowed: []string{utils.OutputPretty, utils.OutputCsv, utils.OutputJson, utils.OutputYaml},
+		Value:   utils.OutputPretty,
+	}
+
+	dbQueryCmd = &cobra.Command{
+		Use:   "query [sql]",
+		Short: "Run a SQL query against the local or linked database",
+		Args:  cobra.MaximumNArgs(1),
+		Example: `  supabase db query "select count(*) from auth.users" -o csv
+  supabase db query "select * from profiles where id = :id" --param id=1 --linked
+  supabase db query -f checks.sql -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var sql string
+			if len(args) > 0 {
+				sql = args[0]
+			}
+			return query.Run(cmd.Context(), sql, queryFile, queryParams, queryLimit, queryOutput.Value, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	fakeRows uint
+	fakeSpec string
+	fakeFile string
+	fakeSeed int64
+
+	dbFakeCmd = &cobra.Command{
+		Use:   "fake",
+		Short: "Generate fake rows for local development and testing",
+		Long:  "Introspect table schemas and generate realistic rows that satisfy foreign keys, enums, not-null and unique constraints. Rows are inserted directly unless --file is specified, asking for confirmation when the target is not the local database.",
+		Example: `  supabase db fake --rows 20
+  supabase db fake --spec supabase/fake.toml --file supabase/seed.sql --seed 42`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fake.Run(cmd.Context(), schema, fakeRows, fakeSpec, fakeFile, fakeSeed, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	watchInterval time.Duration
+	watchKill     bool
+
+	dbWatchQueriesCmd = &cobra.Command{
+		Use:   "watch-queries",
+		Short: "Show a live view of running queries",
+		Long:  "Show currently running queries with their duration, wait events and locks, refreshed periodically. Queries blocked by other backends are highlighted. Prints a single snapshot when not attached to a terminal.",
+		Example: `  supabase db watch-queries --interval 5s
+  supabase db watch-queries --linked --kill`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return watch.Run(cmd.Context(), watchInterval, watchKill, flags.DbConfig)
+		},
+	}
+
+	dbWebhooksCmd = &cobra.Command{
+		Use:   "webhooks",
+		Short: "Manage database webhooks",
+	}
+
+	webhookParams  webhookCreate.WebhookParams
+	webhookMethod  = utils.EnumFlag{Allowed: []string{"POST", "GET"}, Value: "POST"}
+	webhookHeaders []string
+
+	dbWebhooksCreateCmd = &cobra.Command{
+		Use:     "create",
+		Short:   "Create a migration that sends HTTP requests on table changes",
+		Example: `  supabase db webhooks create --table orders --events insert,update --url https://example.com/hook`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			webhookParams.Method = webhookMethod.Value
+			webhookParams.Headers = map[string]string{}
+			for _, h := range webhookHeaders {
+				key, value, found := strings.Cut(h, "=")
+				if !found {
+					return errors.Errorf("invalid header %q: must be in the format key=value", h)
+				}
+				webhookParams.Headers[key] = value
+			}
+			return webhookCreate.Run(webhookParams, afero.NewOsFs())
+		},
+	}
+
+	dbWebhooksListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List database webhooks and their targets",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return webhookList.Run(cmd.Context(), flags.DbConfig)
+		},
+	}
+
+	dbReplicateCmd = &cobra.Command{
+		Use:   "replicate",
+		Short: "Manage read replicas of the linked project",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.GroupID = groupManagementAPI
+			return dbCmd.PersistentPreRunE(cmd, args)
+		},
+	}
+
+	replicaRegion = utils.EnumFlag{
+		Allowed: awsRegions(),
+	}
+
+	dbReplicateCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Set up a read replica in the given region",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return replicateCreate.Run(cmd.Context(), flags.ProjectRef, replicaRegion.Value)
+		},
+	}
+
+	showReplicaLag bool
+
+	dbReplicateListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List read replicas with their connection strings",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var password string
+			if showReplicaLag {
+				password = flags.NewDbConfigWithPassword(flags.ProjectRef).Password
+			}
+			return replicateList.Run(cmd.Context(), flags.ProjectRef, password)
+		},
+	}
+
+	dbReplicateRemoveCmd = &cobra.Command{
+		Use:   "remove <identifier>",
+		Short: "Remove a read replica",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return replicateRemove.Run(cmd.Context(), flags.ProjectRef, args[0])
+		},
+	}
+
+	usePgbouncer bool
+	urlRole      string
+	maskPassword bool
+
+	dbUrlCmd = &cobra.Command{
+		Use:   "url",
+		Short: "Print the connection string of a local or linked database",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Skip prompting for a password that will not be printed
+			if maskPassword {
+				viper.Set("DB_PASSWORD", dbUrl.PasswordPlaceholder)
+			}
+			return dbCmd.PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if maskPassword {
+				flags.DbConfig.Password = dbUrl.PasswordPlaceholder
+			}
+			return dbUrl.Run(cmd.Context(), flags.DbConfig, usePgbouncer, urlRole)
+		},
+	}
+
 	dbTestCmd = &cobra.Command{
 		Hidden: true,
 		Use:    "test [path] ...",
@@ -239,12 +684,22 @@ func init() {
 	dbBranchCmd.AddCommand(dbBranchListCmd)
 	dbBranchCmd.AddCommand(dbSwitchCmd)
 	dbCmd.AddCommand(dbBranchCmd)
+	// Build snapshot command
+	dbSnapshotCmd.AddCommand(dbSnapshotCreateCmd)
+	dbSnapshotCmd.AddCommand(dbSnapshotListCmd)
+	dbSnapshotCmd.AddCommand(dbSnapshotRestoreCmd)
+	dbSnapshotCmd.AddCommand(dbSnapshotDeleteCmd)
+	dbCmd.AddCommand(dbSnapshotCmd)
 	// Build diff command
 	diffFlags := dbDiffCmd.Flags()
+	diffFlags.Var(&diffEngine, "use", "Diff engine used to generate schema diff.")
 	diffFlags.BoolVar(&useMigra, "use-migra", true, "Use migra to generate schema diff.")
 	diffFlags.BoolVar(&usePgAdmin, "use-pgadmin", false, "Use pgAdmin to generate schema diff.")
 	diffFlags.BoolVar(&usePgSchema, "use-pg-schema", false, "Use pg-schema-diff to generate schema diff.")
-	dbDiffCmd.MarkFlagsMutuallyExclusive("use-migra", "use-pgadmin")
+	cobra.CheckErr(diffFlags.MarkDeprecated("use-migra", "use --use migra instead."))
+	cobra.CheckErr(diffFlags.MarkDeprecated("use-pgadmin", "use --use pgadmin instead."))
+	cobra.CheckErr(diffFlags.MarkDeprecated("use-pg-schema", "use --use pg-schema-diff instead."))
+	dbDiffCmd.MarkFlagsMutuallyExclusive("use", "use-migra", "use-pgadmin", "use-pg-schema")
 	diffFlags.String("db-url", "", "Diffs against the database specified by the connection string (must be percent-encoded).")
 	diffFlags.Bool("linked", false, "Diffs local migration files against the linked project.")
 	diffFlags.Bool("local", true, "Diffs local migration files against the local database.")
@@ -252,6 +707,17 @@ func init() {
 	diffFlags.StringVarP(&file, "file", "f", "", "Saves schema diff to a new migration file.")
 	diffFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
 	dbCmd.AddCommand(dbDiffCmd)
+	// Build apply command
+	applyFlags := dbApplyCmd.Flags()
+	applyFlags.String("db-url", "", "Applies schema files to the database specified by the connection string (must be percent-encoded).")
+	applyFlags.Bool("linked", false, "Applies schema files to the linked project.")
+	applyFlags.Bool("local", true, "Applies schema files to the local database.")
+	dbApplyCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	applyFlags.StringVarP(&file, "file", "f", "", "Saves schema changes to a new migration file instead of applying them.")
+	applyFlags.BoolVar(&dryRun, "dry-run", false, "Prints the schema changes that would be applied.")
+	dbApplyCmd.MarkFlagsMutuallyExclusive("file", "dry-run")
+	applyFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
+	dbCmd.AddCommand(dbApplyCmd)
 	// Build dump command
 	dumpFlags := dbDumpCmd.Flags()
 	dumpFlags.BoolVar(&dryRun, "dry-run", false, "Prints the pg_dump script that would be executed.")
@@ -271,13 +737,28 @@ func init() {
 	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", dumpFlags.Lookup("password")))
 	dumpFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
 	dbDumpCmd.MarkFlagsMutuallyExclusive("schema", "role-only")
+	dumpFlags.VarP(&dumpFormat, "format", "F", "Output format of the dump. Custom and directory archives include both schema and data unless --data-only is set.")
+	dumpFlags.BoolVar(&dumpIncremental, "incremental", false, "Dumps only rows changed since the last incremental dump as upserts.")
+	dumpFlags.StringVar(&dumpWatermark.Column, "watermark-column", "updated_at", "Timestamp column used to detect changed rows.")
+	dumpFlags.StringVar(&dumpWatermark.WatermarkPath, "watermark-file", "", "Path to the file tracking the last incremental dump.")
+	dbDumpCmd.MarkFlagsMutuallyExclusive("incremental", "format")
+	dbDumpCmd.MarkFlagsMutuallyExclusive("incremental", "use-copy")
+	dumpFlags.UintVarP(&dumpArchive.Jobs, "jobs", "j", 1, "Number of tables to dump in parallel, requires directory format.")
+	dumpFlags.UintVar(&dumpCompress, "compress", 0, "Compression level from 0 to 9 for custom and directory formats.")
+	dumpFlags.Lookup("compress").DefValue = "pg_dump default"
 	dbCmd.AddCommand(dbDumpCmd)
 	// Build push command
 	pushFlags := dbPushCmd.Flags()
 	pushFlags.BoolVar(&includeAll, "include-all", false, "Include all migrations not found on remote history table.")
+	pushFlags.BoolVar(&includeMigrations, "include-migrations", true, "Include pending migrations from "+utils.MigrationsDir+".")
+	pushFlags.StringVar(&pushFromVersion, "from", "", "Only push pending migrations from this version onwards.")
+	pushFlags.StringVar(&pushToVersion, "to", "", "Only push pending migrations up to and including this version.")
+	pushFlags.StringSliceVar(&noTransaction, "no-transaction", []string{}, "Versions of migrations to apply statement by statement outside of a transaction, ie. for CREATE INDEX CONCURRENTLY.")
 	pushFlags.BoolVar(&includeRoles, "include-roles", false, "Include custom roles from "+utils.CustomRolesPath+".")
 	pushFlags.BoolVar(&includeSeed, "include-seed", false, "Include seed data from your config.")
+	pushFlags.BoolVar(&includeBuckets, "include-buckets", false, "Apply storage buckets declared in "+utils.ConfigPath+" after pushing migrations.")
 	pushFlags.BoolVar(&dryRun, "dry-run", false, "Print the migrations that would be applied, but don't actually apply them.")
+	pushFlags.BoolVar(&pushPlan, "plan", false, "Print statement counts and lock heavy operations of each pending migration. Requires --dry-run.")
 	pushFlags.String("db-url", "", "Pushes to the database specified by the connection string (must be percent-encoded).")
 	pushFlags.Bool("linked", true, "Pushes to the linked project.")
 	pushFlags.Bool("local", false, "Pushes to the local database.")
@@ -295,6 +776,15 @@ func init() {
 	pullFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
 	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", pullFlags.Lookup("password")))
 	dbCmd.AddCommand(dbPullCmd)
+	// Build baseline command
+	baselineFlags := dbBaselineCmd.Flags()
+	baselineFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
+	baselineFlags.String("db-url", "", "Baselines the database specified by the connection string (must be percent-encoded).")
+	baselineFlags.Bool("linked", true, "Baselines the linked project.")
+	dbBaselineCmd.MarkFlagsMutuallyExclusive("db-url", "linked")
+	baselineFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", baselineFlags.Lookup("password")))
+	dbCmd.AddCommand(dbBaselineCmd)
 	// Build remote command
 	remoteFlags := dbRemoteCmd.PersistentFlags()
 	remoteFlags.String("db-url", "", "Connect using the specified Postgres URL (must be percent-encoded).")
@@ -310,9 +800,27 @@ func init() {
 	resetFlags.Bool("linked", false, "Resets the linked project with local migrations.")
 	resetFlags.Bool("local", true, "Resets the local database with local migrations.")
 	resetFlags.BoolVar(&noSeed, "no-seed", false, "Skip running the seed script after reset.")
+	resetFlags.BoolVar(&keepData, "keep-data", false, "Restore local table data that survives the reset.")
 	dbResetCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
 	resetFlags.StringVar(&migrationVersion, "version", "", "Reset up to the specified version.")
 	dbCmd.AddCommand(dbResetCmd)
+	// Build restore command
+	restoreFlags := dbRestoreCmd.Flags()
+	restoreFlags.String("db-url", "", "Restores the database specified by the connection string (must be percent-encoded).")
+	restoreFlags.Bool("linked", false, "Restores the linked project.")
+	restoreFlags.Bool("local", true, "Restores the local database.")
+	dbRestoreCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	restoreFlags.StringVar(&dumpFiles.Roles, "roles-file", "", "Path to the roles dump created by db dump --role-only.")
+	restoreFlags.StringVar(&dumpFiles.Schema, "schema-file", "", "Path to the schema dump created by db dump.")
+	restoreFlags.StringVar(&dumpFiles.Data, "data-file", "", "Path to the data dump created by db dump --data-only.")
+	restoreFlags.StringVar(&backupAt, "backup", "", "Restores the linked project to the point-in-time backup taken at this timestamp.")
+	dbRestoreCmd.MarkFlagsOneRequired("roles-file", "schema-file", "data-file", "backup")
+	dbRestoreCmd.MarkFlagsMutuallyExclusive("backup", "roles-file")
+	dbRestoreCmd.MarkFlagsMutuallyExclusive("backup", "schema-file")
+	dbRestoreCmd.MarkFlagsMutuallyExclusive("backup", "data-file")
+	restoreFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", restoreFlags.Lookup("password")))
+	dbCmd.AddCommand(dbRestoreCmd)
 	// Build lint command
 	lintFlags := dbLintCmd.Flags()
 	lintFlags.String("db-url", "", "Lints the database specified by the connection string (must be percent-encoded).")
@@ -323,8 +831,160 @@ func init() {
 	lintFlags.Var(&level, "level", "Error level to emit.")
 	lintFlags.Var(&lintFailOn, "fail-on", "Error level to exit with non-zero status.")
 	dbCmd.AddCommand(dbLintCmd)
+	// Build roles command
+	rolesFlags := dbRolesCmd.PersistentFlags()
+	rolesFlags.String("db-url", "", "Manages roles of the database specified by the connection string (must be percent-encoded).")
+	rolesFlags.Bool("linked", false, "Manages roles of the linked project.")
+	rolesFlags.Bool("local", true, "Manages roles of the local database.")
+	dbRolesCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	rolesFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", rolesFlags.Lookup("password")))
+	dbRolesCmd.AddCommand(dbRolesListCmd)
+	roleCreateFlags := dbRolesCreateCmd.Flags()
+	roleCreateFlags.StringVar(&rolePassword, "role-password", "", "Password of the new role. Generated if not specified.")
+	roleCreateFlags.Var(&roleTemplate, "grant", "Grant template to apply to the new role.")
+	roleCreateFlags.StringSliceVarP(&roleSchemas, "schema", "s", []string{"public"}, "Comma separated list of schema to grant privileges on.")
+	dbRolesCmd.AddCommand(dbRolesCreateCmd)
+	roleAlterFlags := dbRolesAlterCmd.Flags()
+	roleAlterFlags.StringVar(&rolePassword, "role-password", "", "New password of the role.")
+	roleAlterFlags.BoolVar(&roleAlterParams.GeneratePassword, "generate-password", false, "Generate a new random password for the role.")
+	roleAlterFlags.IntVar(&roleConnLimit, "connection-limit", -1, "Maximum concurrent connections of the role.")
+	roleAlterFlags.StringVar(&roleAlterParams.ValidUntil, "valid-until", "", "Timestamp after which the role password expires.")
+	roleAlterFlags.Var(&roleTemplate, "grant", "Grant template to apply to the role.")
+	roleAlterFlags.StringSliceVarP(&roleSchemas, "schema", "s", []string{"public"}, "Comma separated list of schema to grant privileges on.")
+	dbRolesAlterCmd.MarkFlagsMutuallyExclusive("role-password", "generate-password")
+	dbRolesCmd.AddCommand(dbRolesAlterCmd)
+	dbRolesCmd.AddCommand(dbRolesDropCmd)
+	dbCmd.AddCommand(dbRolesCmd)
+	// Build extensions command
+	extensionFlags := dbExtensionsCmd.PersistentFlags()
+	extensionFlags.String("db-url", "", "Manages extensions of the database specified by the connection string (must be percent-encoded).")
+	extensionFlags.Bool("linked", false, "Manages extensions of the linked project.")
+	extensionFlags.Bool("local", true, "Manages extensions of the local database.")
+	dbExtensionsCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	extensionFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", extensionFlags.Lookup("password")))
+	dbExtensionsListCmd.Flags().BoolVar(&installedOnly, "installed", false, "Only list installed extensions.")
+	dbExtensionsCmd.AddCommand(dbExtensionsListCmd)
+	enableFlags := dbExtensionsEnableCmd.Flags()
+	enableFlags.StringVarP(&extensionSchema, "schema", "s", extensions.DefaultSchema, "Schema to install the extension objects into.")
+	enableFlags.StringVar(&extensionVersion, "version", "", "Version of the extension to install. Defaults to the latest available.")
+	enableFlags.BoolVar(&asMigration, "as-migration", false, "Saves the statement to a new migration file instead of executing it.")
+	dbExtensionsCmd.AddCommand(dbExtensionsEnableCmd)
+	disableFlags := dbExtensionsDisableCmd.Flags()
+	disableFlags.BoolVar(&dropCascade, "cascade", false, "Also drop objects that depend on the extension.")
+	disableFlags.BoolVar(&asMigration, "as-migration", false, "Saves the statement to a new migration file instead of executing it.")
+	dbExtensionsCmd.AddCommand(dbExtensionsDisableCmd)
+	dbCmd.AddCommand(dbExtensionsCmd)
 	// Build start command
 	dbCmd.AddCommand(dbStartCmd)
+	// Build policies command
+	auditFlags := dbPoliciesAuditCmd.Flags()
+	auditFlags.String("db-url", "", "Audits the database specified by the connection string (must be percent-encoded).")
+	auditFlags.Bool("linked", false, "Audits the linked project.")
+	auditFlags.Bool("local", true, "Audits the local database.")
+	dbPoliciesAuditCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	auditFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to audit. Defaults to exposed API schemas.")
+	auditFlags.BoolVar(&failOnMissing, "fail-on-missing", false, "Exit with non-zero status if any table is not covered.")
+	auditFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", auditFlags.Lookup("password")))
+	dbPoliciesCmd.AddCommand(dbPoliciesAuditCmd)
+	dbCmd.AddCommand(dbPoliciesCmd)
+	// Build replicate command
+	dbReplicateCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	replicateCreateFlags := dbReplicateCreateCmd.Flags()
+	replicateCreateFlags.Var(&replicaRegion, "region", "Region to set up the read replica in.")
+	cobra.CheckErr(dbReplicateCreateCmd.MarkFlagRequired("region"))
+	dbReplicateCmd.AddCommand(dbReplicateCreateCmd)
+	replicateListFlags := dbReplicateListCmd.Flags()
+	replicateListFlags.BoolVar(&showReplicaLag, "lag", false, "Connect to each replica to show its replication lag.")
+	replicateListFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", replicateListFlags.Lookup("password")))
+	dbReplicateCmd.AddCommand(dbReplicateListCmd)
+	dbReplicateCmd.AddCommand(dbReplicateRemoveCmd)
+	dbCmd.AddCommand(dbReplicateCmd)
+	// Build psql command
+	psqlFlags := dbPsqlCmd.Flags()
+	psqlFlags.String("db-url", "", "Connects to the database specified by the connection string (must be percent-encoded).")
+	psqlFlags.Bool("linked", false, "Connects to the linked project.")
+	psqlFlags.Bool("local", true, "Connects to the local database.")
+	dbPsqlCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	psqlFlags.StringVarP(&psqlCommand, "command", "c", "", "Run a single SQL command and exit.")
+	psqlFlags.StringVarP(&psqlFile, "file", "f", "", "Run SQL commands from a file and exit.")
+	dbPsqlCmd.MarkFlagsMutuallyExclusive("command", "file")
+	psqlFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", psqlFlags.Lookup("password")))
+	dbCmd.AddCommand(dbPsqlCmd)
+	// Build query command
+	queryFlags := dbQueryCmd.Flags()
+	queryFlags.String("db-url", "", "Queries the database specified by the connection string (must be percent-encoded).")
+	queryFlags.Bool("linked", false, "Queries the linked project.")
+	queryFlags.Bool("local", true, "Queries the local database.")
+	dbQueryCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	queryFlags.StringVarP(&queryFile, "file", "f", "", "Path to a file containing the SQL query.")
+	queryFlags.StringArrayVar(&queryParams, "param", []string{}, "Bind a named parameter, ie. --param key=value for :key in the query.")
+	queryFlags.UintVar(&queryLimit, "limit", 1000, "Maximum number of rows to output. Set to 0 to disable.")
+	queryFlags.VarP(&queryOutput, "output", "o", "Output format of query results.")
+	queryFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", queryFlags.Lookup("password")))
+	dbCmd.AddCommand(dbQueryCmd)
+	// Build fake command
+	fakeFlags := dbFakeCmd.Flags()
+	fakeFlags.String("db-url", "", "Generates rows in the database specified by the connection string (must be percent-encoded).")
+	fakeFlags.Bool("linked", false, "Generates rows in the linked project.")
+	fakeFlags.Bool("local", true, "Generates rows in the local database.")
+	dbFakeCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	fakeFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
+	fakeFlags.UintVar(&fakeRows, "rows", 10, "Number of rows to generate per table, unless overridden by the spec.")
+	fakeFlags.StringVar(&fakeSpec, "spec", "", "Path to a TOML or JSON file with per table row counts.")
+	fakeFlags.StringVarP(&fakeFile, "file", "f", "", "Write INSERT statements to this file instead of executing them.")
+	fakeFlags.Int64Var(&fakeSeed, "seed", 0, "Seed of the random generator for reproducible rows. Defaults to a random seed.")
+	fakeFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", fakeFlags.Lookup("password")))
+	dbCmd.AddCommand(dbFakeCmd)
+	// Build watch-queries command
+	watchFlags := dbWatchQueriesCmd.Flags()
+	watchFlags.String("db-url", "", "Watches queries on the database specified by the connection string (must be percent-encoded).")
+	watchFlags.Bool("linked", false, "Watches queries on the linked project.")
+	watchFlags.Bool("local", true, "Watches queries on the local database.")
+	dbWatchQueriesCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	watchFlags.DurationVar(&watchInterval, "interval", 2*time.Second, "Time between refreshes.")
+	watchFlags.BoolVar(&watchKill, "kill", false, "Allow terminating the selected backend interactively.")
+	watchFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", watchFlags.Lookup("password")))
+	dbCmd.AddCommand(dbWatchQueriesCmd)
+	// Build webhooks command
+	webhookFlags := dbWebhooksCreateCmd.Flags()
+	webhookFlags.StringVar(&webhookParams.Table, "table", "", "Table to watch, optionally qualified by schema.")
+	webhookFlags.StringSliceVar(&webhookParams.Events, "events", []string{"insert"}, "Comma separated list of events: insert, update, delete.")
+	webhookFlags.StringVar(&webhookParams.Url, "url", "", "URL to send HTTP requests to.")
+	webhookFlags.StringVar(&webhookParams.Name, "name", "", "Name of the trigger. Defaults to <table>_webhook.")
+	webhookFlags.Var(&webhookMethod, "method", "HTTP method of the request.")
+	webhookFlags.StringArrayVar(&webhookHeaders, "header", []string{}, "HTTP header of the request, ie. Authorization=Bearer <token>.")
+	webhookFlags.UintVar(&webhookParams.TimeoutMs, "timeout", 1000, "Request timeout in milliseconds.")
+	cobra.CheckErr(dbWebhooksCreateCmd.MarkFlagRequired("table"))
+	cobra.CheckErr(dbWebhooksCreateCmd.MarkFlagRequired("url"))
+	dbWebhooksCmd.AddCommand(dbWebhooksCreateCmd)
+	webhookListFlags := dbWebhooksListCmd.Flags()
+	webhookListFlags.String("db-url", "", "Lists webhooks of the database specified by the connection string (must be percent-encoded).")
+	webhookListFlags.Bool("linked", false, "Lists webhooks of the linked project.")
+	webhookListFlags.Bool("local", true, "Lists webhooks of the local database.")
+	dbWebhooksListCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	webhookListFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", webhookListFlags.Lookup("password")))
+	dbWebhooksCmd.AddCommand(dbWebhooksListCmd)
+	dbCmd.AddCommand(dbWebhooksCmd)
+	// Build url command
+	urlFlags := dbUrlCmd.Flags()
+	urlFlags.Bool("linked", false, "Prints the connection string of the linked project.")
+	urlFlags.Bool("local", true, "Prints the connection string of the local database.")
+	dbUrlCmd.MarkFlagsMutuallyExclusive("linked", "local")
+	urlFlags.BoolVar(&usePgbouncer, "pgbouncer", false, "Use the connection pooler in transaction mode.")
+	urlFlags.StringVar(&urlRole, "role", "", "Replace the database user with the specified role.")
+	urlFlags.BoolVar(&maskPassword, "mask-password", false, "Replace the password with a placeholder.")
+	urlFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", urlFlags.Lookup("password")))
+	dbCmd.AddCommand(dbUrlCmd)
 	// Build test command
 	dbCmd.AddCommand(dbTestCmd)
 	testFlags := dbTestCmd.Flags()
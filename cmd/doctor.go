@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/doctor"
+)
+
+var (
+	doctorCmd = &cobra.Command{
+		GroupID: groupQuickStart,
+		Use:     "doctor",
+		Short:   "Diagnose problems with your development environment",
+		Long:    "Checks Docker, port conflicts, disk space, config.toml, network access to the Management API and your login token. Use --output json to generate a report that is safe to share.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doctor.Run(cmd.Context(), afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
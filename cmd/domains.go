@@ -22,8 +22,9 @@ Use of custom domains and vanity subdomains is mutually exclusive.
 `,
 	}
 
-	rawOutput      bool
-	customHostname string
+	rawOutput       bool
+	customHostname  string
+	waitCertificate bool
 
 	customHostnamesCreateCmd = &cobra.Command{
 		Use:   "create",
@@ -32,7 +33,7 @@ Use of custom domains and vanity subdomains is mutually exclusive.
 
 Expects your custom hostname to have a CNAME record to your Supabase project's subdomain.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return create.Run(cmd.Context(), flags.ProjectRef, customHostname, rawOutput, afero.NewOsFs())
+			return create.Run(cmd.Context(), flags.ProjectRef, customHostname, rawOutput, waitCertificate, afero.NewOsFs())
 		},
 	}
 
@@ -46,10 +47,11 @@ Expects your custom hostname to have a CNAME record to your Supabase project's s
 	}
 
 	customHostnamesReverifyCmd = &cobra.Command{
-		Use:   "reverify",
-		Short: "Re-verify the custom hostname config for your project",
+		Use:     "reverify",
+		Aliases: []string{"verify"},
+		Short:   "Re-verify the custom hostname config for your project",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return reverify.Run(cmd.Context(), flags.ProjectRef, rawOutput, afero.NewOsFs())
+			return reverify.Run(cmd.Context(), flags.ProjectRef, rawOutput, waitCertificate, afero.NewOsFs())
 		},
 	}
 
@@ -61,7 +63,7 @@ Expects your custom hostname to have a CNAME record to your Supabase project's s
 This reconfigures your Supabase project to respond to requests on your custom hostname.
 After the custom hostname is activated, your project's auth services will no longer function on the Supabase-provisioned subdomain.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return activate.Run(cmd.Context(), flags.ProjectRef, rawOutput, afero.NewOsFs())
+			return activate.Run(cmd.Context(), flags.ProjectRef, rawOutput, waitCertificate, afero.NewOsFs())
 		},
 	}
 
@@ -79,6 +81,9 @@ func init() {
 	persistentFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	persistentFlags.BoolVar(&rawOutput, "include-raw-output", false, "Include raw output (useful for debugging).")
 	customHostnamesCreateCmd.Flags().StringVar(&customHostname, "custom-hostname", "", "The custom hostname to use for your Supabase project.")
+	customHostnamesCreateCmd.Flags().BoolVar(&waitCertificate, "wait", false, "Wait for the SSL certificate to be issued.")
+	customHostnamesReverifyCmd.Flags().BoolVar(&waitCertificate, "wait", false, "Wait for the SSL certificate to be issued.")
+	customHostnamesActivateCmd.Flags().BoolVar(&waitCertificate, "wait", false, "Wait for the SSL certificate to be issued before activating.")
 	customHostnamesCmd.AddCommand(customHostnamesGetCmd)
 	customHostnamesCmd.AddCommand(customHostnamesCreateCmd)
 	customHostnamesCmd.AddCommand(customHostnamesReverifyCmd)
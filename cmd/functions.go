@@ -2,12 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"net/http"
+	"strconv"
 
+	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/supabase/cli/internal/functions/delete"
 	"github.com/supabase/cli/internal/functions/deploy"
 	"github.com/supabase/cli/internal/functions/download"
+	"github.com/supabase/cli/internal/functions/invoke"
 	"github.com/supabase/cli/internal/functions/list"
 	new_ "github.com/supabase/cli/internal/functions/new"
 	"github.com/supabase/cli/internal/functions/serve"
@@ -16,6 +20,9 @@ import (
 	"github.com/supabase/cli/pkg/cast"
 )
 
+// Listens on edge_runtime.inspector_port when --inspect is passed without a port
+const inspectDefault = "default"
+
 var (
 	functionsCmd = &cobra.Command{
 		GroupID: groupManagementAPI,
@@ -48,18 +55,19 @@ var (
 		Long:  "Download the source code for a Function from the linked Supabase project.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return download.Run(cmd.Context(), args[0], flags.ProjectRef, useLegacyBundle, afero.NewOsFs())
+			return download.Run(cmd.Context(), args[0], flags.ProjectRef, useLegacyBundle, downloadDir, afero.NewOsFs())
 		},
 	}
 
 	noVerifyJWT     = new(bool)
 	useLegacyBundle bool
+	downloadDir     string
 	importMapPath   string
 
 	functionsDeployCmd = &cobra.Command{
 		Use:   "deploy [Function name]",
 		Short: "Deploy a Function to Supabase",
-		Long:  "Deploy a Function to the linked Supabase project.",
+		Long:  "Deploy a Function to the linked Supabase project. Static files declared in config.toml, ie. [functions.<name>] static_files, are bundled together with the code.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Fallback to config if user did not set the flag.
 			if !cmd.Flags().Changed("no-verify-jwt") {
@@ -69,6 +77,15 @@ var (
 		},
 	}
 
+	functionTemplate = utils.EnumFlag{
+		Allowed: []string{
+			new_.TemplateWebhook,
+			new_.TemplateCron,
+			new_.TemplateOpenAI,
+			new_.TemplateStripe,
+		},
+	}
+
 	functionsNewCmd = &cobra.Command{
 		Use:   "new <Function name>",
 		Short: "Create a new Function locally",
@@ -78,12 +95,31 @@ var (
 			return cmd.Root().PersistentPreRunE(cmd, args)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return new_.Run(cmd.Context(), args[0], afero.NewOsFs())
+			return new_.Run(cmd.Context(), args[0], functionTemplate.Value, afero.NewOsFs())
+		},
+	}
+
+	invokeRemote  bool
+	invokeOptions invoke.InvokeOptions
+
+	functionsInvokeCmd = &cobra.Command{
+		Use:   "invoke <Function name>",
+		Short: "Invoke a Function",
+		Long:  "Invoke a Function served locally, or deployed to the linked project with --remote.",
+		Example: `  supabase functions invoke hello-world --body '{"name":"Functions"}'
+  supabase functions invoke hello-world --body @payload.json --header x-region:us-east-1 --remote`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var projectRef string
+			if invokeRemote {
+				projectRef = flags.ProjectRef
+			}
+			return invoke.Run(cmd.Context(), args[0], projectRef, invokeOptions, afero.NewOsFs())
 		},
 	}
 
 	envFilePath string
-	inspectBrk  bool
+	inspectBrk  string
 	inspectMode = utils.EnumFlag{
 		Allowed: []string{
 			string(serve.InspectModeRun),
@@ -96,6 +132,8 @@ var (
 	functionsServeCmd = &cobra.Command{
 		Use:   "serve",
 		Short: "Serve all Functions locally",
+		Example: `  supabase functions serve --inspect
+  supabase functions serve --inspect=9229 --inspect-function hello`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			cmd.GroupID = groupLocalDev
 			return cmd.Root().PersistentPreRunE(cmd, args)
@@ -108,11 +146,25 @@ var (
 
 			if len(inspectMode.Value) > 0 {
 				runtimeOption.InspectMode = cast.Ptr(serve.InspectMode(inspectMode.Value))
-			} else if inspectBrk {
+			} else if cmd.Flags().Changed("inspect") {
 				runtimeOption.InspectMode = cast.Ptr(serve.InspectModeBrk)
+				// Support the node style `--inspect 9229` where the port is parsed as an arg
+				if inspectBrk == inspectDefault && len(args) == 1 {
+					inspectBrk, args = args[0], nil
+				}
+				if inspectBrk != inspectDefault {
+					port, err := strconv.ParseUint(inspectBrk, 10, 16)
+					if err != nil {
+						return errors.Errorf("invalid inspector port: %s", inspectBrk)
+					}
+					runtimeOption.InspectPort = uint16(port)
+				}
+			}
+			if len(args) > 0 {
+				return errors.Errorf("unknown command %q for %q", args[0], cmd.CommandPath())
 			}
-			if runtimeOption.InspectMode == nil && runtimeOption.InspectMain {
-				return fmt.Errorf("--inspect-main must be used together with one of these flags: [inspect inspect-mode]")
+			if runtimeOption.InspectMode == nil && (runtimeOption.InspectMain || len(runtimeOption.InspectFunctions) > 0) {
+				return fmt.Errorf("--inspect-main and --inspect-function must be used together with one of these flags: [inspect inspect-mode]")
 			}
 
 			return serve.Run(cmd.Context(), envFilePath, noVerifyJWT, importMapPath, runtimeOption, afero.NewOsFs())
@@ -128,22 +180,33 @@ func init() {
 	functionsDeployCmd.Flags().BoolVar(&useLegacyBundle, "legacy-bundle", false, "Use legacy bundling mechanism.")
 	functionsDeployCmd.Flags().StringVar(&importMapPath, "import-map", "", "Path to import map file.")
 	cobra.CheckErr(functionsDeployCmd.Flags().MarkHidden("legacy-bundle"))
+	functionsNewCmd.Flags().Var(&functionTemplate, "template", "Template to scaffold the Function from.")
 	functionsServeCmd.Flags().BoolVar(noVerifyJWT, "no-verify-jwt", false, "Disable JWT verification for the Function.")
 	functionsServeCmd.Flags().StringVar(&envFilePath, "env-file", "", "Path to an env file to be populated to the Function environment.")
 	functionsServeCmd.Flags().StringVar(&importMapPath, "import-map", "", "Path to import map file.")
-	functionsServeCmd.Flags().BoolVar(&inspectBrk, "inspect", false, "Alias of --inspect-mode brk.")
+	functionsServeCmd.Flags().StringVar(&inspectBrk, "inspect", "", "Alias of --inspect-mode brk, optionally listening on the given host port.")
+	functionsServeCmd.Flags().Lookup("inspect").NoOptDefVal = inspectDefault
 	functionsServeCmd.Flags().Var(&inspectMode, "inspect-mode", "Activate inspector capability for debugging.")
 	functionsServeCmd.Flags().BoolVar(&runtimeOption.InspectMain, "inspect-main", false, "Allow inspecting the main worker.")
+	functionsServeCmd.Flags().StringSliceVar(&runtimeOption.InspectFunctions, "inspect-function", []string{}, "Serve only these Functions while inspecting.")
 	functionsServeCmd.MarkFlagsMutuallyExclusive("inspect", "inspect-mode")
 	functionsServeCmd.Flags().Bool("all", true, "Serve all Functions.")
 	cobra.CheckErr(functionsServeCmd.Flags().MarkHidden("all"))
 	functionsDownloadCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	functionsDownloadCmd.Flags().BoolVar(&useLegacyBundle, "legacy-bundle", false, "Use legacy bundling mechanism.")
+	functionsDownloadCmd.Flags().StringVar(&downloadDir, "output-dir", "", "Extract the deployed source to this directory, ie. to diff against local source.")
+	invokeFlags := functionsInvokeCmd.Flags()
+	invokeFlags.BoolVar(&invokeRemote, "remote", false, "Invoke the Function deployed to the linked project.")
+	invokeFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	invokeFlags.StringVarP(&invokeOptions.Method, "method", "X", http.MethodPost, "HTTP method of the request.")
+	invokeFlags.StringVarP(&invokeOptions.Body, "body", "d", "", "Request body, or @file to read it from a file.")
+	invokeFlags.StringArrayVarP(&invokeOptions.Headers, "header", "H", nil, "Request header in the format key:value.")
 	functionsCmd.AddCommand(functionsListCmd)
 	functionsCmd.AddCommand(functionsDeleteCmd)
 	functionsCmd.AddCommand(functionsDeployCmd)
 	functionsCmd.AddCommand(functionsNewCmd)
 	functionsCmd.AddCommand(functionsServeCmd)
 	functionsCmd.AddCommand(functionsDownloadCmd)
+	functionsCmd.AddCommand(functionsInvokeCmd)
 	rootCmd.AddCommand(functionsCmd)
 }
@@ -8,6 +8,9 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/gen/docs"
+	"github.com/supabase/cli/internal/gen/erd"
 	"github.com/supabase/cli/internal/gen/keys"
 	"github.com/supabase/cli/internal/gen/types"
 	"github.com/supabase/cli/internal/utils"
@@ -21,8 +24,10 @@ var (
 		Short:   "Run code generation tools",
 	}
 
-	keyNames  keys.CustomName
-	keyOutput = utils.EnumFlag{
+	keyNames   keys.CustomName
+	keyLocal   bool
+	keyEnvPath string
+	keyOutput  = utils.EnumFlag{
 		Allowed: []string{
 			utils.OutputEnv,
 			utils.OutputJson,
@@ -35,6 +40,9 @@ var (
 	genKeysCmd = &cobra.Command{
 		Use:   "keys",
 		Short: "Generate keys for preview branch",
+		Long:  "Generate keys for preview branch, or with --local, a fresh JWT secret and matching anon and service role keys for the local development setup.",
+		Example: `  supabase gen keys --project-ref abcdefghijklmnopqrst
+  supabase gen keys --local --env-path .env.local`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			es, err := env.EnvironToEnvSet(override)
 			if err != nil {
@@ -43,10 +51,15 @@ var (
 			if err := env.Unmarshal(es, &keyNames); err != nil {
 				return err
 			}
-			cmd.GroupID = groupManagementAPI
+			if !keyLocal {
+				cmd.GroupID = groupManagementAPI
+			}
 			return cmd.Root().PersistentPreRunE(cmd, args)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if keyLocal {
+				return keys.RunLocal(cmd.Context(), keyEnvPath, keyOutput.Value, keyNames, afero.NewOsFs())
+			}
 			return keys.Run(cmd.Context(), flags.ProjectRef, keyOutput.Value, keyNames, afero.NewOsFs())
 		},
 	}
@@ -56,10 +69,14 @@ var (
 			types.LangTypescript,
 			types.LangGo,
 			types.LangSwift,
+			types.LangKotlin,
+			types.LangPython,
 		},
 		Value: types.LangTypescript,
 	}
 	postgrestV9Compat  bool
+	watchTypes         bool
+	typesOutputFile    string
 	swiftAccessControl = utils.EnumFlag{
 		Allowed: []string{
 			types.SwiftInternalAccessControl,
@@ -79,6 +96,9 @@ var (
 			if len(args) > 0 && args[0] != types.LangTypescript && !cmd.Flags().Changed("lang") {
 				return errors.New("use --lang flag to specify the typegen language")
 			}
+			if watchTypes && !cmd.Flags().Changed("local") {
+				return errors.New("--watch can only be used together with --local")
+			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -91,12 +111,50 @@ var (
 					return err
 				}
 			}
+			if watchTypes {
+				return types.RunWatch(ctx, typesOutputFile, flags.DbConfig, lang.Value, schema, postgrestV9Compat, swiftAccessControl.Value, afero.NewOsFs())
+			}
 			return types.Run(ctx, flags.ProjectRef, flags.DbConfig, lang.Value, schema, postgrestV9Compat, swiftAccessControl.Value, afero.NewOsFs())
 		},
 		Example: `  supabase gen types --local
   supabase gen types --linked --lang=go
+  supabase gen types --local --lang=kotlin --schema public
   supabase gen types --project-id abc-def-123 --schema public --schema private
-  supabase gen types --db-url 'postgresql://...' --schema public --schema auth`,
+  supabase gen types --db-url 'postgresql://...' --schema public --schema auth
+  supabase gen types --local --watch --output-file src/database.types.ts`,
+	}
+
+	docsFormat = utils.EnumFlag{
+		Allowed: []string{docs.FormatMarkdown, docs.FormatHtml},
+		Value:   docs.FormatMarkdown,
+	}
+	docsTemplate string
+
+	genDocsCmd = &cobra.Command{
+		Use:   "docs",
+		Short: "Generate documentation from Postgres schema",
+		Long:  "Generate Markdown or HTML documentation of tables, columns, constraints, comments, RLS policies, and functions. Use --template to override the built-in Go template.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return docs.Run(cmd.Context(), flags.DbConfig, schema, docsFormat.Value, docsTemplate, afero.NewOsFs())
+		},
+		Example: `  supabase gen docs --local > SCHEMA.md
+  supabase gen docs --linked --format html --schema public,storage > schema.html
+  supabase gen docs --local --template docs.md.tmpl`,
+	}
+
+	erdFormat = utils.EnumFlag{
+		Allowed: []string{erd.FormatMermaid, erd.FormatDot},
+		Value:   erd.FormatMermaid,
+	}
+
+	genErdCmd = &cobra.Command{
+		Use:   "erd",
+		Short: "Generate entity-relationship diagram from Postgres schema",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return erd.Run(cmd.Context(), flags.DbConfig, schema, erdFormat.Value)
+		},
+		Example: `  supabase gen erd --local > schema.mmd
+  supabase gen erd --linked --format dot | dot -Tsvg > schema.svg`,
 	}
 )
 
@@ -111,11 +169,38 @@ func init() {
 	typeFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
 	typeFlags.Var(&swiftAccessControl, "swift-access-control", "Access control for Swift generated types.")
 	typeFlags.BoolVar(&postgrestV9Compat, "postgrest-v9-compat", false, "Generate types compatible with PostgREST v9 and below. Only use together with --db-url.")
+	typeFlags.BoolVar(&watchTypes, "watch", false, "Apply pending local migrations and regenerate types whenever they or the local database schema change.")
+	typeFlags.StringVar(&typesOutputFile, "output-file", "", "Path to write the generated types to. Required with --watch.")
+	genTypesCmd.MarkFlagsRequiredTogether("watch", "output-file")
 	genCmd.AddCommand(genTypesCmd)
 	keyFlags := genKeysCmd.Flags()
 	keyFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	keyFlags.VarP(&keyOutput, "output", "o", "Output format of key variables.")
 	keyFlags.StringSliceVar(&override, "override-name", []string{}, "Override specific variable names.")
+	keyFlags.BoolVar(&keyLocal, "local", false, "Rotate the JWT secret and API keys of the local development setup.")
+	keyFlags.StringVar(&keyEnvPath, "env-path", ".env", "Path to the env file that stores the local keys, relative to the project directory.")
+	genKeysCmd.MarkFlagsMutuallyExclusive("local", "project-ref")
 	genCmd.AddCommand(genKeysCmd)
+	docsFlags := genDocsCmd.Flags()
+	docsFlags.String("db-url", "", "Generate docs from the database specified by the connection string (must be percent-encoded).")
+	docsFlags.Bool("linked", false, "Generate docs from the linked project.")
+	docsFlags.Bool("local", true, "Generate docs from the local dev database.")
+	genDocsCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	docsFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
+	docsFlags.Var(&docsFormat, "format", "Output format of the generated docs.")
+	docsFlags.StringVar(&docsTemplate, "template", "", "Path to a Go template that overrides the built-in one.")
+	docsFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", docsFlags.Lookup("password")))
+	genCmd.AddCommand(genDocsCmd)
+	erdFlags := genErdCmd.Flags()
+	erdFlags.String("db-url", "", "Generate diagram from the database specified by the connection string (must be percent-encoded).")
+	erdFlags.Bool("linked", false, "Generate diagram from the linked project.")
+	erdFlags.Bool("local", true, "Generate diagram from the local dev database.")
+	genErdCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	erdFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
+	erdFlags.Var(&erdFormat, "format", "Output format of the generated diagram.")
+	erdFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", erdFlags.Lookup("password")))
+	genCmd.AddCommand(genErdCmd)
 	rootCmd.AddCommand(genCmd)
 }
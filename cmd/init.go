@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	_init "github.com/supabase/cli/internal/init"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/utils"
 )
 
@@ -46,7 +47,8 @@ var (
 			return _init.Run(ctx, fsys, createVscodeSettings, createIntellijSettings, initParams)
 		},
 		PostRun: func(cmd *cobra.Command, args []string) {
-			fmt.Println("Finished " + utils.Aqua("supabase init") + ".")
+			fmt.Fprintln(utils.GetHumanWriter(), render.T("finished", utils.Aqua("supabase init")))
+			utils.PrintResult(utils.ConfigPath)
 		},
 	}
 )
@@ -59,5 +61,7 @@ func init() {
 	flags.BoolVar(createIntellijSettings, "with-intellij-settings", false, "Generate IntelliJ IDEA settings for Deno.")
 	flags.BoolVar(&initParams.UseOrioleDB, "use-orioledb", false, "Use OrioleDB storage engine for Postgres.")
 	flags.BoolVar(&initParams.Overwrite, "force", false, "Overwrite existing "+utils.ConfigPath+".")
+	flags.StringVar(&initParams.Template, "template", "", "Scaffold a starter template, ie. nextjs, flutter, expo or bare.")
+	flags.BoolVar(&initParams.RemoteTemplate, "remote-template", false, "Fetch the latest starter templates from GitHub instead of using those bundled with the CLI.")
 	rootCmd.AddCommand(initCmd)
 }
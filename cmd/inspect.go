@@ -11,6 +11,7 @@ import (
 	"github.com/supabase/cli/internal/inspect/bloat"
 	"github.com/supabase/cli/internal/inspect/blocking"
 	"github.com/supabase/cli/internal/inspect/cache"
+	"github.com/supabase/cli/internal/inspect/diagnose"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 
@@ -211,12 +212,44 @@ var (
 		},
 	}
 
-	outputDir string
+	inspectDiagnoseCmd = &cobra.Command{
+		Use:   "diagnose",
+		Short: "Run a suite of health checks and summarise the results",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diagnose.Run(cmd.Context(), flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	outputDir    string
+	reportFormat = utils.EnumFlag{
+		Allowed: inspect.ReportFormats,
+		Value:   inspect.FormatCSV,
+	}
+	reportCompare bool
 
 	reportCmd = &cobra.Command{
 		Use:   "report",
-		Short: "Generate a CSV output for all inspect commands",
+		Short: "Generate a CSV, md, or html report for all inspect commands",
+		Example: `  supabase inspect report --format html
+  supabase inspect report --compare report/report_20250101T090000.md report/report_20250201T090000.md`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if reportCompare {
+				return cobra.ExactArgs(2)(cmd, args)
+			}
+			return cobra.NoArgs(cmd, args)
+		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Comparing saved reports does not require a database connection
+			if reportCompare {
+				cmd.SilenceUsage = true
+				return nil
+			}
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if reportCompare {
+				return inspect.Compare(args[0], args[1], afero.NewOsFs())
+			}
 			ctx := cmd.Context()
 			if len(outputDir) == 0 {
 				defaultPath := filepath.Join(utils.CurrentDirAbs, "report")
@@ -225,9 +258,11 @@ var (
 					return err
 				} else if len(dir) == 0 {
 					outputDir = defaultPath
+				} else {
+					outputDir = dir
 				}
 			}
-			return inspect.Report(ctx, outputDir, flags.DbConfig, afero.NewOsFs())
+			return inspect.Report(ctx, outputDir, reportFormat.Value, flags.DbConfig, afero.NewOsFs())
 		},
 	}
 )
@@ -258,8 +293,13 @@ func init() {
 	inspectDBCmd.AddCommand(inspectVacuumStatsCmd)
 	inspectDBCmd.AddCommand(inspectRoleConfigsCmd)
 	inspectDBCmd.AddCommand(inspectRoleConnectionsCmd)
+	inspectDBCmd.AddCommand(inspectDiagnoseCmd)
 	inspectCmd.AddCommand(inspectDBCmd)
-	reportCmd.Flags().StringVar(&outputDir, "output-dir", "", "Path to save CSV files in")
+	reportFlags := reportCmd.Flags()
+	reportFlags.StringVar(&outputDir, "output-dir", "", "Path to save report files in")
+	reportFlags.Var(&reportFormat, "format", "Save results as separate CSV files or a single md or html report.")
+	reportFlags.BoolVar(&reportCompare, "compare", false, "Compare two md or html reports instead of inspecting the database.")
+	reportCmd.MarkFlagsMutuallyExclusive("compare", "output-dir")
 	inspectCmd.AddCommand(reportCmd)
 	rootCmd.AddCommand(inspectCmd)
 }
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/keys/create"
+	"github.com/supabase/cli/internal/keys/list"
+	"github.com/supabase/cli/internal/keys/revoke"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/api"
+)
+
+var (
+	keysCmd = &cobra.Command{
+		GroupID: groupManagementAPI,
+		Use:     "keys",
+		Short:   "Manage project API keys",
+	}
+
+	revealKeys bool
+
+	keysListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List publishable, secret, and legacy API keys",
+		Long:  "List API keys of the linked project. Secret and service role keys are masked unless --reveal is specified.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context(), flags.ProjectRef, revealKeys)
+		},
+	}
+
+	keyType = utils.EnumFlag{
+		Allowed: []string{
+			string(api.CreateApiKeyBodyTypePublishable),
+			string(api.CreateApiKeyBodyTypeSecret),
+		},
+		Value: string(api.CreateApiKeyBodyTypeSecret),
+	}
+	keyDescription string
+	keySecretName  string
+
+	keysCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Create a publishable or secret API key",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body := api.CreateApiKeyBody{Type: api.CreateApiKeyBodyType(keyType.Value)}
+			if cmd.Flags().Changed("description") {
+				body.Description = &keyDescription
+			}
+			return create.Run(cmd.Context(), flags.ProjectRef, body, revealKeys, keySecretName, afero.NewOsFs())
+		},
+		Example: `  supabase keys create --type publishable --description "Web app"
+  supabase keys create --type secret --secret-name STRIPE_WEBHOOK_KEY`,
+	}
+
+	keysRevokeCmd = &cobra.Command{
+		Use:   "revoke <id or name>",
+		Short: "Revoke a publishable or secret API key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return revoke.Run(cmd.Context(), flags.ProjectRef, args[0])
+		},
+	}
+)
+
+func init() {
+	keysCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	keysListCmd.Flags().BoolVar(&revealKeys, "reveal", false, "Print the full value of secret keys.")
+	keysCmd.AddCommand(keysListCmd)
+	createFlags := keysCreateCmd.Flags()
+	createFlags.Var(&keyType, "type", "Type of API key to create.")
+	createFlags.StringVar(&keyDescription, "description", "", "Description of the API key.")
+	createFlags.BoolVar(&revealKeys, "reveal", false, "Print the full value of the created key.")
+	createFlags.StringVar(&keySecretName, "secret-name", "", "Also store the created key as a function secret with this name.")
+	keysCmd.AddCommand(keysCreateCmd)
+	keysCmd.AddCommand(keysRevokeCmd)
+	rootCmd.AddCommand(keysCmd)
+}
@@ -14,11 +14,16 @@ import (
 )
 
 var (
+	linkName string
+
 	linkCmd = &cobra.Command{
 		GroupID: groupLocalDev,
 		Use:     "link",
 		Short:   "Link to a Supabase project",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := setLinkName(cmd); err != nil {
+				return err
+			}
 			if !term.IsTerminal(int(os.Stdin.Fd())) && !viper.IsSet("PROJECT_ID") {
 				return cmd.MarkFlagRequired("project-ref")
 			}
@@ -39,8 +44,17 @@ var (
 	}
 )
 
+// Selects the named environment to link, overriding SUPABASE_LINK_NAME.
+func setLinkName(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("name") {
+		viper.Set("LINK_NAME", linkName)
+	}
+	return utils.AssertLinkNameIsValid(utils.GetLinkName())
+}
+
 func init() {
 	linkFlags := linkCmd.Flags()
+	linkFlags.StringVar(&linkName, "name", "", "Name of the linked environment, such as staging.")
 	linkFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	linkFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
 	// For some reason, BindPFlag only works for StringVarP instead of StringP
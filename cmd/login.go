@@ -30,7 +30,8 @@ var (
 			if params.Token == "" {
 				params.Token = login.ParseAccessToken(os.Stdin)
 			}
-			if params.Token == "" && !params.OpenBrowser {
+			// Device flow prints the verification code so it works without TTY
+			if params.Token == "" && !params.OpenBrowser && !params.Device {
 				return ErrMissingToken
 			}
 			if cmd.Flags().Changed("no-browser") {
@@ -47,5 +48,7 @@ func init() {
 	loginFlags.StringVar(&params.TokenName, "name", "", "Name that will be used to store token in your settings")
 	loginFlags.Lookup("name").DefValue = "built-in token name generator"
 	loginFlags.Bool("no-browser", false, "Do not open browser automatically")
+	loginFlags.BoolVar(&params.Device, "device", false, "Use OAuth device authorization flow with automatic token refresh")
+	loginCmd.MarkFlagsMutuallyExclusive("token", "device")
 	rootCmd.AddCommand(loginCmd)
 }
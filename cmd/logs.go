@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/logs/query"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	logsCmd = &cobra.Command{
+		GroupID: groupManagementAPI,
+		Use:     "logs",
+		Short:   "Query logs of Supabase services",
+	}
+
+	logsService = utils.EnumFlag{
+		Allowed: query.Services,
+	}
+	logsOutput = utils.EnumFlag{
+		Allowed: []string{utils.OutputPretty, utils.OutputCsv, utils.OutputJson, utils.OutputYaml},
+		Value:   utils.OutputPretty,
+	}
+	logsParams query.QueryParams
+	logsSince  time.Duration
+	logsStart  string
+	logsEnd    string
+
+	logsQueryCmd = &cobra.Command{
+		Use:   "query",
+		Short: "Query logs from the linked project",
+		Long:  "Query platform logs of the linked project using log analytics. Time range defaults to the last hour.",
+		Example: `  supabase logs query --service db --search "deadlock detected"
+  supabase logs query --sql "select timestamp, event_message from edge_logs limit 5" -o csv
+  supabase logs query --service api --start 2024-01-01T00:00:00Z --end 2024-01-01T06:00:00Z -o json`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(logsService.Value) == 0 && len(logsParams.Sql) == 0 {
+				return errors.New("must set either --service or --sql flag")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logsParams.Service = logsService.Value
+			logsParams.End = time.Now().UTC()
+			if len(logsEnd) > 0 {
+				t, err := parseDate(logsEnd)
+				if err != nil {
+					return err
+				}
+				logsParams.End = t
+			}
+			logsParams.Start = logsParams.End.Add(-logsSince)
+			if len(logsStart) > 0 {
+				t, err := parseDate(logsStart)
+				if err != nil {
+					return err
+				}
+				logsParams.Start = t
+			}
+			return query.Run(cmd.Context(), flags.ProjectRef, logsParams, logsOutput.Value)
+		},
+	}
+)
+
+func init() {
+	logsFlags := logsCmd.PersistentFlags()
+	logsFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	queryFlags := logsQueryCmd.Flags()
+	queryFlags.Var(&logsService, "service", "Service to query logs from.")
+	queryFlags.StringVar(&logsParams.Sql, "sql", "", "SQL query against the log source tables, ie. edge_logs.")
+	queryFlags.StringVar(&logsParams.Search, "search", "", "Only show logs with event messages containing this text.")
+	queryFlags.DurationVar(&logsSince, "since", time.Hour, "Query logs within this duration before the end time.")
+	queryFlags.StringVar(&logsStart, "start", "", "Start of the time range as YYYY-MM-DD or RFC3339.")
+	queryFlags.StringVar(&logsEnd, "end", "", "End of the time range as YYYY-MM-DD or RFC3339.")
+	queryFlags.UintVar(&logsParams.Limit, "limit", 100, "Maximum number of log entries to show.")
+	queryFlags.VarP(&logsOutput, "output", "o", "Output format of logs.")
+	logsQueryCmd.MarkFlagsMutuallyExclusive("sql", "service")
+	logsQueryCmd.MarkFlagsMutuallyExclusive("sql", "search")
+	logsQueryCmd.MarkFlagsMutuallyExclusive("since", "start")
+	logsCmd.AddCommand(logsQueryCmd)
+	rootCmd.AddCommand(logsCmd)
+}
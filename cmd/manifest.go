@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/manifest"
+)
+
+var manifestCmd = &cobra.Command{
+	GroupID: groupLocalDev,
+	Use:     "manifest",
+	Short:   "Print a JSON manifest describing the local project",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return manifest.Run(afero.NewOsFs())
+	},
+	Example: `  supabase manifest > manifest.json`,
+}
+
+func init() {
+	rootCmd.AddCommand(manifestCmd)
+}
@@ -1,19 +1,26 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/db/pull"
+	"github.com/supabase/cli/internal/migration/check"
+	"github.com/supabase/cli/internal/migration/down"
 	"github.com/supabase/cli/internal/migration/fetch"
+	"github.com/supabase/cli/internal/migration/lint"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/migration/new"
 	"github.com/supabase/cli/internal/migration/repair"
 	"github.com/supabase/cli/internal/migration/squash"
 	"github.com/supabase/cli/internal/migration/up"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 )
@@ -44,10 +51,12 @@ var (
 		Short: "Create an empty migration script",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return new.Run(args[0], os.Stdin, afero.NewOsFs())
+			return runMigrationNew(cmd.Context(), args[0], os.Stdin, afero.NewOsFs())
 		},
 	}
 
+	fromRemote bool
+
 	targetStatus = utils.EnumFlag{
 		Allowed: []string{
 			repair.Applied,
@@ -62,7 +71,7 @@ var (
 			return repair.Run(cmd.Context(), flags.DbConfig, args, targetStatus.Value, afero.NewOsFs())
 		},
 		PostRun: func(cmd *cobra.Command, args []string) {
-			fmt.Println("Finished " + utils.Aqua("supabase migration repair") + ".")
+			fmt.Println(render.T("finished", utils.Aqua("supabase migration repair")))
 		},
 	}
 
@@ -75,18 +84,63 @@ var (
 			return squash.Run(cmd.Context(), migrationVersion, flags.DbConfig, afero.NewOsFs())
 		},
 		PostRun: func(cmd *cobra.Command, args []string) {
-			fmt.Println("Finished " + utils.Aqua("supabase migration squash") + ".")
+			fmt.Println(render.T("finished", utils.Aqua("supabase migration squash")))
 		},
 	}
 
+	upToVersion string
+
 	migrationUpCmd = &cobra.Command{
 		Use:   "up",
 		Short: "Apply pending migrations to local database",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return up.Run(cmd.Context(), includeAll, flags.DbConfig, afero.NewOsFs())
+			return up.Run(cmd.Context(), includeAll, upToVersion, flags.DbConfig, afero.NewOsFs())
 		},
 		PostRun: func(cmd *cobra.Command, args []string) {
-			fmt.Println("Local database is up to date.")
+			fmt.Println(render.T("local_db_up_to_date"))
+		},
+	}
+
+	downCount     uint
+	downToVersion string
+
+	migrationDownCmd = &cobra.Command{
+		Use:   "down",
+		Short: "Revert applied migrations on local database",
+		Long: `Revert applied migrations on local database, starting from the latest.
+
+Statements after a line containing only "-- migrate:down" between statements of a migration file are run to revert it, and are skipped when the migration is applied.`,
+		Example: `  supabase migration down
+  supabase migration down --count 3
+  supabase migration down --to 20240101000000`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return down.Run(cmd.Context(), downCount, downToVersion, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	migrationCheckCmd = &cobra.Command{
+		Use:   "check",
+		Short: "Check migrations for conflicts before deploying",
+		Long: `Check migrations for conflicts before deploying.
+
+Fails when migration files share a version, when unapplied migrations are older than the latest remote version, or when migrations modify objects in managed schemas. Remote history is only checked when a database is specified.`,
+		Example: `  supabase migration check
+  supabase migration check --linked`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return check.Run(cmd.Context(), flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	failOnUnsafe bool
+
+	migrationLintCmd = &cobra.Command{
+		Use:   "lint [file] ...",
+		Short: "Lint migrations for lock hazards",
+		Long:  "Lint migrations for statements that take ACCESS EXCLUSIVE locks or rewrite tables, and suggest safer alternatives. Lints all local migrations if no file is specified.",
+		Example: `  supabase migration lint
+  supabase migration lint supabase/migrations/20240101000000_add_index.sql --fail-on-unsafe`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return lint.Run(args, failOnUnsafe, afero.NewOsFs())
 		},
 	}
 
@@ -140,7 +194,17 @@ func init() {
 	upFlags.Bool("linked", false, "Applies pending migrations to the linked project.")
 	upFlags.Bool("local", true, "Applies pending migrations to the local database.")
 	migrationUpCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	upFlags.StringVar(&upToVersion, "to", "", "Applies pending migrations up to and including this version.")
 	migrationCmd.AddCommand(migrationUpCmd)
+	// Build down command
+	downFlags := migrationDownCmd.Flags()
+	downFlags.UintVar(&downCount, "count", 1, "Number of migrations to revert.")
+	downFlags.StringVar(&downToVersion, "to", "", "Reverts all migrations applied after this version.")
+	migrationDownCmd.MarkFlagsMutuallyExclusive("count", "to")
+	downFlags.String("db-url", "", "Reverts migrations of the database specified by the connection string (must be percent-encoded).")
+	downFlags.Bool("local", true, "Reverts migrations of the local database.")
+	migrationDownCmd.MarkFlagsMutuallyExclusive("db-url", "local")
+	migrationCmd.AddCommand(migrationDownCmd)
 	// Build up command
 	fetchFlags := migrationFetchCmd.Flags()
 	fetchFlags.String("db-url", "", "Fetches migrations from the database specified by the connection string (must be percent-encoded).")
@@ -148,7 +212,36 @@ func init() {
 	fetchFlags.Bool("local", false, "Fetches migration history from the local database.")
 	migrationFetchCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
 	migrationCmd.AddCommand(migrationFetchCmd)
+	// Build check command
+	checkFlags := migrationCheckCmd.Flags()
+	checkFlags.String("db-url", "", "Checks migration history of the database specified by the connection string (must be percent-encoded).")
+	checkFlags.Bool("linked", false, "Checks migration history of the linked project.")
+	checkFlags.Bool("local", false, "Checks migration history of the local database.")
+	migrationCheckCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	checkFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", checkFlags.Lookup("password")))
+	migrationCheckCmd.MarkFlagsMutuallyExclusive("db-url", "password")
+	migrationCmd.AddCommand(migrationCheckCmd)
+	// Build lint command
+	migrationLintCmd.Flags().BoolVar(&failOnUnsafe, "fail-on-unsafe", false, "Exit with error if any unsafe statement is found.")
+	migrationCmd.AddCommand(migrationLintCmd)
 	// Build new command
+	newFlags := migrationNewCmd.Flags()
+	newFlags.BoolVar(&fromRemote, "from-remote", false, "Diffs the linked project against local migrations and saves the changes as the new migration.")
+	newFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include. Only used with --from-remote.")
+	newFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", newFlags.Lookup("password")))
 	migrationCmd.AddCommand(migrationNewCmd)
 	rootCmd.AddCommand(migrationCmd)
 }
+
+func runMigrationNew(ctx context.Context, name string, stdin afero.File, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if !fromRemote {
+		return new.Run(name, stdin, fsys)
+	}
+	// Captures changes made on the linked project, ie. via the SQL editor
+	if err := flags.ParseLinkedConfig(fsys); err != nil {
+		return err
+	}
+	return pull.Run(ctx, schema, flags.DbConfig, name, fsys, options...)
+}
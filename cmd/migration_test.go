@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+func TestMigrationNew(t *testing.T) {
+	t.Run("pulls changes from linked project", func(t *testing.T) {
+		fromRemote = true
+		viper.Set("DB_PASSWORD", "password")
+		t.Cleanup(func() {
+			fromRemote = false
+			viper.Set("DB_PASSWORD", "")
+			flags.ProjectRef = ""
+		})
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		project := apitest.RandomProjectRef()
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(project), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			ReplyError(pgerrcode.InvalidCatalogName, `database "postgres" does not exist`)
+		// Run test
+		err := runMigrationNew(context.Background(), "test", nil, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, `ERROR: database "postgres" does not exist (SQLSTATE 3D000)`)
+		assert.Equal(t, utils.GetSupabaseDbHost(project), flags.DbConfig.Host)
+		assert.Equal(t, "password", flags.DbConfig.Password)
+	})
+
+	t.Run("throws error on unlinked project", func(t *testing.T) {
+		fromRemote = true
+		t.Cleanup(func() { fromRemote = false })
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := runMigrationNew(context.Background(), "test", nil, fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrNotLinked)
+		exists, err := afero.DirExists(fsys, utils.MigrationsDir)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("creates empty migration by default", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		stdin, err := fsys.Create("/dev/stdin")
+		require.NoError(t, err)
+		// Run test
+		err = runMigrationNew(context.Background(), "test", stdin, fsys)
+		// Check error
+		assert.NoError(t, err)
+		files, err := afero.ReadDir(fsys, utils.MigrationsDir)
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+	})
+}
@@ -13,12 +13,14 @@ var (
 		Short:   "Manage Supabase organizations",
 	}
 
+	orgsListName string
+
 	orgsListCmd = &cobra.Command{
 		Use:   "list",
 		Short: "List all organizations",
 		Long:  "List all organizations the logged-in user belongs.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return list.Run(cmd.Context())
+			return list.Run(cmd.Context(), orgsListName)
 		},
 	}
 
@@ -34,6 +36,7 @@ var (
 )
 
 func init() {
+	orgsListCmd.Flags().StringVar(&orgsListName, "name", "", "Only list organizations whose name contains this text.")
 	orgsCmd.AddCommand(orgsListCmd)
 	orgsCmd.AddCommand(orgsCreateCmd)
 	rootCmd.AddCommand(orgsCmd)
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/plugins/list"
+)
+
+var (
+	pluginsCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "plugins",
+		Short:   "Manage third-party CLI plugins",
+		Long: `Plugins are executables named supabase-<name> on your PATH. Running "supabase <name>" dispatches to
+the plugin with the remaining args, unless <name> is a built-in command.
+
+Plugins run from the current directory and receive the following environment variables:
+  SUPABASE_CLI_VERSION   version of this CLI
+  SUPABASE_WORKDIR       path to the Supabase project directory
+  SUPABASE_API_URL       base URL of the Management API
+  SUPABASE_PROJECT_REF   linked project ref, if any
+  SUPABASE_ACCESS_TOKEN  access token of the logged-in user, if any`,
+	}
+
+	pluginsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List plugins installed on your PATH",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	pluginsCmd.AddCommand(pluginsListCmd)
+	rootCmd.AddCommand(pluginsCmd)
+}
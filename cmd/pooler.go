@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/pooler/get"
+	"github.com/supabase/cli/internal/pooler/update"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	poolerCmd = &cobra.Command{
+		GroupID: groupManagementAPI,
+		Use:     "pooler",
+		Short:   "Manage connection pooler config",
+	}
+
+	poolerGetCmd = &cobra.Command{
+		Use:   "get",
+		Short: "Get the current connection pooler config",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return get.Run(cmd.Context(), flags.ProjectRef)
+		},
+	}
+
+	poolSize uint
+
+	poolerUpdateCmd = &cobra.Command{
+		Use:   "update",
+		Short: "Update connection pooler config",
+		Long: `Update the default pool size of the connection pooler.
+
+The pool size is validated against the max connections allowed by the compute size of the project.
+Max client connections are fixed by the compute size, while pool mode is chosen by the port used
+to connect: 6543 for transaction mode and 5432 for session mode.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return update.Run(cmd.Context(), flags.ProjectRef, poolSize)
+		},
+	}
+)
+
+func init() {
+	poolerCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	poolerCmd.AddCommand(poolerGetCmd)
+	updateFlags := poolerUpdateCmd.Flags()
+	updateFlags.UintVar(&poolSize, "default-pool-size", 0, "Number of server connections to keep open per user and database.")
+	cobra.CheckErr(poolerUpdateCmd.MarkFlagRequired("default-pool-size"))
+	poolerCmd.AddCommand(poolerUpdateCmd)
+	rootCmd.AddCommand(poolerCmd)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/login"
+	"github.com/supabase/cli/internal/profiles/add"
+	"github.com/supabase/cli/internal/profiles/list"
+	"github.com/supabase/cli/internal/profiles/remove"
+)
+
+var (
+	profilesCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "profiles",
+		Short:   "Manage named credential profiles",
+		Long:    "Manage named profiles with separate access tokens and default project refs. Select a profile for any command with --profile or the SUPABASE_PROFILE environment variable.",
+	}
+
+	profilesListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List all profiles",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(afero.NewOsFs())
+		},
+	}
+
+	profileToken      string
+	profileProjectRef string
+
+	profilesAddCmd = &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add or update a profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(profileToken) == 0 {
+				profileToken = login.ParseAccessToken(os.Stdin)
+			}
+			return add.Run(args[0], profileToken, profileProjectRef, afero.NewOsFs())
+		},
+	}
+
+	profilesRemoveCmd = &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a profile and its access token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return remove.Run(args[0], afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	addFlags := profilesAddCmd.Flags()
+	addFlags.StringVar(&profileToken, "token", "", "Access token to store for this profile.")
+	addFlags.StringVar(&profileProjectRef, "project-ref", "", "Default project ref for this profile.")
+	profilesCmd.AddCommand(profilesListCmd)
+	profilesCmd.AddCommand(profilesAddCmd)
+	profilesCmd.AddCommand(profilesRemoveCmd)
+	rootCmd.AddCommand(profilesCmd)
+}
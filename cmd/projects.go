@@ -11,6 +11,7 @@ import (
 	"github.com/supabase/cli/internal/projects/create"
 	"github.com/supabase/cli/internal/projects/delete"
 	"github.com/supabase/cli/internal/projects/list"
+	"github.com/supabase/cli/internal/projects/local"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 	"github.com/supabase/cli/pkg/api"
@@ -28,6 +29,7 @@ var (
 	projectName string
 	orgId       string
 	dbPassword  string
+	waitHealthy bool
 
 	region = utils.EnumFlag{
 		Allowed: awsRegions(),
@@ -78,16 +80,18 @@ var (
 			if cmd.Flags().Changed("size") {
 				body.DesiredInstanceSize = (*api.DesiredInstanceSize)(&size.Value)
 			}
-			return create.Run(cmd.Context(), body, afero.NewOsFs())
+			return create.Run(cmd.Context(), body, waitHealthy, afero.NewOsFs())
 		},
 	}
 
+	projectsListFilter list.Filter
+
 	projectsListCmd = &cobra.Command{
 		Use:   "list",
 		Short: "List all Supabase projects",
 		Long:  "List all Supabase projects the logged-in user can access.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return list.Run(cmd.Context(), afero.NewOsFs())
+			return list.Run(cmd.Context(), projectsListFilter, afero.NewOsFs())
 		},
 	}
 
@@ -99,6 +103,20 @@ var (
 		},
 	}
 
+	projectsLocalCmd = &cobra.Command{
+		Use:   "local",
+		Short: "Manage local development stacks",
+	}
+
+	projectsLocalListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List local stacks started by the CLI",
+		Long:  "List local development stacks across all projects, including their running containers and published ports.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return local.Run(cmd.Context(), afero.NewOsFs())
+		},
+	}
+
 	projectsDeleteCmd = &cobra.Command{
 		Use:   "delete <ref>",
 		Short: "Delete a Supabase project",
@@ -136,8 +154,13 @@ func init() {
 	createFlags.Var(&plan, "plan", "Select a plan that suits your needs.")
 	cobra.CheckErr(createFlags.MarkHidden("plan"))
 	createFlags.Var(&size, "size", "Select a desired instance size for your project.")
+	createFlags.BoolVar(&waitHealthy, "wait", false, "Wait for the project to become healthy and print its connection details.")
 	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", createFlags.Lookup("db-password")))
 
+	listFlags := projectsListCmd.Flags()
+	listFlags.StringVar(&projectsListFilter.OrgId, "org", "", "Only list projects in this organization ID.")
+	listFlags.StringVar(&projectsListFilter.Name, "name", "", "Only list projects whose name contains this text.")
+
 	apiKeysFlags := projectsApiKeysCmd.Flags()
 	apiKeysFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 
@@ -146,6 +169,8 @@ func init() {
 	projectsCmd.AddCommand(projectsDeleteCmd)
 	projectsCmd.AddCommand(projectsListCmd)
 	projectsCmd.AddCommand(projectsApiKeysCmd)
+	projectsLocalCmd.AddCommand(projectsLocalListCmd)
+	projectsCmd.AddCommand(projectsLocalCmd)
 	rootCmd.AddCommand(projectsCmd)
 }
 
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/realtime/broadcast"
+	"github.com/supabase/cli/internal/realtime/listen"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	realtimeCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "realtime",
+		Short:   "Debug Supabase Realtime channels",
+	}
+
+	realtimeChannel string
+	realtimeEvent   string
+	realtimePrivate bool
+	listenOptions   listen.ListenOptions
+
+	realtimeListenCmd = &cobra.Command{
+		Use:   "listen",
+		Short: "Print messages received on a Realtime channel",
+		Example: `  supabase realtime listen --channel room1 --event '*'
+  supabase realtime listen --channel room1 --table public.messages --filter room_id=eq.1 --access-token <user-jwt>`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			listenOptions.Event = realtimeEvent
+			listenOptions.Private = realtimePrivate
+			return listen.Run(ctx, flags.ProjectRef, realtimeChannel, listenOptions)
+		},
+	}
+
+	broadcastPayload string
+
+	realtimeBroadcastCmd = &cobra.Command{
+		Use:     "broadcast",
+		Short:   "Publish a test broadcast to a Realtime channel",
+		Example: `  supabase realtime broadcast --channel room1 --event chat --payload '{"text":"hello"}'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return broadcast.Run(cmd.Context(), flags.ProjectRef, realtimeChannel, realtimeEvent, broadcastPayload, realtimePrivate)
+		},
+	}
+)
+
+func init() {
+	realtimeFlags := realtimeCmd.PersistentFlags()
+	realtimeFlags.Bool("linked", false, "Connects to Realtime of the linked project.")
+	realtimeFlags.Bool("local", true, "Connects to Realtime of the local stack.")
+	realtimeCmd.MarkFlagsMutuallyExclusive("linked", "local")
+	realtimeFlags.StringVar(&realtimeChannel, "channel", "", "Name of the channel.")
+	realtimeFlags.BoolVar(&realtimePrivate, "private", false, "Use a private channel authorized by RLS policies on realtime.messages.")
+	cobra.CheckErr(realtimeCmd.MarkPersistentFlagRequired("channel"))
+	listenFlags := realtimeListenCmd.Flags()
+	listenFlags.StringVar(&realtimeEvent, "event", "*", "Only print broadcasts of this event.")
+	listenFlags.StringVar(&listenOptions.AccessToken, "access-token", "", "User JWT to join the channel with. Defaults to the anon key.")
+	listenFlags.StringVar(&listenOptions.Table, "table", "", "Subscribe to postgres changes of this table, ie. public.messages.")
+	listenFlags.StringVar(&listenOptions.Filter, "filter", "", "Filter postgres changes, ie. id=eq.1.")
+	realtimeCmd.AddCommand(realtimeListenCmd)
+	broadcastFlags := realtimeBroadcastCmd.Flags()
+	broadcastFlags.StringVar(&realtimeEvent, "event", "test", "Event name of the broadcast.")
+	broadcastFlags.StringVar(&broadcastPayload, "payload", "{}", "JSON payload of the broadcast.")
+	realtimeCmd.AddCommand(realtimeBroadcastCmd)
+	rootCmd.AddCommand(realtimeCmd)
+}
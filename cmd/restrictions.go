@@ -15,13 +15,16 @@ var (
 	}
 
 	dbCidrsToAllow   []string
+	cidrsToAllow     []string
 	bypassCidrChecks bool
+	appendCidrs      bool
+	restrictDryRun   bool
 
 	restrictionsUpdateCmd = &cobra.Command{
 		Use:   "update",
 		Short: "Update network restrictions",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return update.Run(cmd.Context(), flags.ProjectRef, dbCidrsToAllow, bypassCidrChecks)
+			return update.Run(cmd.Context(), flags.ProjectRef, append(dbCidrsToAllow, cidrsToAllow...), bypassCidrChecks, appendCidrs, restrictDryRun)
 		},
 	}
 
@@ -36,8 +39,12 @@ var (
 
 func init() {
 	restrictionsCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
-	restrictionsUpdateCmd.Flags().StringSliceVar(&dbCidrsToAllow, "db-allow-cidr", []string{}, "CIDR to allow DB connections from.")
-	restrictionsUpdateCmd.Flags().BoolVar(&bypassCidrChecks, "bypass-cidr-checks", false, "Bypass some of the CIDR validation checks.")
+	updateFlags := restrictionsUpdateCmd.Flags()
+	updateFlags.StringSliceVar(&dbCidrsToAllow, "db-allow-cidr", []string{}, "CIDR to allow DB connections from.")
+	updateFlags.StringSliceVar(&cidrsToAllow, "allow", []string{}, "Alias of --db-allow-cidr.")
+	updateFlags.BoolVar(&bypassCidrChecks, "bypass-cidr-checks", false, "Bypass some of the CIDR validation checks.")
+	updateFlags.BoolVar(&appendCidrs, "append", false, "Append to the existing allow-list instead of replacing it.")
+	updateFlags.BoolVar(&restrictDryRun, "dry-run", false, "Print the resulting allow-list without applying it.")
 	restrictionsCmd.AddCommand(restrictionsGetCmd)
 	restrictionsCmd.AddCommand(restrictionsUpdateCmd)
 	rootCmd.AddCommand(restrictionsCmd)
@@ -6,7 +6,9 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"os/exec"
 	"os/signal"
+	"slices"
 	"strings"
 	"time"
 
@@ -14,10 +16,16 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/metrics"
+	"github.com/supabase/cli/internal/plugins"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/services"
+	"github.com/supabase/cli/internal/storage/client"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/config"
 	"golang.org/x/mod/semver"
 )
 
@@ -28,6 +36,9 @@ const (
 )
 
 func IsManagementAPI(cmd *cobra.Command) bool {
+	if runsLocally(cmd) {
+		return false
+	}
 	for cmd != cmd.Root() {
 		if cmd.GroupID == groupManagementAPI {
 			return true
@@ -41,6 +52,36 @@ func IsManagementAPI(cmd *cobra.Command) bool {
 	return false
 }
 
+// Commands under a management API group that don't call the API, so they should not require login.
+func runsLocally(cmd *cobra.Command) bool {
+	switch cmd {
+	case configValidateCmd:
+		return true
+	case functionsInvokeCmd:
+		return !invokeRemote
+	}
+	for ; cmd.HasParent(); cmd = cmd.Parent() {
+		if cmd == projectsLocalCmd {
+			return true
+		}
+	}
+	return false
+}
+
+func isStorage(cmd *cobra.Command) bool {
+	for ; cmd.HasParent(); cmd = cmd.Parent() {
+		if cmd == storageCmd {
+			return true
+		}
+	}
+	return false
+}
+
+// Storage commands can read public buckets without login or a linked project.
+func IsAnonymousStorage(cmd *cobra.Command) bool {
+	return isStorage(cmd) && client.IsAnonymous()
+}
+
 func promptLogin(fsys afero.Fs) error {
 	if _, err := utils.LoadAccessTokenFS(fsys); err == utils.ErrMissingToken {
 		utils.CmdSuggestion = fmt.Sprintf("Run %s first.", utils.Aqua("supabase login"))
@@ -61,6 +102,187 @@ var experimental = []*cobra.Command{
 	storageCmd,
 }
 
+// Commands that flush partial results on the first Ctrl+C instead of exiting immediately.
+var gracefulInterrupt = []*cobra.Command{
+	lsCmd,
+	cpCmd,
+}
+
+func isGracefulInterrupt(cmd *cobra.Command) bool {
+	for _, c := range gracefulInterrupt {
+		if cmd == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Commands that reach a self-hosted deployment through its API gateway. Others rely on the
+// Management API which is only available on the hosted platform.
+func supportsSelfHosted(cmd *cobra.Command) bool {
+	return isStorage(cmd) || cmd == functionsInvokeCmd
+}
+
+// Commands that call self-hosted APIs without a database, which is only connected when the
+// environment declares db_url.
+func skipsSelfHostedDatabase(cmd *cobra.Command) bool {
+	return supportsSelfHosted(cmd) || cmd.Parent() == authUsersCmd
+}
+
+// Commands that call the APIs of the linked project using keys from the management API, so
+// they require login instead of a database password.
+func usesApiKeys(cmd *cobra.Command) bool {
+	for ; cmd.HasParent(); cmd = cmd.Parent() {
+		if cmd == authCmd || cmd == realtimeCmd {
+			return true
+		}
+	}
+	return false
+}
+
+// Commands that don't change any database, storage or project state, allowed in readonly
+// mode. Commands are refused by default, so new commands must be added here explicitly.
+var readonly = []*cobra.Command{
+	authJwtDecodeCmd,
+	authJwtSignCmd,
+	authUsersListCmd,
+	backupCreateCmd,
+	branchGetCmd,
+	branchListCmd,
+	ciInitCmd,
+	configDiffCmd,
+	configPullCmd,
+	configValidateCmd,
+	cronListCmd,
+	customHostnamesGetCmd,
+	bansGetCmd,
+	dbBranchListCmd,
+	dbDiffCmd,
+	dbDumpCmd,
+	dbExtensionsListCmd,
+	dbLintCmd,
+	dbPoliciesAuditCmd,
+	dbRemoteChangesCmd,
+	dbReplicateListCmd,
+	dbRolesListCmd,
+	dbSnapshotListCmd,
+	dbUrlCmd,
+	dbWebhooksListCmd,
+	doctorCmd,
+	functionsDownloadCmd,
+	functionsListCmd,
+	functionsNewCmd,
+	functionsServeCmd,
+	genDocsCmd,
+	genErdCmd,
+	genKeysCmd,
+	genTypesCmd,
+	initCmd,
+	inspectDBCmd,
+	keysListCmd,
+	linkCmd,
+	loginCmd,
+	logoutCmd,
+	logsQueryCmd,
+	manifestCmd,
+	migrationCheckCmd,
+	migrationFetchCmd,
+	migrationLintCmd,
+	migrationListCmd,
+	migrationNewCmd,
+	orgsListCmd,
+	pluginsListCmd,
+	poolerGetCmd,
+	postgresConfigGetCmd,
+	profilesAddCmd,
+	profilesListCmd,
+	profilesRemoveCmd,
+	projectsApiKeysCmd,
+	projectsListCmd,
+	projectsLocalListCmd,
+	realtimeListenCmd,
+	reportCmd,
+	restrictionsGetCmd,
+	rootKeyGetCmd,
+	secretsListCmd,
+	servicesCmd,
+	snippetsDownloadCmd,
+	snippetsListCmd,
+	sslEnforcementGetCmd,
+	ssoInfoCmd,
+	ssoListCmd,
+	ssoShowCmd,
+	statusCmd,
+	accessCheckCmd,
+	findCmd,
+	lifecycleListCmd,
+	lsCmd,
+	renderCmd,
+	serveCmd,
+	statCmd,
+	storageCacheClearCmd,
+	storageDiffCmd,
+	storageEventsListenCmd,
+	usageCmd,
+	testNewCmd,
+	unlinkCmd,
+	upgradeCmd,
+	vanityCheckCmd,
+	vanityGetCmd,
+	vaultSecretsListCmd,
+}
+
+func isReadonly(cmd *cobra.Command, args []string) bool {
+	switch cmd {
+	case cpCmd:
+		// Downloads are allowed, but not uploads
+		return len(args) > 1 && !strings.HasPrefix(strings.ToLower(args[1]), client.STORAGE_SCHEME+"://")
+	case dbWatchQueriesCmd:
+		return !watchKill
+	case storageVerifyCmd:
+		return !verifyClean
+	}
+	// Help and completion commands are added by cobra
+	if name := cmd.Name(); name == "help" || name == "completion" || name == "generate-fig-spec" || strings.HasPrefix(name, cobra.ShellCompRequestCmd) {
+		return true
+	}
+	if !cmd.HasParent() {
+		return true
+	} else if parent := cmd.Parent(); parent == inspectDBCmd || parent.Name() == "completion" {
+		return true
+	}
+	return slices.Contains(readonly, cmd)
+}
+
+// Readonly mode is meant for production credentials shared with dashboards and reporting
+// jobs, so it is enabled by --readonly, by the top level readonly option of config.toml,
+// or by the environment selected with --env.
+func assertWritable(cmd *cobra.Command, args []string, fsys afero.Fs) error {
+	enabled := viper.GetBool("READONLY") || (flags.Environment != nil && flags.Environment.Readonly)
+	if !enabled {
+		var err error
+		if enabled, err = config.LoadReadonly("", utils.NewRootFS(fsys)); err != nil {
+			return err
+		}
+	}
+	if enabled && !isReadonly(cmd, args) {
+		return errors.Errorf("%s is not allowed in readonly mode", utils.Aqua(cmd.CommandPath()))
+	}
+	return nil
+}
+
+// Runs the before or after hook configured in config.toml for the current command.
+func runHook(cmd *cobra.Command, stage string, args []string) error {
+	command := strings.Fields(cmd.CommandPath())[1:]
+	if len(command) == 0 {
+		return nil
+	}
+	if len(flags.ProjectRef) > 0 {
+		utils.HookEnv[utils.HookEnvProjectRef] = flags.ProjectRef
+	}
+	return utils.RunHook(cmd.Context(), command, stage, args, afero.NewOsFs())
+}
+
 func IsExperimental(cmd *cobra.Command) bool {
 	for _, exp := range experimental {
 		if cmd == exp || cmd.Parent() == exp {
@@ -92,33 +314,89 @@ var (
 				return errors.New("must set the --experimental flag to run this command")
 			}
 			cmd.SilenceUsage = true
-			// Change workdir
+			// Resolve CA certificate relative to the original workdir
 			fsys := afero.NewOsFs()
+			if err := utils.ConfigureTransport(fsys); err != nil {
+				return err
+			}
+			// Change workdir
 			if err := utils.ChangeWorkDir(fsys); err != nil {
 				return err
 			}
+			if err := utils.SetupContainerRuntime(cmd.Context(), fsys); err != nil {
+				return err
+			}
 			// Add common flags
 			ctx := cmd.Context()
-			if IsManagementAPI(cmd) {
-				if err := promptLogin(fsys); err != nil {
+			anonymous := IsAnonymousStorage(cmd)
+			// Storage commands are checked when parsing the connection type
+			if IsManagementAPI(cmd) && (anonymous || !isStorage(cmd)) {
+				if err := utils.AssertOnline("run " + utils.Aqua(cmd.CommandPath())); err != nil {
 					return err
 				}
-				ctx, _ = signal.NotifyContext(ctx, os.Interrupt)
-				if cmd.Flags().Lookup("project-ref") != nil {
+			}
+			if err := flags.ParseEnvironment(cmd.Flags(), fsys); err != nil {
+				return err
+			}
+			if err := assertWritable(cmd, args, fsys); err != nil {
+				return err
+			}
+			// Self-hosted deployments are reached by URL so they don't require login
+			selfHosted := flags.IsSelfHosted()
+			if selfHosted && IsManagementAPI(cmd) && !supportsSelfHosted(cmd) {
+				return errors.Errorf("%s is not supported on self-hosted environments", utils.Aqua(cmd.CommandPath()))
+			}
+			if IsManagementAPI(cmd) && !anonymous {
+				// Storage credential providers are checked after loading config
+				if !isStorage(cmd) && !selfHosted {
+					if err := promptLogin(fsys); err != nil {
+						return err
+					}
+				}
+				if !isGracefulInterrupt(cmd) {
+					ctx, _ = signal.NotifyContext(ctx, os.Interrupt)
+				}
+				if cmd.Flags().Lookup("project-ref") != nil && !selfHosted {
 					if err := flags.ParseProjectRef(ctx, fsys); err != nil {
 						return err
 					}
 				}
 			}
-			if err := flags.ParseDatabaseConfig(cmd.Flags(), fsys); err != nil {
-				return err
+			// Public buckets don't require a linked project, nor self-hosted APIs without db_url
+			if !anonymous && !(selfHosted && skipsSelfHostedDatabase(cmd) && len(flags.Environment.DbUrl) == 0) {
+				if usesApiKeys(cmd) {
+					if err := flags.ParseApiConfig(cmd.Flags(), fsys); err != nil {
+						return err
+					}
+					if len(flags.ProjectRef) > 0 && !selfHosted {
+						if err := promptLogin(fsys); err != nil {
+							return err
+						}
+					}
+				} else if err := flags.ParseDatabaseConfig(cmd.Flags(), fsys); err != nil {
+					return err
+				}
+				if isStorage(cmd) && !selfHosted && !client.HasCredentialProviders() {
+					if err := promptLogin(fsys); err != nil {
+						return err
+					}
+				}
 			}
 			// Prepare context
+			if isGracefulInterrupt(cmd) {
+				ctx = utils.WithGracefulInterrupt(ctx)
+			}
 			if viper.GetBool("DEBUG") {
 				ctx = utils.WithTraceContext(ctx)
 				fmt.Fprintln(os.Stderr, cmd.Root().Short)
 			}
 			cmd.SetContext(ctx)
+			if err := metrics.Setup(ctx, cmd.CommandPath()); err != nil {
+				return err
+			}
+			if err := runHook(cmd, config.HookBefore, args); err != nil {
+				return err
+			}
 			// Setup sentry last to ignore errors from parsing cli flags
 			apiHost, err := url.Parse(utils.GetSupabaseAPIHost())
 			if err != nil {
@@ -127,13 +405,30 @@ var (
 			sentryOpts.Environment = apiHost.Host
 			return sentry.Init(sentryOpts)
 		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			return runHook(cmd, config.HookAfter, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if showCapabilities {
+				return printCapabilities(cmd)
+			}
+			return cmd.Help()
+		},
 		SilenceErrors: true,
 	}
 )
 
 func Execute() {
 	defer recoverAndExit()
-	if err := rootCmd.Execute(); err != nil {
+	if path := findPlugin(os.Args[1:]); len(path) > 0 {
+		executePlugin(path, os.Args[2:])
+		return
+	}
+	registerCompletions()
+	err := rootCmd.Execute()
+	// Flush metrics before exiting so that failed runs are also reported
+	metrics.Shutdown()
+	if err != nil {
 		panic(err)
 	}
 	// Check upgrade last because --version flag is initialised after execute
@@ -149,6 +444,38 @@ func Execute() {
 	}
 }
 
+// Built-in commands always take precedence over plugins with the same name.
+func findPlugin(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	rootCmd.InitDefaultHelpCmd()
+	rootCmd.InitDefaultCompletionCmd()
+	if cmd, _, err := rootCmd.Find(args[:1]); err == nil && cmd != rootCmd {
+		return ""
+	}
+	return plugins.Find(args[0])
+}
+
+func executePlugin(path string, args []string) {
+	initEnv()
+	err := plugins.Exec(context.Background(), path, args, afero.NewOsFs())
+	// Propagate the exit code of plugin without printing our own error
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.ExitCode())
+	} else if err != nil {
+		panic(err)
+	}
+}
+
+func initEnv() {
+	viper.SetEnvPrefix("SUPABASE")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	viper.AutomaticEnv()
+	render.Init()
+}
+
 func checkUpgrade(ctx context.Context, fsys afero.Fs) (string, error) {
 	if shouldFetchRelease(fsys) {
 		version, err := utils.GetLatestRelease(ctx)
@@ -166,6 +493,9 @@ func checkUpgrade(ctx context.Context, fsys afero.Fs) (string, error) {
 }
 
 func shouldFetchRelease(fsys afero.Fs) bool {
+	if utils.IsOffline() || showCapabilities {
+		return false
+	}
 	// Always fetch latest release when using --version flag
 	if vf := rootCmd.Flag("version"); vf != nil && vf.Changed {
 		return true
@@ -195,6 +525,7 @@ func recoverAndExit() {
 		msg = err
 	case error:
 		if !errors.Is(err, context.Canceled) &&
+			!errors.Is(err, utils.ErrInterrupted) &&
 			len(utils.CmdSuggestion) == 0 &&
 			!viper.GetBool("DEBUG") {
 			utils.CmdSuggestion = utils.SuggestDebugFlag
@@ -208,6 +539,10 @@ func recoverAndExit() {
 	if len(utils.CmdSuggestion) > 0 {
 		fmt.Fprintln(os.Stderr, utils.CmdSuggestion)
 	}
+	// Support can locate server side logs by trace ID
+	if utils.WasTraceSent() {
+		fmt.Fprintln(os.Stderr, "Trace ID:", utils.GetTraceId())
+	}
 	// Report error to sentry
 	if createTicket && len(utils.SentryDsn) > 0 {
 		sentry.ConfigureScope(addSentryScope)
@@ -217,25 +552,41 @@ func recoverAndExit() {
 			fmt.Fprintln(os.Stderr, "Quote the crash ID above when filing a bug report: https://github.com/supabase/cli/issues/new/choose")
 		}
 	}
+	if utils.WasInterrupted() {
+		os.Exit(utils.ExitCodeInterrupted)
+	}
 	os.Exit(1)
 }
 
 func init() {
-	cobra.OnInitialize(func() {
-		viper.SetEnvPrefix("SUPABASE")
-		viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
-		viper.AutomaticEnv()
-	})
+	cobra.OnInitialize(initEnv)
 
 	flags := rootCmd.PersistentFlags()
 	flags.Bool("debug", false, "output debug logs to stderr")
-	flags.String("workdir", "", "path to a Supabase project directory")
+	flags.String("workdir", "", "path to a Supabase project directory, instead of searching parent directories for supabase/config.toml")
+	flags.String("profile", "", "use credentials and project ref of the named profile")
+	flags.String("env", "", "target the project ref and database declared under [environments.<name>] in config.toml")
+	flags.String("config-env-file", "", "load environment variables from this file before interpolating config.toml")
 	flags.Bool("experimental", false, "enable experimental features")
+	flags.Bool("offline", false, "fail early on commands that require network access")
 	flags.String("network-id", "", "use the specified docker network instead of a generated one")
 	flags.Var(&utils.OutputFormat, "output", "output format of status variables")
+	flags.Bool("no-spinner", false, "print status messages line by line instead of animating spinners")
+	flags.Bool("quiet", false, "print only the primary result of supported commands to stdout, moving spinners and prompts to stderr (alias: --porcelain)")
 	flags.Var(&utils.DNSResolver, "dns-resolver", "lookup domain names using the specified resolver")
+	flags.String("dns-server", "", "lookup database hosts using this DNS server address instead of the system resolver")
+	flags.Var(&utils.IPFamily, "ip-family", "connect to remote databases using addresses of the specified IP family")
+	flags.Var(&utils.ContainerRuntime, "container-runtime", "use the specified container runtime instead of detecting one")
+	flags.String("ca-cert", "", "trust the CA certificates in this PEM file in addition to the system ones")
+	flags.Bool("insecure-skip-verify", false, "skip TLS certificate verification of all HTTPS requests (insecure)")
 	flags.BoolVar(&createTicket, "create-ticket", false, "create a support ticket for any CLI error")
+	flags.String("otel-endpoint", "", "export metrics of long running operations to this OTLP gRPC endpoint")
+	flags.String("statsd-addr", "", "send metrics of long running operations to this StatsD UDP address")
+	flags.Bool("readonly", false, "refuse to run commands that change database, storage or project state")
+	flags.String("trace-id", "", "send this correlation ID with API requests instead of a random one")
 	cobra.CheckErr(viper.BindPFlags(flags))
+	rootCmd.SetGlobalNormalizationFunc(normalizeFlagName)
+	rootCmd.Flags().BoolVar(&showCapabilities, "capabilities", false, "print CLI version and supported commands as JSON")
 
 	rootCmd.SetVersionTemplate("{{.Version}}\n")
 	rootCmd.AddGroup(&cobra.Group{ID: groupQuickStart, Title: "Quick Start:"})
@@ -243,6 +594,14 @@ func init() {
 	rootCmd.AddGroup(&cobra.Group{ID: groupManagementAPI, Title: "Management APIs:"})
 }
 
+// Accepts --porcelain as an alias of --quiet, following the convention of git.
+func normalizeFlagName(f *pflag.FlagSet, name string) pflag.NormalizedName {
+	if name == "porcelain" {
+		name = "quiet"
+	}
+	return pflag.NormalizedName(name)
+}
+
 // instantiate new rootCmd is a bit tricky with cobra, but it can be done later with the following
 // approach for example: https://github.com/portworx/pxc/tree/master/cmd
 func GetRootCmd() *cobra.Command {
@@ -266,4 +625,5 @@ func addSentryScope(scope *sentry.Scope) {
 		"Image Registry": utils.GetRegistry(),
 		"Project ID":     flags.ProjectRef,
 	})
+	scope.SetTag("trace_id", utils.GetTraceId())
 }
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/metrics"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestAssertWritable(t *testing.T) {
+	t.Run("allows any command by default", func(t *testing.T) {
+		// Run test
+		err := assertWritable(dbPushCmd, nil, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("refuses commands not marked readonly", func(t *testing.T) {
+		viper.Set("READONLY", true)
+		t.Cleanup(func() { viper.Set("READONLY", false) })
+		// Run test
+		for _, cmd := range []*cobra.Command{dbPushCmd, dbQueryCmd, migrationDownCmd, keysCreateCmd, lifecycleSetCmd, presignUploadCmd} {
+			err := assertWritable(cmd, nil, afero.NewMemMapFs())
+			// Check error
+			assert.ErrorContains(t, err, "is not allowed in readonly mode")
+		}
+	})
+
+	t.Run("allows readonly commands", func(t *testing.T) {
+		viper.Set("READONLY", true)
+		t.Cleanup(func() { viper.Set("READONLY", false) })
+		// Run test
+		for _, cmd := range []*cobra.Command{rootCmd, lsCmd, dbDumpCmd, migrationListCmd, inspectLocksCmd} {
+			err := assertWritable(cmd, nil, afero.NewMemMapFs())
+			// Check error
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("allows downloads but not uploads", func(t *testing.T) {
+		viper.Set("READONLY", true)
+		t.Cleanup(func() { viper.Set("READONLY", false) })
+		// Run test
+		assert.NoError(t, assertWritable(cpCmd, []string{"ss:///bucket/file", "file"}, afero.NewMemMapFs()))
+		err := assertWritable(cpCmd, []string{"file", "ss:///bucket/file"}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "is not allowed in readonly mode")
+	})
+
+	t.Run("refuses killing queries", func(t *testing.T) {
+		viper.Set("READONLY", true)
+		watchKill = true
+		t.Cleanup(func() {
+			viper.Set("READONLY", false)
+			watchKill = false
+		})
+		// Run test
+		err := assertWritable(dbWatchQueriesCmd, nil, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "is not allowed in readonly mode")
+	})
+
+	t.Run("enables readonly from environment", func(t *testing.T) {
+		flags.Environment = &config.Environment{Readonly: true}
+		t.Cleanup(func() { flags.Environment = nil })
+		// Run test
+		err := assertWritable(dbResetCmd, nil, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "is not allowed in readonly mode")
+	})
+
+	t.Run("enables readonly from config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte("readonly = true\n"), 0644))
+		// Run test
+		err := assertWritable(functionsDeployCmd, nil, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "is not allowed in readonly mode")
+	})
+}
+
+// Parses global flags the same way as the CLI, restoring their defaults after the test.
+func parseRootFlags(t *testing.T, args ...string) {
+	flagSet := rootCmd.PersistentFlags()
+	require.NoError(t, flagSet.Parse(args))
+	t.Cleanup(func() {
+		flagSet.Visit(func(f *pflag.Flag) {
+			require.NoError(t, f.Value.Set(f.DefValue))
+			f.Changed = false
+		})
+	})
+}
+
+func TestTransportFlags(t *testing.T) {
+	t.Run("reads ca cert from flag", func(t *testing.T) {
+		parseRootFlags(t, "--ca-cert", "missing.pem")
+		// Run test
+		err := utils.ConfigureTransport(afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to read CA certificate")
+	})
+}
+
+func TestDNSServerFlag(t *testing.T) {
+	t.Run("queries dns server from flag", func(t *testing.T) {
+		// Setup dns listener
+		listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+		parseRootFlags(t, "--dns-server", listener.LocalAddr().String())
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		// Run test
+		go func() {
+			_, _ = utils.LookupDatabaseHost(ctx, "db.supabase.invalid")
+		}()
+		// Check query
+		require.NoError(t, listener.SetReadDeadline(time.Now().Add(time.Second)))
+		_, _, err = listener.ReadFrom(make([]byte, 512))
+		assert.NoError(t, err)
+	})
+}
+
+func TestMetricsFlags(t *testing.T) {
+	t.Run("sends metrics to statsd address from flag", func(t *testing.T) {
+		// Setup statsd listener
+		listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+		parseRootFlags(t, "--statsd-addr", listener.LocalAddr().String())
+		require.NoError(t, metrics.Setup(context.Background(), "supabase db push"))
+		defer metrics.Shutdown()
+		// Run test
+		metrics.Add(context.Background(), "db.push.migrations", 1)
+		// Check packet
+		buf := make([]byte, 1024)
+		require.NoError(t, listener.SetReadDeadline(time.Now().Add(time.Second)))
+		n, _, err := listener.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "supabase.db.push.migrations:1|c|#command:supabase db push", string(buf[:n]))
+	})
+}
+
+func TestSkipsSelfHostedDatabase(t *testing.T) {
+	for _, cmd := range []*cobra.Command{lsCmd, authUsersListCmd, functionsInvokeCmd} {
+		assert.True(t, skipsSelfHostedDatabase(cmd), cmd.CommandPath())
+	}
+	for _, cmd := range []*cobra.Command{dbPushCmd, authJwtSignCmd, secretsListCmd} {
+		assert.False(t, skipsSelfHostedDatabase(cmd), cmd.CommandPath())
+	}
+}
+
+func TestUsesApiKeys(t *testing.T) {
+	for _, cmd := range []*cobra.Command{realtimeListenCmd, authUsersListCmd, authJwtSignCmd} {
+		assert.True(t, usesApiKeys(cmd), cmd.CommandPath())
+	}
+	for _, cmd := range []*cobra.Command{dbPushCmd, lsCmd, functionsInvokeCmd} {
+		assert.False(t, usesApiKeys(cmd), cmd.CommandPath())
+	}
+}
+
+func TestRunsLocally(t *testing.T) {
+	t.Cleanup(func() { invokeRemote = false })
+	for _, cmd := range []*cobra.Command{configValidateCmd, projectsLocalListCmd, functionsInvokeCmd} {
+		assert.False(t, IsManagementAPI(cmd), cmd.CommandPath())
+	}
+	invokeRemote = true
+	for _, cmd := range []*cobra.Command{functionsInvokeCmd, functionsDeployCmd, projectsListCmd} {
+		assert.True(t, IsManagementAPI(cmd), cmd.CommandPath())
+	}
+}
+
+func TestConfigEnvFileFlag(t *testing.T) {
+	// Local --env-file flags must not shadow the global one
+	global := rootCmd.PersistentFlags().Lookup("config-env-file")
+	for _, cmd := range []*cobra.Command{secretsSetCmd, functionsServeCmd} {
+		assert.Same(t, global, cmd.InheritedFlags().Lookup("config-env-file"), cmd.CommandPath())
+		assert.NotSame(t, global, cmd.Flags().Lookup("env-file"), cmd.CommandPath())
+	}
+}
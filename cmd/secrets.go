@@ -10,10 +10,16 @@ import (
 )
 
 var (
+	secretsFunction string
+
 	secretsCmd = &cobra.Command{
 		GroupID: groupManagementAPI,
 		Use:     "secrets",
 		Short:   "Manage Supabase secrets",
+		Long: `Manage secrets of the linked project through the Management API.
+
+Secrets are not supported on self-hosted environments selected with --env. Set them in the
+environment of your edge runtime container instead.`,
 	}
 
 	secretsListCmd = &cobra.Command{
@@ -21,16 +27,24 @@ var (
 		Short: "List all secrets on Supabase",
 		Long:  "List all secrets in the linked project.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return list.Run(cmd.Context(), flags.ProjectRef, afero.NewOsFs())
+			return list.Run(cmd.Context(), flags.ProjectRef, secretsFunction, afero.NewOsFs())
 		},
 	}
 
 	secretsSetCmd = &cobra.Command{
 		Use:   "set <NAME=VALUE> ...",
 		Short: "Set a secret(s) on Supabase",
-		Long:  "Set a secret(s) to the linked Supabase project.",
+		Long: `Set a secret(s) to the linked Supabase project.
+
+Secrets set with --function are prefixed by the function slug, ie. STRIPE_KEY for
+hello-world is stored as HELLO_WORLD__STRIPE_KEY, and the function must read it by that
+name. Secrets listed under [functions.<slug>] secrets in config.toml are checked by their
+exact names when the function is deployed.
+
+Prefixes only keep secret names of different functions apart. Every function on the project
+can still read every secret, so they are not an access control.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return set.Run(cmd.Context(), flags.ProjectRef, envFilePath, args, afero.NewOsFs())
+			return set.Run(cmd.Context(), flags.ProjectRef, envFilePath, secretsFunction, args, afero.NewOsFs())
 		},
 	}
 
@@ -39,13 +53,14 @@ var (
 		Short: "Unset a secret(s) on Supabase",
 		Long:  "Unset a secret(s) from the linked Supabase project.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return unset.Run(cmd.Context(), flags.ProjectRef, args, afero.NewOsFs())
+			return unset.Run(cmd.Context(), flags.ProjectRef, secretsFunction, args, afero.NewOsFs())
 		},
 	}
 )
 
 func init() {
 	secretsCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	secretsCmd.PersistentFlags().StringVar(&secretsFunction, "function", "", "Prefix secret names with the slug of this Function.")
 	secretsSetCmd.Flags().StringVar(&envFilePath, "env-file", "", "Read secrets from a .env file.")
 	secretsCmd.AddCommand(secretsListCmd)
 	secretsCmd.AddCommand(secretsSetCmd)
@@ -1,13 +1,42 @@
 package cmd
 
 import (
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/storage/access"
+	storageApply "github.com/supabase/cli/internal/storage/apply"
+	"github.com/supabase/cli/internal/storage/cache"
 	"github.com/supabase/cli/internal/storage/client"
 	"github.com/supabase/cli/internal/storage/cp"
+	"github.com/supabase/cli/internal/storage/crypt"
+	storageDiff "github.com/supabase/cli/internal/storage/diff"
+	"github.com/supabase/cli/internal/storage/events"
+	"github.com/supabase/cli/internal/storage/find"
+	"github.com/supabase/cli/internal/storage/importer"
+	"github.com/supabase/cli/internal/storage/lifecycle/apply"
+	lifecycleList "github.com/supabase/cli/internal/storage/lifecycle/list"
+	"github.com/supabase/cli/internal/storage/lifecycle/set"
+	"github.com/supabase/cli/internal/storage/lifecycle/unset"
 	"github.com/supabase/cli/internal/storage/ls"
 	"github.com/supabase/cli/internal/storage/mv"
+	"github.com/supabase/cli/internal/storage/presign"
+	"github.com/supabase/cli/internal/storage/render"
 	"github.com/supabase/cli/internal/storage/rm"
+	"github.com/supabase/cli/internal/storage/serve"
+	"github.com/supabase/cli/internal/storage/stat"
+	"github.com/supabase/cli/internal/storage/tag"
+	"github.com/supabase/cli/internal/storage/usage"
+	"github.com/supabase/cli/internal/storage/verify"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
 	"github.com/supabase/cli/pkg/storage"
 )
 
@@ -18,39 +47,113 @@ var (
 		Short:   "Manage Supabase Storage objects",
 	}
 
-	recursive bool
+	recursive   bool
+	listBuckets bool
+	summarize   bool
+	tagFilter   []string
+	longListing bool
+	listIDs     bool
+	humanSizes  bool
+	rawBytes    bool
 
 	lsCmd = &cobra.Command{
-		Use:     "ls [path]",
-		Example: "ls ss:///bucket/docs",
-		Short:   "List objects by path prefix",
-		Args:    cobra.MaximumNArgs(1),
+		Use: "ls [path]",
+		Example: `ls ss:///bucket/docs
+ls --buckets
+`,
+		Short: "List objects by path prefix",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			objectPath := client.STORAGE_SCHEME + ":///"
 			if len(args) > 0 {
 				objectPath = args[0]
 			}
-			return ls.Run(cmd.Context(), objectPath, recursive, afero.NewOsFs())
+			if listBuckets {
+				return ls.RunBuckets(cmd.Context(), objectPath)
+			}
+			filter, err := tag.ParseTags(tagFilter)
+			if err != nil {
+				return err
+			}
+			format := ls.Format{Long: longListing, IDs: listIDs}
+			if humanSizes {
+				format.Sizes = ls.SizeHuman
+			} else if rawBytes {
+				format.Sizes = ls.SizeBytes
+			}
+			return ls.Run(cmd.Context(), objectPath, recursive, summarize, format, filter, afero.NewOsFs())
 		},
 	}
 
-	options storage.FileOptions
-	maxJobs uint
+	options     storage.FileOptions
+	maxJobs     uint
+	filter      cp.TransferFilter
+	encrypt     string
+	decrypt     string
+	chunkSize   string
+	headersFile string
 
 	cpCmd = &cobra.Command{
 		Use: "cp <src> <dst>",
 		Example: `cp readme.md ss:///bucket/readme.md
 cp -r docs ss:///bucket/docs
 cp -r ss:///bucket/docs .
+cp -r dist ss:///bucket/site --headers-file headers.json
+cp secrets.json ss:///bucket/secrets.json --encrypt age:age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p
+cp ss:///bucket/secrets.json . --decrypt age:key.txt
 `,
 		Short: "Copy objects from src to dst path",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// Unset headers are filled in by --headers-file before falling back to defaults
 			opts := func(fo *storage.FileOptions) {
-				fo.CacheControl = options.CacheControl
+				if cmd.Flags().Changed("cache-control") {
+					fo.CacheControl = options.CacheControl
+				}
 				fo.ContentType = options.ContentType
 			}
-			return cp.Run(cmd.Context(), args[0], args[1], recursive, maxJobs, afero.NewOsFs(), opts)
+			fsys := afero.NewOsFs()
+			if len(headersFile) > 0 {
+				var err error
+				if cp.Headers, err = cp.LoadHeaderRules(headersFile, fsys); err != nil {
+					return err
+				}
+			}
+			var codec crypt.Codec
+			var err error
+			if len(encrypt) > 0 {
+				codec, err = crypt.ParseEncrypt(encrypt, fsys)
+			} else if len(decrypt) > 0 {
+				codec, err = crypt.ParseDecrypt(decrypt, fsys)
+			}
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("chunk-size") {
+				if cp.ChunkSize, err = units.RAMInBytes(chunkSize); err != nil {
+					return errors.Errorf("invalid chunk size: %w", err)
+				}
+			}
+			return cp.Run(cmd.Context(), args[0], args[1], recursive, maxJobs, filter, codec, fsys, opts)
+		},
+	}
+
+	importManifest string
+	importJobs     uint
+
+	importCmd = &cobra.Command{
+		Use:   "import <src> <dst>",
+		Short: "Import objects from S3 compatible storage",
+		Long: `Import objects from AWS S3 or Google Cloud Storage into Supabase Storage.
+
+Credentials are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, or the profile in ~/.aws/credentials.
+Google Cloud Storage requires HMAC keys in the same variables. Set AWS_ENDPOINT_URL for other S3 compatible providers.`,
+		Example: `import s3://other-bucket/photos ss:///bucket/photos
+import gs://other-bucket ss:///bucket --jobs 8
+`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return importer.Run(cmd.Context(), args[0], args[1], importManifest, importJobs, afero.NewOsFs())
 		},
 	}
 
@@ -72,7 +175,340 @@ rm ss:///bucket/docs/example.md ss:///bucket/readme.md
 `,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return rm.Run(cmd.Context(), args, recursive, afero.NewOsFs())
+			filter, err := tag.ParseTags(tagFilter)
+			if err != nil {
+				return err
+			}
+			return rm.Run(cmd.Context(), args, recursive, filter, afero.NewOsFs())
+		},
+	}
+
+	statExpiresIn uint
+
+	statCmd = &cobra.Command{
+		Use:   "stat <path>",
+		Short: "Show details of a single object",
+		Long:  "Show the size, etag, timestamps, content type, cache control, and custom metadata of an object, together with its public or signed URL.",
+		Example: `stat ss:///bucket/docs/readme.md
+stat ss:///private/report.pdf --expires-in 600 --output json
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return stat.Run(cmd.Context(), args[0], statExpiresIn)
+		},
+	}
+
+	accessJWT    string
+	accessRole   string
+	accessClaims string
+
+	accessCheckCmd = &cobra.Command{
+		Use:   "access-check <path>",
+		Short: "Simulate storage policies for an object",
+		Long:  "Simulate select, insert, update, and delete of an object as the role and claims of a JWT, reporting whether each action is allowed and which row level security policies passed. All changes are rolled back.",
+		Example: `access-check ss:///avatars/user-1/profile.png --jwt "$USER_JWT" --local
+access-check ss:///private/report.pdf --role authenticated --claims '{"sub":"5d2c..."}'
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			identity, err := access.NewIdentity(accessJWT, accessRole, accessClaims)
+			if err != nil {
+				return err
+			}
+			return access.Run(cmd.Context(), args[0], identity, flags.DbConfig)
+		},
+	}
+
+	presignUpsert bool
+
+	presignUploadCmd = &cobra.Command{
+		Use:   "presign-upload [path]...",
+		Short: "Create signed URLs for uploading objects without credentials",
+		Long:  "Create a signed upload URL and token for each object path, read from stdin when no paths are given. Anyone holding a signed URL can upload the object once within 2 hours.",
+		Example: `presign-upload ss:///bucket/builds/app.zip
+find dist -type f | sed 's|^dist|ss:///bucket/site|' | presign-upload --upsert --output json
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return presign.Run(cmd.Context(), args, presignUpsert)
+		},
+	}
+
+	usageLimit     string
+	usageThreshold uint
+
+	usageCmd = &cobra.Command{
+		Use:   "usage",
+		Short: "Show storage size and bandwidth per bucket",
+		Long:  "Show the total size and number of objects in each bucket, and the storage bandwidth of the last 24 hours for linked projects. Use --threshold to exit with an error when usage nears the --limit.",
+		Example: `usage --output json
+usage --limit 100GB --threshold 80
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var limit int64
+			if len(usageLimit) > 0 {
+				var err error
+				if limit, err = units.RAMInBytes(usageLimit); err != nil {
+					return errors.Errorf("invalid limit: %w", err)
+				}
+			}
+			return usage.Run(cmd.Context(), limit, usageThreshold, flags.DbConfig)
+		},
+	}
+
+	tagRemove []string
+
+	tagCmd = &cobra.Command{
+		Use:   "tag <path> [key=value] ...",
+		Short: "Set or remove tags on objects",
+		Long:  "Set or remove key value tags on objects. Tags are stored in the user metadata of each object and can be used to filter ls and rm.",
+		Example: `tag ss:///bucket/docs/readme.md env=staging
+tag -r ss:///bucket/docs env=staging team=web
+tag -r ss:///bucket/docs --remove env
+`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tags, err := tag.ParseTags(args[1:])
+			if err != nil {
+				return err
+			}
+			return tag.Run(cmd.Context(), args[0], tags, tagRemove, recursive, afero.NewOsFs())
+		},
+	}
+
+	findRegex     string
+	findOlderThan string
+	findNewerThan string
+	findAction    = utils.EnumFlag{
+		Allowed: []string{find.ActionPrint, find.ActionRemove},
+		Value:   find.ActionPrint,
+	}
+
+	findCmd = &cobra.Command{
+		Use:   "find <path>",
+		Short: "Find objects matching name and age filters",
+		Example: `find ss:///bucket/ --regex '\.bak$'
+find ss:///bucket/tmp --older-than 30d --exec rm
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var predicate find.Predicate
+			if len(findRegex) > 0 {
+				pattern, err := regexp.Compile(findRegex)
+				if err != nil {
+					return errors.Errorf("failed to compile regex: %w", err)
+				}
+				predicate.Pattern = pattern
+			}
+			if len(findOlderThan) > 0 {
+				age, err := find.ParseAge(findOlderThan)
+				if err != nil {
+					return err
+				}
+				predicate.OlderThan = age
+			}
+			if len(findNewerThan) > 0 {
+				age, err := find.ParseAge(findNewerThan)
+				if err != nil {
+					return err
+				}
+				predicate.NewerThan = age
+			}
+			return find.Run(cmd.Context(), args[0], predicate, findAction.Value, afero.NewOsFs())
+		},
+	}
+
+	renderOutput  string
+	renderOptions storage.TransformOptions
+	renderResize  = utils.EnumFlag{
+		Allowed: []string{"cover", "contain", "fill"},
+	}
+	renderFormat = utils.EnumFlag{
+		Allowed: []string{"origin"},
+	}
+
+	renderCmd = &cobra.Command{
+		Use:   "render <path>",
+		Short: "Download an image through the image transformation endpoint",
+		Long:  "Download an image through the image transformation endpoint to preview transformation parameters.",
+		Example: `render ss:///bucket/photo.jpg --width 400 --quality 70 -o out.jpg
+render ss:///bucket/photo.jpg --width 200 --height 200 --resize contain
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			renderOptions.Resize = renderResize.Value
+			renderOptions.Format = renderFormat.Value
+			return render.Run(cmd.Context(), args[0], renderOutput, renderOptions, afero.NewOsFs())
+		},
+	}
+
+	servePort      uint
+	serveTransform bool
+
+	serveCmd = &cobra.Command{
+		Use:   "serve <path>",
+		Short: "Serve objects under a bucket prefix over local HTTP",
+		Long:  "Serve objects under a bucket prefix over local HTTP with the same content types and cache headers as the storage CDN. Directory paths serve their index.html object.",
+		Example: `serve ss:///public/site
+serve ss:///images --port 8080 --transform
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return serve.Run(ctx, args[0], servePort, serveTransform)
+		},
+	}
+
+	againstProject string
+	useCache       bool
+
+	storageDiffCmd = &cobra.Command{
+		Use:   "diff <path> [target path]",
+		Short: "Compare objects between two storage paths",
+		Long:  "Compare objects between two storage paths, optionally across projects. Reports objects only in the source, only in the target, and objects that differ by size or etag.",
+		Example: `diff ss:///bucket/images --against-project abcdefghijklmnopqrst
+diff ss:///bucket/images ss:///backup/images
+`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var dst string
+			if len(args) > 1 {
+				dst = args[1]
+			}
+			return storageDiff.Run(cmd.Context(), args[0], dst, againstProject, useCache, afero.NewOsFs())
+		},
+	}
+
+	verifyClean bool
+	verifyJobs  uint
+
+	storageVerifyCmd = &cobra.Command{
+		Use:   "verify [path]",
+		Short: "Check storage objects rows against stored contents",
+		Long:  "Cross-check rows in storage.objects against the contents in the storage backend. Reports rows whose contents are missing and, for local storage, contents without a row.",
+		Example: `verify ss:///bucket/images
+verify --clean
+`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			objectPath := client.STORAGE_SCHEME + ":///"
+			if len(args) > 0 {
+				objectPath = args[0]
+			}
+			return verify.Run(cmd.Context(), objectPath, verifyClean, verifyJobs, afero.NewOsFs())
+		},
+	}
+
+	storageEventsCmd = &cobra.Command{
+		Use:   "events",
+		Short: "Debug events of storage objects",
+	}
+
+	storageEventTypes []string
+
+	storageEventsListenCmd = &cobra.Command{
+		Use:   "listen [path]",
+		Short: "Print object events as they happen",
+		Long: `Print created, updated and deleted events of storage objects through Realtime postgres changes.
+
+Requires storage.objects to be added to the Realtime publication:
+  alter publication supabase_realtime add table storage.objects;
+
+Deleted objects are only matched by bucket and prefix if the table has full replica identity:
+  alter table storage.objects replica identity full;`,
+		Example: `events listen ss:///bucket/uploads/
+events listen --event created,deleted --output json
+`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			objectPath := client.STORAGE_SCHEME + ":///"
+			if len(args) > 0 {
+				objectPath = args[0]
+			}
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return events.Run(ctx, objectPath, storageEventTypes)
+		},
+	}
+
+	applyPrune bool
+
+	storageApplyCmd = &cobra.Command{
+		Use:   "apply",
+		Short: "Reconcile storage buckets with " + utils.ConfigPath,
+		Long:  "Create buckets declared in " + utils.ConfigPath + " and update their properties to match. Buckets that are not declared are only deleted with --prune.",
+		Example: `apply --dry-run
+apply --local
+apply --prune
+`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return storageApply.Run(cmd.Context(), flags.ProjectRef, dryRun, applyPrune, afero.NewOsFs())
+		},
+	}
+
+	storageCacheCmd = &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local cache of object listings",
+		Long:  "Object listings used by storage diff are cached under supabase/.temp for up to 10 minutes. Any cp, mv, rm or import through the CLI invalidates the cache of that project.",
+		// The cache is local so login and project ref are not required
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return utils.ChangeWorkDir(afero.NewOsFs())
+		},
+	}
+
+	storageCacheClearCmd = &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached object listings",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cache.Run(afero.NewOsFs())
+		},
+	}
+
+	lifecycleCmd = &cobra.Command{
+		Use:   "lifecycle",
+		Short: "Manage expiry rules of storage objects",
+	}
+
+	lifecyclePrefix      string
+	lifecycleExpireAfter string
+
+	lifecycleSetCmd = &cobra.Command{
+		Use:     "set <path>",
+		Short:   "Expire objects in a bucket after some age",
+		Example: "lifecycle set ss:///bucket --expire-after 90d --prefix tmp/",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return set.Run(args[0], lifecyclePrefix, lifecycleExpireAfter, afero.NewOsFs())
+		},
+	}
+
+	lifecycleUnsetCmd = &cobra.Command{
+		Use:   "unset <path>",
+		Short: "Remove the expiry rule of a bucket prefix",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return unset.Run(args[0], lifecyclePrefix, afero.NewOsFs())
+		},
+	}
+
+	lifecycleListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List all expiry rules",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return lifecycleList.Run(afero.NewOsFs())
+		},
+	}
+
+	lifecycleDryRun bool
+
+	lifecycleApplyCmd = &cobra.Command{
+		Use:   "apply",
+		Short: "Delete objects that have expired",
+		Long:  "Delete objects that have expired according to lifecycle rules. Run this command on a schedule to enforce expiry.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return apply.Run(cmd.Context(), lifecycleDryRun, afero.NewOsFs())
 		},
 	}
 )
@@ -81,8 +517,34 @@ func init() {
 	storageFlags := storageCmd.PersistentFlags()
 	storageFlags.Bool("linked", true, "Connects to Storage API of the linked project.")
 	storageFlags.Bool("local", false, "Connects to Storage API of the local database.")
+	storageFlags.String("project-url", "", "Reads public buckets from this project URL without logging in.")
+	storageFlags.String("anon-key", "", "Anon key to authorize reads from public buckets.")
+	storageFlags.Float64Var(&client.MaxRPS, "max-rps", 0, "Maximum number of requests per second sent to Storage API.")
+	storageFlags.UintVar(&ls.MaxConcurrency, "list-jobs", ls.MaxConcurrency, "Maximum number of directories listed in parallel when walking recursively.")
+	storageFlags.String("inject-faults", "", "Randomly fail Storage API calls for testing, ie. rate=0.1,codes=429,500.")
+	cobra.CheckErr(storageFlags.MarkHidden("inject-faults"))
+	cobra.CheckErr(viper.BindPFlag("INJECT_FAULTS", storageFlags.Lookup("inject-faults")))
 	storageCmd.MarkFlagsMutuallyExclusive("linked", "local")
-	lsCmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Recursively list a directory.")
+	storageCmd.MarkFlagsMutuallyExclusive("project-url", "local")
+	cobra.CheckErr(viper.BindPFlag("PROJECT_URL", storageFlags.Lookup("project-url")))
+	cobra.CheckErr(viper.BindPFlag("ANON_KEY", storageFlags.Lookup("anon-key")))
+	lsFlags := lsCmd.Flags()
+	lsFlags.BoolVarP(&recursive, "recursive", "r", false, "Recursively list a directory.")
+	lsFlags.BoolVar(&listBuckets, "buckets", false, "List buckets with their access and upload limits.")
+	lsFlags.BoolVar(&summarize, "summarize", false, "Print the total number and size of listed objects.")
+	lsCmd.MarkFlagsMutuallyExclusive("recursive", "buckets")
+	lsFlags.StringArrayVar(&tagFilter, "tag", []string{}, "Only list objects with this tag, ie. env=staging.")
+	lsCmd.MarkFlagsMutuallyExclusive("summarize", "buckets")
+	lsCmd.MarkFlagsMutuallyExclusive("tag", "buckets")
+	// Reserve -h for human readable sizes, similar to ls
+	lsFlags.Bool("help", false, "help for ls")
+	lsFlags.BoolVarP(&longListing, "long", "l", false, "Print the size and last modified time of each object.")
+	lsFlags.BoolVarP(&humanSizes, "human-readable", "h", false, "Print sizes in binary units, ie. KiB, MiB, GiB.")
+	lsFlags.BoolVar(&rawBytes, "bytes", false, "Print sizes as raw numbers of bytes for scripting.")
+	lsCmd.MarkFlagsMutuallyExclusive("human-readable", "bytes")
+	lsFlags.BoolVar(&listIDs, "ids", false, "Print the UUID and etag of each object.")
+	lsCmd.MarkFlagsMutuallyExclusive("long", "buckets")
+	lsCmd.MarkFlagsMutuallyExclusive("ids", "buckets")
 	storageCmd.AddCommand(lsCmd)
 	cpFlags := cpCmd.Flags()
 	cpFlags.BoolVarP(&recursive, "recursive", "r", false, "Recursively copy a directory.")
@@ -90,10 +552,91 @@ func init() {
 	cpFlags.StringVar(&options.ContentType, "content-type", "", "Custom Content-Type header for HTTP upload.")
 	cpFlags.Lookup("content-type").DefValue = "auto-detect"
 	cpFlags.UintVarP(&maxJobs, "jobs", "j", 1, "Maximum number of parallel jobs.")
+	cpFlags.BoolVar(&filter.IfNewer, "if-newer", false, "Only copy files that are newer than the destination.")
+	cpFlags.BoolVar(&filter.IfSizeDiffers, "if-size-differs", false, "Only copy files whose size differs from the destination.")
+	cpFlags.StringVar(&encrypt, "encrypt", "", "Encrypt files before upload with age:<recipient> or aes:<keyfile>.")
+	cpFlags.StringVar(&decrypt, "decrypt", "", "Decrypt files after download with age:<identity file> or aes:<keyfile>.")
+	cpFlags.StringVar(&headersFile, "headers-file", "", "Path to a JSON file of content types by extension and cache control by path pattern.")
+	cpFlags.StringVar(&chunkSize, "chunk-size", "", "Download objects larger than this size in resumable chunks, ie. 8MiB.")
+	cpCmd.MarkFlagsMutuallyExclusive("encrypt", "decrypt")
+	// Ciphertext size never matches the plaintext
+	cpCmd.MarkFlagsMutuallyExclusive("encrypt", "if-size-differs")
+	cpCmd.MarkFlagsMutuallyExclusive("decrypt", "if-size-differs")
 	storageCmd.AddCommand(cpCmd)
-	rmCmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Recursively remove a directory.")
+	importFlags := importCmd.Flags()
+	importFlags.StringVar(&importManifest, "manifest", "storage-import.jsonl", "Path to the manifest of transferred keys, used to resume interrupted imports.")
+	importFlags.UintVarP(&importJobs, "jobs", "j", 4, "Maximum number of parallel jobs.")
+	storageCmd.AddCommand(importCmd)
+	rmFlags := rmCmd.Flags()
+	rmFlags.BoolVarP(&recursive, "recursive", "r", false, "Recursively remove a directory.")
+	rmFlags.StringArrayVar(&tagFilter, "tag", []string{}, "Only remove objects with this tag, ie. env=staging.")
 	storageCmd.AddCommand(rmCmd)
+	statCmd.Flags().UintVar(&statExpiresIn, "expires-in", 3600, "Seconds until the signed URL of a private object expires. Set to 0 to skip signing.")
+	storageCmd.AddCommand(statCmd)
+	accessFlags := accessCheckCmd.Flags()
+	accessFlags.StringVar(&accessJWT, "jwt", "", "JWT whose role and claims are used for the check.")
+	accessFlags.StringVar(&accessRole, "role", "", "Database role to check, defaults to the role claim or anon.")
+	accessFlags.StringVar(&accessClaims, "claims", "", "JSON claims to check with when no JWT is given.")
+	accessCheckCmd.MarkFlagsMutuallyExclusive("jwt", "claims")
+	storageCmd.AddCommand(accessCheckCmd)
+	presignUploadCmd.Flags().BoolVar(&presignUpsert, "upsert", false, "Allow the signed uploads to overwrite existing objects.")
+	storageCmd.AddCommand(presignUploadCmd)
+	usageFlags := usageCmd.Flags()
+	usageFlags.StringVar(&usageLimit, "limit", "", "Storage size limit of your plan, ie. 100GB.")
+	usageFlags.UintVar(&usageThreshold, "threshold", 0, "Exit with non-zero status when usage reaches this percentage of the limit.")
+	usageCmd.MarkFlagsRequiredTogether("limit", "threshold")
+	storageCmd.AddCommand(usageCmd)
+	tagFlags := tagCmd.Flags()
+	tagFlags.BoolVarP(&recursive, "recursive", "r", false, "Recursively tag all objects in a directory.")
+	tagFlags.StringSliceVar(&tagRemove, "remove", []string{}, "Tag keys to remove from objects.")
+	storageCmd.AddCommand(tagCmd)
 	mvCmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Recursively move a directory.")
 	storageCmd.AddCommand(mvCmd)
+	findFlags := findCmd.Flags()
+	findFlags.StringVar(&findRegex, "regex", "", "Only match object paths against this regular expression.")
+	findFlags.StringVar(&findOlderThan, "older-than", "", "Only match objects last modified before this age, ie. 30d.")
+	findFlags.StringVar(&findNewerThan, "newer-than", "", "Only match objects last modified within this age, ie. 12h.")
+	findFlags.Var(&findAction, "exec", "Action to apply to each matched object.")
+	storageCmd.AddCommand(findCmd)
+	renderFlags := renderCmd.Flags()
+	renderFlags.IntVar(&renderOptions.Width, "width", 0, "Width of the transformed image in pixels.")
+	renderFlags.IntVar(&renderOptions.Height, "height", 0, "Height of the transformed image in pixels.")
+	renderFlags.IntVar(&renderOptions.Quality, "quality", 0, "Quality of the transformed image, from 20 to 100.")
+	renderFlags.Var(&renderResize, "resize", "Resize mode when both width and height are set.")
+	renderFlags.Var(&renderFormat, "format", "Set to origin to keep the original image format.")
+	renderFlags.StringVarP(&renderOutput, "output", "o", "", "Path to write the transformed image to.")
+	renderFlags.Lookup("output").DefValue = "object name"
+	storageCmd.AddCommand(renderCmd)
+	serveFlags := serveCmd.Flags()
+	serveFlags.UintVar(&servePort, "port", 8000, "Local port to serve objects on.")
+	serveFlags.BoolVar(&serveTransform, "transform", false, "Forward width, height, quality, resize, and format query parameters to the image transformation endpoint.")
+	storageCmd.AddCommand(serveCmd)
+	storageDiffCmd.Flags().StringVar(&againstProject, "against-project", "", "Project ref to compare the target path against.")
+	storageDiffCmd.Flags().BoolVar(&useCache, "cache", false, "Reuse object listings cached by previous runs, which may miss writes by other clients.")
+	storageCmd.AddCommand(storageDiffCmd)
+	verifyFlags := storageVerifyCmd.Flags()
+	verifyFlags.BoolVar(&verifyClean, "clean", false, "Remove orphaned rows and files after confirmation.")
+	verifyFlags.UintVarP(&verifyJobs, "jobs", "j", 4, "Maximum number of parallel jobs.")
+	storageCmd.AddCommand(storageVerifyCmd)
+	storageEventsListenCmd.Flags().StringSliceVar(&storageEventTypes, "event", []string{}, "Only print events of these types: "+strings.Join(events.Types, ", ")+".")
+	storageEventsCmd.AddCommand(storageEventsListenCmd)
+	storageCmd.AddCommand(storageEventsCmd)
+	applyFlags := storageApplyCmd.Flags()
+	applyFlags.BoolVar(&dryRun, "dry-run", false, "Print the changes to buckets without applying them.")
+	applyFlags.BoolVar(&applyPrune, "prune", false, "Delete buckets that are not declared in "+utils.ConfigPath+".")
+	storageCmd.AddCommand(storageApplyCmd)
+	storageCacheCmd.AddCommand(storageCacheClearCmd)
+	storageCmd.AddCommand(storageCacheCmd)
+	setFlags := lifecycleSetCmd.Flags()
+	setFlags.StringVar(&lifecyclePrefix, "prefix", "", "Only apply the rule to objects under this prefix.")
+	setFlags.StringVar(&lifecycleExpireAfter, "expire-after", "", "Delete objects last modified before this age, ie. 90d.")
+	cobra.CheckErr(lifecycleSetCmd.MarkFlagRequired("expire-after"))
+	lifecycleCmd.AddCommand(lifecycleSetCmd)
+	lifecycleUnsetCmd.Flags().StringVar(&lifecyclePrefix, "prefix", "", "Prefix of the rule to remove.")
+	lifecycleCmd.AddCommand(lifecycleUnsetCmd)
+	lifecycleCmd.AddCommand(lifecycleListCmd)
+	lifecycleApplyCmd.Flags().BoolVar(&lifecycleDryRun, "dry-run", false, "Print expired objects without deleting them.")
+	lifecycleCmd.AddCommand(lifecycleApplyCmd)
+	storageCmd.AddCommand(lifecycleCmd)
 	rootCmd.AddCommand(storageCmd)
 }
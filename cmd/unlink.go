@@ -14,6 +14,9 @@ var (
 		GroupID: groupLocalDev,
 		Use:     "unlink",
 		Short:   "Unlink a Supabase project",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return setLinkName(cmd)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
 			return unlink.Run(ctx, afero.NewOsFs())
@@ -22,5 +25,6 @@ var (
 )
 
 func init() {
+	unlinkCmd.Flags().StringVar(&linkName, "name", "", "Name of the linked environment to unlink.")
 	rootCmd.AddCommand(unlinkCmd)
 }
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/upgrade"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	upgradeChannel = utils.EnumFlag{
+		Allowed: []string{upgrade.ChannelStable, upgrade.ChannelBeta},
+		Value:   upgrade.ChannelStable,
+	}
+	upgradeCheck bool
+
+	upgradeCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "upgrade",
+		Short:   "Upgrade Supabase CLI to the latest release",
+		Long:    "Download the latest release for your platform, verify its checksum and signature, and replace the current binary in place.",
+		Example: `  supabase upgrade --check
+  supabase upgrade --channel beta`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return upgrade.Run(cmd.Context(), upgradeChannel.Value, upgradeCheck, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	upgradeFlags := upgradeCmd.Flags()
+	upgradeFlags.Var(&upgradeChannel, "channel", "Release channel to upgrade from.")
+	upgradeFlags.BoolVar(&upgradeCheck, "check", false, "Only print whether a newer version exists.")
+	rootCmd.AddCommand(upgradeCmd)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/internal/vault/secrets"
+	"github.com/supabase/cli/internal/vault/secrets/create"
+	"github.com/supabase/cli/internal/vault/secrets/delete"
+	"github.com/supabase/cli/internal/vault/secrets/list"
+	"github.com/supabase/cli/internal/vault/secrets/update"
+)
+
+var (
+	vaultCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "vault",
+		Short:   "Manage secrets stored in Supabase Vault",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			cmd.SetContext(ctx)
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+	}
+
+	vaultSecretsCmd = &cobra.Command{
+		Use:   "secrets",
+		Short: "Manage Vault secrets",
+	}
+
+	vaultSecretsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List Vault secrets without their decrypted values",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context(), flags.DbConfig)
+		},
+	}
+
+	secretValue       string
+	secretFile        string
+	secretDescription string
+
+	vaultSecretsCreateCmd = &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a Vault secret",
+		Long:  "Create a Vault secret. The value is read from --value, --file, or prompted from stdin.",
+		Example: `  supabase vault secrets create stripe_key --file ./stripe.key
+  echo -n "$STRIPE_KEY" | supabase vault secrets create stripe_key`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := secrets.ReadValue(secretValue, secretFile, afero.NewOsFs())
+			if err != nil {
+				return err
+			}
+			return create.Run(cmd.Context(), args[0], value, secretDescription, flags.DbConfig)
+		},
+	}
+
+	vaultSecretsUpdateCmd = &cobra.Command{
+		Use:   "update <name>",
+		Short: "Update the value of a Vault secret",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := secrets.ReadValue(secretValue, secretFile, afero.NewOsFs())
+			if err != nil {
+				return err
+			}
+			var description *string
+			if cmd.Flags().Changed("description") {
+				description = &secretDescription
+			}
+			return update.Run(cmd.Context(), args[0], value, description, flags.DbConfig)
+		},
+	}
+
+	vaultSecretsDeleteCmd = &cobra.Command{
+		Use:   "delete <name>...",
+		Short: "Delete Vault secrets",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return delete.Run(cmd.Context(), args, flags.DbConfig)
+		},
+	}
+)
+
+func init() {
+	vaultFlags := vaultCmd.PersistentFlags()
+	vaultFlags.String("db-url", "", "Manages secrets of the database specified by the connection string (must be percent-encoded).")
+	vaultFlags.Bool("linked", false, "Manages secrets of the linked project.")
+	vaultFlags.Bool("local", true, "Manages secrets of the local database.")
+	vaultCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	vaultFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", vaultFlags.Lookup("password")))
+	vaultSecretsCmd.AddCommand(vaultSecretsListCmd)
+	for _, c := range []*cobra.Command{vaultSecretsCreateCmd, vaultSecretsUpdateCmd} {
+		secretFlags := c.Flags()
+		secretFlags.StringVar(&secretValue, "value", "", "Secret value. Prefer --file or stdin to keep it out of shell history.")
+		secretFlags.StringVar(&secretFile, "file", "", "Path to a file containing the secret value.")
+		secretFlags.StringVar(&secretDescription, "description", "", "Description of the secret.")
+		c.MarkFlagsMutuallyExclusive("value", "file")
+		vaultSecretsCmd.AddCommand(c)
+	}
+	vaultSecretsCmd.AddCommand(vaultSecretsDeleteCmd)
+	vaultCmd.AddCommand(vaultSecretsCmd)
+	rootCmd.AddCommand(vaultCmd)
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/status"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/internal/utils/tenant"
+	"github.com/supabase/cli/pkg/auth"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+// Connects to the local auth service when project ref is empty, otherwise the hosted project.
+// A self-hosted environment selected with --env takes precedence over both.
+func NewAuthAdminAPI(ctx context.Context, projectRef string) (auth.AuthAdminAPI, error) {
+	server := utils.Config.Api.ExternalUrl
+	client := status.NewKongClient()
+	serviceRoleKey := utils.Config.Auth.ServiceRoleKey
+	if flags.IsSelfHosted() {
+		server = flags.Environment.GetAuthUrl()
+		client = http.DefaultClient
+		serviceRoleKey = flags.Environment.ServiceRoleKey
+		if len(serviceRoleKey) == 0 {
+			return auth.AuthAdminAPI{}, errors.New("Managing users requires service_role_key of the self-hosted environment.")
+		}
+	} else if len(projectRef) > 0 {
+		server = "https://" + utils.GetSupabaseHost(projectRef)
+		client = http.DefaultClient
+		// Special case for calling auth API without personal access token
+		if !viper.IsSet("AUTH_SERVICE_ROLE_KEY") {
+			keys, err := tenant.GetApiKeys(ctx, projectRef)
+			if err != nil {
+				return auth.AuthAdminAPI{}, err
+			}
+			serviceRoleKey = keys.ServiceRole
+		}
+	}
+	header := func(req *http.Request) {
+		req.Header.Add("apikey", serviceRoleKey)
+	}
+	return auth.AuthAdminAPI{Fetcher: fetcher.NewFetcher(
+		server,
+		fetcher.WithHTTPClient(client),
+		fetcher.WithBearerToken(serviceRoleKey),
+		fetcher.WithRequestEditor(header),
+		fetcher.WithUserAgent("SupabaseCLI/"+utils.Version),
+		fetcher.WithExpectedStatus(http.StatusOK),
+	)}, nil
+}
@@ -0,0 +1,78 @@
+package decode
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/golang-jwt/jwt/v5"
+	authJwt "github.com/supabase/cli/internal/auth/jwt"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type DecodedToken struct {
+	Header map[string]any `json:"header"`
+	Claims jwt.MapClaims  `json:"claims"`
+	// Only set when signature is verified
+	Valid *bool `json:"valid,omitempty"`
+}
+
+func Run(ctx context.Context, token, projectRef string, verify bool, secret string) error {
+	if verify && len(secret) == 0 {
+		var err error
+		if secret, err = authJwt.GetSecret(ctx, projectRef); err != nil {
+			return err
+		}
+	}
+	decoded, err := DecodeToken(token, verify, secret)
+	if err != nil {
+		return err
+	}
+	if exp, err := decoded.Claims.GetExpirationTime(); err == nil && exp != nil && exp.Before(time.Now()) {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "token expired at", exp.UTC().Format(time.RFC3339))
+	}
+	if decoded.Valid != nil {
+		if *decoded.Valid {
+			fmt.Fprintln(os.Stderr, "Signature verified.")
+		} else {
+			fmt.Fprintln(os.Stderr, utils.Red("Signature is invalid."))
+		}
+	}
+	format := utils.OutputFormat.Value
+	if format == utils.OutputPretty {
+		format = utils.OutputJson
+	}
+	if err := utils.EncodeOutput(format, os.Stdout, decoded); err != nil {
+		return err
+	}
+	if decoded.Valid != nil && !*decoded.Valid {
+		return errors.New("failed to verify jwt signature")
+	}
+	return nil
+}
+
+func DecodeToken(token string, verify bool, secret string) (DecodedToken, error) {
+	var claims jwt.MapClaims
+	parser := jwt.NewParser(jwt.WithoutClaimsValidation())
+	parsed, _, err := parser.ParseUnverified(token, &claims)
+	if err != nil {
+		return DecodedToken{}, errors.Errorf("failed to decode jwt: %w", err)
+	}
+	result := DecodedToken{Header: parsed.Header, Claims: claims}
+	if verify {
+		_, err := parser.Parse(token, func(t *jwt.Token) (any, error) {
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, errors.Errorf("unexpected signing method: %v", t.Header["alg"])
+			}
+			return []byte(secret), nil
+		})
+		valid := err == nil
+		result.Valid = &valid
+		if err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+		}
+	}
+	return result, nil
+}
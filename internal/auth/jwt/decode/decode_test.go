@@ -0,0 +1,44 @@
+package decode
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "super-secret-jwt-token-with-at-least-32-characters-long"
+
+func TestDecodeToken(t *testing.T) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"role": "anon",
+		"exp":  1,
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+
+	t.Run("decodes expired token without verifying", func(t *testing.T) {
+		decoded, err := DecodeToken(token, false, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "HS256", decoded.Header["alg"])
+		assert.Equal(t, "anon", decoded.Claims["role"])
+		assert.Nil(t, decoded.Valid)
+	})
+
+	t.Run("verifies signature", func(t *testing.T) {
+		decoded, err := DecodeToken(token, true, testSecret)
+		assert.NoError(t, err)
+		assert.True(t, *decoded.Valid)
+	})
+
+	t.Run("rejects wrong secret", func(t *testing.T) {
+		decoded, err := DecodeToken(token, true, "wrong-secret")
+		assert.NoError(t, err)
+		assert.False(t, *decoded.Valid)
+	})
+
+	t.Run("throws error on malformed token", func(t *testing.T) {
+		_, err := DecodeToken("not-a-jwt", false, "")
+		assert.ErrorContains(t, err, "failed to decode jwt:")
+	})
+}
@@ -0,0 +1,24 @@
+package jwt
+
+import (
+	"context"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Returns the local JWT secret when project ref is empty, otherwise fetches it from PostgREST config.
+func GetSecret(ctx context.Context, projectRef string) (string, error) {
+	if len(projectRef) == 0 {
+		return utils.Config.Auth.JwtSecret, nil
+	}
+	resp, err := utils.GetSupabase().V1GetPostgrestServiceConfigWithResponse(ctx, projectRef)
+	if err != nil {
+		return "", errors.Errorf("failed to get jwt secret: %w", err)
+	} else if resp.JSON200 == nil {
+		return "", errors.New("Unexpected error retrieving jwt secret: " + string(resp.Body))
+	} else if resp.JSON200.JwtSecret == nil {
+		return "", errors.New("JWT secret is not available for project: " + projectRef)
+	}
+	return *resp.JSON200.JwtSecret, nil
+}
@@ -0,0 +1,72 @@
+package sign
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/golang-jwt/jwt/v5"
+	authJwt "github.com/supabase/cli/internal/auth/jwt"
+)
+
+type SignParams struct {
+	Role    string
+	Subject string
+	// Extra claims as a JSON object, ie. {"email": "user@example.com"}
+	Claims    string
+	ExpiresIn time.Duration
+	Secret    string
+}
+
+func Run(ctx context.Context, projectRef string, params SignParams) error {
+	if len(params.Secret) == 0 {
+		secret, err := authJwt.GetSecret(ctx, projectRef)
+		if err != nil {
+			return err
+		}
+		params.Secret = secret
+	}
+	token, err := SignToken(projectRef, params, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Println(token)
+	return nil
+}
+
+func SignToken(projectRef string, params SignParams, now time.Time) (string, error) {
+	claims := jwt.MapClaims{
+		"role": params.Role,
+		"iat":  now.Unix(),
+		"exp":  now.Add(params.ExpiresIn).Unix(),
+	}
+	if len(projectRef) > 0 {
+		claims["iss"] = "supabase"
+		claims["ref"] = projectRef
+	} else {
+		claims["iss"] = "supabase-demo"
+	}
+	if len(params.Subject) > 0 {
+		claims["sub"] = params.Subject
+	}
+	// Auth service sets aud to role of signed in users
+	if params.Role == "authenticated" {
+		claims["aud"] = params.Role
+	}
+	if len(params.Claims) > 0 {
+		var extra map[string]any
+		if err := json.Unmarshal([]byte(params.Claims), &extra); err != nil {
+			return "", errors.Errorf("failed to parse claims: %w", err)
+		}
+		for k, v := range extra {
+			claims[k] = v
+		}
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(params.Secret))
+	if err != nil {
+		return "", errors.Errorf("failed to sign jwt: %w", err)
+	}
+	return signed, nil
+}
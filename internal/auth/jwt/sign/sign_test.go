@@ -0,0 +1,91 @@
+package sign
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+const testSecret = "super-secret-jwt-token-with-at-least-32-characters-long"
+
+func TestSignToken(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	t.Run("signs token with extra claims", func(t *testing.T) {
+		params := SignParams{
+			Role:      "authenticated",
+			Subject:   "5f0c6d8e-8b8a-4d36-9d8a-1f2b3c4d5e6f",
+			Claims:    `{"email": "user@example.com", "role": "admin"}`,
+			ExpiresIn: time.Hour,
+			Secret:    testSecret,
+		}
+		// Run test
+		signed, err := SignToken("", params, now)
+		// Check error
+		require.NoError(t, err)
+		claims := jwt.MapClaims{}
+		_, err = jwt.ParseWithClaims(signed, claims, func(t *jwt.Token) (any, error) {
+			return []byte(testSecret), nil
+		}, jwt.WithTimeFunc(func() time.Time { return now }))
+		require.NoError(t, err)
+		assert.Equal(t, jwt.MapClaims{
+			"aud":   "authenticated",
+			"email": "user@example.com",
+			"exp":   float64(1700003600),
+			"iat":   float64(1700000000),
+			"iss":   "supabase-demo",
+			"role":  "admin",
+			"sub":   "5f0c6d8e-8b8a-4d36-9d8a-1f2b3c4d5e6f",
+		}, claims)
+	})
+
+	t.Run("throws error on invalid claims", func(t *testing.T) {
+		_, err := SignToken("", SignParams{Role: "anon", Claims: "{", Secret: testSecret}, now)
+		assert.ErrorContains(t, err, "failed to parse claims:")
+	})
+}
+
+func TestSignRemote(t *testing.T) {
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("fetches project jwt secret", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		secret := testSecret
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/postgrest").
+			Reply(http.StatusOK).
+			JSON(api.PostgrestConfigWithJWTSecretResponse{JwtSecret: &secret})
+		// Run test
+		err := Run(context.Background(), project, SignParams{Role: "anon", ExpiresIn: time.Minute})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing secret", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/postgrest").
+			Reply(http.StatusOK).
+			JSON(api.PostgrestConfigWithJWTSecretResponse{})
+		// Run test
+		err := Run(context.Background(), project, SignParams{Role: "anon"})
+		// Check error
+		assert.ErrorContains(t, err, "JWT secret is not available for project:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,29 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/auth/client"
+	"github.com/supabase/cli/internal/auth/users/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/auth"
+)
+
+func Run(ctx context.Context, projectRef string, body auth.CreateUserRequest, format string) error {
+	if len(body.Email) == 0 && len(body.Phone) == 0 {
+		return errors.New("Either --email or --phone must be specified.")
+	}
+	api, err := client.NewAuthAdminAPI(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	user, err := api.CreateUser(ctx, body)
+	if err != nil {
+		return errors.Errorf("failed to create user: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Created user:", utils.Aqua(user.Id))
+	return list.PrintUsers([]auth.User{user}, format)
+}
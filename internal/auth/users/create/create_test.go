@@ -0,0 +1,74 @@
+package create
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/auth"
+)
+
+func TestCreateUser(t *testing.T) {
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("creates user on hosted project", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-role-key",
+			}})
+		gock.New("https://"+utils.GetSupabaseHost(project)).
+			Post("/auth/v1/admin/users").
+			MatchHeader("apikey", "service-role-key").
+			JSON(auth.CreateUserRequest{Email: "alice@example.com", Password: "secret", EmailConfirm: true}).
+			Reply(http.StatusOK).
+			JSON(auth.User{Id: "5f0c6d8e-8b8a-4d36-9d8a-1f2b3c4d5e6f", Email: "alice@example.com"})
+		// Run test
+		err := Run(context.Background(), project, auth.CreateUserRequest{
+			Email:        "alice@example.com",
+			Password:     "secret",
+			EmailConfirm: true,
+		}, utils.OutputJson)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing identity", func(t *testing.T) {
+		err := Run(context.Background(), project, auth.CreateUserRequest{Password: "secret"}, utils.OutputJson)
+		assert.ErrorContains(t, err, "Either --email or --phone must be specified.")
+	})
+
+	t.Run("throws error on duplicate email", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-role-key",
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(project)).
+			Post("/auth/v1/admin/users").
+			Reply(http.StatusUnprocessableEntity).
+			JSON(map[string]string{"msg": "A user with this email address has already been registered"})
+		// Run test
+		err := Run(context.Background(), project, auth.CreateUserRequest{Email: "alice@example.com"}, utils.OutputJson)
+		// Check error
+		assert.ErrorContains(t, err, "failed to create user: Error status 422:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
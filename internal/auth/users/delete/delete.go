@@ -0,0 +1,36 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/auth/client"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, projectRef string, ids []string, softDelete bool) error {
+	for _, id := range ids {
+		if !utils.UUIDPattern.MatchString(id) {
+			return errors.Errorf("user ID %q is not a UUID", id)
+		}
+	}
+	msg := fmt.Sprintf("Do you want to delete %d user(s)?", len(ids))
+	if shouldDelete, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldDelete {
+		return errors.New(context.Canceled)
+	}
+	api, err := client.NewAuthAdminAPI(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := api.DeleteUser(ctx, id, softDelete); err != nil {
+			return errors.Errorf("failed to delete user %s: %w", id, err)
+		}
+		fmt.Fprintln(os.Stderr, "Deleted user:", utils.Aqua(id))
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package invite
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/auth/client"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/auth"
+)
+
+func Run(ctx context.Context, projectRef string, body auth.InviteUserRequest) error {
+	api, err := client.NewAuthAdminAPI(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	user, err := api.InviteUser(ctx, body)
+	if err != nil {
+		return errors.Errorf("failed to invite user: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Invited user:", utils.Aqua(user.Email))
+	if len(projectRef) == 0 {
+		fmt.Fprintln(os.Stderr, "Open Inbucket to view the invite email:", utils.Aqua(fmt.Sprintf("http://%s:%d", utils.Config.Hostname, utils.Config.Inbucket.Port)))
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package list
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/auth/client"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/auth"
+)
+
+type ListFilter struct {
+	// Glob pattern matched case insensitively, ie. *@example.com
+	EmailPattern string
+	Provider     string
+	CreatedAfter time.Time
+	Page         uint
+	PerPage      uint
+	// Fetches all pages when set
+	All bool
+}
+
+func Run(ctx context.Context, projectRef string, filter ListFilter, format string) error {
+	if _, err := path.Match(filter.EmailPattern, ""); err != nil {
+		return errors.Errorf("invalid email pattern: %w", err)
+	}
+	api, err := client.NewAuthAdminAPI(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	users, err := ListUsers(ctx, api, filter)
+	if err != nil {
+		return err
+	}
+	return PrintUsers(users, format)
+}
+
+func PrintUsers(users []auth.User, format string) error {
+	switch format {
+	case utils.OutputPretty:
+		return list.RenderTable(toMarkdown(users))
+	case utils.OutputCsv:
+		return writeCsv(users, os.Stdout)
+	}
+	return utils.EncodeRows(format, os.Stdout, users)
+}
+
+func ListUsers(ctx context.Context, api auth.AuthAdminAPI, filter ListFilter) ([]auth.User, error) {
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = auth.PAGE_LIMIT
+	}
+	var result []auth.User
+	for page := filter.Page; ; page++ {
+		resp, err := api.ListUsers(ctx, page, filter.PerPage)
+		if err != nil {
+			return nil, errors.Errorf("failed to list users: %w", err)
+		}
+		for _, u := range resp.Users {
+			if filter.matches(u) {
+				result = append(result, u)
+			}
+		}
+		if !filter.All || uint(len(resp.Users)) < filter.PerPage {
+			if !filter.All && resp.Total > 0 {
+				fmt.Fprintf(os.Stderr, "Showing page %d of %d total users.\n", page, resp.Total)
+			}
+			break
+		}
+	}
+	return result, nil
+}
+
+func (f ListFilter) matches(u auth.User) bool {
+	if len(f.EmailPattern) > 0 {
+		if ok, _ := path.Match(strings.ToLower(f.EmailPattern), strings.ToLower(u.Email)); !ok {
+			return false
+		}
+	}
+	if len(f.Provider) > 0 && u.AppMetadata.Provider != f.Provider && !slices.Contains(u.AppMetadata.Providers, f.Provider) {
+		return false
+	}
+	return f.CreatedAfter.IsZero() || u.CreatedAt.After(f.CreatedAfter)
+}
+
+var columns = []string{"ID", "EMAIL", "PHONE", "PROVIDER", "CREATED AT (UTC)", "LAST SIGN IN (UTC)"}
+
+func toRecord(u auth.User) []string {
+	lastSignIn := ""
+	if u.LastSignInAt != nil {
+		lastSignIn = formatTime(*u.LastSignInAt)
+	}
+	return []string{
+		u.Id,
+		u.Email,
+		u.Phone,
+		u.AppMetadata.Provider,
+		formatTime(u.CreatedAt),
+		lastSignIn,
+	}
+}
+
+func toMarkdown(users []auth.User) string {
+	table := "|" + strings.Join(columns, "|") + "|\n|" + strings.Repeat("-|", len(columns)) + "\n"
+	for _, u := range users {
+		table += "|`" + strings.Join(toRecord(u), "`|`") + "`|\n"
+	}
+	return table
+}
+
+func writeCsv(users []auth.User, w io.Writer) error {
+	enc := csv.NewWriter(w)
+	if err := enc.Write(columns); err != nil {
+		return errors.Errorf("failed to write csv: %w", err)
+	}
+	for _, u := range users {
+		if err := enc.Write(toRecord(u)); err != nil {
+			return errors.Errorf("failed to write csv: %w", err)
+		}
+	}
+	enc.Flush()
+	if err := enc.Error(); err != nil {
+		return errors.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,110 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/auth"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+func TestListUsers(t *testing.T) {
+	utils.Config.Api.ExternalUrl = "http://127.0.0.1:54321"
+	api := auth.AuthAdminAPI{Fetcher: fetcher.NewFetcher(
+		utils.Config.Api.ExternalUrl,
+		fetcher.WithExpectedStatus(http.StatusOK),
+	)}
+	created := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	alice := auth.User{
+		Id:          "5f0c6d8e-8b8a-4d36-9d8a-1f2b3c4d5e6f",
+		Email:       "alice@example.com",
+		AppMetadata: auth.AppMetadata{Provider: "email", Providers: []string{"email", "github"}},
+		CreatedAt:   created,
+	}
+	bob := auth.User{
+		Id:          "7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+		Email:       "bob@test.dev",
+		AppMetadata: auth.AppMetadata{Provider: "google"},
+		CreatedAt:   created.AddDate(-1, 0, 0),
+	}
+
+	t.Run("fetches all pages", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.Config.Api.ExternalUrl).
+			Get("/auth/v1/admin/users").
+			MatchParams(map[string]string{"page": "1", "per_page": "1"}).
+			Reply(http.StatusOK).
+			JSON(auth.ListUsersResponse{Users: []auth.User{alice}})
+		gock.New(utils.Config.Api.ExternalUrl).
+			Get("/auth/v1/admin/users").
+			MatchParams(map[string]string{"page": "2", "per_page": "1"}).
+			Reply(http.StatusOK).
+			JSON(auth.ListUsersResponse{Users: []auth.User{bob}})
+		gock.New(utils.Config.Api.ExternalUrl).
+			Get("/auth/v1/admin/users").
+			MatchParams(map[string]string{"page": "3", "per_page": "1"}).
+			Reply(http.StatusOK).
+			JSON(auth.ListUsersResponse{Users: []auth.User{}})
+		// Run test
+		users, err := ListUsers(context.Background(), api, ListFilter{PerPage: 1, All: true})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []auth.User{alice, bob}, users)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("filters users", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.Config.Api.ExternalUrl).
+			Get("/auth/v1/admin/users").
+			Times(3).
+			Reply(http.StatusOK).
+			JSON(auth.ListUsersResponse{Users: []auth.User{alice, bob}})
+		// Run test
+		byEmail, err := ListUsers(context.Background(), api, ListFilter{EmailPattern: "*@EXAMPLE.com"})
+		assert.NoError(t, err)
+		byProvider, err := ListUsers(context.Background(), api, ListFilter{Provider: "github"})
+		assert.NoError(t, err)
+		byDate, err := ListUsers(context.Background(), api, ListFilter{CreatedAfter: created.AddDate(0, -1, 0)})
+		assert.NoError(t, err)
+		// Check error
+		assert.Equal(t, []auth.User{alice}, byEmail)
+		assert.Equal(t, []auth.User{alice}, byProvider)
+		assert.Equal(t, []auth.User{alice}, byDate)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on service unavailable", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.Config.Api.ExternalUrl).
+			Get("/auth/v1/admin/users").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := ListUsers(context.Background(), api, ListFilter{})
+		// Check error
+		assert.ErrorContains(t, err, "failed to list users: Error status 503:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid pattern", func(t *testing.T) {
+		err := Run(context.Background(), "", ListFilter{EmailPattern: "[a-"}, utils.OutputJson)
+		assert.ErrorContains(t, err, "invalid email pattern:")
+	})
+
+	t.Run("writes csv", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, writeCsv([]auth.User{alice}, &out))
+		assert.Equal(t, "ID,EMAIL,PHONE,PROVIDER,CREATED AT (UTC),LAST SIGN IN (UTC)\n"+
+			"5f0c6d8e-8b8a-4d36-9d8a-1f2b3c4d5e6f,alice@example.com,,email,2024-06-01 00:00:00,\n", out.String())
+	})
+}
@@ -0,0 +1,29 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/auth/client"
+	"github.com/supabase/cli/internal/auth/users/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/auth"
+)
+
+func Run(ctx context.Context, projectRef string, body auth.UpdateUserRequest, format string) error {
+	if !utils.UUIDPattern.MatchString(body.Id) {
+		return errors.Errorf("user ID %q is not a UUID", body.Id)
+	}
+	api, err := client.NewAuthAdminAPI(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	user, err := api.UpdateUser(ctx, body)
+	if err != nil {
+		return errors.Errorf("failed to update user: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Updated user:", utils.Aqua(user.Id))
+	return list.PrintUsers([]auth.User{user}, format)
+}
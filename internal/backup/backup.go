@@ -0,0 +1,21 @@
+package backup
+
+import "time"
+
+// Layout of files inside a backup archive.
+const (
+	ManifestPath = "manifest.json"
+	RolesPath    = "db/roles.sql"
+	SchemaPath   = "db/schema.sql"
+	DataPath     = "db/data.sql"
+	BucketsPath  = "storage/buckets.json"
+	ObjectsDir   = "storage/objects"
+	ConfigPath   = "config/config.toml"
+)
+
+type Manifest struct {
+	ProjectRef string    `json:"project_ref,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	CliVersion string    `json:"cli_version"`
+	Objects    int       `json:"objects"`
+}
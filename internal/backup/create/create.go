@@ -0,0 +1,196 @@
+package create
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/backup"
+	"github.com/supabase/cli/internal/db/dump"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/storage/ls"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func Run(ctx context.Context, projectRef string, config pgconn.Config, output string, fsys afero.Fs) error {
+	now := time.Now().UTC()
+	if len(output) == 0 {
+		output = fmt.Sprintf("supabase-backup-%s.tar.gz", now.Format("20060102150405"))
+	}
+	f, err := fsys.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return errors.Errorf("failed to create backup file: %w", err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	manifest := backup.Manifest{
+		ProjectRef: projectRef,
+		CreatedAt:  now,
+		CliVersion: utils.Version,
+	}
+	if err := backupDatabase(ctx, config, tw, fsys); err != nil {
+		return err
+	}
+	api, err := client.NewStorageAPI(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	if manifest.Objects, err = backupStorage(ctx, api, tw, fsys); err != nil {
+		return err
+	}
+	if data, err := afero.ReadFile(fsys, utils.ConfigPath); err == nil {
+		if err := writeEntry(tw, backup.ConfigPath, data); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return errors.Errorf("failed to read config: %w", err)
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := writeEntry(tw, backup.ManifestPath, data); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Errorf("failed to close archive: %w", err)
+	} else if err := gz.Close(); err != nil {
+		return errors.Errorf("failed to compress archive: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, render.T("finished_backup", utils.Bold(output)))
+	return nil
+}
+
+// Storage tables are excluded from the data dump because buckets and objects are backed
+// up through the Storage API, which recreates their rows on restore. Restoring both would
+// conflict on the rows of every re-uploaded object.
+var storageTables = []string{
+	"storage.buckets",
+	"storage.buckets_analytics",
+	"storage.objects",
+	"storage.prefixes",
+	"storage.s3_multipart_uploads",
+	"storage.s3_multipart_uploads_parts",
+}
+
+func backupDatabase(ctx context.Context, config pgconn.Config, tw *tar.Writer, fsys afero.Fs) error {
+	steps := []struct {
+		path string
+		dump func(io.Writer) error
+	}{{
+		path: backup.RolesPath,
+		dump: func(w io.Writer) error { return dump.DumpRole(ctx, config, false, false, w) },
+	}, {
+		path: backup.SchemaPath,
+		dump: func(w io.Writer) error { return dump.DumpSchema(ctx, config, nil, false, false, w) },
+	}, {
+		path: backup.DataPath,
+		dump: func(w io.Writer) error { return dump.DumpData(ctx, config, nil, storageTables, false, false, w) },
+	}}
+	for _, s := range steps {
+		fmt.Fprintln(os.Stderr, "Dumping", utils.Bold(s.path)+"...")
+		if err := writeStreamEntry(tw, s.path, s.dump, fsys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func backupStorage(ctx context.Context, api storage.StorageAPI, tw *tar.Writer, fsys afero.Fs) (int, error) {
+	buckets, err := api.ListBuckets(ctx)
+	if err != nil {
+		return 0, err
+	}
+	data, err := json.MarshalIndent(buckets, "", "  ")
+	if err != nil {
+		return 0, errors.Errorf("failed to encode buckets: %w", err)
+	}
+	if err := writeEntry(tw, backup.BucketsPath, data); err != nil {
+		return 0, err
+	}
+	var count int
+	for _, b := range buckets {
+		fmt.Fprintln(os.Stderr, "Downloading objects from bucket:", b.Name)
+		if err := ls.IterateStoragePathsAll(ctx, api, b.Name+"/", func(objectPath string) error {
+			// Empty buckets are reported with a trailing slash
+			if strings.HasSuffix(objectPath, "/") {
+				return nil
+			}
+			if err := writeStreamEntry(tw, path.Join(backup.ObjectsDir, objectPath), func(w io.Writer) error {
+				return api.DownloadObjectStream(ctx, objectPath, w)
+			}, fsys); err != nil {
+				return err
+			}
+			count++
+			return nil
+		}); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// Tar headers require the entry size upfront, so large dumps and objects are spooled
+// to a temporary file instead of being held in memory.
+func writeStreamEntry(tw *tar.Writer, name string, stream func(io.Writer) error, fsys afero.Fs) error {
+	f, err := afero.TempFile(fsys, "", "supabase-backup-*")
+	if err != nil {
+		return errors.Errorf("failed to create temp file: %w", err)
+	}
+	defer func() {
+		f.Close()
+		_ = fsys.Remove(f.Name())
+	}()
+	if err := stream(f); err != nil {
+		return err
+	}
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return errors.Errorf("failed to seek temp file: %w", err)
+	} else if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return errors.Errorf("failed to seek temp file: %w", err)
+	}
+	if err := writeHeader(tw, name, size); err != nil {
+		return err
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return errors.Errorf("failed to write archive entry: %w", err)
+	}
+	return nil
+}
+
+func writeEntry(tw *tar.Writer, name string, data []byte) error {
+	if err := writeHeader(tw, name, int64(len(data))); err != nil {
+		return err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return errors.Errorf("failed to write archive entry: %w", err)
+	}
+	return nil
+}
+
+func writeHeader(tw *tar.Writer, name string, size int64) error {
+	header := tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(&header); err != nil {
+		return errors.Errorf("failed to write archive header: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package create
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/backup"
+	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/fetcher"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var mockApi = storage.StorageAPI{Fetcher: fetcher.NewFetcher(
+	"http://127.0.0.1",
+)}
+
+func TestBackupStorage(t *testing.T) {
+	t.Run("streams objects into archive", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/bucket").
+			Reply(http.StatusOK).
+			JSON([]storage.BucketResponse{{Id: "private", Name: "private"}})
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{{
+				Name: "a.txt",
+				Id:   cast.Ptr("9b7f9f48-17a6-4ca8-b14a-39b0205a63e9"),
+			}})
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/a.txt").
+			Reply(http.StatusOK).
+			BodyString("hello")
+		// Run test
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		count, err := backupStorage(context.Background(), mockApi, tw, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Empty(t, gock.Pending())
+		assert.Empty(t, gock.GetUnmatchedRequests())
+		require.NoError(t, tw.Close())
+		entries := map[string]string{}
+		tr := tar.NewReader(&buf)
+		for header, err := tr.Next(); err == nil; header, err = tr.Next() {
+			data, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			entries[header.Name] = string(data)
+		}
+		assert.Equal(t, "hello", entries[backup.ObjectsDir+"/private/a.txt"])
+		assert.Contains(t, entries, backup.BucketsPath)
+		// Temp files are cleaned up
+		files, err := afero.ReadDir(fsys, afero.GetTempDir(fsys, ""))
+		assert.NoError(t, err)
+		assert.Empty(t, files)
+	})
+
+	t.Run("throws error on download failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/bucket").
+			Reply(http.StatusOK).
+			JSON([]storage.BucketResponse{{Id: "private", Name: "private"}})
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{{
+				Name: "a.txt",
+				Id:   cast.Ptr("9b7f9f48-17a6-4ca8-b14a-39b0205a63e9"),
+			}})
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/a.txt").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := backupStorage(context.Background(), mockApi, tar.NewWriter(io.Discard), afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Error status 503")
+		assert.Empty(t, gock.Pending())
+	})
+}
@@ -0,0 +1,166 @@
+package restore
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/backup"
+	dbRestore "github.com/supabase/cli/internal/db/restore"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/storage/cache"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func Run(ctx context.Context, archive, projectRef string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	// Archives are extracted to a temp dir so partial restores never touch the project directory
+	dir, err := afero.TempDir(fsys, "", "supabase-restore-")
+	if err != nil {
+		return errors.Errorf("failed to create temp dir: %w", err)
+	}
+	defer func() {
+		if err := fsys.RemoveAll(dir); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}()
+	tmp := afero.NewBasePathFs(fsys, dir)
+	if err := extract(archive, fsys, tmp); err != nil {
+		return err
+	}
+	data, err := afero.ReadFile(tmp, backup.ManifestPath)
+	if err != nil {
+		return errors.Errorf("failed to read manifest: %w", err)
+	}
+	var manifest backup.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return errors.Errorf("failed to parse manifest: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Restoring backup created at %s...\n", utils.Aqua(manifest.CreatedAt.Format("2006-01-02 15:04:05")))
+	files := dbRestore.DumpFiles{
+		Roles:  backup.RolesPath,
+		Schema: backup.SchemaPath,
+		Data:   backup.DataPath,
+	}
+	if err := dbRestore.Run(ctx, files, config, tmp, options...); err != nil {
+		return err
+	}
+	api, err := client.NewStorageAPI(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	defer cache.Invalidate(projectRef, fsys)
+	if err := restoreStorage(ctx, api, tmp); err != nil {
+		return err
+	}
+	return restoreConfig(tmp, fsys)
+}
+
+func extract(archive string, fsys, dst afero.Fs) error {
+	f, err := fsys.Open(archive)
+	if err != nil {
+		return errors.Errorf("failed to open backup file: %w", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return errors.Errorf("failed to decompress backup file: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return errors.Errorf("failed to read backup file: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := afero.WriteReader(dst, filepath.Clean(header.Name), tr); err != nil {
+			return errors.Errorf("failed to extract %s: %w", header.Name, err)
+		}
+	}
+}
+
+func restoreStorage(ctx context.Context, api storage.StorageAPI, tmp afero.Fs) error {
+	data, err := afero.ReadFile(tmp, backup.BucketsPath)
+	if err != nil {
+		return errors.Errorf("failed to read buckets: %w", err)
+	}
+	var buckets []storage.BucketResponse
+	if err := json.Unmarshal(data, &buckets); err != nil {
+		return errors.Errorf("failed to parse buckets: %w", err)
+	}
+	remote, err := api.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]bool, len(remote))
+	for _, b := range remote {
+		exists[b.Name] = true
+	}
+	for _, b := range buckets {
+		if exists[b.Name] {
+			continue
+		}
+		fmt.Fprintln(os.Stderr, render.T("creating_bucket", b.Name))
+		body := storage.CreateBucketRequest{
+			Name:             b.Name,
+			Id:               b.Id,
+			Public:           &b.Public,
+			AllowedMimeTypes: b.AllowedMimeTypes,
+		}
+		if b.FileSizeLimit != nil {
+			body.FileSizeLimit = int64(*b.FileSizeLimit)
+		}
+		if _, err := api.CreateBucket(ctx, body); err != nil {
+			return err
+		}
+	}
+	if _, err := tmp.Stat(backup.ObjectsDir); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return afero.Walk(tmp, backup.ObjectsDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		remotePath, err := filepath.Rel(backup.ObjectsDir, filePath)
+		if err != nil {
+			return errors.Errorf("failed to resolve object path: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, render.T("uploading", remotePath))
+		return api.UploadObject(ctx, filepath.ToSlash(remotePath), filePath, tmp, func(fo *storage.FileOptions) {
+			fo.Overwrite = true
+		})
+	})
+}
+
+func restoreConfig(tmp, fsys afero.Fs) error {
+	data, err := afero.ReadFile(tmp, backup.ConfigPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return errors.Errorf("failed to read config: %w", err)
+	}
+	if _, err := fsys.Stat(utils.ConfigPath); err == nil {
+		fmt.Fprintln(os.Stderr, "Skipped restoring config because "+utils.Bold(utils.ConfigPath)+" already exists.")
+		return nil
+	}
+	if err := utils.WriteFile(utils.ConfigPath, data, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Restored config to "+utils.Bold(utils.ConfigPath)+".")
+	return nil
+}
@@ -0,0 +1,113 @@
+package restore
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/backup"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/fetcher"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var mockApi = storage.StorageAPI{Fetcher: fetcher.NewFetcher(
+	"http://127.0.0.1",
+)}
+
+func writeArchive(t *testing.T, fsys afero.Fs, entries map[string]string) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, data := range entries {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data))}))
+		_, err := tw.Write([]byte(data))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	require.NoError(t, afero.WriteFile(fsys, "backup.tar.gz", buf.Bytes(), 0644))
+}
+
+func TestRestoreStorage(t *testing.T) {
+	t.Run("creates missing buckets and uploads objects", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		writeArchive(t, fsys, map[string]string{
+			backup.BucketsPath:                       `[{"id":"private","name":"private"},{"id":"public","name":"public","public":true}]`,
+			backup.ObjectsDir + "/public/docs/a.txt": "hello",
+		})
+		mem := afero.NewMemMapFs()
+		require.NoError(t, extract("backup.tar.gz", fsys, mem))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/bucket").
+			Reply(http.StatusOK).
+			JSON([]storage.BucketResponse{{Id: "private", Name: "private"}})
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/bucket").
+			JSON(storage.CreateBucketRequest{Id: "public", Name: "public", Public: cast.Ptr(true)}).
+			Reply(http.StatusOK).
+			JSON(storage.CreateBucketResponse{Name: "public"})
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/public/docs/a.txt").
+			MatchHeader("x-upsert", "true").
+			Reply(http.StatusOK)
+		// Run test
+		err := restoreStorage(context.Background(), mockApi, mem)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, gock.Pending())
+		assert.Empty(t, gock.GetUnmatchedRequests())
+	})
+
+	t.Run("throws error on malformed archive", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "backup.tar.gz", []byte("invalid"), 0644))
+		// Run test
+		err := extract("backup.tar.gz", fsys, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to decompress backup file")
+	})
+}
+
+func TestRestoreConfig(t *testing.T) {
+	t.Run("restores missing config", func(t *testing.T) {
+		// Setup in-memory fs
+		mem := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(mem, backup.ConfigPath, []byte(`project_id = "test"`), 0644))
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := restoreConfig(mem, fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, `project_id = "test"`, string(data))
+	})
+
+	t.Run("skips existing config", func(t *testing.T) {
+		// Setup in-memory fs
+		mem := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(mem, backup.ConfigPath, []byte(`project_id = "test"`), 0644))
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "local"`), 0644))
+		// Run test
+		err := restoreConfig(mem, fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, `project_id = "local"`, string(data))
+	})
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/supabase/cli/internal/login"
 	"github.com/supabase/cli/internal/projects/apiKeys"
 	"github.com/supabase/cli/internal/projects/create"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 	"github.com/supabase/cli/internal/utils/tenant"
@@ -81,7 +82,7 @@ func Run(ctx context.Context, starter StarterTemplate, fsys afero.Fs, options ..
 		Name:        filepath.Base(workdir),
 		TemplateUrl: &starter.Url,
 	}
-	if err := create.Run(ctx, params, fsys); err != nil {
+	if err := create.Run(ctx, params, false, fsys); err != nil {
 		return err
 	}
 	// 3. Get api keys
@@ -117,7 +118,7 @@ func Run(ctx context.Context, starter StarterTemplate, fsys afero.Fs, options ..
 	}
 	policy.Reset()
 	if err := backoff.RetryNotify(func() error {
-		return push.Run(ctx, false, false, true, true, config, fsys)
+		return push.Run(ctx, false, false, push.Payload{Migrations: true, Roles: true, Seed: true}, config, fsys)
 	}, policy, newErrorCallback()); err != nil {
 		return err
 	}
@@ -321,7 +322,7 @@ func ListSamples(ctx context.Context, client *github.Client) ([]StarterTemplate,
 }
 
 func downloadSample(ctx context.Context, client *github.Client, templateUrl string, fsys afero.Fs) error {
-	fmt.Println("Downloading:", templateUrl)
+	fmt.Fprintln(utils.GetHumanWriter(), render.T("downloading", templateUrl))
 	// https://github.com/supabase/supabase/tree/master/examples/user-management/nextjs-user-management
 	parsed, err := url.Parse(templateUrl)
 	if err != nil {
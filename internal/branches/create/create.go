@@ -34,6 +34,7 @@ func Run(ctx context.Context, body api.CreateBranchBody, fsys afero.Fs) error {
 		return errors.New("Unexpected error creating preview branch: " + string(resp.Body))
 	}
 
-	fmt.Println("Created preview branch:", resp.JSON201.Id)
+	fmt.Fprintln(utils.GetHumanWriter(), "Created preview branch:", resp.JSON201.Id)
+	utils.PrintResult(resp.JSON201.Id)
 	return nil
 }
@@ -0,0 +1,106 @@
+package init
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	ProviderGithub = "github"
+	ProviderGitlab = "gitlab"
+)
+
+var (
+	//go:embed templates/github.yml
+	githubWorkflow string
+	//go:embed templates/gitlab.yml
+	gitlabWorkflow string
+
+	githubWorkflowPath = filepath.Join(".github", "workflows", "supabase.yml")
+	gitlabWorkflowPath = filepath.Join(".gitlab", "supabase.gitlab-ci.yml")
+)
+
+type WorkflowParams struct {
+	// Empty project ref is read from CI secrets instead
+	ProjectRef      string
+	CliVersion      string
+	Branch          string
+	TypesPath       string
+	SchemaFlag      string
+	DeployFunctions bool
+}
+
+func Run(ctx context.Context, provider, branch, typesPath string, force bool, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	params := WorkflowParams{
+		CliVersion: "latest",
+		Branch:     branch,
+		TypesPath:  filepath.ToSlash(typesPath),
+	}
+	if projectRef, err := flags.LoadProjectRef(fsys); err == nil {
+		params.ProjectRef = projectRef
+	}
+	// Development builds are not published
+	if semver.IsValid("v" + utils.Version) {
+		params.CliVersion = utils.Version
+	}
+	if len(utils.Config.Api.Schemas) > 0 {
+		params.SchemaFlag = " --schema " + strings.Join(utils.Config.Api.Schemas, ",")
+	}
+	if exists, err := afero.DirExists(fsys, utils.FunctionsDir); err != nil {
+		return errors.Errorf("failed to check functions: %w", err)
+	} else {
+		params.DeployFunctions = exists
+	}
+	workflow, path := githubWorkflow, githubWorkflowPath
+	if provider == ProviderGitlab {
+		workflow, path = gitlabWorkflow, gitlabWorkflowPath
+	}
+	if !force {
+		if exists, err := afero.Exists(fsys, path); err != nil {
+			return errors.Errorf("failed to check workflow: %w", err)
+		} else if exists {
+			utils.CmdSuggestion = fmt.Sprintf("Run %s to overwrite the existing workflow.", utils.Aqua("supabase ci init --force"))
+			return errors.Errorf("Workflow already exists: %s", path)
+		}
+	}
+	out, err := RenderWorkflow(workflow, params)
+	if err != nil {
+		return err
+	}
+	if err := utils.WriteFile(path, out, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Generated workflow at "+utils.Bold(path))
+	if provider == ProviderGitlab {
+		fmt.Fprintln(os.Stderr, "Add it to the include list of your .gitlab-ci.yml to enable it.")
+	}
+	return nil
+}
+
+// Templates use [[ ]] delimiters to avoid clashing with expressions in GitHub Actions.
+func RenderWorkflow(workflow string, params WorkflowParams) ([]byte, error) {
+	tmpl, err := template.New("workflow").Delims("[[", "]]").Parse(workflow)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse workflow template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		return nil, errors.Errorf("failed to render workflow: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,81 @@
+package init
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderWorkflow(t *testing.T) {
+	params := WorkflowParams{
+		CliVersion:      "latest",
+		Branch:          "main",
+		TypesPath:       "src/database.types.ts",
+		SchemaFlag:      " --schema public",
+		DeployFunctions: true,
+	}
+
+	t.Run("renders github workflow", func(t *testing.T) {
+		out, err := RenderWorkflow(githubWorkflow, params)
+		require.NoError(t, err)
+		var workflow struct {
+			Env  map[string]string         `yaml:"env"`
+			Jobs map[string]map[string]any `yaml:"jobs"`
+		}
+		require.NoError(t, yaml.Unmarshal(out, &workflow))
+		assert.Equal(t, "${{ secrets.SUPABASE_PROJECT_ID }}", workflow.Env["SUPABASE_PROJECT_ID"])
+		assert.Contains(t, workflow.Jobs, "check-types")
+		assert.Contains(t, workflow.Jobs, "preview")
+		assert.Contains(t, workflow.Jobs, "deploy")
+		assert.Contains(t, string(out), "supabase gen types --local --lang typescript --schema public > src/database.types.ts")
+		assert.Contains(t, string(out), "supabase functions deploy")
+		assert.Contains(t, string(out), `supabase branches create "$HEAD_REF"`)
+	})
+
+	t.Run("renders gitlab pipeline", func(t *testing.T) {
+		params := params
+		params.ProjectRef = "abcdefghijklmnopqrst"
+		params.DeployFunctions = false
+		out, err := RenderWorkflow(gitlabWorkflow, params)
+		require.NoError(t, err)
+		var pipeline struct {
+			Stages    []string          `yaml:"stages"`
+			Variables map[string]string `yaml:"variables"`
+		}
+		require.NoError(t, yaml.Unmarshal(out, &pipeline))
+		assert.Equal(t, []string{"check", "preview", "deploy"}, pipeline.Stages)
+		assert.Equal(t, "abcdefghijklmnopqrst", pipeline.Variables["SUPABASE_PROJECT_ID"])
+		assert.NotContains(t, string(out), "supabase functions deploy")
+	})
+}
+
+func TestInitCommand(t *testing.T) {
+	t.Run("writes github workflow", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), ProviderGithub, "main", "database.types.ts", false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.Exists(fsys, githubWorkflowPath)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("throws error on existing workflow", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, afero.WriteFile(fsys, gitlabWorkflowPath, []byte{}, 0644))
+		// Run test
+		err := Run(context.Background(), ProviderGitlab, "main", "database.types.ts", false, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Workflow already exists: "+gitlabWorkflowPath)
+	})
+}
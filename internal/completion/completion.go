@@ -0,0 +1,145 @@
+package completion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/deploy"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	// Completions are cached so that repeated tab presses don't call the API every time.
+	TTL = 5 * time.Minute
+	// Shells block while waiting for completions, so slow requests are abandoned.
+	Timeout = 3 * time.Second
+)
+
+type Entry struct {
+	CachedAt time.Time `json:"cached_at"`
+	Values   []string  `json:"values"`
+}
+
+func cachePath(key string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", errors.Errorf("failed to get cache dir: %w", err)
+	}
+	return filepath.Join(dir, "supabase", "completion", key+".json"), nil
+}
+
+// Cached returns values stored under key unless expired, otherwise fetches and stores them.
+func Cached(ctx context.Context, key string, fsys afero.Fs, fetch func(context.Context) ([]string, error)) ([]string, error) {
+	path, err := cachePath(key)
+	if err != nil {
+		return nil, err
+	}
+	if data, err := afero.ReadFile(fsys, path); err == nil {
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err == nil && time.Since(entry.CachedAt) < TTL {
+			return entry.Values, nil
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+	values, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(Entry{CachedAt: time.Now().UTC(), Values: values})
+	if err != nil {
+		return nil, errors.Errorf("failed to encode completions: %w", err)
+	}
+	if err := utils.WriteFile(path, data, fsys); err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+	}
+	return values, nil
+}
+
+// ListProjects returns refs of all accessible projects, described by their names.
+func ListProjects(ctx context.Context, fsys afero.Fs) ([]string, error) {
+	if _, err := utils.LoadAccessTokenFS(fsys); err != nil {
+		return nil, err
+	}
+	return Cached(ctx, "projects", fsys, func(ctx context.Context) ([]string, error) {
+		resp, err := utils.GetSupabase().V1ListAllProjectsWithResponse(ctx)
+		if err != nil {
+			return nil, errors.Errorf("failed to list projects: %w", err)
+		} else if resp.JSON200 == nil {
+			return nil, errors.New("Unexpected error listing projects: " + string(resp.Body))
+		}
+		var result []string
+		for _, p := range *resp.JSON200 {
+			result = append(result, p.Id+"\t"+p.Name)
+		}
+		return result, nil
+	})
+}
+
+// ListFunctions returns slugs of functions deployed to the project.
+func ListFunctions(ctx context.Context, projectRef string, fsys afero.Fs) ([]string, error) {
+	if _, err := utils.LoadAccessTokenFS(fsys); err != nil {
+		return nil, err
+	}
+	return Cached(ctx, projectRef+"-functions", fsys, func(ctx context.Context) ([]string, error) {
+		resp, err := utils.GetSupabase().V1ListAllFunctionsWithResponse(ctx, projectRef)
+		if err != nil {
+			return nil, errors.Errorf("failed to list functions: %w", err)
+		} else if resp.JSON200 == nil {
+			return nil, errors.New("Unexpected error listing functions: " + string(resp.Body))
+		}
+		var result []string
+		for _, f := range *resp.JSON200 {
+			result = append(result, f.Slug)
+		}
+		return result, nil
+	})
+}
+
+// ListLocalFunctions returns slugs of functions in the project directory, including those
+// declared in config.
+func ListLocalFunctions(fsys afero.Fs) ([]string, error) {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+	}
+	slugs, err := deploy.GetFunctionSlugs(fsys)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(slugs)
+	return slices.Compact(slugs), nil
+}
+
+// ListBuckets returns names of storage buckets in the project, or the local stack if ref is empty.
+func ListBuckets(ctx context.Context, projectRef string, fsys afero.Fs) ([]string, error) {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return nil, err
+	}
+	key := "local-buckets"
+	if len(projectRef) > 0 {
+		key = projectRef + "-buckets"
+	}
+	return Cached(ctx, key, fsys, func(ctx context.Context) ([]string, error) {
+		api, err := client.NewStorageAPI(ctx, projectRef)
+		if err != nil {
+			return nil, err
+		}
+		buckets, err := api.ListBuckets(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var result []string
+		for _, b := range buckets {
+			result = append(result, b.Name)
+		}
+		return result, nil
+	})
+}
@@ -0,0 +1,109 @@
+package completion
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestListProjects(t *testing.T) {
+	project := api.V1ProjectResponse{
+		Id:   apitest.RandomProjectRef(),
+		Name: "Test Project",
+	}
+
+	t.Run("caches projects", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects").
+			Times(1).
+			Reply(200).
+			JSON([]api.V1ProjectResponse{project})
+		// Run test
+		refs, err := ListProjects(context.Background(), fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{project.Id + "\tTest Project"}, refs)
+		// Second call is served from cache
+		refs, err = ListProjects(context.Background(), fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{project.Id + "\tTest Project"}, refs)
+		// Validate api
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("refreshes expired cache", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path, err := cachePath("projects")
+		require.NoError(t, err)
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(`{"cached_at":"2000-01-01T00:00:00Z","values":["stale"]}`), 0644))
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects").
+			Reply(200).
+			JSON([]api.V1ProjectResponse{project})
+		// Run test
+		refs, err := ListProjects(context.Background(), fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{project.Id + "\tTest Project"}, refs)
+		// Validate api
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing token", func(t *testing.T) {
+		_, err := ListProjects(context.Background(), afero.NewMemMapFs())
+		assert.Error(t, err)
+	})
+
+	t.Run("throws error on network error", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects").
+			ReplyError(errors.New("network error"))
+		// Run test
+		_, err := ListProjects(context.Background(), fsys)
+		assert.ErrorContains(t, err, "network error")
+		// Validate api
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestListLocalFunctions(t *testing.T) {
+	t.Run("lists function directories", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		for _, slug := range []string{"world", "hello"} {
+			path := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+			require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
+		}
+		// Run test
+		slugs, err := ListLocalFunctions(fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"hello", "world"}, slugs)
+	})
+}
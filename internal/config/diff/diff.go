@@ -0,0 +1,120 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/pooler/get"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/config"
+	"github.com/supabase/cli/pkg/diff"
+)
+
+var ErrDrift = errors.New("Remote config has drifted from local config.toml")
+
+type Section struct {
+	Name   string
+	Local  any
+	Remote any
+}
+
+type poolerSettings struct {
+	PoolMode        string `toml:"pool_mode"`
+	DefaultPoolSize *int   `toml:"default_pool_size"`
+	MaxClientConn   *int   `toml:"max_client_conn"`
+}
+
+func Run(ctx context.Context, ref string, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	client := config.NewConfigUpdater(*utils.GetSupabase())
+	local, err := utils.Config.GetRemoteByProjectRef(ref)
+	if err != nil {
+		// Use base config when no remote is declared
+		local.ProjectId = ref
+	}
+	fmt.Fprintln(os.Stderr, "Comparing config with project:", local.ProjectId)
+	remote, err := client.GetRemoteConfig(ctx, local)
+	if err != nil {
+		return err
+	}
+	// Secrets are only returned as hashes so we compare them the same way
+	local.Auth = local.Auth.Clone()
+	local.Auth.HashSecrets(local.ProjectId)
+	sections := []Section{
+		{Name: "api", Local: local.Api, Remote: remote.Api},
+		{Name: "db.settings", Local: local.Db.Settings, Remote: remote.Db.Settings},
+		{Name: "auth", Local: local.Auth, Remote: remote.Auth},
+		{Name: "storage", Local: local.Storage, Remote: remote.Storage},
+	}
+	// Pooler is only compared when managed by config.toml
+	if local.Db.Pooler.Enabled {
+		remotePooler, err := getPrimaryPooler(ctx, local.ProjectId)
+		if err != nil {
+			return err
+		}
+		sections = append(sections, Section{
+			Name: "db.pooler",
+			Local: poolerSettings{
+				PoolMode:        string(local.Db.Pooler.PoolMode),
+				DefaultPoolSize: cast.Ptr(cast.UintToInt(local.Db.Pooler.DefaultPoolSize)),
+				MaxClientConn:   cast.Ptr(cast.UintToInt(local.Db.Pooler.MaxClientConn)),
+			},
+			Remote: remotePooler,
+		})
+	}
+	changed, err := WriteChanges(os.Stdout, sections)
+	if err != nil {
+		return err
+	} else if changed {
+		return errors.New(ErrDrift)
+	}
+	fmt.Fprintln(os.Stderr, render.T("local_config_up_to_date"))
+	return nil
+}
+
+func getPrimaryPooler(ctx context.Context, ref string) (poolerSettings, error) {
+	var result poolerSettings
+	configs, err := get.GetPoolerConfig(ctx, ref)
+	if err != nil {
+		return result, err
+	}
+	for _, c := range configs {
+		if c.DatabaseType == api.PRIMARY {
+			result.PoolMode = string(c.PoolMode)
+			result.DefaultPoolSize = c.DefaultPoolSize
+			result.MaxClientConn = c.MaxClientConn
+			return result, nil
+		}
+	}
+	return result, errors.New("Primary database pooler config not found.")
+}
+
+// WriteChanges prints a unified diff for every section that differs between local and
+// remote, returning true if any section has changed.
+func WriteChanges(w io.Writer, sections []Section) (bool, error) {
+	var changed bool
+	for _, s := range sections {
+		localValue, err := config.ToTomlBytes(s.Local)
+		if err != nil {
+			return false, err
+		}
+		remoteValue, err := config.ToTomlBytes(s.Remote)
+		if err != nil {
+			return false, err
+		}
+		if d := diff.Diff("local["+s.Name+"]", localValue, "remote["+s.Name+"]", remoteValue); len(d) > 0 {
+			fmt.Fprintln(w, "Remote", s.Name, "config differs from local:", string(d))
+			changed = true
+		}
+	}
+	return changed, nil
+}
@@ -0,0 +1,137 @@
+package diff
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
+)
+
+const testConfig = `project_id = "test"
+[auth]
+enabled = false
+
+[storage]
+enabled = false
+
+[db.pooler]
+enabled = true
+pool_mode = "transaction"
+default_pool_size = 20
+max_client_conn = 100
+`
+
+func TestConfigDiff(t *testing.T) {
+	ref := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	mockRemote := func(maxRows int, poolSize int) {
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/postgrest").
+			Reply(http.StatusOK).
+			JSON(api.PostgrestConfigWithJWTSecretResponse{
+				DbSchema:          "public,graphql_public",
+				DbExtraSearchPath: "public,extensions",
+				MaxRows:           maxRows,
+			})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/config/database").
+			Reply(http.StatusOK).
+			JSON(api.PostgresConfigResponse{})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/config/database/pooler").
+			Reply(http.StatusOK).
+			JSON([]api.SupavisorConfigResponse{{
+				DatabaseType: api.READREPLICA,
+				PoolMode:     api.SupavisorConfigResponsePoolModeSession,
+			}, {
+				DatabaseType:    api.PRIMARY,
+				PoolMode:        api.SupavisorConfigResponsePoolModeTransaction,
+				DefaultPoolSize: cast.Ptr(poolSize),
+				MaxClientConn:   cast.Ptr(100),
+			}})
+	}
+
+	t.Run("passes when remote matches local", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(testConfig), 0644))
+		// Setup api mock
+		defer gock.OffAll()
+		mockRemote(1000, 20)
+		// Run test
+		err := Run(context.Background(), ref, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on drift", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(testConfig), 0644))
+		// Setup api mock
+		defer gock.OffAll()
+		mockRemote(500, 30)
+		// Run test
+		err := Run(context.Background(), ref, fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrDrift)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing primary pooler", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(testConfig), 0644))
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/postgrest").
+			Reply(http.StatusOK).
+			JSON(api.PostgrestConfigWithJWTSecretResponse{})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/config/database").
+			Reply(http.StatusOK).
+			JSON(api.PostgresConfigResponse{})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/config/database/pooler").
+			Reply(http.StatusOK).
+			JSON([]api.SupavisorConfigResponse{})
+		// Run test
+		err := Run(context.Background(), ref, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Primary database pooler config not found.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestWriteChanges(t *testing.T) {
+	t.Run("prints diff of changed sections", func(t *testing.T) {
+		var buf bytes.Buffer
+		sections := []Section{
+			{Name: "same", Local: poolerSettings{PoolMode: "session"}, Remote: poolerSettings{PoolMode: "session"}},
+			{Name: "db.pooler", Local: poolerSettings{PoolMode: "session"}, Remote: poolerSettings{PoolMode: "transaction"}},
+		}
+		// Run test
+		changed, err := WriteChanges(&buf, sections)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Contains(t, buf.String(), "Remote db.pooler config differs from local:")
+		assert.Contains(t, buf.String(), `-pool_mode = "session"`)
+		assert.Contains(t, buf.String(), `+pool_mode = "transaction"`)
+		assert.NotContains(t, buf.String(), "Remote same config")
+	})
+}
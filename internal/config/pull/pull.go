@@ -0,0 +1,53 @@
+package pull
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/config/diff"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func Run(ctx context.Context, ref string, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	client := config.NewConfigUpdater(*utils.GetSupabase())
+	local, err := utils.Config.GetRemoteByProjectRef(ref)
+	if err != nil {
+		// Use base config when no remote is declared
+		local.ProjectId = ref
+	}
+	fmt.Fprintln(os.Stderr, "Pulling config from project:", local.ProjectId)
+	remote, err := client.GetRemoteConfig(ctx, local)
+	if err != nil {
+		return err
+	}
+	// Secrets are only returned as hashes so we compare them the same way
+	local.Auth = local.Auth.Clone()
+	local.Auth.HashSecrets(local.ProjectId)
+	changed, err := diff.WriteChanges(os.Stderr, []diff.Section{
+		{Name: "api", Local: local.Api, Remote: remote.Api},
+		{Name: "db.settings", Local: local.Db.Settings, Remote: remote.Db.Settings},
+		{Name: "auth", Local: local.Auth, Remote: remote.Auth},
+		{Name: "storage", Local: local.Storage, Remote: remote.Storage},
+	})
+	if err != nil {
+		return err
+	} else if !changed {
+		fmt.Fprintln(os.Stderr, render.T("local_config_up_to_date"))
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, "Secrets are redacted as hashes. Replace them with env() references before saving to", utils.Bold(utils.ConfigPath))
+	pulled := map[string]any{
+		"api":     remote.Api,
+		"db":      map[string]any{"settings": remote.Db.Settings},
+		"auth":    remote.Auth,
+		"storage": remote.Storage,
+	}
+	return utils.EncodeOutput(utils.OutputToml, os.Stdout, pulled)
+}
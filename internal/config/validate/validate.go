@@ -0,0 +1,240 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+)
+
+type Issue struct {
+	Line    int
+	Col     int
+	Level   string
+	Message string
+}
+
+func (i Issue) String() string {
+	level := utils.Yellow(i.Level)
+	if i.Level == LevelError {
+		level = utils.Red(i.Level)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s", utils.ConfigPath, i.Line, i.Col, level, i.Message)
+}
+
+func Run(ctx context.Context, fsys afero.Fs) error {
+	data, err := afero.ReadFile(fsys, utils.ConfigPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			utils.SuggestProjectNotFound()
+		}
+		return errors.Errorf("failed to read config: %w", err)
+	}
+	issues := Validate(data, fsys)
+	var count int
+	for _, i := range issues {
+		fmt.Fprintln(os.Stderr, i)
+		if i.Level == LevelError {
+			count++
+		}
+	}
+	if count > 0 {
+		return errors.Errorf("found %d errors in %s", count, utils.ConfigPath)
+	}
+	fmt.Fprintln(os.Stderr, render.T("finished_validate", utils.Bold(utils.ConfigPath)))
+	return nil
+}
+
+// Validate checks the raw config.toml for syntax errors, unknown keys, and
+// invalid values, returning all issues sorted by their position in the file.
+func Validate(data []byte, fsys afero.Fs) []Issue {
+	c := config.NewConfig()
+	// Load default values so that omitted fields pass validation
+	var buf bytes.Buffer
+	if err := c.Eject(&buf); err != nil {
+		return []Issue{{Line: 1, Col: 1, Level: LevelError, Message: err.Error()}}
+	} else if _, err := toml.NewDecoder(&buf).Decode(&c); err != nil {
+		return []Issue{{Line: 1, Col: 1, Level: LevelError, Message: err.Error()}}
+	}
+	keys := indexKeys(data)
+	metadata, err := toml.Decode(string(data), &c)
+	var pe toml.ParseError
+	if errors.As(err, &pe) {
+		return []Issue{{Line: pe.Position.Line, Col: pe.Position.Col, Level: LevelError, Message: pe.Message}}
+	} else if err != nil {
+		// Type mismatch errors only include the last decoded key
+		return []Issue{keys.issue(LevelError, keys.find(err.Error()), err.Error())}
+	}
+	var issues []Issue
+	for _, key := range metadata.Undecoded() {
+		if key[0] == "remotes" {
+			continue
+		}
+		issues = append(issues, keys.issue(LevelWarning, key.String(), "unknown config field: "+key.String()))
+	}
+	ports := []option{{"db.port", c.Db.Port}, {"db.shadow_port", c.Db.ShadowPort}}
+	if c.Api.Enabled {
+		ports = append(ports, option{"api.port", c.Api.Port})
+	}
+	if c.Db.Pooler.Enabled {
+		ports = append(ports, option{"db.pooler.port", c.Db.Pooler.Port})
+	}
+	if c.Studio.Enabled {
+		ports = append(ports, option{"studio.port", c.Studio.Port})
+	}
+	if c.Inbucket.Enabled {
+		ports = append(ports,
+			option{"inbucket.port", c.Inbucket.Port},
+			option{"inbucket.smtp_port", c.Inbucket.SmtpPort},
+			option{"inbucket.pop3_port", c.Inbucket.Pop3Port},
+		)
+	}
+	if c.EdgeRuntime.Enabled {
+		ports = append(ports, option{"edge_runtime.inspector_port", c.EdgeRuntime.InspectorPort})
+	}
+	if c.Analytics.Enabled {
+		ports = append(ports, option{"analytics.port", c.Analytics.Port})
+	}
+	issues = append(issues, checkPorts(ports, keys)...)
+	var providers []string
+	for key, enabled := range map[string]bool{
+		"auth.sms.twilio":        c.Auth.Sms.Twilio.Enabled,
+		"auth.sms.twilio_verify": c.Auth.Sms.TwilioVerify.Enabled,
+		"auth.sms.messagebird":   c.Auth.Sms.Messagebird.Enabled,
+		"auth.sms.textlocal":     c.Auth.Sms.Textlocal.Enabled,
+		"auth.sms.vonage":        c.Auth.Sms.Vonage.Enabled,
+	} {
+		if enabled {
+			providers = append(providers, key)
+		}
+	}
+	sort.Strings(providers)
+	issues = append(issues, checkExclusive(providers, keys)...)
+	if err := c.Validate(utils.NewRootFS(fsys)); err != nil {
+		issues = append(issues, keys.issue(LevelError, keys.find(err.Error()), err.Error()))
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+	return issues
+}
+
+type option struct {
+	key   string
+	value uint16
+}
+
+// Services bound to the same host port would fail on start.
+func checkPorts(ports []option, keys keyIndex) []Issue {
+	var issues []Issue
+	used := map[uint16]string{}
+	for _, p := range ports {
+		if p.value == 0 {
+			continue
+		}
+		if prev, ok := used[p.value]; ok {
+			msg := fmt.Sprintf("%s conflicts with %s: port %d is already in use", p.key, prev, p.value)
+			issues = append(issues, keys.issue(LevelError, p.key, msg))
+			continue
+		}
+		used[p.value] = p.key
+	}
+	return issues
+}
+
+// Only one of the enabled options may be set to true.
+func checkExclusive(enabled []string, keys keyIndex) []Issue {
+	var issues []Issue
+	for _, key := range enabled[min(len(enabled), 1):] {
+		msg := fmt.Sprintf("%s is mutually exclusive with %s", key, enabled[0])
+		issues = append(issues, keys.issue(LevelError, key+".enabled", msg))
+	}
+	return issues
+}
+
+// Maps dotted key paths to their line number in config.toml.
+type keyIndex map[string]int
+
+var (
+	tablePattern = regexp.MustCompile(`^\[\[?\s*([^\]]+?)\s*\]\]?`)
+	keyPattern   = regexp.MustCompile(`^([A-Za-z0-9_\-."' ]+?)\s*=`)
+	pathPattern  = regexp.MustCompile(`[a-z_][a-z0-9_]*(\.[a-z0-9_]+)*`)
+)
+
+func indexKeys(data []byte) keyIndex {
+	result := keyIndex{}
+	var table string
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if m := tablePattern.FindStringSubmatch(line); len(m) > 1 {
+			table = normalizeKey(m[1])
+			if _, ok := result[table]; !ok {
+				result[table] = i + 1
+			}
+		} else if m := keyPattern.FindStringSubmatch(line); len(m) > 1 {
+			key := normalizeKey(m[1])
+			if len(table) > 0 {
+				key = table + "." + key
+			}
+			result[key] = i + 1
+		}
+	}
+	return result
+}
+
+func normalizeKey(key string) string {
+	parts := strings.Split(key, ".")
+	for i, p := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(p), `"'`)
+	}
+	return strings.Join(parts, ".")
+}
+
+// Returns the longest key path mentioned in an error message.
+func (k keyIndex) find(msg string) string {
+	var result string
+	for _, path := range pathPattern.FindAllString(msg, -1) {
+		if len(path) > len(result) && k.line(path) > 0 {
+			result = path
+		}
+	}
+	return result
+}
+
+// Falls back to the closest parent table when the key itself is undeclared.
+func (k keyIndex) line(key string) int {
+	for len(key) > 0 {
+		if line, ok := k[key]; ok {
+			return line
+		}
+		i := strings.LastIndexByte(key, '.')
+		if i < 0 {
+			break
+		}
+		key = key[:i]
+	}
+	return 0
+}
+
+func (k keyIndex) issue(level, key, msg string) Issue {
+	line := k.line(key)
+	if line == 0 {
+		line = 1
+	}
+	return Issue{Line: line, Col: 1, Level: level, Message: msg}
+}
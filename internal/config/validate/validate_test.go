@@ -0,0 +1,101 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestValidateConfig(t *testing.T) {
+	t.Run("passes on valid config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		err := Run(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("reports syntax error position", func(t *testing.T) {
+		data := []byte("project_id = \"test\"\n\n[db]\nport = \n")
+		// Run test
+		issues := Validate(data, afero.NewMemMapFs())
+		// Check error
+		require.Len(t, issues, 1)
+		assert.Equal(t, LevelError, issues[0].Level)
+		assert.Equal(t, 4, issues[0].Line)
+	})
+
+	t.Run("reports type mismatch", func(t *testing.T) {
+		data := []byte("[api]\nport = \"abc\"\n")
+		// Run test
+		issues := Validate(data, afero.NewMemMapFs())
+		// Check error
+		require.Len(t, issues, 1)
+		assert.Equal(t, LevelError, issues[0].Level)
+		assert.Equal(t, 2, issues[0].Line)
+	})
+
+	t.Run("warns about unknown keys", func(t *testing.T) {
+		data := []byte("project_id = \"test\"\n\n[auth]\nunknown = true\n\n[remotes.staging]\nproject_id = \"abc\"\n")
+		// Run test
+		issues := Validate(data, afero.NewMemMapFs())
+		// Check error
+		assert.Equal(t, []Issue{{
+			Line:    4,
+			Col:     1,
+			Level:   LevelWarning,
+			Message: "unknown config field: auth.unknown",
+		}}, issues)
+	})
+
+	t.Run("reports port conflicts", func(t *testing.T) {
+		data := []byte("[api]\nport = 54322\n\n[db]\nport = 54322\n")
+		// Run test
+		issues := Validate(data, afero.NewMemMapFs())
+		// Check error
+		require.Len(t, issues, 1)
+		assert.Equal(t, 2, issues[0].Line)
+		assert.Equal(t, "api.port conflicts with db.port: port 54322 is already in use", issues[0].Message)
+	})
+
+	t.Run("reports mutually exclusive sms providers", func(t *testing.T) {
+		data := []byte(`[auth.sms.twilio]
+enabled = true
+account_sid = "sid"
+message_service_sid = "msid"
+auth_token = "token"
+
+[auth.sms.vonage]
+enabled = true
+`)
+		// Run test
+		issues := Validate(data, afero.NewMemMapFs())
+		// Check error
+		require.NotEmpty(t, issues)
+		assert.Equal(t, 8, issues[0].Line)
+		assert.Equal(t, "auth.sms.vonage is mutually exclusive with auth.sms.twilio", issues[0].Message)
+	})
+
+	t.Run("maps validation error to key", func(t *testing.T) {
+		data := []byte("[db.pooler]\nenabled = true\npool_mode = \"invalid\"\n")
+		// Run test
+		issues := Validate(data, afero.NewMemMapFs())
+		// Check error
+		require.Len(t, issues, 1)
+		assert.Equal(t, LevelError, issues[0].Level)
+		assert.Equal(t, 3, issues[0].Line)
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to read config")
+	})
+}
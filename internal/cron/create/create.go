@@ -0,0 +1,51 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/new"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, name, schedule, command string, toMigration bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if len(strings.TrimSpace(command)) == 0 {
+		return errors.New("Missing SQL command to schedule.")
+	}
+	if toMigration {
+		path := new.GetMigrationPath(utils.GetCurrentTimestamp(), "cron_"+name)
+		if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+			return err
+		}
+		if err := afero.WriteFile(fsys, path, []byte(ScheduleSQL(name, schedule, command)+";\n"), 0644); err != nil {
+			return errors.Errorf("failed to write migration: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, render.T("created_migration", utils.Bold(path)))
+		return nil
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	var jobId int64
+	// Simple protocol because cron.schedule is overloaded
+	if err := conn.QueryRow(ctx, ScheduleSQL(name, schedule, command), pgx.QuerySimpleProtocol(true)).Scan(&jobId); err != nil {
+		return errors.Errorf("failed to schedule cron job: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Scheduled cron job %s with id: %d\n", utils.Aqua(name), jobId)
+	return nil
+}
+
+// Scheduling a job with an existing name updates it in place.
+func ScheduleSQL(name, schedule, command string) string {
+	return fmt.Sprintf("SELECT cron.schedule(%s, %s, %s)", utils.QuoteLiteral(name), utils.QuoteLiteral(schedule), utils.QuoteLiteral(command))
+}
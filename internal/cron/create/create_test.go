@@ -0,0 +1,53 @@
+package create
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestCreateJob(t *testing.T) {
+	t.Run("schedules job on database", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("SELECT cron.schedule('cleanup', '*/5 * * * *', 'delete from logs where level = ''debug''')").
+			Reply("SELECT 1", []interface{}{int64(3)})
+		// Run test
+		err := Run(context.Background(), "cleanup", "*/5 * * * *", "delete from logs where level = 'debug'", false, dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("writes job to migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), "cleanup", "@daily", "select 1", true, dbConfig, fsys)
+		// Check error
+		assert.NoError(t, err)
+		files, err := afero.ReadDir(fsys, utils.MigrationsDir)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Regexp(t, `^\d{14}_cron_cleanup\.sql$`, files[0].Name())
+	})
+
+	t.Run("throws error on empty command", func(t *testing.T) {
+		err := Run(context.Background(), "cleanup", "@daily", " ", true, dbConfig, afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "Missing SQL command to schedule.")
+	})
+}
@@ -0,0 +1,34 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const unscheduleJob = "SELECT cron.unschedule($1::text)"
+
+func Run(ctx context.Context, name string, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	msg := fmt.Sprintf("Do you want to delete cron job %s?", utils.Aqua(name))
+	if shouldDelete, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldDelete {
+		return errors.New(context.Canceled)
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	var deleted bool
+	if err := conn.QueryRow(ctx, unscheduleJob, name).Scan(&deleted); err != nil {
+		return errors.Errorf("failed to delete cron job: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Deleted cron job:", utils.Aqua(name))
+	return nil
+}
@@ -0,0 +1,95 @@
+package list
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type Job struct {
+	Id          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Schedule    string     `json:"schedule"`
+	Command     string     `json:"command"`
+	Active      bool       `json:"active"`
+	LastStatus  string     `json:"last_status"`
+	LastRunAt   *time.Time `json:"last_run_at"`
+	LastMessage string     `json:"last_message"`
+	FailedRuns  int64      `json:"failed_runs"`
+}
+
+//go:embed list.sql
+var listJobs string
+
+func Run(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	jobs, err := ListJobs(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeRows(utils.OutputFormat.Value, os.Stdout, jobs)
+	}
+	table := "|ID|NAME|SCHEDULE|ACTIVE|LAST STATUS|LAST RUN (UTC)|FAILED RUNS|COMMAND|\n|-|-|-|-|-|-|-|-|\n"
+	for _, j := range jobs {
+		lastRun := ""
+		if j.LastRunAt != nil {
+			lastRun = j.LastRunAt.UTC().Format("2006-01-02 15:04:05")
+		}
+		status := j.LastStatus
+		if status == "failed" && len(j.LastMessage) > 0 {
+			status += ": " + j.LastMessage
+		}
+		table += fmt.Sprintf("|`%d`|`%s`|`%s`|`%t`|`%s`|`%s`|`%d`|`%s`|\n",
+			j.Id, j.Name, j.Schedule, j.Active, escape(status), lastRun, j.FailedRuns, escape(j.Command))
+	}
+	return list.RenderTable(table)
+}
+
+func ListJobs(ctx context.Context, conn *pgx.Conn) ([]Job, error) {
+	rows, err := conn.Query(ctx, listJobs)
+	if err != nil {
+		return nil, toListError(err)
+	}
+	defer rows.Close()
+	var result []Job
+	for rows.Next() {
+		var j Job
+		if err := rows.Scan(&j.Id, &j.Name, &j.Schedule, &j.Command, &j.Active, &j.LastStatus, &j.LastRunAt, &j.LastMessage, &j.FailedRuns); err != nil {
+			return nil, errors.Errorf("failed to scan cron job: %w", err)
+		}
+		result = append(result, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, toListError(err)
+	}
+	return result, nil
+}
+
+func toListError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (pgErr.Code == pgerrcode.InvalidSchemaName || pgErr.Code == pgerrcode.UndefinedTable) {
+		utils.CmdSuggestion = "Enable the pg_cron extension first: " + utils.Aqua("create extension pg_cron with schema pg_catalog;")
+	}
+	return errors.Errorf("failed to list cron jobs: %w", err)
+}
+
+// Keeps multiline commands on a single table row.
+func escape(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	return strings.ReplaceAll(value, "|", `\|`)
+}
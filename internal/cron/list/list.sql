@@ -0,0 +1,12 @@
+SELECT j.jobid, coalesce(j.jobname, ''), j.schedule, j.command, j.active,
+  coalesce(r.status, ''), r.start_time, coalesce(r.return_message, ''),
+  (SELECT count(*) FROM cron.job_run_details f WHERE f.jobid = j.jobid AND f.status = 'failed')
+FROM cron.job j
+LEFT JOIN LATERAL (
+  SELECT d.status, d.start_time, d.return_message
+  FROM cron.job_run_details d
+  WHERE d.jobid = j.jobid
+  ORDER BY d.start_time DESC NULLS LAST
+  LIMIT 1
+) r ON true
+ORDER BY j.jobid
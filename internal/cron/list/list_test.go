@@ -0,0 +1,50 @@
+package list
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+func TestListJobs(t *testing.T) {
+	t.Run("lists jobs with last run status", func(t *testing.T) {
+		lastRun := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listJobs).
+			Reply("SELECT 1", []interface{}{int64(1), "vacuum", "0 3 * * *", "VACUUM", true, "failed", lastRun, "permission denied", int64(2)})
+		// Run test
+		jobs, err := ListJobs(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 1)
+		assert.Equal(t, "vacuum", jobs[0].Name)
+		assert.Equal(t, "failed", jobs[0].LastStatus)
+		assert.Equal(t, int64(2), jobs[0].FailedRuns)
+		assert.True(t, lastRun.Equal(*jobs[0].LastRunAt))
+	})
+
+	t.Run("suggests enabling pg_cron", func(t *testing.T) {
+		t.Cleanup(func() { utils.CmdSuggestion = "" })
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listJobs).
+			ReplyError(pgerrcode.UndefinedTable, `relation "cron.job" does not exist`)
+		// Run test
+		_, err := ListJobs(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.ErrorContains(t, err, "failed to list cron jobs:")
+		assert.Contains(t, utils.CmdSuggestion, "pg_cron")
+	})
+}
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, `select 1 \| 2`, escape("select 1\n  | 2"))
+}
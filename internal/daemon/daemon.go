@@ -0,0 +1,181 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Standard JSON-RPC 2.0 error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeServerError    = -32000
+)
+
+type Request struct {
+	Version string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type Response struct {
+	Version string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+type Handler func(ctx context.Context, params json.RawMessage) (any, error)
+
+type Server struct {
+	handlers map[string]Handler
+	// Commands share global config, so requests are handled one at a time
+	mu sync.Mutex
+}
+
+func NewServer(handlers map[string]Handler) *Server {
+	return &Server{handlers: handlers}
+}
+
+func Run(ctx context.Context, socketPath, projectRef string, dbConfig pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	l, err := listen(socketPath, fsys)
+	if err != nil {
+		return err
+	}
+	if !filepath.IsAbs(socketPath) {
+		socketPath, _ = filepath.Abs(socketPath)
+	}
+	fmt.Fprintln(os.Stderr, "Listening on", utils.Bold(socketPath))
+	fmt.Fprintln(os.Stderr, "Press Ctrl+C to stop.")
+	server := NewServer(NewHandlers(projectRef, dbConfig, fsys, options...))
+	return server.Serve(ctx, l)
+}
+
+func listen(socketPath string, fsys afero.Fs) (net.Listener, error) {
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(socketPath)); err != nil {
+		return nil, err
+	}
+	// Sockets left behind by a daemon that did not exit cleanly are safe to replace
+	if _, err := fsys.Stat(socketPath); err == nil {
+		if conn, err := net.Dial("unix", socketPath); err == nil {
+			conn.Close()
+			return nil, errors.Errorf("daemon is already listening on %s", socketPath)
+		}
+		if err := fsys.Remove(socketPath); err != nil {
+			return nil, errors.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, errors.Errorf("failed to listen on socket: %w", err)
+	}
+	return l, nil
+}
+
+// Serve accepts connections until the context is cancelled, which also closes the listener.
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Errorf("failed to accept connection: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.serveConn(ctx, conn)
+		}()
+	}
+}
+
+// Each connection carries a stream of newline delimited requests, answered in order.
+func (s *Server) serveConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+	dec := json.NewDecoder(conn)
+	enc := json.NewEncoder(conn)
+	for {
+		var req Request
+		if err := dec.Decode(&req); errors.Is(err, io.EOF) || ctx.Err() != nil {
+			return
+		} else if err != nil {
+			// The stream cannot be resynchronised after malformed input
+			resp := Response{Version: "2.0", Id: json.RawMessage("null")}
+			resp.Error = &Error{Code: CodeParseError, Message: err.Error()}
+			_ = enc.Encode(resp)
+			return
+		}
+		resp := s.handle(ctx, req)
+		// Notifications are requests without an id that expect no response
+		if len(req.Id) == 0 {
+			continue
+		}
+		if err := enc.Encode(resp); err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+			return
+		}
+	}
+}
+
+func (s *Server) handle(ctx context.Context, req Request) Response {
+	resp := Response{Version: "2.0", Id: req.Id}
+	if req.Version != "2.0" || len(req.Method) == 0 {
+		resp.Error = &Error{Code: CodeInvalidRequest, Message: "invalid JSON-RPC 2.0 request"}
+		return resp
+	}
+	handler, ok := s.handlers[req.Method]
+	if !ok {
+		resp.Error = &Error{Code: CodeMethodNotFound, Message: "method not found: " + req.Method}
+		return resp
+	}
+	s.mu.Lock()
+	result, err := handler(ctx, req.Params)
+	s.mu.Unlock()
+	if err == nil {
+		resp.Result, err = json.Marshal(result)
+	}
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: CodeServerError, Message: err.Error()}
+		}
+		resp.Result = nil
+		resp.Error = rpcErr
+	}
+	return resp
+}
@@ -0,0 +1,166 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+// Sends each request line over an in-memory connection and returns the response lines.
+func roundTrip(t *testing.T, server *Server, requests ...string) []string {
+	client, conn := net.Pipe()
+	go server.serveConn(context.Background(), conn)
+	defer client.Close()
+	reader := bufio.NewReader(client)
+	var responses []string
+	for _, r := range requests {
+		_, err := client.Write([]byte(r + "\n"))
+		require.NoError(t, err)
+		var req Request
+		// Notifications and malformed requests are not followed by a response
+		if json.Unmarshal([]byte(r), &req) == nil && len(req.Id) == 0 {
+			continue
+		}
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		responses = append(responses, line)
+	}
+	return responses
+}
+
+func TestServeConn(t *testing.T) {
+	server := NewServer(map[string]Handler{
+		"echo": func(ctx context.Context, params json.RawMessage) (any, error) {
+			return decodeParams[map[string]string](params)
+		},
+		"fail": func(ctx context.Context, params json.RawMessage) (any, error) {
+			return nil, errors.New("network error")
+		},
+	})
+
+	t.Run("answers requests in order", func(t *testing.T) {
+		responses := roundTrip(t, server,
+			`{"jsonrpc":"2.0","id":1,"method":"echo","params":{"a":"b"}}`,
+			`{"jsonrpc":"2.0","method":"echo"}`,
+			`{"jsonrpc":"2.0","id":"2","method":"echo"}`,
+		)
+		assert.Equal(t, []string{
+			`{"jsonrpc":"2.0","id":1,"result":{"a":"b"}}` + "\n",
+			`{"jsonrpc":"2.0","id":"2","result":null}` + "\n",
+		}, responses)
+	})
+
+	t.Run("returns protocol errors", func(t *testing.T) {
+		responses := roundTrip(t, server,
+			`{"id":1,"method":"echo"}`,
+			`{"jsonrpc":"2.0","id":2,"method":"missing"}`,
+			`{"jsonrpc":"2.0","id":3,"method":"echo","params":[1]}`,
+			`{"jsonrpc":"2.0","id":4,"method":"fail"}`,
+		)
+		assert.Equal(t, []string{
+			`{"jsonrpc":"2.0","id":1,"error":{"code":-32600,"message":"invalid JSON-RPC 2.0 request"}}` + "\n",
+			`{"jsonrpc":"2.0","id":2,"error":{"code":-32601,"message":"method not found: missing"}}` + "\n",
+			`{"jsonrpc":"2.0","id":3,"error":{"code":-32602,"message":"json: cannot unmarshal array into Go value of type map[string]string"}}` + "\n",
+			`{"jsonrpc":"2.0","id":4,"error":{"code":-32000,"message":"network error"}}` + "\n",
+		}, responses)
+	})
+
+	t.Run("closes connection on malformed input", func(t *testing.T) {
+		client, conn := net.Pipe()
+		go server.serveConn(context.Background(), conn)
+		defer client.Close()
+		go func() {
+			_, _ = client.Write([]byte("{not json}\n"))
+		}()
+		reader := bufio.NewReader(client)
+		line, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		assert.Contains(t, line, `"code":-32700`)
+		_, err = reader.ReadString('\n')
+		assert.Error(t, err)
+	})
+}
+
+func TestServe(t *testing.T) {
+	t.Run("replaces stale socket", func(t *testing.T) {
+		socketPath := filepath.Join(t.TempDir(), "daemon.sock")
+		fsys := afero.NewOsFs()
+		require.NoError(t, afero.WriteFile(fsys, socketPath, []byte{}, 0644))
+		// Run test
+		l, err := listen(socketPath, fsys)
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- NewServer(NewHandlers("", dbConfig, fsys)).Serve(ctx, l)
+		}()
+		// Check response
+		conn, err := net.Dial("unix", socketPath)
+		require.NoError(t, err)
+		_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"ping"}` + "\n"))
+		require.NoError(t, err)
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		assert.NoError(t, err)
+		assert.Equal(t, `{"jsonrpc":"2.0","id":1,"result":"pong"}`+"\n", line)
+		// A second daemon cannot listen on the same socket
+		_, err = listen(socketPath, fsys)
+		assert.ErrorContains(t, err, "daemon is already listening")
+		// Shutdown
+		cancel()
+		assert.NoError(t, <-errCh)
+		conn.Close()
+	})
+}
+
+func TestMethods(t *testing.T) {
+	t.Run("lists migrations", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "20220727064246_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 1", []interface{}{"20220727064247"})
+		handlers := NewHandlers("", dbConfig, fsys, conn.Intercept)
+		// Run test
+		result, err := handlers["migration.list"](context.Background(), nil)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, MigrationListResult{
+			Local:  []string{"20220727064246"},
+			Remote: []string{"20220727064247"},
+		}, result)
+	})
+
+	t.Run("throws error on invalid storage path", func(t *testing.T) {
+		handlers := NewHandlers("", dbConfig, afero.NewMemMapFs())
+		// Run test
+		_, err := handlers["storage.ls"](context.Background(), json.RawMessage(`{"path":"private"}`))
+		// Check error
+		var rpcErr *Error
+		assert.ErrorAs(t, err, &rpcErr)
+		assert.Equal(t, CodeInvalidParams, rpcErr.Code)
+	})
+}
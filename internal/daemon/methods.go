@@ -0,0 +1,118 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	env "github.com/Netflix/go-env"
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/gen/types"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/status"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/storage/ls"
+)
+
+type GenTypesParams struct {
+	Lang    string   `json:"lang"`
+	Schemas []string `json:"schemas"`
+}
+
+type MigrationListResult struct {
+	Local  []string `json:"local"`
+	Remote []string `json:"remote"`
+}
+
+type StorageLsParams struct {
+	Path      string `json:"path"`
+	Recursive bool   `json:"recursive"`
+}
+
+// NewHandlers returns the methods served by the daemon, which operate on the
+// database and project selected when the daemon was started.
+func NewHandlers(projectRef string, dbConfig pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) map[string]Handler {
+	return map[string]Handler{
+		"ping": func(ctx context.Context, params json.RawMessage) (any, error) {
+			return "pong", nil
+		},
+		"status": func(ctx context.Context, params json.RawMessage) (any, error) {
+			var names status.CustomName
+			if err := env.Unmarshal(env.EnvSet{}, &names); err != nil {
+				return nil, errors.Errorf("failed to parse status names: %w", err)
+			}
+			return status.LoadValues(ctx, names, fsys)
+		},
+		"gen.types": func(ctx context.Context, params json.RawMessage) (any, error) {
+			args, err := decodeParams[GenTypesParams](params)
+			if err != nil {
+				return nil, err
+			}
+			if len(args.Lang) == 0 {
+				args.Lang = types.LangTypescript
+			}
+			var buf bytes.Buffer
+			if err := types.Generate(ctx, &buf, dbConfig, args.Lang, args.Schemas, options...); err != nil {
+				return nil, err
+			}
+			return buf.String(), nil
+		},
+		"migration.list": func(ctx context.Context, params json.RawMessage) (any, error) {
+			remote, err := list.LoadRemoteVersions(ctx, dbConfig, options...)
+			if err != nil {
+				return nil, err
+			}
+			local, err := list.LoadLocalVersions(fsys)
+			if err != nil {
+				return nil, err
+			}
+			result := MigrationListResult{Local: []string{}, Remote: []string{}}
+			result.Local = append(result.Local, local...)
+			result.Remote = append(result.Remote, remote...)
+			return result, nil
+		},
+		"storage.ls": func(ctx context.Context, params json.RawMessage) (any, error) {
+			args, err := decodeParams[StorageLsParams](params)
+			if err != nil {
+				return nil, err
+			}
+			if len(args.Path) == 0 {
+				args.Path = client.STORAGE_SCHEME + ":///"
+			}
+			remotePath, err := client.ParseStorageURL(args.Path)
+			if err != nil {
+				return nil, &Error{Code: CodeInvalidParams, Message: err.Error()}
+			}
+			api, err := client.NewStorageAPI(ctx, projectRef)
+			if err != nil {
+				return nil, err
+			}
+			result := []string{}
+			callback := func(objectPath string) error {
+				result = append(result, objectPath)
+				return nil
+			}
+			if args.Recursive {
+				err = ls.IterateStoragePathsAll(ctx, api, remotePath, callback)
+			} else {
+				err = ls.IterateStoragePaths(ctx, api, remotePath, callback)
+			}
+			return result, err
+		},
+	}
+}
+
+// Omitted params decode to the zero value of T.
+func decodeParams[T any](params json.RawMessage) (T, error) {
+	var result T
+	if len(params) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(params, &result); err != nil {
+		return result, &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return result, nil
+}
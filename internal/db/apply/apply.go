@@ -0,0 +1,70 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/diff"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+// Applies the difference between declarative schema files and the target database,
+// or saves it as a new migration file when file is specified.
+func Run(ctx context.Context, schema []string, file string, dryRun bool, config pgconn.Config, differ diff.DiffFunc, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if utils.IsLocalDatabase(config) {
+		if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+			return err
+		}
+	}
+	out, err := diff.DiffDeclared(ctx, schema, config, os.Stderr, fsys, differ, options...)
+	if err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(out)) == 0 {
+		fmt.Fprintln(os.Stderr, render.T("db_schema_up_to_date"))
+		return nil
+	}
+	if len(file) > 0 || dryRun {
+		return diff.SaveDiff(out, file, fsys)
+	}
+	drops := diff.FindDropStatements(out)
+	msg := fmt.Sprintf("Do you want to apply these changes to the database?\n%s\n", out)
+	if len(drops) > 0 {
+		msg = fmt.Sprintf("%s%s\n%s\n", msg, utils.Yellow("Found drop statements in schema diff:"), strings.Join(drops, "\n"))
+	}
+	// Default to no when data may be lost
+	if shouldApply, err := utils.NewConsole().PromptYesNo(ctx, msg, len(drops) == 0); err != nil {
+		return err
+	} else if !shouldApply {
+		return errors.New(context.Canceled)
+	}
+	return ApplyDiff(ctx, out, config, options...)
+}
+
+func ApplyDiff(ctx context.Context, out string, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	m, err := migration.NewMigrationFromReader(strings.NewReader(out))
+	if err != nil {
+		return err
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if err := m.ExecBatch(ctx, conn); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, render.T("finished", utils.Aqua("supabase db apply")))
+	return nil
+}
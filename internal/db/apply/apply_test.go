@@ -0,0 +1,84 @@
+package apply
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/db/diff"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "db.supabase.co",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestApplyCommand(t *testing.T) {
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), nil, "", false, dbConfig, diff.DiffSchemaMigra, fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("throws error on missing schema files", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), nil, "", false, dbConfig, diff.DiffSchemaMigra, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "No schema files found in")
+	})
+
+	t.Run("throws error on empty schemas dir", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, fsys.MkdirAll(utils.SchemasDir, 0755))
+		// Run test
+		err := Run(context.Background(), nil, "", false, dbConfig, diff.DiffSchemaMigra, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "No schema files found in")
+	})
+}
+
+func TestApplyDiff(t *testing.T) {
+	const sql = "create table t(id int)"
+
+	t.Run("applies schema changes", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(sql).
+			Reply("CREATE TABLE")
+		// Run test
+		err := ApplyDiff(context.Background(), sql, dbConfig, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on failure to apply", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(sql).
+			ReplyError(pgerrcode.DuplicateTable, `relation "t" already exists`)
+		// Run test
+		err := ApplyDiff(context.Background(), sql, dbConfig, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, `ERROR: relation "t" already exists (SQLSTATE 42P07)`)
+	})
+}
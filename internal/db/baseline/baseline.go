@@ -0,0 +1,105 @@
+package baseline
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/dump"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/migration/new"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+var (
+	errLocalMigrations  = errors.Errorf("Found existing migrations in %s directory.", utils.MigrationsDir)
+	errRemoteMigrations = errors.New("The remote database already has a migration history.")
+)
+
+func Run(ctx context.Context, schema []string, config pgconn.Config, name string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	// 1. Sanity checks
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if err := assertNoLocalMigrations(fsys); err != nil {
+		return err
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if err := assertNoRemoteMigrations(ctx, conn); err != nil {
+		return err
+	}
+	// 2. Dump current schema as the first migration
+	timestamp := utils.GetCurrentTimestamp()
+	path := new.GetMigrationPath(timestamp, name)
+	if err := utils.RunProgram(ctx, func(p utils.Program, ctx context.Context) error {
+		p.Send(utils.StatusMsg("Dumping schema from remote database..."))
+		return dumpBaseline(ctx, schema, path, conn.Config().Config, fsys)
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Baseline migration written to "+utils.Bold(path))
+	// 3. Mark baseline as applied since the remote schema already exists
+	if err := repair.UpdateMigrationTable(ctx, conn, []string{timestamp}, repair.Applied, false, fsys); err != nil {
+		return err
+	}
+	utils.PrintResult(path)
+	return nil
+}
+
+func assertNoLocalMigrations(fsys afero.Fs) error {
+	versions, err := list.LoadLocalVersions(fsys)
+	if err != nil {
+		return err
+	}
+	if len(versions) > 0 {
+		utils.CmdSuggestion = fmt.Sprintf("Run %s to pull schema changes on top of existing migrations instead.", utils.Aqua("supabase db pull"))
+		return errors.New(errLocalMigrations)
+	}
+	return nil
+}
+
+func assertNoRemoteMigrations(ctx context.Context, conn *pgx.Conn) error {
+	versions, err := migration.ListRemoteMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if len(versions) > 0 {
+		utils.CmdSuggestion = fmt.Sprintf("Run %s to download the existing migrations instead.", utils.Aqua("supabase migration fetch"))
+		return errors.New(errRemoteMigrations)
+	}
+	return nil
+}
+
+func dumpBaseline(ctx context.Context, schema []string, path string, config pgconn.Config, fsys afero.Fs) error {
+	if err := utils.MkdirIfNotExistFS(fsys, utils.MigrationsDir); err != nil {
+		return err
+	}
+	if err := writeDump(ctx, schema, path, config, fsys); err != nil {
+		// Remove partial dump so that baseline can be retried
+		if err := fsys.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+		}
+		return err
+	}
+	return nil
+}
+
+func writeDump(ctx context.Context, schema []string, path string, config pgconn.Config, fsys afero.Fs) error {
+	f, err := fsys.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Errorf("failed to open baseline file: %w", err)
+	}
+	defer f.Close()
+	// Passing nil schema excludes managed schemas from the dump
+	return dump.DumpSchema(ctx, config, schema, false, false, f)
+}
@@ -0,0 +1,74 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+// Diffs the target database against a shadow database created from declarative schema files.
+// The output migrates the target database to the declared state.
+func DiffDeclared(ctx context.Context, schema []string, config pgconn.Config, w io.Writer, fsys afero.Fs, differ DiffFunc, options ...func(*pgx.ConnConfig)) (string, error) {
+	if exists, err := afero.DirExists(fsys, utils.SchemasDir); err != nil {
+		return "", errors.Errorf("failed to check schemas: %w", err)
+	} else if !exists {
+		return "", errors.Errorf("No schema files found in %s", utils.Bold(utils.SchemasDir))
+	}
+	declared, err := loadDeclaredSchemas(fsys)
+	if err != nil {
+		return "", err
+	} else if len(declared) == 0 {
+		return "", errors.Errorf("No schema files found in %s", utils.Bold(utils.SchemasDir))
+	}
+	fmt.Fprintln(w, "Creating shadow database...")
+	shadow, err := CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort)
+	if err != nil {
+		return "", err
+	}
+	defer utils.DockerRemove(shadow)
+	if err := start.WaitForHealthyService(ctx, start.HealthTimeout, shadow); err != nil {
+		return "", err
+	}
+	conn, err := ConnectShadowDatabase(ctx, 10*time.Second, options...)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close(context.Background())
+	if err := start.SetupDatabase(ctx, conn, shadow[:12], w, fsys); err != nil {
+		return "", err
+	}
+	if err := migration.SeedGlobals(ctx, declared, conn, afero.NewIOFS(fsys)); err != nil {
+		return "", err
+	}
+	// Include schemas that exist on either side so removed schemas are dropped
+	if len(schema) == 0 {
+		if schema, err = migration.ListUserSchemas(ctx, conn); err != nil {
+			return "", err
+		}
+		existing, err := loadSchema(ctx, config, options...)
+		if err != nil {
+			return "", err
+		}
+		schema = utils.RemoveDuplicates(append(schema, existing...))
+	}
+	fmt.Fprintln(w, "Diffing schemas:", strings.Join(schema, ","))
+	source := utils.ToPostgresURL(config)
+	target := utils.ToPostgresURL(pgconn.Config{
+		Host:     utils.Config.Hostname,
+		Port:     utils.Config.Db.ShadowPort,
+		User:     "postgres",
+		Password: utils.Config.Db.Password,
+		Database: "postgres",
+	})
+	return differ(ctx, source, target, schema)
+}
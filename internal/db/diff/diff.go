@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/db/start"
 	"github.com/supabase/cli/internal/gen/keys"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/migration"
 	"github.com/supabase/cli/pkg/parser"
@@ -61,11 +62,11 @@ func Run(ctx context.Context, schema []string, file string, config pgconn.Config
 		return err
 	}
 	branch := keys.GetGitBranch(fsys)
-	fmt.Fprintln(os.Stderr, "Finished "+utils.Aqua("supabase db diff")+" on branch "+utils.Aqua(branch)+".\n")
+	fmt.Fprintln(os.Stderr, render.T("finished_on_branch", utils.Aqua("supabase db diff"), utils.Aqua(branch))+"\n")
 	if err := SaveDiff(out, file, fsys); err != nil {
 		return err
 	}
-	drops := findDropStatements(out)
+	drops := FindDropStatements(out)
 	if len(drops) > 0 {
 		fmt.Fprintln(os.Stderr, "Found drop statements in schema diff. Please double check if these are expected:")
 		fmt.Fprintln(os.Stderr, utils.Yellow(strings.Join(drops, "\n")))
@@ -105,7 +106,7 @@ func loadDeclaredSchemas(fsys afero.Fs) ([]string, error) {
 // https://github.com/djrobstep/migra/blob/master/migra/statements.py#L6
 var dropStatementPattern = regexp.MustCompile(`(?i)drop\s+`)
 
-func findDropStatements(out string) []string {
+func FindDropStatements(out string) []string {
 	lines, err := parser.SplitAndTrim(strings.NewReader(out))
 	if err != nil {
 		return nil
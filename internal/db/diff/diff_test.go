@@ -338,6 +338,6 @@ At statement 0: create schema public`)
 }
 
 func TestDropStatements(t *testing.T) {
-	drops := findDropStatements("create table t(); drop table t; alter table t drop column c")
+	drops := FindDropStatements("create table t(); drop table t; alter table t drop column c")
 	assert.Equal(t, []string{"drop table t", "alter table t drop column c"}, drops)
 }
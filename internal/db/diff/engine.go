@@ -0,0 +1,72 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	EngineMigra        = "migra"
+	EnginePgAdmin      = "pgadmin"
+	EnginePgSchemaDiff = "pg-schema-diff"
+)
+
+var Engines = []string{EngineMigra, EnginePgAdmin, EnginePgSchemaDiff}
+
+// Known limitations are printed before diffing so users can pick another engine.
+var engineLimitations = map[string]string{
+	EngineMigra:        "migra does not diff default privileges and may recreate partitioned tables instead of altering them.",
+	EnginePgAdmin:      "pgAdmin only diffs the local database and may emit statements out of dependency order.",
+	EnginePgSchemaDiff: "pg-schema-diff is experimental and may not include all entities, such as RLS policies, enums, and grants.",
+}
+
+// Diffs schema with the named engine. All engines save output the same way,
+// either to a new migration file or to stdout.
+func RunEngine(ctx context.Context, engine string, schema []string, file string, config pgconn.Config, fsys afero.Fs) error {
+	if note, ok := engineLimitations[engine]; ok {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), note)
+	}
+	switch engine {
+	case EngineMigra:
+		differ := DiffSchemaMigra
+		if !utils.IsLocalDatabase(config) {
+			if isSupabase, err := IsSupabaseDatabase(ctx, config); err != nil {
+				return err
+			} else if !isSupabase {
+				fmt.Fprintln(os.Stderr, "Target database is not hosted by Supabase. Skipping privileges because roles differ between hosts.")
+				differ = DiffSchemaMigraWithoutPrivileges
+			}
+		}
+		return Run(ctx, schema, file, config, differ, fsys)
+	case EnginePgAdmin:
+		return RunPgAdmin(ctx, schema, file, config, fsys)
+	case EnginePgSchemaDiff:
+		return Run(ctx, schema, file, config, DiffPgSchema, fsys)
+	}
+	return errors.Errorf("unsupported diff engine: %s", engine)
+}
+
+// Databases hosted by Supabase, including self-hosted ones, are initialised with this role.
+const checkSupabaseRole = "SELECT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'supabase_admin')"
+
+// IsSupabaseDatabase checks if the target database has Supabase roles, such as anon and
+// authenticated, that the shadow database grants privileges to.
+func IsSupabaseDatabase(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) (bool, error) {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+	var exists bool
+	if err := conn.QueryRow(ctx, checkSupabaseRole).Scan(&exists); err != nil {
+		return false, errors.Errorf("failed to check roles: %w", err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,62 @@
+package diff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+func TestRunEngine(t *testing.T) {
+	t.Run("throws error on unknown engine", func(t *testing.T) {
+		err := RunEngine(context.Background(), "apgdiff", nil, "", pgconn.Config{}, afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "unsupported diff engine: apgdiff")
+	})
+
+	t.Run("documents limitations of every engine", func(t *testing.T) {
+		for _, engine := range Engines {
+			assert.NotEmpty(t, engineLimitations[engine], engine)
+		}
+	})
+}
+
+func TestIsSupabaseDatabase(t *testing.T) {
+	t.Run("detects supabase roles", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(checkSupabaseRole).
+			Reply("SELECT 1", []interface{}{true})
+		// Run test
+		isSupabase, err := IsSupabaseDatabase(context.Background(), dbConfig, conn.Intercept)
+		assert.NoError(t, err)
+		assert.True(t, isSupabase)
+	})
+
+	t.Run("detects foreign database", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(checkSupabaseRole).
+			Reply("SELECT 1", []interface{}{false})
+		// Run test
+		isSupabase, err := IsSupabaseDatabase(context.Background(), dbConfig, conn.Intercept)
+		assert.NoError(t, err)
+		assert.False(t, isSupabase)
+	})
+
+	t.Run("throws error on permission denied", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(checkSupabaseRole).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for table pg_roles")
+		// Run test
+		_, err := IsSupabaseDatabase(context.Background(), dbConfig, conn.Intercept)
+		assert.ErrorContains(t, err, "permission denied for table pg_roles")
+	})
+}
@@ -18,7 +18,19 @@ var diffSchemaScript string
 
 // Diffs local database schema against shadow, dumps output to stdout.
 func DiffSchemaMigra(ctx context.Context, source, target string, schema []string) (string, error) {
+	return diffSchemaMigra(ctx, source, target, schema, false)
+}
+
+// Diffs schema without grants, for target databases that don't have Supabase roles.
+func DiffSchemaMigraWithoutPrivileges(ctx context.Context, source, target string, schema []string) (string, error) {
+	return diffSchemaMigra(ctx, source, target, schema, true)
+}
+
+func diffSchemaMigra(ctx context.Context, source, target string, schema []string, skipPrivileges bool) (string, error) {
 	env := []string{"SOURCE=" + source, "TARGET=" + target}
+	if skipPrivileges {
+		env = append(env, "SKIP_PRIVILEGES=true")
+	}
 	// Passing in script string means command line args must be set manually, ie. "$@"
 	args := "set -- " + strings.Join(schema, " ") + ";"
 	cmd := []string{"/bin/sh", "-c", args + diffSchemaScript}
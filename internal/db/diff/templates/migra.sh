@@ -7,7 +7,9 @@ trap 'kill -9 %1' TERM
 run_migra() {
     # additional flags for diffing extensions
     [ "$schema" = "extensions" ] && set -- --create-extensions-only --ignore-extension-versions "$@"
-    migra --with-privileges --unsafe --schema="$schema" "$@"
+    # roles may differ between hosts, so privileges are optional
+    [ "${SKIP_PRIVILEGES:-}" = "true" ] || set -- --with-privileges "$@"
+    migra --unsafe --schema="$schema" "$@"
 }
 
 # accepts command line args as a list of schema to generate
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/docker/docker/api/types/container"
@@ -24,12 +25,59 @@ var (
 	dumpDataScript string
 	//go:embed templates/dump_role.sh
 	dumpRoleScript string
+	//go:embed templates/dump_archive.sh
+	dumpArchiveScript string
 )
 
-func Run(ctx context.Context, path string, config pgconn.Config, schema, excludeTable []string, dataOnly, roleOnly, keepComments, useCopy, dryRun bool, fsys afero.Fs) error {
+const (
+	FormatPlain     = "plain"
+	FormatCustom    = "custom"
+	FormatDirectory = "directory"
+)
+
+// Archive formats are written by pg_dump directly so they can be restored in parallel with pg_restore.
+type ArchiveOptions struct {
+	Format   string
+	Jobs     uint
+	Compress *uint // Nil uses the pg_dump default
+}
+
+func (o ArchiveOptions) IsEnabled() bool {
+	return len(o.Format) > 0 && o.Format != FormatPlain
+}
+
+func (o ArchiveOptions) Validate(path string, roleOnly bool) error {
+	if o.Jobs > 1 && o.Format != FormatDirectory {
+		return errors.New("Parallel jobs require directory format: --format directory")
+	}
+	if o.Compress != nil && *o.Compress > 9 {
+		return errors.Errorf("Compression level must be between 0 and 9: %d", *o.Compress)
+	}
+	if !o.IsEnabled() {
+		if o.Compress != nil {
+			return errors.New("Compression requires custom or directory format.")
+		}
+		return nil
+	}
+	if roleOnly {
+		return errors.New("Roles can only be dumped in plain format.")
+	}
+	if o.Format == FormatDirectory && len(path) == 0 {
+		return errors.New("Directory format requires an output directory: --file <dir>")
+	}
+	return nil
+}
+
+func Run(ctx context.Context, path string, config pgconn.Config, schema, excludeTable []string, dataOnly, roleOnly, keepComments, useCopy, dryRun bool, archive ArchiveOptions, fsys afero.Fs) error {
+	if err := archive.Validate(path, roleOnly); err != nil {
+		return err
+	}
 	// Initialize output stream
 	var outStream afero.File
-	if len(path) > 0 {
+	// Directory archives are written by pg_dump through a bind mount
+	if archive.Format == FormatDirectory {
+		outStream = os.Stdout
+	} else if len(path) > 0 {
 		f, err := fsys.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 		if err != nil {
 			return errors.Errorf("failed to open dump file: %w", err)
@@ -47,12 +95,15 @@ func Run(ctx context.Context, path string, config pgconn.Config, schema, exclude
 	if utils.IsLocalDatabase(config) {
 		db = "local"
 	}
-	if dataOnly {
+	if archive.IsEnabled() {
+		fmt.Fprintf(os.Stderr, "Dumping %s archive from %s database...\n", archive.Format, db)
+		return DumpArchive(ctx, config, schema, excludeTable, dataOnly, path, archive, dryRun, outStream)
+	} else if dataOnly {
 		fmt.Fprintf(os.Stderr, "Dumping data from %s database...\n", db)
-		return dumpData(ctx, config, schema, excludeTable, useCopy, dryRun, outStream)
+		return DumpData(ctx, config, schema, excludeTable, useCopy, dryRun, outStream)
 	} else if roleOnly {
 		fmt.Fprintf(os.Stderr, "Dumping roles from %s database...\n", db)
-		return dumpRole(ctx, config, keepComments, dryRun, outStream)
+		return DumpRole(ctx, config, keepComments, dryRun, outStream)
 	}
 	fmt.Fprintf(os.Stderr, "Dumping schemas from %s database...\n", db)
 	return DumpSchema(ctx, config, schema, keepComments, dryRun, outStream)
@@ -72,44 +123,45 @@ func DumpSchema(ctx context.Context, config pgconn.Config, schema []string, keep
 	return dump(ctx, config, dumpSchemaScript, env, dryRun, stdout)
 }
 
-func dumpData(ctx context.Context, config pgconn.Config, schema, excludeTable []string, useCopy, dryRun bool, stdout io.Writer) error {
-	// We want to dump user data in auth, storage, etc. for migrating to new project
-	excludedSchemas := []string{
-		"information_schema",
-		"pg_*", // Wildcard pattern follows pg_dump
-		// Owned by extensions
-		// "cron",
-		"graphql",
-		"graphql_public",
-		// "net",
-		// "pgsodium",
-		// "pgsodium_masks",
-		"pgtle",
-		"repack",
-		"tiger",
-		"tiger_data",
-		"timescaledb_*",
-		"_timescaledb_*",
-		"topology",
-		// "vault",
-		// Managed by Supabase
-		// "auth",
-		"extensions",
-		"pgbouncer",
-		"realtime",
-		// "storage",
-		// "supabase_functions",
-		"supabase_migrations",
-		// TODO: Remove in a few version in favor of _supabase internal db
-		"_analytics",
-		"_realtime",
-		"_supavisor",
-	}
+// We want to dump user data in auth, storage, etc. for migrating to new project
+var dataExcludedSchemas = []string{
+	"information_schema",
+	"pg_*", // Wildcard pattern follows pg_dump
+	// Owned by extensions
+	// "cron",
+	"graphql",
+	"graphql_public",
+	// "net",
+	// "pgsodium",
+	// "pgsodium_masks",
+	"pgtle",
+	"repack",
+	"tiger",
+	"tiger_data",
+	"timescaledb_*",
+	"_timescaledb_*",
+	"topology",
+	// "vault",
+	// Managed by Supabase
+	// "auth",
+	"extensions",
+	"pgbouncer",
+	"realtime",
+	// "storage",
+	// "supabase_functions",
+	"supabase_migrations",
+	// TODO: Remove in a few version in favor of _supabase internal db
+	"_analytics",
+	"_realtime",
+	"_supavisor",
+}
+
+func DumpData(ctx context.Context, config pgconn.Config, schema, excludeTable []string, useCopy, dryRun bool, stdout io.Writer) error {
 	var env []string
 	if len(schema) > 0 {
 		env = append(env, "INCLUDED_SCHEMAS="+strings.Join(schema, "|"))
 	} else {
-		env = append(env, "INCLUDED_SCHEMAS=*", "EXCLUDED_SCHEMAS="+strings.Join(excludedSchemas, "|"))
+		env = append(env, "INCLUDED_SCHEMAS=*", "EXCLUDED_SCHEMAS="+strings.Join(dataExcludedSchemas, "|"))
 	}
 	var extraFlags []string
 	if !useCopy {
@@ -131,7 +183,7 @@ func quoteUpperCase(table string) string {
 	return fmt.Sprintf(`"%s"`, escaped)
 }
 
-func dumpRole(ctx context.Context, config pgconn.Config, keepComments, dryRun bool, stdout io.Writer) error {
+func DumpRole(ctx context.Context, config pgconn.Config, keepComments, dryRun bool, stdout io.Writer) error {
 	env := []string{}
 	if !keepComments {
 		env = append(env, "EXTRA_SED=/^--/d")
@@ -139,7 +191,47 @@ func dumpRole(ctx context.Context, config pgconn.Config, keepComments, dryRun bo
 	return dump(ctx, config, dumpRoleScript, env, dryRun, stdout)
 }
 
-func dump(ctx context.Context, config pgconn.Config, script string, env []string, dryRun bool, stdout io.Writer) error {
+// Dumps both schema and data unless dataOnly is set. Directory archives are written to path.
+func DumpArchive(ctx context.Context, config pgconn.Config, schema, excludeTable []string, dataOnly bool, path string, opts ArchiveOptions, dryRun bool, stdout io.Writer) error {
+	env := []string{"FORMAT=" + opts.Format}
+	var extraFlags []string
+	if len(schema) > 0 {
+		extraFlags = append(extraFlags, "--schema="+strings.Join(schema, "|"))
+	} else if dataOnly {
+		env = append(env, "EXCLUDED_SCHEMAS="+strings.Join(dataExcludedSchemas, "|"))
+	} else {
+		env = append(env, "EXCLUDED_SCHEMAS="+strings.Join(utils.InternalSchemas, "|"))
+	}
+	if dataOnly {
+		extraFlags = append(extraFlags, "--data-only")
+	}
+	for _, table := range excludeTable {
+		extraFlags = append(extraFlags, "--exclude-table-data "+quoteUpperCase(table))
+	}
+	if opts.Jobs > 1 {
+		extraFlags = append(extraFlags, fmt.Sprintf("--jobs %d", opts.Jobs))
+	}
+	if opts.Compress != nil {
+		extraFlags = append(extraFlags, fmt.Sprintf("--compress %d", *opts.Compress))
+	}
+	var binds []string
+	if opts.Format == FormatDirectory {
+		// Docker requires all host paths to be absolute
+		hostPath, err := filepath.Abs(path)
+		if err != nil {
+			return errors.Errorf("failed to resolve output directory: %w", err)
+		}
+		hostDir := filepath.Dir(hostPath)
+		binds = append(binds, hostDir+":"+utils.ToDockerPath(hostDir)+":rw")
+		extraFlags = append(extraFlags, "--file "+utils.ToDockerPath(hostPath))
+	}
+	if len(extraFlags) > 0 {
+		env = append(env, "EXTRA_FLAGS="+strings.Join(extraFlags, " "))
+	}
+	return dump(ctx, config, dumpArchiveScript, env, dryRun, stdout, binds...)
+}
+
+func dump(ctx context.Context, config pgconn.Config, script string, env []string, dryRun bool, stdout io.Writer, binds ...string) error {
 	allEnvs := append(env,
 		"PGHOST="+config.Host,
 		fmt.Sprintf("PGPORT=%d", config.Port),
@@ -169,14 +261,20 @@ func dump(ctx context.Context, config pgconn.Config, script string, env []string
 		fmt.Println(expanded)
 		return nil
 	}
+	containerConfig := container.Config{
+		Image: cliConfig.Pg15Image,
+		Env:   allEnvs,
+		Cmd:   []string{"bash", "-c", script, "--"},
+	}
+	// Keep bind mounted output owned by the current user, which is unsupported on Windows
+	if uid, gid := os.Getuid(), os.Getgid(); len(binds) > 0 && uid >= 0 {
+		containerConfig.User = fmt.Sprintf("%d:%d", uid, gid)
+	}
 	return utils.DockerRunOnceWithConfig(
 		ctx,
-		container.Config{
-			Image: cliConfig.Pg15Image,
-			Env:   allEnvs,
-			Cmd:   []string{"bash", "-c", script, "--"},
-		},
+		containerConfig,
 		container.HostConfig{
+			Binds:       binds,
 			NetworkMode: network.NetworkHost,
 		},
 		network.NetworkingConfig{},
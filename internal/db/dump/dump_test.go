@@ -2,7 +2,9 @@ package dump
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"os"
 	"testing"
 
 	"github.com/h2non/gock"
@@ -35,7 +37,7 @@ func TestPullCommand(t *testing.T) {
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "hello world"))
 		// Run test
-		err := Run(context.Background(), "schema.sql", dbConfig, nil, nil, false, false, false, false, false, fsys)
+		err := Run(context.Background(), "schema.sql", dbConfig, nil, nil, false, false, false, false, false, ArchiveOptions{}, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -54,7 +56,7 @@ func TestPullCommand(t *testing.T) {
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "hello world\n"))
 		// Run test
-		err := Run(context.Background(), "", dbConfig, []string{"public"}, nil, false, false, false, false, false, fsys)
+		err := Run(context.Background(), "", dbConfig, []string{"public"}, nil, false, false, false, false, false, ArchiveOptions{}, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -70,7 +72,7 @@ func TestPullCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/images").
 			Reply(http.StatusServiceUnavailable)
 		// Run test
-		err := Run(context.Background(), "", dbConfig, nil, nil, false, false, false, false, false, fsys)
+		err := Run(context.Background(), "", dbConfig, nil, nil, false, false, false, false, false, ArchiveOptions{}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "request returned Service Unavailable for API route and version")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -85,9 +87,95 @@ func TestPullCommand(t *testing.T) {
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "hello world\n"))
 		// Run test
-		err := Run(context.Background(), "schema.sql", dbConfig, nil, nil, false, false, false, false, false, fsys)
+		err := Run(context.Background(), "schema.sql", dbConfig, nil, nil, false, false, false, false, false, ArchiveOptions{}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "operation not permitted")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 }
+
+func TestArchiveOptions(t *testing.T) {
+	level := uint(6)
+
+	t.Run("validates plain format", func(t *testing.T) {
+		assert.NoError(t, ArchiveOptions{}.Validate("", true))
+		assert.NoError(t, ArchiveOptions{Format: FormatPlain, Jobs: 1}.Validate("", false))
+	})
+
+	t.Run("validates archive formats", func(t *testing.T) {
+		assert.NoError(t, ArchiveOptions{Format: FormatCustom, Compress: &level}.Validate("", false))
+		assert.NoError(t, ArchiveOptions{Format: FormatDirectory, Jobs: 4}.Validate("dump", false))
+	})
+
+	t.Run("throws error on parallel jobs without directory format", func(t *testing.T) {
+		err := ArchiveOptions{Format: FormatCustom, Jobs: 4}.Validate("dump.backup", false)
+		assert.ErrorContains(t, err, "Parallel jobs require directory format")
+	})
+
+	t.Run("throws error on compression with plain format", func(t *testing.T) {
+		err := ArchiveOptions{Format: FormatPlain, Compress: &level}.Validate("", false)
+		assert.ErrorContains(t, err, "Compression requires custom or directory format.")
+	})
+
+	t.Run("throws error on invalid compression level", func(t *testing.T) {
+		invalid := uint(10)
+		err := ArchiveOptions{Format: FormatCustom, Compress: &invalid}.Validate("", false)
+		assert.ErrorContains(t, err, "Compression level must be between 0 and 9: 10")
+	})
+
+	t.Run("throws error on role archive", func(t *testing.T) {
+		err := ArchiveOptions{Format: FormatCustom}.Validate("", true)
+		assert.ErrorContains(t, err, "Roles can only be dumped in plain format.")
+	})
+
+	t.Run("throws error on missing output directory", func(t *testing.T) {
+		err := ArchiveOptions{Format: FormatDirectory}.Validate("", false)
+		assert.ErrorContains(t, err, "Directory format requires an output directory")
+	})
+}
+
+func TestDumpArchive(t *testing.T) {
+	imageUrl := utils.GetRegistryImageUrl(utils.Config.Db.Image)
+	const containerId = "test-container"
+
+	t.Run("writes custom archive to file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "PGDMP"))
+		// Run test
+		err := Run(context.Background(), "dump.backup", dbConfig, nil, nil, false, false, false, false, false, ArchiveOptions{Format: FormatCustom}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		contents, err := afero.ReadFile(fsys, "dump.backup")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("PGDMP"), contents)
+	})
+
+	t.Run("prints parallel directory dump script", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Capture stdout
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		oldStdout := os.Stdout
+		os.Stdout = w
+		// Run test
+		err = Run(context.Background(), "/tmp/dump", dbConfig, []string{"public"}, nil, false, false, false, false, true, ArchiveOptions{Format: FormatDirectory, Jobs: 4}, fsys)
+		os.Stdout = oldStdout
+		require.NoError(t, w.Close())
+		// Check error
+		assert.NoError(t, err)
+		out, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Contains(t, string(out), `--format "directory"`)
+		assert.Contains(t, string(out), "--schema=public --jobs 4 --file /tmp/dump")
+		exists, err := afero.Exists(fsys, "/tmp/dump")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -0,0 +1,288 @@
+package dump
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Tracks the end of the previous incremental dump. Rows are selected by the timestamp,
+// while an unchanged LSN means nothing was written to the database since.
+type Watermark struct {
+	Timestamp time.Time `json:"timestamp"`
+	Lsn       string    `json:"lsn"`
+}
+
+type IncrementalOptions struct {
+	// Timestamp column that is bumped whenever a row changes, ie. updated_at
+	Column string
+	// Defaults to a file under supabase/.temp keyed by the target database
+	WatermarkPath string
+}
+
+// Rows are usually stamped with the start time of their transaction, so a row committed
+// after our snapshot may carry a timestamp before the watermark. Dumping again from a
+// margin before the watermark catches those rows, while the upserts make the overlap
+// harmless. Transactions running longer than the margin can still be missed.
+const watermarkMargin = 5 * time.Minute
+
+type incrementalTable struct {
+	Schema  string
+	Name    string
+	Columns []string
+	PKey    []string
+}
+
+// Tables with the watermark column, listing columns in order without generated ones.
+const listIncrementalTables = `SELECT
+  n.nspname AS schema,
+  c.relname AS name,
+  array(
+    SELECT a.attname FROM pg_attribute a
+    WHERE a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped AND a.attgenerated = ''
+    ORDER BY a.attnum
+  )::text[] AS columns,
+  array(
+    SELECT a.attname FROM pg_index i
+    JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+    WHERE i.indrelid = c.oid AND i.indisprimary
+    ORDER BY array_position(i.indkey, a.attnum)
+  )::text[] AS pkey
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind IN ('r', 'p')
+  AND NOT c.relispartition
+  AND EXISTS (
+    SELECT 1 FROM pg_attribute a
+    WHERE a.attrelid = c.oid AND a.attname = $1 AND NOT a.attisdropped
+  )
+  AND CASE WHEN cardinality($2::text[]) > 0
+    THEN n.nspname = ANY($2::text[])
+    ELSE NOT n.nspname LIKE ANY($3::text[])
+  END
+ORDER BY 1, 2`
+
+const currentLsn = "SELECT now(), CASE WHEN pg_is_in_recovery() THEN pg_last_wal_replay_lsn() ELSE pg_current_wal_lsn() END::text"
+
+// RunIncremental dumps rows changed since the watermark as upserts, so that nightly backups
+// of large tables only contain recent changes. Deleted rows cannot be detected this way.
+func RunIncremental(ctx context.Context, path string, config pgconn.Config, schema, excludeTable []string, opts IncrementalOptions, dryRun bool, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if len(opts.WatermarkPath) == 0 {
+		opts.WatermarkPath = defaultWatermarkPath(config)
+	}
+	last, err := loadWatermark(opts.WatermarkPath, fsys)
+	if err != nil {
+		return err
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	// Use a single snapshot so that the new watermark covers every dumped row
+	tx, err := conn.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return errors.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(context.Background()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}()
+	var next Watermark
+	if err := tx.QueryRow(ctx, currentLsn).Scan(&next.Timestamp, &next.Lsn); err != nil {
+		return errors.Errorf("failed to read watermark: %w", err)
+	}
+	if last != nil && last.Lsn == next.Lsn {
+		fmt.Fprintln(os.Stderr, "No changes since the last dump at", last.Timestamp.UTC().Format(time.RFC3339))
+		return nil
+	}
+	tables, err := listTables(ctx, tx, opts.Column, schema, excludeTable)
+	if err != nil {
+		return err
+	}
+	since := "the beginning"
+	if last != nil {
+		since = last.Timestamp.UTC().Format(time.RFC3339)
+	}
+	if dryRun {
+		fmt.Fprintf(os.Stderr, "DRY RUN: would dump rows of %d tables changed since %s.\n", len(tables), since)
+		for _, t := range tables {
+			fmt.Fprintln(os.Stderr, " •", t.qualifiedName())
+		}
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "Dumping rows changed since %s...\n", since)
+	// Initialize output stream
+	var outStream io.Writer = os.Stdout
+	if len(path) > 0 {
+		f, err := fsys.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return errors.Errorf("failed to open dump file: %w", err)
+		}
+		defer f.Close()
+		outStream = f
+	}
+	fmt.Fprintln(outStream, "SET session_replication_role = replica;")
+	for _, t := range tables {
+		if err := dumpChangedRows(ctx, conn.PgConn(), t, opts.Column, last, outStream); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(outStream, "RESET session_replication_role;")
+	return saveWatermark(opts.WatermarkPath, next, fsys)
+}
+
+func listTables(ctx context.Context, tx pgx.Tx, column string, schema, excludeTable []string) ([]incrementalTable, error) {
+	rows, err := tx.Query(ctx, listIncrementalTables, column, schema, reset.LikeEscapeSchema(utils.InternalSchemas))
+	if err != nil {
+		return nil, errors.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+	var result []incrementalTable
+	for rows.Next() {
+		var t incrementalTable
+		if err := rows.Scan(&t.Schema, &t.Name, &t.Columns, &t.PKey); err != nil {
+			return nil, errors.Errorf("failed to scan table: %w", err)
+		}
+		if slices.Contains(excludeTable, t.Schema+"."+t.Name) {
+			continue
+		}
+		// Changed rows cannot be merged without a primary key
+		if len(t.PKey) == 0 {
+			fmt.Fprintln(os.Stderr, "Skipping table without primary key:", t.qualifiedName())
+			continue
+		}
+		result = append(result, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to list tables: %w", err)
+	}
+	return result, nil
+}
+
+// Copies changed rows into a temporary table before merging them, which is much faster
+// to restore than one insert statement per row.
+func dumpChangedRows(ctx context.Context, conn *pgconn.PgConn, t incrementalTable, column string, last *Watermark, w io.Writer) error {
+	columns := quoteIdentifiers(t.Columns)
+	query := fmt.Sprintf("COPY (%s) TO STDOUT", selectChangedRows(t, column, last))
+	// Tables without changes are omitted from the output
+	lazy := &lazyWriter{w: w, header: fmt.Sprintf(`CREATE TEMP TABLE incremental_dump (LIKE %s);
+COPY incremental_dump (%s) FROM stdin;
+`, t.qualifiedName(), columns)}
+	tag, err := conn.CopyTo(ctx, lazy, query)
+	if err != nil {
+		return errors.Errorf("failed to dump %s: %w", t.qualifiedName(), err)
+	}
+	if !lazy.written {
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "Dumped %d rows from %s\n", tag.RowsAffected(), t.qualifiedName())
+	_, err = fmt.Fprintf(w, "\\.\n%s\nDROP TABLE incremental_dump;\n", formatUpsert(t))
+	return err
+}
+
+func selectChangedRows(t incrementalTable, column string, last *Watermark) string {
+	query := fmt.Sprintf("SELECT %s FROM %s", quoteIdentifiers(t.Columns), t.qualifiedName())
+	if last != nil {
+		since := last.Timestamp.Add(-watermarkMargin).UTC().Format(time.RFC3339Nano)
+		query += fmt.Sprintf(" WHERE %s >= '%s'::timestamptz", pgx.Identifier{column}.Sanitize(), since)
+	}
+	return query
+}
+
+func formatUpsert(t incrementalTable) string {
+	columns := quoteIdentifiers(t.Columns)
+	sql := fmt.Sprintf("INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE SELECT %s FROM incremental_dump ON CONFLICT (%s) DO ", t.qualifiedName(), columns, columns, quoteIdentifiers(t.PKey))
+	var updates []string
+	for _, c := range t.Columns {
+		if !slices.Contains(t.PKey, c) {
+			name := pgx.Identifier{c}.Sanitize()
+			updates = append(updates, name+" = EXCLUDED."+name)
+		}
+	}
+	if len(updates) == 0 {
+		return sql + "NOTHING;"
+	}
+	return sql + "UPDATE SET " + strings.Join(updates, ", ") + ";"
+}
+
+func (t incrementalTable) qualifiedName() string {
+	return pgx.Identifier{t.Schema, t.Name}.Sanitize()
+}
+
+func quoteIdentifiers(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = pgx.Identifier{n}.Sanitize()
+	}
+	return strings.Join(quoted, ", ")
+}
+
+type lazyWriter struct {
+	w       io.Writer
+	header  string
+	written bool
+}
+
+func (l *lazyWriter) Write(p []byte) (int, error) {
+	if !l.written {
+		if _, err := io.WriteString(l.w, l.header); err != nil {
+			return 0, err
+		}
+		l.written = true
+	}
+	return l.w.Write(p)
+}
+
+// Local, linked and remote databases are tracked separately, ie. dump-watermark-127.0.0.1-54322-postgres.json
+func defaultWatermarkPath(config pgconn.Config) string {
+	target := fmt.Sprintf("%s-%d-%s", config.Host, config.Port, config.Database)
+	target = strings.Map(func(r rune) rune {
+		if r == '.' || r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, target)
+	return filepath.Join(utils.TempDir, "dump-watermark-"+target+".json")
+}
+
+func loadWatermark(path string, fsys afero.Fs) (*Watermark, error) {
+	data, err := afero.ReadFile(fsys, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to read watermark: %w", err)
+	}
+	var result Watermark
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errors.Errorf("failed to parse watermark: %w", err)
+	}
+	return &result, nil
+}
+
+func saveWatermark(path string, watermark Watermark, fsys afero.Fs) error {
+	data, err := json.MarshalIndent(watermark, "", "  ")
+	if err != nil {
+		return errors.Errorf("failed to encode watermark: %w", err)
+	}
+	if err := utils.WriteFile(path, data, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Saved watermark to", utils.Bold(path))
+	return nil
+}
@@ -0,0 +1,121 @@
+package dump
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+func TestRunIncremental(t *testing.T) {
+	watermarkPath := defaultWatermarkPath(dbConfig)
+	last := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	t.Run("skips dump when lsn is unchanged", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, watermarkPath, []byte(`{"timestamp":"2024-06-01T00:00:00Z","lsn":"0/1A2B3C"}`), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin isolation level repeatable read read only").
+			Reply("BEGIN").
+			Query(currentLsn).
+			Reply("SELECT 1", []interface{}{now, "0/1A2B3C"}).
+			Query("rollback").
+			Reply("ROLLBACK")
+		// Run test
+		err := RunIncremental(context.Background(), "schema.sql", dbConfig, nil, nil, IncrementalOptions{Column: "updated_at"}, false, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.Exists(fsys, "schema.sql")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("lists tables with watermark column on dry run", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, watermarkPath, []byte(`{"timestamp":"2024-06-01T00:00:00Z","lsn":"0/1A2B3C"}`), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin isolation level repeatable read read only").
+			Reply("BEGIN").
+			Query(currentLsn).
+			Reply("SELECT 1", []interface{}{now, "0/2B3C4D"}).
+			Query(listIncrementalTables, "updated_at", []string{"public"}, reset.LikeEscapeSchema(utils.InternalSchemas)).
+			Reply("SELECT 2",
+				[]interface{}{"public", "orders", []string{"id", "total", "updated_at"}, []string{"id"}},
+				[]interface{}{"public", "events", []string{"name", "updated_at"}, []string{}},
+			).
+			Query("rollback").
+			Reply("ROLLBACK")
+		// Run test
+		err := RunIncremental(context.Background(), "", dbConfig, []string{"public"}, nil, IncrementalOptions{Column: "updated_at"}, true, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		// Watermark is not advanced on dry run
+		watermark, err := loadWatermark(watermarkPath, fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, last, watermark.Timestamp)
+	})
+
+	t.Run("throws error on malformed watermark", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, watermarkPath, []byte("{"), 0644))
+		// Run test
+		err := RunIncremental(context.Background(), "", dbConfig, nil, nil, IncrementalOptions{}, false, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse watermark")
+	})
+}
+
+func TestSelectChangedRows(t *testing.T) {
+	table := incrementalTable{Schema: "public", Name: "orders", Columns: []string{"id", "updated_at"}, PKey: []string{"id"}}
+
+	t.Run("selects all rows without watermark", func(t *testing.T) {
+		assert.Equal(t, `SELECT "id", "updated_at" FROM "public"."orders"`, selectChangedRows(table, "updated_at", nil))
+	})
+
+	t.Run("overlaps previous dump by safety margin", func(t *testing.T) {
+		last := Watermark{Timestamp: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+		assert.Equal(t, `SELECT "id", "updated_at" FROM "public"."orders" WHERE "updated_at" >= '2024-05-31T23:55:00Z'::timestamptz`, selectChangedRows(table, "updated_at", &last))
+	})
+}
+
+func TestDefaultWatermarkPath(t *testing.T) {
+	t.Run("keys watermark by target database", func(t *testing.T) {
+		local := defaultWatermarkPath(dbConfig)
+		remote := defaultWatermarkPath(pgconn.Config{Host: "db.abcdefghijklmnopqrst.supabase.co", Port: 5432, Database: "postgres"})
+		assert.Equal(t, filepath.Join(utils.TempDir, "dump-watermark-127.0.0.1-5432-postgres.json"), local)
+		assert.Equal(t, filepath.Join(utils.TempDir, "dump-watermark-db.abcdefghijklmnopqrst.supabase.co-5432-postgres.json"), remote)
+	})
+
+	t.Run("sanitizes path separators", func(t *testing.T) {
+		path := defaultWatermarkPath(pgconn.Config{Host: "/var/run/postgresql", Port: 5432, Database: "app"})
+		assert.Equal(t, filepath.Join(utils.TempDir, "dump-watermark-_var_run_postgresql-5432-app.json"), path)
+	})
+}
+
+func TestFormatUpsert(t *testing.T) {
+	t.Run("updates non key columns", func(t *testing.T) {
+		table := incrementalTable{Schema: "public", Name: "Orders", Columns: []string{"id", "total", "updated_at"}, PKey: []string{"id"}}
+		assert.Equal(t, `INSERT INTO "public"."Orders" ("id", "total", "updated_at") OVERRIDING SYSTEM VALUE SELECT "id", "total", "updated_at" FROM incremental_dump ON CONFLICT ("id") DO UPDATE SET "total" = EXCLUDED."total", "updated_at" = EXCLUDED."updated_at";`, formatUpsert(table))
+	})
+
+	t.Run("ignores conflicts when all columns are keys", func(t *testing.T) {
+		table := incrementalTable{Schema: "public", Name: "tags", Columns: []string{"post_id", "tag"}, PKey: []string{"post_id", "tag"}}
+		assert.Equal(t, `INSERT INTO "public"."tags" ("post_id", "tag") OVERRIDING SYSTEM VALUE SELECT "post_id", "tag" FROM incremental_dump ON CONFLICT ("post_id", "tag") DO NOTHING;`, formatUpsert(table))
+	})
+}
@@ -0,0 +1,20 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+export PGHOST="$PGHOST"
+export PGPORT="$PGPORT"
+export PGUSER="$PGUSER"
+export PGPASSWORD="$PGPASSWORD"
+export PGDATABASE="$PGDATABASE"
+
+# Explanation of pg_dump flags:
+#
+#   --format          custom or directory archive for restoring with pg_restore
+#   --exclude-schema  omit internal schemas as they are maintained by platform
+#
+# Archives are binary so they cannot be post-processed with sed like plain dumps.
+pg_dump \
+    --format "$FORMAT" \
+    --quote-all-identifier \
+    --exclude-schema "${EXCLUDED_SCHEMAS:-}" \
+    ${EXTRA_FLAGS:-}
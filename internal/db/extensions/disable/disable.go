@@ -0,0 +1,46 @@
+package disable
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/extensions"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, name string, cascade, asMigration bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if err := extensions.AssertExtensionName(name); err != nil {
+		return err
+	}
+	if !asMigration {
+		msg := fmt.Sprintf("Do you want to disable extension %s?", utils.Aqua(name))
+		if cascade {
+			msg += " Objects that depend on it will also be dropped."
+		}
+		if shouldDrop, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+			return err
+		} else if !shouldDrop {
+			return errors.New(context.Canceled)
+		}
+	}
+	if err := extensions.Apply(ctx, "disable_"+name, DisableStatements(name, cascade), asMigration, config, fsys, options...); err != nil {
+		return err
+	}
+	if !asMigration {
+		fmt.Fprintln(os.Stderr, "Disabled extension:", utils.Aqua(name))
+	}
+	return nil
+}
+
+func DisableStatements(name string, cascade bool) []string {
+	sql := "DROP EXTENSION IF EXISTS " + pgx.Identifier{name}.Sanitize()
+	if cascade {
+		sql += " CASCADE"
+	}
+	return []string{sql}
+}
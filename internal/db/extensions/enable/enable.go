@@ -0,0 +1,41 @@
+package enable
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/extensions"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, name, schema, version string, asMigration bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if err := extensions.AssertExtensionName(name); err != nil {
+		return err
+	}
+	statements := EnableStatements(name, schema, version)
+	if err := extensions.Apply(ctx, "enable_"+name, statements, asMigration, config, fsys, options...); err != nil {
+		return err
+	}
+	if !asMigration {
+		fmt.Fprintln(os.Stderr, "Enabled extension:", utils.Aqua(name))
+	}
+	return nil
+}
+
+func EnableStatements(name, schema, version string) []string {
+	if len(schema) == 0 {
+		schema = extensions.DefaultSchema
+	}
+	sql := fmt.Sprintf("CREATE EXTENSION IF NOT EXISTS %s WITH SCHEMA %s", pgx.Identifier{name}.Sanitize(), pgx.Identifier{schema}.Sanitize())
+	if len(version) > 0 {
+		sql += " VERSION " + utils.QuoteLiteral(version)
+	}
+	return []string{
+		fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", pgx.Identifier{schema}.Sanitize()),
+		sql + " CASCADE",
+	}
+}
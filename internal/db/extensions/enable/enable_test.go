@@ -0,0 +1,76 @@
+package enable
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestEnableExtension(t *testing.T) {
+	t.Run("enables extension on database", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(`CREATE SCHEMA IF NOT EXISTS "extensions"`).
+			Reply("CREATE SCHEMA").
+			Query(`CREATE EXTENSION IF NOT EXISTS "pg_trgm" WITH SCHEMA "extensions" CASCADE`).
+			Reply("CREATE EXTENSION")
+		// Run test
+		err := Run(context.Background(), "pg_trgm", "", "", false, dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("saves statements as migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), "vector", "public", "0.7.0", true, dbConfig, fsys)
+		// Check error
+		assert.NoError(t, err)
+		files, err := afero.ReadDir(fsys, utils.MigrationsDir)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Regexp(t, `^\d{14}_enable_vector\.sql$`, files[0].Name())
+		data, err := afero.ReadFile(fsys, filepath.Join(utils.MigrationsDir, files[0].Name()))
+		require.NoError(t, err)
+		assert.Equal(t, `CREATE SCHEMA IF NOT EXISTS "public";
+CREATE EXTENSION IF NOT EXISTS "vector" WITH SCHEMA "public" VERSION '0.7.0' CASCADE;
+`, string(data))
+	})
+
+	t.Run("throws error on unavailable extension", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(`CREATE SCHEMA IF NOT EXISTS "extensions"`).
+			Reply("CREATE SCHEMA").
+			Query(`CREATE EXTENSION IF NOT EXISTS "missing" WITH SCHEMA "extensions" CASCADE`).
+			ReplyError(pgerrcode.UndefinedFile, `extension "missing" is not available`)
+		// Run test
+		err := Run(context.Background(), "missing", "", "", false, dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, `extension "missing" is not available`)
+	})
+
+	t.Run("throws error on missing name", func(t *testing.T) {
+		err := Run(context.Background(), " ", "", "", true, dbConfig, afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "Extension name must not be empty.")
+	})
+}
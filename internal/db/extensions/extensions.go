@@ -0,0 +1,53 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/new"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+// Supabase installs extensions to a dedicated schema to keep public clean.
+const DefaultSchema = "extensions"
+
+var errMissingName = errors.New("Extension name must not be empty.")
+
+func AssertExtensionName(name string) error {
+	if len(strings.TrimSpace(name)) == 0 {
+		return errors.New(errMissingName)
+	}
+	return nil
+}
+
+// Either saves the statements as a new migration file or executes them on the database.
+func Apply(ctx context.Context, migrationName string, statements []string, asMigration bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if asMigration {
+		path := new.GetMigrationPath(utils.GetCurrentTimestamp(), migrationName)
+		if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+			return err
+		}
+		sql := strings.Join(statements, ";\n") + ";\n"
+		if err := afero.WriteFile(fsys, path, []byte(sql), 0644); err != nil {
+			return errors.Errorf("failed to write migration: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, render.T("created_migration", utils.Bold(path)))
+		return nil
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	m := migration.MigrationFile{Statements: statements}
+	return m.ExecBatch(ctx, conn)
+}
@@ -0,0 +1,79 @@
+package list
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+//go:embed list.sql
+var listExtensionsQuery string
+
+type Extension struct {
+	Name             string `json:"name"`
+	DefaultVersion   string `json:"default_version"`
+	InstalledVersion string `json:"installed_version,omitempty"`
+	Schema           string `json:"schema,omitempty"`
+	Comment          string `json:"comment"`
+}
+
+func Run(ctx context.Context, installedOnly bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	extensions, err := ListExtensions(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if installedOnly {
+		var installed []Extension
+		for _, e := range extensions {
+			if len(e.InstalledVersion) > 0 {
+				installed = append(installed, e)
+			}
+		}
+		extensions = installed
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, extensions)
+	}
+	table := `|NAME|INSTALLED|AVAILABLE|SCHEMA|DESCRIPTION|
+|-|-|-|-|-|
+`
+	for _, e := range extensions {
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%s`|%s|\n", e.Name, e.InstalledVersion, e.DefaultVersion, e.Schema, strings.ReplaceAll(e.Comment, "|", "\\|"))
+	}
+	return list.RenderTable(table)
+}
+
+// Lists available extensions, with installed ones sorted first.
+func ListExtensions(ctx context.Context, conn *pgx.Conn) ([]Extension, error) {
+	rows, err := conn.Query(ctx, listExtensionsQuery)
+	if err != nil {
+		return nil, errors.Errorf("failed to list extensions: %w", err)
+	}
+	defer rows.Close()
+	var result []Extension
+	for rows.Next() {
+		var e Extension
+		if err := rows.Scan(&e.Name, &e.DefaultVersion, &e.InstalledVersion, &e.Schema, &e.Comment); err != nil {
+			return nil, errors.Errorf("failed to scan extension: %w", err)
+		}
+		result = append(result, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to list extensions: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,10 @@
+select
+  a.name,
+  coalesce(a.default_version, ''),
+  coalesce(a.installed_version, ''),
+  coalesce(n.nspname, ''),
+  coalesce(a.comment, '')
+from pg_available_extensions a
+left join pg_extension e on e.extname = a.name
+left join pg_namespace n on n.oid = e.extnamespace
+order by a.installed_version is null, a.name
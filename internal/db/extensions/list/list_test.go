@@ -0,0 +1,51 @@
+package list
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestListExtensions(t *testing.T) {
+	t.Run("lists installed and available extensions", func(t *testing.T) {
+		utils.OutputFormat.Value = utils.OutputPretty
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listExtensionsQuery).
+			Reply("SELECT 2",
+				[]interface{}{"pg_trgm", "1.6", "1.6", "extensions", "text similarity measurement"},
+				[]interface{}{"vector", "0.7.0", "", "", "vector data type"},
+			)
+		// Run test
+		err := Run(context.Background(), false, dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listExtensionsQuery).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for view pg_available_extensions")
+		// Run test
+		err := Run(context.Background(), true, dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "permission denied for view pg_available_extensions")
+	})
+}
@@ -0,0 +1,543 @@
+package fake
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/gen/types"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+const (
+	// Rows per INSERT statement
+	batchSize = 100
+	// Attempts to generate a row that satisfies all unique constraints
+	maxAttempts = 100
+	// Existing rows sampled from tables that are referenced but not generated
+	sampleSize = 1000
+)
+
+func isInteger(c types.Column) bool {
+	return c.Type == "int2" || c.Type == "int4" || c.Type == "int8"
+}
+
+// Spec configures the number of rows per table, ie.
+//
+//	rows = 10
+//	[tables]
+//	"public.profiles" = 100
+//	"audit_logs" = 0
+//
+// Unqualified table names refer to the public schema. Tables that are not listed
+// default to rows, or the --rows flag if rows is omitted.
+type Spec struct {
+	Rows   *uint           `toml:"rows" json:"rows"`
+	Tables map[string]uint `toml:"tables" json:"tables"`
+}
+
+func LoadSpec(path string, fsys afero.Fs) (Spec, error) {
+	var spec Spec
+	contents, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return spec, errors.Errorf("failed to read spec: %w", err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(contents, &spec)
+	} else {
+		_, err = toml.Decode(string(contents), &spec)
+	}
+	if err != nil {
+		return spec, errors.Errorf("failed to parse spec: %w", err)
+	}
+	return spec, nil
+}
+
+// Count returns the number of rows to generate for table t.
+func (s Spec) Count(t types.Table) uint {
+	if n, ok := s.Tables[t.QualifiedName()]; ok {
+		return n
+	}
+	if n, ok := s.Tables[t.Name]; ok && t.Schema == "public" {
+		return n
+	}
+	if s.Rows != nil {
+		return *s.Rows
+	}
+	return 0
+}
+
+// Schema holds the introspected tables and the existing data that generated rows
+// must be consistent with.
+type Schema struct {
+	Tables      []types.Table
+	Enums       []types.Enum
+	Constraints []types.Constraint
+	// Sampled rows of referenced tables, keyed by qualified foreign key name
+	Existing map[string][]map[string]string
+	// Current maximum of unique integer columns, keyed by qualified column name
+	Max map[string]int64
+}
+
+func Run(ctx context.Context, schemas []string, rows uint, specPath, file string, seed int64, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if len(schemas) == 0 {
+		schemas = []string{"public"}
+	}
+	var spec Spec
+	if len(specPath) > 0 {
+		var err error
+		if spec, err = LoadSpec(specPath, fsys); err != nil {
+			return err
+		}
+	}
+	if spec.Rows == nil {
+		spec.Rows = &rows
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	if len(file) == 0 && !utils.IsLocalDatabase(config) {
+		msg := "Do you want to insert fake rows into the remote database?"
+		if shouldInsert, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+			return err
+		} else if !shouldInsert {
+			return errors.New(context.Canceled)
+		}
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	schema, err := Introspect(ctx, conn, schemas, spec)
+	if err != nil {
+		return err
+	}
+	stats, total, err := Generate(schema, spec, seed)
+	if err != nil {
+		return err
+	}
+	if len(file) > 0 {
+		header := fmt.Sprintf("-- Generated by supabase db fake --seed %d\n", seed)
+		if err := utils.WriteFile(file, []byte(header+strings.Join(stats, ";\n")+";\n"), fsys); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d rows to %s (seed %d)\n", total, utils.Bold(file), seed)
+		return nil
+	}
+	m := migration.MigrationFile{Statements: stats}
+	if err := m.ExecBatch(ctx, conn); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Inserted %d rows (seed %d)\n", total, seed)
+	return nil
+}
+
+// Introspect loads base tables, enums and keys from the given schemas, and samples
+// existing data needed to satisfy foreign keys and unique constraints.
+func Introspect(ctx context.Context, conn *pgx.Conn, schemas []string, spec Spec) (Schema, error) {
+	var result Schema
+	introspected, err := types.Introspect(ctx, conn, schemas)
+	if err != nil {
+		return result, err
+	}
+	// Views and foreign tables are not generated
+	for _, t := range introspected.Tables {
+		if t.Kind == types.KindTable {
+			result.Tables = append(result.Tables, t)
+		}
+	}
+	result.Enums = introspected.Enums
+	if err := spec.validate(result.Tables); err != nil {
+		return result, err
+	}
+	if result.Constraints, err = types.IntrospectConstraints(ctx, conn, schemas); err != nil {
+		return result, err
+	}
+	result.Existing = map[string][]map[string]string{}
+	for _, k := range result.Constraints {
+		if k.Type != types.ConstraintForeignKey {
+			continue
+		}
+		if result.isGenerated(k.ForeignSchema+"."+k.ForeignTable, spec) {
+			continue
+		}
+		if result.Existing[foreignKeyName(k)], err = sampleRows(ctx, conn, k.ForeignSchema, k.ForeignTable, k.ForeignColumns); err != nil {
+			return result, err
+		}
+	}
+	result.Max = map[string]int64{}
+	for _, t := range result.Tables {
+		if spec.Count(t) == 0 {
+			continue
+		}
+		for _, c := range t.Columns {
+			if !isInteger(c) || !result.isUnique(c) {
+				continue
+			}
+			var max int64
+			sql := fmt.Sprintf("SELECT coalesce(max(%s), 0)::bigint FROM %s", pgx.Identifier{c.Name}.Sanitize(), pgx.Identifier{t.Schema, t.Name}.Sanitize())
+			if err := conn.QueryRow(ctx, sql).Scan(&max); err != nil {
+				return result, errors.Errorf("failed to query max of %s.%s: %w", t.QualifiedName(), c.Name, err)
+			}
+			result.Max[t.QualifiedName()+"."+c.Name] = max
+		}
+	}
+	return result, nil
+}
+
+func (s Spec) validate(tables []types.Table) error {
+	for name := range s.Tables {
+		if !slices.ContainsFunc(tables, func(t types.Table) bool {
+			return t.QualifiedName() == name || (t.Schema == "public" && t.Name == name)
+		}) {
+			return errors.Errorf("table not found in spec: %s", name)
+		}
+	}
+	return nil
+}
+
+func sampleRows(ctx context.Context, conn *pgx.Conn, schema, table string, columns []string) ([]map[string]string, error) {
+	var values, filters []string
+	for _, c := range columns {
+		ident := pgx.Identifier{c}.Sanitize()
+		values = append(values, ident+"::text")
+		filters = append(filters, ident+" IS NOT NULL")
+	}
+	sql := fmt.Sprintf("SELECT DISTINCT ARRAY[%s] FROM %s WHERE %s LIMIT %d",
+		strings.Join(values, ", "),
+		pgx.Identifier{schema, table}.Sanitize(),
+		strings.Join(filters, " AND "),
+		sampleSize,
+	)
+	rows, err := conn.Query(ctx, sql)
+	if err != nil {
+		return nil, errors.Errorf("failed to sample %s.%s: %w", schema, table, err)
+	}
+	defer rows.Close()
+	var result []map[string]string
+	for rows.Next() {
+		var row []string
+		if err := rows.Scan(&row); err != nil {
+			return nil, errors.Errorf("failed to scan row: %w", err)
+		}
+		sample := map[string]string{}
+		for i, c := range columns {
+			sample[c] = utils.QuoteLiteral(row[i])
+		}
+		result = append(result, sample)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to sample %s.%s: %w", schema, table, err)
+	}
+	return result, nil
+}
+
+func foreignKeyName(k types.Constraint) string {
+	return k.Schema + "." + k.Table + "." + k.Name
+}
+
+func (s Schema) isGenerated(name string, spec Spec) bool {
+	for _, t := range s.Tables {
+		if t.QualifiedName() == name {
+			return spec.Count(t) > 0
+		}
+	}
+	return false
+}
+
+// isUnique returns true if c alone is a primary key or unique constraint.
+func (s Schema) isUnique(c types.Column) bool {
+	for _, k := range s.Constraints {
+		if k.Schema == c.Schema && k.Table == c.Table && len(k.Columns) == 1 && k.Columns[0] == c.Name &&
+			(k.Type == types.ConstraintPrimaryKey || k.Type == types.ConstraintUnique) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Schema) isKey(c types.Column) bool {
+	for _, k := range s.Constraints {
+		if !k.IsKey() {
+			continue
+		}
+		if k.Schema == c.Schema && k.Table == c.Table && slices.Contains(k.Columns, c.Name) {
+			return true
+		}
+		if k.Type == types.ConstraintForeignKey && k.ForeignSchema == c.Schema && k.ForeignTable == c.Table && slices.Contains(k.ForeignColumns, c.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Schema) constraints(t types.Table, kind ...string) []types.Constraint {
+	var result []types.Constraint
+	for _, k := range s.Constraints {
+		if k.Schema == t.Schema && k.Table == t.Name && slices.Contains(kind, k.Type) {
+			result = append(result, k)
+		}
+	}
+	return result
+}
+
+// Sort orders tables so that referenced tables are generated before the tables that
+// reference them. Tables in a reference cycle keep their introspected order.
+func (s Schema) Sort(tables []types.Table) []types.Table {
+	var result []types.Table
+	done := map[string]bool{}
+	pending := slices.Clone(tables)
+	for len(pending) > 0 {
+		var next []types.Table
+		for _, t := range pending {
+			if s.hasPendingParent(t, pending, done) {
+				next = append(next, t)
+				continue
+			}
+			result = append(result, t)
+			done[t.QualifiedName()] = true
+		}
+		if len(next) == len(pending) {
+			// Break the cycle with the first remaining table
+			result = append(result, next[0])
+			done[next[0].QualifiedName()] = true
+			next = next[1:]
+		}
+		pending = next
+	}
+	return result
+}
+
+func (s Schema) hasPendingParent(t types.Table, pending []types.Table, done map[string]bool) bool {
+	for _, k := range s.constraints(t, types.ConstraintForeignKey) {
+		parent := k.ForeignSchema + "." + k.ForeignTable
+		if parent == t.QualifiedName() || done[parent] {
+			continue
+		}
+		if slices.ContainsFunc(pending, func(p types.Table) bool { return p.QualifiedName() == parent }) {
+			return true
+		}
+	}
+	return false
+}
+
+type generator struct {
+	schema Schema
+	rand   *rand.Rand
+	enums  map[string][]string
+	// Generated rows keyed by qualified table name
+	rows map[string][]map[string]string
+	// Next value of unique integer columns
+	counters map[string]int64
+}
+
+// Generate returns the INSERT statements for all tables in spec and the total number of
+// rows. The same schema, spec and seed always produce the same statements.
+func Generate(schema Schema, spec Spec, seed int64) ([]string, int, error) {
+	g := generator{
+		schema:   schema,
+		rand:     newRand(seed),
+		enums:    map[string][]string{},
+		rows:     map[string][]map[string]string{},
+		counters: map[string]int64{},
+	}
+	for _, e := range schema.Enums {
+		g.enums[e.Schema+"."+e.Name] = e.Values
+	}
+	var tables []types.Table
+	for _, t := range schema.Tables {
+		if spec.Count(t) > 0 {
+			tables = append(tables, t)
+		}
+	}
+	var stats []string
+	var total int
+	for _, t := range schema.Sort(tables) {
+		result, err := g.generateTable(t, spec.Count(t))
+		if err != nil {
+			return nil, 0, err
+		}
+		stats = append(stats, result...)
+		total += len(g.rows[t.QualifiedName()])
+	}
+	return stats, total, nil
+}
+
+func (g *generator) generateTable(t types.Table, count uint) ([]string, error) {
+	// Columns with defaults are left to the database unless other rows depend on them
+	var columns []types.Column
+	for _, c := range t.Columns {
+		if c.Generated || ((c.HasDefault || c.Identity) && !g.schema.isKey(c)) {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	if len(columns) == 0 {
+		return nil, nil
+	}
+	name := t.QualifiedName()
+	unique := g.schema.constraints(t, types.ConstraintPrimaryKey, types.ConstraintUnique)
+	seen := make([]map[string]bool, len(unique))
+	for i := range seen {
+		seen[i] = map[string]bool{}
+	}
+	for i := uint(0); i < count; i++ {
+		row, err := g.generateRow(t, columns, unique, seen)
+		if err != nil {
+			return nil, err
+		}
+		g.rows[name] = append(g.rows[name], row)
+	}
+	return g.insertStatements(t, columns), nil
+}
+
+func (g *generator) generateRow(t types.Table, columns []types.Column, unique []types.Constraint, seen []map[string]bool) (map[string]string, error) {
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		row := map[string]string{}
+		for _, k := range g.schema.constraints(t, types.ConstraintForeignKey) {
+			if err := g.reference(t, k, columns, row); err != nil {
+				return nil, err
+			}
+		}
+		for _, c := range columns {
+			if _, ok := row[c.Name]; ok {
+				continue
+			}
+			value, err := g.column(t, c, attempt)
+			if err != nil {
+				return nil, err
+			}
+			row[c.Name] = value
+		}
+		if keys, ok := uniqueKeys(row, unique, seen); ok {
+			for i, key := range keys {
+				if len(key) > 0 {
+					seen[i][key] = true
+				}
+			}
+			return row, nil
+		}
+	}
+	return nil, errors.Errorf("failed to generate unique rows for %s: reduce its row count", t.QualifiedName())
+}
+
+// uniqueKeys returns the composite key of row for each unique constraint, or false if
+// any key was seen before. Keys containing NULL never conflict.
+func uniqueKeys(row map[string]string, unique []types.Constraint, seen []map[string]bool) ([]string, bool) {
+	keys := make([]string, len(unique))
+	for i, k := range unique {
+		var values []string
+		for _, c := range k.Columns {
+			v, ok := row[c]
+			if !ok || v == nullLiteral {
+				values = nil
+				break
+			}
+			values = append(values, v)
+		}
+		if len(values) == 0 {
+			continue
+		}
+		keys[i] = strings.Join(values, ",")
+		if seen[i][keys[i]] {
+			return nil, false
+		}
+	}
+	return keys, true
+}
+
+// reference fills the columns of foreign key k with values from a random parent row.
+func (g *generator) reference(t types.Table, k types.Constraint, columns []types.Column, row map[string]string) error {
+	parent := k.ForeignSchema + "." + k.ForeignTable
+	candidates := append(slices.Clone(g.schema.Existing[foreignKeyName(k)]), g.rows[parent]...)
+	nullable := true
+	for _, name := range k.Columns {
+		i := slices.IndexFunc(columns, func(c types.Column) bool { return c.Name == name })
+		if i < 0 {
+			// Column is left to its default
+			return nil
+		}
+		nullable = nullable && columns[i].Nullable
+	}
+	if len(candidates) == 0 || (nullable && g.rand.Intn(10) == 0) {
+		if !nullable {
+			return errors.Errorf("no rows in %s to reference from %s: generate or insert rows into %s first", parent, t.QualifiedName(), parent)
+		}
+		for _, name := range k.Columns {
+			row[name] = nullLiteral
+		}
+		return nil
+	}
+	match := candidates[g.rand.Intn(len(candidates))]
+	for i, name := range k.Columns {
+		row[name] = match[k.ForeignColumns[i]]
+	}
+	return nil
+}
+
+func (g *generator) column(t types.Table, c types.Column, attempt int) (string, error) {
+	unique := g.schema.isUnique(c)
+	if unique && isInteger(c) {
+		key := t.QualifiedName() + "." + c.Name
+		if _, ok := g.counters[key]; !ok {
+			g.counters[key] = g.schema.Max[key] + 1
+		}
+		next := g.counters[key]
+		g.counters[key]++
+		return strconv.FormatInt(next, 10), nil
+	}
+	if c.Nullable && !unique && !g.schema.isKey(c) && g.rand.Intn(10) == 0 {
+		return nullLiteral, nil
+	}
+	return g.value(c, attempt)
+}
+
+func (g *generator) insertStatements(t types.Table, columns []types.Column) []string {
+	table := pgx.Identifier{t.Schema, t.Name}.Sanitize()
+	var names []string
+	overriding := ""
+	for _, c := range columns {
+		names = append(names, pgx.Identifier{c.Name}.Sanitize())
+		if c.Identity {
+			overriding = " OVERRIDING SYSTEM VALUE"
+		}
+	}
+	var result []string
+	rows := g.rows[t.QualifiedName()]
+	for start := 0; start < len(rows); start += batchSize {
+		end := min(start+batchSize, len(rows))
+		var values []string
+		for _, row := range rows[start:end] {
+			var literals []string
+			for _, c := range columns {
+				literals = append(literals, row[c.Name])
+			}
+			values = append(values, "("+strings.Join(literals, ", ")+")")
+		}
+		result = append(result, fmt.Sprintf("INSERT INTO %s (%s)%s VALUES\n  %s", table, strings.Join(names, ", "), overriding, strings.Join(values, ",\n  ")))
+	}
+	// Explicit values bypass sequences, which must be advanced to avoid conflicts later
+	for _, c := range columns {
+		if !isInteger(c) || !(c.HasDefault || c.Identity) || !g.schema.isUnique(c) {
+			continue
+		}
+		column := pgx.Identifier{c.Name}.Sanitize()
+		result = append(result, fmt.Sprintf("SELECT setval(pg_get_serial_sequence(%s, %s), (SELECT max(%s) FROM %s))", utils.QuoteLiteral(table), utils.QuoteLiteral(c.Name), column, table))
+	}
+	return result
+}
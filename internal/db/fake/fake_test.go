@@ -0,0 +1,229 @@
+package fake
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/gen/types"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var schema = Schema{
+	Tables: []types.Table{{
+		Schema: "public",
+		Name:   "posts",
+		Columns: []types.Column{
+			{Schema: "public", Table: "posts", Name: "id", Type: "int8", Identity: true},
+			{Schema: "public", Table: "posts", Name: "author_id", Type: "uuid"},
+			{Schema: "public", Table: "posts", Name: "status", TypeSchema: "public", Type: "post_status"},
+			{Schema: "public", Table: "posts", Name: "created_at", Type: "timestamptz", HasDefault: true},
+		},
+	}, {
+		Schema: "public",
+		Name:   "profiles",
+		Columns: []types.Column{
+			{Schema: "public", Table: "profiles", Name: "id", Type: "uuid"},
+			{Schema: "public", Table: "profiles", Name: "email", Type: "varchar", MaxLength: 64},
+			{Schema: "public", Table: "profiles", Name: "bio", Type: "text", Nullable: true},
+		},
+	}},
+	Enums: []types.Enum{{Schema: "public", Name: "post_status", Values: []string{"draft", "published"}}},
+	Constraints: []types.Constraint{
+		{Schema: "public", Table: "posts", Name: "posts_pkey", Type: types.ConstraintPrimaryKey, Columns: []string{"id"}},
+		{Schema: "public", Table: "posts", Name: "posts_author_id_fkey", Type: types.ConstraintForeignKey, Columns: []string{"author_id"}, ForeignSchema: "public", ForeignTable: "profiles", ForeignColumns: []string{"id"}},
+		{Schema: "public", Table: "profiles", Name: "profiles_pkey", Type: types.ConstraintPrimaryKey, Columns: []string{"id"}},
+		{Schema: "public", Table: "profiles", Name: "profiles_email_key", Type: types.ConstraintUnique, Columns: []string{"email"}},
+		{Schema: "public", Table: "profiles", Name: "profiles_id_fkey", Type: types.ConstraintForeignKey, Columns: []string{"id"}, ForeignSchema: "auth", ForeignTable: "users", ForeignColumns: []string{"id"}},
+	},
+	Existing: map[string][]map[string]string{
+		"public.profiles.profiles_id_fkey": {
+			{"id": "'a0d6ec4b-6c4b-4a3e-9e2f-3c1b1d8f0001'"},
+			{"id": "'a0d6ec4b-6c4b-4a3e-9e2f-3c1b1d8f0002'"},
+		},
+	},
+	Max: map[string]int64{"public.posts.id": 10},
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("generates rows in dependency order", func(t *testing.T) {
+		spec := Spec{Tables: map[string]uint{"profiles": 2, "public.posts": 5}}
+		// Run test
+		stats, total, err := Generate(schema, spec, 1)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, 7, total)
+		require.Len(t, stats, 3)
+		assert.True(t, strings.HasPrefix(stats[0], `INSERT INTO "public"."profiles" ("id", "email", "bio") VALUES`))
+		assert.True(t, strings.HasPrefix(stats[1], `INSERT INTO "public"."posts" ("id", "author_id", "status") OVERRIDING SYSTEM VALUE VALUES`))
+		assert.Contains(t, stats[1], "(11, '")
+		assert.Contains(t, stats[1], "(15, '")
+		assert.Equal(t, `SELECT setval(pg_get_serial_sequence('"public"."posts"', 'id'), (SELECT max("id") FROM "public"."posts"))`, stats[2])
+		// Foreign keys reference existing rows
+		for _, id := range regexp.MustCompile(`'[0-9a-f-]{36}'`).FindAllString(stats[0], -1) {
+			assert.Contains(t, []string{"'a0d6ec4b-6c4b-4a3e-9e2f-3c1b1d8f0001'", "'a0d6ec4b-6c4b-4a3e-9e2f-3c1b1d8f0002'"}, id)
+		}
+		// Enums are respected
+		assert.Regexp(t, `'(draft|published)'\)`, stats[1])
+	})
+
+	t.Run("produces the same rows for the same seed", func(t *testing.T) {
+		rows := uint(2)
+		spec := Spec{Rows: &rows}
+		// Run test
+		first, _, err := Generate(schema, spec, 42)
+		assert.NoError(t, err)
+		second, _, err := Generate(schema, spec, 42)
+		assert.NoError(t, err)
+		// Check output
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("throws error on exhausted unique references", func(t *testing.T) {
+		spec := Spec{Tables: map[string]uint{"profiles": 3}}
+		// Run test
+		_, _, err := Generate(schema, spec, 1)
+		// Check error
+		assert.ErrorContains(t, err, "failed to generate unique rows for public.profiles")
+	})
+
+	t.Run("throws error on missing parent rows", func(t *testing.T) {
+		spec := Spec{Tables: map[string]uint{"posts": 1}}
+		// Run test
+		_, _, err := Generate(schema, spec, 1)
+		// Check error
+		assert.ErrorContains(t, err, "no rows in public.profiles to reference from public.posts")
+	})
+}
+
+func TestLoadSpec(t *testing.T) {
+	t.Run("loads toml spec", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "fake.toml", []byte(`rows = 5
+[tables]
+"public.posts" = 100
+`), 0644))
+		// Run test
+		spec, err := LoadSpec("fake.toml", fsys)
+		// Check error
+		assert.NoError(t, err)
+		rows := uint(5)
+		assert.Equal(t, Spec{Rows: &rows, Tables: map[string]uint{"public.posts": 100}}, spec)
+	})
+
+	t.Run("loads json spec", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "fake.json", []byte(`{"tables": {"posts": 0}}`), 0644))
+		// Run test
+		spec, err := LoadSpec("fake.json", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Nil(t, spec.Rows)
+		assert.Equal(t, uint(0), spec.Count(schema.Tables[0]))
+	})
+
+	t.Run("throws error on invalid spec", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "fake.json", []byte(`{"rows": "many"}`), 0644))
+		// Run test
+		_, err := LoadSpec("fake.json", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse spec:")
+	})
+}
+
+func TestRun(t *testing.T) {
+	dbConfig := pgconn.Config{
+		Host:     "127.0.0.1",
+		Port:     5432,
+		User:     "admin",
+		Password: "password",
+		Database: "postgres",
+	}
+	schemas := []string{"public"}
+
+	t.Run("writes seed file", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(types.ColumnsQuery, schemas).
+			Reply("SELECT 2",
+				types.Column{Schema: "public", Table: "tag_names", Name: "name", Type: "text", Nullable: true, Kind: types.KindView},
+				types.Column{Schema: "public", Table: "tags", Name: "id", Type: "int4", HasDefault: true, Kind: types.KindTable},
+			).
+			Query(types.EnumsQuery, schemas).
+			Reply("SELECT 0").
+			Query(types.ConstraintsQuery, schemas).
+			Reply("SELECT 1", types.Constraint{Schema: "public", Table: "tags", Name: "tags_pkey", Type: "p", Columns: []string{"id"}, ForeignColumns: []string{}}).
+			Query(`SELECT coalesce(max("id"), 0)::bigint FROM "public"."tags"`).
+			Reply("SELECT 1", []interface{}{int64(3)})
+		// Run test
+		err := Run(context.Background(), schemas, 2, "", "supabase/seed.sql", 1, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, "supabase/seed.sql")
+		assert.NoError(t, err)
+		assert.Equal(t, `-- Generated by supabase db fake --seed 1
+INSERT INTO "public"."tags" ("id") VALUES
+  (4),
+  (5);
+SELECT setval(pg_get_serial_sequence('"public"."tags"', 'id'), (SELECT max("id") FROM "public"."tags"));
+`, string(contents))
+	})
+
+	t.Run("inserts rows into remote database after confirmation", func(t *testing.T) {
+		t.Cleanup(fstest.MockStdin(t, "y"))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(types.ColumnsQuery, schemas).
+			Reply("SELECT 1", types.Column{Schema: "public", Table: "tags", Name: "id", Type: "int4", HasDefault: true, Kind: types.KindTable}).
+			Query(types.EnumsQuery, schemas).
+			Reply("SELECT 0").
+			Query(types.ConstraintsQuery, schemas).
+			Reply("SELECT 1", types.Constraint{Schema: "public", Table: "tags", Name: "tags_pkey", Type: "p", Columns: []string{"id"}, ForeignColumns: []string{}}).
+			Query(`SELECT coalesce(max("id"), 0)::bigint FROM "public"."tags"`).
+			Reply("SELECT 1", []interface{}{int64(3)}).
+			Query("INSERT INTO \"public\".\"tags\" (\"id\") VALUES\n  (4),\n  (5)").
+			Reply("INSERT 0 2").
+			Query(`SELECT setval(pg_get_serial_sequence('"public"."tags"', 'id'), (SELECT max("id") FROM "public"."tags"))`).
+			Reply("SELECT 1", []interface{}{int64(5)})
+		// Run test
+		err := Run(context.Background(), schemas, 2, "", "", 1, dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on remote insert without confirmation", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), schemas, 2, "", "", 1, dbConfig, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("throws error on unknown table in spec", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "fake.toml", []byte(`[tables]
+missing = 1
+`), 0644))
+		t.Cleanup(fstest.MockStdin(t, "y"))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(types.ColumnsQuery, schemas).
+			Reply("SELECT 0").
+			Query(types.EnumsQuery, schemas).
+			Reply("SELECT 0")
+		// Run test
+		err := Run(context.Background(), schemas, 10, "fake.toml", "", 1, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "table not found in spec: missing")
+	})
+}
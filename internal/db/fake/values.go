@@ -0,0 +1,143 @@
+package fake
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/google/uuid"
+	"github.com/supabase/cli/internal/gen/types"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const nullLiteral = "NULL"
+
+var (
+	firstNames = []string{"Alice", "Bob", "Carol", "Dan", "Erin", "Frank", "Grace", "Heidi", "Ivan", "Judy", "Mallory", "Niaj", "Olivia", "Peggy", "Rupert", "Sybil", "Trent", "Victor", "Walter", "Yuki"}
+	lastNames  = []string{"Anderson", "Brown", "Chen", "Davis", "Evans", "Garcia", "Hughes", "Ito", "Johnson", "Kim", "Lopez", "Miller", "Nguyen", "Okafor", "Patel", "Rossi", "Smith", "Taylor", "Wilson", "Zhang"}
+	cities     = []string{"Amsterdam", "Berlin", "Buenos Aires", "Cape Town", "Lisbon", "London", "Melbourne", "Mumbai", "New York", "Paris", "Seoul", "Singapore", "Tokyo", "Toronto"}
+	countries  = []string{"Argentina", "Australia", "Canada", "France", "Germany", "India", "Japan", "Netherlands", "Portugal", "Singapore", "South Africa", "South Korea", "United Kingdom", "United States"}
+	streets    = []string{"Main St", "High St", "Oak Ave", "Park Rd", "Church Ln", "Station Rd", "Mill Way", "River Dr"}
+	words      = []string{"alpha", "bravo", "cloud", "delta", "ember", "field", "garden", "harbor", "island", "jungle", "kernel", "lemon", "meadow", "north", "ocean", "pixel", "quartz", "river", "summit", "timber", "urban", "valley", "willow", "yonder", "zenith"}
+)
+
+// Fake values are anchored to a fixed date so that the same seed always produces
+// the same output.
+var referenceTime = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// value generates a SQL literal for column c. A positive salt is mixed into text values
+// to resolve collisions on unique columns.
+func (g *generator) value(c types.Column, salt int) (string, error) {
+	if c.IsArray() {
+		return utils.QuoteLiteral("{}"), nil
+	}
+	if values, ok := g.enums[c.TypeSchema+"."+c.Type]; ok && len(values) > 0 {
+		return utils.QuoteLiteral(values[g.rand.Intn(len(values))]), nil
+	}
+	switch c.Type {
+	case "int2":
+		return strconv.Itoa(g.rand.Intn(1000)), nil
+	case "int4", "int8":
+		return strconv.Itoa(g.rand.Intn(100000) + 1), nil
+	case "numeric", "float4", "float8", "money":
+		return fmt.Sprintf("%.2f", g.rand.Float64()*1000), nil
+	case "bool":
+		return strconv.FormatBool(g.rand.Intn(2) == 0), nil
+	case "uuid":
+		id, err := uuid.NewRandomFromReader(g.rand)
+		if err != nil {
+			return "", errors.Errorf("failed to generate uuid: %w", err)
+		}
+		return utils.QuoteLiteral(id.String()), nil
+	case "date":
+		return utils.QuoteLiteral(g.pastTime().Format(time.DateOnly)), nil
+	case "timestamp", "timestamptz":
+		return utils.QuoteLiteral(g.pastTime().Format(time.RFC3339)), nil
+	case "time", "timetz":
+		return utils.QuoteLiteral(g.pastTime().Format(time.TimeOnly)), nil
+	case "interval":
+		return utils.QuoteLiteral(fmt.Sprintf("%d days", g.rand.Intn(365)+1)), nil
+	case "json", "jsonb":
+		return utils.QuoteLiteral("{}"), nil
+	case "bytea":
+		buf := make([]byte, 8)
+		_, _ = g.rand.Read(buf)
+		return utils.QuoteLiteral(fmt.Sprintf(`\x%x`, buf)), nil
+	case "inet", "cidr":
+		return utils.QuoteLiteral(fmt.Sprintf("10.%d.%d.%d", g.rand.Intn(256), g.rand.Intn(256), g.rand.Intn(254)+1)), nil
+	case "text", "varchar", "bpchar", "citext", "name":
+		text := g.text(strings.ToLower(c.Name), salt)
+		if c.MaxLength > 0 && len(text) > c.MaxLength {
+			text = text[len(text)-c.MaxLength:]
+		}
+		return utils.QuoteLiteral(text), nil
+	}
+	if c.Nullable {
+		return nullLiteral, nil
+	}
+	return "", errors.Errorf("unsupported type %s for column %s.%s.%s", c.Type, c.Schema, c.Table, c.Name)
+}
+
+// text guesses a realistic value from the column name, falling back to random words.
+func (g *generator) text(name string, salt int) string {
+	first := g.pick(firstNames)
+	last := g.pick(lastNames)
+	suffix := ""
+	if salt > 0 {
+		suffix = strconv.Itoa(salt)
+	}
+	switch {
+	case strings.Contains(name, "email"):
+		return strings.ToLower(first+"."+last) + suffix + "@example.com"
+	case strings.Contains(name, "first_name"):
+		return first + suffix
+	case strings.Contains(name, "last_name"), strings.Contains(name, "surname"):
+		return last + suffix
+	case strings.Contains(name, "username"), strings.Contains(name, "handle"):
+		return strings.ToLower(first) + strconv.Itoa(g.rand.Intn(1000)) + suffix
+	case strings.Contains(name, "url"), strings.Contains(name, "website"), strings.Contains(name, "avatar"):
+		return "https://example.com/" + g.pick(words) + "/" + strconv.Itoa(g.rand.Intn(10000)) + suffix
+	case strings.Contains(name, "phone"):
+		return fmt.Sprintf("+1 555 %03d %04d", g.rand.Intn(1000), g.rand.Intn(10000)) + suffix
+	case strings.Contains(name, "city"):
+		return g.pick(cities) + suffix
+	case strings.Contains(name, "country"):
+		return g.pick(countries) + suffix
+	case strings.Contains(name, "address"), strings.Contains(name, "street"):
+		return fmt.Sprintf("%d %s", g.rand.Intn(999)+1, g.pick(streets)) + suffix
+	case strings.Contains(name, "zip"), strings.Contains(name, "postal"):
+		return fmt.Sprintf("%05d", g.rand.Intn(100000)) + suffix
+	case strings.Contains(name, "slug"):
+		return g.pick(words) + "-" + g.pick(words) + suffix
+	case strings.Contains(name, "color"), strings.Contains(name, "colour"):
+		return fmt.Sprintf("#%06x", g.rand.Intn(1<<24)) + suffix
+	case strings.Contains(name, "name"):
+		return first + " " + last + suffix
+	case strings.Contains(name, "description"), strings.Contains(name, "bio"), strings.Contains(name, "content"), strings.Contains(name, "body"):
+		return g.sentence(8+g.rand.Intn(8)) + suffix
+	}
+	return g.sentence(1+g.rand.Intn(3)) + suffix
+}
+
+func (g *generator) sentence(n int) string {
+	result := make([]string, n)
+	for i := range result {
+		result[i] = g.pick(words)
+	}
+	return strings.Join(result, " ")
+}
+
+func (g *generator) pick(values []string) string {
+	return values[g.rand.Intn(len(values))]
+}
+
+func (g *generator) pastTime() time.Time {
+	return referenceTime.Add(-time.Duration(g.rand.Int63n(int64(365 * 24 * time.Hour)))).Truncate(time.Second)
+}
+
+func newRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
@@ -0,0 +1,167 @@
+package audit
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	IssueRlsDisabled = "rls_disabled"
+	IssueNoPolicies  = "no_policies"
+	IssueMissingRole = "missing_role"
+)
+
+var (
+	//go:embed templates/tables.sql
+	listTables string
+	//go:embed templates/policies.sql
+	listPolicies string
+	// Matches role checks in policy expressions, ie. auth.role() = 'authenticated'::text
+	roleCheckPattern = regexp.MustCompile(`(?:auth\.role\(\)|current_role|current_user)\s*=\s*'([^']+)'`)
+)
+
+type Issue struct {
+	Schema string `json:"schema"`
+	Table  string `json:"table"`
+	Policy string `json:"policy,omitempty"`
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+}
+
+type tableInfo struct {
+	schema      string
+	name        string
+	rlsEnabled  bool
+	policyCount int64
+}
+
+type policyInfo struct {
+	schema     string
+	table      string
+	name       string
+	roles      []string
+	expression string
+}
+
+func Run(ctx context.Context, schema []string, failOnMissing bool, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	if len(schema) == 0 {
+		schema = utils.RemoveDuplicates(append([]string{"public"}, utils.Config.Api.Schemas...))
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	issues, err := AuditPolicies(ctx, conn, schema)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Fprintln(os.Stderr, "All tables in schemas", strings.Join(schema, ", "), "are covered by RLS policies.")
+		return nil
+	}
+	if err := printIssues(issues); err != nil {
+		return err
+	}
+	if failOnMissing {
+		return errors.Errorf("found %d RLS coverage issues", len(issues))
+	}
+	return nil
+}
+
+func AuditPolicies(ctx context.Context, conn *pgx.Conn, schema []string) ([]Issue, error) {
+	tables, err := queryTables(ctx, conn, schema)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := queryPolicies(ctx, conn, schema)
+	if err != nil {
+		return nil, err
+	}
+	var roles []string
+	if err := conn.QueryRow(ctx, "SELECT array_agg(rolname) FROM pg_roles").Scan(&roles); err != nil {
+		return nil, errors.Errorf("failed to list roles: %w", err)
+	}
+	var issues []Issue
+	for _, t := range tables {
+		if !t.rlsEnabled {
+			issues = append(issues, Issue{Schema: t.schema, Table: t.name, Type: IssueRlsDisabled, Detail: "Row level security is disabled"})
+		} else if t.policyCount == 0 {
+			issues = append(issues, Issue{Schema: t.schema, Table: t.name, Type: IssueNoPolicies, Detail: "RLS is enabled but no policies grant access"})
+		}
+	}
+	for _, p := range policies {
+		referenced := slices.Clone(p.roles)
+		for _, m := range roleCheckPattern.FindAllStringSubmatch(p.expression, -1) {
+			referenced = append(referenced, m[1])
+		}
+		for _, r := range utils.RemoveDuplicates(referenced) {
+			if r != "public" && !slices.Contains(roles, r) {
+				issues = append(issues, Issue{Schema: p.schema, Table: p.table, Policy: p.name, Type: IssueMissingRole, Detail: fmt.Sprintf("Role %q does not exist", r)})
+			}
+		}
+	}
+	return issues, nil
+}
+
+func queryTables(ctx context.Context, conn *pgx.Conn, schema []string) ([]tableInfo, error) {
+	rows, err := conn.Query(ctx, listTables, schema)
+	if err != nil {
+		return nil, errors.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+	var result []tableInfo
+	for rows.Next() {
+		var t tableInfo
+		if err := rows.Scan(&t.schema, &t.name, &t.rlsEnabled, &t.policyCount); err != nil {
+			return nil, errors.Errorf("failed to scan table: %w", err)
+		}
+		result = append(result, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to list tables: %w", err)
+	}
+	return result, nil
+}
+
+func queryPolicies(ctx context.Context, conn *pgx.Conn, schema []string) ([]policyInfo, error) {
+	rows, err := conn.Query(ctx, listPolicies, schema)
+	if err != nil {
+		return nil, errors.Errorf("failed to list policies: %w", err)
+	}
+	defer rows.Close()
+	var result []policyInfo
+	for rows.Next() {
+		var p policyInfo
+		if err := rows.Scan(&p.schema, &p.table, &p.name, &p.roles, &p.expression); err != nil {
+			return nil, errors.Errorf("failed to scan policy: %w", err)
+		}
+		result = append(result, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to list policies: %w", err)
+	}
+	return result, nil
+}
+
+func printIssues(issues []Issue) error {
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeRows(utils.OutputFormat.Value, os.Stdout, issues)
+	}
+	table := "|SCHEMA|TABLE|POLICY|ISSUE|\n|-|-|-|-|\n"
+	for _, i := range issues {
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|%s|\n", i.Schema, i.Table, i.Policy, i.Detail)
+	}
+	return list.RenderTable(table)
+}
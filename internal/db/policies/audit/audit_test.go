@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestAuditPolicies(t *testing.T) {
+	schema := []string{"public"}
+
+	t.Run("reports uncovered tables and missing roles", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listTables, schema).
+			Reply("SELECT 3",
+				[]interface{}{"public", "countries", false, int64(0)},
+				[]interface{}{"public", "profiles", true, int64(1)},
+				[]interface{}{"public", "secrets", true, int64(0)},
+			).
+			Query(listPolicies, schema).
+			Reply("SELECT 1",
+				[]interface{}{"public", "profiles", "owner access", []string{"authenticated", "editor"}, "(auth.role() = 'moderator'::text)"},
+			).
+			Query("SELECT array_agg(rolname) FROM pg_roles").
+			Reply("SELECT 1", []interface{}{[]string{"postgres", "anon", "authenticated"}})
+		// Run test
+		issues, err := AuditPolicies(context.Background(), conn.MockClient(t), schema)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Issue{
+			{Schema: "public", Table: "countries", Type: IssueRlsDisabled, Detail: "Row level security is disabled"},
+			{Schema: "public", Table: "secrets", Type: IssueNoPolicies, Detail: "RLS is enabled but no policies grant access"},
+			{Schema: "public", Table: "profiles", Policy: "owner access", Type: IssueMissingRole, Detail: `Role "editor" does not exist`},
+			{Schema: "public", Table: "profiles", Policy: "owner access", Type: IssueMissingRole, Detail: `Role "moderator" does not exist`},
+		}, issues)
+	})
+
+	t.Run("fails on missing coverage", func(t *testing.T) {
+		utils.OutputFormat.Value = utils.OutputJson
+		t.Cleanup(func() { utils.OutputFormat.Value = utils.OutputPretty })
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listTables, schema).
+			Reply("SELECT 1", []interface{}{"public", "countries", false, int64(0)}).
+			Query(listPolicies, schema).
+			Reply("SELECT 0").
+			Query("SELECT array_agg(rolname) FROM pg_roles").
+			Reply("SELECT 1", []interface{}{[]string{"postgres"}})
+		// Run test
+		err := Run(context.Background(), schema, true, dbConfig, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "found 1 RLS coverage issues")
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listTables, schema).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for table pg_class")
+		// Run test
+		err := Run(context.Background(), schema, false, dbConfig, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "failed to list tables:")
+	})
+}
@@ -0,0 +1,4 @@
+SELECT p.schemaname, p.tablename, p.policyname, p.roles::text[], concat_ws(' ', p.qual, p.with_check)
+FROM pg_policies p
+WHERE p.schemaname = ANY($1)
+ORDER BY p.schemaname, p.tablename, p.policyname
@@ -0,0 +1,7 @@
+SELECT n.nspname, c.relname, c.relrowsecurity, count(p.oid)
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN pg_policy p ON p.polrelid = c.oid
+WHERE c.relkind IN ('r', 'p') AND n.nspname = ANY($1)
+GROUP BY n.nspname, c.relname, c.relrowsecurity
+ORDER BY n.nspname, c.relname
@@ -0,0 +1,158 @@
+package psql
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var lookPath = exec.LookPath
+
+func Run(ctx context.Context, command, file string, args []string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if len(command) > 0 && len(file) > 0 {
+		return errors.New("Only one of --command or --file may be specified.")
+	}
+	if psql, err := lookPath("psql"); err == nil {
+		return runPsql(ctx, psql, command, file, args, config)
+	}
+	fmt.Fprintln(os.Stderr, "psql is not installed, falling back to the built-in SQL shell.")
+	if len(file) > 0 {
+		contents, err := afero.ReadFile(fsys, file)
+		if err != nil {
+			return errors.Errorf("failed to read file: %w", err)
+		}
+		command = string(contents)
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if len(command) > 0 {
+		return ExecQuery(ctx, conn.PgConn(), command, os.Stdout)
+	}
+	return Repl(ctx, conn, os.Stdin, os.Stdout)
+}
+
+func runPsql(ctx context.Context, psql, command, file string, args []string, config pgconn.Config) error {
+	cmd, err := newPsqlCmd(ctx, psql, command, file, args, config)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Errorf("failed to run psql: %w", err)
+	}
+	return nil
+}
+
+func newPsqlCmd(ctx context.Context, psql, command, file string, args []string, config pgconn.Config) (*exec.Cmd, error) {
+	dbUrl, err := url.Parse(utils.ToPostgresURL(config))
+	if err != nil {
+		return nil, errors.Errorf("failed to parse connection string: %w", err)
+	}
+	// Password is passed via env so that it's not visible in the process list
+	dbUrl.User = url.User(config.User)
+	psqlArgs := []string{dbUrl.String()}
+	if len(command) > 0 {
+		psqlArgs = append(psqlArgs, "--command", command)
+	} else if len(file) > 0 {
+		psqlArgs = append(psqlArgs, "--file", file)
+	}
+	cmd := exec.CommandContext(ctx, psql, append(psqlArgs, args...)...)
+	cmd.Env = append(os.Environ(), "PGPASSWORD="+config.Password)
+	return cmd, nil
+}
+
+// Reads statements terminated by semicolon until EOF or \q.
+func Repl(ctx context.Context, conn *pgx.Conn, stdin io.Reader, stdout io.Writer) error {
+	scanner := bufio.NewScanner(stdin)
+	var buf strings.Builder
+	for {
+		prompt := conn.Config().Database + "=> "
+		if buf.Len() > 0 {
+			prompt = "-> "
+		}
+		fmt.Fprint(os.Stderr, prompt)
+		if !scanner.Scan() {
+			break
+		}
+		line := scanner.Text()
+		if buf.Len() == 0 {
+			switch strings.TrimSpace(line) {
+			case `\q`, "exit", "quit":
+				return nil
+			case "":
+				continue
+			}
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+		if !strings.HasSuffix(strings.TrimSpace(line), ";") {
+			continue
+		}
+		if err := ExecQuery(ctx, conn.PgConn(), buf.String(), stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		buf.Reset()
+	}
+	fmt.Fprintln(os.Stderr)
+	if err := scanner.Err(); err != nil {
+		return errors.Errorf("failed to read input: %w", err)
+	}
+	return nil
+}
+
+// Runs one or more statements using the simple protocol and prints the results like psql.
+func ExecQuery(ctx context.Context, conn *pgconn.PgConn, sql string, stdout io.Writer) error {
+	results, err := conn.Exec(ctx, sql).ReadAll()
+	for _, r := range results {
+		if len(r.FieldDescriptions) > 0 {
+			printRows(r, stdout)
+		} else if r.Err == nil {
+			fmt.Fprintln(stdout, r.CommandTag.String())
+		}
+	}
+	if err != nil {
+		return errors.Errorf("failed to execute query: %w", err)
+	}
+	return nil
+}
+
+func printRows(r *pgconn.Result, stdout io.Writer) {
+	w := tabwriter.NewWriter(stdout, 0, 0, 1, ' ', tabwriter.Debug)
+	var header, divider []string
+	for _, f := range r.FieldDescriptions {
+		header = append(header, " "+string(f.Name))
+		divider = append(divider, strings.Repeat("-", len(f.Name)+1))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	fmt.Fprintln(w, strings.Join(divider, "\t"))
+	for _, row := range r.Rows {
+		var values []string
+		for _, v := range row {
+			values = append(values, " "+string(v))
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	w.Flush()
+	suffix := "s"
+	if len(r.Rows) == 1 {
+		suffix = ""
+	}
+	fmt.Fprintf(stdout, "(%d row%s)\n\n", len(r.Rows), suffix)
+}
@@ -0,0 +1,132 @@
+package psql
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestExecQuery(t *testing.T) {
+	t.Run("prints query results", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("select 'hello' as greeting").
+			Reply("SELECT 1", []interface{}{"hello"})
+		// Run test
+		var out bytes.Buffer
+		err := ExecQuery(context.Background(), conn.MockClient(t).PgConn(), "select 'hello' as greeting", &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "hello")
+		assert.Contains(t, out.String(), "(1 row)")
+	})
+
+	t.Run("prints command tag", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("create table t()").
+			Reply("CREATE TABLE")
+		// Run test
+		var out bytes.Buffer
+		err := ExecQuery(context.Background(), conn.MockClient(t).PgConn(), "create table t()", &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "CREATE TABLE\n", out.String())
+	})
+
+	t.Run("throws error on failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("select * from missing").
+			ReplyError(pgerrcode.UndefinedTable, `relation "missing" does not exist`)
+		// Run test
+		err := ExecQuery(context.Background(), conn.MockClient(t).PgConn(), "select * from missing", &bytes.Buffer{})
+		// Check error
+		assert.ErrorContains(t, err, `relation "missing" does not exist`)
+	})
+}
+
+func TestRepl(t *testing.T) {
+	t.Run("executes statements until quit", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("create table t(\nid int);\n").
+			Reply("CREATE TABLE")
+		// Run test
+		var out bytes.Buffer
+		stdin := strings.NewReader("create table t(\nid int);\n\\q\nselect 1;\n")
+		err := Repl(context.Background(), conn.MockClient(t), stdin, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "CREATE TABLE\n", out.String())
+	})
+}
+
+func TestRunFallback(t *testing.T) {
+	lookPath = func(file string) (string, error) {
+		return "", errors.New("executable file not found")
+	}
+	t.Cleanup(func() { lookPath = exec.LookPath })
+
+	t.Run("executes file without psql", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "query.sql", []byte("create table t()"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("create table t()").
+			Reply("CREATE TABLE")
+		// Run test
+		err := Run(context.Background(), "", "query.sql", nil, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		err := Run(context.Background(), "", "missing.sql", nil, dbConfig, afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "failed to read file:")
+	})
+
+	t.Run("throws error on conflicting flags", func(t *testing.T) {
+		err := Run(context.Background(), "select 1", "query.sql", nil, dbConfig, afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "Only one of --command or --file")
+	})
+}
+
+func TestPsqlCmd(t *testing.T) {
+	t.Run("passes password via env", func(t *testing.T) {
+		cmd, err := newPsqlCmd(context.Background(), "psql", "select 1", "", []string{"-X"}, dbConfig)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"psql",
+			"postgresql://admin@127.0.0.1:5432/postgres?connect_timeout=10",
+			"--command", "select 1",
+			"-X",
+		}, cmd.Args)
+		assert.Contains(t, cmd.Env, "PGPASSWORD=password")
+		assert.NotContains(t, strings.Join(cmd.Args, " "), "password")
+	})
+}
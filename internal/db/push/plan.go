@@ -0,0 +1,88 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/lint"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/migration/up"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+type PlanStep struct {
+	Order      int      `json:"order"`
+	File       string   `json:"file"`
+	Statements int      `json:"statements"`
+	Locks      []string `json:"lock_heavy_operations"`
+}
+
+// Prints the ordered plan of pending migrations without applying them.
+func RunPlan(ctx context.Context, ignoreVersionMismatch bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	pending, err := up.GetPendingMigrations(ctx, ignoreVersionMismatch, conn, fsys)
+	if err != nil {
+		return err
+	}
+	plan, err := BuildPlan(pending, fsys)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeRows(utils.OutputFormat.Value, os.Stdout, plan)
+	}
+	if len(plan) == 0 {
+		fmt.Println(render.T("remote_db_up_to_date"))
+		return nil
+	}
+	table := "|#|FILE|STATEMENTS|LOCK HEAVY OPERATIONS|\n|-|-|-|-|\n"
+	for _, s := range plan {
+		locks := "-"
+		if len(s.Locks) > 0 {
+			locks = strings.Join(s.Locks, "<br>")
+		}
+		table += fmt.Sprintf("|`%d`|`%s`|`%d`|%s|\n", s.Order, s.File, s.Statements, locks)
+	}
+	return list.RenderTable(table)
+}
+
+func BuildPlan(pending []string, fsys afero.Fs) ([]PlanStep, error) {
+	plan := []PlanStep{}
+	for i, path := range pending {
+		file, err := migration.NewMigrationFromFile(path, afero.NewIOFS(fsys))
+		if err != nil {
+			return nil, err
+		}
+		step := PlanStep{
+			Order:      i + 1,
+			File:       filepath.Base(path),
+			Statements: len(file.Statements),
+			Locks:      []string{},
+		}
+		for _, stat := range file.Statements {
+			if reason := DetectLock(stat); len(reason) > 0 {
+				step.Locks = append(step.Locks, reason)
+			}
+		}
+		plan = append(plan, step)
+	}
+	return plan, nil
+}
+
+// Returns the reason a statement needs a lock-heavy operation, or empty.
+func DetectLock(stat string) string {
+	reason, _ := lint.DetectHazard(stat)
+	return reason
+}
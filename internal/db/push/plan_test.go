@@ -0,0 +1,69 @@
+package push
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+func TestPushPlan(t *testing.T) {
+	t.Run("prints plan of pending migrations", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create index on todos (title);"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0")
+		// Run test
+		err := RunPlan(context.Background(), false, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("builds ordered plan", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		first := filepath.Join(utils.MigrationsDir, "1_first.sql")
+		require.NoError(t, afero.WriteFile(fsys, first, []byte(`create table todos (id bigint, title text);
+create index concurrently todos_title_idx on todos (title);`), 0644))
+		second := filepath.Join(utils.MigrationsDir, "2_second.sql")
+		require.NoError(t, afero.WriteFile(fsys, second, []byte(`alter table todos alter column title set not null;`), 0644))
+		// Run test
+		plan, err := BuildPlan([]string{first, second}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []PlanStep{{
+			Order:      1,
+			File:       "1_first.sql",
+			Statements: 2,
+			Locks:      []string{},
+		}, {
+			Order:      2,
+			File:       "2_second.sql",
+			Statements: 1,
+			Locks:      []string{"SET NOT NULL scans the table under ACCESS EXCLUSIVE lock"},
+		}}, plan)
+	})
+}
+
+func TestDetectLock(t *testing.T) {
+	assert.NotEmpty(t, DetectLock("ALTER TABLE todos ALTER COLUMN id TYPE bigint"))
+	assert.NotEmpty(t, DetectLock("alter table todos add constraint fk foreign key (user_id) references users (id)"))
+	assert.Empty(t, DetectLock("alter table todos add constraint fk foreign key (user_id) references users (id) not valid"))
+	assert.NotEmpty(t, DetectLock("-- build index\ncreate unique index todos_idx on todos (id)"))
+	assert.Empty(t, DetectLock("create index concurrently todos_idx on todos (id)"))
+	assert.NotEmpty(t, DetectLock("refresh materialized view stats"))
+	assert.Empty(t, DetectLock("alter table todos add column done boolean"))
+	assert.NotEmpty(t, DetectLock("drop index todos_idx"))
+	assert.Empty(t, DetectLock("reindex index concurrently todos_idx"))
+}
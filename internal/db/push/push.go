@@ -5,18 +5,39 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/go-errors/errors"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/metrics"
 	"github.com/supabase/cli/internal/migration/up"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 	"github.com/supabase/cli/pkg/migration"
 )
 
-func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles, includeSeed bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+// Payload selects what is executed against the remote database.
+type Payload struct {
+	Migrations bool
+	Roles      bool
+	Seed       bool
+	// Bounds of pending migration versions to push, inclusive
+	FromVersion string
+	ToVersion   string
+	// Versions of migrations to apply outside of a transaction
+	NoTransaction []string
+}
+
+func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, payload Payload, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) (err error) {
+	start := time.Now()
+	defer func() {
+		metrics.Duration(ctx, "db.push.duration", start, metrics.Status(err))
+	}()
 	if dryRun {
 		fmt.Fprintln(os.Stderr, "DRY RUN: migrations will *not* be pushed to the database.")
 	}
@@ -25,12 +46,29 @@ func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles,
 		return err
 	}
 	defer conn.Close(context.Background())
-	pending, err := up.GetPendingMigrations(ctx, ignoreVersionMismatch, conn, fsys)
-	if err != nil {
-		return err
+	var pending []string
+	if payload.Migrations {
+		if pending, err = up.GetPendingMigrations(ctx, ignoreVersionMismatch, conn, fsys); err != nil {
+			return err
+		}
+		if len(payload.FromVersion) > 0 || len(payload.ToVersion) > 0 {
+			all := pending
+			if pending, err = up.FilterRange(all, payload.FromVersion, payload.ToVersion, fsys); err != nil {
+				return err
+			}
+			if skipped := len(all) - len(pending); skipped > 0 {
+				fmt.Fprintf(os.Stderr, "Skipping %d pending migrations outside of the selected range.\n", skipped)
+			}
+		}
+		versions := getVersions(pending)
+		for _, v := range payload.NoTransaction {
+			if !slices.Contains(versions, v) {
+				return errors.Errorf("--no-transaction version is not pending: %s", v)
+			}
+		}
 	}
 	var seeds []migration.SeedFile
-	if includeSeed {
+	if payload.Seed {
 		if remote, _ := utils.Config.GetRemoteByProjectRef(flags.ProjectRef); !remote.Db.Seed.Enabled {
 			fmt.Fprintln(os.Stderr, "Skipping seed because it is disabled in config.toml for project:", remote.ProjectId)
 		} else if seeds, err = migration.GetPendingSeeds(ctx, remote.Db.Seed.SqlPaths, conn, afero.NewIOFS(fsys)); err != nil {
@@ -38,7 +76,7 @@ func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles,
 		}
 	}
 	var globals []string
-	if includeRoles {
+	if payload.Roles {
 		if exists, err := afero.Exists(fsys, utils.CustomRolesPath); err != nil {
 			return errors.Errorf("failed to find custom roles: %w", err)
 		} else if exists {
@@ -46,65 +84,77 @@ func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles,
 		}
 	}
 	if len(pending) == 0 && len(seeds) == 0 && len(globals) == 0 {
-		fmt.Println("Remote database is up to date.")
+		fmt.Println(render.T("remote_db_up_to_date"))
 		return nil
 	}
-	// Push pending migrations
+	manifest := formatManifest(globals, pending, seeds)
 	if dryRun {
-		if len(globals) > 0 {
-			fmt.Fprintln(os.Stderr, "Would create custom roles "+utils.Bold(globals[0])+"...")
-		}
-		if len(pending) > 0 {
-			fmt.Fprintln(os.Stderr, "Would push these migrations:")
-			fmt.Fprint(os.Stderr, confirmPushAll(pending))
-		}
-		if len(seeds) > 0 {
-			fmt.Fprintln(os.Stderr, "Would seed these files:")
-			fmt.Fprint(os.Stderr, confirmSeedAll(seeds))
-		}
-	} else {
-		if len(globals) > 0 {
-			msg := "Do you want to create custom roles in the database cluster?"
-			if shouldPush, err := utils.NewConsole().PromptYesNo(ctx, msg, true); err != nil {
-				return err
-			} else if !shouldPush {
-				return errors.New(context.Canceled)
-			}
-			if err := migration.SeedGlobals(ctx, globals, conn, afero.NewIOFS(fsys)); err != nil {
-				return err
-			}
+		fmt.Fprintln(os.Stderr, "Would execute the following against the remote database:")
+		fmt.Fprint(os.Stderr, manifest)
+		fmt.Println(render.T("finished", utils.Aqua("supabase db push")))
+		return nil
+	}
+	msg := "Do you want to execute the following against the remote database?\n" + manifest
+	if shouldPush, err := utils.NewConsole().PromptYesNo(ctx, msg, true); err != nil {
+		return err
+	} else if !shouldPush {
+		return errors.New(context.Canceled)
+	}
+	// Roles are created first because migrations may grant privileges to them
+	if len(globals) > 0 {
+		if err := migration.SeedGlobals(ctx, globals, conn, afero.NewIOFS(fsys)); err != nil {
+			return err
 		}
-		if len(pending) > 0 {
-			msg := fmt.Sprintf("Do you want to push these migrations to the remote database?\n%s\n", confirmPushAll(pending))
-			if shouldPush, err := utils.NewConsole().PromptYesNo(ctx, msg, true); err != nil {
-				return err
-			} else if !shouldPush {
-				return errors.New(context.Canceled)
-			}
-			if err := migration.ApplyMigrations(ctx, pending, conn, afero.NewIOFS(fsys)); err != nil {
-				return err
-			}
-		} else {
-			fmt.Fprintln(os.Stderr, "Schema migrations are up to date.")
+	}
+	if len(pending) > 0 {
+		if err := migration.ApplyMigrations(ctx, pending, conn, afero.NewIOFS(fsys), migration.WithNoTransaction(payload.NoTransaction...)); err != nil {
+			return err
 		}
-		if len(seeds) > 0 {
-			msg := fmt.Sprintf("Do you want to seed the remote database with these files?\n%s\n", confirmSeedAll(seeds))
-			if shouldPush, err := utils.NewConsole().PromptYesNo(ctx, msg, true); err != nil {
-				return err
-			} else if !shouldPush {
-				return errors.New(context.Canceled)
-			}
-			if err := migration.SeedData(ctx, seeds, conn, afero.NewIOFS(fsys)); err != nil {
-				return err
-			}
-		} else if includeSeed {
-			fmt.Fprintln(os.Stderr, "Seed files are up to date.")
+		utils.HookEnv[HookEnvVersions] = strings.Join(getVersions(pending), ",")
+		metrics.Add(ctx, "db.push.migrations", int64(len(pending)))
+	} else if payload.Migrations {
+		fmt.Fprintln(os.Stderr, render.T("migrations_up_to_date"))
+	}
+	if len(seeds) > 0 {
+		if err := migration.SeedData(ctx, seeds, conn, afero.NewIOFS(fsys)); err != nil {
+			return err
 		}
+		metrics.Add(ctx, "db.push.seeds", int64(len(seeds)))
+	} else if payload.Seed {
+		fmt.Fprintln(os.Stderr, render.T("seeds_up_to_date"))
 	}
-	fmt.Println("Finished " + utils.Aqua("supabase db push") + ".")
+	fmt.Println(render.T("finished", utils.Aqua("supabase db push")))
 	return nil
 }
 
+// Lists every file executed by push, grouped in the order they are run.
+func formatManifest(globals, pending []string, seeds []migration.SeedFile) (msg string) {
+	if len(globals) > 0 {
+		msg += "Custom roles:\n"
+		for _, path := range globals {
+			msg += fmt.Sprintf(" • %s\n", utils.Bold(path))
+		}
+	}
+	if len(pending) > 0 {
+		msg += "Migrations:\n" + confirmPushAll(pending)
+	}
+	if len(seeds) > 0 {
+		msg += "Seed files:\n" + confirmSeedAll(seeds)
+	}
+	return msg
+}
+
+// Comma separated versions of the migrations applied, exposed to the after hook.
+const HookEnvVersions = "SUPABASE_MIGRATION_VERSIONS"
+
+func getVersions(pending []string) []string {
+	versions := make([]string, len(pending))
+	for i, path := range pending {
+		versions[i], _, _ = strings.Cut(filepath.Base(path), "_")
+	}
+	return versions
+}
+
 func confirmPushAll(pending []string) (msg string) {
 	for _, path := range pending {
 		filename := filepath.Base(path)
@@ -0,0 +1,72 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+// Replaces named parameters, ie. :key, with positional placeholders. Quoted strings,
+// quoted identifiers and type casts are left untouched.
+func BindParams(sql string, params []string) (string, []any, error) {
+	values := make(map[string]string, len(params))
+	for _, p := range params {
+		key, value, found := strings.Cut(p, "=")
+		if !found || len(key) == 0 {
+			return "", nil, errors.Errorf("invalid parameter %q: must be in the format key=value", p)
+		}
+		values[key] = value
+	}
+	if len(values) == 0 {
+		return sql, nil, nil
+	}
+	var result strings.Builder
+	var args []any
+	positions := make(map[string]int, len(values))
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := len(sql)
+			if j := strings.IndexByte(sql[i+1:], c); j >= 0 {
+				end = i + j + 2
+			}
+			result.WriteString(sql[i:end])
+			i = end - 1
+			continue
+		case c == ':' && i+1 < len(sql) && sql[i+1] == ':':
+			result.WriteString("::")
+			i++
+			continue
+		case c == ':':
+			j := i + 1
+			for j < len(sql) && isIdentChar(sql[j]) {
+				j++
+			}
+			name := sql[i+1 : j]
+			if value, ok := values[name]; ok && len(name) > 0 {
+				pos, ok := positions[name]
+				if !ok {
+					args = append(args, value)
+					pos = len(args)
+					positions[name] = pos
+				}
+				fmt.Fprintf(&result, "$%d", pos)
+				i = j - 1
+				continue
+			}
+		}
+		result.WriteByte(c)
+	}
+	for key := range values {
+		if _, ok := positions[key]; !ok {
+			return "", nil, errors.Errorf("parameter %q is not used in the query", key)
+		}
+	}
+	return result.String(), args, nil
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
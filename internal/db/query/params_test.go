@@ -0,0 +1,46 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindParams(t *testing.T) {
+	t.Run("replaces named parameters", func(t *testing.T) {
+		sql, args, err := BindParams("select * from users where id = :id and org = :org or owner = :id", []string{"id=1", "org=acme"})
+		assert.NoError(t, err)
+		assert.Equal(t, "select * from users where id = $1 and org = $2 or owner = $1", sql)
+		assert.Equal(t, []any{"1", "acme"}, args)
+	})
+
+	t.Run("ignores casts and quoted text", func(t *testing.T) {
+		sql, args, err := BindParams(`select ':id', ":id", :id::int`, []string{"id=1"})
+		assert.NoError(t, err)
+		assert.Equal(t, `select ':id', ":id", $1::int`, sql)
+		assert.Equal(t, []any{"1"}, args)
+	})
+
+	t.Run("returns query unchanged without params", func(t *testing.T) {
+		sql, args, err := BindParams("select :id", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "select :id", sql)
+		assert.Empty(t, args)
+	})
+
+	t.Run("handles unterminated quote", func(t *testing.T) {
+		sql, _, err := BindParams("select :id, 'oops", []string{"id=1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "select $1, 'oops", sql)
+	})
+
+	t.Run("throws error on invalid param", func(t *testing.T) {
+		_, _, err := BindParams("select 1", []string{"id"})
+		assert.ErrorContains(t, err, `invalid parameter "id"`)
+	})
+
+	t.Run("throws error on unused param", func(t *testing.T) {
+		_, _, err := BindParams("select 1", []string{"id=1"})
+		assert.ErrorContains(t, err, `parameter "id" is not used in the query`)
+	})
+}
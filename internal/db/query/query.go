@@ -0,0 +1,151 @@
+package query
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Deprecated: use utils.OutputCsv instead.
+const OutputCsv = utils.OutputCsv
+
+type Result struct {
+	Columns []string
+	// Values are in text format, nil represents NULL
+	Rows [][]*string
+}
+
+func Run(ctx context.Context, sql, file string, params []string, limit uint, format string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if len(file) > 0 {
+		contents, err := afero.ReadFile(fsys, file)
+		if err != nil {
+			return errors.Errorf("failed to read file: %w", err)
+		}
+		sql = string(contents)
+	}
+	if len(strings.TrimSpace(sql)) == 0 {
+		return errors.New("Missing SQL query. Pass it as an argument or use --file.")
+	}
+	query, args, err := BindParams(sql, params)
+	if err != nil {
+		return err
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	result, err := execQuery(ctx, conn, query, args, limit)
+	if err != nil {
+		return err
+	}
+	return printResult(result, format, os.Stdout)
+}
+
+func execQuery(ctx context.Context, conn *pgx.Conn, sql string, args []any, limit uint) (Result, error) {
+	// Simple protocol returns all values in text format, same as psql
+	rows, err := conn.Query(ctx, sql, append([]any{pgx.QuerySimpleProtocol(true)}, args...)...)
+	if err != nil {
+		return Result{}, errors.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+	var result Result
+	for _, f := range rows.FieldDescriptions() {
+		result.Columns = append(result.Columns, string(f.Name))
+	}
+	for rows.Next() {
+		if limit > 0 && uint(len(result.Rows)) >= limit {
+			fmt.Fprintf(os.Stderr, "Results truncated to %d rows. Use --limit to change the limit.\n", limit)
+			break
+		}
+		var row []*string
+		for _, v := range rows.RawValues() {
+			if v == nil {
+				row = append(row, nil)
+				continue
+			}
+			value := string(v)
+			row = append(row, &value)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	// Close is required to read the error of a truncated result set
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return Result{}, errors.Errorf("failed to execute query: %w", err)
+	}
+	return result, nil
+}
+
+func printResult(result Result, format string, w io.Writer) error {
+	switch format {
+	case utils.OutputPretty:
+		return list.RenderTable(toMarkdown(result))
+	case utils.OutputCsv:
+		return writeCsv(result, w)
+	}
+	var rows []map[string]*string
+	for _, r := range result.Rows {
+		row := make(map[string]*string, len(result.Columns))
+		for i, c := range result.Columns {
+			row[c] = r[i]
+		}
+		rows = append(rows, row)
+	}
+	return utils.EncodeRows(format, w, rows)
+}
+
+func toMarkdown(result Result) string {
+	if len(result.Columns) == 0 {
+		return ""
+	}
+	var table strings.Builder
+	table.WriteString("|" + strings.Join(result.Columns, "|") + "|\n")
+	table.WriteString("|" + strings.Repeat("-|", len(result.Columns)) + "\n")
+	for _, r := range result.Rows {
+		table.WriteString("|")
+		for _, v := range r {
+			if v != nil {
+				table.WriteString(strings.ReplaceAll(*v, "|", `\|`))
+			} else {
+				table.WriteString("NULL")
+			}
+			table.WriteString("|")
+		}
+		table.WriteString("\n")
+	}
+	return table.String()
+}
+
+func writeCsv(result Result, w io.Writer) error {
+	enc := csv.NewWriter(w)
+	if err := enc.Write(result.Columns); err != nil {
+		return errors.Errorf("failed to write csv: %w", err)
+	}
+	for _, r := range result.Rows {
+		record := make([]string, len(r))
+		for i, v := range r {
+			if v != nil {
+				record[i] = *v
+			}
+		}
+		if err := enc.Write(record); err != nil {
+			return errors.Errorf("failed to write csv: %w", err)
+		}
+	}
+	enc.Flush()
+	if err := enc.Error(); err != nil {
+		return errors.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestExecQuery(t *testing.T) {
+	t.Run("binds parameters and truncates rows", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("select name from users where org =  'acme' ").
+			Reply("SELECT 2",
+				[]interface{}{"alice"},
+				[]interface{}{"bob"},
+			)
+		// Run test
+		result, err := execQuery(context.Background(), conn.MockClient(t), "select name from users where org = $1", []any{"acme"}, 1)
+		// Check error
+		assert.NoError(t, err)
+		assert.Len(t, result.Columns, 1)
+		require.Len(t, result.Rows, 1)
+		assert.Equal(t, "alice", *result.Rows[0][0])
+	})
+
+	t.Run("throws error on sql failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("select * from missing").
+			ReplyError(pgerrcode.UndefinedTable, `relation "missing" does not exist`)
+		// Run test
+		err := Run(context.Background(), "select * from missing", "", nil, 0, utils.OutputJson, dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, `relation "missing" does not exist`)
+	})
+
+	t.Run("throws error on empty query", func(t *testing.T) {
+		err := Run(context.Background(), " ", "", nil, 0, utils.OutputJson, dbConfig, afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "Missing SQL query.")
+	})
+}
+
+func TestPrintResult(t *testing.T) {
+	value := "a,b"
+	result := Result{
+		Columns: []string{"id", "tags"},
+		Rows:    [][]*string{{&value, nil}},
+	}
+
+	t.Run("prints csv", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, printResult(result, OutputCsv, &out))
+		assert.Equal(t, "id,tags\n\"a,b\",\n", out.String())
+	})
+
+	t.Run("prints json", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, printResult(result, utils.OutputJson, &out))
+		assert.JSONEq(t, `[{"id": "a,b", "tags": null}]`, out.String())
+	})
+
+	t.Run("renders markdown", func(t *testing.T) {
+		assert.Equal(t, "|id|tags|\n|-|-|\n|a,b|NULL|\n", toMarkdown(result))
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/supabase/cli/internal/db/dump"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/migration"
 )
@@ -30,7 +31,7 @@ func Run(ctx context.Context, schema []string, config pgconn.Config, fsys afero.
 		return err
 	}
 
-	fmt.Println("Finished " + utils.Aqua("supabase db remote commit") + ".")
+	fmt.Println(render.T("finished", utils.Aqua("supabase db remote commit")))
 	return nil
 }
 
@@ -69,7 +70,7 @@ func fetchRemote(p utils.Program, ctx context.Context, schema []string, timestam
 		return err
 	} else if len(migrations) == 0 {
 		p.Send(utils.StatusMsg("Committing initial migration on remote database..."))
-		return dump.Run(ctx, path, config, nil, nil, false, false, false, false, false, fsys)
+		return dump.Run(ctx, path, config, nil, nil, false, false, false, false, false, dump.ArchiveOptions{}, fsys)
 	}
 
 	w := utils.StatusWriter{Program: p}
@@ -0,0 +1,27 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, projectRef, region string) error {
+	body := api.V1SetupAReadReplicaJSONRequestBody{
+		ReadReplicaRegion: api.SetUpReadReplicaBodyReadReplicaRegion(region),
+	}
+	resp, err := utils.GetSupabase().V1SetupAReadReplicaWithResponse(ctx, projectRef, body)
+	if err != nil {
+		return errors.Errorf("failed to create read replica: %w", err)
+	} else if resp.StatusCode() != http.StatusCreated {
+		return errors.New("Unexpected error creating read replica: " + string(resp.Body))
+	}
+	fmt.Fprintln(os.Stderr, "Setting up read replica in region", utils.Aqua(region)+".")
+	fmt.Fprintln(os.Stderr, "Run", utils.Aqua("supabase db replicate list"), "to check its status.")
+	return nil
+}
@@ -0,0 +1,48 @@
+package create
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestCreateReplica(t *testing.T) {
+	ref := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("sets up read replica", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/read-replicas/setup").
+			JSON(api.SetUpReadReplicaBody{ReadReplicaRegion: api.SetUpReadReplicaBodyReadReplicaRegionUsEast1}).
+			Reply(http.StatusCreated)
+		// Run test
+		err := Run(context.Background(), ref, "us-east-1")
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on forbidden", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/read-replicas/setup").
+			Reply(http.StatusForbidden).
+			JSON(map[string]string{"message": "Read replicas require a paid plan"})
+		// Run test
+		err := Run(context.Background(), ref, "us-east-1")
+		// Check error
+		assert.ErrorContains(t, err, "Read replicas require a paid plan")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
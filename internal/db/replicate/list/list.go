@@ -0,0 +1,105 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/db/url"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// Replicas that are fully caught up report zero lag even when the primary is idle.
+const lagQuery = `SELECT CASE
+  WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+  ELSE coalesce(extract(epoch FROM now() - pg_last_xact_replay_timestamp()), 0)
+END::float8`
+
+type Replica struct {
+	api.ReadReplicaResponse `yaml:",inline"`
+	ConnectionString        string   `json:"connection_string" yaml:"connection_string" toml:"connection_string"`
+	LagSeconds              *float64 `json:"lag_seconds,omitempty" yaml:"lag_seconds,omitempty" toml:"lag_seconds,omitempty"`
+}
+
+// Run lists read replicas of the project. Replication lag is only queried when a
+// database password is given, since replicas share credentials with the primary.
+func Run(ctx context.Context, projectRef, password string, options ...func(*pgx.ConnConfig)) error {
+	resp, err := utils.GetSupabase().V1ListReadReplicasWithResponse(ctx, projectRef)
+	if err != nil {
+		return errors.Errorf("failed to list read replicas: %w", err)
+	} else if resp.JSON200 == nil {
+		return errors.New("Unexpected error listing read replicas: " + string(resp.Body))
+	}
+	replicas := []Replica{}
+	for _, r := range *resp.JSON200 {
+		replica := Replica{ReadReplicaResponse: r, ConnectionString: connectionString(r.DbHost)}
+		if len(password) > 0 {
+			if lag, err := QueryLag(ctx, replicaConfig(r.DbHost, password), options...); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			} else {
+				replica.LagSeconds = &lag
+			}
+		}
+		replicas = append(replicas, replica)
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, replicas)
+	}
+	table := "|IDENTIFIER|REGION|STATUS|LAG|CONNECTION STRING|\n|-|-|-|-|-|\n"
+	for _, r := range replicas {
+		lag := "-"
+		if r.LagSeconds != nil {
+			lag = fmt.Sprintf("%.1fs", *r.LagSeconds)
+		}
+		table += fmt.Sprintf(
+			"|`%s`|`%s`|`%s`|%s|`%s`|\n",
+			r.Identifier,
+			formatRegion(r.Region),
+			r.Status,
+			lag,
+			strings.ReplaceAll(r.ConnectionString, "|", "\\|"),
+		)
+	}
+	return list.RenderTable(table)
+}
+
+func replicaConfig(host, password string) pgconn.Config {
+	return pgconn.Config{
+		Host:     host,
+		Port:     5432,
+		User:     "postgres",
+		Password: password,
+		Database: "postgres",
+	}
+}
+
+// Placeholder must not be percent encoded to be recognisable
+func connectionString(host string) string {
+	return strings.Replace(utils.ToPostgresURL(replicaConfig(host, "")), ":@", ":"+url.PasswordPlaceholder+"@", 1)
+}
+
+func QueryLag(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) (float64, error) {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close(context.Background())
+	var lag float64
+	if err := conn.QueryRow(ctx, lagQuery).Scan(&lag); err != nil {
+		return 0, errors.Errorf("failed to query replication lag: %w", err)
+	}
+	return lag, nil
+}
+
+func formatRegion(region string) string {
+	if readable, ok := utils.RegionMap[region]; ok {
+		return readable
+	}
+	return region
+}
@@ -0,0 +1,30 @@
+package remove
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, projectRef, identifier string) error {
+	msg := fmt.Sprintf("Do you want to remove read replica %s?", utils.Aqua(identifier))
+	if shouldRemove, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldRemove {
+		return errors.New(context.Canceled)
+	}
+	body := api.V1RemoveAReadReplicaJSONRequestBody{DatabaseIdentifier: identifier}
+	resp, err := utils.GetSupabase().V1RemoveAReadReplicaWithResponse(ctx, projectRef, body)
+	if err != nil {
+		return errors.Errorf("failed to remove read replica: %w", err)
+	} else if resp.StatusCode() != http.StatusCreated {
+		return errors.New("Unexpected error removing read replica: " + string(resp.Body))
+	}
+	fmt.Fprintln(os.Stderr, "Removing read replica:", utils.Aqua(identifier))
+	return nil
+}
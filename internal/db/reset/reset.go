@@ -23,12 +23,13 @@ import (
 	"github.com/supabase/cli/internal/gen/keys"
 	"github.com/supabase/cli/internal/migration/apply"
 	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/seed/buckets"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/migration"
 )
 
-func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func Run(ctx context.Context, version string, keepData bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	if len(version) > 0 {
 		if _, err := strconv.Atoi(version); err != nil {
 			return errors.New(repair.ErrInvalidVersion)
@@ -38,6 +39,9 @@ func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.F
 		}
 	}
 	if !utils.IsLocalDatabase(config) {
+		if keepData {
+			return errors.New("--keep-data is only supported for the local database")
+		}
 		msg := "Do you want to reset the remote database?"
 		if shouldReset, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
 			return err
@@ -50,10 +54,22 @@ func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.F
 	if err := utils.AssertSupabaseDbIsRunning(); err != nil {
 		return err
 	}
+	var snapshot DataSnapshot
+	if keepData {
+		var err error
+		if snapshot, err = SnapshotData(ctx, options...); err != nil {
+			return err
+		}
+	}
 	// Reset postgres database because extensions (pg_cron, pg_net) require postgres
 	if err := resetDatabase(ctx, version, fsys, options...); err != nil {
 		return err
 	}
+	if keepData {
+		if err := RestoreData(ctx, snapshot, options...); err != nil {
+			return err
+		}
+	}
 	// Seed objects from supabase/buckets directory
 	if resp, err := utils.Docker.ContainerInspect(ctx, utils.StorageId); err == nil {
 		if resp.State.Health == nil || resp.State.Health.Status != types.Healthy {
@@ -66,7 +82,7 @@ func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.F
 		}
 	}
 	branch := keys.GetGitBranch(fsys)
-	fmt.Fprintln(os.Stderr, "Finished "+utils.Aqua("supabase db reset")+" on branch "+utils.Aqua(branch)+".")
+	fmt.Fprintln(os.Stderr, render.T("finished_on_branch", utils.Aqua("supabase db reset"), utils.Aqua(branch)))
 	return nil
 }
 
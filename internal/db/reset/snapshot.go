@@ -0,0 +1,204 @@
+package reset
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+const (
+	listTables = `
+SELECT c.oid::regclass::text, array_agg(quote_ident(a.attname) ORDER BY a.attnum)
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped AND a.attgenerated = ''
+WHERE c.relkind = 'r' AND n.nspname = ANY($1)
+GROUP BY c.oid
+ORDER BY 1`
+	listColumns = `
+SELECT coalesce(array_agg(quote_ident(a.attname) ORDER BY a.attnum), '{}')
+FROM pg_attribute a
+WHERE a.attrelid = to_regclass($1) AND a.attnum > 0 AND NOT a.attisdropped AND a.attgenerated = ''`
+	listSequences = `
+SELECT format('%I.%I', schemaname, sequencename), last_value
+FROM pg_sequences
+WHERE last_value IS NOT NULL AND schemaname = ANY($1)`
+)
+
+type tableData struct {
+	Name    string
+	Columns []string
+	// Rows in COPY text format, which escapes tabs and newlines within values
+	Rows []byte
+}
+
+// Local table data captured before reset and replayed after migrations.
+type DataSnapshot struct {
+	Tables    []tableData
+	Sequences map[string]int64
+}
+
+func SnapshotData(ctx context.Context, options ...func(*pgx.ConnConfig)) (DataSnapshot, error) {
+	snapshot := DataSnapshot{Sequences: map[string]int64{}}
+	conn, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{}, options...)
+	if err != nil {
+		return snapshot, err
+	}
+	defer conn.Close(context.Background())
+	schemas, err := migration.ListUserSchemas(ctx, conn)
+	if err != nil {
+		return snapshot, err
+	}
+	fmt.Fprintln(os.Stderr, "Snapshotting local data in schemas:", strings.Join(schemas, ","))
+	rows, err := conn.Query(ctx, listTables, schemas)
+	if err != nil {
+		return snapshot, errors.Errorf("failed to list tables: %w", err)
+	}
+	for rows.Next() {
+		var table tableData
+		if err := rows.Scan(&table.Name, &table.Columns); err != nil {
+			return snapshot, errors.Errorf("failed to scan table: %w", err)
+		}
+		snapshot.Tables = append(snapshot.Tables, table)
+	}
+	if err := rows.Err(); err != nil {
+		return snapshot, errors.Errorf("failed to list tables: %w", err)
+	}
+	for i, table := range snapshot.Tables {
+		var buf bytes.Buffer
+		sql := fmt.Sprintf("COPY %s (%s) TO STDOUT", table.Name, strings.Join(table.Columns, ","))
+		if _, err := conn.PgConn().CopyTo(ctx, &buf, sql); err != nil {
+			return snapshot, errors.Errorf("failed to copy table %s: %w", table.Name, err)
+		}
+		snapshot.Tables[i].Rows = buf.Bytes()
+	}
+	rows, err = conn.Query(ctx, listSequences, schemas)
+	if err != nil {
+		return snapshot, errors.Errorf("failed to list sequences: %w", err)
+	}
+	for rows.Next() {
+		var name string
+		var value int64
+		if err := rows.Scan(&name, &value); err != nil {
+			return snapshot, errors.Errorf("failed to scan sequence: %w", err)
+		}
+		snapshot.Sequences[name] = value
+	}
+	if err := rows.Err(); err != nil {
+		return snapshot, errors.Errorf("failed to list sequences: %w", err)
+	}
+	return snapshot, nil
+}
+
+// Replays data into tables that survived the reset. Columns that were dropped
+// by migrations are ignored, while tables that fail to load are skipped.
+func RestoreData(ctx context.Context, snapshot DataSnapshot, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{}, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	// Skip foreign key checks so that tables can be restored in any order
+	if _, err := conn.Exec(ctx, "SET session_replication_role = replica"); err != nil {
+		return errors.Errorf("failed to disable triggers: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Restoring local data...")
+	for _, table := range snapshot.Tables {
+		var columns []string
+		if err := conn.QueryRow(ctx, listColumns, table.Name).Scan(&columns); err != nil {
+			return errors.Errorf("failed to list columns: %w", err)
+		}
+		if len(columns) == 0 {
+			fmt.Fprintln(os.Stderr, "Skipping dropped table:", table.Name)
+			continue
+		}
+		if err := restoreTable(ctx, conn, table, columns); err != nil {
+			fmt.Fprintln(os.Stderr, "Skipping table "+table.Name+":", err)
+		}
+	}
+	for name, value := range snapshot.Sequences {
+		if _, err := conn.Exec(ctx, "SELECT setval(to_regclass($1), $2)", name, value); err != nil {
+			return errors.Errorf("failed to restore sequence %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func restoreTable(ctx context.Context, conn *pgx.Conn, table tableData, columns []string) error {
+	indices, kept := intersectColumns(table.Columns, columns)
+	if len(kept) == 0 {
+		return errors.New("no columns left")
+	}
+	data, err := filterColumns(table.Rows, indices)
+	if err != nil {
+		return err
+	}
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return errors.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(context.Background())
+	}()
+	// Replace seed data with snapshot to avoid conflicts
+	if _, err := tx.Exec(ctx, "DELETE FROM "+table.Name); err != nil {
+		return errors.Errorf("failed to delete seed data: %w", err)
+	}
+	sql := fmt.Sprintf("COPY %s (%s) FROM STDIN", table.Name, strings.Join(kept, ","))
+	if _, err := conn.PgConn().CopyFrom(ctx, bytes.NewReader(data), sql); err != nil {
+		return errors.Errorf("failed to copy data: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Returns positions of the old columns that still exist, and their names.
+func intersectColumns(old, current []string) ([]int, []string) {
+	exists := map[string]bool{}
+	for _, c := range current {
+		exists[c] = true
+	}
+	var indices []int
+	var kept []string
+	for i, c := range old {
+		if exists[c] {
+			indices = append(indices, i)
+			kept = append(kept, c)
+		}
+	}
+	return indices, kept
+}
+
+// Projects rows in COPY text format onto the selected column indices.
+func filterColumns(rows []byte, indices []int) ([]byte, error) {
+	var buf bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(rows))
+	scanner.Buffer(nil, len(rows)+1)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		selected := make([]string, len(indices))
+		for i, j := range indices {
+			if j >= len(fields) {
+				return nil, errors.Errorf("malformed row: %s", scanner.Text())
+			}
+			selected[i] = fields[j]
+		}
+		buf.WriteString(strings.Join(selected, "\t"))
+		buf.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Errorf("failed to read rows: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,31 @@
+package reset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterColumns(t *testing.T) {
+	t.Run("drops removed columns", func(t *testing.T) {
+		indices, kept := intersectColumns([]string{"id", "name", `"Legacy"`}, []string{"id", `"Legacy"`, "created_at"})
+		assert.Equal(t, []int{0, 2}, indices)
+		assert.Equal(t, []string{"id", `"Legacy"`}, kept)
+		// Run test
+		data, err := filterColumns([]byte("1\talice\t\\N\n2\tbob\\tby\tx\\ny\n"), indices)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "1\t\\N\n2\tx\\ny\n", string(data))
+	})
+
+	t.Run("handles empty table", func(t *testing.T) {
+		data, err := filterColumns(nil, []int{0})
+		assert.NoError(t, err)
+		assert.Empty(t, data)
+	})
+
+	t.Run("throws error on malformed row", func(t *testing.T) {
+		_, err := filterColumns([]byte("1\n"), []int{1})
+		assert.ErrorContains(t, err, "malformed row")
+	})
+}
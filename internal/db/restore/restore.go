@@ -0,0 +1,201 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+// Dump files produced by db dump, restored in dependency order.
+type DumpFiles struct {
+	Roles  string
+	Schema string
+	Data   string
+}
+
+func Run(ctx context.Context, files DumpFiles, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if utils.IsLocalDatabase(config) {
+		if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+			return err
+		}
+	} else {
+		msg := "Do you want to restore the remote database? All user schemas will be dropped."
+		if shouldRestore, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+			return err
+		} else if !shouldRestore {
+			return errors.New(context.Canceled)
+		}
+	}
+	if err := restoreDatabase(ctx, files, config, fsys, options...); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, render.T("finished", utils.Aqua("supabase db restore")))
+	return nil
+}
+
+func restoreDatabase(ctx context.Context, files DumpFiles, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	// Load all dump files before dropping anything, so that invalid dumps leave the database intact
+	var paths []string
+	var dumps []*migration.MigrationFile
+	for _, path := range []string{files.Roles, files.Schema, files.Data} {
+		if len(path) == 0 {
+			continue
+		}
+		m, err := loadDumpFile(path, fsys)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, path)
+		dumps = append(dumps, m)
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	fmt.Fprintln(os.Stderr, "Resetting database...")
+	if err := migration.DropUserSchemas(ctx, conn); err != nil {
+		return err
+	}
+	var expected map[string]int
+	for i, m := range dumps {
+		fmt.Fprintln(os.Stderr, "Restoring "+utils.Bold(paths[i])+"...")
+		if err := m.ExecBatch(ctx, conn); err != nil {
+			return err
+		}
+		if paths[i] == files.Data {
+			expected = countInserts(m.Statements)
+		}
+	}
+	if len(expected) == 0 {
+		return nil
+	}
+	return verifyRowCounts(ctx, conn, expected)
+}
+
+var copyPattern = regexp.MustCompile(`(?im)^COPY\s.*\sFROM\s+stdin`)
+
+func loadDumpFile(path string, fsys afero.Fs) (*migration.MigrationFile, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, errors.Errorf("failed to open dump file: %w", err)
+	}
+	defer f.Close()
+	// Read from stream so that dump files are never recorded as migrations
+	m, err := migration.NewMigrationFromReader(f)
+	if err != nil {
+		return nil, err
+	}
+	// Rows following COPY statements are not valid SQL, so they cannot be replayed in a batch
+	for _, sql := range m.Statements {
+		if copyPattern.MatchString(sql) {
+			utils.CmdSuggestion = fmt.Sprintf("Dump the data without %s, or restore it with %s.", utils.Aqua("--use-copy"), utils.Aqua("psql"))
+			return nil, errors.Errorf("failed to restore %s: COPY statements are not supported", path)
+		}
+	}
+	return m, nil
+}
+
+// Data dumps use --inserts or --column-inserts with --rows-per-insert, which puts each
+// additional row of the same statement on a new line. Table names may be unquoted.
+var insertPattern = regexp.MustCompile(`^INSERT INTO ((?:"[^"]+"|[^\s."(]+)\.(?:"[^"]+"|[^\s."(]+))`)
+
+func countInserts(stats []string) map[string]int {
+	result := map[string]int{}
+	for _, sql := range stats {
+		if matches := insertPattern.FindStringSubmatch(sql); len(matches) > 1 {
+			result[matches[1]] += 1 + strings.Count(sql, ",\n\t(")
+		}
+	}
+	return result
+}
+
+func verifyRowCounts(ctx context.Context, conn *pgx.Conn, expected map[string]int) error {
+	tables := make([]string, 0, len(expected))
+	for name := range expected {
+		tables = append(tables, name)
+	}
+	sort.Strings(tables)
+	table := "|Table|Expected|Restored|\n|-|-|-|\n"
+	var mismatch int
+	for _, name := range tables {
+		var count int
+		if err := conn.QueryRow(ctx, "SELECT count(*) FROM "+name).Scan(&count); err != nil {
+			return errors.Errorf("failed to count rows: %w", err)
+		}
+		if count != expected[name] {
+			mismatch++
+		}
+		table += fmt.Sprintf("|`%s`|`%d`|`%d`|\n", name, expected[name], count)
+	}
+	if mismatch > 0 {
+		if err := list.RenderTable(table); err != nil {
+			return err
+		}
+		return errors.Errorf("row counts do not match for %d tables", mismatch)
+	}
+	fmt.Fprintf(os.Stderr, "Verified row counts for %d tables.\n", len(tables))
+	return nil
+}
+
+// Restores the linked project to a platform backup using point-in-time recovery.
+func RunBackup(ctx context.Context, projectRef, backup string) error {
+	resp, err := utils.GetSupabase().V1ListAllBackupsWithResponse(ctx, projectRef)
+	if err != nil {
+		return errors.Errorf("failed to list backups: %w", err)
+	} else if resp.JSON200 == nil {
+		return errors.New("Unexpected error listing backups: " + string(resp.Body))
+	}
+	var target *api.V1Backup
+	var available []string
+	for i, b := range resp.JSON200.Backups {
+		if b.InsertedAt == backup {
+			target = &resp.JSON200.Backups[i]
+		}
+		available = append(available, b.InsertedAt)
+	}
+	if target == nil {
+		if len(available) > 0 {
+			utils.CmdSuggestion = "Available backups: " + strings.Join(available, ", ")
+		}
+		return errors.Errorf("backup not found: %s", backup)
+	}
+	// The management API only restores to a point in time, so daily backups are
+	// restored from the dashboard instead.
+	if !resp.JSON200.PitrEnabled {
+		utils.CmdSuggestion = "Restore the daily backup on your project's dashboard: " + utils.Bold(utils.GetSupabaseDashboardURL()+"/project/"+projectRef+"/database/backups/scheduled")
+		return errors.Errorf("Restoring daily backups is not supported by the CLI: %s", backup)
+	}
+	ts, err := time.Parse(time.RFC3339, target.InsertedAt)
+	if err != nil {
+		return errors.Errorf("failed to parse backup time: %w", err)
+	}
+	msg := fmt.Sprintf("Do you want to restore project %s to the backup taken at %s?", projectRef, utils.Aqua(target.InsertedAt))
+	if shouldRestore, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldRestore {
+		return errors.New(context.Canceled)
+	}
+	body := api.V1RestorePitrBackupJSONRequestBody{RecoveryTimeTargetUnix: ts.Unix()}
+	if resp, err := utils.GetSupabase().V1RestorePitrBackupWithResponse(ctx, projectRef, body); err != nil {
+		return errors.Errorf("failed to restore backup: %w", err)
+	} else if status := resp.StatusCode(); status < 200 || status >= 300 {
+		return errors.Errorf("unexpected restore backup status %d: %s", status, string(resp.Body))
+	}
+	fmt.Fprintln(os.Stderr, "Started restoring project:", projectRef)
+	return nil
+}
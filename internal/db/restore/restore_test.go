@@ -0,0 +1,180 @@
+package restore
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "db.supabase.co",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+var escapedSchemas = append(migration.ManagedSchemas, "extensions", "public")
+
+func TestCountInserts(t *testing.T) {
+	t.Run("counts column inserts", func(t *testing.T) {
+		result := countInserts([]string{"INSERT INTO \"public\".\"todos\" (\"id\") VALUES\n\t(1),\n\t(2)"})
+		assert.Equal(t, map[string]int{`"public"."todos"`: 2}, result)
+	})
+
+	t.Run("counts inserts with unquoted names", func(t *testing.T) {
+		result := countInserts([]string{"INSERT INTO public.todos VALUES (1)", `INSERT INTO public."Todo List" VALUES (1)`})
+		assert.Equal(t, map[string]int{"public.todos": 1, `public."Todo List"`: 1}, result)
+	})
+
+	t.Run("sums rows per insert across statements", func(t *testing.T) {
+		result := countInserts([]string{
+			`INSERT INTO "public"."todos" ("id") VALUES (1)`,
+			"INSERT INTO \"public\".\"todos\" (\"id\") VALUES\n\t(2),\n\t(3),\n\t(4)",
+		})
+		assert.Equal(t, map[string]int{`"public"."todos"`: 4}, result)
+	})
+}
+
+func TestRestoreDatabase(t *testing.T) {
+	schema := "create table public.todos (id int)"
+	data := `INSERT INTO "public"."todos" ("id") VALUES (1)`
+
+	t.Run("restores schema and data", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "schema.sql", []byte(schema+";"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "data.sql", []byte(data+";"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.ListSchemas, escapedSchemas).
+			Reply("SELECT 0").
+			Query(migration.DropObjects).
+			Reply("INSERT 0").
+			Query(schema).
+			Reply("CREATE TABLE").
+			Query(data).
+			Reply("INSERT 0 1").
+			Query(`SELECT count(*) FROM "public"."todos"`).
+			Reply("SELECT 1", []interface{}{int64(1)})
+		// Run test
+		err := restoreDatabase(context.Background(), DumpFiles{
+			Schema: "schema.sql",
+			Data:   "data.sql",
+		}, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on row count mismatch", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "data.sql", []byte(data+";"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.ListSchemas, escapedSchemas).
+			Reply("SELECT 0").
+			Query(migration.DropObjects).
+			Reply("INSERT 0").
+			Query(data).
+			Reply("INSERT 0 1").
+			Query(`SELECT count(*) FROM "public"."todos"`).
+			Reply("SELECT 1", []interface{}{int64(0)})
+		// Run test
+		err := restoreDatabase(context.Background(), DumpFiles{Data: "data.sql"}, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "row counts do not match for 1 tables")
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Run test
+		err := restoreDatabase(context.Background(), DumpFiles{Roles: "roles.sql"}, dbConfig, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to open dump file")
+	})
+
+	t.Run("throws error on copy statements before dropping schemas", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "schema.sql", []byte(schema+";"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "data.sql", []byte("SET session_replication_role = replica;\n\nCOPY \"public\".\"todos\" (\"id\") FROM stdin;\n1\n\\.\n"), 0644))
+		// Run test
+		err := restoreDatabase(context.Background(), DumpFiles{
+			Schema: "schema.sql",
+			Data:   "data.sql",
+		}, dbConfig, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to restore data.sql: COPY statements are not supported")
+	})
+
+	t.Run("throws error on restore failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "schema.sql", []byte(schema+";"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.ListSchemas, escapedSchemas).
+			Reply("SELECT 0").
+			Query(migration.DropObjects).
+			Reply("INSERT 0").
+			Query(schema).
+			ReplyError(pgerrcode.DuplicateTable, `relation "todos" already exists`)
+		// Run test
+		err := restoreDatabase(context.Background(), DumpFiles{Schema: "schema.sql"}, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, `ERROR: relation "todos" already exists (SQLSTATE 42P07)`)
+	})
+}
+
+func TestRunBackup(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("throws error on daily backup", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/database/backups").
+			Reply(http.StatusOK).
+			JSON(api.V1BackupsResponse{Backups: []api.V1Backup{{
+				InsertedAt: "2024-01-01T00:00:00Z",
+			}}})
+		// Run test
+		err := RunBackup(context.Background(), project, "2024-01-01T00:00:00Z")
+		// Check error
+		assert.ErrorContains(t, err, "Restoring daily backups is not supported by the CLI")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing backup", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/database/backups").
+			Reply(http.StatusOK).
+			JSON(api.V1BackupsResponse{PitrEnabled: true})
+		// Run test
+		err := RunBackup(context.Background(), project, "2024-01-01T00:00:00Z")
+		// Check error
+		assert.ErrorContains(t, err, "backup not found: 2024-01-01T00:00:00Z")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,69 @@
+package alter
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/roles"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+type AlterParams struct {
+	Password         string
+	GeneratePassword bool
+	Template         string
+	Schemas          []string
+	ConnectionLimit  *int
+	ValidUntil       string
+}
+
+func Run(ctx context.Context, name string, params AlterParams, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if err := roles.AssertRoleName(name); err != nil {
+		return err
+	}
+	ident := pgx.Identifier{name}.Sanitize()
+	var sql migration.MigrationFile
+	password := params.Password
+	if params.GeneratePassword {
+		var err error
+		if password, err = roles.GeneratePassword(); err != nil {
+			return err
+		}
+	}
+	if len(password) > 0 {
+		sql.Statements = append(sql.Statements, fmt.Sprintf("ALTER ROLE %s WITH PASSWORD %s", ident, utils.QuoteLiteral(password)))
+	}
+	if params.ConnectionLimit != nil {
+		sql.Statements = append(sql.Statements, fmt.Sprintf("ALTER ROLE %s WITH CONNECTION LIMIT %d", ident, *params.ConnectionLimit))
+	}
+	if len(params.ValidUntil) > 0 {
+		sql.Statements = append(sql.Statements, fmt.Sprintf("ALTER ROLE %s WITH VALID UNTIL %s", ident, utils.QuoteLiteral(params.ValidUntil)))
+	}
+	grants, err := roles.GrantStatements(name, params.Template, params.Schemas)
+	if err != nil {
+		return err
+	}
+	sql.Statements = append(sql.Statements, grants...)
+	if len(sql.Statements) == 0 {
+		return errors.New("Nothing to alter. Specify at least one of the flags.")
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if err := sql.ExecBatch(ctx, conn); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Altered role:", utils.Aqua(name))
+	if len(password) > 0 {
+		fmt.Println(roles.ConnectionString(config, name, password))
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/roles"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+func Run(ctx context.Context, name, password, template string, schemas []string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if err := roles.AssertRoleName(name); err != nil {
+		return err
+	}
+	grants, err := roles.GrantStatements(name, template, schemas)
+	if err != nil {
+		return err
+	}
+	if len(password) == 0 {
+		if password, err = roles.GeneratePassword(); err != nil {
+			return err
+		}
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	sql := migration.MigrationFile{Statements: []string{
+		fmt.Sprintf("CREATE ROLE %s WITH LOGIN PASSWORD %s", pgx.Identifier{name}.Sanitize(), utils.QuoteLiteral(password)),
+	}}
+	sql.Statements = append(sql.Statements, grants...)
+	if err := sql.ExecBatch(ctx, conn); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Created role:", utils.Aqua(name))
+	fmt.Println(roles.ConnectionString(config, name, password))
+	return nil
+}
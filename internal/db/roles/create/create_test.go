@@ -0,0 +1,61 @@
+package create
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestCreateRole(t *testing.T) {
+	t.Run("creates role with grants", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(`CREATE ROLE "analyst" WITH LOGIN PASSWORD 'secret'`).
+			Reply("CREATE ROLE").
+			Query(`GRANT USAGE ON SCHEMA "public" TO "analyst"`).
+			Reply("GRANT").
+			Query(`GRANT SELECT ON ALL TABLES IN SCHEMA "public" TO "analyst"`).
+			Reply("GRANT").
+			Query(`GRANT SELECT ON ALL SEQUENCES IN SCHEMA "public" TO "analyst"`).
+			Reply("GRANT").
+			Query(`ALTER DEFAULT PRIVILEGES IN SCHEMA "public" GRANT SELECT ON TABLES TO "analyst"`).
+			Reply("ALTER DEFAULT PRIVILEGES").
+			Query(`ALTER DEFAULT PRIVILEGES IN SCHEMA "public" GRANT SELECT ON SEQUENCES TO "analyst"`).
+			Reply("ALTER DEFAULT PRIVILEGES")
+		// Run test
+		err := Run(context.Background(), "analyst", "secret", "readonly", []string{"public"}, dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on duplicate role", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(`CREATE ROLE "analyst" WITH LOGIN PASSWORD 'secret'`).
+			ReplyError(pgerrcode.DuplicateObject, `role "analyst" already exists`)
+		// Run test
+		err := Run(context.Background(), "analyst", "secret", "", nil, dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, `role "analyst" already exists`)
+	})
+
+	t.Run("throws error on unknown template", func(t *testing.T) {
+		err := Run(context.Background(), "analyst", "", "admin", nil, dbConfig, afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "unknown grant template: admin")
+	})
+}
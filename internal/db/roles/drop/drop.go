@@ -0,0 +1,44 @@
+package drop
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/roles"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+func Run(ctx context.Context, name string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if err := roles.AssertRoleName(name); err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Do you want to drop role %s? Objects owned by it will be reassigned to %s.", utils.Aqua(name), utils.Aqua(config.User))
+	if shouldDrop, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldDrop {
+		return errors.New(context.Canceled)
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	ident := pgx.Identifier{name}.Sanitize()
+	// Reassign owned objects first so that DROP OWNED only revokes privileges
+	sql := migration.MigrationFile{Statements: []string{
+		fmt.Sprintf("REASSIGN OWNED BY %s TO CURRENT_USER", ident),
+		fmt.Sprintf("DROP OWNED BY %s", ident),
+		fmt.Sprintf("DROP ROLE %s", ident),
+	}}
+	if err := sql.ExecBatch(ctx, conn); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Dropped role:", utils.Aqua(name))
+	return nil
+}
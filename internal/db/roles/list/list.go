@@ -0,0 +1,97 @@
+package list
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+//go:embed list.sql
+var listRolesQuery string
+
+type Role struct {
+	Name            string   `json:"name"`
+	CanLogin        bool     `json:"can_login"`
+	Superuser       bool     `json:"superuser"`
+	CreateDb        bool     `json:"create_db"`
+	CreateRole      bool     `json:"create_role"`
+	BypassRLS       bool     `json:"bypass_rls"`
+	ConnectionLimit int      `json:"connection_limit"`
+	ValidUntil      string   `json:"valid_until,omitempty"`
+	MemberOf        []string `json:"member_of"`
+}
+
+func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	roles, err := ListRoles(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, roles)
+	}
+	table := `|NAME|LOGIN|ATTRIBUTES|CONNECTION LIMIT|MEMBER OF|
+|-|-|-|-|-|
+`
+	for _, r := range roles {
+		limit := "unlimited"
+		if r.ConnectionLimit >= 0 {
+			limit = fmt.Sprintf("%d", r.ConnectionLimit)
+		}
+		table += fmt.Sprintf("|`%s`|`%t`|`%s`|`%s`|`%s`|\n", r.Name, r.CanLogin, formatAttributes(r), limit, strings.Join(r.MemberOf, ", "))
+	}
+	return list.RenderTable(table)
+}
+
+func ListRoles(ctx context.Context, conn *pgx.Conn) ([]Role, error) {
+	rows, err := conn.Query(ctx, listRolesQuery)
+	if err != nil {
+		return nil, errors.Errorf("failed to list roles: %w", err)
+	}
+	defer rows.Close()
+	var result []Role
+	for rows.Next() {
+		var r Role
+		if err := rows.Scan(&r.Name, &r.CanLogin, &r.Superuser, &r.CreateDb, &r.CreateRole, &r.BypassRLS, &r.ConnectionLimit, &r.ValidUntil, &r.MemberOf); err != nil {
+			return nil, errors.Errorf("failed to scan role: %w", err)
+		}
+		result = append(result, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to list roles: %w", err)
+	}
+	return result, nil
+}
+
+func formatAttributes(r Role) string {
+	var attrs []string
+	if r.Superuser {
+		attrs = append(attrs, "superuser")
+	}
+	if r.CreateDb {
+		attrs = append(attrs, "create db")
+	}
+	if r.CreateRole {
+		attrs = append(attrs, "create role")
+	}
+	if r.BypassRLS {
+		attrs = append(attrs, "bypass rls")
+	}
+	if len(r.ValidUntil) > 0 {
+		attrs = append(attrs, "valid until "+r.ValidUntil)
+	}
+	return strings.Join(attrs, ", ")
+}
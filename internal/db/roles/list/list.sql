@@ -0,0 +1,16 @@
+select
+  r.rolname,
+  r.rolcanlogin,
+  r.rolsuper,
+  r.rolcreatedb,
+  r.rolcreaterole,
+  r.rolbypassrls,
+  r.rolconnlimit,
+  coalesce(r.rolvaliduntil::text, ''),
+  coalesce(array_agg(m.rolname order by m.rolname) filter (where m.rolname is not null), '{}') as member_of
+from pg_roles r
+left join pg_auth_members am on am.member = r.oid
+left join pg_roles m on m.oid = am.roleid
+where r.rolname not like 'pg\_%'
+group by r.oid, r.rolname, r.rolcanlogin, r.rolsuper, r.rolcreatedb, r.rolcreaterole, r.rolbypassrls, r.rolconnlimit, r.rolvaliduntil
+order by r.rolname
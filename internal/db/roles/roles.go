@@ -0,0 +1,83 @@
+package roles
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	TemplateReadOnly  = "readonly"
+	TemplateReadWrite = "readwrite"
+)
+
+const (
+	passwordLength  = 32
+	passwordCharset = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+var errMissingName = errors.New("Role name must not be empty.")
+
+func AssertRoleName(name string) error {
+	if len(strings.TrimSpace(name)) == 0 {
+		return errors.New(errMissingName)
+	}
+	return nil
+}
+
+// Generates a random alphanumeric password that is safe to embed in connection strings.
+func GeneratePassword() (string, error) {
+	max := big.NewInt(int64(len(passwordCharset)))
+	var sb strings.Builder
+	for i := 0; i < passwordLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", errors.Errorf("failed to generate password: %w", err)
+		}
+		sb.WriteByte(passwordCharset[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+// Lists the statements granting privileges of a template on the given schemas.
+func GrantStatements(role, template string, schemas []string) ([]string, error) {
+	var tablePrivileges, sequencePrivileges string
+	switch template {
+	case "":
+		return nil, nil
+	case TemplateReadOnly:
+		tablePrivileges = "SELECT"
+		sequencePrivileges = "SELECT"
+	case TemplateReadWrite:
+		tablePrivileges = "SELECT, INSERT, UPDATE, DELETE"
+		sequencePrivileges = "USAGE, SELECT, UPDATE"
+	default:
+		return nil, errors.Errorf("unknown grant template: %s", template)
+	}
+	ident := pgx.Identifier{role}.Sanitize()
+	var result []string
+	for _, s := range schemas {
+		schema := pgx.Identifier{s}.Sanitize()
+		result = append(result,
+			fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO %s", schema, ident),
+			fmt.Sprintf("GRANT %s ON ALL TABLES IN SCHEMA %s TO %s", tablePrivileges, schema, ident),
+			fmt.Sprintf("GRANT %s ON ALL SEQUENCES IN SCHEMA %s TO %s", sequencePrivileges, schema, ident),
+			fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA %s GRANT %s ON TABLES TO %s", schema, tablePrivileges, ident),
+			fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA %s GRANT %s ON SEQUENCES TO %s", schema, sequencePrivileges, ident),
+		)
+	}
+	return result, nil
+}
+
+// Returns a connection string for the role using the same host as config.
+func ConnectionString(config pgconn.Config, role, password string) string {
+	config.User = role
+	config.Password = password
+	return utils.ToPostgresURL(config)
+}
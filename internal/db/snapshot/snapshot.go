@@ -0,0 +1,210 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+// Snapshots are template databases cloned from postgres, so their names share the
+// 63 byte limit of identifiers minus the prefix.
+const prefix = "snapshot_"
+
+var (
+	namePattern = regexp.MustCompile(`^[a-z0-9_]{1,54}$`)
+
+	// Database level settings are keyed by oid so they must be copied to the restored database.
+	copySettings = `INSERT INTO pg_db_role_setting (setdatabase, setrole, setconfig)
+SELECT (SELECT oid FROM pg_database WHERE datname = 'postgres_restore'), setrole, setconfig
+FROM pg_db_role_setting WHERE setdatabase = (SELECT oid FROM pg_database WHERE datname = 'postgres')`
+	listSnapshots = `SELECT substr(datname, length($1) + 1), pg_size_pretty(pg_database_size(oid)), coalesce(shobj_description(oid, 'pg_database'), '')
+FROM pg_database WHERE starts_with(datname, $1) ORDER BY datname`
+)
+
+type Snapshot struct {
+	Name      string `json:"name"`
+	Size      string `json:"size"`
+	CreatedAt string `json:"created_at"`
+}
+
+func databaseName(name string) (string, error) {
+	if !namePattern.MatchString(name) {
+		return "", errors.New("Invalid snapshot name: " + utils.Aqua(name) + ". Must contain only lowercase letters, digits, and underscores.")
+	}
+	return pgx.Identifier{prefix + name}.Sanitize(), nil
+}
+
+// Create clones the local postgres database into a new snapshot. Clients are disconnected
+// because Postgres does not allow copying a template database with active connections.
+func Create(ctx context.Context, name string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	target, err := databaseName(name)
+	if err != nil {
+		return err
+	}
+	conn, err := connect(ctx, fsys, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	fmt.Fprintln(os.Stderr, "Creating snapshot", utils.Aqua(name)+"...")
+	// Database commands cannot run inside the implicit transaction of a batch
+	sql := migration.MigrationFile{Statements: []string{
+		"CREATE DATABASE " + target + " WITH TEMPLATE postgres OWNER postgres",
+		// Records the creation time in ISO 8601 since pg_database does not track it
+		"DO $$BEGIN EXECUTE format('COMMENT ON DATABASE %I IS %L', '" + prefix + name + "', to_json(now()) #>> '{}'); END$$",
+		// Prevents clients from blocking restore by connecting to the snapshot
+		"ALTER DATABASE " + target + " ALLOW_CONNECTIONS false",
+	}}
+	if err := withClientsDisconnected(ctx, conn, func() error {
+		return sql.ExecEach(ctx, conn)
+	}); err != nil {
+		return err
+	}
+	fmt.Println("Created snapshot " + utils.Aqua(name) + ".")
+	return nil
+}
+
+// Restore replaces the local postgres database with a copy of the snapshot, which is kept
+// so that it can be restored again.
+func Restore(ctx context.Context, name string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	source, err := databaseName(name)
+	if err != nil {
+		return err
+	}
+	conn, err := connect(ctx, fsys, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if err := assertExists(ctx, conn, name); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Restoring snapshot", utils.Aqua(name)+"...")
+	// Clone before dropping so that a failed copy leaves the current database intact
+	sql := migration.MigrationFile{Statements: []string{
+		"DROP DATABASE IF EXISTS postgres_restore",
+		"CREATE DATABASE postgres_restore WITH TEMPLATE " + source + " OWNER postgres",
+		copySettings,
+		"DROP DATABASE postgres WITH (FORCE)",
+		"ALTER DATABASE postgres_restore RENAME TO postgres",
+	}}
+	if err := withClientsDisconnected(ctx, conn, func() error {
+		return sql.ExecEach(ctx, conn)
+	}); err != nil {
+		return err
+	}
+	fmt.Println("Restored snapshot " + utils.Aqua(name) + ".")
+	return nil
+}
+
+func Delete(ctx context.Context, name string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	target, err := databaseName(name)
+	if err != nil {
+		return err
+	}
+	conn, err := connect(ctx, fsys, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if err := assertExists(ctx, conn, name); err != nil {
+		return err
+	}
+	if _, err := conn.Exec(ctx, "DROP DATABASE "+target); err != nil {
+		return errors.Errorf("failed to delete snapshot: %w", err)
+	}
+	fmt.Println("Deleted snapshot " + utils.Aqua(name) + ".")
+	return nil
+}
+
+func List(ctx context.Context, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := connect(ctx, fsys, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	snapshots, err := loadSnapshots(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, snapshots)
+	}
+	table := "|NAME|SIZE|CREATED AT (UTC)|\n|-|-|-|\n"
+	for _, s := range snapshots {
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", s.Name, s.Size, utils.FormatTimestamp(s.CreatedAt))
+	}
+	return list.RenderTable(table)
+}
+
+func loadSnapshots(ctx context.Context, conn *pgx.Conn) ([]Snapshot, error) {
+	rows, err := conn.Query(ctx, listSnapshots, prefix)
+	if err != nil {
+		return nil, errors.Errorf("failed to list snapshots: %w", err)
+	}
+	result := []Snapshot{}
+	for rows.Next() {
+		var s Snapshot
+		if err := rows.Scan(&s.Name, &s.Size, &s.CreatedAt); err != nil {
+			return nil, errors.Errorf("failed to scan snapshot: %w", err)
+		}
+		result = append(result, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to list snapshots: %w", err)
+	}
+	return result, nil
+}
+
+func assertExists(ctx context.Context, conn *pgx.Conn, name string) error {
+	snapshots, err := loadSnapshots(ctx, conn)
+	if err != nil {
+		return err
+	}
+	for _, s := range snapshots {
+		if s.Name == name {
+			return nil
+		}
+	}
+	return errors.New("Snapshot " + utils.Aqua(name) + " does not exist.")
+}
+
+// Connects to template1 because the postgres database cannot be copied or dropped while
+// we are connected to it.
+func connect(ctx context.Context, fsys afero.Fs, options ...func(*pgx.ConnConfig)) (*pgx.Conn, error) {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return nil, err
+	}
+	if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+		return nil, err
+	}
+	return utils.ConnectLocalPostgres(ctx, pgconn.Config{User: "supabase_admin", Database: "template1"}, options...)
+}
+
+// Connections are allowed again even if f fails, followed by a restart of the database
+// because some extensions do not recover from pg_terminate_backend.
+func withClientsDisconnected(ctx context.Context, conn *pgx.Conn, f func() error) error {
+	if err := reset.DisconnectClients(ctx, conn); err != nil {
+		return err
+	}
+	defer func() {
+		if err := reset.RestartDatabase(context.Background(), os.Stderr); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to restart database:", err)
+		}
+	}()
+	err := f()
+	if _, allowErr := conn.Exec(context.Background(), "ALTER DATABASE postgres ALLOW_CONNECTIONS true"); allowErr != nil {
+		err = errors.Join(err, errors.Errorf("failed to allow connections: %w", allowErr))
+	}
+	return err
+}
@@ -0,0 +1,192 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/h2non/gock"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+func setup(t *testing.T) afero.Fs {
+	fsys := afero.NewMemMapFs()
+	require.NoError(t, utils.WriteConfig(fsys, false))
+	// Setup mock docker
+	require.NoError(t, apitest.MockDocker(utils.Docker))
+	gock.New(utils.Docker.DaemonHost()).
+		Get("/v" + utils.Docker.ClientVersion() + "/containers").
+		Reply(http.StatusOK).
+		JSON(types.ContainerJSON{})
+	return fsys
+}
+
+// Restart is best effort so a failure is only printed to stderr.
+func mockRestart() {
+	gock.New(utils.Docker.DaemonHost()).
+		Post("/v" + utils.Docker.ClientVersion() + "/containers").
+		Reply(http.StatusServiceUnavailable)
+}
+
+func mockDisconnect(conn *pgtest.MockConn) *pgtest.MockConn {
+	return conn.Query("ALTER DATABASE postgres ALLOW_CONNECTIONS false;").
+		Reply("ALTER DATABASE").
+		Query(fmt.Sprintf(utils.TerminateDbSqlFmt, "postgres")).
+		Reply("DO")
+}
+
+func TestCreateSnapshot(t *testing.T) {
+	t.Run("clones postgres database", func(t *testing.T) {
+		defer gock.OffAll()
+		fsys := setup(t)
+		mockRestart()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		mockDisconnect(conn).
+			Query(`CREATE DATABASE "snapshot_before_test" WITH TEMPLATE postgres OWNER postgres`).
+			Reply("CREATE DATABASE").
+			Query(`DO $$BEGIN EXECUTE format('COMMENT ON DATABASE %I IS %L', 'snapshot_before_test', to_json(now()) #>> '{}'); END$$`).
+			Reply("DO").
+			Query(`ALTER DATABASE "snapshot_before_test" ALLOW_CONNECTIONS false`).
+			Reply("ALTER DATABASE").
+			Query("ALTER DATABASE postgres ALLOW_CONNECTIONS true").
+			Reply("ALTER DATABASE")
+		// Run test
+		err := Create(context.Background(), "before_test", fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("allows connections on failure", func(t *testing.T) {
+		defer gock.OffAll()
+		fsys := setup(t)
+		mockRestart()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		mockDisconnect(conn).
+			Query(`CREATE DATABASE "snapshot_existing" WITH TEMPLATE postgres OWNER postgres`).
+			ReplyError(pgerrcode.DuplicateDatabase, `database "snapshot_existing" already exists`).
+			Query("ALTER DATABASE postgres ALLOW_CONNECTIONS true").
+			Reply("ALTER DATABASE")
+		// Run test
+		err := Create(context.Background(), "existing", fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, `database "snapshot_existing" already exists`)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid name", func(t *testing.T) {
+		err := Create(context.Background(), "Not-Valid", afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "Invalid snapshot name:")
+	})
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	t.Run("replaces postgres database", func(t *testing.T) {
+		defer gock.OffAll()
+		fsys := setup(t)
+		mockRestart()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listSnapshots, prefix).
+			Reply("SELECT 1", []interface{}{"before_test", "10 MB", "2026-10-15T08:00:00.123456+00:00"})
+		mockDisconnect(conn).
+			Query("DROP DATABASE IF EXISTS postgres_restore").
+			Reply("DROP DATABASE").
+			Query(`CREATE DATABASE postgres_restore WITH TEMPLATE "snapshot_before_test" OWNER postgres`).
+			Reply("CREATE DATABASE").
+			Query(copySettings).
+			Reply("INSERT 0 2").
+			Query("DROP DATABASE postgres WITH (FORCE)").
+			Reply("DROP DATABASE").
+			Query("ALTER DATABASE postgres_restore RENAME TO postgres").
+			Reply("ALTER DATABASE").
+			Query("ALTER DATABASE postgres ALLOW_CONNECTIONS true").
+			Reply("ALTER DATABASE")
+		// Run test
+		err := Restore(context.Background(), "before_test", fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing snapshot", func(t *testing.T) {
+		defer gock.OffAll()
+		fsys := setup(t)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listSnapshots, prefix).
+			Reply("SELECT 0")
+		// Run test
+		err := Restore(context.Background(), "missing", fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "does not exist.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestListSnapshots(t *testing.T) {
+	t.Run("lists snapshots as table", func(t *testing.T) {
+		defer gock.OffAll()
+		fsys := setup(t)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listSnapshots, prefix).
+			Reply("SELECT 2",
+				[]interface{}{"before_test", "10 MB", "2026-10-15T08:00:00.123456+00:00"},
+				[]interface{}{"seeded", "12 MB", ""},
+			)
+		// Run test
+		err := List(context.Background(), fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		defer gock.OffAll()
+		fsys := setup(t)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listSnapshots, prefix).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for database")
+		// Run test
+		err := List(context.Background(), fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "failed to list snapshots:")
+	})
+}
+
+func TestDeleteSnapshot(t *testing.T) {
+	t.Run("drops snapshot database", func(t *testing.T) {
+		defer gock.OffAll()
+		fsys := setup(t)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listSnapshots, prefix).
+			Reply("SELECT 1", []interface{}{"before_test", "10 MB", ""}).
+			Query(`DROP DATABASE "snapshot_before_test"`).
+			Reply("DROP DATABASE")
+		// Run test
+		err := Delete(context.Background(), "before_test", fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
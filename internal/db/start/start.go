@@ -161,6 +161,21 @@ EOF`}
 }
 
 func WaitForHealthyService(ctx context.Context, timeout time.Duration, started ...string) error {
+	started, err := RetryHealthCheck(ctx, timeout, started...)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		// Print container logs for easier debugging
+		for _, containerId := range started {
+			fmt.Fprintln(os.Stderr, containerId, "container logs:")
+			if err := utils.DockerStreamLogsOnce(context.Background(), containerId, os.Stderr, os.Stderr); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+	}
+	return err
+}
+
+// Returns the containers that are still unhealthy after timeout.
+func RetryHealthCheck(ctx context.Context, timeout time.Duration, started ...string) ([]string, error) {
 	probe := func() error {
 		var errHealth []error
 		var unhealthy []string
@@ -178,16 +193,7 @@ func WaitForHealthyService(ctx context.Context, timeout time.Duration, started .
 		uint64(timeout.Seconds()),
 	), ctx)
 	err := backoff.Retry(probe, policy)
-	if err != nil && !errors.Is(err, context.Canceled) {
-		// Print container logs for easier debugging
-		for _, containerId := range started {
-			fmt.Fprintln(os.Stderr, containerId, "container logs:")
-			if err := utils.DockerStreamLogsOnce(context.Background(), containerId, os.Stderr, os.Stderr); err != nil {
-				fmt.Fprintln(os.Stderr, err)
-			}
-		}
-	}
-	return err
+	return started, err
 }
 
 func IsUnhealthyError(err error) bool {
@@ -0,0 +1,82 @@
+package url
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+// Placeholder printed in place of the database password, same as the dashboard.
+const PasswordPlaceholder = "[YOUR-PASSWORD]"
+
+// Supavisor listens on this port in transaction mode on hosted projects.
+const transactionPort = 6543
+
+func Run(ctx context.Context, config pgconn.Config, pooler bool, role string) error {
+	result, err := GetConnectionString(config, pooler, role)
+	if err != nil {
+		return err
+	}
+	fmt.Println(result)
+	return nil
+}
+
+func GetConnectionString(config pgconn.Config, pooler bool, role string) (string, error) {
+	if pooler {
+		poolerConfig, err := getPoolerConfig(config)
+		if err != nil {
+			return "", err
+		}
+		config = poolerConfig
+	} else if !utils.IsLocalDatabase(config) {
+		// Linked config may point to session pooler so we rebuild the direct config
+		config = pgconn.Config{
+			Host:     utils.GetSupabaseDbHost(flags.ProjectRef),
+			Port:     5432,
+			User:     "postgres",
+			Password: config.Password,
+			Database: "postgres",
+		}
+	}
+	if len(role) > 0 {
+		config.User = replaceRole(config.User, role)
+	}
+	// Placeholder must not be percent encoded to be recognisable
+	if config.Password == PasswordPlaceholder {
+		config.Password = ""
+		return strings.Replace(utils.ToPostgresURL(config), ":@", ":"+PasswordPlaceholder+"@", 1), nil
+	}
+	return utils.ToPostgresURL(config), nil
+}
+
+func getPoolerConfig(config pgconn.Config) (pgconn.Config, error) {
+	if utils.IsLocalDatabase(config) {
+		if !utils.Config.Db.Pooler.Enabled {
+			return pgconn.Config{}, errors.New("Local connection pooler is not enabled. Set db.pooler.enabled to true in config.toml.")
+		}
+		config.Port = utils.Config.Db.Pooler.Port
+		config.User = fmt.Sprintf("%s.%s", config.User, utils.Config.Db.Pooler.TenantId)
+		return config, nil
+	}
+	poolerConfig := utils.GetPoolerConfig(flags.ProjectRef)
+	if poolerConfig == nil {
+		utils.CmdSuggestion = fmt.Sprintf("Run %s to refresh the pooler configuration.", utils.Aqua("supabase link"))
+		return pgconn.Config{}, errors.New("Connection pooler is not configured for the linked project.")
+	}
+	poolerConfig.Port = transactionPort
+	poolerConfig.Password = config.Password
+	return *poolerConfig, nil
+}
+
+// Pooler usernames are suffixed with the tenant id, ie. postgres.<ref>
+func replaceRole(user, role string) string {
+	if _, tenant, found := strings.Cut(user, "."); found {
+		return role + "." + tenant
+	}
+	return role
+}
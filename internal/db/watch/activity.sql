@@ -0,0 +1,16 @@
+SELECT
+  a.pid,
+  coalesce(a.usename::text, '') AS usename,
+  coalesce(a.application_name, '') AS application_name,
+  coalesce(a.state, '') AS state,
+  extract(epoch FROM now() - coalesce(a.query_start, now()))::float8 AS duration,
+  coalesce(a.wait_event_type || ': ' || a.wait_event, '') AS wait_event,
+  pg_blocking_pids(a.pid) AS blocked_by,
+  (SELECT count(*) FROM pg_locks l WHERE l.pid = a.pid AND l.granted)::int4 AS locks,
+  coalesce(a.query, '') AS query
+FROM pg_stat_activity a
+WHERE a.pid <> pg_backend_pid()
+  AND a.backend_type = 'client backend'
+  AND a.state IS NOT NULL
+  AND a.state <> 'idle'
+ORDER BY duration DESC
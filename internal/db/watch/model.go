@@ -0,0 +1,168 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	headerStyle   = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("170"))
+	blockedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	helpStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+)
+
+type (
+	activityMsg struct {
+		rows []Activity
+		err  error
+	}
+	tickMsg      time.Time
+	terminateMsg struct {
+		pid int32
+		err error
+	}
+)
+
+type model struct {
+	ctx      context.Context
+	session  *session
+	interval time.Duration
+	kill     bool
+
+	rows    []Activity
+	cursor  int
+	confirm int32
+	status  string
+	err     error
+	width   int
+}
+
+func newModel(ctx context.Context, s *session, interval time.Duration, kill bool) model {
+	return model{ctx: ctx, session: s, interval: interval, kill: kill}
+}
+
+func (m model) Init() tea.Cmd {
+	return m.refresh
+}
+
+func (m model) refresh() tea.Msg {
+	rows, err := m.session.activity(m.ctx)
+	return activityMsg{rows: rows, err: err}
+}
+
+func (m model) terminate(pid int32) tea.Cmd {
+	return func() tea.Msg {
+		return terminateMsg{pid: pid, err: m.session.terminate(m.ctx, pid)}
+	}
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+	case activityMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, tea.Quit
+		}
+		m.rows = msg.rows
+		if m.cursor >= len(m.rows) {
+			m.cursor = max(len(m.rows)-1, 0)
+		}
+		return m, tea.Tick(m.interval, func(t time.Time) tea.Msg {
+			return tickMsg(t)
+		})
+	case tickMsg:
+		return m, m.refresh
+	case terminateMsg:
+		if msg.err != nil {
+			m.status = msg.err.Error()
+		} else {
+			m.status = fmt.Sprintf("Terminated backend %d.", msg.pid)
+		}
+		return m, nil
+	case tea.KeyMsg:
+		// Any key other than y dismisses a pending confirmation
+		if m.confirm > 0 {
+			pid := m.confirm
+			m.confirm = 0
+			if msg.String() == "y" {
+				m.status = fmt.Sprintf("Terminating backend %d...", pid)
+				return m, m.terminate(pid)
+			}
+			m.status = ""
+			return m, nil
+		}
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down":
+			if m.cursor < len(m.rows)-1 {
+				m.cursor++
+			}
+		case "k":
+			if m.kill && m.cursor < len(m.rows) {
+				m.confirm = m.rows[m.cursor].Pid
+				m.status = fmt.Sprintf("Terminate backend %d? [y/N]", m.confirm)
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m model) View() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d active queries, refreshing every %s\n\n", len(m.rows), m.interval)
+	b.WriteString(headerStyle.Render(fmt.Sprintf("  %-8s %-10s %-20s %-24s %-12s %-6s %s", "PID", "DURATION", "STATE", "WAIT EVENT", "BLOCKED BY", "LOCKS", "QUERY")))
+	b.WriteString("\n")
+	for i, r := range m.rows {
+		line := fmt.Sprintf("%-8d %-10s %-20s %-24s %-12s %-6d %s",
+			r.Pid,
+			formatDuration(r.Duration),
+			truncate(r.State, 20),
+			truncate(r.WaitEvent, 24),
+			truncate(formatPids(r.BlockedBy), 12),
+			r.Locks,
+			compactQuery(r.Query),
+		)
+		if m.width > 2 {
+			line = truncate(line, m.width-2)
+		}
+		switch {
+		case i == m.cursor:
+			line = selectedStyle.Render("> " + line)
+		case len(r.BlockedBy) > 0:
+			line = blockedStyle.Render("  " + line)
+		default:
+			line = "  " + line
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(m.status) > 0 {
+		b.WriteString("\n" + m.status + "\n")
+	}
+	help := "↑/↓ select • q quit"
+	if m.kill {
+		help = "↑/↓ select • k terminate • q quit"
+	}
+	b.WriteString("\n" + helpStyle.Render(help))
+	return b.String()
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:max(n-1, 0)]) + "…"
+	}
+	return s
+}
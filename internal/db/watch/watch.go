@@ -0,0 +1,146 @@
+package watch
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgxv5"
+	"golang.org/x/term"
+)
+
+var (
+	//go:embed activity.sql
+	ActivityQuery string
+
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+const TerminateQuery = "SELECT pg_terminate_backend($1)"
+
+type Activity struct {
+	Pid             int32   `db:"pid" json:"pid"`
+	User            string  `db:"usename" json:"user"`
+	ApplicationName string  `db:"application_name" json:"application_name"`
+	State           string  `db:"state" json:"state"`
+	Duration        float64 `db:"duration" json:"duration"`
+	WaitEvent       string  `db:"wait_event" json:"wait_event"`
+	BlockedBy       []int32 `db:"blocked_by" json:"blocked_by"`
+	Locks           int32   `db:"locks" json:"locks"`
+	Query           string  `db:"query" json:"query"`
+}
+
+// Run shows a live view of running queries, refreshed every interval. When stdin is not a
+// terminal, a single snapshot is printed instead.
+func Run(ctx context.Context, interval time.Duration, kill bool, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		if kill {
+			return errors.New("--kill requires an interactive terminal")
+		}
+		return PrintSnapshot(ctx, conn)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	m := newModel(ctx, &session{conn: conn}, interval, kill)
+	state, err := tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	if err != nil && !errors.Is(err, tea.ErrProgramKilled) {
+		return errors.Errorf("failed to watch queries: %w", err)
+	}
+	if final, ok := state.(model); ok && final.err != nil {
+		return final.err
+	}
+	return nil
+}
+
+func PrintSnapshot(ctx context.Context, conn *pgx.Conn) error {
+	result, err := QueryActivity(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, result)
+	}
+	table := "|PID|DURATION|STATE|WAIT EVENT|BLOCKED BY|LOCKS|QUERY|\n|-|-|-|-|-|-|-|\n"
+	for _, r := range result {
+		table += fmt.Sprintf(
+			"|`%d`|`%s`|`%s`|`%s`|`%s`|`%d`|`%s`|\n",
+			r.Pid,
+			formatDuration(r.Duration),
+			r.State,
+			r.WaitEvent,
+			formatPids(r.BlockedBy),
+			r.Locks,
+			strings.ReplaceAll(compactQuery(r.Query), "|", `\|`),
+		)
+	}
+	return list.RenderTable(table)
+}
+
+func QueryActivity(ctx context.Context, conn *pgx.Conn) ([]Activity, error) {
+	rows, err := conn.Query(ctx, ActivityQuery)
+	if err != nil {
+		return nil, errors.Errorf("failed to query activity: %w", err)
+	}
+	return pgxv5.CollectRows[Activity](rows)
+}
+
+func Terminate(ctx context.Context, conn *pgx.Conn, pid int32) error {
+	var terminated bool
+	if err := conn.QueryRow(ctx, TerminateQuery, pid).Scan(&terminated); err != nil {
+		return errors.Errorf("failed to terminate backend: %w", err)
+	} else if !terminated {
+		return errors.Errorf("backend not found: %d", pid)
+	}
+	return nil
+}
+
+// A single connection is shared by the refresh loop and kill actions, which may run
+// concurrently as tea commands.
+type session struct {
+	mu   sync.Mutex
+	conn *pgx.Conn
+}
+
+func (s *session) activity(ctx context.Context) ([]Activity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return QueryActivity(ctx, s.conn)
+}
+
+func (s *session) terminate(ctx context.Context, pid int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Terminate(ctx, s.conn, pid)
+}
+
+func formatDuration(seconds float64) string {
+	return time.Duration(seconds * float64(time.Second)).Round(100 * time.Millisecond).String()
+}
+
+func formatPids(pids []int32) string {
+	result := make([]string, len(pids))
+	for i, pid := range pids {
+		result[i] = fmt.Sprintf("%d", pid)
+	}
+	return strings.Join(result, ",")
+}
+
+func compactQuery(query string) string {
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(query, " "))
+}
@@ -0,0 +1,130 @@
+package watch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestQueryActivity(t *testing.T) {
+	t.Run("collects running queries", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(ActivityQuery).
+			Reply("SELECT 1", Activity{
+				Pid:       42,
+				State:     "active",
+				Duration:  12.5,
+				WaitEvent: "Lock: relation",
+				BlockedBy: []int32{7},
+				Locks:     3,
+				Query:     "ALTER TABLE\n  todos ADD COLUMN done bool",
+			})
+		db, err := utils.ConnectByConfig(context.Background(), dbConfig, conn.Intercept)
+		require.NoError(t, err)
+		defer db.Close(context.Background())
+		// Run test
+		result, err := QueryActivity(context.Background(), db)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, int32(42), result[0].Pid)
+		assert.Equal(t, []int32{7}, result[0].BlockedBy)
+		assert.Equal(t, "ALTER TABLE todos ADD COLUMN done bool", compactQuery(result[0].Query))
+	})
+
+	t.Run("throws error on permission denied", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(ActivityQuery).
+			ReplyError("42501", "permission denied for view pg_stat_activity")
+		db, err := utils.ConnectByConfig(context.Background(), dbConfig, conn.Intercept)
+		require.NoError(t, err)
+		defer db.Close(context.Background())
+		// Run test
+		_, err = QueryActivity(context.Background(), db)
+		// Check error
+		assert.ErrorContains(t, err, "permission denied for view pg_stat_activity")
+	})
+}
+
+func TestTerminate(t *testing.T) {
+	t.Run("terminates backend", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(TerminateQuery, int32(42)).
+			Reply("SELECT 1", []interface{}{true})
+		db, err := utils.ConnectByConfig(context.Background(), dbConfig, conn.Intercept)
+		require.NoError(t, err)
+		defer db.Close(context.Background())
+		// Run test
+		err = Terminate(context.Background(), db, 42)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on missing backend", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(TerminateQuery, int32(42)).
+			Reply("SELECT 1", []interface{}{false})
+		db, err := utils.ConnectByConfig(context.Background(), dbConfig, conn.Intercept)
+		require.NoError(t, err)
+		defer db.Close(context.Background())
+		// Run test
+		err = Terminate(context.Background(), db, 42)
+		// Check error
+		assert.ErrorContains(t, err, "backend not found: 42")
+	})
+}
+
+func TestModel(t *testing.T) {
+	rows := []Activity{{Pid: 1, Query: "SELECT 1"}, {Pid: 2, BlockedBy: []int32{1}, Query: "SELECT 2"}}
+
+	t.Run("confirms before terminating", func(t *testing.T) {
+		m := newModel(context.Background(), nil, time.Second, true)
+		state, _ := m.Update(activityMsg{rows: rows})
+		state, _ = state.Update(tea.KeyMsg{Type: tea.KeyDown})
+		state, _ = state.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+		assert.Equal(t, int32(2), state.(model).confirm)
+		assert.Contains(t, state.View(), "Terminate backend 2? [y/N]")
+		// Confirm termination
+		state, cmd := state.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+		assert.Zero(t, state.(model).confirm)
+		assert.NotNil(t, cmd)
+	})
+
+	t.Run("ignores kill key without flag", func(t *testing.T) {
+		m := newModel(context.Background(), nil, time.Second, false)
+		state, _ := m.Update(activityMsg{rows: rows})
+		state, cmd := state.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+		assert.Zero(t, state.(model).confirm)
+		assert.Nil(t, cmd)
+	})
+
+	t.Run("clamps cursor on fewer rows", func(t *testing.T) {
+		m := newModel(context.Background(), nil, time.Second, false)
+		m.cursor = 1
+		state, _ := m.Update(activityMsg{rows: rows[:1]})
+		assert.Equal(t, 0, state.(model).cursor)
+	})
+}
@@ -0,0 +1,113 @@
+package create
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/new"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var AllowedEvents = []string{"insert", "update", "delete"}
+
+type WebhookParams struct {
+	Name string
+	// Table name optionally qualified by schema, ie. public.orders
+	Table     string
+	Events    []string
+	Url       string
+	Method    string
+	Headers   map[string]string
+	TimeoutMs uint
+}
+
+func Run(params WebhookParams, fsys afero.Fs) error {
+	sql, err := GenerateSQL(params)
+	if err != nil {
+		return err
+	}
+	path := new.GetMigrationPath(utils.GetCurrentTimestamp(), "webhook_"+webhookName(params))
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := afero.WriteFile(fsys, path, []byte(sql), 0644); err != nil {
+		return errors.Errorf("failed to write migration: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, render.T("created_migration", utils.Bold(path)))
+	fmt.Fprintln(os.Stderr, "Run "+utils.Aqua("supabase migration up")+" to apply the webhook.")
+	return nil
+}
+
+// Generates the same trigger as webhooks created from the dashboard, which calls
+// supabase_functions.http_request to send requests asynchronously via pg_net.
+func GenerateSQL(params WebhookParams) (string, error) {
+	if len(params.Table) == 0 {
+		return "", errors.New("Missing table name.")
+	}
+	if len(params.Events) == 0 {
+		return "", errors.New("At least one event must be specified.")
+	}
+	var events []string
+	for _, e := range params.Events {
+		e = strings.ToLower(strings.TrimSpace(e))
+		if !slices.Contains(AllowedEvents, e) {
+			return "", errors.Errorf("invalid event %q: must be one of %s", e, strings.Join(AllowedEvents, ", "))
+		}
+		events = append(events, strings.ToUpper(e))
+	}
+	if parsed, err := url.Parse(params.Url); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+		return "", errors.Errorf("invalid webhook url %q: must be an absolute http(s) url", params.Url)
+	}
+	method := strings.ToUpper(params.Method)
+	if method != "POST" && method != "GET" {
+		return "", errors.Errorf("invalid method %q: must be POST or GET", params.Method)
+	}
+	headers := params.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	if _, ok := headers["Content-type"]; !ok {
+		headers["Content-type"] = "application/json"
+	}
+	encoded, err := json.Marshal(headers)
+	if err != nil {
+		return "", errors.Errorf("failed to encode headers: %w", err)
+	}
+	schema, table := splitTable(params.Table)
+	var sql strings.Builder
+	fmt.Fprintf(&sql, "create trigger %s\n", pgx.Identifier{webhookName(params)}.Sanitize())
+	fmt.Fprintf(&sql, "after %s on %s\n", strings.Join(utils.RemoveDuplicates(events), " or "), pgx.Identifier{schema, table}.Sanitize())
+	sql.WriteString("for each row\n")
+	sql.WriteString("execute function supabase_functions.http_request(\n")
+	fmt.Fprintf(&sql, "  %s,\n", utils.QuoteLiteral(params.Url))
+	fmt.Fprintf(&sql, "  %s,\n", utils.QuoteLiteral(method))
+	fmt.Fprintf(&sql, "  %s,\n", utils.QuoteLiteral(string(encoded)))
+	sql.WriteString("  '{}',\n")
+	fmt.Fprintf(&sql, "  '%d'\n", params.TimeoutMs)
+	sql.WriteString(");\n")
+	return sql.String(), nil
+}
+
+func splitTable(name string) (string, string) {
+	if schema, table, found := strings.Cut(name, "."); found {
+		return schema, table
+	}
+	return "public", name
+}
+
+func webhookName(params WebhookParams) string {
+	if len(params.Name) > 0 {
+		return params.Name
+	}
+	_, table := splitTable(params.Table)
+	return table + "_webhook"
+}
@@ -0,0 +1,69 @@
+package create
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestGenerateSQL(t *testing.T) {
+	t.Run("generates webhook trigger", func(t *testing.T) {
+		sql, err := GenerateSQL(WebhookParams{
+			Table:     "orders",
+			Events:    []string{"insert", "UPDATE", "insert"},
+			Url:       "https://example.com/hook?key=it's",
+			Method:    "post",
+			Headers:   map[string]string{"Authorization": "Bearer token"},
+			TimeoutMs: 1000,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `create trigger "orders_webhook"
+after INSERT or UPDATE on "public"."orders"
+for each row
+execute function supabase_functions.http_request(
+  'https://example.com/hook?key=it''s',
+  'POST',
+  '{"Authorization":"Bearer token","Content-type":"application/json"}',
+  '{}',
+  '1000'
+);
+`, sql)
+	})
+
+	t.Run("throws error on invalid event", func(t *testing.T) {
+		_, err := GenerateSQL(WebhookParams{Table: "orders", Events: []string{"truncate"}, Url: "https://example.com", Method: "POST"})
+		assert.ErrorContains(t, err, `invalid event "truncate"`)
+	})
+
+	t.Run("throws error on invalid url", func(t *testing.T) {
+		_, err := GenerateSQL(WebhookParams{Table: "orders", Events: []string{"insert"}, Url: "example.com", Method: "POST"})
+		assert.ErrorContains(t, err, `invalid webhook url "example.com"`)
+	})
+
+	t.Run("throws error on invalid method", func(t *testing.T) {
+		_, err := GenerateSQL(WebhookParams{Table: "orders", Events: []string{"insert"}, Url: "https://example.com", Method: "PUT"})
+		assert.ErrorContains(t, err, `invalid method "PUT"`)
+	})
+}
+
+func TestCreateWebhook(t *testing.T) {
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	// Run test
+	err := Run(WebhookParams{
+		Name:   "notify_shipping",
+		Table:  "sales.orders",
+		Events: []string{"delete"},
+		Url:    "http://host.docker.internal:3000",
+		Method: "GET",
+	}, fsys)
+	// Check error
+	assert.NoError(t, err)
+	files, err := afero.ReadDir(fsys, utils.MigrationsDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Regexp(t, `^\d{14}_webhook_notify_shipping\.sql$`, files[0].Name())
+}
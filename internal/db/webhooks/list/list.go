@@ -0,0 +1,92 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type Webhook struct {
+	Name   string   `json:"name"`
+	Schema string   `json:"schema"`
+	Table  string   `json:"table"`
+	Events []string `json:"events"`
+	Method string   `json:"method"`
+	Url    string   `json:"url"`
+}
+
+var (
+	//go:embed list.sql
+	listWebhooks string
+	// Trigger event bits from pg_trigger.tgtype, see include/catalog/pg_trigger.h
+	eventBits = []struct {
+		mask int32
+		name string
+	}{
+		{1 << 2, "insert"},
+		{1 << 3, "delete"},
+		{1 << 4, "update"},
+	}
+)
+
+func Run(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	webhooks, err := ListWebhooks(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeRows(utils.OutputFormat.Value, os.Stdout, webhooks)
+	}
+	table := "|NAME|TABLE|EVENTS|METHOD|URL|\n|-|-|-|-|-|\n"
+	for _, w := range webhooks {
+		table += fmt.Sprintf("|`%s`|`%s.%s`|`%s`|`%s`|`%s`|\n", w.Name, w.Schema, w.Table, strings.Join(w.Events, ", "), w.Method, w.Url)
+	}
+	return list.RenderTable(table)
+}
+
+func ListWebhooks(ctx context.Context, conn *pgx.Conn) ([]Webhook, error) {
+	rows, err := conn.Query(ctx, listWebhooks)
+	if err != nil {
+		return nil, errors.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+	var result []Webhook
+	for rows.Next() {
+		var w Webhook
+		var tgtype int32
+		var tgargs []byte
+		if err := rows.Scan(&w.Name, &w.Schema, &w.Table, &tgtype, &tgargs); err != nil {
+			return nil, errors.Errorf("failed to scan webhook: %w", err)
+		}
+		for _, e := range eventBits {
+			if tgtype&e.mask != 0 {
+				w.Events = append(w.Events, e.name)
+			}
+		}
+		// Trigger arguments are stored as null terminated strings: url, method, headers, params, timeout
+		args := bytes.Split(bytes.TrimSuffix(tgargs, []byte{0}), []byte{0})
+		if len(args) > 1 {
+			w.Url = string(args[0])
+			w.Method = string(args[1])
+		}
+		result = append(result, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to list webhooks: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,8 @@
+SELECT t.tgname, n.nspname, c.relname, t.tgtype::int, t.tgargs
+FROM pg_trigger t
+JOIN pg_class c ON c.oid = t.tgrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_proc p ON p.oid = t.tgfoid
+JOIN pg_namespace pn ON pn.oid = p.pronamespace
+WHERE pn.nspname = 'supabase_functions' AND p.proname = 'http_request' AND NOT t.tgisinternal
+ORDER BY n.nspname, c.relname, t.tgname
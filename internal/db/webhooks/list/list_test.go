@@ -0,0 +1,45 @@
+package list
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+func TestListWebhooks(t *testing.T) {
+	t.Run("parses trigger arguments", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		args := []byte("https://example.com/hook\x00POST\x00{}\x00{}\x001000\x00")
+		conn.Query(listWebhooks).
+			Reply("SELECT 1", []interface{}{"orders_webhook", "public", "orders", int32(1 | 1<<2 | 1<<4), args})
+		// Run test
+		webhooks, err := ListWebhooks(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Webhook{{
+			Name:   "orders_webhook",
+			Schema: "public",
+			Table:  "orders",
+			Events: []string{"insert", "update"},
+			Method: "POST",
+			Url:    "https://example.com/hook",
+		}}, webhooks)
+	})
+
+	t.Run("throws error on missing schema", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listWebhooks).
+			ReplyError(pgerrcode.InvalidSchemaName, `schema "supabase_functions" does not exist`)
+		// Run test
+		_, err := ListWebhooks(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.ErrorContains(t, err, "failed to list webhooks:")
+	})
+}
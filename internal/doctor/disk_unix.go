@@ -0,0 +1,16 @@
+//go:build !windows
+
+package doctor
+
+import (
+	"github.com/go-errors/errors"
+	"golang.org/x/sys/unix"
+)
+
+func freeDiskSpace(path string) (uint64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, errors.Errorf("failed to stat filesystem: %w", err)
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,20 @@
+//go:build windows
+
+package doctor
+
+import (
+	"github.com/go-errors/errors"
+	"golang.org/x/sys/windows"
+)
+
+func freeDiskSpace(path string) (uint64, error) {
+	dir, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, errors.Errorf("failed to encode path: %w", err)
+	}
+	var free uint64
+	if err := windows.GetDiskFreeSpaceEx(dir, &free, nil, nil); err != nil {
+		return 0, errors.Errorf("failed to stat filesystem: %w", err)
+	}
+	return free, nil
+}
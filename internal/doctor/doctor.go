@@ -0,0 +1,289 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	StatusOk   = "ok"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+)
+
+// Docker images of the local stack take up several gigabytes.
+const minFreeSpace = 5 * units.GiB
+
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+	Fix    string `json:"fix,omitempty"`
+}
+
+// Report is safe to share in bug reports: it never includes tokens or passwords.
+type Report struct {
+	CliVersion string  `json:"cli_version"`
+	Os         string  `json:"os"`
+	Arch       string  `json:"arch"`
+	Checks     []Check `json:"checks"`
+}
+
+func (r Report) Failed() int {
+	var count int
+	for _, c := range r.Checks {
+		if c.Status == StatusFail {
+			count++
+		}
+	}
+	return count
+}
+
+func Run(ctx context.Context, fsys afero.Fs) error {
+	report := Diagnose(ctx, fsys)
+	if utils.OutputFormat.Value == utils.OutputPretty {
+		report.print(os.Stdout)
+	} else if err := utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, report); err != nil {
+		return err
+	}
+	if n := report.Failed(); n > 0 {
+		return errors.Errorf("%d of %d checks failed.", n, len(report.Checks))
+	}
+	return nil
+}
+
+func Diagnose(ctx context.Context, fsys afero.Fs) Report {
+	report := Report{
+		CliVersion: utils.Version,
+		Os:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+	}
+	docker := checkDocker(ctx)
+	config := checkConfig(fsys)
+	online := checkNetwork(ctx)
+	report.Checks = append(report.Checks,
+		docker,
+		config,
+		checkPorts(ctx, docker, config),
+		checkDiskSpace(),
+		online,
+		checkLogin(ctx, online, fsys),
+	)
+	return report
+}
+
+func checkDocker(ctx context.Context) Check {
+	result := Check{Name: "Docker"}
+	version, err := utils.Docker.ServerVersion(ctx)
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		result.Fix = "Install Docker Desktop or start the Docker daemon: https://docs.docker.com/desktop"
+		return result
+	}
+	result.Status = StatusOk
+	result.Detail = fmt.Sprintf("%s %s (API %s)", utils.ContainerRuntime.Value, version.Version, version.APIVersion)
+	return result
+}
+
+func checkConfig(fsys afero.Fs) Check {
+	result := Check{Name: "Config"}
+	if err := utils.LoadConfigFS(fsys); errors.Is(err, os.ErrNotExist) {
+		// The fix is already included in this check
+		utils.CmdSuggestion = ""
+		result.Status = StatusWarn
+		result.Detail = "config.toml not found"
+		result.Fix = "Run " + utils.Aqua("supabase init") + " to create a project."
+		return result
+	} else if err != nil {
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		result.Fix = "Fix the reported field in " + utils.Bold(utils.ConfigPath) + "."
+		return result
+	}
+	result.Status = StatusOk
+	result.Detail = utils.ConfigPath
+	return result
+}
+
+func checkPorts(ctx context.Context, docker, config Check) Check {
+	result := Check{Name: "Ports"}
+	if config.Status != StatusOk {
+		result.Status = StatusSkip
+		result.Detail = "config is not loaded"
+		return result
+	}
+	// Ports held by the local stack are expected to be in use
+	if docker.Status == StatusOk {
+		if err := utils.AssertServiceIsRunning(ctx, utils.DbId); err == nil {
+			result.Status = StatusSkip
+			result.Detail = "local stack is running"
+			return result
+		}
+	}
+	var conflicts []string
+	for name, port := range localPorts() {
+		l, err := net.Listen("tcp", net.JoinHostPort(utils.Config.Hostname, strconv.Itoa(int(port))))
+		if err != nil {
+			conflicts = append(conflicts, fmt.Sprintf("%d (%s)", port, name))
+			continue
+		}
+		l.Close()
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		result.Status = StatusFail
+		result.Detail = fmt.Sprintf("ports already in use: %v", conflicts)
+		result.Fix = "Stop the process bound to these ports, or change them in " + utils.Bold(utils.ConfigPath) + "."
+		return result
+	}
+	result.Status = StatusOk
+	result.Detail = "all local ports are available"
+	return result
+}
+
+func localPorts() map[string]uint16 {
+	ports := map[string]uint16{
+		"db.port":        utils.Config.Db.Port,
+		"db.shadow_port": utils.Config.Db.ShadowPort,
+	}
+	if utils.Config.Api.Enabled {
+		ports["api.port"] = utils.Config.Api.Port
+	}
+	if utils.Config.Studio.Enabled {
+		ports["studio.port"] = utils.Config.Studio.Port
+	}
+	if utils.Config.Inbucket.Enabled {
+		ports["inbucket.port"] = utils.Config.Inbucket.Port
+	}
+	if utils.Config.Analytics.Enabled {
+		ports["analytics.port"] = utils.Config.Analytics.Port
+	}
+	if utils.Config.Db.Pooler.Enabled {
+		ports["db.pooler.port"] = utils.Config.Db.Pooler.Port
+	}
+	for k, v := range ports {
+		if v == 0 {
+			delete(ports, k)
+		}
+	}
+	return ports
+}
+
+func checkDiskSpace() Check {
+	result := Check{Name: "Disk space"}
+	free, err := freeDiskSpace(".")
+	if err != nil {
+		result.Status = StatusWarn
+		result.Detail = err.Error()
+		return result
+	}
+	result.Detail = units.BytesSize(float64(free)) + " available"
+	if free < minFreeSpace {
+		result.Status = StatusWarn
+		result.Fix = fmt.Sprintf("Free up at least %s, ie. by running %s.", units.BytesSize(minFreeSpace), utils.Aqua("docker system prune"))
+		return result
+	}
+	result.Status = StatusOk
+	return result
+}
+
+func checkNetwork(ctx context.Context) Check {
+	result := Check{Name: "Management API"}
+	if utils.IsOffline() {
+		result.Status = StatusSkip
+		result.Detail = "offline mode is enabled"
+		return result
+	}
+	host := utils.GetSupabaseAPIHost()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, host, nil)
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		return result
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		result.Fix = "Check your internet connection and proxy settings, or try " + utils.Aqua("--dns-resolver https") + "."
+		return result
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	result.Status = StatusOk
+	result.Detail = host + " is reachable"
+	return result
+}
+
+func checkLogin(ctx context.Context, network Check, fsys afero.Fs) Check {
+	result := Check{Name: "Login"}
+	if _, err := utils.LoadAccessTokenFS(fsys); err != nil {
+		result.Status = StatusWarn
+		result.Detail = err.Error()
+		result.Fix = "Run " + utils.Aqua("supabase login") + " to use Management API commands."
+		return result
+	}
+	if network.Status != StatusOk {
+		result.Status = StatusSkip
+		result.Detail = "Management API is not reachable"
+		return result
+	}
+	resp, err := utils.GetSupabase().V1ListAllOrganizationsWithResponse(ctx)
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		return result
+	}
+	if resp.StatusCode() == http.StatusUnauthorized {
+		result.Status = StatusFail
+		result.Detail = "access token is invalid or expired"
+		result.Fix = "Run " + utils.Aqua("supabase login") + " to generate a new token."
+		return result
+	} else if resp.JSON200 == nil {
+		result.Status = StatusFail
+		result.Detail = "unexpected response: " + string(resp.Body)
+		return result
+	}
+	result.Status = StatusOk
+	result.Detail = "access token is valid"
+	return result
+}
+
+func (r Report) print(w io.Writer) {
+	fmt.Fprintf(w, "Supabase CLI %s (%s/%s)\n\n", r.CliVersion, r.Os, r.Arch)
+	for _, c := range r.Checks {
+		var icon string
+		switch c.Status {
+		case StatusOk:
+			icon = utils.Aqua("✔")
+		case StatusWarn:
+			icon = utils.Yellow("!")
+		case StatusFail:
+			icon = utils.Red("✘")
+		default:
+			icon = "-"
+		}
+		fmt.Fprintf(w, "%s %s: %s\n", icon, utils.Bold(c.Name), c.Detail)
+		if len(c.Fix) > 0 {
+			fmt.Fprintln(w, "    "+c.Fix)
+		}
+	}
+	fmt.Fprintf(w, "\nRun %s to generate a shareable report.\n", utils.Aqua("supabase doctor --output json"))
+}
@@ -0,0 +1,145 @@
+package doctor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestCheckDocker(t *testing.T) {
+	t.Run("reports docker version", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/version").
+			Reply(http.StatusOK).
+			JSON(types.Version{Version: "27.3.1", APIVersion: "1.47"})
+		// Run test
+		result := checkDocker(context.Background())
+		// Check output
+		assert.Equal(t, StatusOk, result.Status)
+		assert.Contains(t, result.Detail, "27.3.1 (API 1.47)")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("suggests fix if daemon is unavailable", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/version").
+			ReplyError(net.ErrClosed)
+		// Run test
+		result := checkDocker(context.Background())
+		// Check output
+		assert.Equal(t, StatusFail, result.Status)
+		assert.NotEmpty(t, result.Fix)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestCheckConfig(t *testing.T) {
+	t.Run("warns on missing config", func(t *testing.T) {
+		// Run test
+		result := checkConfig(afero.NewMemMapFs())
+		// Check output
+		assert.Equal(t, StatusWarn, result.Status)
+		assert.Empty(t, utils.CmdSuggestion)
+	})
+
+	t.Run("fails on invalid config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteFile(utils.ConfigPath, []byte("project_id = "), fsys))
+		// Run test
+		result := checkConfig(fsys)
+		// Check output
+		assert.Equal(t, StatusFail, result.Status)
+		assert.Contains(t, result.Detail, "cannot read config")
+	})
+}
+
+func TestCheckPorts(t *testing.T) {
+	ok := Check{Status: StatusOk}
+
+	t.Run("reports conflicting ports", func(t *testing.T) {
+		// Setup conflicting port
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer l.Close()
+		_, port, err := net.SplitHostPort(l.Addr().String())
+		require.NoError(t, err)
+		value, err := strconv.ParseUint(port, 10, 16)
+		require.NoError(t, err)
+		utils.Config.Db.Port = uint16(value)
+		// Run test
+		result := checkPorts(context.Background(), Check{Status: StatusFail}, ok)
+		// Check output
+		assert.Equal(t, StatusFail, result.Status)
+		assert.Contains(t, result.Detail, port+" (db.port)")
+	})
+
+	t.Run("skips without config", func(t *testing.T) {
+		result := checkPorts(context.Background(), ok, Check{Status: StatusWarn})
+		assert.Equal(t, StatusSkip, result.Status)
+	})
+}
+
+func TestCheckLogin(t *testing.T) {
+	online := Check{Status: StatusOk}
+
+	t.Run("validates access token", func(t *testing.T) {
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/organizations").
+			Reply(http.StatusOK).
+			JSON([]interface{}{})
+		// Run test
+		result := checkLogin(context.Background(), online, afero.NewMemMapFs())
+		// Check output
+		assert.Equal(t, StatusOk, result.Status)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("fails on expired token", func(t *testing.T) {
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/organizations").
+			Reply(http.StatusUnauthorized)
+		// Run test
+		result := checkLogin(context.Background(), online, afero.NewMemMapFs())
+		// Check output
+		assert.Equal(t, StatusFail, result.Status)
+		assert.Contains(t, result.Fix, "supabase login")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips api call when offline", func(t *testing.T) {
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Run test
+		result := checkLogin(context.Background(), Check{Status: StatusSkip}, afero.NewMemMapFs())
+		// Check output
+		assert.Equal(t, StatusSkip, result.Status)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/credentials"
 	"github.com/supabase/cli/pkg/api"
@@ -26,6 +27,6 @@ func Run(ctx context.Context, projectRef string, stdin *os.File) error {
 		return errors.New("Unexpected error updating project root key: " + string(resp.Body))
 	}
 
-	fmt.Println("Finished " + utils.Aqua("supabase root-key update") + ".")
+	fmt.Println(render.T("finished", utils.Aqua("supabase root-key update")))
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/docker/docker/api/types/container"
@@ -26,6 +27,10 @@ func NewDockerBundler(fsys afero.Fs) function.EszipBundler {
 }
 
 func (b *dockerBundler) Bundle(ctx context.Context, entrypoint string, importMap string, output io.Writer) error {
+	return b.BundleWithStaticFiles(ctx, entrypoint, importMap, nil, output)
+}
+
+func (b *dockerBundler) BundleWithStaticFiles(ctx context.Context, entrypoint string, importMap string, staticFiles []string, output io.Writer) error {
 	// Create temp directory to store generated eszip
 	slug := filepath.Base(filepath.Dir(entrypoint))
 	fmt.Fprintln(os.Stderr, "Bundling Function:", utils.Bold(slug))
@@ -43,8 +48,12 @@ func (b *dockerBundler) Bundle(ctx context.Context, entrypoint string, importMap
 			fmt.Fprintln(os.Stderr, err)
 		}
 	}()
+	files, err := GetStaticFiles(staticFiles, b.fsys)
+	if err != nil {
+		return err
+	}
 	// Create bind mounts
-	binds, err := GetBindMounts(cwd, utils.FunctionsDir, hostOutputDir, entrypoint, importMap, b.fsys)
+	binds, err := GetBindMounts(cwd, utils.FunctionsDir, hostOutputDir, entrypoint, importMap, files, b.fsys)
 	if err != nil {
 		return err
 	}
@@ -54,6 +63,9 @@ func (b *dockerBundler) Bundle(ctx context.Context, entrypoint string, importMap
 	if len(importMap) > 0 {
 		cmd = append(cmd, "--import-map", utils.ToDockerPath(importMap))
 	}
+	for _, f := range files {
+		cmd = append(cmd, "--static", utils.ToDockerPath(f))
+	}
 	if viper.GetBool("DEBUG") {
 		cmd = append(cmd, "--verbose")
 	}
@@ -71,7 +83,7 @@ func (b *dockerBundler) Bundle(ctx context.Context, entrypoint string, importMap
 		},
 		network.NetworkingConfig{},
 		"",
-		os.Stdout,
+		utils.GetHumanWriter(),
 		os.Stderr,
 	); err != nil {
 		return err
@@ -85,7 +97,29 @@ func (b *dockerBundler) Bundle(ctx context.Context, entrypoint string, importMap
 	return function.Compress(eszipBytes, output)
 }
 
-func GetBindMounts(cwd, hostFuncDir, hostOutputDir, hostEntrypointPath, hostImportMapPath string, fsys afero.Fs) ([]string, error) {
+// GetStaticFiles expands the glob patterns of static files declared in config. Patterns that
+// match nothing are reported as errors because the function would fail to read them at runtime.
+func GetStaticFiles(patterns []string, fsys afero.Fs) ([]string, error) {
+	var result []string
+	for _, pattern := range patterns {
+		matches, err := afero.Glob(fsys, pattern)
+		if err != nil {
+			return nil, errors.Errorf("failed to glob static files: %w", err)
+		} else if len(matches) == 0 {
+			return nil, errors.Errorf("static files not found: %s", pattern)
+		}
+		for _, m := range matches {
+			if info, err := fsys.Stat(m); err != nil {
+				return nil, errors.Errorf("failed to stat static file: %w", err)
+			} else if !info.IsDir() {
+				result = append(result, m)
+			}
+		}
+	}
+	return utils.RemoveDuplicates(result), nil
+}
+
+func GetBindMounts(cwd, hostFuncDir, hostOutputDir, hostEntrypointPath, hostImportMapPath string, staticFiles []string, fsys afero.Fs) ([]string, error) {
 	sep := string(filepath.Separator)
 	// Docker requires all host paths to be absolute
 	if !filepath.IsAbs(hostFuncDir) {
@@ -151,5 +185,20 @@ func GetBindMounts(cwd, hostFuncDir, hostOutputDir, hostEntrypointPath, hostImpo
 			}
 		}
 	}
+	// Static files outside of ./supabase/functions are bound by their parent directory
+	for _, hostPath := range staticFiles {
+		if !filepath.IsAbs(hostPath) {
+			hostPath = filepath.Join(cwd, hostPath)
+		}
+		hostDir := filepath.Dir(hostPath) + sep
+		if strings.HasPrefix(hostDir, hostFuncDir) ||
+			(len(hostOutputDir) > 0 && strings.HasPrefix(hostDir, hostOutputDir)) ||
+			(len(hostEntrypointDir) > 0 && strings.HasPrefix(hostDir, hostEntrypointDir)) {
+			continue
+		}
+		if mod := hostDir + ":" + utils.ToDockerPath(hostDir) + ":ro"; !slices.Contains(binds, mod) {
+			binds = append(binds, mod)
+		}
+	}
 	return binds, nil
 }
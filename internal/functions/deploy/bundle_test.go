@@ -49,3 +49,52 @@ func TestDockerBundle(t *testing.T) {
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 }
+
+func TestGetStaticFiles(t *testing.T) {
+	t.Run("expands glob patterns", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "supabase/functions/hello/templates/a.html", []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, "supabase/functions/hello/templates/b.html", []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, "supabase/assets/module.wasm", []byte{}, 0644))
+		// Run test
+		files, err := GetStaticFiles([]string{
+			"supabase/functions/hello/templates/*",
+			"supabase/assets/module.wasm",
+			"supabase/functions/hello/templates/a.html",
+		}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"supabase/functions/hello/templates/a.html",
+			"supabase/functions/hello/templates/b.html",
+			"supabase/assets/module.wasm",
+		}, files)
+	})
+
+	t.Run("throws error on missing files", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		// Run test
+		_, err := GetStaticFiles([]string{"supabase/assets/*.wasm"}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "static files not found: supabase/assets/*.wasm")
+	})
+}
+
+func TestGetBindMounts(t *testing.T) {
+	t.Run("binds static files outside functions directory", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		// Run test
+		binds, err := GetBindMounts("/project", "supabase/functions", "", "supabase/functions/hello/index.ts", "", []string{
+			"supabase/functions/hello/templates/a.html",
+			"supabase/assets/a.wasm",
+			"supabase/assets/b.wasm",
+		}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			utils.EdgeRuntimeId + ":/root/.cache/deno:rw",
+			"/project/supabase/functions/:/project/supabase/functions/:ro",
+			"/project/supabase/assets/:/project/supabase/assets/:ro",
+		}, binds)
+	})
+}
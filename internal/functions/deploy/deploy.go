@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/secrets/list"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
 	"github.com/supabase/cli/pkg/cast"
 	"github.com/supabase/cli/pkg/config"
 	"github.com/supabase/cli/pkg/function"
@@ -36,16 +39,56 @@ func Run(ctx context.Context, slugs []string, projectRef string, noVerifyJWT *bo
 	if err != nil {
 		return err
 	}
+	if err := CheckSecrets(ctx, projectRef, slugs); err != nil {
+		return err
+	}
 	api := function.NewEdgeRuntimeAPI(projectRef, *utils.GetSupabase(), NewDockerBundler(fsys))
 	if err := api.UpsertFunctions(ctx, functionConfig); err != nil {
 		return err
 	}
-	fmt.Printf("Deployed Functions on project %s: %s\n", utils.Aqua(projectRef), strings.Join(slugs, ", "))
+	fmt.Fprintf(utils.GetHumanWriter(), "Deployed Functions on project %s: %s\n", utils.Aqua(projectRef), strings.Join(slugs, ", "))
+	utils.HookEnv[HookEnvSlugs] = strings.Join(slugs, ",")
 	url := fmt.Sprintf("%s/project/%v/functions", utils.GetSupabaseDashboardURL(), projectRef)
-	fmt.Println("You can inspect your deployment in the Dashboard: " + url)
+	fmt.Fprintln(utils.GetHumanWriter(), "You can inspect your deployment in the Dashboard: "+url)
+	utils.PrintResult(slugs...)
+	return nil
+}
+
+// CheckSecrets fails the deploy early when a function declares secrets in config.toml
+// that are not set on the project. Names are matched exactly, including the prefix of
+// secrets set with --function, because that is the name the function reads.
+func CheckSecrets(ctx context.Context, projectRef string, slugs []string) error {
+	var secrets []api.SecretResponse
+	var missing []string
+	for _, slug := range slugs {
+		required := utils.Config.Functions[slug].Secrets
+		if len(required) == 0 {
+			continue
+		}
+		if secrets == nil {
+			var err error
+			if secrets, err = list.GetSecretDigests(ctx, projectRef); err != nil {
+				return err
+			}
+		}
+		for _, name := range required {
+			if !slices.ContainsFunc(secrets, func(s api.SecretResponse) bool {
+				return s.Name == name
+			}) {
+				missing = append(missing, fmt.Sprintf("%s (%s)", name, slug))
+			}
+		}
+	}
+	if len(missing) > 0 {
+		utils.CmdSuggestion = fmt.Sprintf("Run %s to set them.", utils.Aqua("supabase secrets set NAME=VALUE"))
+		return errors.Errorf("Missing secrets required by Functions on project %s:\n • %s", projectRef, strings.Join(missing, "\n • "))
+	}
 	return nil
 }
 
+// Comma separated slugs of the functions deployed, exposed to the after hook.
+const HookEnvSlugs = "SUPABASE_FUNCTION_SLUGS"
+
 func GetFunctionSlugs(fsys afero.Fs) (slugs []string, err error) {
 	pattern := filepath.Join(utils.FunctionsDir, "*", "index.ts")
 	paths, err := afero.Glob(fsys, pattern)
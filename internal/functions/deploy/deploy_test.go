@@ -356,3 +356,76 @@ func TestImportMapPath(t *testing.T) {
 		assert.Equal(t, path, fc["test"].ImportMap)
 	})
 }
+
+func TestCheckSecrets(t *testing.T) {
+	const slug = "hello-world"
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	t.Cleanup(func() { clear(utils.Config.Functions) })
+	utils.Config.Functions = config.FunctionConfig{
+		slug:      {Secrets: []string{"HELLO_WORLD__STRIPE_KEY", "SENTRY_DSN"}},
+		"reports": {Secrets: []string{"STRIPE_KEY", "LEGACY__TOKEN"}},
+	}
+
+	t.Run("accepts project wide and prefixed secrets", func(t *testing.T) {
+		project := apitest.RandomProjectRef()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{
+				{Name: "HELLO_WORLD__STRIPE_KEY", Value: "a"},
+				{Name: "SENTRY_DSN", Value: "b"},
+			})
+		// Run test
+		err := CheckSecrets(context.Background(), project, []string{slug})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing secret", func(t *testing.T) {
+		project := apitest.RandomProjectRef()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{
+				{Name: "STRIPE_KEY", Value: "a"},
+				{Name: "SENTRY_DSN", Value: "b"},
+			})
+		// Run test
+		err := CheckSecrets(context.Background(), project, []string{slug})
+		// Check error
+		assert.ErrorContains(t, err, "HELLO_WORLD__STRIPE_KEY (hello-world)")
+		assert.NotContains(t, err.Error(), "SENTRY_DSN")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("matches secrets with separator by exact name", func(t *testing.T) {
+		project := apitest.RandomProjectRef()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{
+				{Name: "STRIPE_KEY", Value: "a"},
+				{Name: "SENTRY_DSN", Value: "b"},
+				{Name: "LEGACY__TOKEN", Value: "c"},
+			})
+		// Run test
+		err := CheckSecrets(context.Background(), project, []string{"reports"})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips functions without declared secrets", func(t *testing.T) {
+		err := CheckSecrets(context.Background(), apitest.RandomProjectRef(), []string{"other"})
+		assert.NoError(t, err)
+	})
+}
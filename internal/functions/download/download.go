@@ -24,7 +24,7 @@ var (
 	legacyImportMapPath  = "file:///src/import_map.json"
 )
 
-func RunLegacy(ctx context.Context, slug string, projectRef string, fsys afero.Fs) error {
+func RunLegacy(ctx context.Context, slug string, projectRef string, outputDir string, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	{
 		if err := utils.ValidateFunctionSlug(slug); err != nil {
@@ -41,7 +41,7 @@ func RunLegacy(ctx context.Context, slug string, projectRef string, fsys afero.F
 	}
 
 	// 2. Download Function.
-	if err := downloadFunction(ctx, projectRef, slug, scriptDir.ExtractPath); err != nil {
+	if err := downloadFunction(ctx, projectRef, slug, outputDir, scriptDir.ExtractPath); err != nil {
 		return err
 	}
 
@@ -73,7 +73,7 @@ func getFunctionMetadata(ctx context.Context, projectRef, slug string) (*api.Fun
 	return resp.JSON200, nil
 }
 
-func downloadFunction(ctx context.Context, projectRef, slug, extractScriptPath string) error {
+func downloadFunction(ctx context.Context, projectRef, slug, funcDir, extractScriptPath string) error {
 	fmt.Println("Downloading " + utils.Bold(slug))
 	denoPath, err := utils.GetDenoPath()
 	if err != nil {
@@ -94,7 +94,6 @@ func downloadFunction(ctx context.Context, projectRef, slug, extractScriptPath s
 	}
 
 	resBuf := bytes.NewReader(resp.Body)
-	funcDir := filepath.Join(utils.FunctionsDir, slug)
 	args := []string{"run", "-A", extractScriptPath, funcDir, *meta.EntrypointPath}
 	cmd := exec.CommandContext(ctx, denoPath, args...)
 	var errBuf bytes.Buffer
@@ -107,9 +106,16 @@ func downloadFunction(ctx context.Context, projectRef, slug, extractScriptPath s
 	return nil
 }
 
-func Run(ctx context.Context, slug string, projectRef string, useLegacyBundle bool, fsys afero.Fs) error {
+// Extracts function source to outputDir, which defaults to the local functions directory.
+func Run(ctx context.Context, slug string, projectRef string, useLegacyBundle bool, outputDir string, fsys afero.Fs) error {
+	if len(outputDir) == 0 {
+		outputDir = filepath.Join(utils.FunctionsDir, slug)
+		if err := confirmOverwrite(ctx, outputDir, fsys); err != nil {
+			return err
+		}
+	}
 	if useLegacyBundle {
-		return RunLegacy(ctx, slug, projectRef, fsys)
+		return RunLegacy(ctx, slug, projectRef, outputDir, fsys)
 	}
 	// 1. Sanity check
 	if err := utils.LoadConfigFS(fsys); err != nil {
@@ -126,13 +132,28 @@ func Run(ctx context.Context, slug string, projectRef string, useLegacyBundle bo
 		}
 	}()
 	// Extract eszip to functions directory
-	err = extractOne(ctx, slug, eszipPath)
+	err = extractOne(ctx, outputDir, eszipPath)
 	if err != nil {
 		utils.CmdSuggestion += suggestLegacyBundle(slug)
 	}
 	return err
 }
 
+// Local source may have changes that were never deployed, so ask before overwriting.
+func confirmOverwrite(ctx context.Context, funcDir string, fsys afero.Fs) error {
+	if empty, err := afero.IsEmpty(fsys, funcDir); err != nil || empty {
+		return nil
+	}
+	msg := fmt.Sprintf("Overwrite local source in %s with the deployed version?", utils.Bold(funcDir))
+	if shouldOverwrite, err := utils.NewConsole().PromptYesNo(ctx, msg, true); err != nil {
+		return err
+	} else if !shouldOverwrite {
+		utils.CmdSuggestion = fmt.Sprintf("Run with %s to download into a different directory.", utils.Aqua("--output-dir"))
+		return errors.New(context.Canceled)
+	}
+	return nil
+}
+
 func downloadOne(ctx context.Context, slug, projectRef string, fsys afero.Fs) (string, error) {
 	fmt.Println("Downloading " + utils.Bold(slug))
 	resp, err := utils.GetSupabase().V1GetAFunctionBody(ctx, projectRef, slug)
@@ -158,8 +179,8 @@ func downloadOne(ctx context.Context, slug, projectRef string, fsys afero.Fs) (s
 	return eszipPath, nil
 }
 
-func extractOne(ctx context.Context, slug, eszipPath string) error {
-	hostFuncDirPath, err := filepath.Abs(filepath.Join(utils.FunctionsDir, slug))
+func extractOne(ctx context.Context, funcDir, eszipPath string) error {
+	hostFuncDirPath, err := filepath.Abs(funcDir)
 	if err != nil {
 		return errors.Errorf("failed to resolve absolute path: %w", err)
 	}
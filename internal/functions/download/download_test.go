@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/h2non/gock"
@@ -61,19 +62,53 @@ func TestDownloadCommand(t *testing.T) {
 			Get("/v1/projects/" + project + "/functions/" + slug + "/body").
 			Reply(http.StatusOK)
 		// Run test
-		err = Run(context.Background(), slug, project, true, fsys)
+		err = Run(context.Background(), slug, project, true, "", fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 
+	t.Run("downloads to output dir", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup existing local source
+		localPath := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, localPath, []byte("local"), 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup valid deno path
+		_, err := fsys.Create(utils.DenoPathOverride)
+		require.NoError(t, err)
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions/" + slug).
+			Reply(http.StatusOK).
+			JSON(api.FunctionResponse{Id: "1"})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions/" + slug + "/body").
+			Reply(http.StatusOK)
+		// Run test
+		err = Run(context.Background(), slug, project, true, "deployed", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		// Local source is left untouched
+		data, err := afero.ReadFile(fsys, localPath)
+		assert.NoError(t, err)
+		assert.Equal(t, "local", string(data))
+	})
+
 	t.Run("throws error on malformed slug", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Setup valid project ref
 		project := apitest.RandomProjectRef()
 		// Run test
-		err := Run(context.Background(), "@", project, true, fsys)
+		err := Run(context.Background(), "@", project, true, "", fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Invalid Function name.")
 	})
@@ -84,7 +119,7 @@ func TestDownloadCommand(t *testing.T) {
 		// Setup valid project ref
 		project := apitest.RandomProjectRef()
 		// Run test
-		err := Run(context.Background(), slug, project, true, fsys)
+		err := Run(context.Background(), slug, project, true, "", fsys)
 		// Check error
 		assert.ErrorContains(t, err, "operation not permitted")
 	})
@@ -98,7 +133,7 @@ func TestDownloadCommand(t *testing.T) {
 		_, err := fsys.Create(utils.DenoPathOverride)
 		require.NoError(t, err)
 		// Run test
-		err = Run(context.Background(), slug, project, true, afero.NewReadOnlyFs(fsys))
+		err = Run(context.Background(), slug, project, true, "", afero.NewReadOnlyFs(fsys))
 		// Check error
 		assert.ErrorContains(t, err, "operation not permitted")
 	})
@@ -121,7 +156,7 @@ func TestDownloadCommand(t *testing.T) {
 			Reply(http.StatusNotFound).
 			JSON(map[string]string{"message": "Function not found"})
 		// Run test
-		err = Run(context.Background(), slug, project, true, fsys)
+		err = Run(context.Background(), slug, project, true, "", fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Function test-func does not exist on the Supabase project.")
 	})
@@ -146,7 +181,7 @@ func TestDownloadFunction(t *testing.T) {
 			Get("/v1/projects/" + project + "/functions/" + slug + "/body").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := downloadFunction(context.Background(), project, slug, "")
+		err := downloadFunction(context.Background(), project, slug, "", "")
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 	})
@@ -162,7 +197,7 @@ func TestDownloadFunction(t *testing.T) {
 			Get("/v1/projects/" + project + "/functions/" + slug + "/body").
 			Reply(http.StatusServiceUnavailable)
 		// Run test
-		err := downloadFunction(context.Background(), project, slug, "")
+		err := downloadFunction(context.Background(), project, slug, "", "")
 		// Check error
 		assert.ErrorContains(t, err, "Unexpected error downloading Function:")
 	})
@@ -180,7 +215,7 @@ func TestDownloadFunction(t *testing.T) {
 			Get("/v1/projects/" + project + "/functions/" + slug + "/body").
 			Reply(http.StatusOK)
 		// Run test
-		err := downloadFunction(context.Background(), project, slug, "")
+		err := downloadFunction(context.Background(), project, slug, "", "")
 		// Check error
 		assert.ErrorContains(t, err, "Error downloading function: exit status 1\nextract failed\n")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -0,0 +1,135 @@
+package invoke
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/status"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/internal/utils/tenant"
+)
+
+type InvokeOptions struct {
+	Method  string
+	Body    string
+	Headers []string
+}
+
+// Calls the locally served function when projectRef is empty, otherwise the deployed one.
+// Functions of a self-hosted environment selected with --env are called through its gateway.
+func Run(ctx context.Context, slug, projectRef string, opts InvokeOptions, fsys afero.Fs) error {
+	if err := utils.ValidateFunctionSlug(slug); err != nil {
+		return err
+	}
+	body, err := ReadBody(opts.Body, fsys)
+	if err != nil {
+		return err
+	}
+	header, err := ParseHeaders(opts.Headers)
+	if err != nil {
+		return err
+	}
+	server, client, anonKey, err := resolveTarget(ctx, projectRef, fsys)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, opts.Method, server+"/functions/v1/"+slug, body)
+	if err != nil {
+		return errors.Errorf("failed to initialise http request: %w", err)
+	}
+	req.Header = header
+	if len(req.Header.Get("Authorization")) == 0 {
+		req.Header.Set("Authorization", "Bearer "+anonKey)
+	}
+	if body != nil && len(req.Header.Get("Content-Type")) == 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("User-Agent", "SupabaseCLI/"+utils.Version)
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Errorf("failed to invoke function: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Errorf("failed to read response body: %w", err)
+	}
+	printResponse(os.Stderr, resp, time.Since(start))
+	if _, err := os.Stdout.Write(data); err != nil {
+		return errors.Errorf("failed to write response body: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("Function %s returned status %d.", slug, resp.StatusCode)
+	}
+	return nil
+}
+
+func resolveTarget(ctx context.Context, projectRef string, fsys afero.Fs) (string, *http.Client, string, error) {
+	if flags.IsSelfHosted() {
+		return flags.Environment.ApiUrl, http.DefaultClient, flags.Environment.AnonKey, nil
+	} else if len(projectRef) > 0 {
+		keys, err := tenant.GetApiKeys(ctx, projectRef)
+		if err != nil {
+			return "", nil, "", err
+		}
+		return "https://" + utils.GetSupabaseHost(projectRef), http.DefaultClient, keys.Anon, nil
+	}
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return "", nil, "", err
+	}
+	return utils.Config.Api.ExternalUrl, status.NewKongClient(), utils.Config.Auth.AnonKey, nil
+}
+
+// Reads the request body from a file if the value is prefixed with @, ie. @payload.json
+func ReadBody(value string, fsys afero.Fs) (io.Reader, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+	path, ok := strings.CutPrefix(value, "@")
+	if !ok {
+		return strings.NewReader(value), nil
+	}
+	data, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return nil, errors.Errorf("failed to read body: %w", err)
+	}
+	return strings.NewReader(string(data)), nil
+}
+
+// Parses headers in curl format, ie. x-region:us-east-1
+func ParseHeaders(values []string) (http.Header, error) {
+	header := http.Header{}
+	for _, h := range values {
+		key, value, ok := strings.Cut(h, ":")
+		if key = strings.TrimSpace(key); !ok || len(key) == 0 {
+			return nil, errors.Errorf("invalid header format: %s", h)
+		}
+		header.Add(key, strings.TrimSpace(value))
+	}
+	return header, nil
+}
+
+func printResponse(w io.Writer, resp *http.Response, elapsed time.Duration) {
+	fmt.Fprintln(w, utils.Bold(resp.Proto+" "+resp.Status))
+	keys := make([]string, 0, len(resp.Header))
+	for k := range resp.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range resp.Header[k] {
+			fmt.Fprintf(w, "%s: %s\n", utils.Aqua(k), v)
+		}
+	}
+	fmt.Fprintf(w, "Completed in %s\n\n", elapsed.Round(time.Millisecond))
+}
@@ -0,0 +1,127 @@
+package invoke
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestInvokeCommand(t *testing.T) {
+	const slug = "test-func"
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("invokes deployed function", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "payload.json", []byte(`{"name":"world"}`), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "anon",
+				ApiKey: "anon-key",
+			}})
+		gock.New("https://"+utils.GetSupabaseHost(project)).
+			Post("/functions/v1/"+slug).
+			MatchHeader("Authorization", "Bearer anon-key").
+			MatchHeader("X-Region", "us-east-1").
+			MatchHeader("Content-Type", "application/json").
+			BodyString(`{"name":"world"}`).
+			Reply(http.StatusOK).
+			JSON(map[string]string{"message": "Hello world!"})
+		// Run test
+		err := Run(context.Background(), slug, project, InvokeOptions{
+			Method:  http.MethodPost,
+			Body:    "@payload.json",
+			Headers: []string{"x-region: us-east-1"},
+		}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on function failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "anon",
+				ApiKey: "anon-key",
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(project)).
+			Get("/functions/v1/" + slug).
+			Reply(http.StatusInternalServerError)
+		// Run test
+		err := Run(context.Background(), slug, project, InvokeOptions{Method: http.MethodGet}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Function test-func returned status 500.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on malformed slug", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), "@", project, InvokeOptions{}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid Function name.")
+	})
+
+	t.Run("throws error on missing body file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), slug, project, InvokeOptions{Body: "@payload.json"}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to read body:")
+	})
+}
+
+func TestReadBody(t *testing.T) {
+	t.Run("reads literal value", func(t *testing.T) {
+		body, err := ReadBody(`{"a":1}`, afero.NewMemMapFs())
+		assert.NoError(t, err)
+		data, err := io.ReadAll(body)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"a":1}`, string(data))
+	})
+
+	t.Run("returns nil on empty value", func(t *testing.T) {
+		body, err := ReadBody("", afero.NewMemMapFs())
+		assert.NoError(t, err)
+		assert.Nil(t, body)
+	})
+}
+
+func TestParseHeaders(t *testing.T) {
+	t.Run("parses curl style headers", func(t *testing.T) {
+		header, err := ParseHeaders([]string{"x-a:1", "X-A: 2", "x-b:c:d"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, header.Values("X-A"))
+		assert.Equal(t, "c:d", header.Get("X-B"))
+	})
+
+	t.Run("throws error on missing separator", func(t *testing.T) {
+		_, err := ParseHeaders([]string{"invalid"})
+		assert.ErrorContains(t, err, "invalid header format: invalid")
+	})
+}
@@ -2,29 +2,47 @@ package new
 
 import (
 	"context"
-	_ "embed"
+	"embed"
 	"fmt"
-	"html/template"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
+	"text/template"
 
 	"github.com/go-errors/errors"
+	"github.com/joho/godotenv"
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/utils"
 )
 
+const (
+	TemplateWebhook = "webhook"
+	TemplateCron    = "cron"
+	TemplateOpenAI  = "openai"
+	TemplateStripe  = "stripe"
+)
+
 var (
-	//go:embed templates/index.ts
-	indexEmbed    string
-	indexTemplate = template.Must(template.New("indexl").Parse(indexEmbed))
+	//go:embed templates
+	templatesFS embed.FS
+	// Environment variables required by each template
+	templateEnvs = map[string][]string{
+		TemplateWebhook: {"WEBHOOK_SECRET"},
+		TemplateCron:    {"CRON_SECRET"},
+		TemplateOpenAI:  {"OPENAI_API_KEY"},
+		TemplateStripe:  {"STRIPE_SECRET_KEY", "STRIPE_WEBHOOK_SIGNING_SECRET"},
+	}
 )
 
 type indexConfig struct {
 	URL   string
 	Token string
+	Slug  string
 }
 
-func Run(ctx context.Context, slug string, fsys afero.Fs) error {
+func Run(ctx context.Context, slug, templateName string, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	funcDir := filepath.Join(utils.FunctionsDir, slug)
 	{
@@ -38,12 +56,6 @@ func Run(ctx context.Context, slug string, fsys afero.Fs) error {
 		if err := utils.MkdirIfNotExistFS(fsys, funcDir); err != nil {
 			return err
 		}
-		path := filepath.Join(funcDir, "index.ts")
-		f, err := fsys.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
-		if err != nil {
-			return errors.Errorf("failed to create function entrypoint: %w", err)
-		}
-		defer f.Close()
 		// Templatize index.ts by config.toml if available
 		if err := utils.LoadConfigFS(fsys); err != nil {
 			utils.CmdSuggestion = ""
@@ -51,12 +63,87 @@ func Run(ctx context.Context, slug string, fsys afero.Fs) error {
 		config := indexConfig{
 			URL:   utils.GetApiUrl("/functions/v1/" + slug),
 			Token: utils.Config.Auth.AnonKey,
+			Slug:  slug,
+		}
+		files, err := listTemplateFiles(templateName)
+		if err != nil {
+			return err
 		}
-		if err := indexTemplate.Option("missingkey=error").Execute(f, config); err != nil {
-			return errors.Errorf("failed to initialise function entrypoint: %w", err)
+		for _, name := range files {
+			if err := writeTemplate(name, filepath.Join(funcDir, path.Base(name)), config, fsys); err != nil {
+				return err
+			}
 		}
 	}
 
-	fmt.Println("Created new Function at " + utils.Bold(funcDir))
+	// 3. Add required secrets to env file.
+	if envs := templateEnvs[templateName]; len(envs) > 0 {
+		if err := appendEnvFile(utils.FallbackEnvFilePath, envs, fsys); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "Set "+strings.Join(envs, ", ")+" in "+utils.Bold(utils.FallbackEnvFilePath)+" before serving your Function.")
+	}
+
+	fmt.Fprintln(utils.GetHumanWriter(), "Created new Function at "+utils.Bold(funcDir))
+	utils.PrintResult(funcDir)
+	return nil
+}
+
+func listTemplateFiles(templateName string) ([]string, error) {
+	if len(templateName) == 0 {
+		return []string{"templates/index.ts"}, nil
+	}
+	if _, ok := templateEnvs[templateName]; !ok {
+		return nil, errors.Errorf("unknown function template: %s", templateName)
+	}
+	entries, err := fs.ReadDir(templatesFS, path.Join("templates", templateName))
+	if err != nil {
+		return nil, errors.Errorf("failed to read function template: %w", err)
+	}
+	var result []string
+	for _, e := range entries {
+		result = append(result, path.Join("templates", templateName, e.Name()))
+	}
+	return result, nil
+}
+
+func writeTemplate(name, dstPath string, config indexConfig, fsys afero.Fs) error {
+	tmpl, err := template.ParseFS(templatesFS, name)
+	if err != nil {
+		return errors.Errorf("failed to parse function template: %w", err)
+	}
+	f, err := fsys.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return errors.Errorf("failed to create function entrypoint: %w", err)
+	}
+	defer f.Close()
+	if err := tmpl.Option("missingkey=error").Execute(f, config); err != nil {
+		return errors.Errorf("failed to initialise function entrypoint: %w", err)
+	}
 	return nil
 }
+
+// Appends placeholders for env vars that are not already declared.
+func appendEnvFile(envFilePath string, envs []string, fsys afero.Fs) error {
+	contents, err := afero.ReadFile(fsys, envFilePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Errorf("failed to read env file: %w", err)
+	}
+	existing, err := godotenv.UnmarshalBytes(contents)
+	if err != nil {
+		return errors.Errorf("failed to parse env file: %w", err)
+	}
+	var buf strings.Builder
+	if len(contents) > 0 && !strings.HasSuffix(string(contents), "\n") {
+		buf.WriteString("\n")
+	}
+	for _, name := range envs {
+		if _, ok := existing[name]; !ok {
+			buf.WriteString(name + "=\n")
+		}
+	}
+	if strings.TrimSpace(buf.String()) == "" {
+		return nil
+	}
+	return utils.WriteFile(envFilePath, append(contents, buf.String()...), fsys)
+}
@@ -16,7 +16,7 @@ func TestNewCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		assert.NoError(t, Run(context.Background(), "test-func", fsys))
+		assert.NoError(t, Run(context.Background(), "test-func", "", fsys))
 		// Validate output
 		funcPath := filepath.Join(utils.FunctionsDir, "test-func", "index.ts")
 		content, err := afero.ReadFile(fsys, funcPath)
@@ -27,7 +27,7 @@ func TestNewCommand(t *testing.T) {
 	})
 
 	t.Run("throws error on malformed slug", func(t *testing.T) {
-		assert.Error(t, Run(context.Background(), "@", afero.NewMemMapFs()))
+		assert.Error(t, Run(context.Background(), "@", "", afero.NewMemMapFs()))
 	})
 
 	t.Run("throws error on duplicate slug", func(t *testing.T) {
@@ -36,13 +36,45 @@ func TestNewCommand(t *testing.T) {
 		funcPath := filepath.Join(utils.FunctionsDir, "test-func", "index.ts")
 		require.NoError(t, afero.WriteFile(fsys, funcPath, []byte{}, 0644))
 		// Run test
-		assert.Error(t, Run(context.Background(), "test-func", fsys))
+		assert.Error(t, Run(context.Background(), "test-func", "", fsys))
 	})
 
 	t.Run("throws error on permission denied", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
 		// Run test
-		assert.Error(t, Run(context.Background(), "test-func", fsys))
+		assert.Error(t, Run(context.Background(), "test-func", "", fsys))
+	})
+
+	t.Run("creates function from template", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.FallbackEnvFilePath, []byte("STRIPE_SECRET_KEY=sk_test"), 0644))
+		// Run test
+		assert.NoError(t, Run(context.Background(), "billing", TemplateStripe, fsys))
+		// Validate output
+		funcDir := filepath.Join(utils.FunctionsDir, "billing")
+		for _, name := range []string{"index.ts", "handler.ts", "index.test.ts"} {
+			exists, err := afero.Exists(fsys, filepath.Join(funcDir, name))
+			assert.NoError(t, err)
+			assert.True(t, exists, name)
+		}
+		content, err := afero.ReadFile(fsys, filepath.Join(funcDir, "index.ts"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "stripe listen --forward-to 'http://127.0.0.1:54321/functions/v1/billing'")
+		env, err := afero.ReadFile(fsys, utils.FallbackEnvFilePath)
+		assert.NoError(t, err)
+		assert.Equal(t, "STRIPE_SECRET_KEY=sk_test\nSTRIPE_WEBHOOK_SIGNING_SECRET=\n", string(env))
+	})
+
+	t.Run("renders all templates", func(t *testing.T) {
+		for name := range templateEnvs {
+			fsys := afero.NewMemMapFs()
+			assert.NoError(t, Run(context.Background(), "test-func", name, fsys), name)
+		}
+	})
+
+	t.Run("throws error on unknown template", func(t *testing.T) {
+		assert.ErrorContains(t, Run(context.Background(), "test-func", "unknown", afero.NewMemMapFs()), "unknown function template: unknown")
 	})
 }
@@ -0,0 +1,35 @@
+export type CronPayload = {
+  scheduled_at: string
+}
+
+export type CronResult = {
+  scheduled_at: string
+  completed_at: string
+}
+
+// Replace with the work to run on each scheduled invocation
+export async function runJob(payload: CronPayload): Promise<CronResult> {
+  console.log("Running scheduled job for", payload.scheduled_at)
+  return {
+    scheduled_at: payload.scheduled_at,
+    completed_at: new Date().toISOString(),
+  }
+}
+
+export async function handler(req: Request): Promise<Response> {
+  if (req.method !== "POST") {
+    return Response.json({ error: "Method not allowed" }, { status: 405 })
+  }
+  const secret = Deno.env.get("CRON_SECRET")
+  if (!secret) {
+    return Response.json({ error: "CRON_SECRET is not set" }, { status: 500 })
+  }
+  if (req.headers.get("x-cron-secret") !== secret) {
+    return Response.json({ error: "Invalid cron secret" }, { status: 401 })
+  }
+
+  const body = await req.text()
+  const payload: CronPayload = body ? JSON.parse(body) : { scheduled_at: new Date().toISOString() }
+  const result = await runJob(payload)
+  return Response.json(result)
+}
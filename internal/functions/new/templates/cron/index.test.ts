@@ -0,0 +1,23 @@
+import { assertEquals } from "jsr:@std/assert"
+import { handler } from "./handler.ts"
+
+Deno.env.set("CRON_SECRET", "test-secret")
+
+Deno.test("runs scheduled job", async () => {
+  const req = new Request("http://localhost/{{ .Slug }}", {
+    method: "POST",
+    headers: { "X-Cron-Secret": "test-secret" },
+    body: JSON.stringify({ scheduled_at: "2024-01-01T00:00:00Z" }),
+  })
+  const res = await handler(req)
+  assertEquals(res.status, 200)
+  const data = await res.json()
+  assertEquals(data.scheduled_at, "2024-01-01T00:00:00Z")
+})
+
+Deno.test("rejects invalid secret", async () => {
+  const req = new Request("http://localhost/{{ .Slug }}", { method: "POST" })
+  const res = await handler(req)
+  assertEquals(res.status, 401)
+  await res.body?.cancel()
+})
@@ -0,0 +1,43 @@
+// Follow this setup guide to integrate the Deno language server with your editor:
+// https://deno.land/manual/getting_started/setup_your_environment
+// This enables autocomplete, go to definition, etc.
+
+// Setup type definitions for built-in Supabase Runtime APIs
+import "jsr:@supabase/functions-js/edge-runtime.d.ts"
+import { handler } from "./handler.ts"
+
+Deno.serve(handler)
+
+/* To schedule with pg_cron and pg_net:
+
+  select cron.schedule(
+    '{{ .Slug }}',
+    '*/5 * * * *',
+    $$
+    select net.http_post(
+      url := '{{ .URL }}',
+      headers := jsonb_build_object(
+        'Authorization', 'Bearer {{ .Token }}',
+        'X-Cron-Secret', '<CRON_SECRET>',
+        'Content-Type', 'application/json'
+      ),
+      body := jsonb_build_object('scheduled_at', now())
+    );
+    $$
+  );
+
+  To invoke locally:
+
+  1. Run `supabase start` (see: https://supabase.com/docs/reference/cli/supabase-start)
+  2. Set CRON_SECRET in supabase/functions/.env and run `supabase functions serve --env-file supabase/functions/.env`
+  3. Make an HTTP request:
+
+  curl -i --location --request POST '{{ .URL }}' \
+    --header 'Authorization: Bearer {{ .Token }}' \
+    --header 'X-Cron-Secret: <CRON_SECRET>' \
+    --header 'Content-Type: application/json' \
+    --data '{"scheduled_at":"2024-01-01T00:00:00Z"}'
+
+  4. Run the tests: `deno test --allow-env supabase/functions/{{ .Slug }}`
+
+*/
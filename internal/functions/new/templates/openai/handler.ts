@@ -0,0 +1,49 @@
+export type CompletionRequest = {
+  prompt: string
+}
+
+export type CompletionResponse = {
+  reply: string
+}
+
+type ChatCompletion = {
+  choices: { message: { role: string; content: string } }[]
+}
+
+const OPENAI_URL = "https://api.openai.com/v1/chat/completions"
+
+export async function handler(req: Request): Promise<Response> {
+  if (req.method !== "POST") {
+    return Response.json({ error: "Method not allowed" }, { status: 405 })
+  }
+  const apiKey = Deno.env.get("OPENAI_API_KEY")
+  if (!apiKey) {
+    return Response.json({ error: "OPENAI_API_KEY is not set" }, { status: 500 })
+  }
+
+  const { prompt }: CompletionRequest = await req.json()
+  if (!prompt) {
+    return Response.json({ error: "Missing prompt" }, { status: 400 })
+  }
+
+  const resp = await fetch(OPENAI_URL, {
+    method: "POST",
+    headers: {
+      "Authorization": `Bearer ${apiKey}`,
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify({
+      model: Deno.env.get("OPENAI_MODEL") ?? "gpt-4o-mini",
+      messages: [{ role: "user", content: prompt }],
+    }),
+  })
+  if (!resp.ok) {
+    return Response.json({ error: await resp.text() }, { status: 502 })
+  }
+
+  const completion: ChatCompletion = await resp.json()
+  const data: CompletionResponse = {
+    reply: completion.choices[0]?.message.content ?? "",
+  }
+  return Response.json(data)
+}
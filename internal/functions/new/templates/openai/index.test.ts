@@ -0,0 +1,24 @@
+import { assertEquals } from "jsr:@std/assert"
+import { stub } from "jsr:@std/testing/mock"
+import { handler } from "./handler.ts"
+
+Deno.env.set("OPENAI_API_KEY", "test-key")
+
+Deno.test("returns completion reply", async () => {
+  const completion = { choices: [{ message: { role: "assistant", content: "Hello!" } }] }
+  using _ = stub(globalThis, "fetch", () => Promise.resolve(Response.json(completion)))
+  const req = new Request("http://localhost/{{ .Slug }}", {
+    method: "POST",
+    body: JSON.stringify({ prompt: "Say hello" }),
+  })
+  const res = await handler(req)
+  assertEquals(res.status, 200)
+  assertEquals(await res.json(), { reply: "Hello!" })
+})
+
+Deno.test("rejects missing prompt", async () => {
+  const req = new Request("http://localhost/{{ .Slug }}", { method: "POST", body: "{}" })
+  const res = await handler(req)
+  assertEquals(res.status, 400)
+  await res.body?.cancel()
+})
@@ -0,0 +1,24 @@
+// Follow this setup guide to integrate the Deno language server with your editor:
+// https://deno.land/manual/getting_started/setup_your_environment
+// This enables autocomplete, go to definition, etc.
+
+// Setup type definitions for built-in Supabase Runtime APIs
+import "jsr:@supabase/functions-js/edge-runtime.d.ts"
+import { handler } from "./handler.ts"
+
+Deno.serve(handler)
+
+/* To invoke locally:
+
+  1. Run `supabase start` (see: https://supabase.com/docs/reference/cli/supabase-start)
+  2. Set OPENAI_API_KEY in supabase/functions/.env and run `supabase functions serve --env-file supabase/functions/.env`
+  3. Make an HTTP request:
+
+  curl -i --location --request POST '{{ .URL }}' \
+    --header 'Authorization: Bearer {{ .Token }}' \
+    --header 'Content-Type: application/json' \
+    --data '{"prompt":"Say hello to Functions!"}'
+
+  4. Run the tests: `deno test --allow-env supabase/functions/{{ .Slug }}`
+
+*/
@@ -0,0 +1,47 @@
+import Stripe from "npm:stripe@^17"
+
+export function newStripe(): Stripe {
+  return new Stripe(Deno.env.get("STRIPE_SECRET_KEY") ?? "", {
+    httpClient: Stripe.createFetchHttpClient(),
+  })
+}
+
+// Replace with your business logic for each event type
+export async function handleEvent(event: Stripe.Event): Promise<void> {
+  switch (event.type) {
+    case "checkout.session.completed":
+      console.log("Checkout completed:", event.data.object.id)
+      break
+    case "customer.subscription.deleted":
+      console.log("Subscription cancelled:", event.data.object.id)
+      break
+    default:
+      console.log("Unhandled event type:", event.type)
+  }
+}
+
+export async function handler(req: Request, stripe = newStripe()): Promise<Response> {
+  if (req.method !== "POST") {
+    return Response.json({ error: "Method not allowed" }, { status: 405 })
+  }
+  const secret = Deno.env.get("STRIPE_WEBHOOK_SIGNING_SECRET")
+  if (!secret) {
+    return Response.json({ error: "STRIPE_WEBHOOK_SIGNING_SECRET is not set" }, { status: 500 })
+  }
+  const signature = req.headers.get("stripe-signature")
+  if (!signature) {
+    return Response.json({ error: "Missing Stripe signature" }, { status: 400 })
+  }
+
+  // Signature verification requires the raw request body
+  const body = await req.text()
+  let event: Stripe.Event
+  try {
+    event = await stripe.webhooks.constructEventAsync(body, signature, secret, undefined, Stripe.createSubtleCryptoProvider())
+  } catch (e) {
+    return Response.json({ error: (e as Error).message }, { status: 400 })
+  }
+
+  await handleEvent(event)
+  return Response.json({ received: true })
+}
@@ -0,0 +1,36 @@
+import { assertEquals } from "jsr:@std/assert"
+import Stripe from "npm:stripe@^17"
+import { handler } from "./handler.ts"
+
+Deno.env.set("STRIPE_SECRET_KEY", "sk_test_123")
+Deno.env.set("STRIPE_WEBHOOK_SIGNING_SECRET", "whsec_test")
+
+const stripe = new Stripe("sk_test_123", { httpClient: Stripe.createFetchHttpClient() })
+
+Deno.test("accepts signed event", async () => {
+  const payload = JSON.stringify({ id: "evt_test", object: "event", type: "checkout.session.completed", data: { object: { id: "cs_test" } } })
+  const signature = await stripe.webhooks.generateTestHeaderStringAsync({
+    payload,
+    secret: "whsec_test",
+    cryptoProvider: Stripe.createSubtleCryptoProvider(),
+  })
+  const req = new Request("http://localhost/{{ .Slug }}", {
+    method: "POST",
+    headers: { "Stripe-Signature": signature },
+    body: payload,
+  })
+  const res = await handler(req, stripe)
+  assertEquals(res.status, 200)
+  assertEquals(await res.json(), { received: true })
+})
+
+Deno.test("rejects invalid signature", async () => {
+  const req = new Request("http://localhost/{{ .Slug }}", {
+    method: "POST",
+    headers: { "Stripe-Signature": "t=0,v1=invalid" },
+    body: "{}",
+  })
+  const res = await handler(req, stripe)
+  assertEquals(res.status, 400)
+  await res.body?.cancel()
+})
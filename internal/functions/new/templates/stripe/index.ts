@@ -0,0 +1,22 @@
+// Follow this setup guide to integrate the Deno language server with your editor:
+// https://deno.land/manual/getting_started/setup_your_environment
+// This enables autocomplete, go to definition, etc.
+
+// Setup type definitions for built-in Supabase Runtime APIs
+import "jsr:@supabase/functions-js/edge-runtime.d.ts"
+import { handler } from "./handler.ts"
+
+Deno.serve(handler)
+
+/* To invoke locally:
+
+  1. Run `supabase start` (see: https://supabase.com/docs/reference/cli/supabase-start)
+  2. Set STRIPE_SECRET_KEY and STRIPE_WEBHOOK_SIGNING_SECRET in supabase/functions/.env
+  3. Run `supabase functions serve --no-verify-jwt --env-file supabase/functions/.env`
+  4. Forward events with the Stripe CLI:
+
+  stripe listen --forward-to '{{ .URL }}'
+
+  5. Run the tests: `deno test --allow-env --allow-net supabase/functions/{{ .Slug }}`
+
+*/
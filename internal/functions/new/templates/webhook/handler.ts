@@ -0,0 +1,36 @@
+// Payload sent by Supabase Database Webhooks
+// Ref: https://supabase.com/docs/guides/database/webhooks#payload
+export type WebhookPayload<T = Record<string, unknown>> =
+  | { type: "INSERT"; table: string; schema: string; record: T; old_record: null }
+  | { type: "UPDATE"; table: string; schema: string; record: T; old_record: T }
+  | { type: "DELETE"; table: string; schema: string; record: null; old_record: T }
+
+export async function handler(req: Request): Promise<Response> {
+  if (req.method !== "POST") {
+    return Response.json({ error: "Method not allowed" }, { status: 405 })
+  }
+  const secret = Deno.env.get("WEBHOOK_SECRET")
+  if (!secret) {
+    return Response.json({ error: "WEBHOOK_SECRET is not set" }, { status: 500 })
+  }
+  if (req.headers.get("x-webhook-secret") !== secret) {
+    return Response.json({ error: "Invalid webhook secret" }, { status: 401 })
+  }
+
+  const payload: WebhookPayload = await req.json()
+  switch (payload.type) {
+    case "INSERT":
+      console.log(`New row in ${payload.schema}.${payload.table}:`, payload.record)
+      break
+    case "UPDATE":
+      console.log(`Updated row in ${payload.schema}.${payload.table}:`, payload.old_record, "=>", payload.record)
+      break
+    case "DELETE":
+      console.log(`Deleted row in ${payload.schema}.${payload.table}:`, payload.old_record)
+      break
+    default:
+      return Response.json({ error: "Unsupported event type" }, { status: 400 })
+  }
+
+  return Response.json({ received: true })
+}
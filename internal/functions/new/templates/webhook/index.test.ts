@@ -0,0 +1,26 @@
+import { assertEquals } from "jsr:@std/assert"
+import { handler } from "./handler.ts"
+
+Deno.env.set("WEBHOOK_SECRET", "test-secret")
+
+Deno.test("accepts insert event", async () => {
+  const req = new Request("http://localhost/{{ .Slug }}", {
+    method: "POST",
+    headers: { "X-Webhook-Secret": "test-secret" },
+    body: JSON.stringify({ type: "INSERT", table: "profiles", schema: "public", record: { id: 1 }, old_record: null }),
+  })
+  const res = await handler(req)
+  assertEquals(res.status, 200)
+  assertEquals(await res.json(), { received: true })
+})
+
+Deno.test("rejects invalid secret", async () => {
+  const req = new Request("http://localhost/{{ .Slug }}", {
+    method: "POST",
+    headers: { "X-Webhook-Secret": "wrong" },
+    body: "{}",
+  })
+  const res = await handler(req)
+  assertEquals(res.status, 401)
+  await res.body?.cancel()
+})
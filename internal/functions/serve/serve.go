@@ -5,8 +5,10 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/supabase/cli/internal/functions/deploy"
 	"github.com/supabase/cli/internal/secrets/set"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
 )
 
 type InspectMode string
@@ -45,6 +48,17 @@ func (mode InspectMode) toFlag() string {
 type RuntimeOption struct {
 	InspectMode *InspectMode
 	InspectMain bool
+	// Host port of the inspector, defaults to edge_runtime.inspector_port in config
+	InspectPort uint16
+	// Serve only these functions so that the debugger attaches to their isolates alone
+	InspectFunctions []string
+}
+
+func (i *RuntimeOption) hostInspectorPort() uint16 {
+	if i.InspectPort > 0 {
+		return i.InspectPort
+	}
+	return utils.Config.EdgeRuntime.InspectorPort
 }
 
 func (i *RuntimeOption) toArgs() []string {
@@ -88,6 +102,18 @@ func Run(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPa
 	if err := ServeFunctions(ctx, envFilePath, noVerifyJWT, importMapPath, dbUrl, runtimeOption, fsys); err != nil {
 		return err
 	}
+	// 4. Hot reload functions affected by file changes
+	cwd, err := os.Getwd()
+	if err != nil {
+		return errors.Errorf("failed to get working directory: %w", err)
+	}
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		if err := watchFunctions(watchCtx, cwd, importMapPath, noVerifyJWT, reloadFunctions, fsys); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to watch functions:", err)
+		}
+	}()
 	if err := utils.DockerStreamLogs(ctx, utils.EdgeRuntimeId, os.Stdout, os.Stderr); err != nil {
 		return err
 	}
@@ -128,18 +154,23 @@ func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool,
 	if err != nil {
 		return errors.Errorf("failed to get working directory: %w", err)
 	}
-	binds, functionsConfigString, err := populatePerFunctionConfigs(cwd, importMapPath, noVerifyJWT, fsys)
+	binds, functionsConfigString, err := populatePerFunctionConfigs(cwd, importMapPath, noVerifyJWT, runtimeOption.InspectFunctions, fsys)
 	if err != nil {
 		return err
 	}
 	env = append(env, "SUPABASE_INTERNAL_FUNCTIONS_CONFIG="+functionsConfigString)
 	// 4. Parse entrypoint script
+	policy := utils.Config.EdgeRuntime.Policy
+	if runtimeOption.InspectMode != nil {
+		// Breakpoints are lost if the worker is recreated on every request
+		policy = config.PolicyPerWorker
+	}
 	cmd := append([]string{
 		"edge-runtime",
 		"start",
 		"--main-service=/root",
 		fmt.Sprintf("--port=%d", dockerRuntimeServerPort),
-		fmt.Sprintf("--policy=%s", utils.Config.EdgeRuntime.Policy),
+		fmt.Sprintf("--policy=%s", policy),
 	}, runtimeOption.toArgs()...)
 	if viper.GetBool("DEBUG") {
 		cmd = append(cmd, "--verbose")
@@ -157,7 +188,7 @@ EOF
 		dockerInspectorPort := nat.Port(fmt.Sprintf("%d/tcp", dockerRuntimeInspectorPort))
 		exposedPorts[dockerInspectorPort] = struct{}{}
 		portBindings[dockerInspectorPort] = []nat.PortBinding{{
-			HostPort: strconv.FormatUint(uint64(utils.Config.EdgeRuntime.InspectorPort), 10),
+			HostPort: strconv.FormatUint(uint64(runtimeOption.hostInspectorPort()), 10),
 		}}
 	}
 	// 6. Start container
@@ -184,7 +215,37 @@ EOF
 		},
 		utils.EdgeRuntimeId,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	if runtimeOption.InspectMode != nil {
+		printInspectorHint(os.Stderr, runtimeOption.hostInspectorPort(), cwd)
+	}
+	return nil
+}
+
+// Source files are mounted at the same path inside the container, so debuggers resolve
+// source maps once the remote root is mapped back to the workspace. The paths only differ
+// on Windows where drive letters are stripped.
+func printInspectorHint(w io.Writer, port uint16, cwd string) {
+	launch := map[string]any{
+		"type":       "node",
+		"request":    "attach",
+		"name":       "Supabase Edge Functions",
+		"address":    "127.0.0.1",
+		"port":       port,
+		"localRoot":  "${workspaceFolder}",
+		"remoteRoot": utils.ToDockerPath(cwd),
+		"sourceMaps": true,
+	}
+	encoded, err := json.MarshalIndent(launch, "", "  ")
+	if err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+		return
+	}
+	fmt.Fprintf(w, "Inspector is listening on %s\n", utils.Aqua(fmt.Sprintf("127.0.0.1:%d", port)))
+	fmt.Fprintln(w, "Open chrome://inspect in Chrome, or attach VS Code with this launch configuration:")
+	fmt.Fprintln(w, string(encoded))
 }
 
 func parseEnvFile(envFilePath string, fsys afero.Fs) ([]string, error) {
@@ -206,11 +267,19 @@ func parseEnvFile(envFilePath string, fsys afero.Fs) ([]string, error) {
 	return env, nil
 }
 
-func populatePerFunctionConfigs(cwd, importMapPath string, noVerifyJWT *bool, fsys afero.Fs) ([]string, string, error) {
+func populatePerFunctionConfigs(cwd, importMapPath string, noVerifyJWT *bool, filter []string, fsys afero.Fs) ([]string, string, error) {
 	slugs, err := deploy.GetFunctionSlugs(fsys)
 	if err != nil {
 		return nil, "", err
 	}
+	if len(filter) > 0 {
+		for _, name := range filter {
+			if !slices.Contains(slugs, name) {
+				return nil, "", errors.Errorf("Function not found: %s", name)
+			}
+		}
+		slugs = filter
+	}
 	functionsConfig, err := deploy.GetFunctionConfig(slugs, importMapPath, noVerifyJWT, fsys)
 	if err != nil {
 		return nil, "", err
@@ -221,13 +290,21 @@ func populatePerFunctionConfigs(cwd, importMapPath string, noVerifyJWT *bool, fs
 			fmt.Fprintln(os.Stderr, "Skipped serving Function:", slug)
 			continue
 		}
-		modules, err := deploy.GetBindMounts(cwd, utils.FunctionsDir, "", fc.Entrypoint, fc.ImportMap, fsys)
+		staticFiles, err := deploy.GetStaticFiles(fc.StaticFiles, fsys)
+		if err != nil {
+			return nil, "", err
+		}
+		modules, err := deploy.GetBindMounts(cwd, utils.FunctionsDir, "", fc.Entrypoint, fc.ImportMap, staticFiles, fsys)
 		if err != nil {
 			return nil, "", err
 		}
 		binds = append(binds, modules...)
 		fc.ImportMap = utils.ToDockerPath(fc.ImportMap)
 		fc.Entrypoint = utils.ToDockerPath(fc.Entrypoint)
+		fc.StaticFiles = make([]string, len(staticFiles))
+		for i, f := range staticFiles {
+			fc.StaticFiles[i] = utils.ToDockerPath(f)
+		}
 		functionsConfig[slug] = fc
 	}
 	functionsConfigBytes, err := json.Marshal(functionsConfig)
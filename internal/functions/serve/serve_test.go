@@ -1,7 +1,9 @@
 package serve
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -106,3 +108,40 @@ func TestServeCommand(t *testing.T) {
 		assert.ErrorIs(t, err, os.ErrNotExist)
 	})
 }
+
+func TestPopulatePerFunctionConfigs(t *testing.T) {
+	t.Run("serves only inspected functions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "hello", "index.ts"), []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "world", "index.ts"), []byte{}, 0644))
+		// Run test
+		_, functionsConfig, err := populatePerFunctionConfigs("/project", "", nil, []string{"world"}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		var result map[string]any
+		require.NoError(t, json.Unmarshal([]byte(functionsConfig), &result))
+		assert.Len(t, result, 1)
+		assert.Contains(t, result, "world")
+	})
+
+	t.Run("throws error on unknown function", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "hello", "index.ts"), []byte{}, 0644))
+		// Run test
+		_, _, err := populatePerFunctionConfigs("/project", "", nil, []string{"world"}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Function not found: world")
+	})
+}
+
+func TestPrintInspectorHint(t *testing.T) {
+	var out bytes.Buffer
+	// Run test
+	printInspectorHint(&out, 9229, "/project")
+	// Check output
+	assert.Contains(t, out.String(), "127.0.0.1:9229")
+	assert.Contains(t, out.String(), `"remoteRoot": "/project"`)
+	assert.Contains(t, out.String(), `"port": 9229`)
+}
@@ -53,6 +53,7 @@ interface FunctionConfig {
   entrypointPath: string;
   importMapPath: string;
   verifyJWT: boolean;
+  staticFiles?: string[];
 }
 
 function getResponse(payload: any, status: number, customHeaders = {}) {
@@ -91,6 +92,14 @@ const functionsConfig: Record<string, FunctionConfig> = (() => {
   }
 })();
 
+// Functions whose source changed since their worker was created
+const staleFunctions = new Set<string>();
+
+function logRequest(req: Request, functionName: string, status: number, start: number) {
+  const latency = (performance.now() - start).toFixed(0);
+  console.log(`[${functionName}] ${req.method} ${status} ${latency}ms`);
+}
+
 function getAuthToken(req: Request) {
   const authHeader = req.headers.get("authorization");
   if (!authHeader) {
@@ -131,97 +140,35 @@ Deno.serve({
       return Response.json(metric);
     }
 
-    const pathParts = pathname.split("/");
-    const functionName = pathParts[1];
-
-    if (!functionName || !(functionName in functionsConfig)) {
-      return getResponse("Function not found", STATUS_CODE.NotFound);
-    }
-
-    if (req.method !== "OPTIONS" && functionsConfig[functionName].verifyJWT) {
+    // handle hot reload of changed functions
+    if (pathname === "/_internal/reload" && req.method === "POST") {
       try {
-        const token = getAuthToken(req);
-        const isValidJWT = await verifyJWT(token);
-
+        const isValidJWT = await verifyJWT(getAuthToken(req));
         if (!isValidJWT) {
           return getResponse({ msg: "Invalid JWT" }, STATUS_CODE.Unauthorized);
         }
       } catch (e) {
-        console.error(e);
         return getResponse({ msg: e.toString() }, STATUS_CODE.Unauthorized);
       }
+      const { functions } = await req.json();
+      for (const name of functions) {
+        staleFunctions.add(name);
+      }
+      console.log(`Reloading functions: ${functions.join(", ")}`);
+      return getResponse({ message: "ok" }, STATUS_CODE.OK);
     }
 
-    const servicePath = posix.dirname(functionsConfig[functionName].entrypointPath);
-    console.error(`serving the request with ${servicePath}`);
-
-    // Ref: https://supabase.com/docs/guides/functions/limits
-    const memoryLimitMb = 256;
-    const workerTimeoutMs = isFinite(WALLCLOCK_LIMIT_SEC) ? WALLCLOCK_LIMIT_SEC * 1000 : 400 * 1000;
-    const noModuleCache = false;
-    const envVarsObj = Deno.env.toObject();
-    const envVars = Object.entries(envVarsObj)
-      .filter(([name, _]) =>
-        !EXCLUDED_ENVS.includes(name) && !name.startsWith("SUPABASE_INTERNAL_")
-      );
-
-    const forceCreate = false;
-    const customModuleRoot = ""; // empty string to allow any local path
-    const cpuTimeSoftLimitMs = 1000;
-    const cpuTimeHardLimitMs = 2000;
-
-    // NOTE(Nyannyacha): Decorator type has been set to tc39 by Lakshan's request,
-    // but in my opinion, we should probably expose this to customers at some
-    // point, as their migration process will not be easy.
-    const decoratorType = "tc39";
-
-    const absEntrypoint = posix.join(Deno.cwd(), functionsConfig[functionName].entrypointPath);
-    const maybeEntrypoint = posix.toFileUrl(absEntrypoint).href;
-
-    try {
-      const worker = await EdgeRuntime.userWorkers.create({
-        servicePath,
-        memoryLimitMb,
-        workerTimeoutMs,
-        noModuleCache,
-        importMapPath: functionsConfig[functionName].importMapPath,
-        envVars,
-        forceCreate,
-        customModuleRoot,
-        cpuTimeSoftLimitMs,
-        cpuTimeHardLimitMs,
-        decoratorType,
-        maybeEntrypoint,
-        context: {
-          useReadSyncFileAPI: true,
-        },
-      });
-
-      return await worker.fetch(req);
-    } catch (e) {
-      console.error(e);
-
-      for (const [denoError, sbCode] of DENO_SB_ERROR_MAP.entries()) {
-        if (denoError !== void 0 && e instanceof denoError) {
-          return getResponse(
-            {
-              code: SB_SPECIFIC_ERROR_TEXT[sbCode],
-              message: SB_SPECIFIC_ERROR_REASON[sbCode],
-            },
-            sbCode
-          );
-        }
-      }
+    const pathParts = pathname.split("/");
+    const functionName = pathParts[1];
 
-      return getResponse(
-        {
-          code: STATUS_TEXT[STATUS_CODE.InternalServerError],
-          message: "Request failed due to an internal server error",
-          trace: JSON.stringify(e.stack)
-        },
-        STATUS_CODE.InternalServerError,
-      );
+    if (!functionName || !(functionName in functionsConfig)) {
+      return getResponse("Function not found", STATUS_CODE.NotFound);
     }
+
+    const start = performance.now();
+    const response = await handleFunction(req, functionName);
+    logRequest(req, functionName, response.status, start);
+    return response;
   },
 
   onListen: () => {
@@ -241,3 +188,92 @@ Deno.serve({
     )
   }
 });
+
+async function handleFunction(req: Request, functionName: string) {
+  if (req.method !== "OPTIONS" && functionsConfig[functionName].verifyJWT) {
+    try {
+      const token = getAuthToken(req);
+      const isValidJWT = await verifyJWT(token);
+
+      if (!isValidJWT) {
+        return getResponse({ msg: "Invalid JWT" }, STATUS_CODE.Unauthorized);
+      }
+    } catch (e) {
+      console.error(e);
+      return getResponse({ msg: e.toString() }, STATUS_CODE.Unauthorized);
+    }
+  }
+
+  const servicePath = posix.dirname(functionsConfig[functionName].entrypointPath);
+  console.error(`serving the request with ${servicePath}`);
+
+  // Ref: https://supabase.com/docs/guides/functions/limits
+  const memoryLimitMb = 256;
+  const workerTimeoutMs = isFinite(WALLCLOCK_LIMIT_SEC) ? WALLCLOCK_LIMIT_SEC * 1000 : 400 * 1000;
+  const noModuleCache = false;
+  const envVarsObj = Deno.env.toObject();
+  const envVars = Object.entries(envVarsObj)
+    .filter(([name, _]) =>
+      !EXCLUDED_ENVS.includes(name) && !name.startsWith("SUPABASE_INTERNAL_")
+    );
+
+  // Recreate the worker if its source changed since last request
+  const forceCreate = staleFunctions.delete(functionName);
+  const customModuleRoot = ""; // empty string to allow any local path
+  const cpuTimeSoftLimitMs = 1000;
+  const cpuTimeHardLimitMs = 2000;
+
+  // NOTE(Nyannyacha): Decorator type has been set to tc39 by Lakshan's request,
+  // but in my opinion, we should probably expose this to customers at some
+  // point, as their migration process will not be easy.
+  const decoratorType = "tc39";
+
+  const absEntrypoint = posix.join(Deno.cwd(), functionsConfig[functionName].entrypointPath);
+  const maybeEntrypoint = posix.toFileUrl(absEntrypoint).href;
+
+  try {
+    const worker = await EdgeRuntime.userWorkers.create({
+      servicePath,
+      memoryLimitMb,
+      workerTimeoutMs,
+      noModuleCache,
+      importMapPath: functionsConfig[functionName].importMapPath,
+      staticPatterns: functionsConfig[functionName].staticFiles ?? [],
+      envVars,
+      forceCreate,
+      customModuleRoot,
+      cpuTimeSoftLimitMs,
+      cpuTimeHardLimitMs,
+      decoratorType,
+      maybeEntrypoint,
+      context: {
+        useReadSyncFileAPI: true,
+      },
+    });
+
+    return await worker.fetch(req);
+  } catch (e) {
+    console.error(e);
+
+    for (const [denoError, sbCode] of DENO_SB_ERROR_MAP.entries()) {
+      if (denoError !== void 0 && e instanceof denoError) {
+        return getResponse(
+          {
+            code: SB_SPECIFIC_ERROR_TEXT[sbCode],
+            message: SB_SPECIFIC_ERROR_REASON[sbCode],
+          },
+          sbCode
+        );
+      }
+    }
+
+    return getResponse(
+      {
+        code: STATUS_TEXT[STATUS_CODE.InternalServerError],
+        message: "Request failed due to an internal server error",
+        trace: JSON.stringify(e.stack)
+      },
+      STATUS_CODE.InternalServerError,
+    );
+  }
+}
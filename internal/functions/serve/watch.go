@@ -0,0 +1,194 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/deploy"
+	"github.com/supabase/cli/internal/status"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+// Wait for editors to finish writing before reloading
+const debounceInterval = 200 * time.Millisecond
+
+// Maps each function slug to the absolute paths of local modules it imports.
+type dependencyGraph map[string]map[string]struct{}
+
+func buildDependencyGraph(cwd string, functionsConfig config.FunctionConfig, fsys afero.Fs) dependencyGraph {
+	graph := dependencyGraph{}
+	for slug, fc := range functionsConfig {
+		var importMap *utils.ImportMap
+		if len(fc.ImportMap) > 0 {
+			// Deno config files without import map are ignored
+			if m, err := utils.NewImportMap(toAbsPath(cwd, fc.ImportMap), fsys); err == nil {
+				importMap = m
+			}
+		}
+		deps := map[string]struct{}{}
+		for _, file := range utils.ListLocalModules(toAbsPath(cwd, fc.Entrypoint), importMap, fsys) {
+			deps[file] = struct{}{}
+		}
+		graph[slug] = deps
+	}
+	return graph
+}
+
+// Returns the sorted slugs of functions that need reloading when path changes.
+func (g dependencyGraph) affected(path, cwd string, functionsConfig config.FunctionConfig) []string {
+	var result []string
+	for slug, fc := range functionsConfig {
+		_, isDep := g[slug][path]
+		isImportMap := len(fc.ImportMap) > 0 && toAbsPath(cwd, fc.ImportMap) == path
+		functionDir := filepath.Dir(toAbsPath(cwd, fc.Entrypoint)) + string(filepath.Separator)
+		if isDep || isImportMap || strings.HasPrefix(path, functionDir) {
+			result = append(result, slug)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Lists directories containing functions, their import maps and local dependencies.
+func (g dependencyGraph) watchDirs(cwd string, functionsConfig config.FunctionConfig, fsys afero.Fs) []string {
+	dirs := map[string]struct{}{}
+	functionsDir := toAbsPath(cwd, utils.FunctionsDir)
+	_ = afero.Walk(fsys, functionsDir, func(path string, info fs.FileInfo, err error) error {
+		if err == nil && info.IsDir() {
+			dirs[path] = struct{}{}
+		}
+		return nil
+	})
+	for slug, fc := range functionsConfig {
+		if len(fc.ImportMap) > 0 {
+			dirs[filepath.Dir(toAbsPath(cwd, fc.ImportMap))] = struct{}{}
+		}
+		for dep := range g[slug] {
+			dirs[filepath.Dir(dep)] = struct{}{}
+		}
+	}
+	result := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		result = append(result, dir)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func toAbsPath(cwd, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(cwd, path)
+}
+
+type reloadFunc func(ctx context.Context, slugs []string) error
+
+// Watches function sources, shared modules and import maps, reloading only
+// the functions affected by each change.
+func watchFunctions(ctx context.Context, cwd, importMapPath string, noVerifyJWT *bool, reload reloadFunc, fsys afero.Fs) error {
+	slugs, err := deploy.GetFunctionSlugs(fsys)
+	if err != nil {
+		return err
+	}
+	functionsConfig, err := deploy.GetFunctionConfig(slugs, importMapPath, noVerifyJWT, fsys)
+	if err != nil {
+		return err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Errorf("failed to create file watcher: %w", err)
+	}
+	defer watcher.Close()
+	graph := buildDependencyGraph(cwd, functionsConfig, fsys)
+	watched := map[string]struct{}{}
+	addWatchDirs := func() {
+		for _, dir := range graph.watchDirs(cwd, functionsConfig, fsys) {
+			if _, ok := watched[dir]; ok {
+				continue
+			}
+			if err := watcher.Add(dir); err != nil {
+				fmt.Fprintln(utils.GetDebugLogger(), err)
+				continue
+			}
+			watched[dir] = struct{}{}
+		}
+	}
+	addWatchDirs()
+	pending := map[string]struct{}{}
+	timer := time.NewTimer(debounceInterval)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+				continue
+			}
+			pending[event.Name] = struct{}{}
+			timer.Reset(debounceInterval)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+		case <-timer.C:
+			// Imports may have changed, so rebuild the graph before resolving
+			graph = buildDependencyGraph(cwd, functionsConfig, fsys)
+			addWatchDirs()
+			stale := map[string]struct{}{}
+			for path := range pending {
+				for _, slug := range graph.affected(path, cwd, functionsConfig) {
+					stale[slug] = struct{}{}
+				}
+				delete(pending, path)
+			}
+			if len(stale) == 0 {
+				continue
+			}
+			result := make([]string, 0, len(stale))
+			for slug := range stale {
+				result = append(result, slug)
+			}
+			sort.Strings(result)
+			if err := reload(ctx, result); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+	}
+}
+
+type reloadRequest struct {
+	Functions []string `json:"functions"`
+}
+
+// Marks functions as stale so the runtime recreates their workers on next request.
+func reloadFunctions(ctx context.Context, slugs []string) error {
+	api := fetcher.NewFetcher(
+		utils.Config.Api.ExternalUrl,
+		fetcher.WithHTTPClient(status.NewKongClient()),
+		fetcher.WithBearerToken(utils.Config.Auth.ServiceRoleKey),
+		fetcher.WithExpectedStatus(http.StatusOK),
+	)
+	resp, err := api.Send(ctx, http.MethodPost, "/functions/v1/_internal/reload", reloadRequest{Functions: slugs})
+	if err != nil {
+		return errors.Errorf("failed to reload functions: %w", err)
+	}
+	return resp.Body.Close()
+}
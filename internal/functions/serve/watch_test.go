@@ -0,0 +1,85 @@
+package serve
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	cwd := "/project"
+	functionsConfig := config.FunctionConfig{
+		"hello": {
+			Entrypoint: "supabase/functions/hello/index.ts",
+			ImportMap:  "/project/supabase/functions/import_map.json",
+		},
+		"world": {
+			Entrypoint: "supabase/functions/world/index.ts",
+		},
+	}
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fsys, "/project/supabase/functions/import_map.json", []byte(`{
+  "imports": {
+    "@shared/": "./_shared/",
+    "oak": "https://deno.land/x/oak/mod.ts"
+  }
+}`), 0644))
+	require.NoError(t, afero.WriteFile(fsys, "/project/supabase/functions/hello/index.ts", []byte(`import { Application } from "oak"
+import { cors } from "@shared/cors.ts"
+import "./handler.ts"`), 0644))
+	require.NoError(t, afero.WriteFile(fsys, "/project/supabase/functions/hello/handler.ts", []byte(`export * from "../_shared/db.ts"`), 0644))
+	require.NoError(t, afero.WriteFile(fsys, "/project/supabase/functions/_shared/cors.ts", []byte{}, 0644))
+	require.NoError(t, afero.WriteFile(fsys, "/project/supabase/functions/_shared/db.ts", []byte{}, 0644))
+	require.NoError(t, afero.WriteFile(fsys, "/project/supabase/functions/world/index.ts", []byte(`const mod = await import("../_shared/db.ts")`), 0644))
+	// Run test
+	graph := buildDependencyGraph(cwd, functionsConfig, fsys)
+	// Check result
+	assert.ElementsMatch(t, []string{
+		"/project/supabase/functions/hello/index.ts",
+		"/project/supabase/functions/hello/handler.ts",
+		"/project/supabase/functions/_shared/cors.ts",
+		"/project/supabase/functions/_shared/db.ts",
+	}, keys(graph["hello"]))
+	assert.ElementsMatch(t, []string{
+		"/project/supabase/functions/world/index.ts",
+		"/project/supabase/functions/_shared/db.ts",
+	}, keys(graph["world"]))
+
+	t.Run("reloads functions importing shared module", func(t *testing.T) {
+		path := filepath.FromSlash("/project/supabase/functions/_shared/db.ts")
+		assert.Equal(t, []string{"hello", "world"}, graph.affected(path, cwd, functionsConfig))
+	})
+
+	t.Run("reloads only the importing function", func(t *testing.T) {
+		path := filepath.FromSlash("/project/supabase/functions/_shared/cors.ts")
+		assert.Equal(t, []string{"hello"}, graph.affected(path, cwd, functionsConfig))
+	})
+
+	t.Run("reloads functions using import map", func(t *testing.T) {
+		path := filepath.FromSlash("/project/supabase/functions/import_map.json")
+		assert.Equal(t, []string{"hello"}, graph.affected(path, cwd, functionsConfig))
+	})
+
+	t.Run("reloads function on new file in its directory", func(t *testing.T) {
+		path := filepath.FromSlash("/project/supabase/functions/world/util.ts")
+		assert.Equal(t, []string{"world"}, graph.affected(path, cwd, functionsConfig))
+	})
+
+	t.Run("ignores unrelated files", func(t *testing.T) {
+		path := filepath.FromSlash("/project/supabase/functions/_shared/unused.ts")
+		assert.Empty(t, graph.affected(path, cwd, functionsConfig))
+	})
+}
+
+func keys(m map[string]struct{}) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+package docs
+
+import (
+	"context"
+	_ "embed"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	FormatMarkdown = "markdown"
+	FormatHtml     = "html"
+)
+
+var (
+	//go:embed templates/docs.md.tmpl
+	MarkdownTemplate string
+	//go:embed templates/docs.html.tmpl
+	HtmlTemplate string
+)
+
+func Run(ctx context.Context, dbConfig pgconn.Config, schemas []string, format, templatePath string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	tmpl := MarkdownTemplate
+	if format == FormatHtml {
+		tmpl = HtmlTemplate
+	}
+	if len(templatePath) > 0 {
+		contents, err := afero.ReadFile(fsys, templatePath)
+		if err != nil {
+			return errors.Errorf("failed to read template: %w", err)
+		}
+		tmpl = string(contents)
+	}
+	if len(schemas) == 0 {
+		schemas = utils.RemoveDuplicates(append([]string{"public"}, utils.Config.Api.Schemas...))
+	}
+	conn, err := utils.ConnectByConfig(ctx, dbConfig, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	doc, err := Introspect(ctx, conn, schemas)
+	if err != nil {
+		return err
+	}
+	return Render(os.Stdout, doc, format, tmpl)
+}
+
+// Render executes the template against introspected schema. HTML templates are
+// auto-escaped so that comments and expressions cannot inject markup.
+func Render(w io.Writer, doc Document, format, tmpl string) error {
+	funcs := map[string]any{
+		"join":  strings.Join,
+		"title": title,
+		"cell":  escapeCell,
+	}
+	if format == FormatHtml {
+		funcs["cell"] = func(s string) string { return s }
+		t, err := htmltemplate.New("docs").Funcs(funcs).Parse(tmpl)
+		if err != nil {
+			return errors.Errorf("failed to parse template: %w", err)
+		}
+		if err := t.Execute(w, doc); err != nil {
+			return errors.Errorf("failed to render template: %w", err)
+		}
+		return nil
+	}
+	t, err := template.New("docs").Funcs(funcs).Parse(tmpl)
+	if err != nil {
+		return errors.Errorf("failed to parse template: %w", err)
+	}
+	if err := t.Execute(w, doc); err != nil {
+		return errors.Errorf("failed to render template: %w", err)
+	}
+	return nil
+}
+
+func title(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// Markdown table cells cannot contain pipes or line breaks.
+func escapeCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
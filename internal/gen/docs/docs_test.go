@@ -0,0 +1,126 @@
+package docs
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/gen/types"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func mockIntrospect(conn *pgtest.MockConn, schemas []string) {
+	conn.Query(TablesQuery, schemas).
+		Reply("SELECT 1", Table{Schema: "public", Name: "todos", Kind: "table", Comment: "Things to do"}).
+		Query(types.ColumnsQuery, schemas).
+		Reply("SELECT 2",
+			types.Column{Schema: "public", Table: "todos", Name: "id", Type: "int8", Format: "bigint", HasDefault: true, Default: "nextval('todos_id_seq'::regclass)", Kind: types.KindTable},
+			types.Column{Schema: "public", Table: "todos", Name: "task", Type: "text", Format: "text", Nullable: true, Kind: types.KindTable, Comment: "What | to do"},
+		).
+		Query(types.ConstraintsQuery, schemas).
+		Reply("SELECT 1", types.Constraint{Schema: "public", Table: "todos", Name: "todos_pkey", Type: types.ConstraintPrimaryKey, Columns: []string{"id"}, ForeignColumns: []string{}, Definition: "PRIMARY KEY (id)"}).
+		Query(PoliciesQuery, schemas).
+		Reply("SELECT 1", Policy{Schema: "public", Table: "todos", Name: "owner", Command: "ALL", Roles: []string{"authenticated"}, Using: "(auth.uid() = owner)"}).
+		Query(FunctionsQuery, schemas).
+		Reply("SELECT 1", Function{Schema: "public", Name: "add_todo", Arguments: "task text", Returns: "bigint", Language: "sql", SecurityDefiner: true})
+}
+
+func TestIntrospect(t *testing.T) {
+	schemas := []string{"public"}
+
+	t.Run("groups objects by table and schema", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		mockIntrospect(conn, schemas)
+		// Run test
+		doc, err := Introspect(context.Background(), conn.MockClient(t), schemas)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, doc.Schemas, 1)
+		require.Len(t, doc.Schemas[0].Tables, 1)
+		table := doc.Schemas[0].Tables[0]
+		assert.Len(t, table.Columns, 2)
+		assert.Len(t, table.Constraints, 1)
+		assert.Len(t, table.Policies, 1)
+		assert.Len(t, doc.Schemas[0].Functions, 1)
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(TablesQuery, schemas).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for table pg_class")
+		// Run test
+		err := Run(context.Background(), dbConfig, schemas, FormatMarkdown, "", afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "permission denied for table pg_class")
+	})
+}
+
+func TestRender(t *testing.T) {
+	doc := Document{Schemas: []Schema{{
+		Name: "public",
+		Tables: []Table{{
+			Schema:  "public",
+			Name:    "todos",
+			Kind:    "table",
+			Comment: "<b>Things</b> to do",
+			Columns: []types.Column{
+				{Name: "task", Type: "text", Format: "text", Nullable: true, Comment: "What | to do"},
+			},
+			Constraints: []types.Constraint{
+				{Name: "todos_pkey", Type: types.ConstraintPrimaryKey, Definition: "PRIMARY KEY (id)"},
+			},
+		}},
+	}}}
+
+	t.Run("renders markdown", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := Render(&out, doc, FormatMarkdown, MarkdownTemplate)
+		// Check output
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "### Table `public.todos`")
+		assert.Contains(t, out.String(), "Row level security: **disabled**")
+		assert.Contains(t, out.String(), "|`task`|`text`|yes||What \\| to do|")
+		assert.Contains(t, out.String(), "|`todos_pkey`|primary key|`PRIMARY KEY (id)`|")
+	})
+
+	t.Run("escapes html", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := Render(&out, doc, FormatHtml, HtmlTemplate)
+		// Check output
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "<p>&lt;b&gt;Things&lt;/b&gt; to do</p>")
+	})
+
+	t.Run("renders custom template", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := Render(&out, doc, FormatMarkdown, `{{range .Schemas}}{{range .Tables}}{{.Name}}{{end}}{{end}}`)
+		// Check output
+		assert.NoError(t, err)
+		assert.Equal(t, "todos", out.String())
+	})
+
+	t.Run("throws error on invalid template", func(t *testing.T) {
+		err := Render(&bytes.Buffer{}, doc, FormatMarkdown, "{{.Missing")
+		assert.ErrorContains(t, err, "failed to parse template:")
+	})
+}
@@ -0,0 +1,131 @@
+package docs
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/gen/types"
+	"github.com/supabase/cli/pkg/pgxv5"
+)
+
+var (
+	//go:embed queries/tables.sql
+	TablesQuery string
+	//go:embed queries/policies.sql
+	PoliciesQuery string
+	//go:embed queries/functions.sql
+	FunctionsQuery string
+)
+
+type Policy struct {
+	Schema  string   `db:"schema"`
+	Table   string   `db:"table"`
+	Name    string   `db:"name"`
+	Command string   `db:"command"`
+	Roles   []string `db:"roles"`
+	Using   string   `db:"using"`
+	Check   string   `db:"check"`
+}
+
+type Function struct {
+	Schema          string `db:"schema"`
+	Name            string `db:"name"`
+	Arguments       string `db:"arguments"`
+	Returns         string `db:"returns"`
+	Language        string `db:"language"`
+	SecurityDefiner bool   `db:"security_definer"`
+	Comment         string `db:"comment"`
+}
+
+type Table struct {
+	Schema      string             `db:"schema"`
+	Name        string             `db:"name"`
+	Kind        string             `db:"kind"`
+	Comment     string             `db:"comment"`
+	RlsEnabled  bool               `db:"rls_enabled"`
+	Columns     []types.Column     `db:"-"`
+	Constraints []types.Constraint `db:"-"`
+	Policies    []Policy           `db:"-"`
+}
+
+type Schema struct {
+	Name      string
+	Tables    []Table
+	Functions []Function
+}
+
+// Document is the data passed to docs templates.
+type Document struct {
+	Schemas []Schema
+}
+
+// Introspect loads tables, views, and functions from the given schemas, keeping
+// the order of schemas as specified by the user.
+func Introspect(ctx context.Context, conn *pgx.Conn, schemas []string) (Document, error) {
+	var doc Document
+	tables, err := queryRows[Table](ctx, conn, TablesQuery, schemas, "tables")
+	if err != nil {
+		return doc, err
+	}
+	// Columns and constraints are shared with other generators
+	columns, err := queryRows[types.Column](ctx, conn, types.ColumnsQuery, schemas, "columns")
+	if err != nil {
+		return doc, err
+	}
+	constraints, err := types.IntrospectConstraints(ctx, conn, schemas)
+	if err != nil {
+		return doc, err
+	}
+	policies, err := queryRows[Policy](ctx, conn, PoliciesQuery, schemas, "policies")
+	if err != nil {
+		return doc, err
+	}
+	functions, err := queryRows[Function](ctx, conn, FunctionsQuery, schemas, "functions")
+	if err != nil {
+		return doc, err
+	}
+	index := map[[2]string]*Table{}
+	for i := range tables {
+		index[[2]string{tables[i].Schema, tables[i].Name}] = &tables[i]
+	}
+	for _, c := range columns {
+		if t, ok := index[[2]string{c.Schema, c.Table}]; ok {
+			t.Columns = append(t.Columns, c)
+		}
+	}
+	for _, c := range constraints {
+		if t, ok := index[[2]string{c.Schema, c.Table}]; ok {
+			t.Constraints = append(t.Constraints, c)
+		}
+	}
+	for _, p := range policies {
+		if t, ok := index[[2]string{p.Schema, p.Table}]; ok {
+			t.Policies = append(t.Policies, p)
+		}
+	}
+	for _, name := range schemas {
+		s := Schema{Name: name}
+		for _, t := range tables {
+			if t.Schema == name {
+				s.Tables = append(s.Tables, t)
+			}
+		}
+		for _, f := range functions {
+			if f.Schema == name {
+				s.Functions = append(s.Functions, f)
+			}
+		}
+		doc.Schemas = append(doc.Schemas, s)
+	}
+	return doc, nil
+}
+
+func queryRows[T any](ctx context.Context, conn *pgx.Conn, sql string, schemas []string, name string) ([]T, error) {
+	rows, err := conn.Query(ctx, sql, schemas)
+	if err != nil {
+		return nil, errors.Errorf("failed to query %s: %w", name, err)
+	}
+	return pgxv5.CollectRows[T](rows)
+}
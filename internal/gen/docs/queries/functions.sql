@@ -0,0 +1,14 @@
+SELECT
+  n.nspname AS schema,
+  p.proname AS name,
+  pg_get_function_arguments(p.oid) AS arguments,
+  pg_get_function_result(p.oid) AS returns,
+  l.lanname AS language,
+  p.prosecdef AS security_definer,
+  coalesce(obj_description(p.oid, 'pg_proc'), '') AS comment
+FROM pg_proc p
+JOIN pg_namespace n ON n.oid = p.pronamespace
+JOIN pg_language l ON l.oid = p.prolang
+LEFT JOIN pg_depend d ON d.objid = p.oid AND d.deptype = 'e'
+WHERE p.prokind IN ('f', 'p') AND n.nspname = ANY($1) AND d.objid IS NULL
+ORDER BY n.nspname, p.proname, pg_get_function_arguments(p.oid)
@@ -0,0 +1,11 @@
+SELECT
+  p.schemaname AS schema,
+  p.tablename AS table,
+  p.policyname AS name,
+  p.cmd AS command,
+  p.roles::text[] AS roles,
+  coalesce(p.qual, '') AS using,
+  coalesce(p.with_check, '') AS check
+FROM pg_policies p
+WHERE p.schemaname = ANY($1)
+ORDER BY p.schemaname, p.tablename, p.policyname
@@ -0,0 +1,10 @@
+SELECT
+  n.nspname AS schema,
+  c.relname AS name,
+  CASE c.relkind WHEN 'v' THEN 'view' WHEN 'm' THEN 'materialized view' WHEN 'f' THEN 'foreign table' ELSE 'table' END AS kind,
+  coalesce(obj_description(c.oid, 'pg_class'), '') AS comment,
+  c.relrowsecurity AS rls_enabled
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f') AND n.nspname = ANY($1)
+ORDER BY n.nspname, c.relname
@@ -0,0 +1,176 @@
+package erd
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/gen/types"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	FormatMermaid = "mermaid"
+	FormatDot     = "dot"
+)
+
+// Diagram holds the introspected tables and their keys.
+type Diagram struct {
+	Tables      []types.Table
+	Constraints []types.Constraint
+}
+
+func Run(ctx context.Context, dbConfig pgconn.Config, schemas []string, format string, options ...func(*pgx.ConnConfig)) error {
+	if len(schemas) == 0 {
+		schemas = utils.RemoveDuplicates(append([]string{"public"}, utils.Config.Api.Schemas...))
+	}
+	conn, err := utils.ConnectByConfig(ctx, dbConfig, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	spec, err := types.Introspect(ctx, conn, schemas)
+	if err != nil {
+		return err
+	}
+	constraints, err := types.IntrospectConstraints(ctx, conn, schemas)
+	if err != nil {
+		return err
+	}
+	diagram := Diagram{Tables: spec.Tables, Constraints: constraints}
+	switch format {
+	case FormatMermaid:
+		return GenerateMermaid(os.Stdout, diagram)
+	case FormatDot:
+		return GenerateDot(os.Stdout, diagram)
+	}
+	return errors.Errorf("unsupported format: %s", format)
+}
+
+func (d Diagram) isKey(c types.Column, kind string) bool {
+	for _, k := range d.Constraints {
+		if k.Type == kind && k.Schema == c.Schema && k.Table == c.Table && slices.Contains(k.Columns, c.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d Diagram) isNullable(k types.Constraint) bool {
+	for _, t := range d.Tables {
+		if t.Schema != k.Schema || t.Name != k.Table {
+			continue
+		}
+		for _, c := range t.Columns {
+			if c.Nullable && slices.Contains(k.Columns, c.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (d Diagram) foreignKeys() []types.Constraint {
+	var result []types.Constraint
+	for _, k := range d.Constraints {
+		if k.Type == types.ConstraintForeignKey {
+			result = append(result, k)
+		}
+	}
+	return result
+}
+
+func columnType(c types.Column) string {
+	if c.IsArray() {
+		return c.ElemType() + "[]"
+	}
+	return c.Type
+}
+
+var invalidIdentifier = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// Mermaid entity ids only allow word characters, so the qualified name is shown as alias.
+func mermaidId(schema, table string) string {
+	return invalidIdentifier.ReplaceAllString(schema+"_"+table, "_")
+}
+
+func GenerateMermaid(w io.Writer, d Diagram) error {
+	var sb strings.Builder
+	sb.WriteString("erDiagram\n")
+	for _, t := range d.Tables {
+		fmt.Fprintf(&sb, "  %s[\"%s.%s\"] {\n", mermaidId(t.Schema, t.Name), t.Schema, t.Name)
+		for _, c := range t.Columns {
+			var keys []string
+			if d.isKey(c, types.ConstraintPrimaryKey) {
+				keys = append(keys, "PK")
+			}
+			if d.isKey(c, types.ConstraintForeignKey) {
+				keys = append(keys, "FK")
+			}
+			fmt.Fprintf(&sb, "    %s %s", columnType(c), invalidIdentifier.ReplaceAllString(c.Name, "_"))
+			if len(keys) > 0 {
+				fmt.Fprintf(&sb, " %s", strings.Join(keys, ","))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("  }\n")
+	}
+	for _, k := range d.foreignKeys() {
+		parent := "||"
+		if d.isNullable(k) {
+			parent = "|o"
+		}
+		fmt.Fprintf(&sb, "  %s %s--o{ %s : \"%s\"\n", mermaidId(k.ForeignSchema, k.ForeignTable), parent, mermaidId(k.Schema, k.Table), k.Name)
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return errors.Errorf("failed to write diagram: %w", err)
+	}
+	return nil
+}
+
+func GenerateDot(w io.Writer, d Diagram) error {
+	var sb strings.Builder
+	sb.WriteString("digraph erd {\n")
+	sb.WriteString("  graph [rankdir=LR];\n")
+	sb.WriteString("  node [shape=plaintext];\n")
+	for _, t := range d.Tables {
+		name := t.Schema + "." + t.Name
+		fmt.Fprintf(&sb, "  %q [label=<<table border=\"0\" cellborder=\"1\" cellspacing=\"0\">", name)
+		fmt.Fprintf(&sb, "<tr><td bgcolor=\"#eeeeee\"><b>%s</b></td></tr>", html.EscapeString(name))
+		for _, c := range t.Columns {
+			label := html.EscapeString(c.Name + ": " + columnType(c))
+			if d.isKey(c, types.ConstraintPrimaryKey) {
+				label = "<b>" + label + "</b>"
+			}
+			fmt.Fprintf(&sb, "<tr><td port=%q align=\"left\">%s</td></tr>", html.EscapeString(c.Name), label)
+		}
+		sb.WriteString("</table>>];\n")
+	}
+	for _, k := range d.foreignKeys() {
+		from := fmt.Sprintf("%q", k.Schema+"."+k.Table)
+		to := fmt.Sprintf("%q", k.ForeignSchema+"."+k.ForeignTable)
+		// Composite keys are drawn between tables instead of columns
+		if len(k.Columns) == 1 && len(k.ForeignColumns) == 1 {
+			from += fmt.Sprintf(":%q", k.Columns[0])
+			to += fmt.Sprintf(":%q", k.ForeignColumns[0])
+		}
+		style := ""
+		if d.isNullable(k) {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&sb, "  %s -> %s [label=%q%s];\n", from, to, k.Name, style)
+	}
+	sb.WriteString("}\n")
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return errors.Errorf("failed to write diagram: %w", err)
+	}
+	return nil
+}
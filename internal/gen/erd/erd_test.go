@@ -0,0 +1,98 @@
+package erd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/gen/types"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var diagram = Diagram{
+	Tables: []types.Table{{
+		Schema: "public",
+		Name:   "todos",
+		Columns: []types.Column{
+			{Schema: "public", Table: "todos", Name: "id", Type: "int8"},
+			{Schema: "public", Table: "todos", Name: "owner", Type: "uuid", Nullable: true},
+			{Schema: "public", Table: "todos", Name: "tags", Type: "_text"},
+		},
+	}},
+	Constraints: []types.Constraint{
+		{Schema: "public", Table: "todos", Name: "todos_pkey", Type: types.ConstraintPrimaryKey, Columns: []string{"id"}},
+		{Schema: "public", Table: "todos", Name: "todos_owner_fkey", Type: types.ConstraintForeignKey, Columns: []string{"owner"}, ForeignSchema: "auth", ForeignTable: "users", ForeignColumns: []string{"id"}},
+	},
+}
+
+func TestGenerateMermaid(t *testing.T) {
+	var out bytes.Buffer
+	// Run test
+	err := GenerateMermaid(&out, diagram)
+	// Check output
+	assert.NoError(t, err)
+	assert.Equal(t, `erDiagram
+  public_todos["public.todos"] {
+    int8 id PK
+    uuid owner FK
+    text[] tags
+  }
+  auth_users |o--o{ public_todos : "todos_owner_fkey"
+`, out.String())
+}
+
+func TestGenerateDot(t *testing.T) {
+	var out bytes.Buffer
+	// Run test
+	err := GenerateDot(&out, diagram)
+	// Check output
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), `<tr><td port="id" align="left"><b>id: int8</b></td></tr>`)
+	assert.Contains(t, out.String(), `"public.todos":"owner" -> "auth.users":"id" [label="todos_owner_fkey", style=dashed];`)
+}
+
+func TestRun(t *testing.T) {
+	dbConfig := pgconn.Config{
+		Host:     "127.0.0.1",
+		Port:     5432,
+		User:     "admin",
+		Password: "password",
+		Database: "postgres",
+	}
+	schemas := []string{"public"}
+
+	t.Run("generates diagram from introspection", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(types.ColumnsQuery, schemas).
+			Reply("SELECT 1", types.Column{Schema: "public", Table: "todos", Name: "id", Type: "int8"}).
+			Query(types.EnumsQuery, schemas).
+			Reply("SELECT 0").
+			Query(types.ConstraintsQuery, schemas).
+			Reply("SELECT 1", types.Constraint{Schema: "public", Table: "todos", Name: "todos_pkey", Type: "p", Columns: []string{"id"}, ForeignColumns: []string{}})
+		// Run test
+		err := Run(context.Background(), dbConfig, schemas, FormatMermaid, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(types.ColumnsQuery, schemas).
+			Reply("SELECT 0").
+			Query(types.EnumsQuery, schemas).
+			Reply("SELECT 0").
+			Query(types.ConstraintsQuery, schemas).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for table pg_constraint")
+		// Run test
+		err := Run(context.Background(), dbConfig, schemas, FormatDot, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "permission denied for table pg_constraint")
+	})
+}
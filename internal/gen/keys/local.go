@@ -0,0 +1,129 @@
+package keys
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/start"
+	"github.com/supabase/cli/internal/stop"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+// Env vars read by config.toml loader to override the default demo keys.
+const (
+	EnvJwtSecret      = "SUPABASE_AUTH_JWT_SECRET"
+	EnvAnonKey        = "SUPABASE_AUTH_ANON_KEY"
+	EnvServiceRoleKey = "SUPABASE_AUTH_SERVICE_ROLE_KEY"
+)
+
+// RunLocal replaces the default demo keys of the local stack with a fresh JWT secret and
+// matching anon and service role keys. The keys are saved to envPath, which is loaded
+// together with config.toml, and the local stack is restarted if it is running.
+func RunLocal(ctx context.Context, envPath, format string, names CustomName, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	keys, err := GenerateLocalKeys()
+	if err != nil {
+		return err
+	}
+	if err := UpdateEnvFile(envPath, keys, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Saved new keys to "+utils.Bold(envPath))
+	if err := utils.EncodeOutput(format, os.Stdout, map[string]string{
+		names.JWTSecret:      keys[EnvJwtSecret],
+		names.AnonKey:        keys[EnvAnonKey],
+		names.ServiceRoleKey: keys[EnvServiceRoleKey],
+	}); err != nil {
+		return err
+	}
+	// Keys are picked up on next start if the local stack is not running
+	if _, err := utils.Docker.ContainerInspect(ctx, utils.DbId); err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+		return nil
+	}
+	if shouldRestart, err := utils.NewConsole().PromptYesNo(ctx, "Restart local development setup to use the new keys?", true); err != nil {
+		return err
+	} else if !shouldRestart {
+		utils.CmdSuggestion = fmt.Sprintf("Run %s and %s to use the new keys.", utils.Aqua("supabase stop"), utils.Aqua("supabase start"))
+		return nil
+	}
+	// Values loaded from env files previously must not shadow the new keys
+	for k, v := range keys {
+		if err := os.Setenv(k, v); err != nil {
+			return errors.Errorf("failed to set env: %w", err)
+		}
+	}
+	if err := stop.Run(ctx, true, "", false, fsys); err != nil {
+		return err
+	}
+	return start.Run(ctx, fsys, []string{}, false, 2*time.Minute)
+}
+
+// GenerateLocalKeys returns a random JWT secret with anon and service role keys signed
+// using the same claims as the default keys of the local stack.
+func GenerateLocalKeys() (map[string]string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.Errorf("failed to generate JWT secret: %w", err)
+	}
+	result := map[string]string{EnvJwtSecret: hex.EncodeToString(secret)}
+	for env, role := range map[string]string{
+		EnvAnonKey:        "anon",
+		EnvServiceRoleKey: "service_role",
+	} {
+		signed, err := config.CustomClaims{Role: role}.NewToken().SignedString([]byte(result[EnvJwtSecret]))
+		if err != nil {
+			return nil, errors.Errorf("failed to sign %s key: %w", role, err)
+		}
+		result[env] = signed
+	}
+	return result, nil
+}
+
+var envLinePattern = regexp.MustCompile(`^(\s*(?:export\s+)?)([A-Za-z_][A-Za-z0-9_]*)\s*=`)
+
+// UpdateEnvFile replaces the values of existing variables in place, keeping comments and
+// other variables untouched, and appends any variables that are missing.
+func UpdateEnvFile(path string, values map[string]string, fsys afero.Fs) error {
+	contents, err := afero.ReadFile(fsys, path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Errorf("failed to read env file: %w", err)
+	}
+	lines := strings.Split(string(contents), "\n")
+	if n := len(lines); n > 0 && len(lines[n-1]) == 0 {
+		lines = lines[:n-1]
+	}
+	updated := map[string]bool{}
+	for i, line := range lines {
+		if m := envLinePattern.FindStringSubmatch(line); len(m) > 0 {
+			if v, ok := values[m[2]]; ok {
+				lines[i] = m[1] + m[2] + "=" + v
+				updated[m[2]] = true
+			}
+		}
+	}
+	for _, k := range slices.Sorted(maps.Keys(values)) {
+		if !updated[k] {
+			lines = append(lines, k+"="+values[k])
+		}
+	}
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line + "\n")
+	}
+	return utils.WriteFile(path, buf.Bytes(), fsys)
+}
@@ -0,0 +1,62 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestGenerateLocalKeys(t *testing.T) {
+	// Run test
+	keys, err := GenerateLocalKeys()
+	// Check error
+	assert.NoError(t, err)
+	assert.Len(t, keys[EnvJwtSecret], 64)
+	for env, role := range map[string]string{EnvAnonKey: "anon", EnvServiceRoleKey: "service_role"} {
+		var claims config.CustomClaims
+		_, err := jwt.ParseWithClaims(keys[env], &claims, func(*jwt.Token) (interface{}, error) {
+			return []byte(keys[EnvJwtSecret]), nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, role, claims.Role)
+	}
+}
+
+func TestUpdateEnvFile(t *testing.T) {
+	t.Run("replaces existing values in place", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ".env", []byte(`# local secrets
+OPENAI_API_KEY=sk-test
+export SUPABASE_AUTH_JWT_SECRET = old
+`), 0644))
+		// Run test
+		err := UpdateEnvFile(".env", map[string]string{
+			EnvJwtSecret: "new",
+			EnvAnonKey:   "anon",
+		}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, ".env")
+		assert.NoError(t, err)
+		assert.Equal(t, `# local secrets
+OPENAI_API_KEY=sk-test
+export SUPABASE_AUTH_JWT_SECRET=new
+SUPABASE_AUTH_ANON_KEY=anon
+`, string(contents))
+	})
+
+	t.Run("creates missing file", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := UpdateEnvFile("supabase/.env", map[string]string{EnvJwtSecret: "new"}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, "supabase/.env")
+		assert.NoError(t, err)
+		assert.Equal(t, "SUPABASE_AUTH_JWT_SECRET=new\n", string(contents))
+	})
+}
@@ -0,0 +1,237 @@
+package types
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/go-errors/errors"
+)
+
+// Generator renders introspected schema to the output language.
+type Generator func(w io.Writer, spec Introspection) error
+
+// Languages not supported by pg-meta are generated natively by the CLI.
+var generators = map[string]Generator{
+	LangKotlin: GenerateKotlin,
+	LangPython: GeneratePython,
+}
+
+func GetGenerator(lang string) (Generator, bool) {
+	gen, ok := generators[lang]
+	return gen, ok
+}
+
+func toPascalCase(name string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func toCamelCase(name string) string {
+	pascal := []rune(toPascalCase(name))
+	if len(pascal) > 0 {
+		pascal[0] = unicode.ToLower(pascal[0])
+	}
+	return string(pascal)
+}
+
+// Tables outside the public schema are prefixed to avoid name collisions.
+func typeName(schema, name string) string {
+	if schema == "public" {
+		return toPascalCase(name)
+	}
+	return toPascalCase(schema + "_" + name)
+}
+
+var kotlinTypes = map[string]string{
+	"bool":        "Boolean",
+	"int2":        "Short",
+	"int4":        "Int",
+	"int8":        "Long",
+	"float4":      "Float",
+	"float8":      "Double",
+	"numeric":     "Double",
+	"json":        "JsonElement",
+	"jsonb":       "JsonElement",
+	"text":        "String",
+	"varchar":     "String",
+	"bpchar":      "String",
+	"uuid":        "String",
+	"date":        "String",
+	"time":        "String",
+	"timetz":      "String",
+	"timestamp":   "String",
+	"timestamptz": "String",
+}
+
+func kotlinType(c Column, spec Introspection) string {
+	result, ok := kotlinTypes[c.ElemType()]
+	if e, found := spec.FindEnum(c.TypeSchema, c.ElemType()); found {
+		result = kotlinIdent(typeName(e.Schema, e.Name))
+	} else if !ok {
+		result = "String"
+	}
+	if c.IsArray() {
+		result = fmt.Sprintf("List<%s>", result)
+	}
+	if c.Nullable {
+		result += "?"
+	}
+	return result
+}
+
+// Hard keywords cannot be used as identifiers without escaping.
+var kotlinKeywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true, "else": true,
+	"false": true, "for": true, "fun": true, "if": true, "in": true, "interface": true,
+	"is": true, "null": true, "object": true, "package": true, "return": true, "super": true,
+	"this": true, "throw": true, "true": true, "try": true, "typealias": true, "typeof": true,
+	"val": true, "var": true, "when": true, "while": true,
+}
+
+// Escapes names starting with a digit, and keywords, in backticks.
+func kotlinIdent(name string) string {
+	if len(name) == 0 || unicode.IsDigit([]rune(name)[0]) || kotlinKeywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+func GenerateKotlin(w io.Writer, spec Introspection) error {
+	var sb strings.Builder
+	sb.WriteString("import kotlinx.serialization.SerialName\n")
+	sb.WriteString("import kotlinx.serialization.Serializable\n")
+	sb.WriteString("import kotlinx.serialization.json.JsonElement\n")
+	for _, e := range spec.Enums {
+		fmt.Fprintf(&sb, "\n@Serializable\nenum class %s {\n", kotlinIdent(typeName(e.Schema, e.Name)))
+		for i, v := range e.Values {
+			fmt.Fprintf(&sb, "    @SerialName(%q) %s", v, kotlinIdent(strings.ToUpper(toCamelCase(v))))
+			if i < len(e.Values)-1 {
+				sb.WriteString(",")
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("}\n")
+	}
+	for _, t := range spec.Tables {
+		fmt.Fprintf(&sb, "\n@Serializable\ndata class %s(\n", kotlinIdent(typeName(t.Schema, t.Name)))
+		for _, c := range t.Columns {
+			fmt.Fprintf(&sb, "    @SerialName(%q) val %s: %s", c.Name, kotlinIdent(toCamelCase(c.Name)), kotlinType(c, spec))
+			if c.Nullable {
+				sb.WriteString(" = null")
+			}
+			sb.WriteString(",\n")
+		}
+		sb.WriteString(")\n")
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return errors.Errorf("failed to write kotlin types: %w", err)
+	}
+	return nil
+}
+
+var pythonTypes = map[string]string{
+	"bool":        "bool",
+	"int2":        "int",
+	"int4":        "int",
+	"int8":        "int",
+	"float4":      "float",
+	"float8":      "float",
+	"numeric":     "float",
+	"json":        "Any",
+	"jsonb":       "Any",
+	"text":        "str",
+	"varchar":     "str",
+	"bpchar":      "str",
+	"uuid":        "str",
+	"date":        "str",
+	"time":        "str",
+	"timetz":      "str",
+	"timestamp":   "str",
+	"timestamptz": "str",
+}
+
+func pythonType(c Column, spec Introspection) string {
+	result, ok := pythonTypes[c.ElemType()]
+	if e, found := spec.FindEnum(c.TypeSchema, c.ElemType()); found {
+		result = typeName(e.Schema, e.Name)
+	} else if !ok {
+		result = "str"
+	}
+	if c.IsArray() {
+		result = fmt.Sprintf("List[%s]", result)
+	}
+	if c.Nullable {
+		result = fmt.Sprintf("Optional[%s]", result)
+	}
+	return result
+}
+
+// Reserved words cannot be used as attribute names, even on dataclasses.
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true,
+	"async": true, "await": true, "break": true, "class": true, "continue": true, "def": true,
+	"del": true, "elif": true, "else": true, "except": true, "finally": true, "for": true,
+	"from": true, "global": true, "if": true, "import": true, "in": true, "is": true,
+	"lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true, "raise": true,
+	"return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+// Replaces characters not allowed in python identifiers with underscores, prefixes
+// names starting with a digit, and suffixes keywords with an underscore.
+func pythonIdent(name string) string {
+	ident := strings.Join(strings.FieldsFunc(name, isSeparator), "_")
+	if len(ident) == 0 || unicode.IsDigit([]rune(ident)[0]) {
+		ident = "_" + ident
+	}
+	if pythonKeywords[ident] {
+		ident += "_"
+	}
+	return ident
+}
+
+func GeneratePython(w io.Writer, spec Introspection) error {
+	var sb strings.Builder
+	sb.WriteString("from dataclasses import dataclass, field\n")
+	sb.WriteString("from enum import Enum\n")
+	sb.WriteString("from typing import Any, List, Optional\n")
+	for _, e := range spec.Enums {
+		fmt.Fprintf(&sb, "\n\nclass %s(str, Enum):\n", typeName(e.Schema, e.Name))
+		for _, v := range e.Values {
+			fmt.Fprintf(&sb, "    %s = %q\n", pythonIdent(strings.ToUpper(v)), v)
+		}
+	}
+	for _, t := range spec.Tables {
+		fmt.Fprintf(&sb, "\n\n@dataclass\nclass %s:\n", typeName(t.Schema, t.Name))
+		for _, c := range t.Columns {
+			ident := pythonIdent(c.Name)
+			fmt.Fprintf(&sb, "    %s: %s", ident, pythonType(c, spec))
+			// Keep the original column name for serialisation
+			if ident != c.Name {
+				fmt.Fprintf(&sb, " = field(metadata={\"alias\": %q})", c.Name)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return errors.Errorf("failed to write python types: %w", err)
+	}
+	return nil
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+}
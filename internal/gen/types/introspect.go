@@ -0,0 +1,181 @@
+package types
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/pkg/pgxv5"
+)
+
+var (
+	//go:embed queries/columns.sql
+	ColumnsQuery string
+	//go:embed queries/enums.sql
+	EnumsQuery string
+	//go:embed queries/constraints.sql
+	ConstraintsQuery string
+)
+
+const (
+	ConstraintPrimaryKey = "p"
+	ConstraintUnique     = "u"
+	ConstraintForeignKey = "f"
+	ConstraintCheck      = "c"
+	ConstraintExclusion  = "x"
+	ConstraintTrigger    = "t"
+)
+
+// Relation kinds of tables and their columns.
+const (
+	KindTable            = "table"
+	KindView             = "view"
+	KindMaterializedView = "materialized view"
+	KindForeignTable     = "foreign table"
+)
+
+// Column describes a column of a table or view. Type is the name of the base type in
+// pg_type, while Format is the SQL type with modifiers, ie. character varying(64).
+type Column struct {
+	Schema     string `db:"schema"`
+	Table      string `db:"table"`
+	Name       string `db:"name"`
+	TypeSchema string `db:"type_schema"`
+	Type       string `db:"type"`
+	Format     string `db:"format"`
+	Nullable   bool   `db:"nullable"`
+	HasDefault bool   `db:"has_default"`
+	Default    string `db:"default"`
+	MaxLength  int    `db:"max_length"`
+	Identity   bool   `db:"identity"`
+	Generated  bool   `db:"generated"`
+	Kind       string `db:"kind"`
+	Comment    string `db:"comment"`
+}
+
+// Array types are prefixed with an underscore in pg_type, ie. _text
+func (c Column) IsArray() bool {
+	return len(c.Type) > 1 && c.Type[0] == '_'
+}
+
+func (c Column) ElemType() string {
+	if c.IsArray() {
+		return c.Type[1:]
+	}
+	return c.Type
+}
+
+type Table struct {
+	Schema  string
+	Name    string
+	Kind    string
+	Columns []Column
+}
+
+func (t Table) QualifiedName() string {
+	return t.Schema + "." + t.Name
+}
+
+type Enum struct {
+	Schema string   `db:"schema"`
+	Name   string   `db:"name"`
+	Values []string `db:"values"`
+}
+
+// Constraint describes a table constraint, ie. a primary or foreign key. Foreign
+// columns are empty for all but foreign keys.
+type Constraint struct {
+	Schema         string   `db:"schema"`
+	Table          string   `db:"table"`
+	Name           string   `db:"name"`
+	Type           string   `db:"type"`
+	Columns        []string `db:"columns"`
+	ForeignSchema  string   `db:"foreign_schema"`
+	ForeignTable   string   `db:"foreign_table"`
+	ForeignColumns []string `db:"foreign_columns"`
+	Definition     string   `db:"definition"`
+}
+
+func (k Constraint) IsKey() bool {
+	return k.Type == ConstraintPrimaryKey || k.Type == ConstraintUnique || k.Type == ConstraintForeignKey
+}
+
+// TypeName returns the readable name of the constraint type, ie. primary key.
+func (k Constraint) TypeName() string {
+	switch k.Type {
+	case ConstraintPrimaryKey:
+		return "primary key"
+	case ConstraintUnique:
+		return "unique"
+	case ConstraintForeignKey:
+		return "foreign key"
+	case ConstraintCheck:
+		return "check"
+	case ConstraintExclusion:
+		return "exclusion"
+	case ConstraintTrigger:
+		return "trigger"
+	}
+	return k.Type
+}
+
+type Introspection struct {
+	Tables []Table
+	Enums  []Enum
+}
+
+// Enums of the same name may be declared in different schemas, ie. public.status and
+// billing.status, so both must match.
+func (s Introspection) FindEnum(schema, name string) (Enum, bool) {
+	for _, e := range s.Enums {
+		if e.Schema == schema && e.Name == name {
+			return e, true
+		}
+	}
+	return Enum{}, false
+}
+
+// Introspect loads tables, views, and enums from the given schemas. It is the
+// common input to all generators that run inside the CLI instead of pg-meta.
+func Introspect(ctx context.Context, conn *pgx.Conn, schemas []string) (Introspection, error) {
+	var result Introspection
+	rows, err := conn.Query(ctx, ColumnsQuery, schemas)
+	if err != nil {
+		return result, errors.Errorf("failed to query columns: %w", err)
+	}
+	columns, err := pgxv5.CollectRows[Column](rows)
+	if err != nil {
+		return result, err
+	}
+	for _, c := range columns {
+		if n := len(result.Tables); n > 0 && result.Tables[n-1].Schema == c.Schema && result.Tables[n-1].Name == c.Table {
+			result.Tables[n-1].Columns = append(result.Tables[n-1].Columns, c)
+			continue
+		}
+		result.Tables = append(result.Tables, Table{
+			Schema:  c.Schema,
+			Name:    c.Table,
+			Kind:    c.Kind,
+			Columns: []Column{c},
+		})
+	}
+	rows, err = conn.Query(ctx, EnumsQuery, schemas)
+	if err != nil {
+		return result, errors.Errorf("failed to query enums: %w", err)
+	}
+	if result.Enums, err = pgxv5.CollectRows[Enum](rows); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// IntrospectConstraints loads all table constraints from the given schemas.
+// Generators that only need column types can skip this query.
+func IntrospectConstraints(ctx context.Context, conn *pgx.Conn, schemas []string) ([]Constraint, error) {
+	rows, err := conn.Query(ctx, ConstraintsQuery, schemas)
+	if err != nil {
+		return nil, errors.Errorf("failed to query constraints: %w", err)
+	}
+	return pgxv5.CollectRows[Constraint](rows)
+}
@@ -0,0 +1,26 @@
+SELECT
+  n.nspname AS schema,
+  c.relname AS table,
+  a.attname AS name,
+  tn.nspname AS type_schema,
+  t.typname AS type,
+  format_type(a.atttypid, a.atttypmod) AS format,
+  NOT a.attnotnull AS nullable,
+  d.adbin IS NOT NULL AND a.attgenerated = '' AS has_default,
+  coalesce(pg_get_expr(d.adbin, d.adrelid), '') AS default,
+  CASE WHEN t.typname IN ('varchar', 'bpchar') AND a.atttypmod > 4 THEN a.atttypmod - 4 ELSE 0 END AS max_length,
+  a.attidentity <> '' AS identity,
+  a.attgenerated <> '' AS generated,
+  CASE c.relkind WHEN 'v' THEN 'view' WHEN 'm' THEN 'materialized view' WHEN 'f' THEN 'foreign table' ELSE 'table' END AS kind,
+  coalesce(col_description(c.oid, a.attnum), '') AS comment
+FROM pg_attribute a
+JOIN pg_class c ON c.oid = a.attrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_type dt ON dt.oid = a.atttypid
+-- Domains are resolved to their base type
+JOIN pg_type t ON t.oid = CASE WHEN dt.typtype = 'd' THEN dt.typbasetype ELSE dt.oid END
+JOIN pg_namespace tn ON tn.oid = t.typnamespace
+LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f') AND n.nspname = ANY($1)
+  AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY n.nspname, c.relname, a.attnum
@@ -0,0 +1,25 @@
+SELECT
+  n.nspname AS schema,
+  c.relname AS table,
+  k.conname AS name,
+  k.contype::text AS type,
+  array(
+    SELECT a.attname FROM unnest(k.conkey) WITH ORDINALITY u(attnum, ord)
+    JOIN pg_attribute a ON a.attrelid = k.conrelid AND a.attnum = u.attnum
+    ORDER BY u.ord
+  )::text[] AS columns,
+  coalesce(fn.nspname, '') AS foreign_schema,
+  coalesce(fc.relname, '') AS foreign_table,
+  array(
+    SELECT a.attname FROM unnest(k.confkey) WITH ORDINALITY u(attnum, ord)
+    JOIN pg_attribute a ON a.attrelid = k.confrelid AND a.attnum = u.attnum
+    ORDER BY u.ord
+  )::text[] AS foreign_columns,
+  pg_get_constraintdef(k.oid) AS definition
+FROM pg_constraint k
+JOIN pg_class c ON c.oid = k.conrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN pg_class fc ON fc.oid = k.confrelid
+LEFT JOIN pg_namespace fn ON fn.oid = fc.relnamespace
+WHERE n.nspname = ANY($1)
+ORDER BY n.nspname, c.relname, k.conname
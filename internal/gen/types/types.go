@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -20,6 +21,8 @@ const (
 	LangTypescript = "typescript"
 	LangGo         = "go"
 	LangSwift      = "swift"
+	LangKotlin     = "kotlin"
+	LangPython     = "python"
 )
 
 const (
@@ -28,11 +31,24 @@ const (
 )
 
 func Run(ctx context.Context, projectId string, dbConfig pgconn.Config, lang string, schemas []string, postgrestV9Compat bool, swiftAccessControl string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
-	originalURL := utils.ToPostgresURL(dbConfig)
-	// Add default schemas if --schema flag is not specified
+	return generate(ctx, os.Stdout, projectId, dbConfig, lang, getSchemas(schemas), postgrestV9Compat, swiftAccessControl, options...)
+}
+
+// Generate writes types for the database at dbConfig to w, using default options for the language.
+func Generate(ctx context.Context, w io.Writer, dbConfig pgconn.Config, lang string, schemas []string, options ...func(*pgx.ConnConfig)) error {
+	return generate(ctx, w, "", dbConfig, lang, getSchemas(schemas), false, SwiftInternalAccessControl, options...)
+}
+
+// Adds default schemas if --schema flag is not specified
+func getSchemas(schemas []string) []string {
 	if len(schemas) == 0 {
-		schemas = utils.RemoveDuplicates(append([]string{"public"}, utils.Config.Api.Schemas...))
+		return utils.RemoveDuplicates(append([]string{"public"}, utils.Config.Api.Schemas...))
 	}
+	return schemas
+}
+
+func generate(ctx context.Context, w io.Writer, projectId string, dbConfig pgconn.Config, lang string, schemas []string, postgrestV9Compat bool, swiftAccessControl string, options ...func(*pgx.ConnConfig)) error {
+	originalURL := utils.ToPostgresURL(dbConfig)
 	included := strings.Join(schemas, ",")
 
 	if projectId != "" {
@@ -50,10 +66,14 @@ func Run(ctx context.Context, projectId string, dbConfig pgconn.Config, lang str
 			return errors.New("failed to retrieve generated types: " + string(resp.Body))
 		}
 
-		fmt.Print(resp.JSON200.Types)
+		fmt.Fprint(w, resp.JSON200.Types)
 		return nil
 	}
 
+	if generate, ok := GetGenerator(lang); ok {
+		return runGenerator(ctx, w, dbConfig, schemas, generate, options...)
+	}
+
 	hostConfig := container.HostConfig{}
 	if utils.IsLocalDatabase(dbConfig) {
 		if err := utils.AssertSupabaseDbIsRunning(); err != nil {
@@ -100,11 +120,24 @@ func Run(ctx context.Context, projectId string, dbConfig pgconn.Config, lang str
 		hostConfig,
 		network.NetworkingConfig{},
 		"",
-		os.Stdout,
+		w,
 		os.Stderr,
 	)
 }
 
+func runGenerator(ctx context.Context, w io.Writer, dbConfig pgconn.Config, schemas []string, generate Generator, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, dbConfig, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	spec, err := Introspect(ctx, conn, schemas)
+	if err != nil {
+		return err
+	}
+	return generate(w, spec)
+}
+
 func isRequireSSL(ctx context.Context, dbUrl string, options ...func(*pgx.ConnConfig)) (bool, error) {
 	conn, err := utils.ConnectByUrl(ctx, dbUrl+"&sslmode=require", options...)
 	if err != nil {
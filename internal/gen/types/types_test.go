@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/h2non/gock"
 	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -112,6 +114,137 @@ func TestGenLocalCommand(t *testing.T) {
 	})
 }
 
+func TestGenNativeCommand(t *testing.T) {
+	dbConfig := pgconn.Config{
+		Host:     "db.supabase.co",
+		Port:     5432,
+		User:     "admin",
+		Password: "password",
+		Database: "postgres",
+	}
+
+	t.Run("generates kotlin types", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(ColumnsQuery, []string{"public"}).
+			Reply("SELECT 1", Column{
+				Schema:   "public",
+				Table:    "todos",
+				Name:     "status",
+				Type:     "todo_status",
+				Nullable: true,
+			}).
+			Query(EnumsQuery, []string{"public"}).
+			Reply("SELECT 1", Enum{
+				Schema: "public",
+				Name:   "todo_status",
+				Values: []string{"done", "in_progress"},
+			})
+		// Run test
+		assert.NoError(t, Run(context.Background(), "", dbConfig, LangKotlin, []string{"public"}, true, "", afero.NewMemMapFs(), conn.Intercept))
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(ColumnsQuery, []string{"public"}).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for table columns")
+		// Run test
+		err := Run(context.Background(), "", dbConfig, LangPython, []string{"public"}, true, "", afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "permission denied for table columns")
+	})
+}
+
+func TestGenerators(t *testing.T) {
+	spec := Introspection{
+		Tables: []Table{{
+			Schema: "auth",
+			Name:   "users",
+			Columns: []Column{
+				{Name: "id", Type: "uuid"},
+				{Name: "tags", Type: "_text", Nullable: true},
+			},
+		}},
+	}
+
+	t.Run("renders kotlin data class", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, GenerateKotlin(&buf, spec))
+		assert.Contains(t, buf.String(), "data class AuthUsers(")
+		assert.Contains(t, buf.String(), `@SerialName("tags") val tags: List<String>? = null,`)
+	})
+
+	t.Run("escapes kotlin identifiers", func(t *testing.T) {
+		spec := Introspection{
+			Tables: []Table{{
+				Schema: "public",
+				Name:   "events",
+				Columns: []Column{
+					{Name: "class", Type: "text"},
+					{Name: "2fa_enabled", Type: "bool"},
+					{Name: "status", TypeSchema: "billing", Type: "status"},
+				},
+			}},
+			Enums: []Enum{{
+				Schema: "public",
+				Name:   "status",
+				Values: []string{"active"},
+			}, {
+				Schema: "billing",
+				Name:   "status",
+				Values: []string{"1st", "in"},
+			}},
+		}
+		var buf bytes.Buffer
+		assert.NoError(t, GenerateKotlin(&buf, spec))
+		assert.Contains(t, buf.String(), "    @SerialName(\"1st\") `1ST`,\n")
+		assert.Contains(t, buf.String(), "    @SerialName(\"in\") IN\n")
+		assert.Contains(t, buf.String(), "    @SerialName(\"class\") val `class`: String,\n")
+		assert.Contains(t, buf.String(), "    @SerialName(\"2fa_enabled\") val `2faEnabled`: Boolean,\n")
+		assert.Contains(t, buf.String(), "    @SerialName(\"status\") val status: BillingStatus,\n")
+	})
+
+	t.Run("renders python dataclass", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, GeneratePython(&buf, spec))
+		assert.Contains(t, buf.String(), "class AuthUsers:")
+		assert.Contains(t, buf.String(), "    tags: Optional[List[str]]\n")
+	})
+
+	t.Run("sanitizes python identifiers", func(t *testing.T) {
+		spec := Introspection{
+			Tables: []Table{{
+				Schema: "public",
+				Name:   "events",
+				Columns: []Column{
+					{Name: "from", Type: "text"},
+					{Name: "start date", Type: "date"},
+					{Name: "user-id", Type: "uuid"},
+					{Name: "_id", Type: "int8"},
+				},
+			}},
+			Enums: []Enum{{
+				Schema: "public",
+				Name:   "priority",
+				Values: []string{"1st", "second-best", "None"},
+			}},
+		}
+		var buf bytes.Buffer
+		assert.NoError(t, GeneratePython(&buf, spec))
+		assert.Contains(t, buf.String(), "from dataclasses import dataclass, field\n")
+		assert.Contains(t, buf.String(), "    _1ST = \"1st\"\n")
+		assert.Contains(t, buf.String(), "    SECOND_BEST = \"second-best\"\n")
+		assert.Contains(t, buf.String(), "    NONE = \"None\"\n")
+		assert.Contains(t, buf.String(), "    from_: str = field(metadata={\"alias\": \"from\"})\n")
+		assert.Contains(t, buf.String(), "    start_date: str = field(metadata={\"alias\": \"start date\"})\n")
+		assert.Contains(t, buf.String(), "    user_id: str = field(metadata={\"alias\": \"user-id\"})\n")
+		assert.Contains(t, buf.String(), "    _id: int\n")
+	})
+}
+
 func TestGenLinkedCommand(t *testing.T) {
 	// Setup valid projectId id
 	projectId := apitest.RandomProjectRef()
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/up"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Wait for migration files and schema changes to settle before regenerating
+const debounceInterval = 500 * time.Millisecond
+
+// How often the local database is polled for schema changes
+var pollInterval = 2 * time.Second
+
+// Hashes the definitions that affect generated types, ie. tables, columns, functions, and enums.
+const fingerprintQuery = `
+SELECT md5(coalesce(string_agg(def, ',' ORDER BY def), ''))
+FROM (
+  SELECT format('%s.%s:%s', n.nspname, c.relname, c.relkind) AS def
+  FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+  WHERE n.nspname = ANY($1)
+  UNION ALL
+  SELECT format('%s.%s.%s:%s:%s:%s', n.nspname, c.relname, a.attname, format_type(a.atttypid, a.atttypmod), a.attnotnull, a.atthasdef)
+  FROM pg_attribute a JOIN pg_class c ON c.oid = a.attrelid JOIN pg_namespace n ON n.oid = c.relnamespace
+  WHERE n.nspname = ANY($1) AND a.attnum > 0 AND NOT a.attisdropped
+  UNION ALL
+  SELECT format('%s.%s(%s):%s', n.nspname, p.proname, pg_get_function_arguments(p.oid), pg_get_function_result(p.oid))
+  FROM pg_proc p JOIN pg_namespace n ON n.oid = p.pronamespace
+  WHERE n.nspname = ANY($1)
+  UNION ALL
+  SELECT format('%s.%s:%s', n.nspname, t.typname, e.enumlabel)
+  FROM pg_enum e JOIN pg_type t ON t.oid = e.enumtypid JOIN pg_namespace n ON n.oid = t.typnamespace
+  WHERE n.nspname = ANY($1)
+) defs
+`
+
+func RunWatch(ctx context.Context, outputPath string, dbConfig pgconn.Config, lang string, schemas []string, postgrestV9Compat bool, swiftAccessControl string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if !utils.IsLocalDatabase(dbConfig) {
+		return errors.New("--watch can only be used together with --local")
+	}
+	schemas = getSchemas(schemas)
+	regenerate := func(ctx context.Context) error {
+		var buf bytes.Buffer
+		if err := generate(ctx, &buf, "", dbConfig, lang, schemas, postgrestV9Compat, swiftAccessControl, options...); err != nil {
+			return err
+		}
+		if changed, err := writeIfChanged(outputPath, buf.Bytes(), fsys); err != nil {
+			return err
+		} else if changed {
+			fmt.Fprintln(os.Stderr, "Updated types:", utils.Bold(outputPath))
+		}
+		return nil
+	}
+	fingerprint := func(ctx context.Context) (string, error) {
+		conn, err := utils.ConnectByConfig(ctx, dbConfig, options...)
+		if err != nil {
+			return "", err
+		}
+		defer conn.Close(context.Background())
+		var hash string
+		if err := conn.QueryRow(ctx, fingerprintQuery, schemas).Scan(&hash); err != nil {
+			return "", errors.Errorf("failed to fingerprint schema: %w", err)
+		}
+		return hash, nil
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Errorf("failed to create watcher: %w", err)
+	}
+	defer watcher.Close()
+	if err := utils.MkdirIfNotExistFS(fsys, utils.MigrationsDir); err != nil {
+		return err
+	}
+	if err := watcher.Add(utils.MigrationsDir); err != nil {
+		return errors.Errorf("failed to watch migrations: %w", err)
+	}
+	migrate := func(ctx context.Context) error {
+		return up.Run(ctx, false, "", dbConfig, fsys, options...)
+	}
+	fmt.Fprintln(os.Stderr, "Watching for schema changes. Press Ctrl+C to stop.")
+	return watchSchema(ctx, watcher, fingerprint, migrate, regenerate)
+}
+
+// Regenerates on migration file changes or when the database fingerprint differs from the last poll.
+// Pending migrations are applied first so that new migration files are reflected in the types.
+func watchSchema(ctx context.Context, watcher *fsnotify.Watcher, fingerprint func(context.Context) (string, error), migrate, regenerate func(context.Context) error) error {
+	if err := migrate(ctx); err != nil {
+		return err
+	}
+	if err := regenerate(ctx); err != nil {
+		return err
+	}
+	last, err := fingerprint(ctx)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	timer := time.NewTimer(debounceInterval)
+	timer.Stop()
+	migrationsChanged := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			// Ignore permission changes
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			fmt.Fprintln(utils.GetDebugLogger(), "Migration changed:", event.Name)
+			migrationsChanged = true
+			timer.Reset(debounceInterval)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+		case <-ticker.C:
+			hash, err := fingerprint(ctx)
+			if err != nil {
+				fmt.Fprintln(utils.GetDebugLogger(), err)
+				continue
+			}
+			if hash != last {
+				last = hash
+				timer.Reset(debounceInterval)
+			}
+		case <-timer.C:
+			// Keep watching on failures, eg. while the database is being reset
+			if migrationsChanged {
+				if err := migrate(ctx); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					continue
+				}
+				migrationsChanged = false
+			}
+			if err := regenerate(ctx); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+	}
+}
+
+// Writes data to path only if the existing content differs, to avoid triggering downstream watchers.
+func writeIfChanged(path string, data []byte, fsys afero.Fs) (bool, error) {
+	if existing, err := afero.ReadFile(fsys, path); err == nil && bytes.Equal(existing, data) {
+		return false, nil
+	}
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+		return false, err
+	}
+	if err := afero.WriteFile(fsys, path, data, 0644); err != nil {
+		return false, errors.Errorf("failed to write types: %w", err)
+	}
+	return true, nil
+}
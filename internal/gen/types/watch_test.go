@@ -0,0 +1,128 @@
+package types
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteIfChanged(t *testing.T) {
+	t.Run("writes new file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		changed, err := writeIfChanged("src/types.ts", []byte("export {}"), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		data, err := afero.ReadFile(fsys, "src/types.ts")
+		assert.NoError(t, err)
+		assert.Equal(t, "export {}", string(data))
+	})
+
+	t.Run("skips identical content", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "types.ts", []byte("export {}"), 0644))
+		// Run test
+		changed, err := writeIfChanged("types.ts", []byte("export {}"), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("throws error on permission denied", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
+		// Run test
+		_, err := writeIfChanged("types.ts", []byte("export {}"), fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrPermission)
+	})
+}
+
+func TestWatchSchema(t *testing.T) {
+	pollInterval = 10 * time.Millisecond
+
+	t.Run("regenerates on migration and schema changes", func(t *testing.T) {
+		dir := t.TempDir()
+		watcher, err := fsnotify.NewWatcher()
+		require.NoError(t, err)
+		defer watcher.Close()
+		require.NoError(t, watcher.Add(dir))
+		var hash, runs atomic.Int32
+		fingerprint := func(context.Context) (string, error) {
+			return string(rune('a' + hash.Load())), nil
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var migrations atomic.Int32
+		migrate := func(context.Context) error {
+			migrations.Add(1)
+			return nil
+		}
+		regenerate := func(context.Context) error {
+			// Migrations must be applied before each regeneration triggered by files
+			if runs.Load() < 2 && migrations.Load() != runs.Load()+1 {
+				return os.ErrInvalid
+			}
+			runs.Add(1)
+			return nil
+		}
+		// Run test
+		errCh := make(chan error, 1)
+		go func() { errCh <- watchSchema(ctx, watcher, fingerprint, migrate, regenerate) }()
+		require.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, 10*time.Millisecond)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "0_init.sql"), []byte("create table test()"), 0644))
+		require.Eventually(t, func() bool { return runs.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+		hash.Add(1)
+		require.Eventually(t, func() bool { return runs.Load() == 3 }, 5*time.Second, 10*time.Millisecond)
+		cancel()
+		// Schema changes alone do not apply migrations
+		assert.Equal(t, int32(2), migrations.Load())
+		// Check error
+		assert.NoError(t, <-errCh)
+	})
+
+	t.Run("throws error on initial generation failure", func(t *testing.T) {
+		watcher, err := fsnotify.NewWatcher()
+		require.NoError(t, err)
+		defer watcher.Close()
+		// Run test
+		err = watchSchema(context.Background(), watcher, nil, func(context.Context) error {
+			return nil
+		}, func(context.Context) error {
+			return os.ErrNotExist
+		})
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("throws error on initial migration failure", func(t *testing.T) {
+		watcher, err := fsnotify.NewWatcher()
+		require.NoError(t, err)
+		defer watcher.Close()
+		// Run test
+		err = watchSchema(context.Background(), watcher, nil, func(context.Context) error {
+			return os.ErrPermission
+		}, nil)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrPermission)
+	})
+}
+
+func TestRunWatch(t *testing.T) {
+	t.Run("throws error on remote database", func(t *testing.T) {
+		err := RunWatch(context.Background(), "types.ts", pgconn.Config{Host: "db.supabase.co"}, LangTypescript, nil, false, "", afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "--watch can only be used together with --local")
+	})
+}
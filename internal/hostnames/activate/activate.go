@@ -3,6 +3,7 @@ package activate
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
@@ -10,7 +11,7 @@ import (
 	"github.com/supabase/cli/internal/utils"
 )
 
-func Run(ctx context.Context, projectRef string, includeRawOutput bool, fsys afero.Fs) error {
+func Run(ctx context.Context, projectRef string, includeRawOutput, wait bool, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	{
 		resp, err := hostnames.GetCustomHostnameConfig(ctx, projectRef)
@@ -21,6 +22,13 @@ func Run(ctx context.Context, projectRef string, includeRawOutput bool, fsys afe
 		if err != nil {
 			return err
 		}
+		// Activation requires the certificate to be issued
+		if wait {
+			fmt.Fprintln(os.Stderr, "Waiting for certificate issuance...")
+			if _, err := hostnames.WaitForCertificate(ctx, projectRef); err != nil {
+				return err
+			}
+		}
 	}
 
 	// 2. activate custom hostname config
@@ -32,11 +40,6 @@ func Run(ctx context.Context, projectRef string, includeRawOutput bool, fsys afe
 		if resp.JSON201 == nil {
 			return errors.New("failed to activate custom hostname config: " + string(resp.Body))
 		}
-		status, err := hostnames.TranslateStatus(resp.JSON201, includeRawOutput)
-		if err != nil {
-			return err
-		}
-		fmt.Println(status)
-		return nil
+		return hostnames.PrintStatus(projectRef, resp.JSON201, includeRawOutput)
 	}
 }
@@ -80,14 +80,9 @@ func TranslateStatus(response *api.UpdateCustomHostnameResponse, includeRawOutpu
 		return appendRawOutputIfNeeded(fmt.Sprintf("Custom hostname setup completed. Project is now accessible at %s.", response.CustomHostname), response, includeRawOutput), nil
 	}
 	if response.Status == api.N4OriginSetupCompleted {
-		var res RawResponse
-		rawBody, err := json.Marshal(response.Data)
+		res, err := parseRawResponse(response)
 		if err != nil {
-			return "", errors.Errorf("failed to serialize body: %w", err)
-		}
-		err = json.Unmarshal(rawBody, &res)
-		if err != nil {
-			return "", errors.Errorf("failed to deserialize body: %w", err)
+			return "", err
 		}
 		return appendRawOutputIfNeeded(fmt.Sprintf(`Custom hostname configuration complete, and ready for activation.
 
@@ -95,14 +90,9 @@ Please ensure that your custom domain is set up as a CNAME record to your Supaba
 	%s CNAME -> %s`, response.CustomHostname, res.Result.CustomOriginServer), response, includeRawOutput), nil
 	}
 	if response.Status == api.N2Initiated {
-		var res RawResponse
-		rawBody, err := json.Marshal(response.Data)
-		if err != nil {
-			return "", errors.Errorf("failed to serialize body: %w", err)
-		}
-		err = json.Unmarshal(rawBody, &res)
+		res, err := parseRawResponse(response)
 		if err != nil {
-			return "", errors.Errorf("failed to deserialize body: %w", err)
+			return "", err
 		}
 		ssl := res.Result.Ssl.ValidationRecords
 		if res.Result.Ssl.Status == "initializing" {
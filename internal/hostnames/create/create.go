@@ -3,6 +3,7 @@ package create
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/go-errors/errors"
@@ -12,7 +13,7 @@ import (
 	"github.com/supabase/cli/pkg/api"
 )
 
-func Run(ctx context.Context, projectRef string, customHostname string, includeRawOutput bool, fsys afero.Fs) error {
+func Run(ctx context.Context, projectRef string, customHostname string, includeRawOutput, wait bool, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	hostname := strings.TrimSpace(customHostname)
 	{
@@ -37,11 +38,20 @@ func Run(ctx context.Context, projectRef string, customHostname string, includeR
 		if resp.JSON201 == nil {
 			return errors.New("failed to create custom hostname config: " + string(resp.Body))
 		}
-		status, err := hostnames.TranslateStatus(resp.JSON201, includeRawOutput)
+		if !wait {
+			return hostnames.PrintStatus(projectRef, resp.JSON201, includeRawOutput)
+		}
+		// Show required DNS records while waiting, but only print the final status as json
+		if utils.OutputFormat.Value == utils.OutputPretty {
+			if err := hostnames.PrintStatus(projectRef, resp.JSON201, includeRawOutput); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintln(os.Stderr, "Waiting for certificate issuance...")
+		status, err := hostnames.WaitForCertificate(ctx, projectRef)
 		if err != nil {
 			return err
 		}
-		fmt.Println(status)
-		return nil
+		return hostnames.PrintStatus(projectRef, status, includeRawOutput)
 	}
 }
@@ -2,8 +2,6 @@ package get
 
 import (
 	"context"
-	"fmt"
-
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/hostnames"
 )
@@ -16,11 +14,6 @@ func Run(ctx context.Context, projectRef string, includeRawOutput bool, fsys afe
 		if err != nil {
 			return err
 		}
-		status, err := hostnames.TranslateStatus(resp.JSON200, includeRawOutput)
-		if err != nil {
-			return err
-		}
-		fmt.Println(status)
-		return nil
+		return hostnames.PrintStatus(projectRef, resp.JSON200, includeRawOutput)
 	}
 }
@@ -0,0 +1,140 @@
+package hostnames
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+var (
+	// Overridden in tests to avoid waiting between polls
+	pollInterval = 10 * time.Second
+	waitTimeout  = 30 * time.Minute
+)
+
+type DnsRecord struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Value  string `json:"value"`
+	Status string `json:"status,omitempty"`
+}
+
+type HostnameStatus struct {
+	CustomHostname string                                 `json:"custom_hostname"`
+	Status         api.UpdateCustomHostnameResponseStatus `json:"status"`
+	Records        []DnsRecord                            `json:"records"`
+}
+
+func parseRawResponse(response *api.UpdateCustomHostnameResponse) (RawResponse, error) {
+	var res RawResponse
+	rawBody, err := json.Marshal(response.Data)
+	if err != nil {
+		return res, errors.Errorf("failed to serialize body: %w", err)
+	}
+	if err := json.Unmarshal(rawBody, &res); err != nil {
+		return res, errors.Errorf("failed to deserialize body: %w", err)
+	}
+	return res, nil
+}
+
+// Lists the DNS records required to verify ownership and issue certificates for a custom hostname.
+func ListDnsRecords(projectRef string, response *api.UpdateCustomHostnameResponse) ([]DnsRecord, error) {
+	if len(response.CustomHostname) == 0 {
+		return nil, nil
+	}
+	res, err := parseRawResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	origin := res.Result.CustomOriginServer
+	if len(origin) == 0 {
+		origin = utils.GetSupabaseHost(projectRef)
+	}
+	records := []DnsRecord{{
+		Name:  response.CustomHostname,
+		Type:  "CNAME",
+		Value: origin,
+	}}
+	if v := res.Result.OwnershipVerification; len(v.Name) > 0 {
+		records = append(records, DnsRecord{
+			Name:  v.Name,
+			Type:  strings.ToUpper(v.Type),
+			Value: v.Value,
+		})
+	}
+	for _, r := range res.Result.Ssl.ValidationRecords {
+		if len(r.TxtName) > 0 {
+			records = append(records, DnsRecord{
+				Name:   r.TxtName,
+				Type:   "TXT",
+				Value:  r.TxtValue,
+				Status: r.Status,
+			})
+		}
+	}
+	return records, nil
+}
+
+// Prints the hostname status with its DNS records in the selected output format.
+func PrintStatus(projectRef string, response *api.UpdateCustomHostnameResponse, includeRawOutput bool) error {
+	records, err := ListDnsRecords(projectRef, response)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, HostnameStatus{
+			CustomHostname: response.CustomHostname,
+			Status:         response.Status,
+			Records:        records,
+		})
+	}
+	status, err := TranslateStatus(response, includeRawOutput)
+	if err != nil {
+		return err
+	}
+	fmt.Println(status)
+	if len(records) == 0 || response.Status == api.N5ServicesReconfigured {
+		return nil
+	}
+	table := `|NAME|TYPE|VALUE|STATUS|
+|-|-|-|-|
+`
+	for _, r := range records {
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%s`|\n", r.Name, r.Type, r.Value, r.Status)
+	}
+	return list.RenderTable(table)
+}
+
+// Polls DNS verification until the certificate for custom hostname is issued.
+func WaitForCertificate(ctx context.Context, projectRef string) (*api.UpdateCustomHostnameResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, waitTimeout)
+	defer cancel()
+	policy := backoff.WithContext(backoff.NewConstantBackOff(pollInterval), ctx)
+	return backoff.RetryNotifyWithData(func() (*api.UpdateCustomHostnameResponse, error) {
+		resp, err := utils.GetSupabase().V1VerifyDnsConfigWithResponse(ctx, projectRef)
+		if err != nil {
+			return nil, errors.Errorf("failed to re-verify custom hostname: %w", err)
+		}
+		if resp.JSON201 == nil {
+			return nil, errors.New("failed to re-verify custom hostname config: " + string(resp.Body))
+		}
+		switch resp.JSON201.Status {
+		case api.N4OriginSetupCompleted, api.N5ServicesReconfigured:
+			return resp.JSON201, nil
+		case api.N1NotStarted:
+			return nil, backoff.Permanent(errors.New("Custom hostname configuration not started."))
+		}
+		return nil, errors.Errorf("Waiting for certificate issuance: %s", resp.JSON201.Status)
+	}, policy, func(err error, d time.Duration) {
+		fmt.Fprintln(os.Stderr, err)
+	})
+}
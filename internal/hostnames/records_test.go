@@ -0,0 +1,91 @@
+package hostnames
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestListDnsRecords(t *testing.T) {
+	t.Run("lists pending validation records", func(t *testing.T) {
+		response := api.UpdateCustomHostnameResponse{
+			CustomHostname: "api.example.com",
+			Status:         api.N2Initiated,
+			Data: api.CfResponse{Result: api.CustomHostnameDetails{
+				CustomOriginServer: "abcdefghijklmnopqrst.supabase.co",
+				OwnershipVerification: api.OwnershipVerification{
+					Name:  "_cf-custom-hostname.api.example.com",
+					Type:  "txt",
+					Value: "owner-token",
+				},
+				Ssl: api.SslValidation{
+					Status: "pending_validation",
+					ValidationRecords: []api.ValidationRecord{{
+						TxtName:  "_acme-challenge.api.example.com",
+						TxtValue: "acme-token",
+					}},
+				},
+			}},
+		}
+		// Run test
+		records, err := ListDnsRecords("abcdefghijklmnopqrst", &response)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []DnsRecord{
+			{Name: "api.example.com", Type: "CNAME", Value: "abcdefghijklmnopqrst.supabase.co"},
+			{Name: "_cf-custom-hostname.api.example.com", Type: "TXT", Value: "owner-token"},
+			{Name: "_acme-challenge.api.example.com", Type: "TXT", Value: "acme-token"},
+		}, records)
+	})
+
+	t.Run("returns nothing when not configured", func(t *testing.T) {
+		records, err := ListDnsRecords("abcdefghijklmnopqrst", &api.UpdateCustomHostnameResponse{})
+		assert.NoError(t, err)
+		assert.Empty(t, records)
+	})
+}
+
+func TestWaitForCertificate(t *testing.T) {
+	pollInterval = 0
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("polls until certificate issued", func(t *testing.T) {
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/custom-hostname/reverify").
+			Reply(http.StatusCreated).
+			JSON(api.UpdateCustomHostnameResponse{CustomHostname: "api.example.com", Status: api.N2Initiated})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/custom-hostname/reverify").
+			Reply(http.StatusCreated).
+			JSON(api.UpdateCustomHostnameResponse{CustomHostname: "api.example.com", Status: api.N4OriginSetupCompleted})
+		// Run test
+		resp, err := WaitForCertificate(context.Background(), project)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, api.N4OriginSetupCompleted, resp.Status)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error if not started", func(t *testing.T) {
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/custom-hostname/reverify").
+			Reply(http.StatusCreated).
+			JSON(api.UpdateCustomHostnameResponse{Status: api.N1NotStarted})
+		// Run test
+		_, err := WaitForCertificate(context.Background(), project)
+		// Check error
+		assert.ErrorContains(t, err, "Custom hostname configuration not started.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
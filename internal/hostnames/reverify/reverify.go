@@ -3,6 +3,7 @@ package reverify
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
@@ -10,9 +11,17 @@ import (
 	"github.com/supabase/cli/internal/utils"
 )
 
-func Run(ctx context.Context, projectRef string, includeRawOutput bool, fsys afero.Fs) error {
+func Run(ctx context.Context, projectRef string, includeRawOutput, wait bool, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	// 2. attempt to re-verify custom hostname config
+	if wait {
+		fmt.Fprintln(os.Stderr, "Waiting for certificate issuance...")
+		status, err := hostnames.WaitForCertificate(ctx, projectRef)
+		if err != nil {
+			return err
+		}
+		return hostnames.PrintStatus(projectRef, status, includeRawOutput)
+	}
 	{
 		resp, err := utils.GetSupabase().V1VerifyDnsConfigWithResponse(ctx, projectRef)
 		if err != nil {
@@ -21,11 +30,6 @@ func Run(ctx context.Context, projectRef string, includeRawOutput bool, fsys afe
 		if resp.JSON201 == nil {
 			return errors.New("failed to re-verify custom hostname config: " + string(resp.Body))
 		}
-		status, err := hostnames.TranslateStatus(resp.JSON201, includeRawOutput)
-		if err != nil {
-			return err
-		}
-		fmt.Println(status)
-		return nil
+		return hostnames.PrintStatus(projectRef, resp.JSON201, includeRawOutput)
 	}
 }
@@ -32,6 +32,16 @@ var (
 )
 
 func Run(ctx context.Context, fsys afero.Fs, createVscodeSettings, createIntellijSettings *bool, params utils.InitParams) error {
+	// Resolve template before writing any files
+	var template *Template
+	if len(params.Template) > 0 {
+		t, err := FindTemplate(ctx, params.Template, params.RemoteTemplate)
+		if err != nil {
+			return err
+		}
+		template = &t
+	}
+
 	// 1. Write `config.toml`.
 	if err := utils.InitConfig(params, fsys); err != nil {
 		if errors.Is(err, os.ErrExist) {
@@ -40,14 +50,21 @@ func Run(ctx context.Context, fsys afero.Fs, createVscodeSettings, createIntelli
 		return err
 	}
 
-	// 2. Append to `.gitignore`.
+	// 2. Lay down starter template.
+	if template != nil {
+		if err := ApplyTemplate(*template, params.Overwrite, fsys); err != nil {
+			return err
+		}
+	}
+
+	// 3. Append to `.gitignore`.
 	if utils.IsGitRepo() {
 		if err := updateGitIgnore(utils.GitIgnorePath, fsys); err != nil {
 			return err
 		}
 	}
 
-	// 3. Generate VS Code settings.
+	// 4. Generate VS Code settings.
 	if createVscodeSettings != nil {
 		if *createVscodeSettings {
 			return writeVscodeConfig(fsys)
@@ -156,7 +173,7 @@ func writeVscodeConfig(fsys afero.Fs) error {
 	if err := updateJsonFile(settingsPath, vscodeSettings, fsys); err != nil {
 		return err
 	}
-	fmt.Println("Generated VS Code settings in " + utils.Bold(settingsPath) + ". Please install the recommended extension!")
+	fmt.Fprintln(utils.GetHumanWriter(), "Generated VS Code settings in "+utils.Bold(settingsPath)+". Please install the recommended extension!")
 	return nil
 }
 
@@ -164,6 +181,6 @@ func writeIntelliJConfig(fsys afero.Fs) error {
 	if err := utils.WriteFile(denoPath, []byte(intelliJDeno), fsys); err != nil {
 		return err
 	}
-	fmt.Println("Generated IntelliJ settings in " + utils.Bold(denoPath) + ". Please install the Deno plugin!")
+	fmt.Fprintln(utils.GetHumanWriter(), "Generated IntelliJ settings in "+utils.Bold(denoPath)+". Please install the Deno plugin!")
 	return nil
 }
@@ -0,0 +1,230 @@
+package init
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Template is a starter scaffold laid down on top of the default config.
+type Template struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Overrides auth redirects in config.toml for the frontend framework
+	SiteUrl      string   `json:"site_url,omitempty"`
+	RedirectUrls []string `json:"redirect_urls,omitempty"`
+	Buckets      []Bucket `json:"buckets,omitempty"`
+	// Maps paths relative to the supabase directory to their contents
+	Files map[string]string `json:"files,omitempty"`
+}
+
+type Bucket struct {
+	Name             string   `json:"name"`
+	Public           bool     `json:"public"`
+	FileSizeLimit    string   `json:"file_size_limit,omitempty"`
+	AllowedMimeTypes []string `json:"allowed_mime_types,omitempty"`
+}
+
+type templatesRepo struct {
+	Templates []Template `json:"templates"`
+}
+
+var (
+	//go:embed templates/starter
+	starterFS embed.FS
+
+	avatarsBucket = Bucket{
+		Name:             "avatars",
+		Public:           true,
+		FileSizeLimit:    "5MiB",
+		AllowedMimeTypes: []string{"image/png", "image/jpeg", "image/webp"},
+	}
+
+	siteUrlPattern     = regexp.MustCompile(`(?m)^site_url = .*$`)
+	redirectUrlPattern = regexp.MustCompile(`(?m)^additional_redirect_urls = .*$`)
+	bareKeyPattern     = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// Templates bundled with the CLI, each scaffolding the common starter files overlaid
+// with those specific to its frontend framework.
+func builtinTemplates() ([]Template, error) {
+	templates := []Template{{
+		Name:        "bare",
+		Description: "Default config without examples",
+	}, {
+		Name:         "nextjs",
+		Description:  "Next.js app with profiles, avatars bucket and an example Edge Function",
+		SiteUrl:      "http://localhost:3000",
+		RedirectUrls: []string{"http://localhost:3000/auth/callback"},
+		Buckets:      []Bucket{avatarsBucket},
+	}, {
+		Name:         "expo",
+		Description:  "Expo app with profiles, avatars bucket and an example Edge Function",
+		SiteUrl:      "exp://127.0.0.1:8081",
+		RedirectUrls: []string{"exp://127.0.0.1:8081/--/auth/callback"},
+		Buckets:      []Bucket{avatarsBucket},
+	}, {
+		Name:         "flutter",
+		Description:  "Flutter app with profiles, avatars bucket and an example Edge Function",
+		SiteUrl:      "io.supabase.flutterquickstart://login-callback/",
+		RedirectUrls: []string{"io.supabase.flutterquickstart://login-callback/"},
+		Buckets:      []Bucket{avatarsBucket},
+	}}
+	// The bare template only updates config
+	for i := 1; i < len(templates); i++ {
+		t := &templates[i]
+		t.Files = map[string]string{}
+		for _, dir := range []string{"common", t.Name} {
+			if err := loadStarterFiles(dir, t.Files); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return templates, nil
+}
+
+// Files of later directories replace those with the same path.
+func loadStarterFiles(dir string, files map[string]string) error {
+	root := "templates/starter/" + dir
+	if err := fs.WalkDir(starterFS, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := starterFS.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		files[strings.TrimPrefix(name, root+"/")] = string(data)
+		return nil
+	}); err != nil {
+		return errors.Errorf("failed to load starter files: %w", err)
+	}
+	return nil
+}
+
+// ListTemplates returns the templates bundled with the CLI. If remote is set, the latest
+// templates are fetched from GitHub instead, falling back to the bundled ones on error.
+func ListTemplates(ctx context.Context, remote bool) ([]Template, error) {
+	if remote && !utils.IsOffline() {
+		templates, err := fetchTemplates(ctx)
+		if err == nil && len(templates) > 0 {
+			return templates, nil
+		}
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+		fmt.Fprintln(os.Stderr, "Using bundled templates because the templates registry is unreachable.")
+	}
+	return builtinTemplates()
+}
+
+func fetchTemplates(ctx context.Context) ([]Template, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	client := utils.GetGitHubClient(ctx)
+	file, _, _, err := client.Repositories.GetContents(ctx, "supabase-community", "supabase-samples", "templates.json", nil)
+	if err != nil {
+		return nil, errors.Errorf("failed to list templates: %w", err)
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, errors.Errorf("failed to decode templates: %w", err)
+	}
+	var data templatesRepo
+	if err := json.Unmarshal([]byte(content), &data); err != nil {
+		return nil, errors.Errorf("failed to unmarshal templates: %w", err)
+	}
+	return data.Templates, nil
+}
+
+func FindTemplate(ctx context.Context, name string, remote bool) (Template, error) {
+	templates, err := ListTemplates(ctx, remote)
+	if err != nil {
+		return Template{}, err
+	}
+	var names []string
+	for _, t := range templates {
+		if t.Name == name {
+			return t, nil
+		}
+		names = append(names, t.Name)
+	}
+	return Template{}, errors.Errorf("unknown template %q: must be one of %s", name, strings.Join(names, ", "))
+}
+
+// ApplyTemplate updates the generated config and writes example files of the template.
+func ApplyTemplate(t Template, overwrite bool, fsys afero.Fs) error {
+	if err := updateConfig(t, fsys); err != nil {
+		return err
+	}
+	for name, contents := range t.Files {
+		// Registry paths are untrusted
+		if !filepath.IsLocal(name) {
+			return errors.Errorf("invalid template file path: %s", name)
+		}
+		dst := filepath.Join(utils.SupabaseDirPath, filepath.FromSlash(name))
+		if exists, err := afero.Exists(fsys, dst); err != nil {
+			return errors.Errorf("failed to check template file: %w", err)
+		} else if exists && !overwrite {
+			fmt.Fprintln(os.Stderr, "Skipped existing file:", utils.Bold(dst))
+			continue
+		}
+		if err := utils.WriteFile(dst, []byte(contents), fsys); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Applied template:", utils.Aqua(t.Name))
+	return nil
+}
+
+func updateConfig(t Template, fsys afero.Fs) error {
+	data, err := afero.ReadFile(fsys, utils.ConfigPath)
+	if err != nil {
+		return errors.Errorf("failed to read config: %w", err)
+	}
+	if len(t.SiteUrl) > 0 {
+		data = siteUrlPattern.ReplaceAllLiteral(data, []byte("site_url = "+strconv.Quote(t.SiteUrl)))
+	}
+	if len(t.RedirectUrls) > 0 {
+		data = redirectUrlPattern.ReplaceAllLiteral(data, []byte("additional_redirect_urls = "+quoteArray(t.RedirectUrls)))
+	}
+	for _, b := range t.Buckets {
+		data = append(data, formatBucket(b)...)
+	}
+	return utils.WriteFile(utils.ConfigPath, data, fsys)
+}
+
+func formatBucket(b Bucket) string {
+	var sb strings.Builder
+	name := b.Name
+	if !bareKeyPattern.MatchString(name) {
+		name = strconv.Quote(name)
+	}
+	fmt.Fprintf(&sb, "\n[storage.buckets.%s]\n", name)
+	fmt.Fprintf(&sb, "public = %t\n", b.Public)
+	if len(b.FileSizeLimit) > 0 {
+		fmt.Fprintf(&sb, "file_size_limit = %s\n", strconv.Quote(b.FileSizeLimit))
+	}
+	if len(b.AllowedMimeTypes) > 0 {
+		fmt.Fprintf(&sb, "allowed_mime_types = %s\n", quoteArray(b.AllowedMimeTypes))
+	}
+	return sb.String()
+}
+
+func quoteArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,127 @@
+package init
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v62/github"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestListTemplates(t *testing.T) {
+	t.Run("fetches templates from registry", func(t *testing.T) {
+		body, err := json.Marshal(templatesRepo{Templates: []Template{{Name: "svelte"}}})
+		require.NoError(t, err)
+		encoded := base64.StdEncoding.EncodeToString(body)
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://api.github.com").
+			Get("/repos/supabase-community/supabase-samples/contents/templates.json").
+			Reply(http.StatusOK).
+			JSON(github.RepositoryContent{Content: &encoded, Encoding: github.String("base64")})
+		// Run test
+		templates, err := ListTemplates(context.Background(), true)
+		assert.NoError(t, err)
+		assert.Equal(t, []Template{{Name: "svelte"}}, templates)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("falls back to bundled templates", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://api.github.com").
+			Get("/repos/supabase-community/supabase-samples/contents/templates.json").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		templates, err := ListTemplates(context.Background(), true)
+		assert.NoError(t, err)
+		var names []string
+		for _, tmpl := range templates {
+			names = append(names, tmpl.Name)
+		}
+		assert.Equal(t, []string{"bare", "nextjs", "expo", "flutter"}, names)
+		assert.Contains(t, templates[1].Files, "functions/hello/index.ts")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("uses bundled templates by default", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		// Run test
+		templates, err := ListTemplates(context.Background(), false)
+		assert.NoError(t, err)
+		assert.Len(t, templates, 4)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("overlays files per template", func(t *testing.T) {
+		// Run test
+		templates, err := builtinTemplates()
+		require.NoError(t, err)
+		// Check output
+		assert.Empty(t, templates[0].Files)
+		nextjs, expo := templates[1].Files, templates[2].Files
+		assert.Contains(t, nextjs["functions/hello/index.ts"], "http://localhost:3000")
+		assert.NotContains(t, expo["functions/hello/index.ts"], "Access-Control-Allow-Origin")
+		assert.Contains(t, nextjs["README.md"], "NEXT_PUBLIC_SUPABASE_URL")
+		assert.Contains(t, expo["README.md"], "EXPO_PUBLIC_SUPABASE_URL")
+		assert.Equal(t, nextjs["seed.sql"], expo["seed.sql"])
+	})
+}
+
+func TestApplyTemplate(t *testing.T) {
+	templates, err := builtinTemplates()
+	require.NoError(t, err)
+
+	t.Run("scaffolds starter files", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Run test
+		assert.NoError(t, ApplyTemplate(templates[1], false, fsys))
+		// Validate generated files
+		for _, name := range []string{"seed.sql", "migrations/20240101000000_profiles.sql", "migrations/20240101000001_avatars.sql", "functions/hello/index.ts", "README.md"} {
+			exists, err := afero.Exists(fsys, filepath.Join(utils.SupabaseDirPath, name))
+			assert.NoError(t, err)
+			assert.True(t, exists, name)
+		}
+		// Validate updated config
+		assert.NoError(t, utils.LoadConfigFS(fsys))
+		assert.Equal(t, "http://localhost:3000", utils.Config.Auth.SiteUrl)
+		assert.Equal(t, []string{"http://localhost:3000/auth/callback"}, utils.Config.Auth.AdditionalRedirectUrls)
+		assert.Contains(t, utils.Config.Storage.Buckets, "avatars")
+	})
+
+	t.Run("skips existing files", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		seedPath := filepath.Join(utils.SupabaseDirPath, "seed.sql")
+		require.NoError(t, afero.WriteFile(fsys, seedPath, []byte("select 1;"), 0644))
+		// Run test
+		assert.NoError(t, ApplyTemplate(templates[1], false, fsys))
+		// Validate seed is unchanged
+		contents, err := afero.ReadFile(fsys, seedPath)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("select 1;"), contents)
+	})
+
+	t.Run("throws error on invalid file path", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		tmpl := Template{Name: "evil", Files: map[string]string{"../.bashrc": ""}}
+		// Run test
+		err := ApplyTemplate(tmpl, false, fsys)
+		assert.ErrorContains(t, err, "invalid template file path: ../.bashrc")
+	})
+}
@@ -0,0 +1,8 @@
+// Invoke locally with: supabase functions invoke hello --data '{"name":"Functions"}'
+Deno.serve(async (req) => {
+  const { name } = await req.json()
+  const data = { message: `Hello ${name}!` }
+  return new Response(JSON.stringify(data), {
+    headers: { "Content-Type": "application/json" },
+  })
+})
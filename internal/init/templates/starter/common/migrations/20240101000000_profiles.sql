@@ -0,0 +1,17 @@
+create table public.profiles (
+  id uuid primary key references auth.users on delete cascade,
+  username text unique,
+  avatar_url text,
+  updated_at timestamptz default now()
+);
+
+alter table public.profiles enable row level security;
+
+create policy "Profiles are viewable by everyone." on public.profiles
+  for select using (true);
+
+create policy "Users can insert their own profile." on public.profiles
+  for insert with check ((select auth.uid()) = id);
+
+create policy "Users can update their own profile." on public.profiles
+  for update using ((select auth.uid()) = id);
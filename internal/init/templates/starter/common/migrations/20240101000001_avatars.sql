@@ -0,0 +1,8 @@
+create policy "Avatar images are publicly accessible." on storage.objects
+  for select using (bucket_id = 'avatars');
+
+create policy "Users can upload their own avatar." on storage.objects
+  for insert with check (bucket_id = 'avatars' and (select auth.uid())::text = (storage.foldername(name))[1]);
+
+create policy "Users can update their own avatar." on storage.objects
+  for update using (bucket_id = 'avatars' and (select auth.uid())::text = (storage.foldername(name))[1]);
@@ -0,0 +1,5 @@
+insert into auth.users (id, aud, role, email, email_confirmed_at)
+values ('00000000-0000-0000-0000-000000000001', 'authenticated', 'authenticated', 'demo@example.com', now());
+
+insert into public.profiles (id, username)
+values ('00000000-0000-0000-0000-000000000001', 'demo');
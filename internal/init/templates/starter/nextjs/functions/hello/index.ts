@@ -0,0 +1,16 @@
+// Invoke from the Next.js app with: supabase.functions.invoke('hello', { body: { name: 'Functions' } })
+const corsHeaders = {
+  "Access-Control-Allow-Origin": "http://localhost:3000",
+  "Access-Control-Allow-Headers": "authorization, x-client-info, apikey, content-type",
+}
+
+Deno.serve(async (req) => {
+  if (req.method === "OPTIONS") {
+    return new Response("ok", { headers: corsHeaders })
+  }
+  const { name } = await req.json()
+  const data = { message: `Hello ${name}!` }
+  return new Response(JSON.stringify(data), {
+    headers: { ...corsHeaders, "Content-Type": "application/json" },
+  })
+})
@@ -4,6 +4,7 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"os"
 
 	"github.com/go-errors/errors"
 	"github.com/jackc/pgconn"
@@ -40,6 +41,9 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 	if err != nil {
 		return err
 	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeRows(utils.OutputFormat.Value, os.Stdout, result)
+	}
 
 	table := "|Type|Schema name|Object name|Bloat|Waste\n|-|-|-|-|-|\n"
 	for _, r := range result {
@@ -0,0 +1,159 @@
+package inspect
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+)
+
+const (
+	FormatCSV      = "csv"
+	FormatMarkdown = "md"
+	FormatHTML     = "html"
+)
+
+var ReportFormats = []string{FormatCSV, FormatMarkdown, FormatHTML}
+
+// Bundle holds the results of all inspect queries for a single report file.
+type Bundle struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Sections    []Section `json:"sections"`
+}
+
+type Section struct {
+	Name    string     `json:"name"`
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+}
+
+func collectSection(ctx context.Context, name, query string, conn *pgconn.PgConn) (Section, error) {
+	section := Section{Name: name}
+	results, err := conn.Exec(ctx, strings.ReplaceAll(query, "$1", ignoreSchemas)).ReadAll()
+	if err != nil {
+		return section, errors.Errorf("failed to run %s query: %w", name, err)
+	}
+	for _, r := range results {
+		if len(r.FieldDescriptions) == 0 {
+			continue
+		}
+		section.Columns = make([]string, len(r.FieldDescriptions))
+		for i, fd := range r.FieldDescriptions {
+			section.Columns[i] = string(fd.Name)
+		}
+		section.Rows = make([][]string, len(r.Rows))
+		for i, row := range r.Rows {
+			section.Rows[i] = make([]string, len(row))
+			for j, v := range row {
+				section.Rows[i][j] = string(v)
+			}
+		}
+	}
+	return section, nil
+}
+
+// Raw results are embedded in a comment so that rendered reports can be compared later.
+const (
+	dataStart = "<!-- supabase-inspect-report\n"
+	dataEnd   = "\n-->\n"
+)
+
+func encodeData(b Bundle) (string, error) {
+	// Marshal escapes < and > so query text cannot terminate the comment early
+	data, err := json.Marshal(b)
+	if err != nil {
+		return "", errors.Errorf("failed to encode report data: %w", err)
+	}
+	return dataStart + string(data) + dataEnd, nil
+}
+
+func decodeData(contents []byte) (Bundle, error) {
+	var b Bundle
+	_, data, found := bytes.Cut(contents, []byte(dataStart))
+	if !found {
+		return b, errors.New("missing report data: only md and html reports generated by inspect report can be compared")
+	}
+	data, _, _ = bytes.Cut(data, []byte(dataEnd))
+	if err := json.Unmarshal(data, &b); err != nil {
+		return b, errors.Errorf("failed to decode report data: %w", err)
+	}
+	return b, nil
+}
+
+func renderMarkdown(b Bundle) (string, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Database inspect report\n\nGenerated at %s\n", b.GeneratedAt.Format(time.RFC3339))
+	for _, s := range b.Sections {
+		fmt.Fprintf(&sb, "\n## %s\n\n", s.Name)
+		if len(s.Rows) == 0 {
+			sb.WriteString("_No rows._\n")
+			continue
+		}
+		sb.WriteString(markdownRow(s.Columns))
+		sb.WriteString("|" + strings.Repeat("-|", len(s.Columns)) + "\n")
+		for _, row := range s.Rows {
+			sb.WriteString(markdownRow(row))
+		}
+	}
+	data, err := encodeData(b)
+	if err != nil {
+		return "", err
+	}
+	return sb.String() + "\n" + data, nil
+}
+
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		c = strings.ReplaceAll(c, "|", `\|`)
+		escaped[i] = strings.ReplaceAll(c, "\n", "<br>")
+	}
+	return "|" + strings.Join(escaped, "|") + "|\n"
+}
+
+//go:embed templates/report.html
+var reportTemplate string
+
+func renderHTML(b Bundle) (string, error) {
+	tmpl, err := template.New("report").Parse(reportTemplate)
+	if err != nil {
+		return "", errors.Errorf("failed to parse report template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, b); err != nil {
+		return "", errors.Errorf("failed to render report: %w", err)
+	}
+	data, err := encodeData(b)
+	if err != nil {
+		return "", err
+	}
+	return buf.String() + data, nil
+}
+
+func writeBundle(b Bundle, format, outPath string, fsys afero.Fs) error {
+	var contents string
+	var err error
+	switch format {
+	case FormatMarkdown:
+		contents, err = renderMarkdown(b)
+	case FormatHTML:
+		contents, err = renderHTML(b)
+	default:
+		return errors.Errorf("unsupported report format: %s", format)
+	}
+	if err != nil {
+		return err
+	}
+	if err := afero.WriteFile(fsys, outPath, []byte(contents), 0644); err != nil {
+		return errors.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
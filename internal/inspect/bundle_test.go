@@ -0,0 +1,97 @@
+package inspect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mockBundle = Bundle{
+	GeneratedAt: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC),
+	Sections: []Section{{
+		Name:    "table_sizes",
+		Columns: []string{"name", "size"},
+		Rows: [][]string{
+			{"public.users", "16 kB"},
+			{"public.a|b", "<!-- -->\n8 kB"},
+		},
+	}, {
+		Name:    "locks",
+		Columns: []string{"pid", "query"},
+	}},
+}
+
+func TestRenderBundle(t *testing.T) {
+	t.Run("renders markdown with embedded data", func(t *testing.T) {
+		out, err := renderMarkdown(mockBundle)
+		assert.NoError(t, err)
+		assert.Contains(t, out, "## table_sizes\n\n|name|size|\n|-|-|\n|public.users|16 kB|\n")
+		assert.Contains(t, out, `|public.a\|b|<!-- --><br>8 kB|`)
+		assert.Contains(t, out, "## locks\n\n_No rows._\n")
+		decoded, err := decodeData([]byte(out))
+		assert.NoError(t, err)
+		assert.Equal(t, mockBundle, decoded)
+	})
+
+	t.Run("renders html with embedded data", func(t *testing.T) {
+		out, err := renderHTML(mockBundle)
+		assert.NoError(t, err)
+		assert.Contains(t, out, "<td>public.users</td><td>16 kB</td>")
+		assert.Contains(t, out, "<td>&lt;!-- --&gt;\n8 kB</td>")
+		decoded, err := decodeData([]byte(out))
+		assert.NoError(t, err)
+		assert.Equal(t, mockBundle, decoded)
+	})
+
+	t.Run("throws error on csv report", func(t *testing.T) {
+		_, err := decodeData([]byte("name,size\n"))
+		assert.ErrorContains(t, err, "missing report data")
+	})
+}
+
+func TestCompareReports(t *testing.T) {
+	t.Run("diffs rows by first column", func(t *testing.T) {
+		head := Bundle{Sections: []Section{{
+			Name:    "table_sizes",
+			Columns: []string{"name", "size"},
+			Rows: [][]string{
+				{"public.users", "32 kB"},
+				{"public.posts", "8 kB"},
+			},
+		}}}
+		changes := diffBundles(mockBundle, head)
+		assert.Equal(t, []change{
+			{section: "table_sizes", key: "public.users", kind: "changed", column: "size", before: "16 kB", after: "32 kB"},
+			{section: "table_sizes", key: "public.posts", kind: "added", after: "public.posts, 8 kB"},
+			{section: "table_sizes", key: "public.a|b", kind: "removed", before: "public.a|b, <!-- -->\n8 kB"},
+		}, changes)
+	})
+
+	t.Run("disambiguates repeated keys", func(t *testing.T) {
+		_, keys := indexRows([][]string{{"a", "1"}, {"a", "2"}, {"a", "3"}})
+		assert.Equal(t, []string{"a", "a (2)", "a (3)"}, keys)
+	})
+
+	t.Run("compares identical reports", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		out, err := renderMarkdown(mockBundle)
+		require.NoError(t, err)
+		require.NoError(t, afero.WriteFile(fsys, "base.md", []byte(out), 0644))
+		out, err = renderHTML(mockBundle)
+		require.NoError(t, err)
+		require.NoError(t, afero.WriteFile(fsys, "head.html", []byte(out), 0644))
+		// Run test
+		err = Compare("base.md", "head.html", fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on missing report", func(t *testing.T) {
+		err := Compare("base.md", "head.md", afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "failed to read report")
+	})
+}
@@ -0,0 +1,113 @@
+package inspect
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+)
+
+// Compare prints the rows that were added, removed, or changed between two reports.
+func Compare(basePath, headPath string, fsys afero.Fs) error {
+	base, err := loadBundle(basePath, fsys)
+	if err != nil {
+		return err
+	}
+	head, err := loadBundle(headPath, fsys)
+	if err != nil {
+		return err
+	}
+	changes := diffBundles(base, head)
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, "No differences found between reports.")
+		return nil
+	}
+	table := "|Section|Row|Change|Column|Before|After|\n|-|-|-|-|-|-|\n"
+	for _, c := range changes {
+		table += markdownRow([]string{c.section, c.key, c.kind, c.column, c.before, c.after})
+	}
+	return list.RenderTable(table)
+}
+
+func loadBundle(reportPath string, fsys afero.Fs) (Bundle, error) {
+	contents, err := afero.ReadFile(fsys, reportPath)
+	if err != nil {
+		return Bundle{}, errors.Errorf("failed to read report: %w", err)
+	}
+	b, err := decodeData(contents)
+	if err != nil {
+		return b, errors.Errorf("%s: %w", reportPath, err)
+	}
+	return b, nil
+}
+
+type change struct {
+	section, key, kind, column, before, after string
+}
+
+func diffBundles(base, head Bundle) []change {
+	baseSections := make(map[string]Section, len(base.Sections))
+	for _, s := range base.Sections {
+		baseSections[s.Name] = s
+	}
+	var result []change
+	for _, h := range head.Sections {
+		result = append(result, diffSection(baseSections[h.Name], h)...)
+	}
+	return result
+}
+
+// Rows are matched by their first column, which names the object each query reports on.
+func diffSection(base, head Section) []change {
+	name := head.Name
+	baseRows, baseKeys := indexRows(base.Rows)
+	headRows, headKeys := indexRows(head.Rows)
+	var result []change
+	for _, key := range headKeys {
+		after := headRows[key]
+		before, ok := baseRows[key]
+		if !ok {
+			result = append(result, change{section: name, key: key, kind: "added", after: strings.Join(after, ", ")})
+			continue
+		}
+		for i := 1; i < len(head.Columns) && i < len(after); i++ {
+			var prev string
+			if i < len(before) {
+				prev = before[i]
+			}
+			if prev != after[i] {
+				result = append(result, change{section: name, key: key, kind: "changed", column: head.Columns[i], before: prev, after: after[i]})
+			}
+		}
+	}
+	for _, key := range baseKeys {
+		if _, ok := headRows[key]; !ok {
+			result = append(result, change{section: name, key: key, kind: "removed", before: strings.Join(baseRows[key], ", ")})
+		}
+	}
+	return result
+}
+
+func indexRows(rows [][]string) (map[string][]string, []string) {
+	index := make(map[string][]string, len(rows))
+	keys := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		// Disambiguate repeated keys by their order of appearance
+		key := row[0]
+		for n := 2; ; n++ {
+			if _, ok := index[key]; !ok {
+				break
+			}
+			key = fmt.Sprintf("%s (%d)", row[0], n)
+		}
+		index[key] = row
+		keys = append(keys, key)
+	}
+	return index, keys
+}
@@ -0,0 +1,162 @@
+package diagnose
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/inspect/bloat"
+	"github.com/supabase/cli/internal/inspect/blocking"
+	"github.com/supabase/cli/internal/inspect/cache"
+	"github.com/supabase/cli/internal/inspect/long_running_queries"
+	"github.com/supabase/cli/internal/inspect/unused_indexes"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgxv5"
+)
+
+const (
+	StatusPass = "PASS"
+	StatusWarn = "WARN"
+	StatusFail = "FAIL"
+)
+
+type Result struct {
+	Check   string `json:"check"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type check func(context.Context, *pgx.Conn) (Result, error)
+
+// Ref: https://github.com/heroku/heroku-pg-extras/blob/main/commands/diagnose.js
+var checks = []check{
+	checkCacheHit,
+	checkUnusedIndexes,
+	checkBloat,
+	checkLongRunningQueries,
+	checkBlocking,
+}
+
+func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	var result []Result
+	for _, apply := range checks {
+		r, err := apply(ctx, conn)
+		if err != nil {
+			return err
+		}
+		result = append(result, r)
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeRows(utils.OutputFormat.Value, os.Stdout, result)
+	}
+	table := "|Check|Status|Message|\n|-|-|-|\n"
+	for _, r := range result {
+		table += fmt.Sprintf("|`%s`|`%s`|%s|\n", r.Check, r.Status, r.Message)
+	}
+	return list.RenderTable(table)
+}
+
+func checkCacheHit(ctx context.Context, conn *pgx.Conn) (Result, error) {
+	rows, err := conn.Query(ctx, cache.CacheQuery)
+	if err != nil {
+		return Result{}, errors.Errorf("failed to query rows: %w", err)
+	}
+	ratios, err := pgxv5.CollectRows[cache.Result](rows)
+	if err != nil {
+		return Result{}, err
+	}
+	r := Result{Check: "Hit Rate", Status: StatusPass, Message: "Cache hit rates are above 94%."}
+	for _, v := range ratios {
+		if v.Ratio < 0.94 {
+			r.Status = StatusWarn
+			r.Message = fmt.Sprintf("The %s is %.2f%%, consider upgrading your compute.", v.Name, v.Ratio*100)
+		}
+	}
+	return r, nil
+}
+
+func checkUnusedIndexes(ctx context.Context, conn *pgx.Conn) (Result, error) {
+	rows, err := conn.Query(ctx, unused_indexes.UnusedIndexesQuery, reset.LikeEscapeSchema(utils.InternalSchemas))
+	if err != nil {
+		return Result{}, errors.Errorf("failed to query rows: %w", err)
+	}
+	indexes, err := pgxv5.CollectRows[unused_indexes.Result](rows)
+	if err != nil {
+		return Result{}, err
+	}
+	r := Result{Check: "Unused Indexes", Status: StatusPass, Message: "No unused indexes found."}
+	if len(indexes) > 0 {
+		r.Status = StatusWarn
+		r.Message = fmt.Sprintf("Found %d indexes with low usage, run `inspect db unused-indexes` for details.", len(indexes))
+	}
+	return r, nil
+}
+
+func checkBloat(ctx context.Context, conn *pgx.Conn) (Result, error) {
+	rows, err := conn.Query(ctx, bloat.BloatQuery, reset.LikeEscapeSchema(utils.InternalSchemas))
+	if err != nil {
+		return Result{}, errors.Errorf("failed to query rows: %w", err)
+	}
+	objects, err := pgxv5.CollectRows[bloat.Result](rows)
+	if err != nil {
+		return Result{}, err
+	}
+	r := Result{Check: "Bloat", Status: StatusPass, Message: "No tables or indexes with bloat above 10x."}
+	var count int
+	for _, v := range objects {
+		if ratio, err := strconv.ParseFloat(v.Bloat, 64); err == nil && ratio > 10 {
+			count++
+		}
+	}
+	if count > 0 {
+		r.Status = StatusWarn
+		r.Message = fmt.Sprintf("Found %d relations with bloat above 10x, run `inspect db bloat` for details.", count)
+	}
+	return r, nil
+}
+
+func checkLongRunningQueries(ctx context.Context, conn *pgx.Conn) (Result, error) {
+	rows, err := conn.Query(ctx, long_running_queries.LongRunningQueriesQuery)
+	if err != nil {
+		return Result{}, errors.Errorf("failed to query rows: %w", err)
+	}
+	queries, err := pgxv5.CollectRows[long_running_queries.Result](rows)
+	if err != nil {
+		return Result{}, err
+	}
+	r := Result{Check: "Long Running Queries", Status: StatusPass, Message: "No queries running for longer than 5 minutes."}
+	if len(queries) > 0 {
+		r.Status = StatusWarn
+		r.Message = fmt.Sprintf("Found %d queries running for longer than 5 minutes, run `inspect db long-running-queries` for details.", len(queries))
+	}
+	return r, nil
+}
+
+func checkBlocking(ctx context.Context, conn *pgx.Conn) (Result, error) {
+	rows, err := conn.Query(ctx, blocking.BlockingQuery)
+	if err != nil {
+		return Result{}, errors.Errorf("failed to query rows: %w", err)
+	}
+	locks, err := pgxv5.CollectRows[blocking.Result](rows)
+	if err != nil {
+		return Result{}, err
+	}
+	r := Result{Check: "Blocking Queries", Status: StatusPass, Message: "No queries are waiting on locks."}
+	if len(locks) > 0 {
+		r.Status = StatusFail
+		r.Message = fmt.Sprintf("Found %d queries waiting on locks, run `inspect db blocking` for details.", len(locks))
+	}
+	return r, nil
+}
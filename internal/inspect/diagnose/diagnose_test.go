@@ -0,0 +1,74 @@
+package diagnose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/inspect/bloat"
+	"github.com/supabase/cli/internal/inspect/blocking"
+	"github.com/supabase/cli/internal/inspect/cache"
+	"github.com/supabase/cli/internal/inspect/long_running_queries"
+	"github.com/supabase/cli/internal/inspect/unused_indexes"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestDiagnoseCommand(t *testing.T) {
+	t.Run("runs all checks", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(cache.CacheQuery).
+			Reply("SELECT 1", cache.Result{
+				Name:  "index hit rate",
+				Ratio: 0.9,
+			}).
+			Query(unused_indexes.UnusedIndexesQuery, reset.LikeEscapeSchema(utils.InternalSchemas)).
+			Reply("SELECT 0").
+			Query(bloat.BloatQuery, reset.LikeEscapeSchema(utils.InternalSchemas)).
+			Reply("SELECT 1", bloat.Result{
+				Type:        "table",
+				Schemaname:  "public",
+				Object_name: "todos",
+				Bloat:       "12.5",
+				Waste:       "8 kB",
+			}).
+			Query(long_running_queries.LongRunningQueriesQuery).
+			Reply("SELECT 0").
+			Query(blocking.BlockingQuery).
+			Reply("SELECT 0")
+		// Run test
+		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(cache.CacheQuery).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for view pg_statio_user_indexes")
+		// Run test
+		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "permission denied for view pg_statio_user_indexes")
+	})
+}
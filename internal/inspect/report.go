@@ -21,8 +21,9 @@ import (
 //go:embed **/*.sql
 var queries embed.FS
 
-func Report(ctx context.Context, out string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
-	date := time.Now().Format("2006-01-02")
+func Report(ctx context.Context, out, format string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	now := time.Now()
+	date := now.Format("2006-01-02")
 	if err := utils.MkdirIfNotExistFS(fsys, out); err != nil {
 		return err
 	}
@@ -32,6 +33,7 @@ func Report(ctx context.Context, out string, config pgconn.Config, fsys afero.Fs
 	}
 	defer conn.Close(context.Background())
 	fmt.Fprintln(os.Stderr, "Running queries...")
+	bundle := Bundle{GeneratedAt: now.UTC()}
 	if err := fs.WalkDir(queries, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return errors.Errorf("failed to walk queries: %w", err)
@@ -44,11 +46,22 @@ func Report(ctx context.Context, out string, config pgconn.Config, fsys afero.Fs
 			return errors.Errorf("failed to read query: %w", err)
 		}
 		name := strings.Split(d.Name(), ".")[0]
+		if format != FormatCSV {
+			section, err := collectSection(ctx, name, string(query), conn.PgConn())
+			bundle.Sections = append(bundle.Sections, section)
+			return err
+		}
 		outPath := filepath.Join(out, fmt.Sprintf("%s_%s.csv", name, date))
 		return copyToCSV(ctx, string(query), outPath, conn.PgConn(), fsys)
 	}); err != nil {
 		return err
 	}
+	if format != FormatCSV {
+		out = filepath.Join(out, fmt.Sprintf("report_%s.%s", now.Format("20060102T150405"), format))
+		if err := writeBundle(bundle, format, out, fsys); err != nil {
+			return err
+		}
+	}
 	if !filepath.IsAbs(out) {
 		out, _ = filepath.Abs(out)
 	}
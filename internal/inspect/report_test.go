@@ -87,7 +87,7 @@ func TestReportCommand(t *testing.T) {
 			Query(wrapQuery(vacuum_stats.VacuumStatsQuery)).
 			Reply("COPY 0")
 		// Run test
-		err := Report(context.Background(), ".", dbConfig, fsys, conn.Intercept)
+		err := Report(context.Background(), ".", FormatCSV, dbConfig, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 		matches, err := afero.Glob(fsys, "*.csv")
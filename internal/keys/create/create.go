@@ -0,0 +1,40 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/keys/list"
+	"github.com/supabase/cli/internal/secrets/set"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, projectRef string, body api.CreateApiKeyBody, reveal bool, secretName string, fsys afero.Fs) error {
+	resp, err := utils.GetSupabase().CreateApiKeyWithResponse(ctx, projectRef, body, list.WithReveal(reveal || len(secretName) > 0))
+	if err != nil {
+		return errors.Errorf("failed to create api key: %w", err)
+	}
+	if resp.JSON201 == nil {
+		return errors.New("Unexpected error creating api key: " + string(resp.Body))
+	}
+	key := *resp.JSON201
+	fmt.Fprintln(os.Stderr, "Created API key:", utils.Aqua(key.Name))
+	if len(secretName) > 0 {
+		if err := set.Run(ctx, projectRef, "", "", []string{secretName + "=" + key.ApiKey}, fsys); err != nil {
+			return err
+		}
+	}
+	if !reveal && list.IsSecret(key) {
+		key = list.Mask(key)
+		fmt.Fprintln(os.Stderr, "Run with --reveal to print the full key.")
+	}
+	if utils.OutputFormat.Value == utils.OutputPretty {
+		fmt.Println(key.ApiKey)
+		return nil
+	}
+	return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, key)
+}
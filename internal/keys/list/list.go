@@ -0,0 +1,94 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, projectRef string, reveal bool) error {
+	keys, err := ListApiKeys(ctx, projectRef, reveal)
+	if err != nil {
+		return err
+	}
+	if !reveal {
+		for i := range keys {
+			keys[i] = Mask(keys[i])
+		}
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, keys)
+	}
+	table := `|NAME|TYPE|ID|KEY VALUE|DESCRIPTION|
+|-|-|-|-|-|
+`
+	for _, k := range keys {
+		table += fmt.Sprintf(
+			"|`%s`|`%s`|`%s`|`%s`|%s|\n",
+			strings.ReplaceAll(k.Name, "|", "\\|"),
+			valueOrEmpty(k.Type),
+			valueOrEmpty(k.Id),
+			k.ApiKey,
+			strings.ReplaceAll(valueOrEmpty(k.Description), "|", "\\|"),
+		)
+	}
+	return list.RenderTable(table)
+}
+
+func ListApiKeys(ctx context.Context, projectRef string, reveal bool) ([]api.ApiKeyResponse, error) {
+	resp, err := utils.GetSupabase().V1GetProjectApiKeysWithResponse(ctx, projectRef, WithReveal(reveal))
+	if err != nil {
+		return nil, errors.Errorf("failed to list api keys: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, errors.New("Unexpected error listing api keys: " + string(resp.Body))
+	}
+	return *resp.JSON200, nil
+}
+
+// WithReveal asks the API to return full secret keys instead of masked values.
+func WithReveal(reveal bool) api.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		if reveal {
+			q := req.URL.Query()
+			q.Set("reveal", "true")
+			req.URL.RawQuery = q.Encode()
+		}
+		return nil
+	}
+}
+
+// IsSecret returns true for keys that bypass RLS, ie. secret and legacy service role keys.
+func IsSecret(key api.ApiKeyResponse) bool {
+	if key.Type != nil && *key.Type == api.ApiKeyResponseTypeSecret {
+		return true
+	}
+	return key.Name == "service_role"
+}
+
+// Mask hides the value of secret keys, keeping only their prefix for identification.
+func Mask(key api.ApiKeyResponse) api.ApiKeyResponse {
+	if !IsSecret(key) || len(key.ApiKey) == 0 {
+		return key
+	}
+	prefix := valueOrEmpty(key.Prefix)
+	if len(prefix) == 0 {
+		prefix = key.ApiKey[:min(len(key.ApiKey), 8)]
+	}
+	key.ApiKey = prefix + "····"
+	return key
+}
+
+func valueOrEmpty[T ~string](value *T) string {
+	if value == nil {
+		return ""
+	}
+	return string(*value)
+}
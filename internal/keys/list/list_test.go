@@ -0,0 +1,92 @@
+package list
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
+)
+
+func TestListApiKeys(t *testing.T) {
+	ref := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("masks secret keys", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{
+				{Name: "anon", ApiKey: "anon-key", Type: cast.Ptr(api.ApiKeyResponseTypeLegacy)},
+				{Name: "service_role", ApiKey: "service-role-key", Type: cast.Ptr(api.ApiKeyResponseTypeLegacy)},
+			})
+		// Run test
+		err := Run(context.Background(), ref, false)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("reveals secret keys", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+ref+"/api-keys").
+			MatchParam("reveal", "true").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{})
+		// Run test
+		keys, err := ListApiKeys(context.Background(), ref, true)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, keys)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on service unavailable", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/api-keys").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), ref, false)
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error listing api keys:")
+	})
+}
+
+func TestMask(t *testing.T) {
+	t.Run("masks secret key with prefix", func(t *testing.T) {
+		key := api.ApiKeyResponse{
+			Name:   "default",
+			ApiKey: "sb_secret_abcdefghijklmnop",
+			Prefix: cast.Ptr("sb_secret_abc"),
+			Type:   cast.Ptr(api.ApiKeyResponseTypeSecret),
+		}
+		assert.Equal(t, "sb_secret_abc····", Mask(key).ApiKey)
+	})
+
+	t.Run("masks legacy service role key", func(t *testing.T) {
+		key := api.ApiKeyResponse{Name: "service_role", ApiKey: "eyJhbGciOiJIUzI1NiJ9.payload"}
+		assert.Equal(t, "eyJhbGci····", Mask(key).ApiKey)
+	})
+
+	t.Run("keeps publishable key", func(t *testing.T) {
+		key := api.ApiKeyResponse{
+			Name:   "default",
+			ApiKey: "sb_publishable_abcdef",
+			Type:   cast.Ptr(api.ApiKeyResponseTypePublishable),
+		}
+		assert.Equal(t, "sb_publishable_abcdef", Mask(key).ApiKey)
+	})
+}
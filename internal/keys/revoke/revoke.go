@@ -0,0 +1,50 @@
+package revoke
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/keys/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, projectRef, idOrName string) error {
+	keys, err := list.ListApiKeys(ctx, projectRef, false)
+	if err != nil {
+		return err
+	}
+	key, err := findKey(keys, idOrName)
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Do you want to revoke API key %s? Clients using it will lose access.", utils.Aqua(key.Name))
+	if shouldRevoke, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldRevoke {
+		return errors.New(context.Canceled)
+	}
+	resp, err := utils.GetSupabase().DeleteApiKeyWithResponse(ctx, projectRef, *key.Id)
+	if err != nil {
+		return errors.Errorf("failed to revoke api key: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return errors.New("Unexpected error revoking api key: " + string(resp.Body))
+	}
+	fmt.Fprintln(os.Stderr, "Revoked API key:", utils.Aqua(key.Name))
+	return nil
+}
+
+func findKey(keys []api.ApiKeyResponse, idOrName string) (api.ApiKeyResponse, error) {
+	for _, k := range keys {
+		if (k.Id != nil && *k.Id == idOrName) || k.Name == idOrName {
+			if k.Id == nil || (k.Type != nil && *k.Type == api.ApiKeyResponseTypeLegacy) {
+				return k, errors.Errorf("Legacy API key %s cannot be revoked. Rotate the JWT secret from the dashboard instead.", k.Name)
+			}
+			return k, nil
+		}
+	}
+	return api.ApiKeyResponse{}, errors.Errorf("API key not found: %s", idOrName)
+}
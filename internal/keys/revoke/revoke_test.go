@@ -0,0 +1,66 @@
+package revoke
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
+)
+
+func TestRevokeApiKey(t *testing.T) {
+	ref := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	keys := []api.ApiKeyResponse{
+		{Name: "anon", ApiKey: "anon-key", Type: cast.Ptr(api.ApiKeyResponseTypeLegacy)},
+		{Name: "backend", Id: cast.Ptr("key-id"), ApiKey: "sb_secret_abc", Type: cast.Ptr(api.ApiKeyResponseTypeSecret)},
+	}
+
+	t.Run("throws error on legacy key", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/api-keys").
+			Reply(http.StatusOK).
+			JSON(keys)
+		// Run test
+		err := Run(context.Background(), ref, "anon")
+		// Check error
+		assert.ErrorContains(t, err, "Legacy API key anon cannot be revoked.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing key", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/api-keys").
+			Reply(http.StatusOK).
+			JSON(keys)
+		// Run test
+		err := Run(context.Background(), ref, "missing")
+		// Check error
+		assert.ErrorContains(t, err, "API key not found: missing")
+	})
+
+	t.Run("cancels on declined prompt", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/api-keys").
+			Reply(http.StatusOK).
+			JSON(keys)
+		// Run test
+		err := Run(context.Background(), ref, "key-id")
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
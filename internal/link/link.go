@@ -13,6 +13,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
 	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/credentials"
 	"github.com/supabase/cli/internal/utils/flags"
@@ -56,10 +57,15 @@ func Run(ctx context.Context, projectRef string, fsys afero.Fs, options ...func(
 	}
 
 	// 3. Save project ref
-	if err := utils.WriteFile(utils.ProjectRefPath, []byte(projectRef), fsys); err != nil {
+	if err := utils.WriteFile(utils.GetProjectRefPath(), []byte(projectRef), fsys); err != nil {
 		return err
 	}
-	fmt.Fprintln(os.Stdout, "Finished "+utils.Aqua("supabase link")+".")
+	if name := utils.GetLinkName(); len(name) > 0 {
+		fmt.Fprintln(utils.GetHumanWriter(), render.T("finished_as", utils.Aqua("supabase link"), utils.Bold(name)))
+	} else {
+		fmt.Fprintln(utils.GetHumanWriter(), render.T("finished", utils.Aqua("supabase link")))
+	}
+	utils.PrintResult(projectRef)
 
 	// 4. Suggest config update
 	updated, err := cliConfig.ToTomlBytes(utils.Config.Clone())
@@ -69,7 +75,7 @@ func Run(ctx context.Context, projectRef string, fsys afero.Fs, options ...func(
 
 	if lineDiff := diff.Diff(utils.ConfigPath, original, projectRef, updated); len(lineDiff) > 0 {
 		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Local config differs from linked project. Try updating", utils.Bold(utils.ConfigPath))
-		fmt.Println(string(lineDiff))
+		fmt.Fprintln(utils.GetHumanWriter(), string(lineDiff))
 	}
 	return nil
 }
@@ -146,7 +152,7 @@ func linkPostgrestVersion(ctx context.Context, api tenant.TenantAPI, fsys afero.
 	if err != nil {
 		return err
 	}
-	return utils.WriteFile(utils.RestVersionPath, []byte(version), fsys)
+	return utils.WriteFile(utils.GetLinkedPath(utils.RestVersionPath), []byte(version), fsys)
 }
 
 func linkGotrue(ctx context.Context, projectRef string) error {
@@ -165,7 +171,7 @@ func linkGotrueVersion(ctx context.Context, api tenant.TenantAPI, fsys afero.Fs)
 	if err != nil {
 		return err
 	}
-	return utils.WriteFile(utils.GotrueVersionPath, []byte(version), fsys)
+	return utils.WriteFile(utils.GetLinkedPath(utils.GotrueVersionPath), []byte(version), fsys)
 }
 
 func linkStorage(ctx context.Context, projectRef string) error {
@@ -184,7 +190,7 @@ func linkStorageVersion(ctx context.Context, api tenant.TenantAPI, fsys afero.Fs
 	if err != nil {
 		return err
 	}
-	return utils.WriteFile(utils.StorageVersionPath, []byte(version), fsys)
+	return utils.WriteFile(utils.GetLinkedPath(utils.StorageVersionPath), []byte(version), fsys)
 }
 
 func linkDatabaseSettings(ctx context.Context, projectRef string) error {
@@ -238,7 +244,7 @@ func linkPooler(ctx context.Context, projectRef string, fsys afero.Fs) error {
 			updatePoolerConfig(config)
 		}
 	}
-	return utils.WriteFile(utils.PoolerUrlPath, []byte(utils.Config.Db.Pooler.ConnectionString), fsys)
+	return utils.WriteFile(utils.GetLinkedPath(utils.PoolerUrlPath), []byte(utils.Config.Db.Pooler.ConnectionString), fsys)
 }
 
 func updatePoolerConfig(config api.SupavisorConfigResponse) {
@@ -281,7 +287,7 @@ func checkRemoteProjectStatus(ctx context.Context, projectRef string, fsys afero
 
 	// Update postgres image version to match the remote project
 	if version := resp.JSON200.Database.Version; len(version) > 0 {
-		return utils.WriteFile(utils.PostgresVersionPath, []byte(version), fsys)
+		return utils.WriteFile(utils.GetLinkedPath(utils.PostgresVersionPath), []byte(version), fsys)
 	}
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"path/filepath"
 	"testing"
 
 	"github.com/h2non/gock"
@@ -11,7 +12,9 @@ import (
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/supabase/cli/internal/testing/apitest"
 	"github.com/supabase/cli/internal/testing/fstest"
 	"github.com/supabase/cli/internal/testing/helper"
@@ -261,6 +264,35 @@ func TestStatusCheck(t *testing.T) {
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 
+	t.Run("keeps postgres version of named link separately", func(t *testing.T) {
+		t.Cleanup(func() { viper.Set("LINK_NAME", "") })
+		viper.Set("LINK_NAME", "staging")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.PostgresVersionPath, []byte("15.1.0.147"), 0644))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		// Mock project status
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project).
+			Reply(http.StatusOK).
+			JSON(api.V1ProjectResponse{
+				Status:   api.V1ProjectResponseStatusACTIVEHEALTHY,
+				Database: &api.V1DatabaseResponse{Version: "15.6.1.139"},
+			})
+		// Run test
+		err := checkRemoteProjectStatus(context.Background(), project, fsys)
+		// Check error
+		assert.NoError(t, err)
+		version, err := afero.ReadFile(fsys, filepath.Join(utils.LinksDir, "staging", "postgres-version"))
+		assert.NoError(t, err)
+		assert.Equal(t, "15.6.1.139", string(version))
+		version, err = afero.ReadFile(fsys, utils.PostgresVersionPath)
+		assert.NoError(t, err)
+		assert.Equal(t, "15.1.0.147", string(version))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
 	t.Run("ignores project not found", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
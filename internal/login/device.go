@@ -0,0 +1,102 @@
+package login
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+// Device authorization response defined by https://www.rfc-editor.org/rfc/rfc8628#section-3.2
+type DeviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationUri         string `json:"verification_uri"`
+	VerificationUriComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+const (
+	deviceCodePath  = "/v1/oauth/device/code"
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+// Unit of the polling interval returned by the server, shortened in tests.
+var pollInterval = time.Second
+
+func runDeviceFlow(ctx context.Context, stdout io.Writer, params RunParams) error {
+	resp, err := utils.PostOAuthForm(ctx, deviceCodePath, url.Values{})
+	if err != nil {
+		return errors.Errorf("failed to request device code: %w", err)
+	}
+	defer resp.Body.Close()
+	device, err := fetcher.ParseJSON[DeviceCodeResponse](resp.Body)
+	if err != nil {
+		return err
+	}
+	verifyUrl := device.VerificationUriComplete
+	if len(verifyUrl) == 0 {
+		verifyUrl = device.VerificationUri
+	}
+	fmt.Fprintf(stdout, "Your verification code is %s\n", utils.Bold(device.UserCode))
+	if params.OpenBrowser {
+		fmt.Fprintf(stdout, "Here is your login link in case browser did not open %s\n\n", utils.Bold(verifyUrl))
+		if err := RunOpenCmd(ctx, verifyUrl); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	} else {
+		fmt.Fprintf(stdout, "Open %s in the browser and enter the code above.\n\n", utils.Bold(device.VerificationUri))
+	}
+	token, err := pollForDeviceToken(ctx, device)
+	if err != nil {
+		return err
+	}
+	if err := utils.SaveOAuthToken(token, params.Fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, loggedInMsg)
+	return nil
+}
+
+func pollForDeviceToken(ctx context.Context, device DeviceCodeResponse) (utils.OAuthToken, error) {
+	interval := time.Duration(device.Interval) * pollInterval
+	if interval <= 0 {
+		interval = 5 * pollInterval
+	}
+	form := url.Values{}
+	form.Set("grant_type", deviceGrantType)
+	form.Set("device_code", device.DeviceCode)
+	for {
+		select {
+		case <-ctx.Done():
+			return utils.OAuthToken{}, errors.New(ctx.Err())
+		case <-time.After(interval):
+		}
+		token, err := utils.RequestOAuthToken(ctx, form)
+		if err == nil {
+			return token, nil
+		}
+		var oauthErr *utils.OAuthError
+		if !errors.As(err, &oauthErr) {
+			return token, err
+		}
+		switch oauthErr.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * pollInterval
+		case "expired_token":
+			return token, errors.New("Device code expired. Please run " + utils.Aqua("supabase login") + " again.")
+		case "access_denied":
+			return token, errors.New("Login request was denied.")
+		default:
+			return token, err
+		}
+	}
+}
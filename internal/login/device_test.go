@@ -0,0 +1,102 @@
+package login
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"github.com/zalando/go-keyring"
+)
+
+func TestDeviceLogin(t *testing.T) {
+	keyring.MockInit()
+	pollInterval = time.Millisecond
+	token := utils.OAuthToken{
+		AccessToken:  "sbp_oauth_" + "0123456789abcdef0123456789abcdef01234567",
+		RefreshToken: "refresh-token",
+		ExpiresIn:    3600,
+	}
+	device := DeviceCodeResponse{
+		DeviceCode:      "device-code",
+		UserCode:        "ABCD-EFGH",
+		VerificationUri: "https://supabase.com/device",
+		Interval:        1,
+	}
+
+	t.Run("logs in after authorization is pending", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post(deviceCodePath).
+			Reply(http.StatusOK).
+			JSON(device)
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post("/v1/oauth/token").
+			Reply(http.StatusBadRequest).
+			JSON(utils.OAuthError{Code: "authorization_pending"})
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post("/v1/oauth/token").
+			Reply(http.StatusOK).
+			JSON(token)
+		// Run test
+		err := Run(context.Background(), io.Discard, RunParams{
+			Device: true,
+			Fsys:   afero.NewMemMapFs(),
+		})
+		// Check error
+		assert.NoError(t, err)
+		saved, err := credentials.StoreProvider.Get(utils.AccessTokenKey)
+		assert.NoError(t, err)
+		assert.Equal(t, token.AccessToken, saved)
+		session, err := credentials.StoreProvider.Get(utils.OAuthSessionKey)
+		assert.NoError(t, err)
+		assert.Contains(t, session, token.RefreshToken)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on access denied", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post(deviceCodePath).
+			Reply(http.StatusOK).
+			JSON(device)
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post("/v1/oauth/token").
+			Reply(http.StatusBadRequest).
+			JSON(utils.OAuthError{Code: "access_denied"})
+		// Run test
+		err := Run(context.Background(), io.Discard, RunParams{
+			Device: true,
+			Fsys:   afero.NewMemMapFs(),
+		})
+		// Check error
+		assert.ErrorContains(t, err, "Login request was denied.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on device code failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post(deviceCodePath).
+			Reply(http.StatusBadRequest).
+			JSON(utils.OAuthError{Code: "invalid_client"})
+		// Run test
+		err := Run(context.Background(), io.Discard, RunParams{
+			Device: true,
+			Fsys:   afero.NewMemMapFs(),
+		})
+		// Check error
+		assert.ErrorContains(t, err, "invalid_client")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
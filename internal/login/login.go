@@ -29,6 +29,7 @@ type RunParams struct {
 	Token       string
 	TokenName   string
 	OpenBrowser bool
+	Device      bool
 	SessionId   string
 	Encryption  LoginEncryptor
 	Fsys        afero.Fs
@@ -172,6 +173,10 @@ func Run(ctx context.Context, stdout io.Writer, params RunParams) error {
 		return nil
 	}
 
+	if params.Device {
+		return runDeviceFlow(ctx, stdout, params)
+	}
+
 	// Initialise login encryption and Session ID for end-to-end communication.
 	if params.Encryption == nil {
 		var err error
@@ -0,0 +1,191 @@
+package query
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+const (
+	ServiceApi       = "api"
+	ServiceAuth      = "auth"
+	ServiceDb        = "db"
+	ServiceFunctions = "functions"
+	ServiceRealtime  = "realtime"
+	ServiceStorage   = "storage"
+)
+
+// Maps each service to its log analytics source table.
+var sources = map[string]string{
+	ServiceApi:       "edge_logs",
+	ServiceAuth:      "auth_logs",
+	ServiceDb:        "postgres_logs",
+	ServiceFunctions: "function_logs",
+	ServiceRealtime:  "realtime_logs",
+	ServiceStorage:   "storage_logs",
+}
+
+var Services = []string{
+	ServiceApi,
+	ServiceAuth,
+	ServiceDb,
+	ServiceFunctions,
+	ServiceRealtime,
+	ServiceStorage,
+}
+
+// Log analytics rejects queries spanning more than a day.
+const maxRange = 24 * time.Hour
+
+type QueryParams struct {
+	Service string
+	// Raw SQL against the log source tables, ie. select * from edge_logs
+	Sql string
+	// Case sensitive substring matched against the event message
+	Search string
+	Start  time.Time
+	End    time.Time
+	Limit  uint
+}
+
+func Run(ctx context.Context, projectRef string, params QueryParams, format string) error {
+	sql, err := BuildQuery(params)
+	if err != nil {
+		return err
+	}
+	if !params.Start.Before(params.End) {
+		return errors.Errorf("start time must be before end time: %s", params.Start.Format(time.RFC3339))
+	}
+	if params.End.Sub(params.Start) > maxRange {
+		return errors.Errorf("time range must not exceed %s", maxRange)
+	}
+	rows, err := QueryLogs(ctx, projectRef, sql, params.Start, params.End)
+	if err != nil {
+		return err
+	}
+	return PrintLogs(rows, format)
+}
+
+func BuildQuery(params QueryParams) (string, error) {
+	if len(params.Sql) > 0 {
+		return params.Sql, nil
+	}
+	table, ok := sources[params.Service]
+	if !ok {
+		return "", errors.Errorf("unknown log service %q: must be one of %s", params.Service, strings.Join(Services, ", "))
+	}
+	sql := "select id, cast(timestamp as datetime) as timestamp, event_message from " + table
+	if len(params.Search) > 0 {
+		sql += fmt.Sprintf(" where regexp_contains(event_message, '%s')", escapeLiteral(regexp.QuoteMeta(params.Search)))
+	}
+	return sql + fmt.Sprintf(" order by timestamp desc limit %d", params.Limit), nil
+}
+
+func escapeLiteral(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+}
+
+func QueryLogs(ctx context.Context, projectRef, sql string, start, end time.Time) ([]map[string]any, error) {
+	resp, err := utils.GetSupabase().V1GetProjectLogsWithResponse(ctx, projectRef, &api.V1GetProjectLogsParams{
+		Sql:               &sql,
+		IsoTimestampStart: &start,
+		IsoTimestampEnd:   &end,
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to query logs: %w", err)
+	} else if resp.JSON200 == nil {
+		return nil, errors.Errorf("unexpected logs query status %d: %s", resp.StatusCode(), string(resp.Body))
+	} else if e := resp.JSON200.Error; e != nil && e.Message != nil {
+		return nil, errors.Errorf("failed to query logs: %s", *e.Message)
+	}
+	if resp.JSON200.Result == nil {
+		return nil, nil
+	}
+	return *resp.JSON200.Result, nil
+}
+
+func PrintLogs(rows []map[string]any, format string) error {
+	switch format {
+	case utils.OutputPretty:
+		if len(rows) == 0 {
+			fmt.Fprintln(os.Stderr, "No logs found.")
+			return nil
+		}
+		return list.RenderTable(toMarkdown(rows))
+	case utils.OutputCsv:
+		return writeCsv(rows, os.Stdout)
+	}
+	return utils.EncodeRows(format, os.Stdout, rows)
+}
+
+// Lists the union of all row keys, with timestamp first for readability.
+func columns(rows []map[string]any) []string {
+	var result []string
+	for _, r := range rows {
+		for k := range r {
+			if !slices.Contains(result, k) {
+				result = append(result, k)
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i] == "timestamp" || result[j] == "timestamp" {
+			return result[i] == "timestamp"
+		}
+		return result[i] < result[j]
+	})
+	return result
+}
+
+func toRecord(r map[string]any, cols []string) []string {
+	record := make([]string, len(cols))
+	for i, c := range cols {
+		if v, ok := r[c]; ok && v != nil {
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return record
+}
+
+func toMarkdown(rows []map[string]any) string {
+	cols := columns(rows)
+	table := "|" + strings.Join(cols, "|") + "|\n|" + strings.Repeat("-|", len(cols)) + "\n"
+	for _, r := range rows {
+		record := toRecord(r, cols)
+		for i, v := range record {
+			record[i] = strings.ReplaceAll(v, "|", `\|`)
+		}
+		table += "|`" + strings.Join(record, "`|`") + "`|\n"
+	}
+	return table
+}
+
+func writeCsv(rows []map[string]any, w io.Writer) error {
+	cols := columns(rows)
+	enc := csv.NewWriter(w)
+	if err := enc.Write(cols); err != nil {
+		return errors.Errorf("failed to write csv: %w", err)
+	}
+	for _, r := range rows {
+		if err := enc.Write(toRecord(r, cols)); err != nil {
+			return errors.Errorf("failed to write csv: %w", err)
+		}
+	}
+	enc.Flush()
+	if err := enc.Error(); err != nil {
+		return errors.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestBuildQuery(t *testing.T) {
+	t.Run("uses raw sql", func(t *testing.T) {
+		sql, err := BuildQuery(QueryParams{Service: "invalid", Sql: "select 1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "select 1", sql)
+	})
+
+	t.Run("lists recent logs of service", func(t *testing.T) {
+		sql, err := BuildQuery(QueryParams{Service: ServiceDb, Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, "select id, cast(timestamp as datetime) as timestamp, event_message from postgres_logs order by timestamp desc limit 10", sql)
+	})
+
+	t.Run("escapes search term", func(t *testing.T) {
+		sql, err := BuildQuery(QueryParams{Service: ServiceApi, Search: "it's 5.0", Limit: 1})
+		assert.NoError(t, err)
+		assert.Contains(t, sql, `from edge_logs where regexp_contains(event_message, 'it\'s 5\\.0') order by`)
+	})
+
+	t.Run("throws error on unknown service", func(t *testing.T) {
+		_, err := BuildQuery(QueryParams{Service: "cron"})
+		assert.ErrorContains(t, err, `unknown log service "cron"`)
+	})
+}
+
+func TestQueryLogs(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	end := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	start := end.Add(-time.Hour)
+
+	t.Run("queries log analytics", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+project+"/analytics/endpoints/logs.all").
+			MatchParam("sql", "select 1").
+			MatchParam("iso_timestamp_start", "2024-01-01T23:00:00Z").
+			MatchParam("iso_timestamp_end", "2024-01-02T00:00:00Z").
+			Reply(http.StatusOK).
+			JSON(api.V1AnalyticsResponse{Result: &[]map[string]interface{}{{
+				"event_message": "hello",
+			}}})
+		// Run test
+		rows, err := QueryLogs(context.Background(), project, "select 1", start, end)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]any{{"event_message": "hello"}}, rows)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/analytics/endpoints/logs.all").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"error": map[string]any{"message": "Table not found: invalid_logs"}})
+		// Run test
+		_, err := QueryLogs(context.Background(), project, "select 1 from invalid_logs", start, end)
+		// Check error
+		assert.ErrorContains(t, err, "failed to query logs: Table not found: invalid_logs")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on network failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/analytics/endpoints/logs.all").
+			ReplyError(errors.New("network error"))
+		// Run test
+		_, err := QueryLogs(context.Background(), project, "select 1", start, end)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on range too large", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), project, QueryParams{
+			Sql:   "select 1",
+			Start: end.Add(-48 * time.Hour),
+			End:   end,
+		}, utils.OutputJson)
+		// Check error
+		assert.ErrorContains(t, err, "time range must not exceed 24h0m0s")
+	})
+}
+
+func TestWriteCsv(t *testing.T) {
+	rows := []map[string]any{
+		{"event_message": "a,b", "timestamp": "2024-01-01T00:00:00"},
+		{"id": "1"},
+	}
+	var out bytes.Buffer
+	assert.NoError(t, writeCsv(rows, &out))
+	assert.Equal(t, `timestamp,event_message,id
+2024-01-01T00:00:00,"a,b",
+,,1
+`, out.String())
+}
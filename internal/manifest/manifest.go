@@ -0,0 +1,270 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/go-git/go-git/v5"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/deploy"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+type Manifest struct {
+	GitCommit  string          `json:"git_commit,omitempty"`
+	Config     ConfigSummary   `json:"config"`
+	Migrations []MigrationInfo `json:"migrations"`
+	Functions  []FunctionInfo  `json:"functions"`
+	Buckets    []BucketInfo    `json:"buckets"`
+	Types      TypesInfo       `json:"types"`
+}
+
+type ConfigSummary struct {
+	ProjectId      string   `json:"project_id"`
+	Hash           string   `json:"hash"`
+	DbMajorVersion uint     `json:"db_major_version"`
+	ApiSchemas     []string `json:"api_schemas"`
+	Services       []string `json:"services"`
+}
+
+type MigrationInfo struct {
+	Version string `json:"version"`
+	Name    string `json:"name"`
+	Hash    string `json:"hash"`
+}
+
+type FunctionInfo struct {
+	Slug      string `json:"slug"`
+	VerifyJWT bool   `json:"verify_jwt"`
+	// Digest of local source files, which is not comparable with the deployed eszip bundle
+	SourceHash string `json:"source_hash"`
+}
+
+type BucketInfo struct {
+	Name             string   `json:"name"`
+	Public           bool     `json:"public"`
+	FileSizeLimit    int64    `json:"file_size_limit,omitempty"`
+	AllowedMimeTypes []string `json:"allowed_mime_types,omitempty"`
+}
+
+type TypesInfo struct {
+	// Image of postgres-meta used by gen types, which determines the generated output
+	Generator string `json:"generator"`
+}
+
+func Run(fsys afero.Fs) error {
+	m, err := Build(fsys)
+	if err != nil {
+		return err
+	}
+	m.GitCommit = gitCommit()
+	return utils.EncodeOutput(utils.OutputJson, os.Stdout, m)
+}
+
+func Build(fsys afero.Fs) (Manifest, error) {
+	var m Manifest
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return m, err
+	}
+	var err error
+	if m.Config, err = summariseConfig(fsys); err != nil {
+		return m, err
+	}
+	if m.Migrations, err = listMigrations(fsys); err != nil {
+		return m, err
+	}
+	if m.Functions, err = listFunctions(fsys); err != nil {
+		return m, err
+	}
+	m.Buckets = listBuckets()
+	m.Types.Generator = utils.Config.Studio.PgmetaImage
+	return m, nil
+}
+
+func summariseConfig(fsys afero.Fs) (ConfigSummary, error) {
+	hash, err := hashFile(utils.ConfigPath, fsys)
+	if err != nil {
+		return ConfigSummary{}, err
+	}
+	summary := ConfigSummary{
+		ProjectId:      utils.Config.ProjectId,
+		Hash:           hash,
+		DbMajorVersion: utils.Config.Db.MajorVersion,
+		ApiSchemas:     append([]string{}, utils.Config.Api.Schemas...),
+		Services:       []string{},
+	}
+	enabled := []struct {
+		name string
+		ok   bool
+	}{
+		{"api", utils.Config.Api.Enabled},
+		{"auth", utils.Config.Auth.Enabled},
+		{"storage", utils.Config.Storage.Enabled},
+		{"realtime", utils.Config.Realtime.Enabled},
+		{"edge_runtime", utils.Config.EdgeRuntime.Enabled},
+		{"analytics", utils.Config.Analytics.Enabled},
+		{"studio", utils.Config.Studio.Enabled},
+		{"inbucket", utils.Config.Inbucket.Enabled},
+	}
+	for _, s := range enabled {
+		if s.ok {
+			summary.Services = append(summary.Services, s.name)
+		}
+	}
+	return summary, nil
+}
+
+func listMigrations(fsys afero.Fs) ([]MigrationInfo, error) {
+	paths, err := migration.ListLocalMigrations(utils.MigrationsDir, afero.NewIOFS(fsys))
+	if err != nil {
+		return nil, err
+	}
+	result := []MigrationInfo{}
+	for _, p := range paths {
+		hash, err := hashFile(p, fsys)
+		if err != nil {
+			return nil, err
+		}
+		version, name, _ := strings.Cut(strings.TrimSuffix(filepath.Base(p), ".sql"), "_")
+		result = append(result, MigrationInfo{Version: version, Name: name, Hash: hash})
+	}
+	return result, nil
+}
+
+func listFunctions(fsys afero.Fs) ([]FunctionInfo, error) {
+	slugs, err := deploy.GetFunctionSlugs(fsys)
+	if err != nil {
+		return nil, err
+	}
+	slugs = utils.RemoveDuplicates(slugs)
+	sort.Strings(slugs)
+	functionConfig, err := deploy.GetFunctionConfig(slugs, "", nil, fsys)
+	if err != nil {
+		return nil, err
+	}
+	result := []FunctionInfo{}
+	for _, slug := range slugs {
+		fc := functionConfig[slug]
+		if !fc.IsEnabled() {
+			continue
+		}
+		staticFiles, err := deploy.GetStaticFiles(fc.StaticFiles, fsys)
+		if err != nil {
+			return nil, err
+		}
+		inputs := append([]string{fc.ImportMap}, staticFiles...)
+		var importMap *utils.ImportMap
+		if len(fc.ImportMap) > 0 {
+			// Deno config files without import map are ignored
+			if m, err := utils.NewImportMap(fc.ImportMap, fsys); err == nil {
+				importMap = m
+			}
+		}
+		// Include shared modules imported from outside the function directory
+		for _, path := range utils.ListLocalModules(fc.Entrypoint, importMap, fsys) {
+			if exists, _ := afero.Exists(fsys, path); exists {
+				inputs = append(inputs, path)
+			}
+		}
+		hash, err := hashSources(filepath.Dir(fc.Entrypoint), inputs, fsys)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, FunctionInfo{
+			Slug:       slug,
+			VerifyJWT:  fc.VerifyJWT == nil || *fc.VerifyJWT,
+			SourceHash: hash,
+		})
+	}
+	return result, nil
+}
+
+func listBuckets() []BucketInfo {
+	result := []BucketInfo{}
+	for name, b := range utils.Config.Storage.Buckets {
+		result = append(result, BucketInfo{
+			Name:             name,
+			Public:           b.Public != nil && *b.Public,
+			FileSizeLimit:    int64(b.FileSizeLimit),
+			AllowedMimeTypes: b.AllowedMimeTypes,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Hashes the sorted paths and contents of all files under dir plus any extra files,
+// so that the digest changes whenever a file is added, renamed, or modified.
+func hashSources(dir string, extra []string, fsys afero.Fs) (string, error) {
+	files := map[string]struct{}{}
+	if err := afero.Walk(fsys, dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			files[path] = struct{}{}
+		}
+		return nil
+	}); err != nil {
+		return "", errors.Errorf("failed to walk function directory: %w", err)
+	}
+	for _, path := range extra {
+		if len(path) > 0 {
+			files[path] = struct{}{}
+		}
+	}
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	digest := sha256.New()
+	for _, path := range paths {
+		hash, err := hashFile(path, fsys)
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(utils.SupabaseDirPath, path)
+		if err != nil {
+			rel = path
+		}
+		io.WriteString(digest, filepath.ToSlash(rel)+"\x00"+hash+"\n")
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+func hashFile(path string, fsys afero.Fs) (string, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return "", errors.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+	digest := sha256.New()
+	if _, err := io.Copy(digest, f); err != nil {
+		return "", errors.Errorf("failed to hash file: %w", err)
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// Returns the commit checked out in the current repository, if any.
+func gitCommit() string {
+	opts := &git.PlainOpenOptions{DetectDotGit: true}
+	repo, err := git.PlainOpenWithOptions(".", opts)
+	if err != nil {
+		return ""
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return ""
+	}
+	return head.Hash().String()
+}
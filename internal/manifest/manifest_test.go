@@ -0,0 +1,109 @@
+package manifest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const testConfig = `project_id = "test"
+
+[storage.buckets.images]
+public = true
+file_size_limit = "1MiB"
+allowed_mime_types = ["image/png"]
+
+[storage.buckets.docs]
+
+[functions.hello]
+verify_jwt = false
+static_files = ["./functions/hello/*.html"]
+`
+
+func setupProject(t *testing.T) afero.Fs {
+	fsys := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(testConfig), 0644))
+	migrations := map[string]string{
+		"20240101000000_create_users.sql": "create table users();",
+		"20240102000000_seed.sql":         "insert into users default values;",
+	}
+	for name, sql := range migrations {
+		path := filepath.Join(utils.MigrationsDir, name)
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(sql), 0644))
+	}
+	functionDir := filepath.Join(utils.FunctionsDir, "hello")
+	require.NoError(t, afero.WriteFile(fsys, filepath.Join(functionDir, "index.ts"), []byte("Deno.serve()"), 0644))
+	require.NoError(t, afero.WriteFile(fsys, filepath.Join(functionDir, "page.html"), []byte("<html/>"), 0644))
+	return fsys
+}
+
+func TestBuildManifest(t *testing.T) {
+	t.Run("describes local project", func(t *testing.T) {
+		fsys := setupProject(t)
+		// Run test
+		m, err := Build(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "test", m.Config.ProjectId)
+		assert.Len(t, m.Config.Hash, 64)
+		assert.Contains(t, m.Config.Services, "storage")
+		assert.Equal(t, []MigrationInfo{{
+			Version: "20240101000000",
+			Name:    "create_users",
+			Hash:    "5a2546b5b64e66962442b8823294fbfb7e21cb40a3d3b11f839e464a8fde2370",
+		}, {
+			Version: "20240102000000",
+			Name:    "seed",
+			Hash:    "eed2640ff70343cb56310afb0f8ae561b0e9d26bfa4a8e594d6fcdb249018835",
+		}}, m.Migrations)
+		require.Len(t, m.Functions, 1)
+		assert.Equal(t, "hello", m.Functions[0].Slug)
+		assert.False(t, m.Functions[0].VerifyJWT)
+		assert.Equal(t, []BucketInfo{
+			{Name: "docs", FileSizeLimit: 50 << 20},
+			{Name: "images", Public: true, FileSizeLimit: 1 << 20, AllowedMimeTypes: []string{"image/png"}},
+		}, m.Buckets)
+		assert.NotEmpty(t, m.Types.Generator)
+	})
+
+	t.Run("source hash changes with function files", func(t *testing.T) {
+		fsys := setupProject(t)
+		before, err := Build(fsys)
+		require.NoError(t, err)
+		// Modify static file
+		path := filepath.Join(utils.FunctionsDir, "hello", "page.html")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("<html></html>"), 0644))
+		// Run test
+		after, err := Build(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.NotEqual(t, before.Functions[0].SourceHash, after.Functions[0].SourceHash)
+		assert.Equal(t, before.Migrations, after.Migrations)
+	})
+
+	t.Run("source hash changes with shared imports", func(t *testing.T) {
+		fsys := setupProject(t)
+		entrypoint := filepath.Join(utils.FunctionsDir, "hello", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte(`import "../_shared/cors.ts"`), 0644))
+		shared := filepath.Join(utils.FunctionsDir, "_shared", "cors.ts")
+		require.NoError(t, afero.WriteFile(fsys, shared, []byte("export {}"), 0644))
+		before, err := Build(fsys)
+		require.NoError(t, err)
+		// Modify shared module
+		require.NoError(t, afero.WriteFile(fsys, shared, []byte("export const cors = {}"), 0644))
+		// Run test
+		after, err := Build(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.NotEqual(t, before.Functions[0].SourceHash, after.Functions[0].SourceHash)
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		_, err := Build(afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "cannot read config")
+	})
+}
@@ -0,0 +1,108 @@
+// Package metrics exports counters and durations of long running operations, such as
+// storage transfers and migrations, so that scheduled jobs can be monitored. Nothing is
+// recorded unless an OpenTelemetry or StatsD endpoint is configured.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Prefix of all metric names, ie. supabase.storage.objects
+const namespace = "supabase."
+
+type sink interface {
+	add(ctx context.Context, name string, value int64, attrs []attribute.KeyValue)
+	duration(ctx context.Context, name string, d time.Duration, attrs []attribute.KeyValue)
+	shutdown(ctx context.Context) error
+}
+
+var (
+	sinks  []sink
+	common []attribute.KeyValue
+	mu     sync.RWMutex
+)
+
+// Setup starts exporting to the endpoints set by --otel-endpoint, --statsd-addr, or the
+// standard OTEL_EXPORTER_OTLP_ENDPOINT variables. Metrics are tagged with the command.
+func Setup(ctx context.Context, command string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	common = []attribute.KeyValue{attribute.String("command", command)}
+	if endpoint := viper.GetString("otel-endpoint"); len(endpoint) > 0 || hasOtelEnv() {
+		s, err := newOtelSink(ctx, endpoint)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, s)
+	}
+	if addr := viper.GetString("statsd-addr"); len(addr) > 0 {
+		s, err := newStatsdSink(addr)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, s)
+	}
+	return nil
+}
+
+func hasOtelEnv() bool {
+	return len(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")) > 0 || len(os.Getenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT")) > 0
+}
+
+// Shutdown flushes pending metrics, giving up after a few seconds so that an unreachable
+// collector does not delay the exit of the CLI.
+func Shutdown() {
+	mu.Lock()
+	defer mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, s := range sinks {
+		if err := s.shutdown(ctx); err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), "failed to flush metrics:", err)
+		}
+	}
+	sinks = nil
+}
+
+// Add increments the counter with the given name.
+func Add(ctx context.Context, name string, value int64, attrs ...attribute.KeyValue) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if len(sinks) == 0 {
+		return
+	}
+	attrs = append(attrs, common...)
+	for _, s := range sinks {
+		s.add(ctx, namespace+name, value, attrs)
+	}
+}
+
+// Duration records the time taken by an operation started at start.
+func Duration(ctx context.Context, name string, start time.Time, attrs ...attribute.KeyValue) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if len(sinks) == 0 {
+		return
+	}
+	attrs = append(attrs, common...)
+	d := time.Since(start)
+	for _, s := range sinks {
+		s.duration(ctx, namespace+name, d, attrs)
+	}
+}
+
+// Status tags operations by whether they succeeded.
+func Status(err error) attribute.KeyValue {
+	if err != nil {
+		return attribute.String("status", "error")
+	}
+	return attribute.String("status", "ok")
+}
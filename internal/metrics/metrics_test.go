@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestStatsdSink(t *testing.T) {
+	t.Run("sends counters and durations", func(t *testing.T) {
+		// Setup statsd listener
+		listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+		viper.Set("statsd-addr", listener.LocalAddr().String())
+		t.Cleanup(viper.Reset)
+		require.NoError(t, Setup(context.Background(), "supabase storage cp"))
+		defer Shutdown()
+		// Run test
+		Add(context.Background(), "storage.objects", 3, attribute.String("direction", "upload"))
+		Duration(context.Background(), "db.push.duration", time.Now().Add(-time.Second), Status(nil))
+		// Check packets
+		assert.Equal(t, "supabase.storage.objects:3|c|#direction:upload,command:supabase storage cp", readPacket(t, listener))
+		assert.Regexp(t, `^supabase\.db\.push\.duration:1\d{3}\|ms\|#status:ok,command:supabase storage cp$`, readPacket(t, listener))
+	})
+
+	t.Run("escapes reserved characters", func(t *testing.T) {
+		assert.Equal(t, "url:https_//example.com,a_b:c_d", formatTags([]attribute.KeyValue{
+			attribute.String("url", "https://example.com"),
+			attribute.String("a|b", "c,d"),
+		}))
+	})
+}
+
+func TestNoSink(t *testing.T) {
+	require.NoError(t, Setup(context.Background(), "supabase db push"))
+	defer Shutdown()
+	// Does not panic without any sinks
+	Add(context.Background(), "db.push.migrations", 1)
+	Duration(context.Background(), "db.push.duration", time.Now(), Status(nil))
+	assert.Empty(t, sinks)
+}
+
+func readPacket(t *testing.T, conn net.PacketConn) string {
+	buf := make([]byte, 1024)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	return string(buf[:n])
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+type otelSink struct {
+	provider   *sdkmetric.MeterProvider
+	meter      metric.Meter
+	mu         sync.Mutex
+	counters   map[string]metric.Int64Counter
+	histograms map[string]metric.Float64Histogram
+}
+
+// Endpoints without a scheme use TLS, while the exporter reads the remaining settings,
+// such as headers, from the standard OTEL_EXPORTER_OTLP_* variables.
+func newOtelSink(ctx context.Context, endpoint string) (*otelSink, error) {
+	var opts []otlpmetricgrpc.Option
+	if strings.Contains(endpoint, "://") {
+		opts = append(opts, otlpmetricgrpc.WithEndpointURL(endpoint))
+	} else if len(endpoint) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithEndpoint(endpoint))
+	}
+	exporter, err := otlpmetricgrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, errors.Errorf("failed to create metrics exporter: %w", err)
+	}
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName("supabase-cli"),
+		semconv.ServiceVersion(utils.Version),
+	)
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
+	)
+	return &otelSink{
+		provider:   provider,
+		meter:      provider.Meter("github.com/supabase/cli"),
+		counters:   map[string]metric.Int64Counter{},
+		histograms: map[string]metric.Float64Histogram{},
+	}, nil
+}
+
+func (s *otelSink) add(ctx context.Context, name string, value int64, attrs []attribute.KeyValue) {
+	s.mu.Lock()
+	counter, ok := s.counters[name]
+	if !ok {
+		var err error
+		if counter, err = s.meter.Int64Counter(name); err != nil {
+			s.mu.Unlock()
+			return
+		}
+		s.counters[name] = counter
+	}
+	s.mu.Unlock()
+	counter.Add(ctx, value, metric.WithAttributes(attrs...))
+}
+
+func (s *otelSink) duration(ctx context.Context, name string, d time.Duration, attrs []attribute.KeyValue) {
+	s.mu.Lock()
+	histogram, ok := s.histograms[name]
+	if !ok {
+		var err error
+		if histogram, err = s.meter.Float64Histogram(name, metric.WithUnit("s")); err != nil {
+			s.mu.Unlock()
+			return
+		}
+		s.histograms[name] = histogram
+	}
+	s.mu.Unlock()
+	histogram.Record(ctx, d.Seconds(), metric.WithAttributes(attrs...))
+}
+
+func (s *otelSink) shutdown(ctx context.Context) error {
+	return s.provider.Shutdown(ctx)
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Replaces characters that are reserved by the StatsD line protocol.
+var statsdReplacer = strings.NewReplacer(":", "_", "|", "_", "@", "_", ",", "_", "#", "_")
+
+// Sends each metric as a separate UDP packet in the DogStatsD format, which is also
+// understood by the Telegraf and Grafana Agent StatsD receivers.
+type statsdSink struct {
+	conn io.WriteCloser
+}
+
+func newStatsdSink(addr string) (*statsdSink, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, errors.Errorf("failed to connect to statsd: %w", err)
+	}
+	return &statsdSink{conn: conn}, nil
+}
+
+func (s *statsdSink) add(ctx context.Context, name string, value int64, attrs []attribute.KeyValue) {
+	s.send(fmt.Sprintf("%s:%d|c", statsdReplacer.Replace(name), value), attrs)
+}
+
+func (s *statsdSink) duration(ctx context.Context, name string, d time.Duration, attrs []attribute.KeyValue) {
+	s.send(fmt.Sprintf("%s:%d|ms", statsdReplacer.Replace(name), d.Milliseconds()), attrs)
+}
+
+// Metrics are best effort so write errors, ie. no listener on the port, are ignored.
+func (s *statsdSink) send(line string, attrs []attribute.KeyValue) {
+	if tags := formatTags(attrs); len(tags) > 0 {
+		line += "|#" + tags
+	}
+	_, _ = s.conn.Write([]byte(line))
+}
+
+func formatTags(attrs []attribute.KeyValue) string {
+	tags := make([]string, len(attrs))
+	for i, kv := range attrs {
+		tags[i] = statsdReplacer.Replace(string(kv.Key)) + ":" + statsdReplacer.Replace(kv.Value.Emit())
+	}
+	return strings.Join(tags, ",")
+}
+
+func (s *statsdSink) shutdown(ctx context.Context) error {
+	return s.conn.Close()
+}
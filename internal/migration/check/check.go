@@ -0,0 +1,198 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/parser"
+)
+
+const (
+	IssueDuplicateVersion = "duplicate_version"
+	IssueOutOfOrder       = "out_of_order"
+	IssueMissingLocal     = "missing_local"
+	IssueManagedSchema    = "managed_schema"
+)
+
+type Issue struct {
+	Kind      string `json:"kind"`
+	Migration string `json:"migration"`
+	Detail    string `json:"detail"`
+}
+
+var (
+	migrateFilePattern = regexp.MustCompile(`^([0-9]+)_(.*)\.sql$`)
+	// Schemas owned by Supabase services. Policies, triggers, and grants on
+	// these objects are allowed, but creating or altering them is not.
+	managedSchemas = []string{
+		"_analytics",
+		"_realtime",
+		"_supavisor",
+		"auth",
+		"graphql",
+		"graphql_public",
+		"net",
+		"pgbouncer",
+		"pgsodium",
+		"realtime",
+		"storage",
+		"supabase_functions",
+		"supabase_migrations",
+		"vault",
+	}
+	ddlPattern = regexp.MustCompile(`(?is)^\s*(?:create(?:\s+or\s+replace)?|alter|drop)\s+` +
+		`(?:(schema)|table|view|materialized\s+view|function|procedure|type|sequence|domain)\s+` +
+		`(?:if\s+(?:not\s+)?exists\s+)?"?(\w+)"?(\.|\s|;|$)`)
+)
+
+func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	local, issues, err := checkLocal(fsys)
+	if err != nil {
+		return err
+	}
+	// Remote history is only checked when a database is specified
+	if len(config.Host) > 0 {
+		conn, err := utils.ConnectByConfig(ctx, config, options...)
+		if err != nil {
+			return err
+		}
+		defer conn.Close(context.Background())
+		remote, err := migration.ListRemoteMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		issues = append(issues, CheckHistory(local, remote)...)
+	}
+	if len(issues) == 0 {
+		fmt.Fprintln(os.Stderr, "No migration issues found.")
+		return nil
+	}
+	table := "|KIND|MIGRATION|DETAIL|\n|-|-|-|\n"
+	for _, i := range issues {
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", i.Kind, i.Migration, strings.ReplaceAll(i.Detail, "|", `\|`))
+	}
+	if err := list.RenderTable(table); err != nil {
+		return err
+	}
+	return errors.Errorf("Found %d migration issue(s).", len(issues))
+}
+
+// Returns local versions in order along with issues found in migration files.
+func checkLocal(fsys afero.Fs) ([]string, []Issue, error) {
+	entries, err := afero.ReadDir(fsys, utils.MigrationsDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, errors.Errorf("failed to read directory: %w", err)
+	}
+	var versions []string
+	var issues []Issue
+	seen := map[string]string{}
+	for _, e := range entries {
+		matches := migrateFilePattern.FindStringSubmatch(e.Name())
+		if e.IsDir() || len(matches) == 0 {
+			continue
+		}
+		if prev, ok := seen[matches[1]]; ok {
+			issues = append(issues, Issue{
+				Kind:      IssueDuplicateVersion,
+				Migration: e.Name(),
+				Detail:    "shares version with " + prev,
+			})
+		} else {
+			seen[matches[1]] = e.Name()
+			versions = append(versions, matches[1])
+		}
+		path := filepath.Join(utils.MigrationsDir, e.Name())
+		found, err := CheckManagedSchemas(path, afero.NewIOFS(fsys))
+		if err != nil {
+			return nil, nil, err
+		}
+		issues = append(issues, found...)
+	}
+	return versions, issues, nil
+}
+
+// Reports local migrations that sort before the latest remote version without
+// having been applied, and remote versions that are missing locally.
+func CheckHistory(local, remote []string) []Issue {
+	var issues []Issue
+	applied := map[string]bool{}
+	var latest string
+	for _, v := range remote {
+		applied[v] = true
+		latest = max(latest, v)
+	}
+	found := map[string]bool{}
+	for _, v := range local {
+		found[v] = true
+		if !applied[v] && v < latest {
+			issues = append(issues, Issue{
+				Kind:      IssueOutOfOrder,
+				Migration: v,
+				Detail:    "older than latest remote version " + latest,
+			})
+		}
+	}
+	for _, v := range remote {
+		if !found[v] {
+			issues = append(issues, Issue{
+				Kind:      IssueMissingLocal,
+				Migration: v,
+				Detail:    "applied on remote but not found locally",
+			})
+		}
+	}
+	return issues
+}
+
+// Reports statements that create, alter, or drop objects in managed schemas.
+func CheckManagedSchemas(path string, fsys fs.FS) ([]Issue, error) {
+	sql, err := fsys.Open(path)
+	if err != nil {
+		return nil, errors.Errorf("failed to open migration file: %w", err)
+	}
+	defer sql.Close()
+	stats, err := parser.Split(sql)
+	if err != nil {
+		return nil, err
+	}
+	var issues []Issue
+	for _, s := range stats {
+		matches := ddlPattern.FindStringSubmatch(stripComments(s))
+		// Unqualified names are only schemas when the statement targets a schema
+		if len(matches) < 4 || (len(matches[1]) == 0 && matches[3] != ".") {
+			continue
+		}
+		if utils.SliceContains(managedSchemas, strings.ToLower(matches[2])) {
+			issues = append(issues, Issue{
+				Kind:      IssueManagedSchema,
+				Migration: filepath.Base(path),
+				Detail:    firstLine(s),
+			})
+		}
+	}
+	return issues, nil
+}
+
+var commentPattern = regexp.MustCompile(`(?m)^\s*--.*$`)
+
+func stripComments(stat string) string {
+	return commentPattern.ReplaceAllString(stat, "")
+}
+
+func firstLine(stat string) string {
+	stat = strings.TrimSpace(stripComments(stat))
+	line, _, _ := strings.Cut(stat, "\n")
+	return strings.TrimSpace(line)
+}
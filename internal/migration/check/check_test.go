@@ -0,0 +1,115 @@
+package check
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func writeMigration(t *testing.T, fsys afero.Fs, name, sql string) {
+	path := filepath.Join(utils.MigrationsDir, name)
+	require.NoError(t, afero.WriteFile(fsys, path, []byte(sql), 0644))
+}
+
+func TestMigrationCheck(t *testing.T) {
+	t.Run("passes on clean migrations", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		writeMigration(t, fsys, "20240101000000_init.sql", "create table public.todos (id bigint);")
+		writeMigration(t, fsys, "20240102000000_policy.sql", "create policy read on storage.objects for select using (true);")
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 1", []interface{}{"20240101000000"})
+		// Run test
+		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on duplicate version", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		writeMigration(t, fsys, "20240101000000_a.sql", "select 1;")
+		writeMigration(t, fsys, "20240101000000_b.sql", "select 2;")
+		// Run test
+		err := Run(context.Background(), pgconn.Config{}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Found 1 migration issue(s).")
+	})
+
+	t.Run("throws error on out of order migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		writeMigration(t, fsys, "20240101000000_a.sql", "select 1;")
+		writeMigration(t, fsys, "20240102000000_b.sql", "select 2;")
+		writeMigration(t, fsys, "20240103000000_c.sql", "select 3;")
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 2", []interface{}{"20240101000000"}, []interface{}{"20240103000000"})
+		// Run test
+		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "Found 1 migration issue(s).")
+	})
+}
+
+func TestCheckHistory(t *testing.T) {
+	issues := CheckHistory(
+		[]string{"20240101000000", "20240102000000", "20240104000000"},
+		[]string{"20240101000000", "20240103000000"},
+	)
+	assert.Equal(t, []Issue{{
+		Kind:      IssueOutOfOrder,
+		Migration: "20240102000000",
+		Detail:    "older than latest remote version 20240103000000",
+	}, {
+		Kind:      IssueMissingLocal,
+		Migration: "20240103000000",
+		Detail:    "applied on remote but not found locally",
+	}}, issues)
+}
+
+func TestCheckManagedSchemas(t *testing.T) {
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	writeMigration(t, fsys, "20240101000000_auth.sql", `-- tweak auth
+alter table auth.users add column nickname text;
+create table "storage".extra (id int);
+create trigger on_signup after insert on auth.users for each row execute function public.handle();
+create table auth (id int);
+drop schema if exists realtime;
+create or replace function public.handle() returns trigger as $$ begin return new; end $$ language plpgsql;`)
+	// Run test
+	issues, err := CheckManagedSchemas(filepath.Join(utils.MigrationsDir, "20240101000000_auth.sql"), afero.NewIOFS(fsys))
+	// Check error
+	assert.NoError(t, err)
+	var details []string
+	for _, i := range issues {
+		details = append(details, i.Detail)
+	}
+	assert.Equal(t, []string{
+		"alter table auth.users add column nickname text;",
+		`create table "storage".extra (id int);`,
+		"drop schema if exists realtime;",
+	}, details)
+}
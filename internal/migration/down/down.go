@@ -0,0 +1,102 @@
+package down
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+// Reverts the last count applied migrations, or all migrations after targetVersion if it is
+// not empty. Each migration must define a down section in its local file.
+func Run(ctx context.Context, count uint, targetVersion string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	remote, err := migration.ListRemoteMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+	versions, err := selectVersions(remote, count, targetVersion)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		fmt.Fprintln(os.Stderr, "No migrations to revert.")
+		return nil
+	}
+	files, err := loadDownMigrations(versions, fsys)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		fmt.Fprintf(os.Stderr, "Reverting migration %s_%s.sql...\n", file.Version, file.Name)
+		if err := file.ExecDownBatch(ctx, conn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns applied versions to revert, starting from the latest.
+func selectVersions(remote []string, count uint, targetVersion string) ([]string, error) {
+	var result []string
+	if len(targetVersion) > 0 {
+		if !slices.Contains(remote, targetVersion) {
+			return nil, errors.Errorf("Migration version not found in migration history: %s", targetVersion)
+		}
+		for _, version := range remote {
+			if version > targetVersion {
+				result = append(result, version)
+			}
+		}
+	} else {
+		start := max(len(remote)-int(count), 0)
+		result = append(result, remote[start:]...)
+	}
+	slices.Reverse(result)
+	return result, nil
+}
+
+// Parses all files before reverting any, so that a missing down section fails early.
+func loadDownMigrations(versions []string, fsys afero.Fs) ([]*migration.MigrationFile, error) {
+	local, err := migration.ListLocalMigrations(utils.MigrationsDir, afero.NewIOFS(fsys), func(version string) bool {
+		return slices.Contains(versions, version)
+	})
+	if err != nil {
+		return nil, err
+	}
+	paths := map[string]string{}
+	for _, path := range local {
+		version, _, _ := strings.Cut(filepath.Base(path), "_")
+		paths[version] = path
+	}
+	var result []*migration.MigrationFile
+	for _, version := range versions {
+		path, ok := paths[version]
+		if !ok {
+			return nil, errors.Errorf("Migration version not found in local migrations directory: %s", version)
+		}
+		file, err := migration.NewMigrationFromFile(path, afero.NewIOFS(fsys))
+		if err != nil {
+			return nil, err
+		}
+		if len(file.DownStatements) == 0 {
+			utils.CmdSuggestion = fmt.Sprintf("Add a line with %s to %s, followed by statements that revert the migration.", utils.Aqua(migration.DownMarker), utils.Bold(path))
+			return nil, errors.Errorf("Migration has no down section: %s", filepath.Base(path))
+		}
+		result = append(result, file)
+	}
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package down
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestMigrationDown(t *testing.T) {
+	t.Run("reverts latest migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "0_schema.sql"), []byte("create schema a;\n-- migrate:down\ndrop schema a;"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "1_table.sql"), []byte("create table t ();\n-- migrate:down\ndrop table t;"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 2", []interface{}{"0"}, []interface{}{"1"}).
+			Query("drop table t").
+			Reply("DROP TABLE").
+			Query(migration.DELETE_MIGRATION_VERSION, []string{"1"}).
+			Reply("DELETE 1")
+		// Run test
+		err := Run(context.Background(), 1, "", dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on missing down section", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "0_schema.sql"), []byte("create schema a;"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 1", []interface{}{"0"})
+		// Run test
+		err := Run(context.Background(), 1, "", dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "Migration has no down section: 0_schema.sql")
+	})
+}
+
+func TestSelectVersions(t *testing.T) {
+	remote := []string{"0", "1", "2"}
+
+	t.Run("selects latest versions by count", func(t *testing.T) {
+		versions, err := selectVersions(remote, 2, "")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "1"}, versions)
+	})
+
+	t.Run("caps count at history length", func(t *testing.T) {
+		versions, err := selectVersions(remote, 5, "")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "1", "0"}, versions)
+	})
+
+	t.Run("selects versions after target", func(t *testing.T) {
+		versions, err := selectVersions(remote, 1, "0")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "1"}, versions)
+	})
+
+	t.Run("throws error on unknown target", func(t *testing.T) {
+		_, err := selectVersions(remote, 1, "9")
+		assert.ErrorContains(t, err, "Migration version not found in migration history: 9")
+	})
+}
@@ -3,6 +3,7 @@ package fetch
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -32,17 +33,38 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 	if err != nil {
 		return err
 	}
+	if len(result) == 0 {
+		fmt.Fprintln(os.Stderr, "Remote migration history is empty.")
+		return nil
+	}
+	var unrecorded []string
 	for _, r := range result {
 		name := fmt.Sprintf("%s_%s.sql", r.Version, r.Name)
 		path := filepath.Join(utils.MigrationsDir, name)
-		contents := strings.Join(r.Statements, ";\n") + ";\n"
-		if err := afero.WriteFile(fsys, path, []byte(contents), 0644); err != nil {
+		if len(r.Statements) == 0 {
+			unrecorded = append(unrecorded, r.Version)
+		}
+		if err := afero.WriteFile(fsys, path, []byte(formatStatements(r.Statements)), 0644); err != nil {
 			return errors.Errorf("failed to write migration: %w", err)
 		}
 	}
+	fmt.Fprintf(os.Stderr, "Fetched %d migrations to %s\n", len(result), utils.Bold(utils.MigrationsDir))
+	if len(unrecorded) > 0 {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Remote history has no statements for versions:", strings.Join(unrecorded, ", "))
+		utils.CmdSuggestion = fmt.Sprintf("These migrations were likely marked as applied by %s. Run %s to capture the remote schema instead.", utils.Aqua("supabase migration repair"), utils.Aqua("supabase db pull"))
+	}
 	return nil
 }
 
+// Migrations marked as applied by repair have no statements, so their files are left empty
+// rather than containing a stray semicolon.
+func formatStatements(statements []string) string {
+	if len(statements) == 0 {
+		return ""
+	}
+	return strings.Join(statements, ";\n") + ";\n"
+}
+
 func fetchMigrationHistory(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) ([]migration.MigrationFile, error) {
 	conn, err := utils.ConnectByConfig(ctx, config, options...)
 	if err != nil {
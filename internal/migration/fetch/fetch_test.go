@@ -0,0 +1,101 @@
+package fetch
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+type historyRow struct {
+	Version    string
+	Name       string
+	Statements []string
+}
+
+func TestFetchMigrations(t *testing.T) {
+	t.Run("writes remote history to files", func(t *testing.T) {
+		t.Cleanup(func() { utils.CmdSuggestion = "" })
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.SELECT_VERSION_TABLE).
+			Reply("SELECT 2",
+				historyRow{Version: "20240101000000", Name: "init", Statements: []string{"create schema test", "create table test.t (id int)"}},
+				historyRow{Version: "20240102000000", Name: "repaired", Statements: []string{}},
+			)
+		// Run test
+		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, filepath.Join(utils.MigrationsDir, "20240101000000_init.sql"))
+		assert.NoError(t, err)
+		assert.Equal(t, "create schema test;\ncreate table test.t (id int);\n", string(data))
+		data, err = afero.ReadFile(fsys, filepath.Join(utils.MigrationsDir, "20240102000000_repaired.sql"))
+		assert.NoError(t, err)
+		assert.Empty(t, data)
+		assert.Contains(t, utils.CmdSuggestion, "supabase db pull")
+	})
+
+	t.Run("ignores empty history", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.SELECT_VERSION_TABLE).
+			Reply("SELECT 0")
+		// Run test
+		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		empty, err := afero.IsEmpty(fsys, utils.MigrationsDir)
+		assert.NoError(t, err)
+		assert.True(t, empty)
+	})
+
+	t.Run("throws error on cancel", func(t *testing.T) {
+		t.Cleanup(fstest.MockStdin(t, "n"))
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
+		// Run test
+		err := Run(context.Background(), dbConfig, fsys)
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("throws error on missing table", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.SELECT_VERSION_TABLE).
+			ReplyError(pgerrcode.UndefinedTable, `relation "supabase_migrations.schema_migrations" does not exist`)
+		// Run test
+		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, `relation "supabase_migrations.schema_migrations" does not exist`)
+	})
+}
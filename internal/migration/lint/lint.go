@@ -0,0 +1,160 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+type Hazard struct {
+	File       string `json:"file"`
+	Statement  string `json:"statement"`
+	Reason     string `json:"reason"`
+	Suggestion string `json:"suggestion"`
+}
+
+type rule struct {
+	pattern *regexp.Regexp
+	// Statements matching exempt acquire weaker locks, ie. concurrently
+	exempt     *regexp.Regexp
+	reason     string
+	suggestion string
+}
+
+var rules = []rule{{
+	pattern:    regexp.MustCompile(`(?is)^\s*alter\s+table\b.*\balter\s+(?:column\s+)?\S+\s+(?:set\s+data\s+)?type\b`),
+	reason:     "ALTER COLUMN TYPE may rewrite the table under ACCESS EXCLUSIVE lock",
+	suggestion: "Add a new column, backfill it in batches, then swap reads and drop the old column.",
+}, {
+	pattern:    regexp.MustCompile(`(?is)^\s*alter\s+table\b.*\bset\s+not\s+null\b`),
+	reason:     "SET NOT NULL scans the table under ACCESS EXCLUSIVE lock",
+	suggestion: "Add a CHECK (column IS NOT NULL) NOT VALID constraint, validate it in a separate migration, then set NOT NULL.",
+}, {
+	pattern:    regexp.MustCompile(`(?is)^\s*alter\s+table\b.*\badd\s+(?:column\s+)?(?:if\s+not\s+exists\s+)?\S+\s+[^,]*\bnot\s+null\b`),
+	exempt:     regexp.MustCompile(`(?is)\bdefault\b|\badd\s+(?:constraint|check|foreign|unique|primary)\b`),
+	reason:     "adding a NOT NULL column without default fails on non-empty tables",
+	suggestion: "Add the column as nullable or with a constant default, backfill, then set NOT NULL.",
+}, {
+	pattern:    regexp.MustCompile(`(?is)^\s*alter\s+table\b.*\badd\s+(?:column\s+)?\S+\s+[^,]*\bdefault\s+(?:now|clock_timestamp|random|gen_random_uuid|uuid_generate_v4)\s*\(`),
+	reason:     "adding a column with a volatile default rewrites the table under ACCESS EXCLUSIVE lock",
+	suggestion: "Add the column without default, set the default separately, then backfill existing rows in batches.",
+}, {
+	pattern:    regexp.MustCompile(`(?is)^\s*alter\s+table\b.*\badd\s+(?:constraint\s+\S+\s+)?(?:foreign\s+key|check)\b`),
+	exempt:     regexp.MustCompile(`(?is)\bnot\s+valid\b`),
+	reason:     "ADD CONSTRAINT without NOT VALID validates all rows while holding a lock",
+	suggestion: "Add the constraint with NOT VALID, then run VALIDATE CONSTRAINT in a separate migration.",
+}, {
+	pattern:    regexp.MustCompile(`(?is)^\s*alter\s+table\b.*\badd\s+(?:constraint\s+\S+\s+)?(?:unique|primary\s+key)\b`),
+	exempt:     regexp.MustCompile(`(?is)\busing\s+index\b`),
+	reason:     "ADD UNIQUE or PRIMARY KEY builds an index under ACCESS EXCLUSIVE lock",
+	suggestion: "Create a unique index concurrently, then add the constraint with USING INDEX.",
+}, {
+	pattern:    regexp.MustCompile(`(?is)^\s*create\s+(?:unique\s+)?index\b`),
+	exempt:     regexp.MustCompile(`(?is)^\s*create\s+(?:unique\s+)?index\s+concurrently\b`),
+	reason:     "CREATE INDEX without CONCURRENTLY blocks writes",
+	suggestion: "Use CREATE INDEX CONCURRENTLY in its own migration file.",
+}, {
+	pattern:    regexp.MustCompile(`(?is)^\s*drop\s+index\b`),
+	exempt:     regexp.MustCompile(`(?is)^\s*drop\s+index\s+concurrently\b`),
+	reason:     "DROP INDEX without CONCURRENTLY holds ACCESS EXCLUSIVE lock",
+	suggestion: "Use DROP INDEX CONCURRENTLY in its own migration file.",
+}, {
+	pattern:    regexp.MustCompile(`(?is)^\s*(?:reindex|cluster|vacuum\s+full)\b`),
+	exempt:     regexp.MustCompile(`(?is)^\s*reindex\b.*\bconcurrently\b`),
+	reason:     "table maintenance holds ACCESS EXCLUSIVE lock",
+	suggestion: "Use REINDEX CONCURRENTLY, or pg_repack to reclaim space online.",
+}, {
+	pattern:    regexp.MustCompile(`(?is)^\s*refresh\s+materialized\s+view\b`),
+	exempt:     regexp.MustCompile(`(?is)^\s*refresh\s+materialized\s+view\s+concurrently\b`),
+	reason:     "REFRESH MATERIALIZED VIEW without CONCURRENTLY blocks reads",
+	suggestion: "Add a unique index on the view and use REFRESH MATERIALIZED VIEW CONCURRENTLY.",
+}, {
+	pattern:    regexp.MustCompile(`(?is)^\s*(?:drop\s+table|truncate|lock\s+table)\b`),
+	reason:     "statement holds ACCESS EXCLUSIVE lock",
+	suggestion: "Make sure no running application code references the table before deploying.",
+}}
+
+var commentPattern = regexp.MustCompile(`(?m)^\s*--.*$`)
+
+// Returns the reason and safer alternative if a statement takes a heavy lock
+// or rewrites a table, or empty strings if the statement is safe.
+func DetectHazard(stat string) (string, string) {
+	stat = commentPattern.ReplaceAllString(stat, "")
+	for _, r := range rules {
+		if r.pattern.MatchString(stat) && (r.exempt == nil || !r.exempt.MatchString(stat)) {
+			return r.reason, r.suggestion
+		}
+	}
+	return "", ""
+}
+
+func Run(files []string, failOnUnsafe bool, fsys afero.Fs) error {
+	if len(files) == 0 {
+		var err error
+		if files, err = migration.ListLocalMigrations(utils.MigrationsDir, afero.NewIOFS(fsys)); err != nil {
+			return err
+		}
+	}
+	var result []Hazard
+	for _, path := range files {
+		hazards, err := LintFile(path, fsys)
+		if err != nil {
+			return err
+		}
+		result = append(result, hazards...)
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		if err := utils.EncodeRows(utils.OutputFormat.Value, os.Stdout, result); err != nil {
+			return err
+		}
+	} else if len(result) == 0 {
+		fmt.Fprintln(os.Stderr, "No lock hazards found in", len(files), "migration(s).")
+	} else if err := list.RenderTable(toMarkdown(result)); err != nil {
+		return err
+	}
+	if failOnUnsafe && len(result) > 0 {
+		return errors.Errorf("Found %d unsafe statement(s) in migrations.", len(result))
+	}
+	return nil
+}
+
+func LintFile(path string, fsys afero.Fs) ([]Hazard, error) {
+	file, err := migration.NewMigrationFromFile(path, afero.NewIOFS(fsys))
+	if err != nil {
+		return nil, err
+	}
+	var result []Hazard
+	for _, stat := range file.Statements {
+		if reason, suggestion := DetectHazard(stat); len(reason) > 0 {
+			result = append(result, Hazard{
+				File:       filepath.Base(path),
+				Statement:  firstLine(stat),
+				Reason:     reason,
+				Suggestion: suggestion,
+			})
+		}
+	}
+	return result, nil
+}
+
+func firstLine(stat string) string {
+	stat = strings.TrimSpace(commentPattern.ReplaceAllString(stat, ""))
+	line, _, _ := strings.Cut(stat, "\n")
+	return strings.TrimSpace(line)
+}
+
+func toMarkdown(result []Hazard) string {
+	table := "|FILE|STATEMENT|HAZARD|SUGGESTION|\n|-|-|-|-|\n"
+	for _, h := range result {
+		table += fmt.Sprintf("|`%s`|`%s`|%s|%s|\n", h.File, strings.ReplaceAll(h.Statement, "|", `\|`), h.Reason, h.Suggestion)
+	}
+	return table
+}
@@ -0,0 +1,78 @@
+package lint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestMigrationLint(t *testing.T) {
+	t.Run("lints all local migrations", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create index on todos (title);"), 0644))
+		// Run test
+		err := Run(nil, false, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on unsafe statement", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create index on todos (title);"), 0644))
+		// Run test
+		err := Run([]string{path}, true, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Found 1 unsafe statement(s) in migrations.")
+	})
+
+	t.Run("passes on safe statements", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(`create table todos (id bigint);
+alter table todos add column done boolean not null default false;`), 0644))
+		// Run test
+		err := Run([]string{path}, true, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		err := Run([]string{"missing.sql"}, true, afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "failed to open migration file:")
+	})
+}
+
+func TestDetectHazard(t *testing.T) {
+	cases := map[string]bool{
+		"ALTER TABLE todos ALTER COLUMN id TYPE bigint":                                                   true,
+		"alter table todos alter column title set not null":                                               true,
+		"alter table todos add column owner uuid not null":                                                true,
+		"alter table todos add column owner uuid not null default '00000000-0000-0000-0000-000000000000'": false,
+		"alter table todos add column created_at timestamptz default now()":                               true,
+		"alter table todos add constraint fk foreign key (user_id) references users (id)":                 true,
+		"alter table todos add constraint fk foreign key (user_id) references users (id) not valid":       false,
+		"alter table todos add constraint title_nn check (title is not null) not valid":                   false,
+		"alter table todos add constraint todos_pkey primary key using index todos_idx":                   false,
+		"alter table todos add constraint todos_title_key unique (title)":                                 true,
+		"-- build index\ncreate unique index todos_idx on todos (id)":                                     true,
+		"create index concurrently todos_idx on todos (id)":                                               false,
+		"drop index todos_idx":                      true,
+		"reindex index concurrently todos_idx":      false,
+		"refresh materialized view stats":           true,
+		"alter table todos add column done boolean": false,
+	}
+	for stat, unsafe := range cases {
+		reason, suggestion := DetectHazard(stat)
+		assert.Equal(t, unsafe, len(reason) > 0, stat)
+		assert.Equal(t, unsafe, len(suggestion) > 0, stat)
+	}
+}
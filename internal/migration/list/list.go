@@ -4,19 +4,19 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"os"
 	"strconv"
 
-	"github.com/charmbracelet/glamour"
-	"github.com/go-errors/errors"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/migration"
 )
 
 func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
-	remoteVersions, err := loadRemoteVersions(ctx, config, options...)
+	remoteVersions, err := LoadRemoteVersions(ctx, config, options...)
 	if err != nil {
 		return err
 	}
@@ -28,7 +28,7 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 	return RenderTable(table)
 }
 
-func loadRemoteVersions(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) ([]string, error) {
+func LoadRemoteVersions(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) ([]string, error) {
 	conn, err := utils.ConnectByConfig(ctx, config, options...)
 	if err != nil {
 		return nil, err
@@ -72,19 +72,7 @@ func makeTable(remoteMigrations, localMigrations []string) string {
 }
 
 func RenderTable(markdown string) error {
-	r, err := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
-		glamour.WithWordWrap(-1),
-	)
-	if err != nil {
-		return errors.Errorf("failed to initialise terminal renderer: %w", err)
-	}
-	out, err := r.Render(markdown)
-	if err != nil {
-		return errors.Errorf("failed to render markdown: %w", err)
-	}
-	fmt.Print(out)
-	return nil
+	return render.Table(os.Stdout, markdown)
 }
 
 func LoadLocalVersions(fsys afero.Fs) ([]string, error) {
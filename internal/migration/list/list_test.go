@@ -73,7 +73,7 @@ func TestRemoteMigrations(t *testing.T) {
 		conn.Query(migration.LIST_MIGRATION_VERSION).
 			Reply("SELECT 1", []interface{}{"20220727064247"})
 		// Run test
-		versions, err := loadRemoteVersions(context.Background(), dbConfig, conn.Intercept)
+		versions, err := LoadRemoteVersions(context.Background(), dbConfig, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 		assert.ElementsMatch(t, []string{"20220727064247"}, versions)
@@ -81,7 +81,7 @@ func TestRemoteMigrations(t *testing.T) {
 
 	t.Run("throws error on connect failure", func(t *testing.T) {
 		// Run test
-		_, err := loadRemoteVersions(context.Background(), pgconn.Config{})
+		_, err := LoadRemoteVersions(context.Background(), pgconn.Config{})
 		// Check error
 		assert.ErrorContains(t, err, "invalid port (outside range)")
 	})
@@ -93,7 +93,7 @@ func TestRemoteMigrations(t *testing.T) {
 		conn.Query(migration.LIST_MIGRATION_VERSION).
 			ReplyError(pgerrcode.UndefinedTable, "relation \"supabase_migrations.schema_migrations\" does not exist")
 		// Run test
-		versions, err := loadRemoteVersions(context.Background(), dbConfig, conn.Intercept)
+		versions, err := LoadRemoteVersions(context.Background(), dbConfig, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, versions)
@@ -106,7 +106,7 @@ func TestRemoteMigrations(t *testing.T) {
 		conn.Query(migration.LIST_MIGRATION_VERSION).
 			Reply("SELECT 1", []interface{}{})
 		// Run test
-		_, err := loadRemoteVersions(context.Background(), dbConfig, conn.Intercept)
+		_, err := LoadRemoteVersions(context.Background(), dbConfig, conn.Intercept)
 		// Check error
 		assert.ErrorContains(t, err, "number of field descriptions must equal number of destinations, got 0 and 1")
 	})
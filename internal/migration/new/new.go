@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/utils"
 )
 
@@ -21,7 +22,8 @@ func Run(migrationName string, stdin afero.File, fsys afero.Fs) error {
 		return errors.Errorf("failed to open migration file: %w", err)
 	}
 	defer func() {
-		fmt.Println("Created new migration at " + utils.Bold(path))
+		fmt.Fprintln(utils.GetHumanWriter(), render.T("created_migration", utils.Bold(path)))
+		utils.PrintResult(path)
 		// File descriptor will always be closed when process quits
 		_ = f.Close()
 	}()
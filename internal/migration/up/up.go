@@ -3,6 +3,7 @@ package up
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/go-errors/errors"
@@ -13,7 +14,8 @@ import (
 	"github.com/supabase/cli/pkg/migration"
 )
 
-func Run(ctx context.Context, includeAll bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+// Applies pending migrations, stopping after targetVersion if it is not empty.
+func Run(ctx context.Context, includeAll bool, targetVersion string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	conn, err := utils.ConnectByConfig(ctx, config, options...)
 	if err != nil {
 		return err
@@ -23,9 +25,40 @@ func Run(ctx context.Context, includeAll bool, config pgconn.Config, fsys afero.
 	if err != nil {
 		return err
 	}
+	if len(targetVersion) > 0 {
+		if pending, err = FilterRange(pending, "", targetVersion, fsys); err != nil {
+			return err
+		}
+	}
 	return migration.ApplyMigrations(ctx, pending, conn, afero.NewIOFS(fsys))
 }
 
+// FilterRange keeps pending migrations with versions between fromVersion and toVersion,
+// inclusive. Empty bounds are left open.
+func FilterRange(pending []string, fromVersion, toVersion string, fsys afero.Fs) ([]string, error) {
+	for _, bound := range []string{fromVersion, toVersion} {
+		if len(bound) == 0 {
+			continue
+		}
+		local, err := migration.ListLocalMigrations(utils.MigrationsDir, afero.NewIOFS(fsys), func(version string) bool {
+			return version == bound
+		})
+		if err != nil {
+			return nil, err
+		} else if len(local) == 0 {
+			return nil, errors.Errorf("Migration version not found in local migrations directory: %s", bound)
+		}
+	}
+	var result []string
+	for _, path := range pending {
+		version, _, _ := strings.Cut(filepath.Base(path), "_")
+		if (len(fromVersion) == 0 || version >= fromVersion) && (len(toVersion) == 0 || version <= toVersion) {
+			result = append(result, path)
+		}
+	}
+	return result, nil
+}
+
 func GetPendingMigrations(ctx context.Context, includeAll bool, conn *pgx.Conn, fsys afero.Fs) ([]string, error) {
 	remoteMigrations, err := migration.ListRemoteMigrations(ctx, conn)
 	if err != nil {
@@ -166,3 +166,33 @@ func TestIgnoreVersionMismatch(t *testing.T) {
 		assert.Contains(t, utils.CmdSuggestion, "supabase migration repair --status reverted 20221201000001 20221201000003 20221201000004")
 	})
 }
+
+func TestFilterRange(t *testing.T) {
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	files := []string{
+		filepath.Join(utils.MigrationsDir, "20221201000000_test.sql"),
+		filepath.Join(utils.MigrationsDir, "20221201000001_test.sql"),
+		filepath.Join(utils.MigrationsDir, "20221201000002_test.sql"),
+	}
+	for _, path := range files {
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(""), 0644))
+	}
+
+	t.Run("stops at target version", func(t *testing.T) {
+		pending, err := FilterRange(files, "", "20221201000001", fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, files[:2], pending)
+	})
+
+	t.Run("starts at from version", func(t *testing.T) {
+		pending, err := FilterRange(files, "20221201000001", "20221201000002", fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, files[1:], pending)
+	})
+
+	t.Run("throws error on unknown version", func(t *testing.T) {
+		_, err := FilterRange(files, "20221201000009", "", fsys)
+		assert.ErrorContains(t, err, "Migration version not found in local migrations directory: 20221201000009")
+	})
+}
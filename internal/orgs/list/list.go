@@ -3,14 +3,16 @@ package list
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
 )
 
-func Run(ctx context.Context) error {
+func Run(ctx context.Context, name string) error {
 	resp, err := utils.GetSupabase().V1ListAllOrganizationsWithResponse(ctx)
 	if err != nil {
 		return errors.Errorf("failed to list organizations: %w", err)
@@ -20,10 +22,29 @@ func Run(ctx context.Context) error {
 		return errors.New("Unexpected error retrieving organizations: " + string(resp.Body))
 	}
 
+	orgs := []api.OrganizationResponseV1{}
+	for _, org := range *resp.JSON200 {
+		if strings.Contains(strings.ToLower(org.Name), strings.ToLower(name)) {
+			orgs = append(orgs, org)
+		}
+	}
+
+	switch utils.OutputFormat.Value {
+	case utils.OutputPretty:
+	case utils.OutputToml:
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, struct {
+			Organizations []api.OrganizationResponseV1 `toml:"organizations"`
+		}{
+			Organizations: orgs,
+		})
+	default:
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, orgs)
+	}
+
 	table := `|ID|NAME|
 |-|-|
 `
-	for _, org := range *resp.JSON200 {
+	for _, org := range orgs {
 		table += fmt.Sprintf("|`%s`|`%s`|\n", org.Id, strings.ReplaceAll(org.Name, "|", "\\|"))
 	}
 
@@ -30,7 +30,28 @@ func TestOrganizationListCommand(t *testing.T) {
 				},
 			})
 		// Run test
-		assert.NoError(t, Run(context.Background()))
+		assert.NoError(t, Run(context.Background(), ""))
+		// Validate api
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("encodes filtered organizations as json", func(t *testing.T) {
+		utils.OutputFormat.Value = utils.OutputJson
+		t.Cleanup(func() { utils.OutputFormat.Value = utils.OutputPretty })
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/organizations").
+			Reply(http.StatusOK).
+			JSON([]api.OrganizationResponseV1{
+				{Id: "combined-fuchsia-lion", Name: "Test Organization"},
+				{Id: "other-org", Name: "Other"},
+			})
+		// Run test
+		assert.NoError(t, Run(context.Background(), "test"))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -45,7 +66,7 @@ func TestOrganizationListCommand(t *testing.T) {
 			Get("/v1/organizations").
 			ReplyError(errors.New("network error"))
 		// Run test
-		assert.Error(t, Run(context.Background()))
+		assert.Error(t, Run(context.Background(), ""))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -61,7 +82,7 @@ func TestOrganizationListCommand(t *testing.T) {
 			Reply(http.StatusServiceUnavailable).
 			JSON(map[string]string{"message": "unavailable"})
 		// Run test
-		assert.Error(t, Run(context.Background()))
+		assert.Error(t, Run(context.Background(), ""))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -0,0 +1,31 @@
+package list
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/plugins"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(fsys afero.Fs) error {
+	result := plugins.List(os.Getenv("PATH"), fsys)
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, map[string][]plugins.Plugin{
+			"plugins": result,
+		})
+	}
+	if len(result) == 0 {
+		fmt.Fprintln(os.Stderr, "No plugins found. Install an executable named "+utils.Aqua(plugins.Prefix+"<name>")+" on your PATH.")
+		return nil
+	}
+	table := `|NAME|PATH|
+|-|-|
+`
+	for _, p := range result {
+		table += fmt.Sprintf("|`%s`|`%s`|\n", p.Name, p.Path)
+	}
+	return list.RenderTable(table)
+}
@@ -0,0 +1,134 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+// Executables named with this prefix on PATH are dispatched as subcommands, ie.
+// `supabase foo` runs `supabase-foo`.
+const Prefix = "supabase-"
+
+// Environment variables passed to every plugin.
+const (
+	EnvCliVersion  = "SUPABASE_CLI_VERSION"
+	EnvWorkdir     = "SUPABASE_WORKDIR"
+	EnvApiUrl      = "SUPABASE_API_URL"
+	EnvProjectRef  = "SUPABASE_PROJECT_REF"
+	EnvAccessToken = "SUPABASE_ACCESS_TOKEN"
+)
+
+type Plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// List finds all plugins on the given PATH. Executables in earlier directories shadow
+// those with the same name in later ones, matching the lookup order of the shell.
+func List(path string, fsys afero.Fs) []Plugin {
+	var result []Plugin
+	seen := map[string]bool{}
+	for _, dir := range filepath.SplitList(path) {
+		entries, err := afero.ReadDir(fsys, dir)
+		if err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+			continue
+		}
+		for _, e := range entries {
+			name, ok := pluginName(e)
+			if !ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			result = append(result, Plugin{Name: name, Path: filepath.Join(dir, e.Name())})
+		}
+	}
+	return result
+}
+
+func pluginName(info os.FileInfo) (string, bool) {
+	if info.IsDir() || !strings.HasPrefix(info.Name(), Prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(info.Name(), Prefix)
+	if runtime.GOOS == "windows" {
+		ext := filepath.Ext(name)
+		if !strings.EqualFold(ext, ".exe") {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, ext)
+	} else if info.Mode().Perm()&0111 == 0 {
+		return "", false
+	}
+	return name, len(name) > 0
+}
+
+// Find returns the path to the plugin executable for name, or empty if not installed.
+func Find(name string) string {
+	if len(name) == 0 || strings.HasPrefix(name, "-") || strings.ContainsAny(name, `/\`) {
+		return ""
+	}
+	path, err := exec.LookPath(Prefix + name)
+	if err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+		return ""
+	}
+	return path
+}
+
+// Exec runs the plugin from the current directory, forwarding stdio and args. The linked
+// project ref and access token are passed via env so that plugins can call the Management
+// API without reimplementing login. Both are omitted if they cannot be resolved.
+func Exec(ctx context.Context, path string, args []string, fsys afero.Fs) error {
+	if err := utils.ChangeWorkDir(fsys); err != nil {
+		return err
+	}
+	workdir, err := os.Getwd()
+	if err != nil {
+		return errors.Errorf("failed to get workdir: %w", err)
+	}
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Dir = utils.CurrentDirAbs
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), Environ(fsys)...)
+	cmd.Env = append(cmd.Env, EnvWorkdir+"="+workdir)
+	// Let the plugin decide how to handle interrupts
+	signal.Notify(make(chan os.Signal, 1), os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+	if err := cmd.Run(); err != nil {
+		return errors.Errorf("failed to run plugin: %w", err)
+	}
+	return nil
+}
+
+// Environ returns the structured context passed to plugins as env vars.
+func Environ(fsys afero.Fs) []string {
+	env := []string{
+		EnvCliVersion + "=" + utils.Version,
+		EnvApiUrl + "=" + utils.GetSupabaseAPIHost(),
+	}
+	if ref, err := flags.LoadProjectRef(fsys); err == nil {
+		env = append(env, EnvProjectRef+"="+ref)
+	} else {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+	}
+	if token, err := utils.LoadAccessTokenFS(fsys); err == nil {
+		env = append(env, EnvAccessToken+"="+token)
+	} else {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+	}
+	return env
+}
@@ -0,0 +1,57 @@
+package plugins
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are matched by file extension on windows")
+	}
+
+	t.Run("lists executables with prefix", func(t *testing.T) {
+		local := filepath.Join("/usr", "local", "bin")
+		global := filepath.Join("/usr", "bin")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(local, "supabase-foo"), []byte{}, 0755))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(global, "supabase-foo"), []byte{}, 0755))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(global, "supabase-bar"), []byte{}, 0755))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(global, "supabase-data"), []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(global, "supabase"), []byte{}, 0755))
+		require.NoError(t, fsys.MkdirAll(filepath.Join(global, "supabase-dir"), 0755))
+		// Run test
+		result := List(local+string(filepath.ListSeparator)+global, fsys)
+		// Check output
+		assert.ElementsMatch(t, []Plugin{
+			{Name: "foo", Path: filepath.Join(local, "supabase-foo")},
+			{Name: "bar", Path: filepath.Join(global, "supabase-bar")},
+		}, result)
+	})
+
+	t.Run("skips missing directories", func(t *testing.T) {
+		// Run test
+		result := List("/missing", afero.NewMemMapFs())
+		// Check output
+		assert.Empty(t, result)
+	})
+}
+
+func TestFindPlugin(t *testing.T) {
+	t.Run("rejects flags and paths", func(t *testing.T) {
+		assert.Empty(t, Find("--debug"))
+		assert.Empty(t, Find("../foo"))
+		assert.Empty(t, Find(""))
+	})
+
+	t.Run("returns empty if not installed", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+		assert.Empty(t, Find("missing"))
+	})
+}
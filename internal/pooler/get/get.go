@@ -0,0 +1,53 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, projectRef string) error {
+	configs, err := GetPoolerConfig(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, configs)
+	}
+	table := "|DATABASE|IDENTIFIER|POOL MODE|DEFAULT POOL SIZE|MAX CLIENTS|PORT|\n|-|-|-|-|-|-|\n"
+	for _, c := range configs {
+		table += fmt.Sprintf(
+			"|`%s`|`%s`|`%s`|%s|%s|`%d`|\n",
+			c.DatabaseType,
+			c.Identifier,
+			c.PoolMode,
+			formatSize(c.DefaultPoolSize),
+			formatSize(c.MaxClientConn),
+			c.DbPort,
+		)
+	}
+	return list.RenderTable(table)
+}
+
+func GetPoolerConfig(ctx context.Context, projectRef string) ([]api.SupavisorConfigResponse, error) {
+	resp, err := utils.GetSupabase().V1GetSupavisorConfigWithResponse(ctx, projectRef)
+	if err != nil {
+		return nil, errors.Errorf("failed to get pooler config: %w", err)
+	} else if resp.JSON200 == nil {
+		return nil, errors.New("Unexpected error retrieving pooler config: " + string(resp.Body))
+	}
+	return *resp.JSON200, nil
+}
+
+// Sizes are unset when the project uses the defaults of its compute add-on.
+func formatSize(size *int) string {
+	if size == nil {
+		return "default"
+	}
+	return fmt.Sprintf("`%d`", *size)
+}
@@ -0,0 +1,85 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/pooler/get"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
+)
+
+// Max direct connections to Postgres by compute size, as documented at
+// https://supabase.com/docs/guides/platform/compute-add-ons
+var maxConnections = map[string]uint{
+	"ci_nano":     60,
+	"ci_micro":    60,
+	"ci_small":    90,
+	"ci_medium":   120,
+	"ci_large":    160,
+	"ci_xlarge":   240,
+	"ci_2xlarge":  380,
+	"ci_4xlarge":  480,
+	"ci_8xlarge":  490,
+	"ci_12xlarge": 500,
+	"ci_16xlarge": 500,
+}
+
+// Projects without a compute add-on run on the smallest instance.
+const defaultComputeSize = "ci_nano"
+
+func Run(ctx context.Context, projectRef string, poolSize uint) error {
+	size, err := GetComputeSize(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	if err := ValidatePoolSize(poolSize, size); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Updating pooler config...")
+	body := api.UpdateSupavisorConfigBody{DefaultPoolSize: cast.Ptr(int(poolSize))}
+	resp, err := utils.GetSupabase().V1UpdateSupavisorConfigWithResponse(ctx, projectRef, body)
+	if err != nil {
+		return errors.Errorf("failed to update pooler config: %w", err)
+	} else if resp.JSON200 == nil {
+		return errors.New("Unexpected error updating pooler config: " + string(resp.Body))
+	}
+	return get.Run(ctx, projectRef)
+}
+
+func GetComputeSize(ctx context.Context, projectRef string) (string, error) {
+	resp, err := utils.GetSupabase().V1ListProjectAddonsWithResponse(ctx, projectRef)
+	if err != nil {
+		return "", errors.Errorf("failed to list project add-ons: %w", err)
+	} else if resp.JSON200 == nil {
+		return "", errors.New("Unexpected error listing project add-ons: " + string(resp.Body))
+	}
+	for _, addon := range resp.JSON200.SelectedAddons {
+		if addon.Type == "compute_instance" {
+			return addon.Variant.Id, nil
+		}
+	}
+	return defaultComputeSize, nil
+}
+
+// ValidatePoolSize rejects pool sizes that would exhaust the direct connections available
+// to the database, which are limited by the compute size of the project.
+func ValidatePoolSize(poolSize uint, computeSize string) error {
+	limit, ok := maxConnections[computeSize]
+	if !ok {
+		fmt.Fprintln(os.Stderr, "Skipped validating pool size for unknown compute size:", computeSize)
+		return nil
+	}
+	name := strings.TrimPrefix(computeSize, "ci_")
+	if poolSize == 0 || poolSize > limit {
+		return errors.Errorf("pool size must be between 1 and %d for %s compute: %d", limit, name, poolSize)
+	}
+	if poolSize > limit*4/5 {
+		fmt.Fprintf(os.Stderr, "%s pool size %d leaves few of the %d available connections for other clients.\n", utils.Yellow("WARNING:"), poolSize, limit)
+	}
+	return nil
+}
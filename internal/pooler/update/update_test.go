@@ -0,0 +1,88 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
+)
+
+func TestUpdatePooler(t *testing.T) {
+	ref := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("updates default pool size", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/billing/addons").
+			Reply(http.StatusOK).
+			JSON(api.ListProjectAddonsResponse{SelectedAddons: []api.SelectedProjectAddon{{
+				Type:    "compute_instance",
+				Variant: api.ProjectAddonVariant{Id: "ci_small", Name: "Small"},
+			}}})
+		gock.New(utils.DefaultApiHost).
+			Patch("/v1/projects/" + ref + "/config/database/pooler").
+			JSON(api.UpdateSupavisorConfigBody{DefaultPoolSize: cast.Ptr(30)}).
+			Reply(http.StatusOK).
+			JSON(api.UpdateSupavisorConfigResponse{DefaultPoolSize: cast.Ptr(30)})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/config/database/pooler").
+			Reply(http.StatusOK).
+			JSON([]api.SupavisorConfigResponse{{
+				DatabaseType:    api.PRIMARY,
+				Identifier:      ref,
+				PoolMode:        api.SupavisorConfigResponsePoolModeTransaction,
+				DefaultPoolSize: cast.Ptr(30),
+				MaxClientConn:   cast.Ptr(400),
+				DbPort:          6543,
+			}})
+		// Run test
+		err := Run(context.Background(), ref, 30)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on pool size above compute limit", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/billing/addons").
+			Reply(http.StatusOK).
+			JSON(api.ListProjectAddonsResponse{SelectedAddons: []api.SelectedProjectAddon{}})
+		// Run test
+		err := Run(context.Background(), ref, 100)
+		// Check error
+		assert.ErrorContains(t, err, "pool size must be between 1 and 60 for nano compute: 100")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on service unavailable", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + ref + "/billing/addons").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), ref, 15)
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error listing project add-ons:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestValidatePoolSize(t *testing.T) {
+	assert.NoError(t, ValidatePoolSize(160, "ci_large"))
+	assert.ErrorContains(t, ValidatePoolSize(0, "ci_large"), "pool size must be between 1 and 160 for large compute: 0")
+	assert.ErrorContains(t, ValidatePoolSize(161, "ci_large"), "pool size must be between 1 and 160 for large compute: 161")
+	assert.NoError(t, ValidatePoolSize(1000, "ci_unknown"))
+}
@@ -0,0 +1,52 @@
+package add
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(name, token, projectRef string, fsys afero.Fs) error {
+	if !utils.ProfileNamePattern.MatchString(name) {
+		return errors.New(utils.ErrInvalidProfile)
+	}
+	if len(projectRef) > 0 {
+		if err := utils.AssertProjectRefIsValid(projectRef); err != nil {
+			return err
+		}
+	}
+	profiles, err := utils.LoadProfiles(fsys)
+	if err != nil {
+		return err
+	}
+	if len(token) > 0 {
+		// Credentials are scoped to the selected profile
+		viper.Set("PROFILE", name)
+		if err := utils.SaveAccessToken(token, fsys); err != nil {
+			return err
+		}
+	}
+	profile := utils.Profile{Name: name, ProjectRef: projectRef}
+	var found bool
+	for i, p := range profiles {
+		if p.Name == name {
+			profiles[i] = profile
+			found = true
+		}
+	}
+	if !found {
+		profiles = append(profiles, profile)
+	}
+	if err := utils.SaveProfiles(profiles, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Saved profile:", utils.Aqua(name))
+	if len(token) == 0 {
+		fmt.Fprintln(os.Stderr, "Run "+utils.Aqua("supabase login --profile "+name)+" to authenticate this profile.")
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package list
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(fsys afero.Fs) error {
+	profiles, err := utils.LoadProfiles(fsys)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, profiles)
+	}
+	current := utils.GetProfileName()
+	table := `|ACTIVE|NAME|PROJECT REF|
+|-|-|-|
+`
+	for _, p := range profiles {
+		var active string
+		if p.Name == current {
+			active = "*"
+		}
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", active, p.Name, p.ProjectRef)
+	}
+	return list.RenderTable(table)
+}
@@ -0,0 +1,37 @@
+package remove
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(name string, fsys afero.Fs) error {
+	profiles, err := utils.LoadProfiles(fsys)
+	if err != nil {
+		return err
+	}
+	result := make([]utils.Profile, 0, len(profiles))
+	for _, p := range profiles {
+		if p.Name != name {
+			result = append(result, p)
+		}
+	}
+	if len(result) == len(profiles) {
+		return errors.Errorf("profile not found: %s", name)
+	}
+	// Credentials are scoped to the selected profile
+	viper.Set("PROFILE", name)
+	if err := utils.DeleteAccessToken(fsys); err != nil && !errors.Is(err, utils.ErrNotLoggedIn) {
+		return err
+	}
+	if err := utils.SaveProfiles(result, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Removed profile:", utils.Aqua(name))
+	return nil
+}
@@ -15,7 +15,7 @@ import (
 	"github.com/supabase/cli/pkg/api"
 )
 
-func Run(ctx context.Context, params api.V1CreateProjectBody, fsys afero.Fs) error {
+func Run(ctx context.Context, params api.V1CreateProjectBody, wait bool, fsys afero.Fs) error {
 	if err := promptMissingParams(ctx, &params); err != nil {
 		return err
 	}
@@ -36,11 +36,34 @@ func Run(ctx context.Context, params api.V1CreateProjectBody, fsys afero.Fs) err
 
 	projectUrl := fmt.Sprintf("%s/project/%s", utils.GetSupabaseDashboardURL(), resp.JSON201.Id)
 	fmt.Fprintf(os.Stderr, "Created a new project %s at %s\n", utils.Aqua(resp.JSON201.Name), utils.Bold(projectUrl))
+	details := ProjectDetails{V1ProjectResponse: *resp.JSON201}
+	if wait {
+		fmt.Fprintln(os.Stderr, "Waiting for project to become healthy...")
+		conn, err := WaitForProject(ctx, flags.ProjectRef)
+		if err != nil {
+			return err
+		}
+		details.Connection = &conn
+	}
 	if utils.OutputFormat.Value == utils.OutputPretty {
+		if details.Connection != nil && !utils.IsQuiet() {
+			printConnection(*details.Connection)
+		}
+		utils.PrintResult(details.Id)
 		return nil
 	}
 
-	return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, resp.JSON201)
+	return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, details)
+}
+
+func printConnection(conn ConnectionDetails) {
+	fmt.Println(printKeyValue("API URL", conn.ApiUrl))
+	fmt.Println(printKeyValue("DB host", conn.DbHost))
+	fmt.Println(printKeyValue("DB port", fmt.Sprintf("%d", conn.DbPort)))
+	fmt.Println(printKeyValue("DB user", conn.DbUser))
+	fmt.Println(printKeyValue("DB name", conn.DbName))
+	fmt.Println(printKeyValue("anon key", conn.AnonKey))
+	fmt.Println(printKeyValue("service_role key", conn.ServiceRoleKey))
 }
 
 func printKeyValue(key, value string) string {
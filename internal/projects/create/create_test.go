@@ -3,6 +3,7 @@ package create
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/h2non/gock"
@@ -42,13 +43,13 @@ func TestProjectCreateCommand(t *testing.T) {
 				CreatedAt:      "2022-04-25T02:14:55.906498Z",
 			})
 		// Run test
-		assert.NoError(t, Run(context.Background(), params, fsys))
+		assert.NoError(t, Run(context.Background(), params, false, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 
 	t.Run("throws error on failure to load token", func(t *testing.T) {
-		assert.Error(t, Run(context.Background(), params, afero.NewMemMapFs()))
+		assert.Error(t, Run(context.Background(), params, false, afero.NewMemMapFs()))
 	})
 
 	t.Run("throws error on network error", func(t *testing.T) {
@@ -65,7 +66,7 @@ func TestProjectCreateCommand(t *testing.T) {
 			JSON(params).
 			ReplyError(errors.New("network error"))
 		// Run test
-		assert.Error(t, Run(context.Background(), params, fsys))
+		assert.Error(t, Run(context.Background(), params, false, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -85,7 +86,7 @@ func TestProjectCreateCommand(t *testing.T) {
 			Reply(500).
 			JSON(map[string]string{"message": "unavailable"})
 		// Run test
-		assert.Error(t, Run(context.Background(), params, fsys))
+		assert.Error(t, Run(context.Background(), params, false, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -105,8 +106,64 @@ func TestProjectCreateCommand(t *testing.T) {
 			Reply(200).
 			JSON([]string{})
 		// Run test
-		assert.Error(t, Run(context.Background(), params, fsys))
+		assert.Error(t, Run(context.Background(), params, false, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 }
+
+func TestWaitForProject(t *testing.T) {
+	pollInterval = 0
+	// Setup valid projectRef
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("waits for project to be healthy", func(t *testing.T) {
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project).
+			Reply(http.StatusOK).
+			JSON(api.V1ProjectResponse{Id: project, Status: api.V1ProjectResponseStatusCOMINGUP})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project).
+			Reply(http.StatusOK).
+			JSON(api.V1ProjectResponse{Id: project, Status: api.V1ProjectResponseStatusACTIVEHEALTHY})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/health").
+			Reply(http.StatusOK).
+			JSON([]api.V1ServiceHealthResponse{{Name: api.V1ServiceHealthResponseNameDb, Healthy: true}})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{
+				{Name: "anon", ApiKey: "anon-key"},
+				{Name: "service_role", ApiKey: "service-key"},
+			})
+		// Run test
+		conn, err := WaitForProject(context.Background(), project)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "db."+project+".supabase.co", conn.DbHost)
+		assert.Equal(t, "https://"+project+".supabase.co", conn.ApiUrl)
+		assert.Equal(t, "anon-key", conn.AnonKey)
+		assert.Equal(t, "service-key", conn.ServiceRoleKey)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on failed project", func(t *testing.T) {
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project).
+			Reply(http.StatusOK).
+			JSON(api.V1ProjectResponse{Id: project, Status: api.V1ProjectResponseStatusINITFAILED})
+		// Run test
+		_, err := WaitForProject(context.Background(), project)
+		// Check error
+		assert.ErrorContains(t, err, "Project failed to start: INIT_FAILED")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
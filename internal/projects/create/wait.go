@@ -0,0 +1,102 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/tenant"
+	"github.com/supabase/cli/pkg/api"
+)
+
+var (
+	// Overridden in tests to avoid waiting between polls
+	pollInterval = 5 * time.Second
+	waitTimeout  = 15 * time.Minute
+)
+
+type ConnectionDetails struct {
+	ApiUrl         string `json:"api_url"`
+	DbHost         string `json:"db_host"`
+	DbPort         uint16 `json:"db_port"`
+	DbUser         string `json:"db_user"`
+	DbName         string `json:"db_name"`
+	AnonKey        string `json:"anon_key"`
+	ServiceRoleKey string `json:"service_role_key"`
+}
+
+type ProjectDetails struct {
+	api.V1ProjectResponse
+	Connection *ConnectionDetails `json:"connection,omitempty"`
+}
+
+// Polls the project until it is healthy, then returns its connection details.
+func WaitForProject(ctx context.Context, projectRef string) (ConnectionDetails, error) {
+	ctx, cancel := context.WithTimeout(ctx, waitTimeout)
+	defer cancel()
+	policy := backoff.WithContext(backoff.NewConstantBackOff(pollInterval), ctx)
+	if err := backoff.RetryNotify(func() error {
+		return checkProjectStatus(ctx, projectRef)
+	}, policy, func(err error, d time.Duration) {
+		fmt.Fprintln(os.Stderr, err)
+	}); err != nil {
+		return ConnectionDetails{}, err
+	}
+	keys, err := tenant.GetApiKeys(ctx, projectRef)
+	if err != nil {
+		return ConnectionDetails{}, err
+	}
+	return ConnectionDetails{
+		ApiUrl:         "https://" + utils.GetSupabaseHost(projectRef),
+		DbHost:         utils.GetSupabaseDbHost(projectRef),
+		DbPort:         5432,
+		DbUser:         "postgres",
+		DbName:         "postgres",
+		AnonKey:        keys.Anon,
+		ServiceRoleKey: keys.ServiceRole,
+	}, nil
+}
+
+func checkProjectStatus(ctx context.Context, projectRef string) error {
+	resp, err := utils.GetSupabase().V1GetProjectWithResponse(ctx, projectRef)
+	if err != nil {
+		return errors.Errorf("failed to retrieve project status: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return errors.New("Unexpected error retrieving project status: " + string(resp.Body))
+	}
+	switch resp.JSON200.Status {
+	case api.V1ProjectResponseStatusACTIVEHEALTHY:
+		return checkServicesHealth(ctx, projectRef)
+	case api.V1ProjectResponseStatusINITFAILED, api.V1ProjectResponseStatusREMOVED:
+		return backoff.Permanent(errors.Errorf("Project failed to start: %s", resp.JSON200.Status))
+	}
+	return errors.Errorf("Waiting for project to become healthy: %s", resp.JSON200.Status)
+}
+
+func checkServicesHealth(ctx context.Context, projectRef string) error {
+	params := api.V1GetServicesHealthParams{
+		Services: []api.V1GetServicesHealthParamsServices{
+			api.V1GetServicesHealthParamsServicesDb,
+			api.V1GetServicesHealthParamsServicesRest,
+			api.V1GetServicesHealthParamsServicesAuth,
+		},
+	}
+	resp, err := utils.GetSupabase().V1GetServicesHealthWithResponse(ctx, projectRef, &params)
+	if err != nil {
+		return errors.Errorf("failed to check services health: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return errors.Errorf("Error status %d: %s", resp.StatusCode(), resp.Body)
+	}
+	for _, service := range *resp.JSON200 {
+		if !service.Healthy {
+			return errors.Errorf("Service not healthy: %s (%s)", service.Name, service.Status)
+		}
+	}
+	return nil
+}
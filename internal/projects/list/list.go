@@ -16,10 +16,24 @@ import (
 
 type linkedProject struct {
 	api.V1ProjectResponse `yaml:",inline"`
-	Linked                bool `json:"linked"`
+	Linked                bool   `json:"linked"`
+	ApiUrl                string `json:"api_url" yaml:"api_url" toml:"api_url"`
 }
 
-func Run(ctx context.Context, fsys afero.Fs) error {
+// Filter narrows down the listed projects. Empty fields match all projects.
+type Filter struct {
+	OrgId string
+	Name  string
+}
+
+func (f Filter) Match(project api.V1ProjectResponse) bool {
+	if len(f.OrgId) > 0 && project.OrganizationId != f.OrgId {
+		return false
+	}
+	return strings.Contains(strings.ToLower(project.Name), strings.ToLower(f.Name))
+}
+
+func Run(ctx context.Context, filter Filter, fsys afero.Fs) error {
 	resp, err := utils.GetSupabase().V1ListAllProjectsWithResponse(ctx)
 	if err != nil {
 		return errors.Errorf("failed to list projects: %w", err)
@@ -34,26 +48,31 @@ func Run(ctx context.Context, fsys afero.Fs) error {
 		fmt.Fprintln(os.Stderr, err)
 	}
 
-	var projects []linkedProject
+	projects := []linkedProject{}
 	for _, project := range *resp.JSON200 {
+		if !filter.Match(project) {
+			continue
+		}
 		projects = append(projects, linkedProject{
 			V1ProjectResponse: project,
 			Linked:            project.Id == projectRef,
+			ApiUrl:            "https://" + utils.GetSupabaseHost(project.Id),
 		})
 	}
 
 	if utils.OutputFormat.Value == utils.OutputPretty {
-		table := `LINKED|ORG ID|REFERENCE ID|NAME|REGION|CREATED AT (UTC)
-|-|-|-|-|-|-|
+		table := `LINKED|ORG ID|REFERENCE ID|NAME|REGION|STATUS|CREATED AT (UTC)
+|-|-|-|-|-|-|-|
 `
 		for _, project := range projects {
 			table += fmt.Sprintf(
-				"|`%s`|`%s`|`%s`|`%s`|`%s`|`%s`|\n",
+				"|`%s`|`%s`|`%s`|`%s`|`%s`|`%s`|`%s`|\n",
 				formatBullet(project.Linked),
 				project.OrganizationId,
 				project.Id,
 				strings.ReplaceAll(project.Name, "|", "\\|"),
 				formatRegion(project.Region),
+				project.Status,
 				utils.FormatTimestamp(project.CreatedAt),
 			)
 		}
@@ -35,13 +35,13 @@ func TestProjectListCommand(t *testing.T) {
 				},
 			})
 		// Run test
-		assert.NoError(t, Run(context.Background(), fsys))
+		assert.NoError(t, Run(context.Background(), Filter{}, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 
 	t.Run("throws error on failure to load token", func(t *testing.T) {
-		assert.Error(t, Run(context.Background(), afero.NewMemMapFs()))
+		assert.Error(t, Run(context.Background(), Filter{}, afero.NewMemMapFs()))
 	})
 
 	t.Run("throws error on network error", func(t *testing.T) {
@@ -56,7 +56,7 @@ func TestProjectListCommand(t *testing.T) {
 			Get("/v1/projects").
 			ReplyError(errors.New("network error"))
 		// Run test
-		assert.Error(t, Run(context.Background(), fsys))
+		assert.Error(t, Run(context.Background(), Filter{}, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -74,7 +74,7 @@ func TestProjectListCommand(t *testing.T) {
 			Reply(500).
 			JSON(map[string]string{"message": "unavailable"})
 		// Run test
-		assert.Error(t, Run(context.Background(), fsys))
+		assert.Error(t, Run(context.Background(), Filter{}, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -92,8 +92,32 @@ func TestProjectListCommand(t *testing.T) {
 			Reply(200).
 			JSON(map[string]string{})
 		// Run test
-		assert.Error(t, Run(context.Background(), fsys))
+		assert.Error(t, Run(context.Background(), Filter{}, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 }
+
+func TestProjectFilter(t *testing.T) {
+	project := api.V1ProjectResponse{
+		Id:             apitest.RandomProjectRef(),
+		OrganizationId: "combined-fuchsia-lion",
+		Name:           "Staging Project",
+	}
+
+	t.Run("matches all by default", func(t *testing.T) {
+		assert.True(t, Filter{}.Match(project))
+	})
+
+	t.Run("matches org and name case insensitively", func(t *testing.T) {
+		assert.True(t, Filter{OrgId: "combined-fuchsia-lion", Name: "staging"}.Match(project))
+	})
+
+	t.Run("excludes other orgs", func(t *testing.T) {
+		assert.False(t, Filter{OrgId: "other-org"}.Match(project))
+	})
+
+	t.Run("excludes other names", func(t *testing.T) {
+		assert.False(t, Filter{Name: "production"}.Match(project))
+	})
+}
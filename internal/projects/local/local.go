@@ -0,0 +1,138 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// A local development stack started by the CLI.
+type Stack struct {
+	ProjectId string   `json:"project_id"`
+	Workdir   string   `json:"workdir"`
+	Running   int      `json:"running"`
+	Total     int      `json:"total"`
+	Ports     []uint16 `json:"ports"`
+}
+
+func Run(ctx context.Context, fsys afero.Fs) error {
+	stacks, err := ListStacks(ctx)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeRows(utils.OutputFormat.Value, os.Stdout, stacks)
+	}
+	if len(stacks) == 0 {
+		fmt.Fprintln(os.Stderr, "No local stacks found.")
+		return nil
+	}
+	// Highlights the stack of the current directory if available
+	var current string
+	if err := utils.LoadConfigFS(fsys); err == nil {
+		current = utils.Config.ProjectId
+	} else {
+		utils.CmdSuggestion = ""
+	}
+	table := `CURRENT|PROJECT ID|WORKDIR|CONTAINERS|PORTS
+|-|-|-|-|-|
+`
+	for _, s := range stacks {
+		ports := make([]string, len(s.Ports))
+		for i, p := range s.Ports {
+			ports[i] = fmt.Sprintf("%d", p)
+		}
+		table += fmt.Sprintf(
+			"|`%s`|`%s`|`%s`|`%d/%d running`|`%s`|\n",
+			formatBullet(s.ProjectId == current),
+			s.ProjectId,
+			s.Workdir,
+			s.Running,
+			s.Total,
+			strings.Join(ports, ", "),
+		)
+	}
+	return list.RenderTable(table)
+}
+
+func formatBullet(value bool) string {
+	if value {
+		return "  ●"
+	}
+	return " "
+}
+
+// Groups containers of all local projects by their project label.
+func ListStacks(ctx context.Context) ([]Stack, error) {
+	containers, err := utils.Docker.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: utils.CliProjectFilter(""),
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to list containers: %w", err)
+	}
+	stacks := map[string]*Stack{}
+	for _, c := range containers {
+		projectId := c.Labels[utils.CliProjectLabel]
+		s, ok := stacks[projectId]
+		if !ok {
+			s = &Stack{ProjectId: projectId}
+			stacks[projectId] = s
+		}
+		if workdir := c.Labels[utils.CliWorkdirLabel]; len(workdir) > 0 {
+			s.Workdir = workdir
+		}
+		s.Total++
+		if c.State != "running" {
+			continue
+		}
+		s.Running++
+		for _, p := range c.Ports {
+			if p.PublicPort > 0 && !slices.Contains(s.Ports, p.PublicPort) {
+				s.Ports = append(s.Ports, p.PublicPort)
+			}
+		}
+	}
+	result := make([]Stack, 0, len(stacks))
+	for _, s := range stacks {
+		slices.Sort(s.Ports)
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ProjectId < result[j].ProjectId
+	})
+	return result, nil
+}
+
+// Checks that no other local stack has already published any of the given host ports.
+func AssertNoPortConflicts(ctx context.Context, projectId string, ports []uint16) error {
+	stacks, err := ListStacks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range stacks {
+		if s.ProjectId == projectId {
+			continue
+		}
+		var conflicts []string
+		for _, p := range ports {
+			if slices.Contains(s.Ports, p) {
+				conflicts = append(conflicts, fmt.Sprintf("%d", p))
+			}
+		}
+		if len(conflicts) > 0 {
+			utils.CmdSuggestion = fmt.Sprintf("Stop the other project with %s or change the ports in %s.", utils.Aqua("supabase stop --project-id "+s.ProjectId), utils.Bold(utils.ConfigPath))
+			return errors.Errorf("Ports %s are already used by local project %s.", strings.Join(conflicts, ", "), s.ProjectId)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package local
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func mockContainers(t *testing.T, containers []types.Container) {
+	require.NoError(t, apitest.MockDocker(utils.Docker))
+	gock.New(utils.Docker.DaemonHost()).
+		Get("/v" + utils.Docker.ClientVersion() + "/containers/json").
+		Reply(http.StatusOK).
+		JSON(containers)
+}
+
+func TestListStacks(t *testing.T) {
+	t.Run("groups containers by project", func(t *testing.T) {
+		// Setup mock docker
+		defer gock.OffAll()
+		mockContainers(t, []types.Container{{
+			ID:     "db-b",
+			State:  "running",
+			Labels: map[string]string{utils.CliProjectLabel: "beta", utils.CliWorkdirLabel: "/tmp/beta"},
+			Ports:  []types.Port{{PrivatePort: 5432, PublicPort: 64322}},
+		}, {
+			ID:     "kong-a",
+			State:  "running",
+			Labels: map[string]string{utils.CliProjectLabel: "alpha"},
+			Ports:  []types.Port{{PrivatePort: 8000, PublicPort: 54321}, {PrivatePort: 8443}},
+		}, {
+			ID:     "db-a",
+			State:  "running",
+			Labels: map[string]string{utils.CliProjectLabel: "alpha", utils.CliWorkdirLabel: "/tmp/alpha"},
+			Ports:  []types.Port{{PrivatePort: 5432, PublicPort: 54322}},
+		}, {
+			ID:     "studio-b",
+			State:  "exited",
+			Labels: map[string]string{utils.CliProjectLabel: "beta"},
+		}})
+		// Run test
+		stacks, err := ListStacks(context.Background())
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Stack{
+			{ProjectId: "alpha", Workdir: "/tmp/alpha", Running: 2, Total: 2, Ports: []uint16{54321, 54322}},
+			{ProjectId: "beta", Workdir: "/tmp/beta", Running: 1, Total: 2, Ports: []uint16{64322}},
+		}, stacks)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on docker failure", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/json").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := ListStacks(context.Background())
+		// Check error
+		assert.ErrorContains(t, err, "failed to list containers:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestPortConflicts(t *testing.T) {
+	containers := []types.Container{{
+		ID:     "db-a",
+		State:  "running",
+		Labels: map[string]string{utils.CliProjectLabel: "alpha"},
+		Ports:  []types.Port{{PrivatePort: 5432, PublicPort: 54322}},
+	}}
+
+	t.Run("allows ports of the same project", func(t *testing.T) {
+		// Setup mock docker
+		defer gock.OffAll()
+		mockContainers(t, containers)
+		// Run test
+		err := AssertNoPortConflicts(context.Background(), "alpha", []uint16{54321, 54322})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on ports used by another project", func(t *testing.T) {
+		// Setup mock docker
+		defer gock.OffAll()
+		mockContainers(t, containers)
+		// Run test
+		err := AssertNoPortConflicts(context.Background(), "beta", []uint16{54321, 54322})
+		// Check error
+		assert.ErrorContains(t, err, "Ports 54322 are already used by local project alpha.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
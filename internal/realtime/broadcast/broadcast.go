@@ -0,0 +1,58 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/realtime"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+type broadcastMessage struct {
+	Topic   string          `json:"topic"`
+	Event   string          `json:"event"`
+	Payload json.RawMessage `json:"payload"`
+	Private bool            `json:"private,omitempty"`
+}
+
+type broadcastRequest struct {
+	Messages []broadcastMessage `json:"messages"`
+}
+
+func Run(ctx context.Context, projectRef, channel, event, payload string, private bool) error {
+	if !json.Valid([]byte(payload)) {
+		return errors.Errorf("payload must be valid JSON: %s", payload)
+	}
+	endpoint, err := realtime.GetEndpoint(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	api := fetcher.NewFetcher(
+		endpoint.Url,
+		fetcher.WithBearerToken(endpoint.ServiceRoleKey),
+		fetcher.WithRequestEditor(func(req *http.Request) {
+			req.Header.Add("apikey", endpoint.ServiceRoleKey)
+		}),
+		fetcher.WithUserAgent("SupabaseCLI/"+utils.Version),
+		fetcher.WithExpectedStatus(http.StatusAccepted),
+	)
+	body := broadcastRequest{Messages: []broadcastMessage{{
+		// Broadcast API expects the channel name without realtime: prefix
+		Topic:   channel,
+		Event:   event,
+		Payload: json.RawMessage(payload),
+		Private: private,
+	}}}
+	resp, err := api.Send(ctx, http.MethodPost, "/realtime/v1/api/broadcast", body)
+	if err != nil {
+		return errors.Errorf("failed to broadcast message: %w", err)
+	}
+	defer resp.Body.Close()
+	fmt.Fprintln(os.Stderr, "Broadcasted", utils.Aqua(event), "to channel:", utils.Aqua(channel))
+	return nil
+}
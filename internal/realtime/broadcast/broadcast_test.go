@@ -0,0 +1,54 @@
+package broadcast
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestBroadcast(t *testing.T) {
+	utils.Config.Api.ExternalUrl = "http://127.0.0.1:54321"
+	utils.Config.Auth.ServiceRoleKey = "service-role-key"
+
+	t.Run("sends broadcast to local realtime", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.Config.Api.ExternalUrl).
+			Post("/realtime/v1/api/broadcast").
+			MatchHeader("apikey", "service-role-key").
+			JSON(map[string]any{"messages": []map[string]any{{
+				"topic":   "room1",
+				"event":   "chat",
+				"payload": map[string]any{"text": "hello"},
+			}}}).
+			Reply(http.StatusAccepted)
+		// Run test
+		err := Run(context.Background(), "", "room1", "chat", `{"text":"hello"}`, false)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid payload", func(t *testing.T) {
+		err := Run(context.Background(), "", "room1", "chat", `{text}`, false)
+		assert.ErrorContains(t, err, "payload must be valid JSON")
+	})
+
+	t.Run("throws error on unexpected status", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.Config.Api.ExternalUrl).
+			Post("/realtime/v1/api/broadcast").
+			Reply(http.StatusUnauthorized)
+		// Run test
+		err := Run(context.Background(), "", "room1", "chat", `{}`, true)
+		// Check error
+		assert.ErrorContains(t, err, "failed to broadcast message:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
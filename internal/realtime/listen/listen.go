@@ -0,0 +1,191 @@
+package listen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/gorilla/websocket"
+	"github.com/supabase/cli/internal/realtime"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type ListenOptions struct {
+	Event       string
+	Private     bool
+	AccessToken string
+	// Subscribes to postgres changes of schema.table when set
+	Table  string
+	Filter string
+}
+
+var heartbeatInterval = 25 * time.Second
+
+func Run(ctx context.Context, projectRef, channel string, opts ListenOptions) error {
+	endpoint, err := realtime.GetEndpoint(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	if len(opts.AccessToken) == 0 {
+		opts.AccessToken = endpoint.AnonKey
+	}
+	return Listen(ctx, endpoint.WebsocketUrl(), channel, opts, os.Stdout)
+}
+
+func Listen(ctx context.Context, url, channel string, opts ListenOptions, w io.Writer) error {
+	return Subscribe(ctx, url, channel, opts, func(msg realtime.Message) error {
+		return handleMessage(msg, opts.Event, w)
+	})
+}
+
+// Subscribe joins the channel and passes all messages other than replies and system
+// events to handle, until the context is cancelled or the server closes the channel.
+func Subscribe(ctx context.Context, url, channel string, opts ListenOptions, handle func(realtime.Message) error) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return errors.Errorf("failed to connect to realtime: %w", err)
+	}
+	defer conn.Close()
+	topic := realtime.Topic(channel)
+	join, err := newJoinMessage(topic, opts)
+	if err != nil {
+		return err
+	}
+	if err := conn.WriteJSON(join); err != nil {
+		return errors.Errorf("failed to join channel: %w", err)
+	}
+	// Unblock reads when context is cancelled
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+		for ref := 2; ; ref++ {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				heartbeat := realtime.Message{Topic: "phoenix", Event: "heartbeat", Payload: json.RawMessage("{}"), Ref: strconv.Itoa(ref)}
+				if err := conn.WriteJSON(heartbeat); err != nil {
+					fmt.Fprintln(utils.GetDebugLogger(), err)
+				}
+			}
+		}
+	}()
+	fmt.Fprintln(os.Stderr, "Listening on channel:", utils.Aqua(channel))
+	for {
+		var msg realtime.Message
+		if err := conn.ReadJSON(&msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Errorf("failed to read message: %w", err)
+		}
+		if err := handleProtocol(msg, join.Ref, handle); err != nil {
+			return err
+		}
+	}
+}
+
+func newJoinMessage(topic string, opts ListenOptions) (realtime.Message, error) {
+	config := map[string]any{
+		"broadcast": map[string]any{"self": true},
+		"presence":  map[string]any{"key": ""},
+		"private":   opts.Private,
+	}
+	if len(opts.Table) > 0 {
+		schema, table, found := strings.Cut(opts.Table, ".")
+		if !found {
+			schema, table = "public", opts.Table
+		}
+		change := map[string]string{"event": "*", "schema": schema, "table": table}
+		if len(opts.Filter) > 0 {
+			change["filter"] = opts.Filter
+		}
+		config["postgres_changes"] = []map[string]string{change}
+	}
+	payload, err := json.Marshal(map[string]any{
+		"config":       config,
+		"access_token": opts.AccessToken,
+	})
+	if err != nil {
+		return realtime.Message{}, errors.Errorf("failed to encode join payload: %w", err)
+	}
+	return realtime.Message{Topic: topic, Event: "phx_join", Payload: payload, Ref: "1", JoinRef: "1"}, nil
+}
+
+type replyPayload struct {
+	Status   string          `json:"status"`
+	Response json.RawMessage `json:"response"`
+}
+
+type broadcastPayload struct {
+	Event   string          `json:"event"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+func handleProtocol(msg realtime.Message, joinRef string, handle func(realtime.Message) error) error {
+	switch msg.Event {
+	case "phx_reply":
+		var reply replyPayload
+		if err := json.Unmarshal(msg.Payload, &reply); err != nil {
+			return errors.Errorf("failed to parse reply: %w", err)
+		}
+		if msg.Ref != joinRef {
+			return nil
+		} else if reply.Status != "ok" {
+			return errors.Errorf("failed to join channel: %s", string(reply.Response))
+		}
+		fmt.Fprintln(os.Stderr, "Joined channel. Press Ctrl+C to stop.")
+	case "phx_error", "phx_close":
+		return errors.Errorf("channel closed by server: %s", string(msg.Payload))
+	case "system":
+		fmt.Fprintln(os.Stderr, "System:", string(msg.Payload))
+	default:
+		return handle(msg)
+	}
+	return nil
+}
+
+func handleMessage(msg realtime.Message, event string, w io.Writer) error {
+	switch msg.Event {
+	case "presence_state", "presence_diff":
+		return printMessage(msg.Event, msg.Payload, w)
+	case "broadcast":
+		var body broadcastPayload
+		if err := json.Unmarshal(msg.Payload, &body); err != nil {
+			return errors.Errorf("failed to parse broadcast: %w", err)
+		}
+		if event == "*" || event == body.Event {
+			return printMessage(body.Event, body.Payload, w)
+		}
+	case "postgres_changes":
+		return printMessage(msg.Event, msg.Payload, w)
+	}
+	return nil
+}
+
+func printMessage(event string, payload json.RawMessage, w io.Writer) error {
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		var value any
+		if err := json.Unmarshal(payload, &value); err != nil {
+			return errors.Errorf("failed to parse payload: %w", err)
+		}
+		return utils.EncodeOutput(utils.OutputFormat.Value, w, map[string]any{
+			"event":   event,
+			"payload": value,
+		})
+	}
+	timestamp := time.Now().Format(time.TimeOnly)
+	fmt.Fprintf(w, "[%s] %s %s\n", timestamp, utils.Bold(event), string(payload))
+	return nil
+}
@@ -0,0 +1,94 @@
+package listen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/realtime"
+)
+
+func mockServer(t *testing.T, handler func(*websocket.Conn)) string {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		handler(conn)
+	}))
+	t.Cleanup(server.Close)
+	return strings.Replace(server.URL, "http", "ws", 1)
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+func TestListen(t *testing.T) {
+	t.Run("prints matching broadcasts", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var join realtime.Message
+		url := mockServer(t, func(conn *websocket.Conn) {
+			if err := conn.ReadJSON(&join); err != nil {
+				t.Error(err)
+				return
+			}
+			replies := []realtime.Message{
+				{Topic: join.Topic, Event: "phx_reply", Ref: "1", Payload: json.RawMessage(`{"status":"ok","response":{}}`)},
+				{Topic: join.Topic, Event: "broadcast", Payload: json.RawMessage(`{"event":"ignored","payload":{}}`)},
+				{Topic: join.Topic, Event: "broadcast", Payload: json.RawMessage(`{"event":"chat","payload":{"text":"hello"}}`)},
+			}
+			for _, r := range replies {
+				assert.NoError(t, conn.WriteJSON(r))
+			}
+			// Wait for client to disconnect
+			_, _, _ = conn.ReadMessage()
+		})
+		// Run test
+		var out bytes.Buffer
+		w := writerFunc(func(p []byte) (int, error) {
+			defer cancel()
+			return out.Write(p)
+		})
+		err := Listen(ctx, url, "room1", ListenOptions{Event: "chat", Table: "messages", Filter: "room_id=eq.1"}, w)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "realtime:room1", join.Topic)
+		assert.Contains(t, string(join.Payload), `"postgres_changes":[{"event":"*","filter":"room_id=eq.1","schema":"public","table":"messages"}]`)
+		assert.Contains(t, out.String(), `{"text":"hello"}`)
+		assert.NotContains(t, out.String(), "ignored")
+	})
+
+	t.Run("throws error on join failure", func(t *testing.T) {
+		url := mockServer(t, func(conn *websocket.Conn) {
+			var join realtime.Message
+			if err := conn.ReadJSON(&join); err != nil {
+				t.Error(err)
+				return
+			}
+			reply := realtime.Message{Topic: join.Topic, Event: "phx_reply", Ref: "1", Payload: json.RawMessage(`{"status":"error","response":{"reason":"Unauthorized"}}`)}
+			assert.NoError(t, conn.WriteJSON(reply))
+		})
+		// Run test
+		err := Listen(context.Background(), url, "room1", ListenOptions{Event: "*", Private: true}, &bytes.Buffer{})
+		// Check error
+		assert.ErrorContains(t, err, `failed to join channel: {"reason":"Unauthorized"}`)
+	})
+
+	t.Run("throws error on connection failure", func(t *testing.T) {
+		err := Listen(context.Background(), "ws://127.0.0.1:0", "room1", ListenOptions{}, &bytes.Buffer{})
+		assert.ErrorContains(t, err, "failed to connect to realtime:")
+	})
+}
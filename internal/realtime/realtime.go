@@ -0,0 +1,55 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/tenant"
+)
+
+type Endpoint struct {
+	// Base url of the API gateway, ie. http://127.0.0.1:54321
+	Url            string
+	AnonKey        string
+	ServiceRoleKey string
+}
+
+// Resolves the local API gateway when project ref is empty, otherwise the hosted project.
+func GetEndpoint(ctx context.Context, projectRef string) (Endpoint, error) {
+	if len(projectRef) == 0 {
+		return Endpoint{
+			Url:            utils.Config.Api.ExternalUrl,
+			AnonKey:        utils.Config.Auth.AnonKey,
+			ServiceRoleKey: utils.Config.Auth.ServiceRoleKey,
+		}, nil
+	}
+	keys, err := tenant.GetApiKeys(ctx, projectRef)
+	if err != nil {
+		return Endpoint{}, err
+	}
+	return Endpoint{
+		Url:            "https://" + utils.GetSupabaseHost(projectRef),
+		AnonKey:        keys.Anon,
+		ServiceRoleKey: keys.ServiceRole,
+	}, nil
+}
+
+func (e Endpoint) WebsocketUrl() string {
+	url := strings.Replace(strings.TrimSuffix(e.Url, "/"), "http", "ws", 1)
+	return url + "/realtime/v1/websocket?vsn=1.0.0&apikey=" + e.AnonKey
+}
+
+// Phoenix channel message, see https://hexdocs.pm/phoenix/writing_a_channels_client.html
+type Message struct {
+	Topic   string          `json:"topic"`
+	Event   string          `json:"event"`
+	Payload json.RawMessage `json:"payload"`
+	Ref     string          `json:"ref,omitempty"`
+	JoinRef string          `json:"join_ref,omitempty"`
+}
+
+func Topic(channel string) string {
+	return "realtime:" + channel
+}
@@ -0,0 +1,78 @@
+package render
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+const defaultLocale = "en"
+
+var (
+	//go:embed messages/*.json
+	catalogFS embed.FS
+
+	catalog     map[string]string
+	catalogOnce sync.Once
+)
+
+// T returns the message for key in the current locale, formatted with args as by
+// fmt.Sprintf. Messages missing from a locale fall back to English, and unknown keys
+// are returned as is.
+func T(key string, args ...any) string {
+	catalogOnce.Do(func() {
+		catalog = loadCatalog(Locale())
+	})
+	format, ok := catalog[key]
+	if !ok {
+		format = key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Locale returns the language code from the POSIX locale environment variables, for
+// example "de" for LANG=de_DE.UTF-8.
+func Locale() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if len(value) == 0 {
+			continue
+		}
+		lang, _, _ := strings.Cut(value, ".")
+		lang, _, _ = strings.Cut(lang, "_")
+		if lang == "C" || lang == "POSIX" {
+			return defaultLocale
+		}
+		return strings.ToLower(lang)
+	}
+	return defaultLocale
+}
+
+func loadCatalog(locale string) map[string]string {
+	result := readCatalog(defaultLocale)
+	if locale != defaultLocale {
+		for k, v := range readCatalog(locale) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// Locales without a catalog are silently ignored since English is always available.
+func readCatalog(locale string) map[string]string {
+	result := map[string]string{}
+	data, err := catalogFS.ReadFile("messages/" + locale + ".json")
+	if err != nil {
+		return result
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to parse message catalog:", err)
+	}
+	return result
+}
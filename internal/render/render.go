@@ -0,0 +1,55 @@
+// Package render is the terminal output layer shared by all commands. It decides whether
+// colors and spinners are shown, renders tables to fit the terminal width, and looks up
+// human readable messages from the catalog of the current locale.
+package render
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+// Init applies the color settings to all lipgloss styles. It should be called once
+// after flags and environment variables are parsed.
+func Init() {
+	if !ColorEnabled() {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// ColorEnabled reports whether styled output is allowed, following https://no-color.org.
+func ColorEnabled() bool {
+	return len(os.Getenv("NO_COLOR")) == 0 && !IsDumb()
+}
+
+// IsDumb reports whether the terminal is unable to handle escape sequences.
+func IsDumb() bool {
+	return os.Getenv("TERM") == "dumb"
+}
+
+// SpinnerEnabled reports whether animated spinners and progress bars may be drawn.
+// Otherwise status messages are printed line by line, which suits CI logs better.
+// Spinners are drawn on stderr, so redirecting it also disables them.
+func SpinnerEnabled() bool {
+	if viper.GetBool("no-spinner") || IsDumb() || len(os.Getenv("CI")) > 0 {
+		return false
+	}
+	return term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+// Width returns the number of columns of the terminal attached to stdout, or 0 when
+// stdout is not a terminal.
+func Width() int {
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil {
+		return 0
+	}
+	return width
+}
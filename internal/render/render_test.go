@@ -0,0 +1,130 @@
+package render
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColorEnabled(t *testing.T) {
+	t.Run("disables colors with NO_COLOR", func(t *testing.T) {
+		t.Setenv("TERM", "xterm-256color")
+		t.Setenv("NO_COLOR", "1")
+		assert.False(t, ColorEnabled())
+	})
+
+	t.Run("disables colors in dumb terminal", func(t *testing.T) {
+		t.Setenv("TERM", "dumb")
+		t.Setenv("NO_COLOR", "")
+		assert.False(t, ColorEnabled())
+	})
+
+	t.Run("enables colors by default", func(t *testing.T) {
+		t.Setenv("TERM", "xterm-256color")
+		t.Setenv("NO_COLOR", "")
+		assert.True(t, ColorEnabled())
+	})
+}
+
+func TestSpinnerEnabled(t *testing.T) {
+	t.Run("disables spinner in CI", func(t *testing.T) {
+		t.Setenv("TERM", "xterm-256color")
+		t.Setenv("CI", "true")
+		assert.False(t, SpinnerEnabled())
+	})
+
+	t.Run("disables spinner when stderr is redirected", func(t *testing.T) {
+		t.Setenv("TERM", "xterm-256color")
+		t.Setenv("CI", "")
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		defer r.Close()
+		defer w.Close()
+		stderr := os.Stderr
+		os.Stderr = w
+		t.Cleanup(func() { os.Stderr = stderr })
+		assert.False(t, SpinnerEnabled())
+	})
+}
+
+func TestRecords(t *testing.T) {
+	table := `Some text before
+
+|ID|NAME|DESCRIPTION|
+|-|-|:-:|
+|` + "`1`" + `|first|a \| b|
+|2|second|a description that is longer than the terminal width|
+`
+
+	t.Run("prints rows as key value pairs", func(t *testing.T) {
+		out := Records(table, 40)
+		assert.Equal(t, `-[ RECORD 1 ]---------------------------
+ID          | 1
+NAME        | first
+DESCRIPTION | a | b
+-[ RECORD 2 ]---------------------------
+ID          | 2
+NAME        | second
+DESCRIPTION | a description that is
+            | longer than the terminal
+            | width
+`, out)
+	})
+
+	t.Run("ignores text without table", func(t *testing.T) {
+		assert.Empty(t, Records("no table here", 40))
+	})
+}
+
+func TestMessages(t *testing.T) {
+	t.Run("parses locale from environment", func(t *testing.T) {
+		t.Setenv("LC_ALL", "")
+		t.Setenv("LC_MESSAGES", "")
+		t.Setenv("LANG", "de_DE.UTF-8")
+		assert.Equal(t, "de", Locale())
+	})
+
+	t.Run("defaults to english for C locale", func(t *testing.T) {
+		t.Setenv("LC_ALL", "C")
+		assert.Equal(t, "en", Locale())
+	})
+
+	t.Run("falls back to english catalog", func(t *testing.T) {
+		catalog := loadCatalog("xx")
+		assert.Equal(t, "Finished %s.", catalog["finished"])
+	})
+
+	t.Run("formats message with args", func(t *testing.T) {
+		assert.Equal(t, "Finished supabase link.", T("finished", "supabase link"))
+	})
+
+	t.Run("returns unknown key as is", func(t *testing.T) {
+		assert.Equal(t, "unknown", T("unknown"))
+	})
+
+	t.Run("declares all keys in english catalog", func(t *testing.T) {
+		catalog := readCatalog(defaultLocale)
+		pattern := regexp.MustCompile(`render\.T\("([^"]+)"`)
+		// Walk the module root for call sites
+		err := filepath.WalkDir("../..", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			for _, m := range pattern.FindAllStringSubmatch(string(data), -1) {
+				assert.Contains(t, catalog, m[1], "missing message in %s", path)
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,130 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/go-errors/errors"
+	"github.com/muesli/reflow/ansi"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/muesli/reflow/wrap"
+)
+
+// Table renders a markdown table to w. When the rendered table is wider than the
+// terminal, each row is printed as a separate record of key value pairs instead.
+func Table(w io.Writer, markdown string) error {
+	style := glamour.WithAutoStyle()
+	if !ColorEnabled() {
+		style = glamour.WithStandardStyle("notty")
+	}
+	r, err := glamour.NewTermRenderer(style, glamour.WithWordWrap(-1))
+	if err != nil {
+		return errors.Errorf("failed to initialise terminal renderer: %w", err)
+	}
+	out, err := r.Render(markdown)
+	if err != nil {
+		return errors.Errorf("failed to render markdown: %w", err)
+	}
+	if width := Width(); width > 0 && maxLineWidth(out) > width {
+		out = Records(markdown, width)
+	}
+	_, err = fmt.Fprint(w, out)
+	return err
+}
+
+func maxLineWidth(s string) int {
+	var result int
+	for _, line := range strings.Split(s, "\n") {
+		result = max(result, ansi.PrintableRuneWidth(line))
+	}
+	return result
+}
+
+// Records formats every row of a markdown table as a block of key value pairs, in the
+// style of psql's expanded display, wrapping values to fit within width.
+func Records(markdown string, width int) string {
+	header, rows := parseTable(markdown)
+	var keyWidth int
+	for _, h := range header {
+		keyWidth = max(keyWidth, ansi.PrintableRuneWidth(h))
+	}
+	valueWidth := max(width-keyWidth-3, 10)
+	var sb strings.Builder
+	for i, row := range rows {
+		title := fmt.Sprintf("-[ RECORD %d ]", i+1)
+		sb.WriteString(title + strings.Repeat("-", max(width-len(title), 0)) + "\n")
+		for j, key := range header {
+			var value string
+			if j < len(row) {
+				value = row[j]
+			}
+			lines := strings.Split(wrap.String(wordwrap.String(value, valueWidth), valueWidth), "\n")
+			for k, line := range lines {
+				if k > 0 {
+					key = ""
+				}
+				fmt.Fprintf(&sb, "%-*s | %s\n", keyWidth, key, line)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// Parses the header and body of a markdown table, ignoring the delimiter row and any
+// text outside the table.
+func parseTable(markdown string) ([]string, [][]string) {
+	var header []string
+	var rows [][]string
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "|") {
+			continue
+		}
+		cells := splitRow(line)
+		if header == nil {
+			header = cells
+		} else if !isDelimiter(cells) {
+			rows = append(rows, cells)
+		}
+	}
+	return header, rows
+}
+
+func splitRow(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, trimCell(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, trimCell(cell.String()))
+}
+
+// Removes padding and inline code markers, which only matter to the markdown renderer.
+func trimCell(cell string) string {
+	cell = strings.TrimSpace(cell)
+	if len(cell) > 1 && strings.HasPrefix(cell, "`") && strings.HasSuffix(cell, "`") {
+		cell = cell[1 : len(cell)-1]
+	}
+	return strings.TrimSpace(cell)
+}
+
+func isDelimiter(cells []string) bool {
+	for _, c := range cells {
+		if len(strings.Trim(c, "-: ")) > 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -4,18 +4,36 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
+	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
 )
 
-func Run(ctx context.Context, projectRef string, dbCidrsToAllow []string, bypassCidrChecks bool) error {
+func Run(ctx context.Context, projectRef string, dbCidrsToAllow []string, bypassCidrChecks, appendCidrs, dryRun bool) error {
 	// 1. separate CIDR to v4 and v6
 	body := api.V1UpdateNetworkRestrictionsJSONRequestBody{
 		DbAllowedCidrs:   &[]string{},
 		DbAllowedCidrsV6: &[]string{},
 	}
+	if appendCidrs {
+		resp, err := utils.GetSupabase().V1GetNetworkRestrictionsWithResponse(ctx, projectRef)
+		if err != nil {
+			return errors.Errorf("failed to retrieve network restrictions: %w", err)
+		}
+		if resp.JSON200 == nil {
+			return errors.New("failed to retrieve network restrictions; received: " + string(resp.Body))
+		}
+		if cidrs := resp.JSON200.Config.DbAllowedCidrs; cidrs != nil {
+			*body.DbAllowedCidrs = append(*body.DbAllowedCidrs, *cidrs...)
+		}
+		if cidrs := resp.JSON200.Config.DbAllowedCidrsV6; cidrs != nil {
+			*body.DbAllowedCidrsV6 = append(*body.DbAllowedCidrsV6, *cidrs...)
+		}
+	}
 	for _, cidr := range dbCidrsToAllow {
 		ip, _, err := net.ParseCIDR(cidr)
 		if err != nil {
@@ -25,11 +43,16 @@ func Run(ctx context.Context, projectRef string, dbCidrsToAllow []string, bypass
 			return errors.Errorf("private IP provided: %s", cidr)
 		}
 		if ip.To4() != nil {
-			*body.DbAllowedCidrs = append(*body.DbAllowedCidrs, cidr)
+			*body.DbAllowedCidrs = appendUnique(*body.DbAllowedCidrs, cidr)
 		} else {
-			*body.DbAllowedCidrsV6 = append(*body.DbAllowedCidrsV6, cidr)
+			*body.DbAllowedCidrsV6 = appendUnique(*body.DbAllowedCidrsV6, cidr)
 		}
 	}
+	if dryRun {
+		fmt.Fprintln(os.Stderr, "DRY RUN: network restrictions will not be applied.")
+		printAllowList(*body.DbAllowedCidrs, *body.DbAllowedCidrsV6)
+		return nil
+	}
 
 	// 2. update restrictions
 	resp, err := utils.GetSupabase().V1UpdateNetworkRestrictionsWithResponse(ctx, projectRef, body)
@@ -40,8 +63,21 @@ func Run(ctx context.Context, projectRef string, dbCidrsToAllow []string, bypass
 		return errors.New("failed to apply network restrictions: " + string(resp.Body))
 	}
 
-	fmt.Printf("DB Allowed IPv4 CIDRs: %+v\n", resp.JSON201.Config.DbAllowedCidrs)
-	fmt.Printf("DB Allowed IPv6 CIDRs: %+v\n", resp.JSON201.Config.DbAllowedCidrsV6)
+	printAllowList(cast.Val(resp.JSON201.Config.DbAllowedCidrs, nil), cast.Val(resp.JSON201.Config.DbAllowedCidrsV6, nil))
 	fmt.Printf("Restrictions applied successfully: %+v\n", resp.JSON201.Status == "applied")
 	return nil
 }
+
+func printAllowList(v4, v6 []string) {
+	fmt.Printf("DB Allowed IPv4 CIDRs: %s\n", strings.Join(v4, ", "))
+	fmt.Printf("DB Allowed IPv6 CIDRs: %s\n", strings.Join(v6, ", "))
+}
+
+func appendUnique(cidrs []string, cidr string) []string {
+	for _, c := range cidrs {
+		if c == cidr {
+			return cidrs
+		}
+	}
+	return append(cidrs, cidr)
+}
@@ -2,12 +2,15 @@ package update
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"os"
 	"testing"
 
 	"github.com/go-errors/errors"
 	"github.com/h2non/gock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/supabase/cli/internal/testing/apitest"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/api"
@@ -34,7 +37,7 @@ func TestUpdateRestrictionsCommand(t *testing.T) {
 				Status: api.NetworkRestrictionsResponseStatus("applied"),
 			})
 		// Run test
-		err := Run(context.Background(), projectRef, []string{"12.3.4.5/32", "2001:db8:abcd:0012::0/64", "1.2.3.1/24"}, false)
+		err := Run(context.Background(), projectRef, []string{"12.3.4.5/32", "2001:db8:abcd:0012::0/64", "1.2.3.1/24"}, false, false, false)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -53,7 +56,7 @@ func TestUpdateRestrictionsCommand(t *testing.T) {
 			}).
 			ReplyError(errNetwork)
 		// Run test
-		err := Run(context.Background(), projectRef, []string{}, true)
+		err := Run(context.Background(), projectRef, []string{}, true, false, false)
 		// Check error
 		assert.ErrorIs(t, err, errNetwork)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -71,7 +74,7 @@ func TestUpdateRestrictionsCommand(t *testing.T) {
 			}).
 			Reply(http.StatusServiceUnavailable)
 		// Run test
-		err := Run(context.Background(), projectRef, []string{}, true)
+		err := Run(context.Background(), projectRef, []string{}, true, false, false)
 		// Check error
 		assert.ErrorContains(t, err, "failed to apply network restrictions:")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -99,7 +102,7 @@ func TestValidateCIDR(t *testing.T) {
 				Status: api.NetworkRestrictionsResponseStatus("applied"),
 			})
 		// Run test
-		err := Run(context.Background(), projectRef, []string{"10.0.0.0/8"}, true)
+		err := Run(context.Background(), projectRef, []string{"10.0.0.0/8"}, true, false, false)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -107,15 +110,69 @@ func TestValidateCIDR(t *testing.T) {
 
 	t.Run("throws error on private subnet", func(t *testing.T) {
 		// Run test
-		err := Run(context.Background(), projectRef, []string{"12.3.4.5/32", "10.0.0.0/8", "1.2.3.1/24"}, false)
+		err := Run(context.Background(), projectRef, []string{"12.3.4.5/32", "10.0.0.0/8", "1.2.3.1/24"}, false, false, false)
 		// Check error
 		assert.ErrorContains(t, err, "private IP provided: 10.0.0.0/8")
 	})
 
 	t.Run("throws error on invalid subnet", func(t *testing.T) {
 		// Run test
-		err := Run(context.Background(), projectRef, []string{"12.3.4.5", "10.0.0.0/8", "1.2.3.1/24"}, false)
+		err := Run(context.Background(), projectRef, []string{"12.3.4.5", "10.0.0.0/8", "1.2.3.1/24"}, false, false, false)
 		// Check error
 		assert.ErrorContains(t, err, "failed to parse IP: 12.3.4.5")
 	})
+
+	t.Run("appends to existing CIDRs", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/network-restrictions").
+			Reply(http.StatusOK).
+			JSON(api.NetworkRestrictionsResponse{
+				Config: api.NetworkRestrictionsRequest{
+					DbAllowedCidrs:   &[]string{"12.3.4.5/32"},
+					DbAllowedCidrsV6: &[]string{"2001:db8:abcd:0012::0/64"},
+				},
+			})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + projectRef + "/network-restrictions/apply").
+			MatchType("json").
+			JSON(api.NetworkRestrictionsRequest{
+				DbAllowedCidrs:   &[]string{"12.3.4.5/32", "1.2.3.1/24"},
+				DbAllowedCidrsV6: &[]string{"2001:db8:abcd:0012::0/64"},
+			}).
+			Reply(http.StatusCreated).
+			JSON(api.NetworkRestrictionsResponse{
+				Status: api.NetworkRestrictionsResponseStatus("applied"),
+			})
+		// Run test
+		err := Run(context.Background(), projectRef, []string{"1.2.3.1/24", "12.3.4.5/32"}, false, true, false)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips apply on dry run", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/network-restrictions").
+			Reply(http.StatusOK).
+			JSON(api.NetworkRestrictionsResponse{})
+		// Capture stdout
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		oldStdout := os.Stdout
+		os.Stdout = w
+		t.Cleanup(func() { os.Stdout = oldStdout })
+		// Run test
+		err = Run(context.Background(), projectRef, []string{"1.2.3.1/24", "12.3.4.5/32"}, false, true, true)
+		require.NoError(t, w.Close())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		output, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, "DB Allowed IPv4 CIDRs: 1.2.3.1/24, 12.3.4.5/32\nDB Allowed IPv6 CIDRs: \n", string(output))
+	})
 }
@@ -13,11 +13,21 @@ import (
 	"github.com/supabase/cli/pkg/api"
 )
 
-func Run(ctx context.Context, projectRef string, fsys afero.Fs) error {
+func Run(ctx context.Context, projectRef, slug string, fsys afero.Fs) error {
+	var known []string
+	if len(slug) > 0 {
+		var err error
+		if known, err = LoadScopes(slug, fsys); err != nil {
+			return err
+		}
+	}
 	secrets, err := GetSecretDigests(ctx, projectRef)
 	if err != nil {
 		return err
 	}
+	if len(slug) > 0 {
+		secrets = FilterByFunction(secrets, slug, known)
+	}
 
 	table := `|NAME|DIGEST|
 |-|-|
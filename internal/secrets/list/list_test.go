@@ -34,7 +34,7 @@ func TestSecretListCommand(t *testing.T) {
 				},
 			})
 		// Run test
-		err := Run(context.Background(), project, fsys)
+		err := Run(context.Background(), project, "", fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -45,7 +45,7 @@ func TestSecretListCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "", fsys)
+		err := Run(context.Background(), "", "", fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Unexpected error retrieving project secrets")
 	})
@@ -64,7 +64,7 @@ func TestSecretListCommand(t *testing.T) {
 			Get("/v1/projects/" + project + "/secrets").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), project, fsys)
+		err := Run(context.Background(), project, "", fsys)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -85,7 +85,7 @@ func TestSecretListCommand(t *testing.T) {
 			Reply(500).
 			JSON(map[string]string{"message": "unavailable"})
 		// Run test
-		err := Run(context.Background(), project, fsys)
+		err := Run(context.Background(), project, "", fsys)
 		// Check error
 		assert.ErrorContains(t, err, `Unexpected error retrieving project secrets: {"message":"unavailable"}`)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -106,9 +106,46 @@ func TestSecretListCommand(t *testing.T) {
 			Reply(200).
 			JSON(map[string]string{})
 		// Run test
-		err := Run(context.Background(), project, fsys)
+		err := Run(context.Background(), project, "", fsys)
 		// Check error
 		assert.ErrorContains(t, err, "json: cannot unmarshal object into Go value of type []api.SecretResponse")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 }
+
+func TestFilterByFunction(t *testing.T) {
+	secrets := []api.SecretResponse{
+		{Name: "HELLO_WORLD__STRIPE_KEY", Value: "a"},
+		{Name: "OTHER__STRIPE_KEY", Value: "b"},
+		{Name: "SENTRY_DSN", Value: "c"},
+		{Name: "LEGACY__TOKEN", Value: "d"},
+	}
+
+	t.Run("keeps full names of prefixed secrets", func(t *testing.T) {
+		// Run test
+		filtered := FilterByFunction(secrets, "hello-world", []string{"hello-world", "other"})
+		// Check output
+		assert.Equal(t, []api.SecretResponse{{Name: "HELLO_WORLD__STRIPE_KEY", Value: "a"}}, filtered)
+	})
+
+	t.Run("prefers longest matching prefix", func(t *testing.T) {
+		// Run test
+		filtered := FilterByFunction([]api.SecretResponse{
+			{Name: "HELLO__KEY", Value: "a"},
+			{Name: "HELLO__WORLD__KEY", Value: "b"},
+		}, "hello", []string{"hello__world"})
+		// Check output
+		assert.Equal(t, []api.SecretResponse{{Name: "HELLO__KEY", Value: "a"}}, filtered)
+	})
+}
+
+func TestAssertUniqueScopes(t *testing.T) {
+	t.Run("accepts distinct scopes", func(t *testing.T) {
+		assert.NoError(t, AssertUniqueScopes([]string{"hello-world", "hello", "hello-world"}))
+	})
+
+	t.Run("throws error on colliding slugs", func(t *testing.T) {
+		err := AssertUniqueScopes([]string{"hello-world", "Hello_World"})
+		assert.ErrorContains(t, err, "cannot both prefix secrets with HELLO_WORLD__")
+	})
+}
@@ -0,0 +1,101 @@
+package list
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// Secrets are stored per project, so those set for a function are prefixed by its
+// slug, ie. STRIPE_KEY of hello-world is stored as HELLO_WORLD__STRIPE_KEY.
+//
+// The prefix is part of the name: every function on the project can read every secret,
+// and must read its own by the prefixed name. It only keeps names from clashing.
+const scopeSeparator = "__"
+
+func ScopedName(slug, name string) string {
+	if len(slug) == 0 {
+		return name
+	}
+	return functionScope(slug) + name
+}
+
+func functionScope(slug string) string {
+	return strings.ToUpper(strings.ReplaceAll(slug, "-", "_")) + scopeSeparator
+}
+
+// Returns the slugs of functions declared in config.toml or found in the functions
+// directory, which are the only prefixes treated as belonging to a function.
+func LoadFunctionSlugs(fsys afero.Fs) ([]string, error) {
+	var slugs []string
+	for slug := range utils.Config.Functions {
+		slugs = append(slugs, slug)
+	}
+	paths, err := afero.Glob(fsys, filepath.Join(utils.FunctionsDir, "*"))
+	if err != nil {
+		return nil, errors.Errorf("failed to glob function slugs: %w", err)
+	}
+	for _, path := range paths {
+		slug := filepath.Base(path)
+		if isDir, err := afero.IsDir(fsys, path); err == nil && isDir && utils.FuncSlugPattern.MatchString(slug) && !slices.Contains(slugs, slug) {
+			slugs = append(slugs, slug)
+		}
+	}
+	slices.Sort(slugs)
+	return slugs, nil
+}
+
+// Slugs that only differ by case, dashes or underscores would share the same prefix,
+// ie. hello-world and hello_world, so they are rejected instead.
+func AssertUniqueScopes(slugs []string) error {
+	seen := make(map[string]string, len(slugs))
+	for _, slug := range slugs {
+		scope := functionScope(slug)
+		if other, ok := seen[scope]; ok && other != slug {
+			return errors.Errorf("Functions %s and %s cannot both prefix secrets with %s", utils.Aqua(other), utils.Aqua(slug), utils.Bold(scope))
+		}
+		seen[scope] = slug
+	}
+	return nil
+}
+
+// Validates the slug given by --function and returns the slugs of other known functions.
+func LoadScopes(slug string, fsys afero.Fs) ([]string, error) {
+	if err := utils.ValidateFunctionSlug(slug); err != nil {
+		return nil, err
+	}
+	known, err := LoadFunctionSlugs(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if err := AssertUniqueScopes(append(known, slug)); err != nil {
+		return nil, err
+	}
+	return known, nil
+}
+
+// Returns the secrets prefixed by the given slug with their full names. Secrets prefixed
+// by other known functions are excluded, ie. HELLO__WORLD__KEY belongs to hello__world
+// rather than hello.
+func FilterByFunction(secrets []api.SecretResponse, slug string, known []string) []api.SecretResponse {
+	prefix := functionScope(slug)
+	var result []api.SecretResponse
+	for _, s := range secrets {
+		if !strings.HasPrefix(s.Name, prefix) {
+			continue
+		}
+		// The longest prefix wins
+		if !slices.ContainsFunc(known, func(k string) bool {
+			other := functionScope(k)
+			return len(other) > len(prefix) && strings.HasPrefix(s.Name, other)
+		}) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
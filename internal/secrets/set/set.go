@@ -12,11 +12,18 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/joho/godotenv"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/secrets/list"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/api"
 )
 
-func Run(ctx context.Context, projectRef, envFilePath string, args []string, fsys afero.Fs) error {
+func Run(ctx context.Context, projectRef, envFilePath, slug string, args []string, fsys afero.Fs) error {
+	if len(slug) > 0 {
+		if _, err := list.LoadScopes(slug, fsys); err != nil {
+			return err
+		}
+	}
 	// 1. Sanity checks.
 	envMap := make(map[string]string, len(args))
 	if len(envFilePath) > 0 {
@@ -48,7 +55,7 @@ func Run(ctx context.Context, projectRef, envFilePath string, args []string, fsy
 			continue
 		}
 		secret := api.CreateSecretBody{
-			Name:  name,
+			Name:  list.ScopedName(slug, name),
 			Value: value,
 		}
 		secrets = append(secrets, secret)
@@ -63,7 +70,7 @@ func Run(ctx context.Context, projectRef, envFilePath string, args []string, fsy
 		return errors.New("Unexpected error setting project secrets: " + string(resp.Body))
 	}
 
-	fmt.Println("Finished " + utils.Aqua("supabase secrets set") + ".")
+	fmt.Println(render.T("finished", utils.Aqua("supabase secrets set")))
 	return nil
 }
 
@@ -35,7 +35,29 @@ func TestSecretSetCommand(t *testing.T) {
 			JSON(api.V1BulkCreateSecretsJSONRequestBody{dummy}).
 			Reply(http.StatusCreated)
 		// Run test
-		err := Run(context.Background(), project, "", []string{dummyEnv}, fsys)
+		err := Run(context.Background(), project, "", "", []string{dummyEnv}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("Sets secret prefixed by function", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/secrets").
+			MatchType("json").
+			JSON(api.V1BulkCreateSecretsJSONRequestBody{{Name: "HELLO_WORLD__my_name", Value: "my_value"}}).
+			Reply(http.StatusCreated)
+		// Run test
+		err := Run(context.Background(), project, "", "hello-world", []string{dummyEnv}, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -58,7 +80,7 @@ func TestSecretSetCommand(t *testing.T) {
 			JSON(api.V1BulkCreateSecretsJSONRequestBody{dummy}).
 			Reply(http.StatusCreated)
 		// Run test
-		err := Run(context.Background(), project, "/tmp/.env", []string{}, fsys)
+		err := Run(context.Background(), project, "/tmp/.env", "", []string{}, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -73,7 +95,7 @@ func TestSecretSetCommand(t *testing.T) {
 		token := apitest.RandomAccessToken(t)
 		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
 		// Run test
-		err := Run(context.Background(), project, "", []string{}, fsys)
+		err := Run(context.Background(), project, "", "", []string{}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "No arguments found. Use --env-file to read from a .env file.")
 	})
@@ -87,7 +109,7 @@ func TestSecretSetCommand(t *testing.T) {
 		token := apitest.RandomAccessToken(t)
 		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
 		// Run test
-		err := Run(context.Background(), project, "", []string{"malformed"}, fsys)
+		err := Run(context.Background(), project, "", "", []string{"malformed"}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Invalid secret pair: malformed. Must be NAME=VALUE.")
 	})
@@ -108,7 +130,7 @@ func TestSecretSetCommand(t *testing.T) {
 			JSON(api.V1BulkCreateSecretsJSONRequestBody{dummy}).
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), project, "", []string{dummyEnv}, fsys)
+		err := Run(context.Background(), project, "", "", []string{dummyEnv}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -131,7 +153,7 @@ func TestSecretSetCommand(t *testing.T) {
 			Reply(500).
 			JSON(map[string]string{"message": "unavailable"})
 		// Run test
-		err := Run(context.Background(), project, "", []string{dummyEnv}, fsys)
+		err := Run(context.Background(), project, "", "", []string{dummyEnv}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, `Unexpected error setting project secrets: {"message":"unavailable"}`)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
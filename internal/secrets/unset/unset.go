@@ -9,20 +9,39 @@ import (
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/secrets/list"
 	"github.com/supabase/cli/internal/utils"
 )
 
-func Run(ctx context.Context, projectRef string, args []string, fsys afero.Fs) error {
+func Run(ctx context.Context, projectRef, slug string, args []string, fsys afero.Fs) error {
+	var known []string
+	if len(slug) > 0 {
+		var err error
+		if known, err = list.LoadScopes(slug, fsys); err != nil {
+			return err
+		}
+	}
 	if len(args) == 0 {
 		secrets, err := list.GetSecretDigests(ctx, projectRef)
 		if err != nil {
 			return err
 		}
-		for _, secret := range secrets {
-			if !strings.HasPrefix(secret.Name, "SUPABASE_") {
+		if len(slug) > 0 {
+			// Only unset secrets prefixed by this function, not those of other functions
+			for _, secret := range list.FilterByFunction(secrets, slug, known) {
 				args = append(args, secret.Name)
 			}
+		} else {
+			for _, secret := range secrets {
+				if !strings.HasPrefix(secret.Name, "SUPABASE_") {
+					args = append(args, secret.Name)
+				}
+			}
+		}
+	} else {
+		for i, name := range args {
+			args[i] = list.ScopedName(slug, name)
 		}
 	}
 	// 1. Sanity checks.
@@ -44,6 +63,6 @@ func Run(ctx context.Context, projectRef string, args []string, fsys afero.Fs) e
 	if resp.StatusCode() != http.StatusOK {
 		return errors.New("Unexpected error unsetting project secrets: " + string(resp.Body))
 	}
-	fmt.Println("Finished " + utils.Aqua("supabase secrets unset") + ".")
+	fmt.Println(render.T("finished", utils.Aqua("supabase secrets unset")))
 	return nil
 }
@@ -30,7 +30,7 @@ func TestSecretUnsetCommand(t *testing.T) {
 			JSON(api.V1BulkDeleteSecretsJSONRequestBody{"my-secret"}).
 			Reply(200)
 		// Run test
-		err := Run(context.Background(), project, []string{"my-secret"}, fsys)
+		err := Run(context.Background(), project, "", []string{"my-secret"}, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -52,7 +52,7 @@ func TestSecretUnsetCommand(t *testing.T) {
 			JSON(api.V1BulkDeleteSecretsJSONRequestBody{"my-secret"}).
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), project, []string{"my-secret"}, fsys)
+		err := Run(context.Background(), project, "", []string{"my-secret"}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -75,7 +75,7 @@ func TestSecretUnsetCommand(t *testing.T) {
 			Reply(500).
 			JSON(map[string]string{"message": "unavailable"})
 		// Run test
-		err := Run(context.Background(), project, []string{"my-secret"}, fsys)
+		err := Run(context.Background(), project, "", []string{"my-secret"}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, `Unexpected error unsetting project secrets: {"message":"unavailable"}`)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
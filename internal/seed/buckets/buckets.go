@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/storage/cache"
 	"github.com/supabase/cli/internal/storage/client"
 	"github.com/supabase/cli/internal/utils"
 )
@@ -14,6 +15,7 @@ func Run(ctx context.Context, projectRef string, interactive bool, fsys afero.Fs
 	if err != nil {
 		return err
 	}
+	defer cache.Invalidate(projectRef, fsys)
 	console := utils.NewConsole()
 	if !interactive {
 		console.IsTTY = false
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"sync"
 
@@ -17,25 +18,33 @@ var suggestLinkCommand = fmt.Sprintf("Run %s to sync your local image versions w
 
 func Run(ctx context.Context, fsys afero.Fs) error {
 	_ = utils.LoadConfigFS(fsys)
-	serviceImages := GetServiceImages()
+	serviceImages := utils.Config.ListServiceImages()
 
 	var linked map[string]string
 	if projectRef, err := flags.LoadProjectRef(fsys); err == nil {
 		linked = GetRemoteImages(ctx, projectRef)
 	}
 
-	table := `|SERVICE IMAGE|LOCAL|LINKED|
-|-|-|-|
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, serviceImages)
+	}
+
+	table := `|SERVICE IMAGE|LOCAL|DEFAULT|LINKED|
+|-|-|-|-|
 `
 	for _, image := range serviceImages {
-		parts := strings.Split(image, ":")
-		version, ok := linked[image]
+		name, local, _ := strings.Cut(image.Image, ":")
+		version, ok := linked[image.Image]
 		if !ok {
 			version = "-"
-		} else if parts[1] != version && image != utils.Config.Db.Image {
+		} else if local != version && image.Image != utils.Config.Db.Image {
 			utils.CmdSuggestion = suggestLinkCommand
 		}
-		table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", parts[0], parts[1], version)
+		if image.Pinned {
+			local += " (pinned)"
+		}
+		_, defaultVersion, _ := strings.Cut(image.Default, ":")
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%s`|\n", name, local, defaultVersion, version)
 	}
 
 	return list.RenderTable(table)
@@ -58,6 +67,9 @@ func GetServiceImages() []string {
 
 func GetRemoteImages(ctx context.Context, projectRef string) map[string]string {
 	linked := make(map[string]string, 4)
+	if utils.IsOffline() {
+		return linked
+	}
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
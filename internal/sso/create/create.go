@@ -58,7 +58,11 @@ func Run(ctx context.Context, params RunParams) error {
 	}
 
 	if params.Domains != nil {
-		body.Domains = &params.Domains
+		domains, err := saml.NormalizeDomains(params.Domains)
+		if err != nil {
+			return err
+		}
+		body.Domains = &domains
 	}
 
 	resp, err := utils.GetSupabase().V1CreateASsoProviderWithResponse(ctx, params.ProjectRef, body)
@@ -0,0 +1,89 @@
+package create
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestSSOProvidersCreateCommand(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	projectRef := apitest.RandomProjectRef()
+
+	t.Run("creates provider from metadata file", func(t *testing.T) {
+		// Setup in-memory fs
+		Fs = afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(Fs, "metadata.xml", []byte(`<?xml version="1.0"?>`), 0644))
+		require.NoError(t, afero.WriteFile(Fs, "mapping.json", []byte(`{"keys":{"email":{"name":"mail"}}}`), 0644))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + projectRef + "/config/auth/sso/providers").
+			MatchType("json").
+			JSON(map[string]any{
+				"type":         "saml",
+				"metadata_xml": `<?xml version="1.0"?>`,
+				"attribute_mapping": map[string]any{
+					"keys": map[string]any{"email": map[string]any{"name": "mail"}},
+				},
+				"domains": []string{"example.com", "sub.example.org"},
+			}).
+			Reply(http.StatusCreated).
+			JSON(api.CreateProviderResponse{Id: "8682fcf4-4056-455c-bd93-f33295604929"})
+		// Run test
+		err := Run(context.Background(), RunParams{
+			ProjectRef:       projectRef,
+			Format:           utils.OutputJson,
+			Type:             "saml",
+			Domains:          []string{"Example.com", " sub.example.org"},
+			MetadataFile:     "metadata.xml",
+			AttributeMapping: "mapping.json",
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid domain", func(t *testing.T) {
+		Fs = afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), RunParams{
+			ProjectRef:        projectRef,
+			Type:              "saml",
+			Domains:           []string{"admin@example.com"},
+			MetadataURL:       "https://example.com/metadata",
+			SkipURLValidation: true,
+		})
+		// Check error
+		assert.ErrorContains(t, err, `invalid email domain "admin@example.com"`)
+	})
+
+	t.Run("throws error on saml disabled", func(t *testing.T) {
+		Fs = afero.NewMemMapFs()
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + projectRef + "/config/auth/sso/providers").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Run(context.Background(), RunParams{
+			ProjectRef:        projectRef,
+			Type:              "saml",
+			MetadataURL:       "https://example.com/metadata",
+			SkipURLValidation: true,
+		})
+		// Check error
+		assert.ErrorContains(t, err, "SAML 2.0 support is not enabled for this project.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
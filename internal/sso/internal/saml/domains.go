@@ -0,0 +1,27 @@
+package saml
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+var domainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+// NormalizeDomains lowercases email domains and rejects values that are not
+// bare domain names, such as full email addresses or URLs.
+func NormalizeDomains(domains []string) ([]string, error) {
+	if domains == nil {
+		return nil, nil
+	}
+	result := make([]string, 0, len(domains))
+	for _, d := range domains {
+		domain := strings.ToLower(strings.TrimSpace(d))
+		if !domainPattern.MatchString(domain) {
+			return nil, errors.Errorf("invalid email domain %q: expected a domain name like example.com", d)
+		}
+		result = append(result, domain)
+	}
+	return result, nil
+}
@@ -44,6 +44,16 @@ func Run(ctx context.Context, params RunParams) error {
 		return errors.New("unexpected error fetching identity provider: " + string(getResp.Body))
 	}
 
+	if params.Domains, err = saml.NormalizeDomains(params.Domains); err != nil {
+		return err
+	}
+	if params.AddDomains, err = saml.NormalizeDomains(params.AddDomains); err != nil {
+		return err
+	}
+	if params.RemoveDomains, err = saml.NormalizeDomains(params.RemoveDomains); err != nil {
+		return err
+	}
+
 	var body api.V1UpdateASsoProviderJSONRequestBody
 
 	if params.MetadataFile != "" {
@@ -0,0 +1,126 @@
+package start
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Number of log lines collected from each unhealthy container.
+const diagnosticLogLines = "50"
+
+type diagnostic struct {
+	Container string
+	Image     string
+	Status    string
+	// Output of the last failed health check
+	Health        string
+	PortConflicts []string
+	Logs          string
+}
+
+// Waits for services until timeout, printing a diagnostic bundle for each unhealthy container.
+func waitForHealthy(ctx context.Context, w io.Writer, timeout time.Duration, started ...string) error {
+	unhealthy, err := start.RetryHealthCheck(ctx, timeout, started...)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		for _, containerId := range unhealthy {
+			d, err := diagnose(context.Background(), containerId)
+			if err != nil {
+				fmt.Fprintln(w, err)
+			}
+			d.print(w)
+		}
+		utils.CmdSuggestion = fmt.Sprintf("Try rerunning with a longer %s if services are slow to start.", utils.Aqua("--health-timeout"))
+	}
+	return err
+}
+
+func diagnose(ctx context.Context, containerId string) (diagnostic, error) {
+	result := diagnostic{Container: containerId}
+	resp, err := utils.Docker.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return result, errors.Errorf("failed to inspect container: %w", err)
+	}
+	if resp.Config != nil {
+		result.Image = resp.Config.Image
+	}
+	if state := resp.State; state != nil {
+		result.Status = state.Status
+		if len(state.Error) > 0 {
+			result.Status += ": " + state.Error
+		}
+		if h := state.Health; h != nil {
+			result.Status += fmt.Sprintf(" (%s)", h.Status)
+			if n := len(h.Log); n > 0 && h.Log[n-1] != nil {
+				result.Health = strings.TrimSpace(h.Log[n-1].Output)
+			}
+		}
+		// Ports held by a running container are expected to be in use
+		if !state.Running && resp.HostConfig != nil {
+			result.PortConflicts = findPortConflicts(resp.HostConfig.PortBindings)
+		}
+	}
+	logs, err := utils.Docker.ContainerLogs(ctx, containerId, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       diagnosticLogLines,
+	})
+	if err != nil {
+		return result, errors.Errorf("failed to read docker logs: %w", err)
+	}
+	defer logs.Close()
+	var buf bytes.Buffer
+	if _, err := stdcopy.StdCopy(&buf, &buf, logs); err != nil {
+		return result, errors.Errorf("failed to copy docker logs: %w", err)
+	}
+	result.Logs = buf.String()
+	return result, nil
+}
+
+// Lists host ports that are already bound by another process.
+func findPortConflicts(bindings nat.PortMap) []string {
+	var result []string
+	for _, hosts := range bindings {
+		for _, h := range hosts {
+			if len(h.HostPort) == 0 {
+				continue
+			}
+			l, err := net.Listen("tcp", net.JoinHostPort(h.HostIP, h.HostPort))
+			if err != nil {
+				result = append(result, h.HostPort)
+				continue
+			}
+			l.Close()
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (d diagnostic) print(w io.Writer) {
+	fmt.Fprintln(w, utils.Bold("Diagnostics for "+d.Container+":"))
+	fmt.Fprintln(w, "  Image:", d.Image)
+	fmt.Fprintln(w, "  Status:", d.Status)
+	if len(d.Health) > 0 {
+		fmt.Fprintln(w, "  Last health check:", d.Health)
+	}
+	if len(d.PortConflicts) > 0 {
+		fmt.Fprintln(w, "  Port conflicts:", utils.Red(strings.Join(d.PortConflicts, ", ")))
+	}
+	fmt.Fprintln(w, "  Recent logs:")
+	for _, line := range strings.Split(strings.TrimRight(d.Logs, "\n"), "\n") {
+		fmt.Fprintln(w, "    "+line)
+	}
+}
@@ -0,0 +1,94 @@
+package start
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestDiagnose(t *testing.T) {
+	const containerId = "test-auth"
+
+	t.Run("collects diagnostics of exited container", func(t *testing.T) {
+		// Setup conflicting port
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer l.Close()
+		_, port, err := net.SplitHostPort(l.Addr().String())
+		require.NoError(t, err)
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + containerId + "/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					State: &types.ContainerState{
+						Status: "exited",
+						Health: &types.Health{
+							Status: types.Unhealthy,
+							Log:    []*types.HealthcheckResult{{Output: "connection refused\n"}},
+						},
+					},
+					HostConfig: &container.HostConfig{PortBindings: nat.PortMap{
+						"9999/tcp": []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: port}},
+					}},
+				},
+				Config: &container.Config{Image: "supabase/gotrue:v2.0.0"},
+			})
+		var body bytes.Buffer
+		_, err = stdcopy.NewStdWriter(&body, stdcopy.Stderr).Write([]byte("fatal: invalid config\n"))
+		require.NoError(t, err)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v"+utils.Docker.ClientVersion()+"/containers/"+containerId+"/logs").
+			MatchParam("tail", diagnosticLogLines).
+			Reply(http.StatusOK).
+			SetHeader("Content-Type", "application/vnd.docker.raw-stream").
+			Body(&body)
+		// Run test
+		d, err := diagnose(context.Background(), containerId)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, diagnostic{
+			Container:     containerId,
+			Image:         "supabase/gotrue:v2.0.0",
+			Status:        "exited (unhealthy)",
+			Health:        "connection refused",
+			PortConflicts: []string{port},
+			Logs:          "fatal: invalid config\n",
+		}, d)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		// Check output
+		var out bytes.Buffer
+		d.print(&out)
+		assert.Contains(t, out.String(), "  Image: supabase/gotrue:v2.0.0\n")
+		assert.Contains(t, out.String(), "    fatal: invalid config\n")
+	})
+
+	t.Run("throws error on inspect failure", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + containerId + "/json").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := diagnose(context.Background(), containerId)
+		// Check error
+		assert.ErrorContains(t, err, "failed to inspect container:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
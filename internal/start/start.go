@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/db/start"
 	"github.com/supabase/cli/internal/functions/serve"
+	"github.com/supabase/cli/internal/projects/local"
 	"github.com/supabase/cli/internal/seed/buckets"
 	"github.com/supabase/cli/internal/services"
 	"github.com/supabase/cli/internal/status"
@@ -43,7 +44,34 @@ func suggestUpdateCmd(serviceImages map[string]string) string {
 	return cmd
 }
 
-func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignoreHealthCheck bool) error {
+// Returns the host ports published by enabled services.
+func getHostPorts() []uint16 {
+	ports := []uint16{utils.Config.Db.Port}
+	if utils.Config.Api.Enabled {
+		ports = append(ports, utils.Config.Api.Port)
+	}
+	if utils.Config.Db.Pooler.Enabled {
+		ports = append(ports, utils.Config.Db.Pooler.Port)
+	}
+	if utils.Config.Studio.Enabled {
+		ports = append(ports, utils.Config.Studio.Port)
+	}
+	if utils.Config.Inbucket.Enabled {
+		ports = append(ports, utils.Config.Inbucket.Port)
+		if utils.Config.Inbucket.SmtpPort > 0 {
+			ports = append(ports, utils.Config.Inbucket.SmtpPort)
+		}
+		if utils.Config.Inbucket.Pop3Port > 0 {
+			ports = append(ports, utils.Config.Inbucket.Pop3Port)
+		}
+	}
+	if utils.Config.Analytics.Enabled {
+		ports = append(ports, utils.Config.Analytics.Port)
+	}
+	return ports
+}
+
+func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignoreHealthCheck bool, healthTimeout time.Duration) error {
 	// Sanity checks.
 	{
 		if err := utils.LoadConfigFS(fsys); err != nil {
@@ -56,6 +84,9 @@ func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignore
 		} else if !errors.Is(err, utils.ErrNotRunning) {
 			return err
 		}
+		if err := local.AssertNoPortConflicts(ctx, utils.Config.ProjectId, getHostPorts()); err != nil {
+			return err
+		}
 		if _, err := utils.LoadAccessTokenFS(fsys); err == nil {
 			if ref, err := flags.LoadProjectRef(fsys); err == nil {
 				local := services.GetServiceImages()
@@ -81,7 +112,7 @@ func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignore
 			Password: utils.Config.Db.Password,
 			Database: "postgres",
 		}
-		return run(p, ctx, fsys, excludedContainers, dbConfig)
+		return run(p, ctx, fsys, excludedContainers, healthTimeout, dbConfig)
 	}); err != nil {
 		if ignoreHealthCheck && start.IsUnhealthyError(err) {
 			fmt.Fprintln(os.Stderr, err)
@@ -167,9 +198,7 @@ var (
 	poolerTenantTemplate = template.Must(template.New("poolerTenant").Parse(poolerTenantEmbed))
 )
 
-var serviceTimeout = 30 * time.Second
-
-func run(p utils.Program, ctx context.Context, fsys afero.Fs, excludedContainers []string, dbConfig pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+func run(p utils.Program, ctx context.Context, fsys afero.Fs, excludedContainers []string, healthTimeout time.Duration, dbConfig pgconn.Config, options ...func(*pgx.ConnConfig)) error {
 	excluded := make(map[string]bool)
 	for _, name := range excludedContainers {
 		excluded[name] = true
@@ -1095,7 +1124,7 @@ EOF
 
 	p.Send(utils.StatusMsg("Waiting for health checks..."))
 	if utils.NoBackupVolume && utils.SliceContains(started, utils.StorageId) {
-		if err := start.WaitForHealthyService(ctx, serviceTimeout, utils.StorageId); err != nil {
+		if err := waitForHealthy(ctx, os.Stderr, healthTimeout, utils.StorageId); err != nil {
 			return err
 		}
 		// Disable prompts when seeding
@@ -1103,7 +1132,7 @@ EOF
 			return err
 		}
 	}
-	return start.WaitForHealthyService(ctx, serviceTimeout, started...)
+	return waitForHealthy(ctx, os.Stderr, healthTimeout, started...)
 }
 
 func isContainerExcluded(imageName string, excluded map[string]bool) bool {
@@ -66,14 +66,7 @@ func (c *CustomName) toValues(exclude ...string) map[string]string {
 }
 
 func Run(ctx context.Context, names CustomName, format string, fsys afero.Fs) error {
-	// Sanity checks.
-	if err := utils.LoadConfigFS(fsys); err != nil {
-		return err
-	}
-	if err := assertContainerHealthy(ctx, utils.DbId); err != nil {
-		return err
-	}
-	stopped, err := checkServiceHealth(ctx)
+	stopped, err := checkStatus(ctx, fsys)
 	if err != nil {
 		return err
 	}
@@ -88,6 +81,27 @@ func Run(ctx context.Context, names CustomName, format string, fsys afero.Fs) er
 	return printStatus(names, format, os.Stdout, stopped...)
 }
 
+// Returns the containers that are stopped while the local database is running.
+func checkStatus(ctx context.Context, fsys afero.Fs) ([]string, error) {
+	// Sanity checks.
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return nil, err
+	}
+	if err := assertContainerHealthy(ctx, utils.DbId); err != nil {
+		return nil, err
+	}
+	return checkServiceHealth(ctx)
+}
+
+// LoadValues returns the status variables of running services, keyed by their names.
+func LoadValues(ctx context.Context, names CustomName, fsys afero.Fs) (map[string]string, error) {
+	stopped, err := checkStatus(ctx, fsys)
+	if err != nil {
+		return nil, err
+	}
+	return names.toValues(stopped...), nil
+}
+
 func checkServiceHealth(ctx context.Context) ([]string, error) {
 	resp, err := utils.Docker.ContainerList(ctx, container.ListOptions{
 		Filters: utils.CliProjectFilter(utils.Config.ProjectId),
@@ -150,18 +164,25 @@ func NewKongClient() *http.Client {
 		Timeout: 10 * time.Second,
 	}
 	if t, ok := http.DefaultTransport.(*http.Transport); ok {
-		pool, err := x509.SystemCertPool()
-		if err != nil {
-			fmt.Fprintln(utils.GetDebugLogger(), err)
-			pool = x509.NewCertPool()
+		config := &tls.Config{MinVersion: tls.VersionTLS12}
+		if t.TLSClientConfig != nil {
+			config = t.TLSClientConfig.Clone()
+		}
+		pool := config.RootCAs
+		if pool == nil {
+			var err error
+			if pool, err = x509.SystemCertPool(); err != nil {
+				fmt.Fprintln(utils.GetDebugLogger(), err)
+				pool = x509.NewCertPool()
+			}
+		} else {
+			pool = pool.Clone()
 		}
 		// No need to replace TLS config if we fail to append cert
 		if pool.AppendCertsFromPEM([]byte(KongCert)) {
 			rt := t.Clone()
-			rt.TLSClientConfig = &tls.Config{
-				MinVersion: tls.VersionTLS12,
-				RootCAs:    pool,
-			}
+			config.RootCAs = pool
+			rt.TLSClientConfig = config
 			client.Transport = rt
 		}
 	}
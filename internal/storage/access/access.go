@@ -0,0 +1,322 @@
+package access
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/auth/jwt/decode"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	// Claims are read by auth.uid() and auth.jwt() in policy expressions
+	SET_CLAIMS    = "SELECT set_config('request.jwt.claims', $1, true), set_config('storage.allow_delete_query', 'true', true)"
+	FIND_OBJECT   = "SELECT EXISTS (SELECT 1 FROM storage.buckets WHERE id = $1), EXISTS (SELECT 1 FROM storage.objects WHERE bucket_id = $1 AND name = $2)"
+	INSERT_OBJECT = "INSERT INTO storage.objects (bucket_id, name, owner_id) VALUES ($1, $2, $3)"
+	SELECT_OBJECT = "SELECT count(*) FROM storage.objects WHERE bucket_id = $1 AND name = $2"
+	UPDATE_OBJECT = "UPDATE storage.objects SET last_accessed_at = now() WHERE bucket_id = $1 AND name = $2"
+	DELETE_OBJECT = "DELETE FROM storage.objects WHERE bucket_id = $1 AND name = $2"
+	LIST_POLICIES = "SELECT policyname, permissive = 'PERMISSIVE', roles::text[], cmd, coalesce(qual, ''), coalesce(with_check, '') FROM pg_policies WHERE schemaname = 'storage' AND tablename = 'objects' ORDER BY policyname"
+	RESET_ROLE    = "RESET ROLE"
+	SAVEPOINT     = "SAVEPOINT access_check"
+	ROLLBACK_TO   = "ROLLBACK TO SAVEPOINT access_check"
+)
+
+var errMissingObject = errors.New("Path must point to an object, ie. ss:///bucket/readme.md")
+
+type Identity struct {
+	Role   string
+	Claims map[string]any
+}
+
+// NewIdentity reads the role and claims from an unverified jwt, or from the role and claims flags.
+func NewIdentity(token, role, claims string) (Identity, error) {
+	result := Identity{Role: role, Claims: map[string]any{}}
+	if len(token) > 0 {
+		decoded, err := decode.DecodeToken(token, false, "")
+		if err != nil {
+			return result, err
+		}
+		result.Claims = decoded.Claims
+	} else if len(claims) > 0 {
+		if err := json.Unmarshal([]byte(claims), &result.Claims); err != nil {
+			return result, errors.Errorf("failed to parse claims: %w", err)
+		}
+	}
+	if len(result.Role) == 0 {
+		result.Role, _ = result.Claims["role"].(string)
+	}
+	if len(result.Role) == 0 {
+		result.Role = "anon"
+	}
+	result.Claims["role"] = result.Role
+	return result, nil
+}
+
+func (i Identity) subject() *string {
+	if sub, ok := i.Claims["sub"].(string); ok && len(sub) > 0 {
+		return &sub
+	}
+	return nil
+}
+
+type Decision struct {
+	Action   string   `json:"action"`
+	Allowed  bool     `json:"allowed"`
+	Policies []Policy `json:"policies"`
+}
+
+type Policy struct {
+	Name       string `json:"name"`
+	Permissive bool   `json:"permissive"`
+	Passed     bool   `json:"passed"`
+	Error      string `json:"error,omitempty"`
+}
+
+func Run(ctx context.Context, objectPath string, identity Identity, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	remotePath, err := client.ParseStorageURL(objectPath)
+	if err != nil {
+		return err
+	}
+	bucket, name := client.SplitBucketPrefix(remotePath)
+	if len(bucket) == 0 || len(name) == 0 || strings.HasSuffix(name, "/") {
+		return errors.New(errMissingObject)
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	result, err := Check(ctx, conn, bucket, name, identity)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, result)
+	}
+	fmt.Fprintf(os.Stderr, "Checking access to %s as role %s\n", utils.Bold(remotePath), utils.Aqua(identity.Role))
+	return list.RenderTable(toMarkdown(result))
+}
+
+// Check simulates each storage action as the given identity inside a transaction that is
+// always rolled back, then evaluates the applicable policies to explain the outcome.
+func Check(ctx context.Context, conn *pgx.Conn, bucket, name string, identity Identity) ([]Decision, error) {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, errors.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(context.Background()); err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+		}
+	}()
+	claims, err := json.Marshal(identity.Claims)
+	if err != nil {
+		return nil, errors.Errorf("failed to encode claims: %w", err)
+	}
+	if _, err := tx.Exec(ctx, SET_CLAIMS, string(claims)); err != nil {
+		return nil, errors.Errorf("failed to set claims: %w", err)
+	}
+	var bucketExists, objectExists bool
+	if err := tx.QueryRow(ctx, FIND_OBJECT, bucket, name).Scan(&bucketExists, &objectExists); err != nil {
+		return nil, errors.Errorf("failed to find object: %w", err)
+	} else if !bucketExists {
+		return nil, errors.Errorf("bucket not found: %s", bucket)
+	}
+	setRole := "SET LOCAL ROLE " + pgx.Identifier{identity.Role}.Sanitize()
+	if _, err := tx.Exec(ctx, setRole); err != nil {
+		return nil, errors.Errorf("failed to set role: %w", err)
+	}
+	// Inserting an existing object passes row security before violating the unique key
+	insert := Decision{Action: "insert"}
+	if insert.Allowed, err = tryWrite(ctx, tx, INSERT_OBJECT, bucket, name, identity.subject()); err != nil {
+		return nil, err
+	}
+	// Remaining actions need the object to exist, so a missing one is created as its owner
+	if !objectExists {
+		fmt.Fprintln(os.Stderr, "Object does not exist, simulating one owned by the token subject.")
+		if _, err := tx.Exec(ctx, RESET_ROLE); err != nil {
+			return nil, errors.Errorf("failed to reset role: %w", err)
+		}
+		if _, err := tx.Exec(ctx, INSERT_OBJECT, bucket, name, identity.subject()); err != nil {
+			return nil, errors.Errorf("failed to simulate object: %w", err)
+		}
+		if _, err := tx.Exec(ctx, setRole); err != nil {
+			return nil, errors.Errorf("failed to set role: %w", err)
+		}
+	}
+	read := Decision{Action: "select"}
+	var count int
+	if err := tx.QueryRow(ctx, SELECT_OBJECT, bucket, name).Scan(&count); isDenied(err) {
+		read.Allowed = false
+	} else if err != nil {
+		return nil, errors.Errorf("failed to select object: %w", err)
+	} else {
+		read.Allowed = count > 0
+	}
+	update := Decision{Action: "update"}
+	if update.Allowed, err = tryWrite(ctx, tx, UPDATE_OBJECT, bucket, name); err != nil {
+		return nil, err
+	}
+	remove := Decision{Action: "delete"}
+	if remove.Allowed, err = tryWrite(ctx, tx, DELETE_OBJECT, bucket, name); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, RESET_ROLE); err != nil {
+		return nil, errors.Errorf("failed to reset role: %w", err)
+	}
+	result := []Decision{read, insert, update, remove}
+	if err := explain(ctx, tx, bucket, name, identity.Role, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Runs a statement within a savepoint, returning true if it changed any rows or only failed
+// on a unique violation, and false if it was rejected by row security or grants.
+func tryWrite(ctx context.Context, tx pgx.Tx, sql string, args ...any) (bool, error) {
+	if _, err := tx.Exec(ctx, SAVEPOINT); err != nil {
+		return false, errors.Errorf("failed to create savepoint: %w", err)
+	}
+	tag, err := tx.Exec(ctx, sql, args...)
+	var pgErr *pgconn.PgError
+	allowed := err == nil && tag.RowsAffected() > 0
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		allowed = true
+	} else if err != nil && !isDenied(err) {
+		return false, errors.Errorf("failed to simulate %s: %w", strings.Fields(sql)[0], err)
+	}
+	if _, err := tx.Exec(ctx, ROLLBACK_TO); err != nil {
+		return false, errors.Errorf("failed to rollback savepoint: %w", err)
+	}
+	return allowed, nil
+}
+
+func isDenied(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgerrcode.InsufficientPrivilege
+}
+
+type policyRow struct {
+	name       string
+	permissive bool
+	roles      []string
+	cmd        string
+	using      string
+	withCheck  string
+}
+
+// Policy expressions are evaluated by the session user against the object row, so checks on
+// current_user may differ from the simulated role.
+func explain(ctx context.Context, tx pgx.Tx, bucket, name, role string, decisions []Decision) error {
+	rows, err := tx.Query(ctx, LIST_POLICIES)
+	if err != nil {
+		return errors.Errorf("failed to list policies: %w", err)
+	}
+	var policies []policyRow
+	for rows.Next() {
+		var p policyRow
+		if err := rows.Scan(&p.name, &p.permissive, &p.roles, &p.cmd, &p.using, &p.withCheck); err != nil {
+			return errors.Errorf("failed to scan policy: %w", err)
+		}
+		policies = append(policies, p)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Errorf("failed to list policies: %w", err)
+	}
+	for i, d := range decisions {
+		decisions[i].Policies = []Policy{}
+		for _, p := range policies {
+			if !p.appliesTo(role, d.Action) {
+				continue
+			}
+			result := Policy{Name: p.name, Permissive: p.permissive}
+			result.Passed, err = evaluate(ctx, tx, p.expression(d.Action), bucket, name)
+			if err != nil {
+				result.Error = err.Error()
+			}
+			decisions[i].Policies = append(decisions[i].Policies, result)
+		}
+	}
+	return nil
+}
+
+func (p policyRow) appliesTo(role, action string) bool {
+	if p.cmd != "ALL" && !strings.EqualFold(p.cmd, action) {
+		return false
+	}
+	return slices.Contains(p.roles, role) || slices.Contains(p.roles, "public")
+}
+
+// New rows are checked by WITH CHECK, which defaults to USING when omitted.
+func (p policyRow) expression(action string) string {
+	using, check := p.using, p.withCheck
+	if len(check) == 0 {
+		check = using
+	}
+	switch action {
+	case "insert":
+		return check
+	case "update":
+		if len(using) > 0 && len(check) > 0 {
+			return fmt.Sprintf("(%s) AND (%s)", using, check)
+		}
+		return using + check
+	default:
+		return using
+	}
+}
+
+func evaluate(ctx context.Context, tx pgx.Tx, expr, bucket, name string) (bool, error) {
+	if len(expr) == 0 {
+		return true, nil
+	}
+	if _, err := tx.Exec(ctx, SAVEPOINT); err != nil {
+		return false, errors.Errorf("failed to create savepoint: %w", err)
+	}
+	var passed *bool
+	sql := fmt.Sprintf("SELECT (%s) FROM storage.objects WHERE bucket_id = $1 AND name = $2", expr)
+	err := tx.QueryRow(ctx, sql, bucket, name).Scan(&passed)
+	if _, err := tx.Exec(ctx, ROLLBACK_TO); err != nil {
+		return false, errors.Errorf("failed to rollback savepoint: %w", err)
+	}
+	return passed != nil && *passed, err
+}
+
+func toMarkdown(decisions []Decision) string {
+	table := "|ACTION|ALLOWED|POLICIES|\n|-|-|-|\n"
+	for _, d := range decisions {
+		allowed := "no"
+		if d.Allowed {
+			allowed = "yes"
+		}
+		var policies []string
+		for _, p := range d.Policies {
+			status := "fail"
+			if len(p.Error) > 0 {
+				status = "error"
+			} else if p.Passed {
+				status = "pass"
+			}
+			if !p.Permissive {
+				status = "restrictive " + status
+			}
+			policies = append(policies, fmt.Sprintf("%s (%s)", p.Name, status))
+		}
+		if len(policies) == 0 {
+			policies = append(policies, "-")
+		}
+		table += fmt.Sprintf("|`%s`|%s|%s|\n", d.Action, allowed, strings.Join(policies, ", "))
+	}
+	return table
+}
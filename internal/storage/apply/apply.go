@@ -0,0 +1,220 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/storage/cache"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+const (
+	ActionCreate     = "create"
+	ActionUpdate     = "update"
+	ActionUnchanged  = "unchanged"
+	ActionUndeclared = "undeclared"
+)
+
+type Change struct {
+	Action string   `json:"action"`
+	Bucket string   `json:"bucket"`
+	Diff   []string `json:"diff,omitempty"`
+}
+
+// Run reconciles storage buckets declared in config.toml with those of the project.
+func Run(ctx context.Context, projectRef string, dryRun, prune bool, fsys afero.Fs) error {
+	if client.IsAnonymous() {
+		return errors.New("Applying storage buckets requires the service role key of a linked or local project.")
+	}
+	api, err := client.NewStorageAPI(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	remote, err := api.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+	declared := utils.Config.Storage.Buckets
+	if len(projectRef) > 0 {
+		// Remote config overrides buckets of the base config
+		if c, err := utils.Config.GetRemoteByProjectRef(projectRef); err == nil {
+			declared = c.Storage.Buckets
+		}
+	}
+	changes := PlanBuckets(declared, remote)
+	if err := printChanges(changes, os.Stdout); err != nil {
+		return err
+	}
+	if !prune {
+		utils.CmdSuggestion = suggestPrune(changes)
+	}
+	if dryRun {
+		return nil
+	}
+	pending := slices.ContainsFunc(changes, func(c Change) bool {
+		return c.Action == ActionCreate || c.Action == ActionUpdate || (prune && c.Action == ActionUndeclared)
+	})
+	if !pending {
+		fmt.Fprintln(os.Stderr, render.T("buckets_up_to_date"))
+		return nil
+	}
+	if shouldApply, err := utils.NewConsole().PromptYesNo(ctx, "Apply these changes to storage buckets?", true); err != nil {
+		return err
+	} else if !shouldApply {
+		return errors.New(context.Canceled)
+	}
+	defer cache.Invalidate(projectRef, fsys)
+	return applyChanges(ctx, api, changes, declared, prune)
+}
+
+// PlanBuckets compares declared buckets against existing ones. Public and allowed MIME types
+// are left unchanged when not declared, while file size limit falls back to the global limit.
+func PlanBuckets(declared config.BucketConfig, existing []storage.BucketResponse) []Change {
+	remote := make(map[string]storage.BucketResponse, len(existing))
+	for _, b := range existing {
+		remote[b.Name] = b
+	}
+	var changes []Change
+	for name, bucket := range declared {
+		actual, ok := remote[name]
+		if !ok {
+			changes = append(changes, Change{Action: ActionCreate, Bucket: name})
+			continue
+		}
+		var diff []string
+		if bucket.Public != nil && *bucket.Public != actual.Public {
+			diff = append(diff, fmt.Sprintf("public: %t => %t", actual.Public, *bucket.Public))
+		}
+		var actualLimit int64
+		if actual.FileSizeLimit != nil {
+			actualLimit = int64(*actual.FileSizeLimit)
+		}
+		if limit := int64(bucket.FileSizeLimit); limit != actualLimit {
+			diff = append(diff, fmt.Sprintf("file_size_limit: %d => %d", actualLimit, limit))
+		}
+		if bucket.AllowedMimeTypes != nil && !slices.Equal(bucket.AllowedMimeTypes, actual.AllowedMimeTypes) {
+			diff = append(diff, fmt.Sprintf("allowed_mime_types: %v => %v", actual.AllowedMimeTypes, bucket.AllowedMimeTypes))
+		}
+		action := ActionUnchanged
+		if len(diff) > 0 {
+			action = ActionUpdate
+		}
+		changes = append(changes, Change{Action: action, Bucket: name, Diff: diff})
+	}
+	for name := range remote {
+		if _, ok := declared[name]; !ok {
+			changes = append(changes, Change{Action: ActionUndeclared, Bucket: name})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Bucket < changes[j].Bucket
+	})
+	return changes
+}
+
+var actionSymbols = map[string]string{
+	ActionCreate:     "+",
+	ActionUpdate:     "~",
+	ActionUnchanged:  " ",
+	ActionUndeclared: "-",
+}
+
+func printChanges(changes []Change, w io.Writer) error {
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		if changes == nil {
+			changes = []Change{}
+		}
+		return utils.EncodeOutput(utils.OutputFormat.Value, w, changes)
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, "No storage buckets declared in "+utils.Bold(utils.ConfigPath)+".")
+		return nil
+	}
+	for _, c := range changes {
+		fmt.Fprintf(w, "%s %s (%s)\n", actionSymbols[c.Action], c.Bucket, c.Action)
+		for _, d := range c.Diff {
+			fmt.Fprintln(w, "    "+d)
+		}
+	}
+	return nil
+}
+
+func applyChanges(ctx context.Context, api storage.StorageAPI, changes []Change, declared config.BucketConfig, prune bool) error {
+	var errs []error
+	for _, c := range changes {
+		bucket := declared[c.Bucket]
+		var err error
+		switch c.Action {
+		case ActionCreate:
+			fmt.Fprintln(os.Stderr, render.T("creating_bucket", c.Bucket))
+			_, err = api.CreateBucket(ctx, storage.CreateBucketRequest{
+				Name:             c.Bucket,
+				Public:           bucket.Public,
+				FileSizeLimit:    int64(bucket.FileSizeLimit),
+				AllowedMimeTypes: bucket.AllowedMimeTypes,
+			})
+		case ActionUpdate:
+			fmt.Fprintln(os.Stderr, render.T("updating_bucket", c.Bucket))
+			body := updateBucketRequest{Public: bucket.Public}
+			if limit := int64(bucket.FileSizeLimit); limit > 0 {
+				body.FileSizeLimit = &limit
+			}
+			if bucket.AllowedMimeTypes != nil {
+				body.AllowedMimeTypes = &bucket.AllowedMimeTypes
+			}
+			var resp *http.Response
+			if resp, err = api.Send(ctx, http.MethodPut, "/storage/v1/bucket/"+c.Bucket, body); err == nil {
+				resp.Body.Close()
+			}
+		case ActionUndeclared:
+			if !prune {
+				continue
+			}
+			// Storage API rejects deleting buckets that still contain objects
+			fmt.Fprintln(os.Stderr, "Deleting Storage bucket:", c.Bucket)
+			_, err = api.DeleteBucket(ctx, c.Bucket)
+		}
+		if err != nil {
+			errs = append(errs, errors.Errorf("failed to %s bucket %s: %w", c.Action, c.Bucket, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, render.T("finished_storage_apply", utils.Bold(utils.ConfigPath)))
+	return nil
+}
+
+// Unlike storage.UpdateBucketRequest, empty values are sent explicitly so that declaring
+// allowed_mime_types = [] or a zero file_size_limit clears them.
+type updateBucketRequest struct {
+	Public           *bool     `json:"public,omitempty"`
+	FileSizeLimit    *int64    `json:"file_size_limit"`
+	AllowedMimeTypes *[]string `json:"allowed_mime_types,omitempty"`
+}
+
+func suggestPrune(changes []Change) string {
+	var names []string
+	for _, c := range changes {
+		if c.Action == ActionUndeclared {
+			names = append(names, c.Bucket)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Buckets %s are not declared in %s. Run %s to delete them.",
+		utils.Bold(strings.Join(names, ", ")), utils.Bold(utils.ConfigPath), utils.Aqua("supabase storage apply --prune"))
+}
@@ -0,0 +1,182 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/config"
+	"github.com/supabase/cli/pkg/fetcher"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var mockApi = storage.StorageAPI{Fetcher: fetcher.NewFetcher(
+	"http://127.0.0.1",
+)}
+
+func TestPlanBuckets(t *testing.T) {
+	t.Run("plans create, update and undeclared buckets", func(t *testing.T) {
+		var declared config.BucketConfig
+		require.NoError(t, toml.Unmarshal([]byte(`
+[avatars]
+public = true
+file_size_limit = "5MiB"
+[docs]
+public = false
+[images]
+allowed_mime_types = ["image/png"]
+[videos]
+file_size_limit = "1KiB"
+allowed_mime_types = []`), &declared))
+		existing := []storage.BucketResponse{{
+			Name:   "avatars",
+			Public: false,
+		}, {
+			Name:          "docs",
+			FileSizeLimit: cast.Ptr(1024),
+		}, {
+			Name: "legacy",
+		}, {
+			Name:             "videos",
+			FileSizeLimit:    cast.Ptr(1024),
+			AllowedMimeTypes: []string{"video/mp4"},
+		}}
+		// Run test
+		changes := PlanBuckets(declared, existing)
+		// Check output
+		assert.Equal(t, []Change{{
+			Action: ActionUpdate,
+			Bucket: "avatars",
+			Diff:   []string{"public: false => true", "file_size_limit: 0 => 5242880"},
+		}, {
+			Action: ActionUpdate,
+			Bucket: "docs",
+			Diff:   []string{"file_size_limit: 1024 => 0"},
+		}, {
+			Action: ActionCreate,
+			Bucket: "images",
+		}, {
+			Action: ActionUndeclared,
+			Bucket: "legacy",
+		}, {
+			Action: ActionUpdate,
+			Bucket: "videos",
+			Diff:   []string{"allowed_mime_types: [video/mp4] => []"},
+		}}, changes)
+	})
+
+	t.Run("prints changes", func(t *testing.T) {
+		var out bytes.Buffer
+		changes := []Change{
+			{Action: ActionCreate, Bucket: "images"},
+			{Action: ActionUpdate, Bucket: "avatars", Diff: []string{"public: false => true"}},
+		}
+		// Run test
+		require.NoError(t, printChanges(changes, &out))
+		// Check output
+		assert.Equal(t, "+ images (create)\n~ avatars (update)\n    public: false => true\n", out.String())
+	})
+}
+
+func TestApplyBuckets(t *testing.T) {
+	t.Cleanup(func() { clear(utils.Config.Storage.Buckets) })
+	require.NoError(t, toml.Unmarshal([]byte(`
+[images]
+public = true`), &utils.Config.Storage.Buckets))
+
+	t.Run("suggests prune on dry run", func(t *testing.T) {
+		t.Cleanup(func() { utils.CmdSuggestion = "" })
+		defer gock.OffAll()
+		// Setup mock api
+		gock.New(utils.Config.Api.ExternalUrl).
+			Get("/storage/v1/bucket").
+			Reply(http.StatusOK).
+			JSON([]storage.BucketResponse{{Name: "legacy", Id: "legacy"}})
+		// Run test
+		err := Run(context.Background(), "", true, false, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, utils.CmdSuggestion, "legacy")
+	})
+
+	t.Run("creates, updates and prunes buckets", func(t *testing.T) {
+		defer gock.OffAll()
+		// Setup mock api
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/bucket").
+			Reply(http.StatusOK).
+			JSON(storage.CreateBucketResponse{Name: "images"})
+		gock.New("http://127.0.0.1").
+			Put("/storage/v1/bucket/docs").
+			Reply(http.StatusOK).
+			JSON(storage.UpdateBucketResponse{})
+		gock.New("http://127.0.0.1").
+			Delete("/storage/v1/bucket/legacy").
+			Reply(http.StatusOK).
+			JSON(storage.DeleteBucketResponse{})
+		changes := []Change{
+			{Action: ActionCreate, Bucket: "images"},
+			{Action: ActionUpdate, Bucket: "docs"},
+			{Action: ActionUndeclared, Bucket: "legacy"},
+		}
+		// Run test
+		err := applyChanges(context.Background(), mockApi, changes, utils.Config.Storage.Buckets, true)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("clears empty properties on update", func(t *testing.T) {
+		var declared config.BucketConfig
+		require.NoError(t, toml.Unmarshal([]byte(`
+[videos]
+allowed_mime_types = []`), &declared))
+		defer gock.OffAll()
+		// Setup mock api
+		gock.New("http://127.0.0.1").
+			Put("/storage/v1/bucket/videos").
+			JSON(map[string]any{"file_size_limit": nil, "allowed_mime_types": []string{}}).
+			Reply(http.StatusOK).
+			JSON(storage.UpdateBucketResponse{})
+		changes := []Change{{Action: ActionUpdate, Bucket: "videos"}}
+		// Run test
+		err := applyChanges(context.Background(), mockApi, changes, declared, false)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("keeps undeclared buckets without prune", func(t *testing.T) {
+		defer gock.OffAll()
+		changes := []Change{{Action: ActionUndeclared, Bucket: "legacy"}}
+		// Run test
+		err := applyChanges(context.Background(), mockApi, changes, utils.Config.Storage.Buckets, false)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on delete failure", func(t *testing.T) {
+		defer gock.OffAll()
+		// Setup mock api
+		gock.New("http://127.0.0.1").
+			Delete("/storage/v1/bucket/legacy").
+			Reply(http.StatusBadRequest).
+			JSON(map[string]string{"message": "Bucket not empty"})
+		changes := []Change{{Action: ActionUndeclared, Bucket: "legacy"}}
+		// Run test
+		err := applyChanges(context.Background(), mockApi, changes, utils.Config.Storage.Buckets, true)
+		// Check error
+		assert.ErrorContains(t, err, "failed to undeclared bucket legacy")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+// Listings older than this are fetched again, since objects may be changed by other clients.
+const TTL = 10 * time.Minute
+
+var CacheDir = filepath.Join(utils.TempDir, "storage-cache")
+
+type Listing struct {
+	CachedAt time.Time                         `json:"cached_at"`
+	Objects  map[string]storage.ObjectResponse `json:"objects"`
+}
+
+// Listings are stored per project and bucket, so that writes can invalidate them together.
+func listingPath(ref, bucket, prefix string) string {
+	if len(ref) == 0 {
+		ref = "local"
+	}
+	digest := sha256.Sum256([]byte(prefix))
+	return filepath.Join(CacheDir, ref, bucket, hex.EncodeToString(digest[:])+".json")
+}
+
+// Get returns the cached objects under bucket and prefix, including their etags, unless expired.
+func Get(ref, bucket, prefix string, fsys afero.Fs) (map[string]storage.ObjectResponse, bool) {
+	data, err := afero.ReadFile(fsys, listingPath(ref, bucket, prefix))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+		}
+		return nil, false
+	}
+	var listing Listing
+	if err := json.Unmarshal(data, &listing); err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+		return nil, false
+	}
+	if time.Since(listing.CachedAt) > TTL {
+		return nil, false
+	}
+	return listing.Objects, true
+}
+
+func Put(ref, bucket, prefix string, objects map[string]storage.ObjectResponse, fsys afero.Fs) error {
+	data, err := json.Marshal(Listing{CachedAt: time.Now().UTC(), Objects: objects})
+	if err != nil {
+		return errors.Errorf("failed to encode listing: %w", err)
+	}
+	return utils.WriteFile(listingPath(ref, bucket, prefix), data, fsys)
+}
+
+// Invalidate removes all cached listings of a project after objects are modified by the CLI.
+func Invalidate(ref string, fsys afero.Fs) {
+	if len(ref) == 0 {
+		ref = "local"
+	}
+	if err := fsys.RemoveAll(filepath.Join(CacheDir, ref)); err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+	}
+}
+
+// Run clears cached listings of all projects.
+func Run(fsys afero.Fs) error {
+	if err := fsys.RemoveAll(CacheDir); err != nil {
+		return errors.Errorf("failed to clear cache: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Cleared storage listing cache.")
+	return nil
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func TestListingCache(t *testing.T) {
+	objects := map[string]storage.ObjectResponse{
+		"a.png": {Name: "a.png", Metadata: &storage.ObjectMetadata{Size: 1, ETag: `"a"`}},
+	}
+
+	t.Run("reads back cached listing", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, Put("test-project", "private", "images/", objects, fsys))
+		// Run test
+		result, ok := Get("test-project", "private", "images/", fsys)
+		// Check output
+		assert.True(t, ok)
+		assert.Equal(t, objects, result)
+		_, ok = Get("test-project", "private", "docs/", fsys)
+		assert.False(t, ok)
+	})
+
+	t.Run("ignores expired listing", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		data, err := json.Marshal(Listing{CachedAt: time.Now().Add(-TTL - time.Minute), Objects: objects})
+		require.NoError(t, err)
+		require.NoError(t, afero.WriteFile(fsys, listingPath("test-project", "private", ""), data, 0644))
+		// Run test
+		_, ok := Get("test-project", "private", "", fsys)
+		// Check output
+		assert.False(t, ok)
+	})
+
+	t.Run("invalidates project listings", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, Put("test-project", "private", "", objects, fsys))
+		require.NoError(t, Put("other-project", "private", "", objects, fsys))
+		// Run test
+		Invalidate("test-project", fsys)
+		// Check output
+		_, ok := Get("test-project", "private", "", fsys)
+		assert.False(t, ok)
+		_, ok = Get("other-project", "private", "", fsys)
+		assert.True(t, ok)
+	})
+
+	t.Run("clears all listings", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, Put("test-project", "private", "", objects, fsys))
+		// Run test
+		assert.NoError(t, Run(fsys))
+		// Check output
+		exists, err := afero.DirExists(fsys, CacheDir)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
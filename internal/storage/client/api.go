@@ -3,22 +3,57 @@ package client
 import (
 	"context"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/spf13/viper"
 	"github.com/supabase/cli/internal/status"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
 	"github.com/supabase/cli/internal/utils/tenant"
 	"github.com/supabase/cli/pkg/fetcher"
 	"github.com/supabase/cli/pkg/storage"
 )
 
+var (
+	transportOnce sync.Once
+	transport     http.RoundTripper
+)
+
+// Public buckets can be read with just the project url and anon key.
+func IsAnonymous() bool {
+	return len(viper.GetString("PROJECT_URL")) > 0
+}
+
 func NewStorageAPI(ctx context.Context, projectRef string) (storage.StorageAPI, error) {
 	client := storage.StorageAPI{}
-	if len(projectRef) == 0 {
+	if err := loadFaultSpec(); err != nil {
+		return client, err
+	}
+	if flags.IsSelfHosted() {
+		// Writes require the service role key, similar to linked projects
+		env := flags.Environment
+		key := env.ServiceRoleKey
+		if len(key) == 0 {
+			key = env.AnonKey
+			client.Public = true
+		}
+		rt := newAuditTransport(sharedTransport(), utils.Config.Storage.Client.AuditLog, viper.GetString("ENV"))
+		client.Fetcher = newAnonClient(env.GetStorageUrl(), key, rt)
+	} else if IsAnonymous() {
+		client.Fetcher = newAnonClient(viper.GetString("PROJECT_URL"), viper.GetString("ANON_KEY"), sharedTransport())
+		client.Public = true
+	} else if len(projectRef) == 0 {
 		client.Fetcher = newLocalClient()
 	} else if viper.IsSet("AUTH_SERVICE_ROLE_KEY") {
 		// Special case for calling storage API without personal access token
 		client.Fetcher = newRemoteClient(projectRef, utils.Config.Auth.ServiceRoleKey)
+	} else if token, err := ResolveToken(ctx, utils.Config.Storage.Client.Credentials); err != nil {
+		return client, err
+	} else if len(token) > 0 {
+		client.Fetcher = newRemoteClient(projectRef, token)
+	} else if _, err := utils.LoadAccessToken(); err != nil {
+		return client, err
 	} else if apiKey, err := tenant.GetApiKeys(ctx, projectRef); err == nil {
 		client.Fetcher = newRemoteClient(projectRef, apiKey.ServiceRole)
 	} else {
@@ -27,22 +62,82 @@ func NewStorageAPI(ctx context.Context, projectRef string) (storage.StorageAPI,
 	return client, nil
 }
 
+// Returns the base URL that storage object URLs are served from.
+func GetStorageURL(projectRef string) string {
+	if flags.IsSelfHosted() {
+		return flags.Environment.GetStorageUrl()
+	} else if IsAnonymous() {
+		return strings.TrimSuffix(viper.GetString("PROJECT_URL"), "/")
+	} else if len(projectRef) == 0 {
+		return utils.Config.Api.ExternalUrl
+	}
+	return "https://" + utils.GetSupabaseHost(projectRef)
+}
+
 func newLocalClient() *fetcher.Fetcher {
 	client := status.NewKongClient()
+	if t, ok := client.Transport.(*http.Transport); ok {
+		tuneTransport(t)
+	}
+	client.Transport = newAuditTransport(newRateLimitTransport(newFaultTransport(client.Transport, injectFaults), MaxRPS), utils.Config.Storage.Client.AuditLog, "local")
 	return fetcher.NewFetcher(
 		utils.Config.Api.ExternalUrl,
 		fetcher.WithHTTPClient(client),
 		fetcher.WithBearerToken(utils.Config.Auth.ServiceRoleKey),
 		fetcher.WithUserAgent("SupabaseCLI/"+utils.Version),
-		fetcher.WithExpectedStatus(http.StatusOK),
+		fetcher.WithRequestEditor(utils.SetTraceHeader),
+		fetcher.WithExpectedStatus(http.StatusOK, http.StatusPartialContent),
 	)
 }
 
 func newRemoteClient(projectRef, token string) *fetcher.Fetcher {
+	client := &http.Client{Transport: newAuditTransport(sharedTransport(), utils.Config.Storage.Client.AuditLog, projectRef)}
 	return fetcher.NewFetcher(
 		"https://"+utils.GetSupabaseHost(projectRef),
+		fetcher.WithHTTPClient(client),
 		fetcher.WithBearerToken(token),
 		fetcher.WithUserAgent("SupabaseCLI/"+utils.Version),
-		fetcher.WithExpectedStatus(http.StatusOK),
+		fetcher.WithRequestEditor(utils.SetTraceHeader),
+		fetcher.WithExpectedStatus(http.StatusOK, http.StatusPartialContent),
 	)
 }
+
+func newAnonClient(projectUrl, anonKey string, rt http.RoundTripper) *fetcher.Fetcher {
+	client := &http.Client{Transport: rt}
+	opts := []fetcher.FetcherOption{
+		fetcher.WithHTTPClient(client),
+		fetcher.WithUserAgent("SupabaseCLI/" + utils.Version),
+		fetcher.WithRequestEditor(utils.SetTraceHeader),
+		fetcher.WithExpectedStatus(http.StatusOK, http.StatusPartialContent),
+	}
+	if len(anonKey) > 0 {
+		header := func(req *http.Request) {
+			req.Header.Add("apikey", anonKey)
+		}
+		opts = append(opts, fetcher.WithBearerToken(anonKey), fetcher.WithRequestEditor(header))
+	}
+	return fetcher.NewFetcher(strings.TrimSuffix(projectUrl, "/"), opts...)
+}
+
+// Reuses connections across all storage API calls so that recursive operations
+// don't pay the TLS handshake cost on every request.
+func sharedTransport() http.RoundTripper {
+	transportOnce.Do(func() {
+		rt := http.DefaultTransport
+		if t, ok := http.DefaultTransport.(*http.Transport); ok {
+			clone := t.Clone()
+			tuneTransport(clone)
+			rt = clone
+		}
+		transport = newRateLimitTransport(newFaultTransport(rt, injectFaults), MaxRPS)
+	})
+	return transport
+}
+
+func tuneTransport(t *http.Transport) {
+	t.ForceAttemptHTTP2 = true
+	t.MaxConnsPerHost = utils.Config.Storage.Client.MaxConnsPerHost
+	t.MaxIdleConnsPerHost = max(t.MaxConnsPerHost, http.DefaultMaxIdleConnsPerHost)
+	t.IdleConnTimeout = utils.Config.Storage.Client.IdleConnTimeout
+	t.ResponseHeaderTimeout = utils.Config.Storage.Client.ResponseHeaderTimeout
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestTuneTransport(t *testing.T) {
+	t.Run("applies storage client config", func(t *testing.T) {
+		utils.Config.Storage.Client.MaxConnsPerHost = 32
+		utils.Config.Storage.Client.IdleConnTimeout = time.Minute
+		utils.Config.Storage.Client.ResponseHeaderTimeout = time.Second
+		rt := &http.Transport{}
+		// Run test
+		tuneTransport(rt)
+		// Check transport
+		assert.True(t, rt.ForceAttemptHTTP2)
+		assert.Equal(t, 32, rt.MaxConnsPerHost)
+		assert.Equal(t, 32, rt.MaxIdleConnsPerHost)
+		assert.Equal(t, time.Minute, rt.IdleConnTimeout)
+		assert.Equal(t, time.Second, rt.ResponseHeaderTimeout)
+	})
+
+	t.Run("shares transport across clients", func(t *testing.T) {
+		first := newRemoteClient("test-project", "token")
+		second := newRemoteClient("test-project", "token")
+		// Check transport
+		assert.NotSame(t, first, second)
+		assert.Same(t, sharedTransport(), sharedTransport())
+	})
+}
+
+func TestSelfHostedStorage(t *testing.T) {
+	t.Cleanup(func() { flags.Environment = nil })
+
+	t.Run("authorizes with service role key", func(t *testing.T) {
+		flags.Environment = &config.Environment{
+			ApiUrl:         "https://supabase.example.com",
+			StorageUrl:     "https://storage.example.com",
+			ServiceRoleKey: "service-key",
+		}
+		// Run test
+		api, err := NewStorageAPI(context.Background(), "")
+		// Check error
+		assert.NoError(t, err)
+		assert.False(t, api.Public)
+		assert.Equal(t, "https://storage.example.com", GetStorageURL(""))
+	})
+
+	t.Run("falls back to anon key as read only", func(t *testing.T) {
+		flags.Environment = &config.Environment{
+			ApiUrl:  "https://supabase.example.com",
+			AnonKey: "anon-key",
+		}
+		// Run test
+		api, err := NewStorageAPI(context.Background(), "")
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, api.Public)
+		assert.Equal(t, "https://supabase.example.com", GetStorageURL(""))
+	})
+
+	t.Run("records audit log", func(t *testing.T) {
+		auditPath := filepath.Join(t.TempDir(), "audit.jsonl")
+		utils.Config.Storage.Client.AuditLog = auditPath
+		t.Cleanup(func() { utils.Config.Storage.Client.AuditLog = "" })
+		// Setup mock server
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		flags.Environment = &config.Environment{
+			ApiUrl:         server.URL,
+			ServiceRoleKey: "service-key",
+		}
+		// Run test
+		api, err := NewStorageAPI(context.Background(), "")
+		require.NoError(t, err)
+		_, err = api.Send(context.Background(), http.MethodDelete, "/storage/v1/bucket/private", nil)
+		// Check error
+		assert.NoError(t, err)
+		data, err := os.ReadFile(auditPath)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `"operation":"delete_bucket"`)
+	})
+}
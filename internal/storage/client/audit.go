@@ -0,0 +1,145 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+)
+
+type AuditEntry struct {
+	Time      time.Time       `json:"time"`
+	User      string          `json:"user"`
+	Project   string          `json:"project"`
+	Operation string          `json:"operation"`
+	Object    string          `json:"object,omitempty"`
+	Request   json.RawMessage `json:"request,omitempty"`
+	Status    int             `json:"status,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// Records mutating storage requests to a local JSONL file, so teams can trace what bulk
+// scripts actually changed. Read only requests pass through without being logged.
+type auditTransport struct {
+	http.RoundTripper
+	path    string
+	project string
+	user    string
+	mu      sync.Mutex
+}
+
+func newAuditTransport(rt http.RoundTripper, path, project string) http.RoundTripper {
+	if len(path) == 0 {
+		return rt
+	}
+	t := auditTransport{RoundTripper: rt, path: path, project: project}
+	if u, err := user.Current(); err == nil {
+		t.user = u.Username
+	}
+	return &t
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, object := getOperation(req.Method, req.URL.Path)
+	if len(op) == 0 {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	entry := AuditEntry{
+		User:      t.user,
+		Project:   t.project,
+		Operation: op,
+		Object:    object,
+		Request:   readJSONBody(req),
+	}
+	resp, err := t.RoundTripper.RoundTrip(req)
+	entry.Time = time.Now().UTC()
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+	}
+	// The operation has already happened so a broken audit log should not fail the command
+	if err := t.append(entry); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	return resp, err
+}
+
+func (t *auditTransport) append(entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Errorf("failed to encode audit entry: %w", err)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return errors.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(t.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return errors.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Maps storage API endpoints to the operation they perform, returning an empty string for reads.
+func getOperation(method, path string) (string, string) {
+	path = strings.TrimPrefix(path, "/storage/v1/")
+	switch method {
+	case http.MethodPost:
+		if path == "bucket" {
+			return "create_bucket", ""
+		} else if path == "object/move" {
+			return "move_object", ""
+		} else if path == "object/copy" {
+			return "copy_object", ""
+		} else if strings.HasPrefix(path, "object/list/") {
+			return "", ""
+		} else if object, ok := strings.CutPrefix(path, "object/"); ok {
+			return "upload_object", object
+		}
+	case http.MethodPut:
+		if bucket, ok := strings.CutPrefix(path, "bucket/"); ok {
+			return "update_bucket", bucket
+		} else if object, ok := strings.CutPrefix(path, "object/"); ok {
+			return "upload_object", object
+		}
+	case http.MethodDelete:
+		if bucket, ok := strings.CutPrefix(path, "bucket/"); ok {
+			return "delete_bucket", bucket
+		} else if bucket, ok := strings.CutPrefix(path, "object/"); ok {
+			return "delete_objects", bucket
+		}
+	}
+	return "", ""
+}
+
+// Captures JSON request bodies, such as the list of deleted prefixes, without consuming them.
+func readJSONBody(req *http.Request) json.RawMessage {
+	if req.GetBody == nil || !strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil || !json.Valid(data) {
+		return nil
+	}
+	return bytes.TrimSpace(data)
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+func TestAuditTransport(t *testing.T) {
+	t.Run("records mutating operations", func(t *testing.T) {
+		auditPath := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]string{})
+		gock.New("http://127.0.0.1").
+			Delete("/storage/v1/object/private").
+			Reply(http.StatusOK).
+			JSON([]string{})
+		gock.New("http://127.0.0.1").
+			Delete("/storage/v1/bucket/private").
+			Reply(http.StatusBadRequest)
+		api := fetcher.NewFetcher("http://127.0.0.1", fetcher.WithHTTPClient(&http.Client{
+			Transport: newAuditTransport(http.DefaultTransport, auditPath, "test-project"),
+		}))
+		// Run test
+		_, err := api.Send(context.Background(), http.MethodPost, "/storage/v1/object/list/private", map[string]string{"prefix": ""})
+		require.NoError(t, err)
+		_, err = api.Send(context.Background(), http.MethodDelete, "/storage/v1/object/private", map[string][]string{"prefixes": {"docs/readme.md"}})
+		require.NoError(t, err)
+		_, err = api.Send(context.Background(), http.MethodDelete, "/storage/v1/bucket/private", nil)
+		require.Error(t, err)
+		// Check audit log
+		data, err := os.ReadFile(auditPath)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, lines, 2)
+		var entry AuditEntry
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+		assert.Equal(t, "test-project", entry.Project)
+		assert.Equal(t, "delete_objects", entry.Operation)
+		assert.Equal(t, "private", entry.Object)
+		assert.JSONEq(t, `{"prefixes":["docs/readme.md"]}`, string(entry.Request))
+		assert.Equal(t, http.StatusOK, entry.Status)
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+		assert.Equal(t, "delete_bucket", entry.Operation)
+		assert.Equal(t, http.StatusBadRequest, entry.Status)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips audit when path is unset", func(t *testing.T) {
+		rt := newAuditTransport(http.DefaultTransport, "", "local")
+		assert.Equal(t, http.DefaultTransport, rt)
+	})
+}
+
+func TestGetOperation(t *testing.T) {
+	for _, c := range []struct {
+		method string
+		path   string
+		op     string
+		object string
+	}{
+		{http.MethodPost, "/storage/v1/bucket", "create_bucket", ""},
+		{http.MethodPut, "/storage/v1/bucket/images", "update_bucket", "images"},
+		{http.MethodPost, "/storage/v1/object/images/cat.png", "upload_object", "images/cat.png"},
+		{http.MethodPost, "/storage/v1/object/move", "move_object", ""},
+		{http.MethodPost, "/storage/v1/object/list/images", "", ""},
+		{http.MethodGet, "/storage/v1/object/images/cat.png", "", ""},
+	} {
+		op, object := getOperation(c.method, c.path)
+		assert.Equal(t, c.op, op, c.path)
+		assert.Equal(t, c.object, object, c.path)
+	}
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/google/shlex"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"github.com/supabase/cli/pkg/config"
+)
+
+// Storage commands can authenticate without login when providers are configured.
+func HasCredentialProviders() bool {
+	return len(utils.Config.Storage.Client.Credentials) > 0
+}
+
+// Returns the token from the first provider that resolves one, or an empty
+// string if none of the providers are available in this environment.
+func ResolveToken(ctx context.Context, providers []config.CredentialProvider) (string, error) {
+	for _, p := range providers {
+		token, err := resolveProvider(ctx, p)
+		if err != nil {
+			return "", err
+		}
+		if token = strings.TrimSpace(token); len(token) > 0 {
+			fmt.Fprintln(utils.GetDebugLogger(), "Using storage credentials from provider:", p.Type)
+			return token, nil
+		}
+	}
+	return "", nil
+}
+
+func resolveProvider(ctx context.Context, p config.CredentialProvider) (string, error) {
+	switch p.Type {
+	case config.CredentialEnv:
+		return os.Getenv(p.Env), nil
+	case config.CredentialCommand:
+		// Config is usually checked in, so running commands from it must be opted in by the user
+		if !viper.GetBool("ALLOW_CREDENTIAL_COMMAND") {
+			fmt.Fprintln(os.Stderr, "Skipping credential command because SUPABASE_ALLOW_CREDENTIAL_COMMAND is not set:", p.Command)
+			return "", nil
+		}
+		return execCommand(ctx, p.Command)
+	case config.CredentialKeychain:
+		token, err := credentials.StoreProvider.Get(p.Key)
+		if err != nil {
+			// Keychain may be unavailable, ie. in CI, so try the next provider
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+			return "", nil
+		}
+		return token, nil
+	}
+	return "", errors.Errorf("unsupported credential provider: %s", p.Type)
+}
+
+// Runs the command without a shell so that the same config works across platforms.
+func execCommand(ctx context.Context, command string) (string, error) {
+	args, err := shlex.Split(command)
+	if err != nil {
+		return "", errors.Errorf("failed to parse credential command: %w", err)
+	} else if len(args) == 0 {
+		return "", errors.New("credential command must not be empty")
+	}
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Errorf("failed to run credential command: %w", err)
+	}
+	return stdout.String(), nil
+}
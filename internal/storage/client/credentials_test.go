@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestResolveToken(t *testing.T) {
+	t.Run("skips unavailable providers", func(t *testing.T) {
+		t.Setenv("STORAGE_TOKEN", "env-token")
+		t.Cleanup(credentials.MockInit())
+		// Run test
+		token, err := ResolveToken(context.Background(), []config.CredentialProvider{
+			{Type: config.CredentialCommand, Command: "echo 'cmd-token'"},
+			{Type: config.CredentialKeychain, Key: "storage"},
+			{Type: config.CredentialEnv, Env: "STORAGE_TOKEN"},
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "env-token", token)
+	})
+
+	t.Run("skips command without opt in", func(t *testing.T) {
+		// Run test
+		token, err := ResolveToken(context.Background(), []config.CredentialProvider{
+			{Type: config.CredentialCommand, Command: "echo 'cmd-token'"},
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, token)
+	})
+
+	t.Run("reads token from keychain", func(t *testing.T) {
+		t.Cleanup(credentials.MockInit())
+		require.NoError(t, credentials.StoreProvider.Set("storage", "keychain-token"))
+		// Run test
+		token, err := ResolveToken(context.Background(), []config.CredentialProvider{
+			{Type: config.CredentialKeychain, Key: "storage"},
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "keychain-token", token)
+	})
+
+	t.Run("reads token from command output", func(t *testing.T) {
+		viper.Set("ALLOW_CREDENTIAL_COMMAND", true)
+		t.Cleanup(func() { viper.Set("ALLOW_CREDENTIAL_COMMAND", false) })
+		token, err := ResolveToken(context.Background(), []config.CredentialProvider{
+			{Type: config.CredentialCommand, Command: "echo 'cmd-token'"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "cmd-token", token)
+	})
+
+	t.Run("throws error on failed command", func(t *testing.T) {
+		viper.Set("ALLOW_CREDENTIAL_COMMAND", true)
+		t.Cleanup(func() { viper.Set("ALLOW_CREDENTIAL_COMMAND", false) })
+		_, err := ResolveToken(context.Background(), []config.CredentialProvider{
+			{Type: config.CredentialCommand, Command: "false"},
+		})
+		assert.ErrorContains(t, err, "failed to run credential command:")
+	})
+}
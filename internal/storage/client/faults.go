@@ -0,0 +1,124 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Fault injection is only meant for testing scripts built on the CLI, ie.
+// --inject-faults rate=0.1,codes=429,500 fails about 10% of storage API calls.
+type FaultSpec struct {
+	Rate  float64
+	Codes []int
+}
+
+var (
+	injectFaults FaultSpec
+	warnFaults   sync.Once
+)
+
+func ParseFaultSpec(spec string) (FaultSpec, error) {
+	result := FaultSpec{Codes: []int{http.StatusInternalServerError}}
+	var key string
+	var codes []int
+	for _, token := range strings.Split(spec, ",") {
+		value := strings.TrimSpace(token)
+		if k, v, found := strings.Cut(value, "="); found {
+			key, value = strings.TrimSpace(k), strings.TrimSpace(v)
+		} else if key != "codes" {
+			// Only the list of codes can span multiple comma separated values
+			return result, errors.Errorf("invalid fault spec: %s", token)
+		}
+		switch key {
+		case "rate":
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil || rate <= 0 || rate > 1 {
+				return result, errors.Errorf("fault rate must be a number between 0 and 1: %s", value)
+			}
+			result.Rate = rate
+		case "codes":
+			code, err := strconv.Atoi(value)
+			if err != nil || code < 400 || code > 599 {
+				return result, errors.Errorf("fault code must be an HTTP error status: %s", value)
+			}
+			codes = append(codes, code)
+		default:
+			return result, errors.Errorf("unknown fault spec key: %s", key)
+		}
+	}
+	if result.Rate == 0 {
+		return result, errors.New("missing fault rate, ie. rate=0.1")
+	}
+	if len(codes) > 0 {
+		result.Codes = codes
+	}
+	return result, nil
+}
+
+func loadFaultSpec() error {
+	spec := viper.GetString("INJECT_FAULTS")
+	if len(spec) == 0 {
+		return nil
+	}
+	var err error
+	if injectFaults, err = ParseFaultSpec(spec); err != nil {
+		return err
+	}
+	// Commands may create multiple storage clients, but the warning is only useful once
+	warnFaults.Do(func() {
+		fmt.Fprintf(os.Stderr, "%s injecting faults into %g%% of storage API calls\n", utils.Yellow("WARNING:"), injectFaults.Rate*100)
+	})
+	return nil
+}
+
+// Fails requests before they are sent, so the server never sees them. Injected 429
+// responses are retried by the rate limiter just like real ones.
+type faultTransport struct {
+	http.RoundTripper
+	spec FaultSpec
+}
+
+func newFaultTransport(rt http.RoundTripper, spec FaultSpec) http.RoundTripper {
+	if spec.Rate <= 0 || len(spec.Codes) == 0 {
+		return rt
+	}
+	return &faultTransport{RoundTripper: rt, spec: spec}
+}
+
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rand.Float64() >= t.spec.Rate {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	// Round trippers must always close the request body
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	code := t.spec.Codes[rand.IntN(len(t.spec.Codes))]
+	fmt.Fprintln(utils.GetDebugLogger(), "Injected fault:", code, req.Method, req.URL.Path)
+	body := fmt.Sprintf(`{"statusCode":"%d","error":"%s","message":"Injected fault"}`, code, http.StatusText(code))
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	if code == http.StatusTooManyRequests {
+		header.Set("Retry-After", "1")
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		StatusCode:    code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
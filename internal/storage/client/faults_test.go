@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+)
+
+func TestParseFaultSpec(t *testing.T) {
+	t.Run("parses rate and codes", func(t *testing.T) {
+		spec, err := ParseFaultSpec("rate=0.1,codes=429,500")
+		assert.NoError(t, err)
+		assert.Equal(t, FaultSpec{Rate: 0.1, Codes: []int{429, 500}}, spec)
+	})
+
+	t.Run("defaults to internal server error", func(t *testing.T) {
+		spec, err := ParseFaultSpec("rate=1")
+		assert.NoError(t, err)
+		assert.Equal(t, []int{http.StatusInternalServerError}, spec.Codes)
+	})
+
+	t.Run("throws error on invalid rate", func(t *testing.T) {
+		_, err := ParseFaultSpec("rate=2")
+		assert.ErrorContains(t, err, "fault rate must be a number between 0 and 1")
+	})
+
+	t.Run("throws error on missing rate", func(t *testing.T) {
+		_, err := ParseFaultSpec("codes=503")
+		assert.ErrorContains(t, err, "missing fault rate")
+	})
+
+	t.Run("throws error on non error code", func(t *testing.T) {
+		_, err := ParseFaultSpec("rate=0.5,codes=200")
+		assert.ErrorContains(t, err, "fault code must be an HTTP error status")
+	})
+
+	t.Run("throws error on unknown key", func(t *testing.T) {
+		_, err := ParseFaultSpec("rate=0.5,latency=1s")
+		assert.ErrorContains(t, err, "unknown fault spec key: latency")
+	})
+}
+
+func TestFaultTransport(t *testing.T) {
+	t.Run("fails request without sending", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		client := http.Client{Transport: newFaultTransport(http.DefaultTransport, FaultSpec{Rate: 1, Codes: []int{503}})}
+		// Run test
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://127.0.0.1/storage/v1/bucket", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		// Check error
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"statusCode":"503","error":"Service Unavailable","message":"Injected fault"}`, string(body))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("retries injected rate limit", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/bucket").
+			Reply(http.StatusOK)
+		calls := 0
+		faults := newFaultTransport(http.DefaultTransport, FaultSpec{Rate: 1, Codes: []int{429}})
+		client := http.Client{Transport: newRateLimitTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			// Only fail the first attempt
+			if calls++; calls == 1 {
+				return faults.RoundTrip(req)
+			}
+			return http.DefaultTransport.RoundTrip(req)
+		}), 0)}
+		// Run test
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://127.0.0.1/storage/v1/bucket", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, calls)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("passes through when disabled", func(t *testing.T) {
+		assert.Equal(t, http.DefaultTransport, newFaultTransport(http.DefaultTransport, FaultSpec{}))
+	})
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/metrics"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Maximum requests per second sent to storage API. Zero means unlimited.
+var MaxRPS float64
+
+const maxRetries = 5
+
+// Paces storage API requests and retries those throttled by the server, so
+// that large recursive traversals slow down instead of erroring out.
+type rateLimitTransport struct {
+	http.RoundTripper
+	interval time.Duration
+	mu       sync.Mutex
+	next     time.Time
+}
+
+func newRateLimitTransport(rt http.RoundTripper, maxRPS float64) *rateLimitTransport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	t := &rateLimitTransport{RoundTripper: rt}
+	if maxRPS > 0 {
+		t.interval = time.Duration(float64(time.Second) / maxRPS)
+	}
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := t.wait(req.Context()); err != nil {
+			return nil, err
+		}
+		resp, err := t.RoundTripper.RoundTrip(req)
+		if err != nil {
+			metrics.Add(req.Context(), "storage.api_calls", 1, attribute.String("method", req.Method), attribute.String("status", "error"))
+			return nil, err
+		}
+		recordResponse(req, resp)
+		delay := retryDelay(resp.Header, attempt)
+		if resp.StatusCode != http.StatusTooManyRequests {
+			// Pause subsequent requests once the server reports no remaining quota
+			if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+				t.pause(delay)
+			}
+			return resp, nil
+		}
+		if attempt >= maxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+		// Drain body so the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if req, err = rewindRequest(req); err != nil {
+			return nil, err
+		}
+		fmt.Fprintln(os.Stderr, "Rate limited by storage API, retrying in", delay)
+		metrics.Add(req.Context(), "storage.retries", 1, attribute.String("method", req.Method))
+		t.pause(delay)
+	}
+}
+
+// Counts bytes in both directions since object transfers dominate the traffic of storage API.
+func recordResponse(req *http.Request, resp *http.Response) {
+	ctx := req.Context()
+	metrics.Add(ctx, "storage.api_calls", 1, attribute.String("method", req.Method), attribute.Int("status", resp.StatusCode))
+	if req.ContentLength > 0 {
+		metrics.Add(ctx, "storage.bytes_sent", req.ContentLength)
+	}
+	resp.Body = &countingReader{ReadCloser: resp.Body, ctx: ctx}
+}
+
+// Reports the number of bytes read from the response body when it is closed.
+type countingReader struct {
+	io.ReadCloser
+	ctx context.Context
+	n   int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+func (r *countingReader) Close() error {
+	if r.n > 0 {
+		metrics.Add(r.ctx, "storage.bytes_received", r.n)
+		r.n = 0
+	}
+	return r.ReadCloser.Close()
+}
+
+// Blocks until the next request is allowed to be sent.
+func (t *rateLimitTransport) wait(ctx context.Context) error {
+	t.mu.Lock()
+	start := time.Now()
+	if t.next.After(start) {
+		start = t.next
+	}
+	t.next = start.Add(t.interval)
+	t.mu.Unlock()
+	timer := time.NewTimer(time.Until(start))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (t *rateLimitTransport) pause(delay time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if resume := time.Now().Add(delay); resume.After(t.next) {
+		t.next = resume
+	}
+}
+
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, errors.Errorf("failed to rewind request body: %w", err)
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+// Parses server hints on when to retry, falling back to exponential backoff.
+func retryDelay(header http.Header, attempt int) time.Duration {
+	if value := header.Get("Retry-After"); len(value) > 0 {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return time.Until(date)
+		}
+	}
+	if value := header.Get("X-RateLimit-Reset"); len(value) > 0 {
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+			// Some servers send an epoch timestamp instead of seconds until reset
+			if reset := time.Unix(seconds, 0); reset.After(time.Now()) {
+				return time.Until(reset)
+			}
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return time.Second << attempt
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+)
+
+func TestRateLimitTransport(t *testing.T) {
+	t.Run("retries throttled request with body", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			BodyString(`{"prefix":""}`).
+			Reply(http.StatusTooManyRequests).
+			SetHeader("Retry-After", "0")
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			BodyString(`{"prefix":""}`).
+			Reply(http.StatusOK)
+		client := http.Client{Transport: newRateLimitTransport(http.DefaultTransport, 0)}
+		// Run test
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://127.0.0.1/storage/v1/object/list/private", strings.NewReader(`{"prefix":""}`))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("paces requests by max rps", func(t *testing.T) {
+		rt := newRateLimitTransport(http.DefaultTransport, 100)
+		// Run test
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			require.NoError(t, rt.wait(context.Background()))
+		}
+		// Check elapsed
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+
+	t.Run("throws error on cancelled context", func(t *testing.T) {
+		rt := newRateLimitTransport(http.DefaultTransport, 0)
+		rt.pause(time.Hour)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		// Run test
+		err := rt.wait(ctx)
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Run("parses retry after seconds", func(t *testing.T) {
+		header := http.Header{"Retry-After": []string{"3"}}
+		assert.Equal(t, 3*time.Second, retryDelay(header, 0))
+	})
+
+	t.Run("parses rate limit reset", func(t *testing.T) {
+		header := http.Header{"X-Ratelimit-Reset": []string{"5"}}
+		assert.Equal(t, 5*time.Second, retryDelay(header, 0))
+	})
+
+	t.Run("falls back to exponential backoff", func(t *testing.T) {
+		assert.Equal(t, 4*time.Second, retryDelay(http.Header{}, 2))
+	})
+}
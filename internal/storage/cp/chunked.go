@@ -0,0 +1,142 @@
+package cp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+// Objects larger than ChunkSize are downloaded in ranged chunks when set.
+var ChunkSize int64
+
+// Progress of a chunked download, saved next to the partial file after each chunk.
+type journal struct {
+	ETag      string `json:"etag"`
+	Size      int64  `json:"size"`
+	ChunkSize int64  `json:"chunk_size"`
+	// Number of contiguous chunks written from the start of file
+	Completed int64 `json:"completed"`
+}
+
+// Resuming is only safe if the object has not changed since the journal was written.
+func (j journal) matches(other journal) bool {
+	return j.ETag == other.ETag && j.Size == other.Size && j.ChunkSize == other.ChunkSize
+}
+
+func partPaths(localPath string) (string, string) {
+	return localPath + ".part", localPath + ".part.json"
+}
+
+// Number of times a chunked download starts over when the object changes midway.
+const maxRestarts = 3
+
+// Downloads the object in chunks to a partial file that is renamed to localPath when
+// complete. Returns false without downloading if the object fits in a single chunk.
+func downloadChunked(ctx context.Context, api storage.StorageAPI, remotePath, localPath string, fsys afero.Fs) (bool, error) {
+	for attempt := 0; ; attempt++ {
+		ok, err := downloadChunks(ctx, api, remotePath, localPath, fsys)
+		if !errors.Is(err, storage.ErrObjectChanged) || attempt >= maxRestarts {
+			return ok, err
+		}
+		// Chunks already written belong to the old version of the object
+		fmt.Fprintln(os.Stderr, "Object changed during download, restarting:", remotePath)
+		_, journalPath := partPaths(localPath)
+		if err := fsys.Remove(journalPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return false, errors.Errorf("failed to remove journal: %w", err)
+		}
+	}
+}
+
+func downloadChunks(ctx context.Context, api storage.StorageAPI, remotePath, localPath string, fsys afero.Fs) (bool, error) {
+	object, err := statRemoteObject(ctx, api, remotePath)
+	if err != nil {
+		return false, err
+	} else if object == nil || object.Metadata == nil || int64(object.Metadata.Size) <= ChunkSize {
+		return false, nil
+	}
+	want := journal{
+		ETag:      object.Metadata.ETag,
+		Size:      int64(object.Metadata.Size),
+		ChunkSize: ChunkSize,
+	}
+	partPath, journalPath := partPaths(localPath)
+	progress := loadJournal(journalPath, fsys)
+	flag := os.O_WRONLY | os.O_CREATE
+	if !progress.matches(want) || !hasCompletedChunks(partPath, progress, fsys) {
+		progress = want
+		flag |= os.O_TRUNC
+	} else if progress.Completed > 0 {
+		fmt.Fprintf(os.Stderr, "Resuming %s from %d bytes\n", remotePath, progress.Completed*ChunkSize)
+	}
+	f, err := fsys.OpenFile(partPath, flag, 0644)
+	if err != nil {
+		return false, errors.Errorf("failed to open partial file: %w", err)
+	}
+	defer f.Close()
+	for offset := progress.Completed * ChunkSize; offset < progress.Size; offset += ChunkSize {
+		if utils.IsInterrupted(ctx) {
+			return false, errors.New(utils.ErrInterrupted)
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return false, errors.Errorf("failed to seek partial file: %w", err)
+		}
+		length := min(ChunkSize, progress.Size-offset)
+		if err := api.DownloadObjectRange(ctx, remotePath, offset, length, progress.ETag, f); err != nil {
+			return false, err
+		}
+		progress.Completed++
+		if err := saveJournal(journalPath, progress, fsys); err != nil {
+			return false, err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return false, errors.Errorf("failed to close partial file: %w", err)
+	}
+	if err := fsys.Rename(partPath, localPath); err != nil {
+		return false, errors.Errorf("failed to rename partial file: %w", err)
+	}
+	if err := fsys.Remove(journalPath); err != nil {
+		return false, errors.Errorf("failed to remove journal: %w", err)
+	}
+	return true, nil
+}
+
+// A partial file shorter than the completed chunks, ie. deleted or truncated after the
+// journal was written, cannot be resumed.
+func hasCompletedChunks(partPath string, progress journal, fsys afero.Fs) bool {
+	info, err := fsys.Stat(partPath)
+	if err != nil {
+		return false
+	}
+	return info.Size() >= min(progress.Completed*progress.ChunkSize, progress.Size)
+}
+
+// A missing or corrupt journal restarts the download from the first chunk.
+func loadJournal(journalPath string, fsys afero.Fs) journal {
+	var result journal
+	if data, err := afero.ReadFile(fsys, journalPath); err != nil {
+		return journal{}
+	} else if err := json.Unmarshal(data, &result); err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+		return journal{}
+	}
+	return result
+}
+
+func saveJournal(journalPath string, progress journal, fsys afero.Fs) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return errors.Errorf("failed to encode journal: %w", err)
+	}
+	if err := afero.WriteFile(fsys, journalPath, data, 0644); err != nil {
+		return errors.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package cp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func mockLargeObject(size int) storage.ObjectResponse {
+	return storage.ObjectResponse{
+		Name:     "large.bin",
+		Id:       cast.Ptr("9b7f9f48-17a6-4ca8-b14a-39b0205a63e9"),
+		Metadata: &storage.ObjectMetadata{ETag: `"etag"`, Size: size},
+	}
+}
+
+func setChunkSize(t *testing.T, size int64) {
+	ChunkSize = size
+	t.Cleanup(func() { ChunkSize = 0 })
+}
+
+func TestDownloadChunked(t *testing.T) {
+	t.Run("downloads object in chunks", func(t *testing.T) {
+		setChunkSize(t, 4)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockLargeObject(10)})
+		for _, r := range []struct{ header, body string }{
+			{"bytes=0-3", "0123"},
+			{"bytes=4-7", "4567"},
+			{"bytes=8-9", "89"},
+		} {
+			gock.New("http://127.0.0.1").
+				Get("/storage/v1/object/private/large.bin").
+				MatchHeader("Range", r.header).
+				MatchHeader("If-Match", `"etag"`).
+				Reply(http.StatusPartialContent).
+				BodyString(r.body)
+		}
+		// Run test
+		ok, err := downloadChunked(context.Background(), mockApi, "/private/large.bin", "/tmp/large.bin", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		data, err := afero.ReadFile(fsys, "/tmp/large.bin")
+		assert.NoError(t, err)
+		assert.Equal(t, "0123456789", string(data))
+		exists, err := afero.Exists(fsys, "/tmp/large.bin.part.json")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("resumes from last complete chunk", func(t *testing.T) {
+		setChunkSize(t, 4)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/large.bin.part", []byte("0123xx"), 0644))
+		progress := journal{ETag: `"etag"`, Size: 10, ChunkSize: 4, Completed: 1}
+		require.NoError(t, saveJournal("/tmp/large.bin.part.json", progress, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockLargeObject(10)})
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/large.bin").
+			MatchHeader("Range", "bytes=4-7").
+			Reply(http.StatusPartialContent).
+			BodyString("4567")
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/large.bin").
+			MatchHeader("Range", "bytes=8-9").
+			Reply(http.StatusPartialContent).
+			BodyString("89")
+		// Run test
+		ok, err := downloadChunked(context.Background(), mockApi, "/private/large.bin", "/tmp/large.bin", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		data, err := afero.ReadFile(fsys, "/tmp/large.bin")
+		assert.NoError(t, err)
+		assert.Equal(t, "0123456789", string(data))
+	})
+
+	t.Run("restarts when partial file is truncated", func(t *testing.T) {
+		setChunkSize(t, 4)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/large.bin.part", []byte("01"), 0644))
+		progress := journal{ETag: `"etag"`, Size: 10, ChunkSize: 4, Completed: 2}
+		require.NoError(t, saveJournal("/tmp/large.bin.part.json", progress, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockLargeObject(10)})
+		for _, r := range []struct{ header, body string }{
+			{"bytes=0-3", "0123"},
+			{"bytes=4-7", "4567"},
+			{"bytes=8-9", "89"},
+		} {
+			gock.New("http://127.0.0.1").
+				Get("/storage/v1/object/private/large.bin").
+				MatchHeader("Range", r.header).
+				Reply(http.StatusPartialContent).
+				BodyString(r.body)
+		}
+		// Run test
+		ok, err := downloadChunked(context.Background(), mockApi, "/private/large.bin", "/tmp/large.bin", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		data, err := afero.ReadFile(fsys, "/tmp/large.bin")
+		assert.NoError(t, err)
+		assert.Equal(t, "0123456789", string(data))
+	})
+
+	t.Run("restarts when object changed", func(t *testing.T) {
+		setChunkSize(t, 4)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/large.bin.part", []byte("stale-data"), 0644))
+		progress := journal{ETag: `"old"`, Size: 10, ChunkSize: 4, Completed: 2}
+		require.NoError(t, saveJournal("/tmp/large.bin.part.json", progress, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockLargeObject(5)})
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/large.bin").
+			MatchHeader("Range", "bytes=0-3").
+			Reply(http.StatusPartialContent).
+			BodyString("abcd")
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/large.bin").
+			MatchHeader("Range", "bytes=4-4").
+			Reply(http.StatusPartialContent).
+			BodyString("e")
+		// Run test
+		ok, err := downloadChunked(context.Background(), mockApi, "/private/large.bin", "/tmp/large.bin", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		data, err := afero.ReadFile(fsys, "/tmp/large.bin")
+		assert.NoError(t, err)
+		assert.Equal(t, "abcde", string(data))
+	})
+
+	t.Run("restarts when object changes during download", func(t *testing.T) {
+		setChunkSize(t, 4)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockLargeObject(6)})
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/large.bin").
+			MatchHeader("Range", "bytes=0-3").
+			MatchHeader("If-Match", `"etag"`).
+			Reply(http.StatusPartialContent).
+			BodyString("0123")
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/large.bin").
+			MatchHeader("Range", "bytes=4-5").
+			MatchHeader("If-Match", `"etag"`).
+			Reply(http.StatusPreconditionFailed)
+		changed := mockLargeObject(5)
+		changed.Metadata.ETag = `"new"`
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{changed})
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/large.bin").
+			MatchHeader("Range", "bytes=0-3").
+			MatchHeader("If-Match", `"new"`).
+			Reply(http.StatusPartialContent).
+			BodyString("abcd")
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/large.bin").
+			MatchHeader("Range", "bytes=4-4").
+			MatchHeader("If-Match", `"new"`).
+			Reply(http.StatusPartialContent).
+			BodyString("e")
+		// Run test
+		ok, err := downloadChunked(context.Background(), mockApi, "/private/large.bin", "/tmp/large.bin", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		data, err := afero.ReadFile(fsys, "/tmp/large.bin")
+		assert.NoError(t, err)
+		assert.Equal(t, "abcde", string(data))
+	})
+
+	t.Run("skips object within chunk size", func(t *testing.T) {
+		setChunkSize(t, 16)
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockLargeObject(10)})
+		// Run test
+		ok, err := downloadChunked(context.Background(), mockApi, "/private/large.bin", "/tmp/large.bin", afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("keeps journal on range failure", func(t *testing.T) {
+		setChunkSize(t, 4)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockLargeObject(10)})
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/large.bin").
+			MatchHeader("Range", "bytes=0-3").
+			Reply(http.StatusPartialContent).
+			BodyString("0123")
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/large.bin").
+			MatchHeader("Range", "bytes=4-7").
+			Reply(http.StatusOK).
+			BodyString("0123456789")
+		// Run test
+		ok, err := downloadChunked(context.Background(), mockApi, "/private/large.bin", "/tmp/large.bin", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "range requests are not supported")
+		assert.False(t, ok)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		assert.Equal(t, int64(1), loadJournal("/tmp/large.bin.part.json", fsys).Completed)
+	})
+}
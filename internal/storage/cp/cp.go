@@ -3,31 +3,55 @@ package cp
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/fs"
-	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/metrics"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/storage/cache"
 	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/storage/crypt"
 	"github.com/supabase/cli/internal/storage/ls"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 	"github.com/supabase/cli/pkg/queue"
 	"github.com/supabase/cli/pkg/storage"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-var errUnsupportedOperation = errors.New("Unsupported operation")
+var (
+	errUnsupportedOperation = errors.New("Unsupported operation")
+	errReadOnly             = errors.New("Uploading objects requires a linked project or service role key.")
+)
+
+func Run(ctx context.Context, src, dst string, recursive bool, maxJobs uint, filter TransferFilter, codec crypt.Codec, fsys afero.Fs, opts ...func(*storage.FileOptions)) error {
+	start := time.Now()
+	direction := attribute.String("direction", "upload")
+	if strings.HasPrefix(strings.ToLower(src), client.STORAGE_SCHEME+"://") {
+		direction = attribute.String("direction", "download")
+	}
+	err := run(ctx, src, dst, recursive, maxJobs, filter, codec, fsys, opts...)
+	if err == nil && !recursive {
+		metrics.Add(ctx, "storage.objects", 1, direction)
+	}
+	metrics.Duration(ctx, "storage.cp.duration", start, direction, metrics.Status(err))
+	return err
+}
 
-func Run(ctx context.Context, src, dst string, recursive bool, maxJobs uint, fsys afero.Fs, opts ...func(*storage.FileOptions)) error {
-	srcParsed, err := url.Parse(src)
+func run(ctx context.Context, src, dst string, recursive bool, maxJobs uint, filter TransferFilter, codec crypt.Codec, fsys afero.Fs, opts ...func(*storage.FileOptions)) error {
+	srcParsed, err := hostPath.parse(src)
 	if err != nil {
 		return errors.Errorf("failed to parse src url: %w", err)
 	}
-	dstParsed, err := url.Parse(dst)
+	dstParsed, err := hostPath.parse(dst)
 	if err != nil {
 		return errors.Errorf("failed to parse dst url: %w", err)
 	}
@@ -35,24 +59,48 @@ func Run(ctx context.Context, src, dst string, recursive bool, maxJobs uint, fsy
 	if err != nil {
 		return err
 	}
+	defer cache.Invalidate(flags.ProjectRef, fsys)
 	if strings.EqualFold(srcParsed.Scheme, client.STORAGE_SCHEME) && dstParsed.Scheme == "" {
+		// Absolute paths are extended past MAX_PATH by the os package on Windows
 		localPath := dst
 		if !filepath.IsAbs(dst) {
 			localPath = filepath.Join(utils.CurrentDirAbs, dst)
 		}
 		if recursive {
-			return DownloadStorageObjectAll(ctx, api, srcParsed.Path, localPath, maxJobs, fsys)
+			return DownloadStorageObjectAll(ctx, api, srcParsed.Path, localPath, maxJobs, filter, codec, fsys)
+		}
+		if filter.IsEnabled() {
+			if skip, err := skipDownload(ctx, api, srcParsed.Path, localPath, filter, fsys); err != nil || skip {
+				return err
+			}
+		}
+		if codec == nil {
+			// Existing files are never overwritten without --recursive flag
+			if exists, _ := afero.Exists(fsys, localPath); ChunkSize > 0 && !exists {
+				if ok, err := downloadChunked(ctx, api, srcParsed.Path, localPath, fsys); err != nil || ok {
+					return err
+				}
+			}
+			return api.DownloadObject(ctx, srcParsed.Path, localPath, fsys)
 		}
-		return api.DownloadObject(ctx, srcParsed.Path, localPath, fsys)
+		return decryptObject(ctx, api, srcParsed.Path, localPath, false, codec, fsys)
 	} else if srcParsed.Scheme == "" && strings.EqualFold(dstParsed.Scheme, client.STORAGE_SCHEME) {
+		if api.Public {
+			return errors.New(errReadOnly)
+		}
 		localPath := src
 		if !filepath.IsAbs(localPath) {
 			localPath = filepath.Join(utils.CurrentDirAbs, localPath)
 		}
 		if recursive {
-			return UploadStorageObjectAll(ctx, api, dstParsed.Path, localPath, maxJobs, fsys, opts...)
+			return UploadStorageObjectAll(ctx, api, dstParsed.Path, localPath, maxJobs, filter, codec, fsys, opts...)
 		}
-		return api.UploadObject(ctx, dstParsed.Path, src, fsys, opts...)
+		if filter.IsEnabled() {
+			if skip, err := skipUpload(ctx, api, localPath, dstParsed.Path, filter, fsys); err != nil || skip {
+				return err
+			}
+		}
+		return uploadObject(ctx, api, dstParsed.Path, localPath, codec, fsys, opts...)
 	} else if strings.EqualFold(srcParsed.Scheme, client.STORAGE_SCHEME) && strings.EqualFold(dstParsed.Scheme, client.STORAGE_SCHEME) {
 		return errors.New("Copying between buckets is not supported")
 	}
@@ -60,19 +108,65 @@ func Run(ctx context.Context, src, dst string, recursive bool, maxJobs uint, fsy
 	return errors.New(errUnsupportedOperation)
 }
 
-func DownloadStorageObjectAll(ctx context.Context, api storage.StorageAPI, remotePath, localPath string, maxJobs uint, fsys afero.Fs) error {
+func skipDownload(ctx context.Context, api storage.StorageAPI, remotePath, localPath string, filter TransferFilter, fsys afero.Fs) (bool, error) {
+	local, err := fsys.Stat(localPath)
+	if err != nil {
+		return false, nil
+	}
+	remote, err := statRemoteObject(ctx, api, remotePath)
+	if err != nil || filter.ShouldDownload(remote, local) {
+		return false, err
+	}
+	fmt.Fprintln(os.Stderr, render.T("skipping_unchanged", remotePath))
+	return true, nil
+}
+
+func skipUpload(ctx context.Context, api storage.StorageAPI, localPath, remotePath string, filter TransferFilter, fsys afero.Fs) (bool, error) {
+	local, err := fsys.Stat(localPath)
+	if err != nil {
+		return false, nil
+	}
+	remote, err := statRemoteObject(ctx, api, remotePath)
+	if err != nil || filter.ShouldUpload(local, remote) {
+		return false, err
+	}
+	fmt.Fprintln(os.Stderr, render.T("skipping_unchanged", localPath))
+	return true, nil
+}
+
+func DownloadStorageObjectAll(ctx context.Context, api storage.StorageAPI, remotePath, localPath string, maxJobs uint, filter TransferFilter, codec crypt.Codec, fsys afero.Fs) error {
 	// Prepare local directory for download
 	if fi, err := fsys.Stat(localPath); err == nil && fi.IsDir() {
 		localPath = filepath.Join(localPath, path.Base(remotePath))
 	}
+	remoteObjects := map[string]storage.ObjectResponse{}
+	if filter.IsEnabled() {
+		var err error
+		if remoteObjects, err = listRemoteObjects(ctx, api, remotePath); err != nil {
+			return err
+		}
+	}
 	// No need to be atomic because it's incremented only on main thread
 	count := 0
+	written := newPathSet(hostPath)
+	var done atomic.Int64
 	jq := queue.NewJobQueue(maxJobs)
 	err := ls.IterateStoragePathsAll(ctx, api, remotePath, func(objectPath string) error {
+		if utils.IsInterrupted(ctx) {
+			return errors.New(utils.ErrInterrupted)
+		}
 		relPath := strings.TrimPrefix(objectPath, remotePath)
 		dstPath := filepath.Join(localPath, filepath.FromSlash(relPath))
-		fmt.Fprintln(os.Stderr, "Downloading:", objectPath, "=>", dstPath)
 		count++
+		if prev, ok := written.add(dstPath, objectPath); ok {
+			fmt.Fprintln(os.Stderr, "Skipping conflict:", objectPath, "maps to the same local file as", prev)
+			return nil
+		}
+		if local, err := fsys.Stat(dstPath); err == nil && !filter.ShouldDownload(lookupObject(remoteObjects, objectPath), local) {
+			fmt.Fprintln(os.Stderr, render.T("skipping_unchanged", objectPath))
+			return nil
+		}
+		fmt.Fprintln(os.Stderr, render.T("downloading_to", objectPath, dstPath))
 		job := func() error {
 			if strings.HasSuffix(objectPath, "/") {
 				return utils.MkdirIfNotExistFS(fsys, dstPath)
@@ -80,23 +174,43 @@ func DownloadStorageObjectAll(ctx context.Context, api storage.StorageAPI, remot
 			if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(dstPath)); err != nil {
 				return err
 			}
+			if codec == nil && ChunkSize > 0 {
+				if ok, err := downloadChunked(ctx, api, objectPath, dstPath, fsys); err != nil {
+					return err
+				} else if ok {
+					done.Add(1)
+					return nil
+				}
+			}
 			// Overwrites existing file when using --recursive flag
+			if codec != nil {
+				if err := decryptObject(ctx, api, objectPath, dstPath, true, codec, fsys); err != nil {
+					return err
+				}
+				done.Add(1)
+				return nil
+			}
 			f, err := fsys.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 			if err != nil {
 				return errors.Errorf("failed to create file: %w", err)
 			}
 			defer f.Close()
-			return api.DownloadObjectStream(ctx, objectPath, f)
+			if err := downloadObject(ctx, api, objectPath, f, nil); err != nil {
+				return err
+			}
+			done.Add(1)
+			return nil
 		}
 		return jq.Put(job)
 	})
 	if count == 0 {
 		return errors.New("Object not found: " + remotePath)
 	}
-	return errors.Join(err, jq.Collect())
+	metrics.Add(ctx, "storage.objects", done.Load(), attribute.String("direction", "download"))
+	return flushInterrupted(errors.Join(err, jq.Collect()), "Downloaded", done.Load())
 }
 
-func UploadStorageObjectAll(ctx context.Context, api storage.StorageAPI, remotePath, localPath string, maxJobs uint, fsys afero.Fs, opts ...func(*storage.FileOptions)) error {
+func UploadStorageObjectAll(ctx context.Context, api storage.StorageAPI, remotePath, localPath string, maxJobs uint, filter TransferFilter, codec crypt.Codec, fsys afero.Fs, opts ...func(*storage.FileOptions)) error {
 	noSlash := strings.TrimSuffix(remotePath, "/")
 	// Check if directory exists on remote
 	dirExists := false
@@ -115,16 +229,27 @@ func UploadStorageObjectAll(ctx context.Context, api storage.StorageAPI, remoteP
 			return err
 		}
 	}
+	remoteObjects := map[string]storage.ObjectResponse{}
+	if filter.IsEnabled() {
+		var err error
+		if remoteObjects, err = listRemoteObjects(ctx, api, noSlash); err != nil {
+			return err
+		}
+	}
 	// Overwrites existing object when using --recursive flag
 	opts = append(opts, func(fo *storage.FileOptions) {
 		fo.Overwrite = true
 	})
 	baseName := filepath.Base(localPath)
+	var done atomic.Int64
 	jq := queue.NewJobQueue(maxJobs)
 	err := afero.Walk(fsys, localPath, func(filePath string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return errors.New(err)
 		}
+		if utils.IsInterrupted(ctx) {
+			return errors.New(utils.ErrInterrupted)
+		}
 		if !info.Mode().IsRegular() {
 			return nil
 		}
@@ -144,11 +269,21 @@ func UploadStorageObjectAll(ctx context.Context, api storage.StorageAPI, remoteP
 			if baseName != "." && (dirExists || len(noSlash) == 0) {
 				dstPath = path.Join(dstPath, baseName)
 			}
-			dstPath = path.Join(dstPath, relPath)
+			dstPath = path.Join(dstPath, hostPath.toKey(relPath))
+		}
+		remote := lookupObject(remoteObjects, dstPath)
+		if relPath == "." && filter.IsEnabled() {
+			if remote, err = statRemoteObject(ctx, api, dstPath); err != nil {
+				return err
+			}
 		}
-		fmt.Fprintln(os.Stderr, "Uploading:", filePath, "=>", dstPath)
+		if !filter.ShouldUpload(info, remote) {
+			fmt.Fprintln(os.Stderr, render.T("skipping_unchanged", filePath))
+			return nil
+		}
+		fmt.Fprintln(os.Stderr, render.T("uploading_to", filePath, dstPath))
 		job := func() error {
-			err := api.UploadObject(ctx, dstPath, filePath, fsys, opts...)
+			err := uploadObject(ctx, api, dstPath, filePath, codec, fsys, opts...)
 			if err != nil && strings.Contains(err.Error(), `"error":"Bucket not found"`) {
 				// Retry after creating bucket
 				if bucket, prefix := client.SplitBucketPrefix(dstPath); len(prefix) > 0 {
@@ -161,14 +296,108 @@ func UploadStorageObjectAll(ctx context.Context, api storage.StorageAPI, remoteP
 					if _, err := api.CreateBucket(ctx, body); err != nil {
 						return err
 					}
-					err = api.UploadObject(ctx, dstPath, filePath, fsys, opts...)
+					err = uploadObject(ctx, api, dstPath, filePath, codec, fsys, opts...)
 				}
 			}
+			if err == nil {
+				done.Add(1)
+			}
 			return err
 		}
 		return jq.Put(job)
 	})
-	return errors.Join(err, jq.Collect())
+	metrics.Add(ctx, "storage.objects", done.Load(), attribute.String("direction", "upload"))
+	return flushInterrupted(errors.Join(err, jq.Collect()), "Uploaded", done.Load())
+}
+
+// Reports progress made before Ctrl+C since in-flight transfers are allowed to finish.
+func flushInterrupted(err error, action string, count int64) error {
+	if errors.Is(err, utils.ErrInterrupted) {
+		fmt.Fprintf(os.Stderr, "%s %d files before interrupt.\n", action, count)
+	}
+	return err
+}
+
+// Encrypts the local file while streaming it to storage when codec is set.
+func uploadObject(ctx context.Context, api storage.StorageAPI, remotePath, localPath string, codec crypt.Codec, fsys afero.Fs, opts ...func(*storage.FileOptions)) error {
+	opts = append(opts, Headers.options(remotePath))
+	if codec == nil {
+		return api.UploadObject(ctx, remotePath, localPath, fsys, opts...)
+	}
+	f, err := fsys.Open(localPath)
+	if err != nil {
+		return errors.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+	// Ciphertext is opaque so the content type of plaintext must not leak
+	opts = append(opts, func(fo *storage.FileOptions) {
+		fo.ContentType = "application/octet-stream"
+	})
+	fo, err := storage.ParseFileOptions(f, opts...)
+	if err != nil {
+		return err
+	}
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		w, err := codec.Encrypt(pw)
+		if err == nil {
+			if _, err = io.Copy(w, f); err == nil {
+				err = w.Close()
+			}
+		}
+		pw.CloseWithError(err)
+	}()
+	return api.UploadObjectStream(ctx, remotePath, pr, *fo)
+}
+
+// Decrypts the remote object while streaming it to w when codec is set.
+func downloadObject(ctx context.Context, api storage.StorageAPI, remotePath string, w io.Writer, codec crypt.Codec) error {
+	if codec == nil {
+		return api.DownloadObjectStream(ctx, remotePath, w)
+	}
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		pw.CloseWithError(api.DownloadObjectStream(ctx, remotePath, pw))
+	}()
+	r, err := codec.Decrypt(pr)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		return errors.Errorf("failed to download %s: %w", remotePath, err)
+	}
+	return nil
+}
+
+// Decrypts the remote object to a temporary file that replaces localPath only when
+// complete, so that a wrong key or failed download never leaves partial plaintext.
+func decryptObject(ctx context.Context, api storage.StorageAPI, remotePath, localPath string, overwrite bool, codec crypt.Codec, fsys afero.Fs) error {
+	if !overwrite {
+		if exists, err := afero.Exists(fsys, localPath); err != nil {
+			return errors.Errorf("failed to check file: %w", err)
+		} else if exists {
+			return errors.Errorf("failed to create file: %w", os.ErrExist)
+		}
+	}
+	f, err := afero.TempFile(fsys, filepath.Dir(localPath), filepath.Base(localPath)+".*.tmp")
+	if err != nil {
+		return errors.Errorf("failed to create temp file: %w", err)
+	}
+	err = downloadObject(ctx, api, remotePath, f, codec)
+	if cerr := f.Close(); err == nil && cerr != nil {
+		err = errors.Errorf("failed to close temp file: %w", cerr)
+	}
+	if err == nil {
+		if err = fsys.Rename(f.Name(), localPath); err != nil {
+			err = errors.Errorf("failed to rename temp file: %w", err)
+		}
+	}
+	if err != nil {
+		_ = fsys.Remove(f.Name())
+	}
+	return err
 }
 
 func IsDir(objectPrefix string) bool {
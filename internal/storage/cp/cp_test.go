@@ -1,15 +1,19 @@
 package cp
 
 import (
+	"bytes"
 	"context"
 	"io/fs"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/h2non/gock"
 	"github.com/spf13/afero"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/storage/crypt"
 	"github.com/supabase/cli/internal/testing/apitest"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
@@ -63,7 +67,7 @@ func TestStorageCP(t *testing.T) {
 			Post("/storage/v1/object/private/file").
 			Reply(http.StatusOK)
 		// Run test
-		err := Run(context.Background(), "/tmp/file", "ss:///private/file", false, 1, fsys)
+		err := Run(context.Background(), "/tmp/file", "ss:///private/file", false, 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -86,7 +90,7 @@ func TestStorageCP(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON([]storage.BucketResponse{})
 		// Run test
-		err := Run(context.Background(), "abstract.pdf", "ss:///private", true, 1, fsys)
+		err := Run(context.Background(), "abstract.pdf", "ss:///private", true, 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.ErrorIs(t, err, fs.ErrNotExist)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -108,7 +112,7 @@ func TestStorageCP(t *testing.T) {
 			Get("/storage/v1/object/private/file").
 			Reply(http.StatusOK)
 		// Run test
-		err := Run(context.Background(), "ss:///private/file", "abstract.pdf", false, 1, fsys)
+		err := Run(context.Background(), "ss:///private/file", "abstract.pdf", false, 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -117,6 +121,37 @@ func TestStorageCP(t *testing.T) {
 		assert.True(t, exists)
 	})
 
+	t.Run("copy public object with anon key", func(t *testing.T) {
+		t.Cleanup(func() {
+			viper.Set("PROJECT_URL", "")
+			viper.Set("ANON_KEY", "")
+		})
+		viper.Set("PROJECT_URL", "https://"+utils.GetSupabaseHost(flags.ProjectRef))
+		viper.Set("ANON_KEY", "anon-key")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://"+utils.GetSupabaseHost(flags.ProjectRef)).
+			Get("/storage/v1/object/public/public/file").
+			MatchHeader("apikey", "anon-key").
+			Reply(http.StatusOK)
+		// Run test
+		err := Run(context.Background(), "ss:///public/file", "abstract.pdf", false, 1, TransferFilter{}, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on anonymous upload", func(t *testing.T) {
+		t.Cleanup(func() { viper.Set("PROJECT_URL", "") })
+		viper.Set("PROJECT_URL", "https://"+utils.GetSupabaseHost(flags.ProjectRef))
+		// Run test
+		err := Run(context.Background(), "abstract.pdf", "ss:///public/file", false, 1, TransferFilter{}, nil, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, errReadOnly)
+	})
+
 	t.Run("throws error on missing bucket", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
@@ -134,7 +169,7 @@ func TestStorageCP(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON([]storage.BucketResponse{})
 		// Run test
-		err := Run(context.Background(), "ss:///private", ".", true, 1, fsys)
+		err := Run(context.Background(), "ss:///private", ".", true, 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Object not found: /private")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -144,7 +179,7 @@ func TestStorageCP(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), ":", ".", false, 1, fsys)
+		err := Run(context.Background(), ":", ".", false, 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "missing protocol scheme")
 	})
@@ -153,7 +188,7 @@ func TestStorageCP(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), ".", ":", false, 1, fsys)
+		err := Run(context.Background(), ".", ":", false, 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "missing protocol scheme")
 	})
@@ -171,7 +206,7 @@ func TestStorageCP(t *testing.T) {
 				ApiKey: "service-key",
 			}})
 		// Run test
-		err := Run(context.Background(), ".", ".", false, 1, fsys)
+		err := Run(context.Background(), ".", ".", false, 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.ErrorIs(t, err, errUnsupportedOperation)
 	})
@@ -200,7 +235,7 @@ func TestUploadAll(t *testing.T) {
 			Post("/storage/v1/object/tmp/readme.md").
 			Reply(http.StatusOK)
 		// Run test
-		err := UploadStorageObjectAll(context.Background(), mockApi, "", "/tmp", 1, fsys)
+		err := UploadStorageObjectAll(context.Background(), mockApi, "", "/tmp", 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -224,7 +259,7 @@ func TestUploadAll(t *testing.T) {
 			Post("/storage/v1/bucket").
 			Reply(http.StatusServiceUnavailable)
 		// Run test
-		err := UploadStorageObjectAll(context.Background(), mockApi, "", "/tmp", 1, fsys)
+		err := UploadStorageObjectAll(context.Background(), mockApi, "", "/tmp", 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Error status 503:")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -250,7 +285,7 @@ func TestUploadAll(t *testing.T) {
 			Post("/storage/v1/object/private/dir/tmp/docs/api.md").
 			Reply(http.StatusOK)
 		// Run test
-		err := UploadStorageObjectAll(context.Background(), mockApi, "/private/dir/", "/tmp", 1, fsys)
+		err := UploadStorageObjectAll(context.Background(), mockApi, "/private/dir/", "/tmp", 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -275,7 +310,7 @@ func TestUploadAll(t *testing.T) {
 			Post("/storage/v1/object/private/readme.md").
 			Reply(http.StatusOK)
 		// Run test
-		err := UploadStorageObjectAll(context.Background(), mockApi, "private", "/tmp/readme.md", 1, fsys)
+		err := UploadStorageObjectAll(context.Background(), mockApi, "private", "/tmp/readme.md", 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -297,7 +332,7 @@ func TestUploadAll(t *testing.T) {
 			Post("/storage/v1/object/private/file").
 			Reply(http.StatusOK)
 		// Run test
-		err := UploadStorageObjectAll(context.Background(), mockApi, "private/file", "/tmp/readme.md", 1, fsys)
+		err := UploadStorageObjectAll(context.Background(), mockApi, "private/file", "/tmp/readme.md", 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -312,11 +347,24 @@ func TestUploadAll(t *testing.T) {
 			Get("/storage/v1/bucket").
 			Reply(http.StatusServiceUnavailable)
 		// Run test
-		err := UploadStorageObjectAll(context.Background(), mockApi, "missing", ".", 1, fsys)
+		err := UploadStorageObjectAll(context.Background(), mockApi, "missing", ".", 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Error status 503:")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
+
+	t.Run("stops uploading when interrupted", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/readme.md", []byte{}, 0644))
+		ctx, interrupt := utils.WithInterrupt(context.Background())
+		interrupt()
+		// Run test
+		err := UploadStorageObjectAll(ctx, mockApi, "", "/tmp", 1, TransferFilter{}, nil, fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrInterrupted)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
 }
 
 func TestDownloadAll(t *testing.T) {
@@ -349,7 +397,7 @@ func TestDownloadAll(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON([]storage.ObjectResponse{})
 		// Run test
-		err := DownloadStorageObjectAll(context.Background(), mockApi, "", "/", 1, fsys)
+		err := DownloadStorageObjectAll(context.Background(), mockApi, "", "/", 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -384,7 +432,7 @@ func TestDownloadAll(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON([]storage.ObjectResponse{})
 		// Run test
-		err := DownloadStorageObjectAll(context.Background(), mockApi, "/private", "/tmp", 1, fsys)
+		err := DownloadStorageObjectAll(context.Background(), mockApi, "/private", "/tmp", 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -406,7 +454,7 @@ func TestDownloadAll(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON([]storage.ObjectResponse{})
 		// Run test
-		err := DownloadStorageObjectAll(context.Background(), mockApi, "private/dir/", "/", 1, fsys)
+		err := DownloadStorageObjectAll(context.Background(), mockApi, "private/dir/", "/", 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Object not found: private/dir/")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -453,7 +501,7 @@ func TestDownloadAll(t *testing.T) {
 			Get("/storage/v1/object/private/tmp/docs/readme.md").
 			Reply(http.StatusOK)
 		// Run test
-		err := DownloadStorageObjectAll(context.Background(), mockApi, "private/tmp/", "/", 1, fsys)
+		err := DownloadStorageObjectAll(context.Background(), mockApi, "private/tmp/", "/", 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -478,7 +526,7 @@ func TestDownloadAll(t *testing.T) {
 			Get("/storage/v1/object/private/abstract.pdf").
 			Reply(http.StatusOK)
 		// Run test
-		err := DownloadStorageObjectAll(context.Background(), mockApi, "/private/abstract.pdf", "/tmp/file", 1, fsys)
+		err := DownloadStorageObjectAll(context.Background(), mockApi, "/private/abstract.pdf", "/tmp/file", 1, TransferFilter{}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -489,4 +537,119 @@ func TestDownloadAll(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, exists)
 	})
+
+	t.Run("decrypts downloaded object", func(t *testing.T) {
+		codec, err := crypt.NewAesCodec(make([]byte, 32))
+		require.NoError(t, err)
+		var ciphertext bytes.Buffer
+		w, err := codec.Encrypt(&ciphertext)
+		require.NoError(t, err)
+		_, err = w.Write([]byte("secret"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockFile})
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/abstract.pdf").
+			Reply(http.StatusOK).
+			Body(&ciphertext)
+		// Run test
+		err = DownloadStorageObjectAll(context.Background(), mockApi, "/private/abstract.pdf", "/tmp/file", 1, TransferFilter{}, codec, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		data, err := afero.ReadFile(fsys, "/tmp/file")
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", string(data))
+	})
+
+	t.Run("keeps existing file on decrypt failure", func(t *testing.T) {
+		codec, err := crypt.NewAesCodec(make([]byte, 32))
+		require.NoError(t, err)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/file", []byte("old"), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockFile})
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/abstract.pdf").
+			Reply(http.StatusOK).
+			BodyString("not encrypted")
+		// Run test
+		err = DownloadStorageObjectAll(context.Background(), mockApi, "/private/abstract.pdf", "/tmp/file", 1, TransferFilter{}, codec, fsys)
+		// Check error
+		assert.Error(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		data, err := afero.ReadFile(fsys, "/tmp/file")
+		assert.NoError(t, err)
+		assert.Equal(t, "old", string(data))
+		files, err := afero.ReadDir(fsys, "/tmp")
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+	})
+}
+
+func TestTransferFilter(t *testing.T) {
+	t.Run("skips unchanged files on upload", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/abstract.pdf", make([]byte, mockFile.Metadata.Size), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/readme.md", []byte("hello"), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			JSON(storage.ListObjectsQuery{Search: "dir", Limit: storage.PAGE_LIMIT}).
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{{
+				Name: "dir",
+			}})
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			JSON(storage.ListObjectsQuery{Prefix: "dir/", Limit: storage.PAGE_LIMIT}).
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{{
+				Name: "tmp",
+			}})
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			JSON(storage.ListObjectsQuery{Prefix: "dir/tmp/", Limit: storage.PAGE_LIMIT}).
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockFile})
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/private/dir/tmp/readme.md").
+			Reply(http.StatusOK)
+		// Run test
+		filter := TransferFilter{IfSizeDiffers: true}
+		err := UploadStorageObjectAll(context.Background(), mockApi, "/private/dir/", "/tmp", 1, filter, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("compares modified time", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/abstract.pdf", []byte{}, 0644))
+		updatedAt, err := mockFile.LastModified()
+		require.NoError(t, err)
+		require.NoError(t, fsys.Chtimes("/tmp/abstract.pdf", updatedAt, updatedAt.Add(-time.Hour)))
+		local, err := fsys.Stat("/tmp/abstract.pdf")
+		require.NoError(t, err)
+		// Check filter
+		filter := TransferFilter{IfNewer: true}
+		assert.False(t, filter.ShouldUpload(local, &mockFile))
+		assert.True(t, filter.ShouldDownload(&mockFile, local))
+		assert.True(t, filter.ShouldUpload(local, nil))
+	})
 }
@@ -0,0 +1,100 @@
+package cp
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/storage/find"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+// Skips transferring files that are unchanged at the destination. When multiple
+// conditions are enabled, a file is transferred if any of them is satisfied.
+type TransferFilter struct {
+	IfNewer       bool
+	IfSizeDiffers bool
+}
+
+func (f TransferFilter) IsEnabled() bool {
+	return f.IfNewer || f.IfSizeDiffers
+}
+
+// Returns true if the local file should be uploaded over the remote object.
+func (f TransferFilter) ShouldUpload(local fs.FileInfo, remote *storage.ObjectResponse) bool {
+	return f.shouldTransfer(local, remote, true)
+}
+
+// Returns true if the remote object should be downloaded over the local file.
+func (f TransferFilter) ShouldDownload(remote *storage.ObjectResponse, local fs.FileInfo) bool {
+	return f.shouldTransfer(local, remote, false)
+}
+
+func (f TransferFilter) shouldTransfer(local fs.FileInfo, remote *storage.ObjectResponse, upload bool) bool {
+	if !f.IsEnabled() || local == nil || remote == nil {
+		return true
+	}
+	if f.IfSizeDiffers && (remote.Metadata == nil || int64(remote.Metadata.Size) != local.Size()) {
+		return true
+	}
+	if f.IfNewer {
+		updatedAt, err := remote.LastModified()
+		if err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+			return true
+		}
+		if upload && local.ModTime().After(updatedAt) {
+			return true
+		}
+		if !upload && updatedAt.After(local.ModTime()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Lists remote objects under remotePath, keyed by bucket and object name.
+func listRemoteObjects(ctx context.Context, api storage.StorageAPI, remotePath string) (map[string]storage.ObjectResponse, error) {
+	result := map[string]storage.ObjectResponse{}
+	bucket, prefix := client.SplitBucketPrefix(remotePath)
+	if len(bucket) == 0 {
+		return result, nil
+	}
+	if len(prefix) > 0 && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	err := find.WalkObjects(ctx, api, bucket, prefix, func(objectName string, object storage.ObjectResponse) error {
+		result[bucket+"/"+objectName] = object
+		return nil
+	})
+	return result, err
+}
+
+// Returns the remote object at remotePath, or nil if it does not exist.
+func statRemoteObject(ctx context.Context, api storage.StorageAPI, remotePath string) (*storage.ObjectResponse, error) {
+	bucket, prefix := client.SplitBucketPrefix(remotePath)
+	if len(bucket) == 0 || IsDir(prefix) {
+		return nil, nil
+	}
+	objects, err := api.ListObjects(ctx, bucket, prefix, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range objects {
+		if o.Id != nil && o.Name == path.Base(prefix) {
+			return &o, nil
+		}
+	}
+	return nil, nil
+}
+
+func lookupObject(objects map[string]storage.ObjectResponse, objectPath string) *storage.ObjectResponse {
+	if o, ok := objects[strings.TrimPrefix(objectPath, "/")]; ok {
+		return &o
+	}
+	return nil
+}
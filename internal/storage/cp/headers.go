@@ -0,0 +1,79 @@
+package cp
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+// Headers set on uploaded objects unless overridden by --content-type or --cache-control.
+var Headers HeaderRules
+
+type HeaderRules struct {
+	// Maps file extensions, ie. .wasm, to content types
+	ContentTypes map[string]string `json:"content_types"`
+	// The first rule matching the object key sets its cache control
+	CacheControl []CacheRule `json:"cache_control"`
+}
+
+type CacheRule struct {
+	// Patterns without a slash match the file name, otherwise the object key within its bucket
+	Pattern string `json:"pattern"`
+	Value   string `json:"value"`
+}
+
+func LoadHeaderRules(rulesPath string, fsys afero.Fs) (HeaderRules, error) {
+	var rules HeaderRules
+	data, err := afero.ReadFile(fsys, rulesPath)
+	if err != nil {
+		return rules, errors.Errorf("failed to read headers file: %w", err)
+	}
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return rules, errors.Errorf("failed to parse headers file: %w", err)
+	}
+	normalized := make(map[string]string, len(rules.ContentTypes))
+	for ext, contentType := range rules.ContentTypes {
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		normalized[strings.ToLower(ext)] = contentType
+	}
+	rules.ContentTypes = normalized
+	for _, r := range rules.CacheControl {
+		if _, err := path.Match(r.Pattern, ""); err != nil {
+			return rules, errors.Errorf("invalid cache control pattern %q: %w", r.Pattern, err)
+		}
+	}
+	return rules, nil
+}
+
+// Returns a file option that fills in headers left empty by the user.
+func (h HeaderRules) options(remotePath string) func(*storage.FileOptions) {
+	_, key := client.SplitBucketPrefix(remotePath)
+	return func(fo *storage.FileOptions) {
+		if len(fo.ContentType) == 0 {
+			fo.ContentType = h.ContentTypes[strings.ToLower(path.Ext(key))]
+		}
+		if len(fo.CacheControl) == 0 {
+			fo.CacheControl = h.cacheControl(key)
+		}
+	}
+}
+
+func (h HeaderRules) cacheControl(key string) string {
+	for _, r := range h.CacheControl {
+		name := key
+		if !strings.Contains(r.Pattern, "/") {
+			name = path.Base(key)
+		}
+		if matched, _ := path.Match(r.Pattern, name); matched {
+			return r.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+package cp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func TestHeaderRules(t *testing.T) {
+	t.Run("loads rules from file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "headers.json", []byte(`{
+  "content_types": {"wasm": "application/wasm", ".MJS": "text/javascript"},
+  "cache_control": [{"pattern": "*.html", "value": "no-cache"}]
+}`), 0644))
+		// Run test
+		rules, err := LoadHeaderRules("headers.json", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			".wasm": "application/wasm",
+			".mjs":  "text/javascript",
+		}, rules.ContentTypes)
+	})
+
+	t.Run("throws error on invalid pattern", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "headers.json", []byte(`{"cache_control": [{"pattern": "[", "value": "no-cache"}]}`), 0644))
+		// Run test
+		_, err := LoadHeaderRules("headers.json", fsys)
+		// Check error
+		assert.ErrorContains(t, err, `invalid cache control pattern "["`)
+	})
+
+	t.Run("fills in unset headers", func(t *testing.T) {
+		rules := HeaderRules{
+			ContentTypes: map[string]string{".wasm": "application/wasm"},
+			CacheControl: []CacheRule{
+				{Pattern: "*.html", Value: "no-cache"},
+				{Pattern: "assets/*", Value: "max-age=31536000, immutable"},
+			},
+		}
+		// Run test
+		var fo storage.FileOptions
+		rules.options("/site/assets/app.wasm")(&fo)
+		// Check output
+		assert.Equal(t, storage.FileOptions{
+			ContentType:  "application/wasm",
+			CacheControl: "max-age=31536000, immutable",
+		}, fo)
+		// Nested html files match by name
+		fo = storage.FileOptions{ContentType: "text/html"}
+		rules.options("/site/docs/index.html")(&fo)
+		assert.Equal(t, "no-cache", fo.CacheControl)
+		// User provided headers are kept
+		fo = storage.FileOptions{ContentType: "application/octet-stream", CacheControl: "max-age=60"}
+		rules.options("/site/assets/app.wasm")(&fo)
+		assert.Equal(t, storage.FileOptions{ContentType: "application/octet-stream", CacheControl: "max-age=60"}, fo)
+	})
+
+	t.Run("uploads with rule headers", func(t *testing.T) {
+		Headers = HeaderRules{
+			ContentTypes: map[string]string{".wasm": "application/wasm"},
+			CacheControl: []CacheRule{{Pattern: "*.wasm", Value: "max-age=31536000, immutable"}},
+		}
+		t.Cleanup(func() { Headers = HeaderRules{} })
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/app.wasm", []byte{0, 'a', 's', 'm'}, 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/site/app.wasm").
+			MatchHeader("Content-Type", "application/wasm").
+			MatchHeader("Cache-Control", "max-age=31536000, immutable").
+			Reply(http.StatusOK)
+		// Run test
+		err := uploadObject(context.Background(), mockApi, "/site/app.wasm", "/tmp/app.wasm", nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("detects content type from extension", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/style.css", []byte("body {}"), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/site/style.css").
+			MatchHeader("Content-Type", "text/css").
+			MatchHeader("Cache-Control", "max-age=3600").
+			Reply(http.StatusOK)
+		// Run test
+		err := uploadObject(context.Background(), mockApi, "/site/style.css", "/tmp/style.css", nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,74 @@
+package cp
+
+import (
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Describes how local paths of the host differ from object keys, which are always
+// separated by forward slashes and case sensitive.
+type pathStyle struct {
+	separator byte
+	// Paths may start with a drive letter, ie. C:\data
+	volumes bool
+	// Objects differing only by case map to the same local file
+	caseInsensitive bool
+}
+
+var hostPath = pathStyle{
+	separator:       filepath.Separator,
+	volumes:         runtime.GOOS == "windows",
+	caseInsensitive: runtime.GOOS == "windows" || runtime.GOOS == "darwin",
+}
+
+// Converts a path relative to the upload directory to an object key.
+func (s pathStyle) toKey(relPath string) string {
+	if s.separator == '/' {
+		return relPath
+	}
+	return strings.ReplaceAll(relPath, string(s.separator), "/")
+}
+
+func (s pathStyle) hasVolume(localPath string) bool {
+	if !s.volumes || len(localPath) < 2 {
+		return false
+	}
+	c := localPath[0]
+	isLetter := ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+	return isLetter && localPath[1] == ':'
+}
+
+// Parses a cp argument, keeping Windows paths local because url.Parse mistakes their
+// drive letter for a scheme.
+func (s pathStyle) parse(arg string) (*url.URL, error) {
+	if s.hasVolume(arg) {
+		return &url.URL{Path: arg}, nil
+	}
+	return url.Parse(arg)
+}
+
+// Tracks local paths already written to, so that objects differing only by case do not
+// silently overwrite each other on case insensitive filesystems.
+type pathSet struct {
+	style pathStyle
+	seen  map[string]string
+}
+
+func newPathSet(style pathStyle) pathSet {
+	return pathSet{style: style, seen: map[string]string{}}
+}
+
+// Returns the object previously mapped to localPath, if any.
+func (p pathSet) add(localPath, objectPath string) (string, bool) {
+	key := localPath
+	if p.style.caseInsensitive {
+		key = strings.ToLower(key)
+	}
+	if prev, ok := p.seen[key]; ok {
+		return prev, true
+	}
+	p.seen[key] = objectPath
+	return "", false
+}
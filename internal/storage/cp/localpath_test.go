@@ -0,0 +1,129 @@
+package cp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var windowsPath = pathStyle{separator: '\\', volumes: true, caseInsensitive: true}
+
+// Emulates paths walked on Windows, where nested files are joined by backslashes.
+func useWindowsPath(t *testing.T) {
+	original := hostPath
+	hostPath = windowsPath
+	t.Cleanup(func() { hostPath = original })
+}
+
+func TestPathStyle(t *testing.T) {
+	t.Run("converts separators to object keys", func(t *testing.T) {
+		assert.Equal(t, "docs/api/v1.md", windowsPath.toKey(`docs\api\v1.md`))
+		assert.Equal(t, `docs\api.md`, pathStyle{separator: '/'}.toKey(`docs\api.md`))
+	})
+
+	t.Run("parses drive letters as local paths", func(t *testing.T) {
+		parsed, err := windowsPath.parse(`C:\Users\data`)
+		assert.NoError(t, err)
+		assert.Empty(t, parsed.Scheme)
+		assert.Equal(t, `C:\Users\data`, parsed.Path)
+	})
+
+	t.Run("parses storage urls", func(t *testing.T) {
+		parsed, err := windowsPath.parse("ss:///private/Docs")
+		assert.NoError(t, err)
+		assert.Equal(t, "ss", parsed.Scheme)
+		assert.Equal(t, "/private/Docs", parsed.Path)
+	})
+
+	t.Run("detects case conflicts", func(t *testing.T) {
+		written := newPathSet(windowsPath)
+		_, ok := written.add(`C:\tmp\Readme.md`, "private/tmp/Readme.md")
+		assert.False(t, ok)
+		prev, ok := written.add(`C:\tmp\README.md`, "private/tmp/README.md")
+		assert.True(t, ok)
+		assert.Equal(t, "private/tmp/Readme.md", prev)
+	})
+}
+
+func TestWindowsTransfer(t *testing.T) {
+	t.Run("uploads nested files with slash separated keys", func(t *testing.T) {
+		useWindowsPath(t)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, `/tmp/Docs\API.md`, []byte{}, 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{})
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/private/dir/Docs/API.md").
+			Reply(http.StatusOK)
+		// Run test
+		err := UploadStorageObjectAll(context.Background(), mockApi, "/private/dir", "/tmp", 1, TransferFilter{}, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("uploads file beyond max path", func(t *testing.T) {
+		useWindowsPath(t)
+		name := strings.Repeat("a", 300) + ".txt"
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/"+name, []byte{}, 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{})
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/private/dir/" + name).
+			Reply(http.StatusOK)
+		// Run test
+		err := UploadStorageObjectAll(context.Background(), mockApi, "/private/dir", "/tmp", 1, TransferFilter{}, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips objects differing only by case", func(t *testing.T) {
+		useWindowsPath(t)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		upper := mockFile
+		upper.Name = "README.md"
+		lower := mockFile
+		lower.Name = "readme.md"
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{upper, lower})
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/private/tmp/README.md").
+			Reply(http.StatusOK)
+		// Run test
+		err := DownloadStorageObjectAll(context.Background(), mockApi, "private/tmp/", "/", 1, TransferFilter{}, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		exists, err := afero.Exists(fsys, "/tmp/README.md")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		exists, err = afero.Exists(fsys, "/tmp/readme.md")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
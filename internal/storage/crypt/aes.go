@@ -0,0 +1,152 @@
+package crypt
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"github.com/go-errors/errors"
+)
+
+// Encrypted files start with a magic header followed by a random nonce prefix. The plaintext is
+// sealed in fixed size chunks, each using the prefix, a chunk counter, and a final chunk flag as
+// nonce so that reordered or truncated ciphertext fails authentication.
+const (
+	keySize    = 32
+	chunkSize  = 64 * 1024
+	prefixSize = 7
+	magic      = "SBAESGCM1"
+)
+
+var errDecrypt = errors.New("failed to decrypt: message authentication failed")
+
+type aesCodec struct {
+	aead cipher.AEAD
+}
+
+func NewAesCodec(key []byte) (Codec, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Errorf("failed to create cipher: %w", err)
+	}
+	return &aesCodec{aead: aead}, nil
+}
+
+func (c *aesCodec) Encrypt(dst io.Writer) (io.WriteCloser, error) {
+	w := &aesWriter{aead: c.aead, dst: dst}
+	if _, err := rand.Read(w.prefix[:]); err != nil {
+		return nil, errors.Errorf("failed to generate nonce: %w", err)
+	}
+	if _, err := io.WriteString(dst, magic); err != nil {
+		return nil, errors.Errorf("failed to write header: %w", err)
+	}
+	if _, err := dst.Write(w.prefix[:]); err != nil {
+		return nil, errors.Errorf("failed to write header: %w", err)
+	}
+	return w, nil
+}
+
+func (c *aesCodec) Decrypt(src io.Reader) (io.Reader, error) {
+	r := &aesReader{aead: c.aead, src: bufio.NewReader(src)}
+	header := make([]byte, len(magic)+prefixSize)
+	if _, err := io.ReadFull(r.src, header); err != nil {
+		return nil, errors.Errorf("failed to read header: %w", err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, errors.New("failed to decrypt: file is not encrypted with aes")
+	}
+	copy(r.prefix[:], header[len(magic):])
+	return r, nil
+}
+
+func nonce(prefix [prefixSize]byte, counter uint32, last bool) []byte {
+	result := make([]byte, prefixSize+5)
+	copy(result, prefix[:])
+	binary.BigEndian.PutUint32(result[prefixSize:], counter)
+	if last {
+		result[len(result)-1] = 1
+	}
+	return result
+}
+
+type aesWriter struct {
+	aead    cipher.AEAD
+	dst     io.Writer
+	prefix  [prefixSize]byte
+	counter uint32
+	buf     bytes.Buffer
+}
+
+func (w *aesWriter) Write(p []byte) (int, error) {
+	n, _ := w.buf.Write(p)
+	// Hold back a full chunk since only Close knows which chunk is final
+	for w.buf.Len() > chunkSize {
+		if err := w.seal(w.buf.Next(chunkSize), false); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (w *aesWriter) Close() error {
+	return w.seal(w.buf.Next(w.buf.Len()), true)
+}
+
+func (w *aesWriter) seal(chunk []byte, last bool) error {
+	out := w.aead.Seal(nil, nonce(w.prefix, w.counter, last), chunk, nil)
+	w.counter++
+	if _, err := w.dst.Write(out); err != nil {
+		return errors.Errorf("failed to write chunk: %w", err)
+	}
+	return nil
+}
+
+type aesReader struct {
+	aead    cipher.AEAD
+	src     *bufio.Reader
+	prefix  [prefixSize]byte
+	counter uint32
+	plain   []byte
+	done    bool
+}
+
+func (r *aesReader) Read(p []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.plain)
+	r.plain = r.plain[n:]
+	return n, nil
+}
+
+func (r *aesReader) open() error {
+	chunk := make([]byte, chunkSize+r.aead.Overhead())
+	n, err := io.ReadFull(r.src, chunk)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		r.done = true
+	} else if err != nil {
+		return errors.Errorf("failed to read chunk: %w", err)
+	} else if _, err := r.src.Peek(1); errors.Is(err, io.EOF) {
+		r.done = true
+	}
+	plain, err := r.aead.Open(nil, nonce(r.prefix, r.counter, r.done), chunk[:n], nil)
+	if err != nil {
+		return errors.New(errDecrypt)
+	}
+	r.counter++
+	r.plain = plain
+	return nil
+}
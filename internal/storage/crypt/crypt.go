@@ -0,0 +1,116 @@
+package crypt
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	SchemeAge = "age"
+	SchemeAes = "aes"
+)
+
+// Encrypts objects before upload and decrypts them after download.
+type Codec interface {
+	// Returns a writer that encrypts to dst. Close must be called to flush the final block.
+	Encrypt(dst io.Writer) (io.WriteCloser, error)
+	// Returns a reader that decrypts from src.
+	Decrypt(src io.Reader) (io.Reader, error)
+}
+
+// Parses an encryption key of the form age:<recipient> or aes:<keyfile>.
+func ParseEncrypt(value string, fsys afero.Fs) (Codec, error) {
+	scheme, arg, err := splitScheme(value)
+	if err != nil {
+		return nil, err
+	}
+	if scheme == SchemeAes {
+		return loadAesCodec(arg, fsys)
+	}
+	recipient, err := age.ParseX25519Recipient(arg)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse age recipient: %w", err)
+	}
+	return &ageCodec{recipients: []age.Recipient{recipient}}, nil
+}
+
+// Parses a decryption key of the form age:<identity file> or aes:<keyfile>.
+func ParseDecrypt(value string, fsys afero.Fs) (Codec, error) {
+	scheme, arg, err := splitScheme(value)
+	if err != nil {
+		return nil, err
+	}
+	if scheme == SchemeAes {
+		return loadAesCodec(arg, fsys)
+	}
+	f, err := fsys.Open(arg)
+	if err != nil {
+		return nil, errors.Errorf("failed to open age identity file: %w", err)
+	}
+	defer f.Close()
+	identities, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse age identity file: %w", err)
+	}
+	return &ageCodec{identities: identities}, nil
+}
+
+func splitScheme(value string) (string, string, error) {
+	scheme, arg, ok := strings.Cut(value, ":")
+	if !ok || len(arg) == 0 || (scheme != SchemeAge && scheme != SchemeAes) {
+		return "", "", errors.Errorf("Invalid key %q: must be of the form age:<key> or aes:<keyfile>", value)
+	}
+	return scheme, arg, nil
+}
+
+type ageCodec struct {
+	recipients []age.Recipient
+	identities []age.Identity
+}
+
+func (c *ageCodec) Encrypt(dst io.Writer) (io.WriteCloser, error) {
+	if len(c.recipients) == 0 {
+		return nil, errors.New("missing age recipient")
+	}
+	w, err := age.Encrypt(dst, c.recipients...)
+	if err != nil {
+		return nil, errors.Errorf("failed to encrypt: %w", err)
+	}
+	return w, nil
+}
+
+func (c *ageCodec) Decrypt(src io.Reader) (io.Reader, error) {
+	if len(c.identities) == 0 {
+		return nil, errors.New("missing age identity")
+	}
+	r, err := age.Decrypt(src, c.identities...)
+	if err != nil {
+		return nil, errors.Errorf("failed to decrypt: %w", err)
+	}
+	return r, nil
+}
+
+// Reads a 256-bit key stored as raw bytes, hex, or base64.
+func loadAesCodec(keyfile string, fsys afero.Fs) (Codec, error) {
+	data, err := afero.ReadFile(fsys, keyfile)
+	if err != nil {
+		return nil, errors.Errorf("failed to read keyfile: %w", err)
+	}
+	if len(data) == keySize {
+		return NewAesCodec(data)
+	}
+	text := strings.TrimSpace(string(data))
+	if key, err := hex.DecodeString(text); err == nil && len(key) == keySize {
+		return NewAesCodec(key)
+	}
+	if key, err := base64.StdEncoding.DecodeString(text); err == nil && len(key) == keySize {
+		return NewAesCodec(key)
+	}
+	return nil, errors.Errorf("Invalid keyfile %s: must contain a %d byte key as raw bytes, hex, or base64", keyfile, keySize)
+}
@@ -0,0 +1,132 @@
+package crypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encrypt(t *testing.T, codec Codec, plain []byte) []byte {
+	var buf bytes.Buffer
+	w, err := codec.Encrypt(&buf)
+	require.NoError(t, err)
+	_, err = w.Write(plain)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func decrypt(codec Codec, data []byte) ([]byte, error) {
+	r, err := codec.Decrypt(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestAesCodec(t *testing.T) {
+	key := make([]byte, keySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	codec, err := NewAesCodec(key)
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 10, chunkSize, 2*chunkSize + 7} {
+		plain := make([]byte, size)
+		_, err := rand.Read(plain)
+		require.NoError(t, err)
+		// Run test
+		data := encrypt(t, codec, plain)
+		out, err := decrypt(codec, data)
+		// Check output
+		assert.NoError(t, err)
+		assert.Equal(t, plain, out)
+	}
+
+	t.Run("throws error on tampered ciphertext", func(t *testing.T) {
+		data := encrypt(t, codec, []byte("secret"))
+		data[len(data)-1] ^= 1
+		_, err := decrypt(codec, data)
+		assert.ErrorIs(t, err, errDecrypt)
+	})
+
+	t.Run("throws error on truncated ciphertext", func(t *testing.T) {
+		data := encrypt(t, codec, make([]byte, 2*chunkSize))
+		_, err := decrypt(codec, data[:len(magic)+prefixSize+chunkSize+codec.(*aesCodec).aead.Overhead()])
+		assert.ErrorIs(t, err, errDecrypt)
+	})
+
+	t.Run("throws error on wrong key", func(t *testing.T) {
+		other, err := NewAesCodec(make([]byte, keySize))
+		require.NoError(t, err)
+		_, err = decrypt(other, encrypt(t, codec, []byte("secret")))
+		assert.ErrorIs(t, err, errDecrypt)
+	})
+
+	t.Run("throws error on missing header", func(t *testing.T) {
+		_, err := decrypt(codec, []byte("plaintext file without any header"))
+		assert.ErrorContains(t, err, "file is not encrypted with aes")
+	})
+}
+
+func TestParseCodec(t *testing.T) {
+	t.Run("parses hex keyfile", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		key := hex.EncodeToString(make([]byte, keySize))
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/key", []byte(key+"\n"), 0600))
+		// Run test
+		enc, err := ParseEncrypt("aes:/tmp/key", fsys)
+		require.NoError(t, err)
+		dec, err := ParseDecrypt("aes:/tmp/key", fsys)
+		require.NoError(t, err)
+		// Check output
+		out, err := decrypt(dec, encrypt(t, enc, []byte("secret")))
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", string(out))
+	})
+
+	t.Run("parses age recipient and identity", func(t *testing.T) {
+		identity, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/key.txt", []byte(identity.String()+"\n"), 0600))
+		// Run test
+		enc, err := ParseEncrypt("age:"+identity.Recipient().String(), fsys)
+		require.NoError(t, err)
+		dec, err := ParseDecrypt("age:/tmp/key.txt", fsys)
+		require.NoError(t, err)
+		// Check output
+		out, err := decrypt(dec, encrypt(t, enc, []byte("secret")))
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", string(out))
+	})
+
+	t.Run("throws error on unknown scheme", func(t *testing.T) {
+		_, err := ParseEncrypt("gpg:key", afero.NewMemMapFs())
+		assert.ErrorContains(t, err, `Invalid key "gpg:key"`)
+	})
+
+	t.Run("throws error on invalid keyfile", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/key", []byte("short"), 0600))
+		// Run test
+		_, err := ParseDecrypt("aes:/tmp/key", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid keyfile /tmp/key")
+	})
+
+	t.Run("throws error on invalid recipient", func(t *testing.T) {
+		_, err := ParseEncrypt("age:invalid", afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "failed to parse age recipient")
+	})
+}
@@ -0,0 +1,183 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/storage/cache"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/storage/find"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+const (
+	StatusOnlyInSource = "only_in_source"
+	StatusOnlyInTarget = "only_in_target"
+	StatusChanged      = "changed"
+)
+
+var (
+	errMissingBucket = errors.New("You must specify a bucket to compare.")
+	errSamePath      = errors.New("Source and target must differ by path or project.")
+)
+
+type Entry struct {
+	Status     string `json:"status"`
+	Name       string `json:"name"`
+	SourceSize *int   `json:"source_size,omitempty"`
+	TargetSize *int   `json:"target_size,omitempty"`
+}
+
+// Compares objects under src in the current project against dst in the target
+// project. An empty targetRef compares both paths within the current project.
+// Listings are only read from and written to the local cache when useCache is set.
+func Run(ctx context.Context, src, dst, targetRef string, useCache bool, fsys afero.Fs) error {
+	if len(dst) == 0 {
+		dst = src
+	}
+	if dst == src && (len(targetRef) == 0 || targetRef == flags.ProjectRef) {
+		return errors.New(errSamePath)
+	}
+	srcBucket, srcPrefix, err := parseBucketPrefix(src)
+	if err != nil {
+		return err
+	}
+	dstBucket, dstPrefix, err := parseBucketPrefix(dst)
+	if err != nil {
+		return err
+	}
+	srcApi, err := client.NewStorageAPI(ctx, flags.ProjectRef)
+	if err != nil {
+		return err
+	}
+	dstApi := srcApi
+	if len(targetRef) == 0 {
+		targetRef = flags.ProjectRef
+	} else if targetRef != flags.ProjectRef {
+		if dstApi, err = client.NewStorageAPI(ctx, targetRef); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Listing source objects:", src)
+	srcObjects, err := listObjects(ctx, srcApi, flags.ProjectRef, srcBucket, srcPrefix, useCache, fsys)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Listing target objects:", dst)
+	dstObjects, err := listObjects(ctx, dstApi, targetRef, dstBucket, dstPrefix, useCache, fsys)
+	if err != nil {
+		return err
+	}
+	result := Compare(srcObjects, dstObjects)
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeRows(utils.OutputFormat.Value, os.Stdout, result)
+	}
+	if len(result) == 0 {
+		fmt.Fprintln(os.Stderr, "No differences found.")
+		return nil
+	}
+	return list.RenderTable(toMarkdown(result))
+}
+
+func parseBucketPrefix(objectPath string) (string, string, error) {
+	remotePath, err := client.ParseStorageURL(objectPath)
+	if err != nil {
+		return "", "", err
+	}
+	bucket, prefix := client.SplitBucketPrefix(remotePath)
+	if len(bucket) == 0 {
+		return "", "", errors.New(errMissingBucket)
+	}
+	if len(prefix) > 0 && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return bucket, prefix, nil
+}
+
+// Lists objects under prefix, keyed by their name relative to prefix.
+func listObjects(ctx context.Context, api storage.StorageAPI, ref, bucket, prefix string, useCache bool, fsys afero.Fs) (map[string]storage.ObjectResponse, error) {
+	if useCache {
+		if result, ok := cache.Get(ref, bucket, prefix, fsys); ok {
+			fmt.Fprintf(os.Stderr, "Using cached listing of %d objects. Omit %s to refresh.\n", len(result), utils.Aqua("--cache"))
+			return result, nil
+		}
+	}
+	result := map[string]storage.ObjectResponse{}
+	err := find.WalkObjects(ctx, api, bucket, prefix, func(objectName string, object storage.ObjectResponse) error {
+		result[strings.TrimPrefix(objectName, prefix)] = object
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !useCache {
+		return result, nil
+	}
+	if err := cache.Put(ref, bucket, prefix, result, fsys); err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+	}
+	return result, nil
+}
+
+// Reports objects missing from either side, or differing by size or etag.
+func Compare(source, target map[string]storage.ObjectResponse) []Entry {
+	var result []Entry
+	for name, s := range source {
+		t, ok := target[name]
+		if !ok {
+			result = append(result, Entry{Status: StatusOnlyInSource, Name: name, SourceSize: sizeOf(s)})
+		} else if isChanged(s, t) {
+			result = append(result, Entry{Status: StatusChanged, Name: name, SourceSize: sizeOf(s), TargetSize: sizeOf(t)})
+		}
+	}
+	for name, t := range target {
+		if _, ok := source[name]; !ok {
+			result = append(result, Entry{Status: StatusOnlyInTarget, Name: name, TargetSize: sizeOf(t)})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func isChanged(source, target storage.ObjectResponse) bool {
+	if source.Metadata == nil || target.Metadata == nil {
+		return source.Metadata != target.Metadata
+	}
+	if source.Metadata.Size != target.Metadata.Size {
+		return true
+	}
+	return len(source.Metadata.ETag) > 0 && len(target.Metadata.ETag) > 0 &&
+		source.Metadata.ETag != target.Metadata.ETag
+}
+
+func sizeOf(object storage.ObjectResponse) *int {
+	if object.Metadata == nil {
+		return nil
+	}
+	return &object.Metadata.Size
+}
+
+func toMarkdown(result []Entry) string {
+	table := "|STATUS|NAME|SOURCE SIZE|TARGET SIZE|\n|-|-|-|-|\n"
+	for _, r := range result {
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%s`|\n", r.Status, r.Name, formatSize(r.SourceSize), formatSize(r.TargetSize))
+	}
+	return table
+}
+
+func formatSize(size *int) string {
+	if size == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", *size)
+}
@@ -0,0 +1,129 @@
+package diff
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/storage/cache"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func mockObject(name string, size int, etag string) storage.ObjectResponse {
+	return storage.ObjectResponse{
+		Name:     name,
+		Id:       cast.Ptr("9b7f9f48-17a6-4ca8-b14a-39b0205a63e9"),
+		Metadata: &storage.ObjectMetadata{Size: size, ETag: etag},
+	}
+}
+
+func TestStorageDiff(t *testing.T) {
+	flags.ProjectRef = apitest.RandomProjectRef()
+	targetRef := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("compares objects across projects", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		for _, ref := range []string{flags.ProjectRef, targetRef} {
+			gock.New(utils.DefaultApiHost).
+				Get("/v1/projects/" + ref + "/api-keys").
+				Reply(http.StatusOK).
+				JSON([]api.ApiKeyResponse{{
+					Name:   "service_role",
+					ApiKey: "service-key",
+				}})
+		}
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockObject("a.png", 1, `"a"`)})
+		gock.New("https://" + utils.GetSupabaseHost(targetRef)).
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockObject("b.png", 1, `"b"`)})
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), "ss:///private/images", "", targetRef, false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		// Listings are not cached unless opted in
+		exists, err := afero.DirExists(fsys, cache.CacheDir)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("uses cached listings", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		objects := map[string]storage.ObjectResponse{"a.png": mockObject("a.png", 1, `"a"`)}
+		require.NoError(t, cache.Put(flags.ProjectRef, "private", "images/", objects, fsys))
+		require.NoError(t, cache.Put(targetRef, "private", "images/", objects, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		for _, ref := range []string{flags.ProjectRef, targetRef} {
+			gock.New(utils.DefaultApiHost).
+				Get("/v1/projects/" + ref + "/api-keys").
+				Reply(http.StatusOK).
+				JSON([]api.ApiKeyResponse{{
+					Name:   "service_role",
+					ApiKey: "service-key",
+				}})
+		}
+		// Run test
+		err := Run(context.Background(), "ss:///private/images", "", targetRef, true, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on same path and project", func(t *testing.T) {
+		err := Run(context.Background(), "ss:///private", "", "", false, afero.NewMemMapFs())
+		assert.ErrorIs(t, err, errSamePath)
+	})
+
+	t.Run("throws error on missing bucket", func(t *testing.T) {
+		err := Run(context.Background(), "ss:///", "", targetRef, false, afero.NewMemMapFs())
+		assert.ErrorIs(t, err, errMissingBucket)
+	})
+
+	t.Run("throws error on invalid URL", func(t *testing.T) {
+		err := Run(context.Background(), "/private", "", targetRef, false, afero.NewMemMapFs())
+		assert.ErrorIs(t, err, client.ErrInvalidURL)
+	})
+}
+
+func TestCompareObjects(t *testing.T) {
+	source := map[string]storage.ObjectResponse{
+		"same.png":    mockObject("same.png", 10, `"x"`),
+		"resized.png": mockObject("resized.png", 10, `"x"`),
+		"edited.png":  mockObject("edited.png", 10, `"x"`),
+		"new.png":     mockObject("new.png", 10, `"x"`),
+	}
+	target := map[string]storage.ObjectResponse{
+		"same.png":    mockObject("same.png", 10, `"x"`),
+		"resized.png": mockObject("resized.png", 20, `"x"`),
+		"edited.png":  mockObject("edited.png", 10, `"y"`),
+		"old.png":     mockObject("old.png", 10, `"x"`),
+	}
+	result := Compare(source, target)
+	assert.Equal(t, []Entry{
+		{Status: StatusChanged, Name: "edited.png", SourceSize: cast.Ptr(10), TargetSize: cast.Ptr(10)},
+		{Status: StatusOnlyInSource, Name: "new.png", SourceSize: cast.Ptr(10)},
+		{Status: StatusOnlyInTarget, Name: "old.png", TargetSize: cast.Ptr(10)},
+		{Status: StatusChanged, Name: "resized.png", SourceSize: cast.Ptr(10), TargetSize: cast.Ptr(20)},
+	}, result)
+}
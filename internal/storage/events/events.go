@@ -0,0 +1,162 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/realtime"
+	"github.com/supabase/cli/internal/realtime/listen"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+const (
+	EventCreated = "created"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+)
+
+var Types = []string{EventCreated, EventUpdated, EventDeleted}
+
+// Maps postgres change types on storage.objects to object events
+var changeTypes = map[string]string{
+	"INSERT": EventCreated,
+	"UPDATE": EventUpdated,
+	"DELETE": EventDeleted,
+}
+
+type Event struct {
+	Type      string `json:"type"`
+	Bucket    string `json:"bucket"`
+	Name      string `json:"name"`
+	Id        string `json:"id"`
+	Size      int64  `json:"size,omitempty"`
+	Mimetype  string `json:"mimetype,omitempty"`
+	Timestamp string `json:"timestamp"`
+}
+
+type Filter struct {
+	Bucket string
+	Prefix string
+	Types  []string
+}
+
+// Match returns true if the event is of the selected types and under the bucket prefix.
+func (f Filter) Match(e Event) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, e.Type) {
+		return false
+	}
+	if len(f.Bucket) > 0 && e.Bucket != f.Bucket {
+		return false
+	}
+	return strings.HasPrefix(e.Name, f.Prefix)
+}
+
+func Run(ctx context.Context, objectPath string, types []string) error {
+	if client.IsAnonymous() {
+		return errors.New("Listening to storage events requires the service role key of a linked or local project.")
+	}
+	for _, t := range types {
+		if !slices.Contains(Types, t) {
+			return errors.Errorf("invalid event type %q: must be one of %s", t, strings.Join(Types, ", "))
+		}
+	}
+	remotePath, err := client.ParseStorageURL(objectPath)
+	if err != nil {
+		return err
+	}
+	bucket, prefix := client.SplitBucketPrefix(remotePath)
+	filter := Filter{Bucket: bucket, Prefix: prefix, Types: types}
+	endpoint, err := realtime.GetEndpoint(ctx, flags.ProjectRef)
+	if err != nil {
+		return err
+	}
+	// Service role bypasses RLS policies on storage.objects
+	opts := listen.ListenOptions{Table: "storage.objects", AccessToken: endpoint.ServiceRoleKey}
+	if len(bucket) > 0 {
+		opts.Filter = "bucket_id=eq." + bucket
+	}
+	return listen.Subscribe(ctx, endpoint.WebsocketUrl(), "storage-events", opts, func(msg realtime.Message) error {
+		return handleMessage(msg, filter, os.Stdout)
+	})
+}
+
+func handleMessage(msg realtime.Message, filter Filter, w io.Writer) error {
+	if msg.Event != "postgres_changes" {
+		return nil
+	}
+	event, err := ParseChange(msg.Payload)
+	if err != nil {
+		return err
+	}
+	if !filter.Match(event) {
+		return nil
+	}
+	return printEvent(event, w)
+}
+
+type objectRecord struct {
+	Id       string `json:"id"`
+	BucketId string `json:"bucket_id"`
+	Name     string `json:"name"`
+	Metadata struct {
+		Size     int64  `json:"size"`
+		Mimetype string `json:"mimetype"`
+	} `json:"metadata"`
+}
+
+type changePayload struct {
+	Data struct {
+		Type            string       `json:"type"`
+		CommitTimestamp string       `json:"commit_timestamp"`
+		Record          objectRecord `json:"record"`
+		OldRecord       objectRecord `json:"old_record"`
+	} `json:"data"`
+}
+
+// ParseChange converts a postgres change on storage.objects to an object event.
+func ParseChange(payload json.RawMessage) (Event, error) {
+	var change changePayload
+	if err := json.Unmarshal(payload, &change); err != nil {
+		return Event{}, errors.Errorf("failed to parse postgres change: %w", err)
+	}
+	record := change.Data.Record
+	// Deleted rows only include columns of the replica identity, which is the primary key by default
+	if change.Data.Type == "DELETE" {
+		record = change.Data.OldRecord
+	}
+	return Event{
+		Type:      changeTypes[change.Data.Type],
+		Bucket:    record.BucketId,
+		Name:      record.Name,
+		Id:        record.Id,
+		Size:      record.Metadata.Size,
+		Mimetype:  record.Metadata.Mimetype,
+		Timestamp: change.Data.CommitTimestamp,
+	}, nil
+}
+
+func printEvent(e Event, w io.Writer) error {
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, w, e)
+	}
+	timestamp := time.Now().Format(time.TimeOnly)
+	path := e.Id
+	if len(e.Name) > 0 {
+		path = client.STORAGE_SCHEME + ":///" + e.Bucket + "/" + e.Name
+	}
+	line := fmt.Sprintf("[%s] %-7s %s", timestamp, e.Type, path)
+	if e.Size > 0 {
+		line += fmt.Sprintf(" (%d bytes)", e.Size)
+	}
+	fmt.Fprintln(w, line)
+	return nil
+}
@@ -0,0 +1,106 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/realtime"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const insertPayload = `{"ids":[1],"data":{
+	"type":"INSERT","schema":"storage","table":"objects","commit_timestamp":"2024-01-01T00:00:00Z",
+	"record":{"id":"obj-1","bucket_id":"images","name":"uploads/cat.png","metadata":{"size":42,"mimetype":"image/png"}},
+	"old_record":null
+}}`
+
+func TestParseChange(t *testing.T) {
+	t.Run("parses created object", func(t *testing.T) {
+		event, err := ParseChange(json.RawMessage(insertPayload))
+		assert.NoError(t, err)
+		assert.Equal(t, Event{
+			Type:      EventCreated,
+			Bucket:    "images",
+			Name:      "uploads/cat.png",
+			Id:        "obj-1",
+			Size:      42,
+			Mimetype:  "image/png",
+			Timestamp: "2024-01-01T00:00:00Z",
+		}, event)
+	})
+
+	t.Run("parses deleted object from old record", func(t *testing.T) {
+		payload := `{"data":{"type":"DELETE","commit_timestamp":"2024-01-01T00:00:00Z","record":null,"old_record":{"id":"obj-1"}}}`
+		event, err := ParseChange(json.RawMessage(payload))
+		assert.NoError(t, err)
+		assert.Equal(t, EventDeleted, event.Type)
+		assert.Equal(t, "obj-1", event.Id)
+		assert.Empty(t, event.Name)
+	})
+
+	t.Run("throws error on malformed payload", func(t *testing.T) {
+		_, err := ParseChange(json.RawMessage(`[]`))
+		assert.ErrorContains(t, err, "failed to parse postgres change:")
+	})
+}
+
+func TestFilter(t *testing.T) {
+	event := Event{Type: EventCreated, Bucket: "images", Name: "uploads/cat.png"}
+
+	t.Run("matches all events by default", func(t *testing.T) {
+		assert.True(t, Filter{}.Match(event))
+	})
+
+	t.Run("matches bucket prefix", func(t *testing.T) {
+		assert.True(t, Filter{Bucket: "images", Prefix: "uploads/"}.Match(event))
+		assert.False(t, Filter{Bucket: "images", Prefix: "thumbs/"}.Match(event))
+		assert.False(t, Filter{Bucket: "videos"}.Match(event))
+	})
+
+	t.Run("matches event types", func(t *testing.T) {
+		assert.True(t, Filter{Types: []string{EventCreated}}.Match(event))
+		assert.False(t, Filter{Types: []string{EventUpdated, EventDeleted}}.Match(event))
+	})
+}
+
+func TestHandleMessage(t *testing.T) {
+	t.Run("prints matching events as json", func(t *testing.T) {
+		utils.OutputFormat.Value = utils.OutputJson
+		t.Cleanup(func() { utils.OutputFormat.Value = utils.OutputPretty })
+		msg := realtime.Message{Event: "postgres_changes", Payload: json.RawMessage(insertPayload)}
+		// Run test
+		var out bytes.Buffer
+		assert.NoError(t, handleMessage(msg, Filter{Bucket: "images"}, &out))
+		assert.NoError(t, handleMessage(msg, Filter{Bucket: "videos"}, &out))
+		// Check output
+		var event Event
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &event))
+		assert.Equal(t, "uploads/cat.png", event.Name)
+	})
+
+	t.Run("prints pretty events", func(t *testing.T) {
+		msg := realtime.Message{Event: "postgres_changes", Payload: json.RawMessage(insertPayload)}
+		// Run test
+		var out bytes.Buffer
+		assert.NoError(t, handleMessage(msg, Filter{}, &out))
+		// Check output
+		assert.Contains(t, out.String(), "created ss:///images/uploads/cat.png (42 bytes)")
+	})
+
+	t.Run("ignores other messages", func(t *testing.T) {
+		var out bytes.Buffer
+		msg := realtime.Message{Event: "presence_state", Payload: json.RawMessage(`{}`)}
+		assert.NoError(t, handleMessage(msg, Filter{}, &out))
+		assert.Empty(t, out.String())
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("throws error on invalid event type", func(t *testing.T) {
+		err := Run(context.Background(), "ss:///images", []string{"moved"})
+		assert.ErrorContains(t, err, `invalid event type "moved"`)
+	})
+}
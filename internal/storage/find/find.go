@@ -0,0 +1,167 @@
+package find
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/storage/cache"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+const (
+	ActionPrint  = "print"
+	ActionRemove = "rm"
+)
+
+var (
+	errMissingBucket = errors.New("You must specify a bucket to search.")
+	errInvalidAge    = errors.New("Invalid age. Must be a duration like 30d, 12h or 90m.")
+)
+
+type Match struct {
+	Bucket string
+	Name   string
+}
+
+// Filters objects by name and age. Empty fields match all objects.
+type Predicate struct {
+	Pattern   *regexp.Regexp
+	OlderThan time.Duration
+	NewerThan time.Duration
+}
+
+func (p Predicate) Match(objectName string, object storage.ObjectResponse, now time.Time) bool {
+	if p.Pattern != nil && !p.Pattern.MatchString(objectName) {
+		return false
+	}
+	if p.OlderThan > 0 || p.NewerThan > 0 {
+		updatedAt, err := object.LastModified()
+		if err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+			return false
+		}
+		age := now.Sub(updatedAt)
+		if p.OlderThan > 0 && age < p.OlderThan {
+			return false
+		}
+		if p.NewerThan > 0 && age > p.NewerThan {
+			return false
+		}
+	}
+	return true
+}
+
+func Run(ctx context.Context, objectPath string, predicate Predicate, action string, fsys afero.Fs) error {
+	remotePath, err := client.ParseStorageURL(objectPath)
+	if err != nil {
+		return err
+	}
+	bucket, prefix := client.SplitBucketPrefix(remotePath)
+	if len(bucket) == 0 {
+		return errors.New(errMissingBucket)
+	}
+	if len(prefix) > 0 && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	api, err := client.NewStorageAPI(ctx, flags.ProjectRef)
+	if err != nil {
+		return err
+	}
+	// Collect matches before acting on them to avoid breaking pagination result
+	var matches []string
+	now := time.Now()
+	if err := WalkObjects(ctx, api, bucket, prefix, func(objectName string, object storage.ObjectResponse) error {
+		if predicate.Match(objectName, object, now) {
+			matches = append(matches, objectName)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	switch action {
+	case ActionRemove:
+		defer cache.Invalidate(flags.ProjectRef, fsys)
+		return RemoveObjects(ctx, api, bucket, matches)
+	default:
+		for _, name := range matches {
+			fmt.Printf("/%s/%s\n", bucket, name)
+		}
+	}
+	return nil
+}
+
+// Visits every object under prefix, which must be terminated by "/" or "".
+func WalkObjects(ctx context.Context, api storage.StorageAPI, bucket, prefix string, callback func(objectName string, object storage.ObjectResponse) error) error {
+	queue := []string{prefix}
+	for len(queue) > 0 {
+		dirPrefix := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		for page := 0; ; page++ {
+			objects, err := api.ListObjects(ctx, bucket, dirPrefix, page)
+			if err != nil {
+				return err
+			}
+			for _, o := range objects {
+				objectName := dirPrefix + o.Name
+				if o.Id == nil {
+					queue = append(queue, objectName+"/")
+				} else if err := callback(objectName, o); err != nil {
+					return err
+				}
+			}
+			if len(objects) < storage.PAGE_LIMIT {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func RemoveObjects(ctx context.Context, api storage.StorageAPI, bucket string, matches []string) error {
+	if len(matches) == 0 {
+		fmt.Fprintln(os.Stderr, "No matching objects found.")
+		return nil
+	}
+	confirm := fmt.Sprintf("Confirm deleting %d files in bucket %v?", len(matches), utils.Bold(bucket))
+	if shouldDelete, err := utils.NewConsole().PromptYesNo(ctx, confirm, false); err != nil {
+		return err
+	} else if !shouldDelete {
+		return errors.New(context.Canceled)
+	}
+	for start := 0; start < len(matches); start += storage.PAGE_LIMIT {
+		end := min(start+storage.PAGE_LIMIT, len(matches))
+		batch := matches[start:end]
+		fmt.Fprintln(os.Stderr, render.T("deleting_objects", batch))
+		if _, err := api.DeleteObjects(ctx, bucket, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parses a duration that additionally accepts days as unit, ie. 30d.
+func ParseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.ParseUint(days, 10, 32)
+		if err != nil {
+			return 0, errors.New(errInvalidAge)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, errors.New(errInvalidAge)
+	}
+	return age, nil
+}
@@ -0,0 +1,114 @@
+package find
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func TestStorageFind(t *testing.T) {
+	flags.ProjectRef = apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("throws error on missing bucket", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "ss:///", Predicate{}, ActionPrint, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, errMissingBucket)
+	})
+
+	t.Run("removes matched objects recursively", func(t *testing.T) {
+		t.Cleanup(fstest.MockStdin(t, "y"))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Post("/storage/v1/object/list/private").
+			JSON(storage.ListObjectsQuery{Prefix: "", Limit: storage.PAGE_LIMIT}).
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{{
+				Name: "docs",
+			}, {
+				Name:      "readme.md",
+				Id:        cast.Ptr("9b7f9f48-17a6-4ca8-b14a-39b0205a63e9"),
+				UpdatedAt: cast.Ptr("2023-10-13T18:08:22.068Z"),
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Post("/storage/v1/object/list/private").
+			JSON(storage.ListObjectsQuery{Prefix: "docs/", Limit: storage.PAGE_LIMIT}).
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{{
+				Name:      "backup.bak",
+				Id:        cast.Ptr("cf5c5c53-ee73-4806-84e3-7d92c954b436"),
+				UpdatedAt: cast.Ptr("2023-10-13T18:08:22.068Z"),
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Delete("/storage/v1/object/private").
+			JSON(storage.DeleteObjectsRequest{Prefixes: []string{
+				"docs/backup.bak",
+			}}).
+			Reply(http.StatusOK).
+			JSON([]storage.DeleteObjectsResponse{})
+		// Run test
+		predicate := Predicate{
+			Pattern:   regexp.MustCompile(`\.bak$`),
+			OlderThan: 30 * 24 * time.Hour,
+		}
+		err := Run(context.Background(), "ss:///private/", predicate, ActionRemove, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestMatchPredicate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	object := storage.ObjectResponse{
+		Name:      "readme.md",
+		UpdatedAt: cast.Ptr("2023-12-25T00:00:00Z"),
+	}
+
+	t.Run("matches by age", func(t *testing.T) {
+		assert.True(t, Predicate{OlderThan: 24 * time.Hour}.Match("readme.md", object, now))
+		assert.False(t, Predicate{OlderThan: 30 * 24 * time.Hour}.Match("readme.md", object, now))
+		assert.True(t, Predicate{NewerThan: 30 * 24 * time.Hour}.Match("readme.md", object, now))
+	})
+
+	t.Run("skips objects without timestamp", func(t *testing.T) {
+		assert.False(t, Predicate{OlderThan: time.Hour}.Match("readme.md", storage.ObjectResponse{}, now))
+	})
+}
+
+func TestParseAge(t *testing.T) {
+	age, err := ParseAge("30d")
+	assert.NoError(t, err)
+	assert.Equal(t, 720*time.Hour, age)
+
+	age, err = ParseAge("90m")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, age)
+
+	_, err = ParseAge("-1d")
+	assert.ErrorIs(t, err, errInvalidAge)
+}
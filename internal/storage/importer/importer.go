@@ -0,0 +1,167 @@
+package importer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/storage/cache"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/queue"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+const maxRetries = 3
+
+// Initial delay between retries of a failed transfer, doubled on each attempt.
+var retryInterval = time.Second
+
+// A line in the manifest of transferred keys.
+type ManifestEntry struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Size        int64  `json:"size"`
+	ETag        string `json:"etag,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+func Run(ctx context.Context, src, dst, manifestPath string, maxJobs uint, fsys afero.Fs) error {
+	srcUrl, err := url.Parse(src)
+	if err != nil {
+		return errors.Errorf("failed to parse source url: %w", err)
+	}
+	if scheme := strings.ToLower(srcUrl.Scheme); scheme != SchemeS3 && scheme != SchemeGCS || len(srcUrl.Host) == 0 {
+		return errors.Errorf("Unsupported source url: %s. Must be like s3://bucket/prefix or gs://bucket/prefix", src)
+	}
+	dstPath, err := client.ParseStorageURL(dst)
+	if err != nil {
+		return err
+	}
+	source, err := newS3Client(strings.ToLower(srcUrl.Scheme), fsys)
+	if err != nil {
+		return err
+	}
+	api, err := client.NewStorageAPI(ctx, flags.ProjectRef)
+	if err != nil {
+		return err
+	}
+	defer cache.Invalidate(flags.ProjectRef, fsys)
+	done, err := loadManifest(manifestPath, fsys)
+	if err != nil {
+		return err
+	}
+	manifest, err := fsys.OpenFile(manifestPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Errorf("failed to open manifest: %w", err)
+	}
+	defer manifest.Close()
+	w := manifestWriter{enc: json.NewEncoder(manifest)}
+	bucket := srcUrl.Host
+	prefix := strings.TrimPrefix(srcUrl.Path, "/")
+	var transferred, skipped int
+	jq := queue.NewJobQueue(maxJobs)
+	err = source.ListObjects(ctx, bucket, prefix, func(o s3Object) error {
+		// Folder placeholders have no content to import
+		if strings.HasSuffix(o.Key, "/") {
+			return nil
+		}
+		entry := ManifestEntry{
+			Source:      fmt.Sprintf("%s://%s/%s", srcUrl.Scheme, bucket, o.Key),
+			Destination: path.Join(dstPath, strings.TrimPrefix(o.Key, prefix)),
+			Size:        o.Size,
+			ETag:        strings.Trim(o.ETag, `"`),
+		}
+		if etag, ok := done[entry.Destination]; ok && etag == entry.ETag {
+			skipped++
+			return nil
+		}
+		transferred++
+		return jq.Put(func() error {
+			fmt.Fprintln(os.Stderr, "Importing:", entry.Source, "=>", entry.Destination)
+			err := importObject(ctx, source, api, bucket, o.Key, entry.Destination)
+			if err != nil {
+				entry.Error = err.Error()
+			}
+			return errors.Join(err, w.write(entry))
+		})
+	})
+	if err = errors.Join(err, jq.Collect()); err != nil {
+		utils.CmdSuggestion = fmt.Sprintf("Rerun the command to resume from the manifest at %s.", utils.Bold(manifestPath))
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Imported %d objects, skipped %d already in manifest %s.\n", transferred, skipped, utils.Bold(manifestPath))
+	return nil
+}
+
+// Streams a single object into storage, retrying the whole transfer because the body can't be replayed.
+func importObject(ctx context.Context, source *s3Client, api storage.StorageAPI, bucket, key, dstPath string) error {
+	policy := backoff.WithContext(backoff.WithMaxRetries(backoff.NewExponentialBackOff(
+		backoff.WithInitialInterval(retryInterval),
+	), maxRetries), ctx)
+	return backoff.Retry(func() error {
+		resp, err := source.GetObject(ctx, bucket, key)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		return api.UploadObjectStream(ctx, dstPath, resp.Body, storage.FileOptions{
+			ContentType:  resp.Header.Get("Content-Type"),
+			CacheControl: resp.Header.Get("Cache-Control"),
+			Overwrite:    true,
+		})
+	}, policy)
+}
+
+// Returns the ETag of each destination that was imported successfully by a previous run.
+func loadManifest(manifestPath string, fsys afero.Fs) (map[string]string, error) {
+	result := map[string]string{}
+	f, err := fsys.Open(manifestPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to open manifest: %w", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry ManifestEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, errors.Errorf("failed to parse manifest: %w", err)
+		}
+		if len(entry.Error) == 0 {
+			result[entry.Destination] = entry.ETag
+		} else {
+			delete(result, entry.Destination)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Errorf("failed to read manifest: %w", err)
+	}
+	return result, nil
+}
+
+type manifestWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (w *manifestWriter) write(entry ManifestEntry) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.enc.Encode(entry); err != nil {
+		return errors.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/api"
+)
+
+const mockEndpoint = "http://127.0.0.1:9000"
+
+const mockListing = `<?xml version="1.0" encoding="UTF-8"?>
+<ListBucketResult>
+  <Contents><Key>photos/</Key><Size>0</Size><ETag>"d41d8cd98f00b204e9800998ecf8427e"</ETag></Contents>
+  <Contents><Key>photos/cat.png</Key><Size>3</Size><ETag>"a"</ETag></Contents>
+  <Contents><Key>photos/2024/dog.png</Key><Size>3</Size><ETag>"b"</ETag></Contents>
+  <IsTruncated>false</IsTruncated>
+</ListBucketResult>`
+
+func TestImportCommand(t *testing.T) {
+	retryInterval = 0
+	flags.ProjectRef = apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	// Setup source credentials
+	t.Setenv("AWS_ACCESS_KEY_ID", "access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret-key")
+	t.Setenv("AWS_ENDPOINT_URL", mockEndpoint)
+
+	t.Run("imports objects and writes manifest", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockEndpoint).
+			Get("/source").
+			MatchParam("list-type", "2").
+			MatchParam("prefix", "photos").
+			Reply(http.StatusOK).
+			BodyString(mockListing)
+		gock.New(mockEndpoint).
+			Get("/source/photos/cat.png").
+			Reply(http.StatusOK).
+			SetHeader("Content-Type", "image/png").
+			BodyString("cat")
+		gock.New(mockEndpoint).
+			Get("/source/photos/2024/dog.png").
+			Reply(http.StatusOK).
+			SetHeader("Content-Type", "image/png").
+			BodyString("dog")
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New("https://"+utils.GetSupabaseHost(flags.ProjectRef)).
+			Post("/storage/v1/object/private/imported/cat.png").
+			MatchHeader("Content-Type", "image/png").
+			Reply(http.StatusOK)
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Post("/storage/v1/object/private/imported/2024/dog.png").
+			Reply(http.StatusOK)
+		// Run test
+		err := Run(context.Background(), "s3://source/photos", "ss:///private/imported", "manifest.jsonl", 1, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		data, err := afero.ReadFile(fsys, "manifest.jsonl")
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, lines, 2)
+		var entry ManifestEntry
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+		assert.Equal(t, ManifestEntry{
+			Source:      "s3://source/photos/cat.png",
+			Destination: "/private/imported/cat.png",
+			Size:        3,
+			ETag:        "a",
+		}, entry)
+	})
+
+	t.Run("skips objects already in manifest", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "manifest.jsonl", []byte(
+			`{"source":"s3://source/photos/cat.png","destination":"/private/cat.png","size":3,"etag":"a"}
+{"source":"s3://source/photos/2024/dog.png","destination":"/private/2024/dog.png","size":3,"etag":"b","error":"Error status 503"}
+`), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockEndpoint).
+			Get("/source").
+			Reply(http.StatusOK).
+			BodyString(mockListing)
+		gock.New(mockEndpoint).
+			Get("/source/photos/2024/dog.png").
+			Reply(http.StatusOK).
+			BodyString("dog")
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Post("/storage/v1/object/private/2024/dog.png").
+			Reply(http.StatusOK)
+		// Run test
+		err := Run(context.Background(), "s3://source/photos/", "ss:///private/", "manifest.jsonl", 1, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("retries failed transfer", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockEndpoint).
+			Get("/source").
+			Reply(http.StatusOK).
+			BodyString(`<ListBucketResult><Contents><Key>cat.png</Key><ETag>"a"</ETag></Contents></ListBucketResult>`)
+		gock.New(mockEndpoint).
+			Get("/source/cat.png").
+			Times(2).
+			Reply(http.StatusOK).
+			BodyString("cat")
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Post("/storage/v1/object/private/cat.png").
+			Reply(http.StatusServiceUnavailable)
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Post("/storage/v1/object/private/cat.png").
+			Reply(http.StatusOK)
+		// Run test
+		err := Run(context.Background(), "gs://source", "ss:///private", "manifest.jsonl", 1, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid source", func(t *testing.T) {
+		err := Run(context.Background(), "ftp://source", "ss:///private", "manifest.jsonl", 1, afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "Unsupported source url: ftp://source")
+	})
+}
@@ -0,0 +1,321 @@
+package importer
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	SchemeS3  = "s3"
+	SchemeGCS = "gs"
+
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	amzDateFormat   = "20060102T150405Z"
+)
+
+type credentials struct {
+	AccessKeyId     string
+	SecretAccessKey string
+	SessionToken    string
+	Region          string
+}
+
+// Minimal client for S3 compatible APIs, including GCS interoperability mode with HMAC keys.
+type s3Client struct {
+	endpoint string
+	creds    credentials
+	client   *http.Client
+}
+
+type s3Object struct {
+	Key  string `xml:"Key"`
+	Size int64  `xml:"Size"`
+	ETag string `xml:"ETag"`
+}
+
+type listBucketResult struct {
+	Contents              []s3Object `xml:"Contents"`
+	IsTruncated           bool       `xml:"IsTruncated"`
+	NextContinuationToken string     `xml:"NextContinuationToken"`
+}
+
+func newS3Client(scheme string, fsys afero.Fs) (*s3Client, error) {
+	creds, err := loadCredentials(fsys)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := os.Getenv("AWS_ENDPOINT_URL_S3")
+	if len(endpoint) == 0 {
+		endpoint = os.Getenv("AWS_ENDPOINT_URL")
+	}
+	if len(endpoint) == 0 {
+		if scheme == SchemeGCS {
+			endpoint = "https://storage.googleapis.com"
+		} else {
+			endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", creds.Region)
+		}
+	}
+	return &s3Client{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		creds:    creds,
+		client:   http.DefaultClient,
+	}, nil
+}
+
+// Resolves credentials the same way as the AWS CLI: environment variables take precedence
+// over the shared credentials and config files of the selected profile.
+func loadCredentials(fsys afero.Fs) (credentials, error) {
+	creds := credentials{
+		AccessKeyId:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		Region:          os.Getenv("AWS_REGION"),
+	}
+	if len(creds.Region) == 0 {
+		creds.Region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	profile := os.Getenv("AWS_PROFILE")
+	if len(profile) == 0 {
+		profile = "default"
+	}
+	home, _ := os.UserHomeDir()
+	if len(creds.AccessKeyId) == 0 {
+		credsPath := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+		if len(credsPath) == 0 {
+			credsPath = filepath.Join(home, ".aws", "credentials")
+		}
+		section, err := readProfile(fsys, credsPath, profile)
+		if err != nil {
+			return creds, err
+		}
+		creds.AccessKeyId = section["aws_access_key_id"]
+		creds.SecretAccessKey = section["aws_secret_access_key"]
+		creds.SessionToken = section["aws_session_token"]
+	}
+	if len(creds.Region) == 0 {
+		configPath := os.Getenv("AWS_CONFIG_FILE")
+		if len(configPath) == 0 {
+			configPath = filepath.Join(home, ".aws", "config")
+		}
+		name := profile
+		if name != "default" {
+			name = "profile " + name
+		}
+		section, err := readProfile(fsys, configPath, name)
+		if err != nil {
+			return creds, err
+		}
+		creds.Region = section["region"]
+	}
+	if len(creds.AccessKeyId) == 0 || len(creds.SecretAccessKey) == 0 {
+		return creds, errors.New("missing AWS credentials: set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or configure a profile in ~/.aws/credentials")
+	}
+	if len(creds.Region) == 0 {
+		creds.Region = "us-east-1"
+	}
+	return creds, nil
+}
+
+// Reads a section from an ini formatted AWS config file, ignoring missing files.
+func readProfile(fsys afero.Fs, path, name string) (map[string]string, error) {
+	result := map[string]string{}
+	f, err := fsys.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to open aws config: %w", err)
+	}
+	defer f.Close()
+	var current string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok && current == name {
+			result[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Errorf("failed to read aws config: %w", err)
+	}
+	return result, nil
+}
+
+func (c *s3Client) ListObjects(ctx context.Context, bucket, prefix string, callback func(s3Object) error) error {
+	query := url.Values{}
+	query.Set("list-type", "2")
+	if len(prefix) > 0 {
+		query.Set("prefix", prefix)
+	}
+	for {
+		resp, err := c.send(ctx, http.MethodGet, "/"+bucket, query)
+		if err != nil {
+			return err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return errors.Errorf("failed to parse object list: %w", err)
+		}
+		for _, o := range result.Contents {
+			if err := callback(o); err != nil {
+				return err
+			}
+		}
+		if !result.IsTruncated || len(result.NextContinuationToken) == 0 {
+			return nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// Caller is responsible for closing the returned body.
+func (c *s3Client) GetObject(ctx context.Context, bucket, key string) (*http.Response, error) {
+	return c.send(ctx, http.MethodGet, "/"+bucket+"/"+key, nil)
+}
+
+func (c *s3Client) send(ctx context.Context, method, path string, query url.Values) (*http.Response, error) {
+	reqUrl := c.endpoint + escapePath(path)
+	if len(query) > 0 {
+		reqUrl += "?" + canonicalQuery(query)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqUrl, nil)
+	if err != nil {
+		return nil, errors.Errorf("failed to initialise request: %w", err)
+	}
+	req.Header.Set("x-amz-content-sha256", unsignedPayload)
+	signRequest(req, c.creds, time.Now().UTC())
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Errorf("failed to execute request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, errors.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+	return resp, nil
+}
+
+// Signs the request with AWS Signature Version 4, covering the host and all headers already set.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func signRequest(req *http.Request, creds credentials, now time.Time) {
+	amzDate := now.Format(amzDateFormat)
+	req.Header.Set("x-amz-date", amzDate)
+	if len(creds.SessionToken) > 0 {
+		req.Header.Set("x-amz-security-token", creds.SessionToken)
+	}
+	payloadHash := req.Header.Get("x-amz-content-sha256")
+	if len(payloadHash) == 0 {
+		payloadHash = unsignedPayload
+	}
+	// Canonical headers must be lowercase and sorted
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", k, headers[k])
+	}
+	signedHeaders := strings.Join(names, ";")
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		escapePath(req.URL.Path),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	date := amzDate[:8]
+	scope := strings.Join([]string{date, creds.Region, "s3", "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, creds.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyId, scope, signedHeaders, signature,
+	))
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// Encodes query parameters as required by SigV4, ie. sorted by key with spaces as %20.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func escapePath(p string) string {
+	return uriEncode(p, false)
+}
+
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
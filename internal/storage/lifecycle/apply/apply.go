@@ -0,0 +1,61 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/storage/find"
+	"github.com/supabase/cli/internal/storage/lifecycle"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func Run(ctx context.Context, dryRun bool, fsys afero.Fs) error {
+	rules, err := lifecycle.LoadRules(fsys)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		fmt.Fprintln(os.Stderr, "No lifecycle rules found.")
+		return nil
+	}
+	api, err := client.NewStorageAPI(ctx, flags.ProjectRef)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, r := range rules {
+		age, err := find.ParseAge(r.ExpireAfter)
+		if err != nil {
+			return err
+		}
+		predicate := find.Predicate{OlderThan: age}
+		var expired []string
+		if err := find.WalkObjects(ctx, api, r.Bucket, r.Prefix, func(objectName string, object storage.ObjectResponse) error {
+			if predicate.Match(objectName, object, now) {
+				expired = append(expired, objectName)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Found %d expired objects in %s/%s\n", len(expired), r.Bucket, r.Prefix)
+		if dryRun {
+			for _, name := range expired {
+				fmt.Printf("/%s/%s\n", r.Bucket, name)
+			}
+			continue
+		}
+		if len(expired) == 0 {
+			continue
+		}
+		if err := find.RemoveObjects(ctx, api, r.Bucket, expired); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package apply
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/storage/lifecycle"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func TestApplyLifecycle(t *testing.T) {
+	flags.ProjectRef = apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("deletes expired objects", func(t *testing.T) {
+		t.Cleanup(fstest.MockStdin(t, "y"))
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, lifecycle.SaveRules([]lifecycle.Rule{{
+			Bucket:      "private",
+			Prefix:      "tmp/",
+			ExpireAfter: "90d",
+		}}, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Post("/storage/v1/object/list/private").
+			JSON(storage.ListObjectsQuery{Prefix: "tmp/", Limit: storage.PAGE_LIMIT}).
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{{
+				Name:      "abstract.pdf",
+				Id:        cast.Ptr("9b7f9f48-17a6-4ca8-b14a-39b0205a63e9"),
+				UpdatedAt: cast.Ptr("2023-10-13T18:08:22.068Z"),
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Delete("/storage/v1/object/private").
+			JSON(storage.DeleteObjectsRequest{Prefixes: []string{
+				"tmp/abstract.pdf",
+			}}).
+			Reply(http.StatusOK).
+			JSON([]storage.DeleteObjectsResponse{})
+		// Run test
+		err := Run(context.Background(), false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips api calls without rules", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), false, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,80 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var errMissingBucket = errors.New("You must specify a bucket for lifecycle rules.")
+
+// Storage API has no native support for object expiry, so rules are kept in
+// the project directory and enforced by running `storage lifecycle apply`.
+type Rule struct {
+	Bucket      string `json:"bucket"`
+	Prefix      string `json:"prefix,omitempty"`
+	ExpireAfter string `json:"expire_after"`
+}
+
+func (r Rule) Matches(bucket, prefix string) bool {
+	return r.Bucket == bucket && r.Prefix == prefix
+}
+
+// Resolves the bucket and directory prefix targeted by a storage url.
+func ParseTarget(objectPath, prefix string) (string, string, error) {
+	remotePath, err := client.ParseStorageURL(objectPath)
+	if err != nil {
+		return "", "", err
+	}
+	bucket, basePrefix := client.SplitBucketPrefix(remotePath)
+	if len(bucket) == 0 {
+		return "", "", errors.New(errMissingBucket)
+	}
+	// Rules always apply to a directory prefix
+	if prefix = path.Join(basePrefix, prefix); len(prefix) > 0 {
+		prefix = strings.TrimPrefix(prefix, "/") + "/"
+	}
+	return bucket, prefix, nil
+}
+
+func LoadRules(fsys afero.Fs) ([]Rule, error) {
+	data, err := afero.ReadFile(fsys, utils.StorageLifecyclePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to read lifecycle rules: %w", err)
+	}
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, errors.Errorf("failed to parse lifecycle rules: %w", err)
+	}
+	return rules, nil
+}
+
+func SaveRules(rules []Rule, fsys afero.Fs) error {
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Bucket == rules[j].Bucket {
+			return rules[i].Prefix < rules[j].Prefix
+		}
+		return rules[i].Bucket < rules[j].Bucket
+	})
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return errors.Errorf("failed to encode lifecycle rules: %w", err)
+	}
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(utils.StorageLifecyclePath)); err != nil {
+		return err
+	}
+	if err := afero.WriteFile(fsys, utils.StorageLifecyclePath, append(data, '\n'), 0644); err != nil {
+		return errors.Errorf("failed to save lifecycle rules: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package lifecycle
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTarget(t *testing.T) {
+	t.Run("joins url and prefix", func(t *testing.T) {
+		bucket, prefix, err := ParseTarget("ss:///bucket/docs", "tmp")
+		assert.NoError(t, err)
+		assert.Equal(t, "bucket", bucket)
+		assert.Equal(t, "docs/tmp/", prefix)
+	})
+
+	t.Run("defaults to entire bucket", func(t *testing.T) {
+		bucket, prefix, err := ParseTarget("ss:///bucket/", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "bucket", bucket)
+		assert.Empty(t, prefix)
+	})
+
+	t.Run("throws error on missing bucket", func(t *testing.T) {
+		_, _, err := ParseTarget("ss:///", "tmp/")
+		assert.ErrorIs(t, err, errMissingBucket)
+	})
+}
+
+func TestSaveRules(t *testing.T) {
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	// Run test
+	require.NoError(t, SaveRules([]Rule{
+		{Bucket: "private", ExpireAfter: "7d"},
+		{Bucket: "images", Prefix: "tmp/", ExpireAfter: "90d"},
+	}, fsys))
+	// Check rules are sorted
+	rules, err := LoadRules(fsys)
+	assert.NoError(t, err)
+	assert.Equal(t, []Rule{
+		{Bucket: "images", Prefix: "tmp/", ExpireAfter: "90d"},
+		{Bucket: "private", ExpireAfter: "7d"},
+	}, rules)
+}
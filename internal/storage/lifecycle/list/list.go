@@ -0,0 +1,28 @@
+package list
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/storage/lifecycle"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(fsys afero.Fs) error {
+	rules, err := lifecycle.LoadRules(fsys)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, rules)
+	}
+	table := `|BUCKET|PREFIX|EXPIRE AFTER|
+|-|-|-|
+`
+	for _, r := range rules {
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", r.Bucket, r.Prefix, r.ExpireAfter)
+	}
+	return list.RenderTable(table)
+}
@@ -0,0 +1,42 @@
+package set
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/storage/find"
+	"github.com/supabase/cli/internal/storage/lifecycle"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(objectPath, prefix, expireAfter string, fsys afero.Fs) error {
+	if _, err := find.ParseAge(expireAfter); err != nil {
+		return err
+	}
+	bucket, prefix, err := lifecycle.ParseTarget(objectPath, prefix)
+	if err != nil {
+		return err
+	}
+	rules, err := lifecycle.LoadRules(fsys)
+	if err != nil {
+		return err
+	}
+	rule := lifecycle.Rule{Bucket: bucket, Prefix: prefix, ExpireAfter: expireAfter}
+	var found bool
+	for i, r := range rules {
+		if r.Matches(bucket, prefix) {
+			rules[i] = rule
+			found = true
+		}
+	}
+	if !found {
+		rules = append(rules, rule)
+	}
+	if err := lifecycle.SaveRules(rules, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Objects in %s expire after %s.\n", utils.Aqua(bucket+"/"+prefix), expireAfter)
+	fmt.Fprintln(os.Stderr, "Schedule "+utils.Aqua("supabase storage lifecycle apply")+" to delete expired objects.")
+	return nil
+}
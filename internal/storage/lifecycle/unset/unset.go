@@ -0,0 +1,36 @@
+package unset
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/storage/lifecycle"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(objectPath, prefix string, fsys afero.Fs) error {
+	bucket, prefix, err := lifecycle.ParseTarget(objectPath, prefix)
+	if err != nil {
+		return err
+	}
+	rules, err := lifecycle.LoadRules(fsys)
+	if err != nil {
+		return err
+	}
+	result := make([]lifecycle.Rule, 0, len(rules))
+	for _, r := range rules {
+		if !r.Matches(bucket, prefix) {
+			result = append(result, r)
+		}
+	}
+	if len(result) == len(rules) {
+		return errors.Errorf("lifecycle rule not found: %s/%s", bucket, prefix)
+	}
+	if err := lifecycle.SaveRules(result, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Removed lifecycle rule:", utils.Aqua(bucket+"/"+prefix))
+	return nil
+}
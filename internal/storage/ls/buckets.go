@@ -0,0 +1,63 @@
+package ls
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+// Lists bucket metadata instead of just names. Object counts are omitted
+// because Storage API has no endpoint to fetch them without listing.
+func RunBuckets(ctx context.Context, objectPath string) error {
+	remotePath, err := client.ParseStorageURL(objectPath)
+	if err != nil {
+		return err
+	}
+	api, err := client.NewStorageAPI(ctx, flags.ProjectRef)
+	if err != nil {
+		return err
+	}
+	buckets, err := api.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+	name, _ := client.SplitBucketPrefix(remotePath)
+	var result []storage.BucketResponse
+	for _, b := range buckets {
+		if strings.HasPrefix(b.Name, name) {
+			result = append(result, b)
+		}
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeRows(utils.OutputFormat.Value, os.Stdout, result)
+	}
+	return list.RenderTable(toMarkdown(result))
+}
+
+func toMarkdown(buckets []storage.BucketResponse) string {
+	table := "|NAME|ACCESS|CREATED AT (UTC)|FILE SIZE LIMIT|ALLOWED MIME TYPES|\n|-|-|-|-|-|\n"
+	for _, b := range buckets {
+		access := "private"
+		if b.Public {
+			access = "public"
+		}
+		sizeLimit := "-"
+		if b.FileSizeLimit != nil && *b.FileSizeLimit > 0 {
+			sizeLimit = units.BytesSize(float64(*b.FileSizeLimit))
+		}
+		mimeTypes := "*"
+		if len(b.AllowedMimeTypes) > 0 {
+			mimeTypes = strings.Join(b.AllowedMimeTypes, ", ")
+		}
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%s`|`%s`|\n", b.Name, access, utils.FormatTimestamp(b.CreatedAt), sizeLimit, mimeTypes)
+	}
+	return table
+}
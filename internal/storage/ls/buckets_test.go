@@ -0,0 +1,68 @@
+package ls
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func TestListBuckets(t *testing.T) {
+	flags.ProjectRef = apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("lists bucket details", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Get("/storage/v1/bucket").
+			Reply(http.StatusOK).
+			JSON([]storage.BucketResponse{{
+				Name:      "private",
+				CreatedAt: "2023-10-13T17:48:58.491Z",
+			}})
+		// Run test
+		err := RunBuckets(context.Background(), "ss:///")
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid URL", func(t *testing.T) {
+		err := RunBuckets(context.Background(), "")
+		assert.ErrorIs(t, err, client.ErrInvalidURL)
+	})
+}
+
+func TestBucketsMarkdown(t *testing.T) {
+	table := toMarkdown([]storage.BucketResponse{{
+		Name:             "avatars",
+		Public:           true,
+		FileSizeLimit:    cast.Ptr(5 * 1024 * 1024),
+		AllowedMimeTypes: []string{"image/png", "image/jpeg"},
+		CreatedAt:        "2023-10-13T17:48:58.491Z",
+	}, {
+		Name:      "private",
+		CreatedAt: "2023-10-13T17:48:58.491Z",
+	}})
+	assert.Contains(t, table, "|`avatars`|`public`|`2023-10-13 17:48:58`|`5MiB`|`image/png, image/jpeg`|")
+	assert.Contains(t, table, "|`private`|`private`|`2023-10-13 17:48:58`|`-`|`*`|")
+}
@@ -7,29 +7,74 @@ import (
 	"path"
 	"strings"
 
+	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/storage/tag"
+	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 	"github.com/supabase/cli/pkg/storage"
 )
 
-func Run(ctx context.Context, objectPath string, recursive bool, fsys afero.Fs) error {
+func Run(ctx context.Context, objectPath string, recursive, summarize bool, format Format, filter tag.Filter, fsys afero.Fs) error {
 	remotePath, err := client.ParseStorageURL(objectPath)
 	if err != nil {
 		return err
 	}
-	callback := func(objectPath string) error {
-		fmt.Println(objectPath)
-		return nil
-	}
 	api, err := client.NewStorageAPI(ctx, flags.ProjectRef)
 	if err != nil {
 		return err
 	}
+	basePath := remotePath
+	if !strings.HasSuffix(remotePath, "/") {
+		basePath, _ = path.Split(remotePath)
+	}
+	var summary Summary
+	callback := func(objectPath string, object *storage.ObjectResponse) error {
+		if utils.IsInterrupted(ctx) {
+			return errors.New(utils.ErrInterrupted)
+		}
+		if len(filter) > 0 {
+			// Directories and buckets cannot be tagged
+			if object == nil {
+				return nil
+			}
+			fullPath := objectPath
+			if !recursive {
+				fullPath = basePath + objectPath
+			}
+			tags, err := tag.LoadTags(ctx, api, fullPath, object)
+			if err != nil {
+				return err
+			}
+			if !filter.Match(tags) {
+				return nil
+			}
+		}
+		line := objectPath
+		if format.IDs {
+			line = formatIDs(object) + "  " + line
+		}
+		if format.Long {
+			line = formatLong(line, object, format.Sizes)
+		}
+		fmt.Println(line)
+		summary.Add(object)
+		return nil
+	}
 	if recursive {
-		return IterateStoragePathsAll(ctx, api, remotePath, callback)
+		err = IterateStorageObjectsAll(ctx, api, remotePath, callback)
+	} else {
+		err = IterateStorageObjects(ctx, api, remotePath, callback)
 	}
-	return IterateStoragePaths(ctx, api, remotePath, callback)
+	// Flush partial summary when interrupted
+	if err != nil && !errors.Is(err, utils.ErrInterrupted) {
+		return err
+	}
+	if summarize {
+		fmt.Println(summary.Format(format.Sizes))
+	}
+	return err
 }
 
 func ListStoragePaths(ctx context.Context, api storage.StorageAPI, remotePath string) ([]string, error) {
@@ -42,6 +87,13 @@ func ListStoragePaths(ctx context.Context, api storage.StorageAPI, remotePath st
 }
 
 func IterateStoragePaths(ctx context.Context, api storage.StorageAPI, remotePath string, callback func(objectName string) error) error {
+	return IterateStorageObjects(ctx, api, remotePath, func(objectName string, _ *storage.ObjectResponse) error {
+		return callback(objectName)
+	})
+}
+
+// Same as IterateStoragePaths but also passes the listed object, which is nil for buckets and directories.
+func IterateStorageObjects(ctx context.Context, api storage.StorageAPI, remotePath string, callback func(objectName string, object *storage.ObjectResponse) error) error {
 	bucket, prefix := client.SplitBucketPrefix(remotePath)
 	if len(bucket) == 0 || (len(prefix) == 0 && !strings.HasSuffix(remotePath, "/")) {
 		buckets, err := api.ListBuckets(ctx)
@@ -50,7 +102,7 @@ func IterateStoragePaths(ctx context.Context, api storage.StorageAPI, remotePath
 		}
 		for _, b := range buckets {
 			if strings.HasPrefix(b.Name, bucket) {
-				if err := callback(b.Name + "/"); err != nil {
+				if err := callback(b.Name+"/", nil); err != nil {
 					return err
 				}
 			}
@@ -64,10 +116,12 @@ func IterateStoragePaths(ctx context.Context, api storage.StorageAPI, remotePath
 			}
 			for _, o := range objects {
 				name := o.Name
+				object := &o
 				if o.Id == nil {
 					name += "/"
+					object = nil
 				}
-				if err := callback(name); err != nil {
+				if err := callback(name, object); err != nil {
 					return err
 				}
 			}
@@ -91,46 +145,116 @@ func ListStoragePathsAll(ctx context.Context, api storage.StorageAPI, remotePath
 	return result, err
 }
 
+// Maximum number of directories listed concurrently when walking recursively, set
+// by the --list-jobs flag of storage commands.
+var MaxConcurrency uint = 8
+
 func IterateStoragePathsAll(ctx context.Context, api storage.StorageAPI, remotePath string, callback func(objectPath string) error) error {
+	return IterateStorageObjectsAll(ctx, api, remotePath, func(objectPath string, _ *storage.ObjectResponse) error {
+		return callback(objectPath)
+	})
+}
+
+// IterateStorageObjectsAll walks remotePath breadth first, listing up to MaxConcurrency
+// directories at a time. Listings are buffered by their position in the walk and merged
+// in that order, so callbacks see the same sequence of paths as a sequential walk:
+// objects of each directory in the order returned by Storage API, and directories in the
+// order they were found. Callbacks run one at a time on the calling goroutine.
+func IterateStorageObjectsAll(ctx context.Context, api storage.StorageAPI, remotePath string, callback func(objectPath string, object *storage.ObjectResponse) error) error {
 	basePath := remotePath
 	if !strings.HasSuffix(remotePath, "/") {
 		basePath, _ = path.Split(remotePath)
 	}
-	// BFS so we can list paths in increasing depth
-	dirQueue := make([]string, 0)
+	// Directories in the order they were found, including those already listed
+	var dirQueue []string
 	// We don't know if user passed in a directory or file, so query storage first.
-	if err := IterateStoragePaths(ctx, api, remotePath, func(objectName string) error {
+	if err := IterateStorageObjects(ctx, api, remotePath, func(objectName string, object *storage.ObjectResponse) error {
 		objectPath := basePath + objectName
 		if strings.HasSuffix(objectName, "/") {
 			dirQueue = append(dirQueue, objectPath)
 			return nil
 		}
-		return callback(objectPath)
+		return callback(objectPath, object)
 	}); err != nil {
 		return err
 	}
-	for len(dirQueue) > 0 {
-		dirPath := dirQueue[len(dirQueue)-1]
-		dirQueue = dirQueue[:len(dirQueue)-1]
-		empty := true
-		if err := IterateStoragePaths(ctx, api, dirPath, func(objectName string) error {
-			empty = false
-			objectPath := dirPath + objectName
-			if strings.HasSuffix(objectName, "/") {
-				dirQueue = append(dirQueue, objectPath)
-				return nil
+	emit := func(l dirListing) error {
+		for _, f := range l.files {
+			if err := callback(f.path, f.object); err != nil {
+				return err
 			}
-			return callback(objectPath)
-		}); err != nil {
-			return err
+		}
+		if l.err != nil {
+			return l.err
 		}
 		// Also report empty buckets
-		bucket, prefix := client.SplitBucketPrefix(dirPath)
-		if empty && len(prefix) == 0 {
-			if err := callback(bucket + "/"); err != nil {
+		bucket, prefix := client.SplitBucketPrefix(l.path)
+		if len(l.files) == 0 && len(l.dirs) == 0 && len(prefix) == 0 {
+			if err := callback(bucket+"/", nil); err != nil {
+				return err
+			}
+		}
+		dirQueue = append(dirQueue, l.dirs...)
+		return nil
+	}
+	workers := int(max(MaxConcurrency, 1))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Buffered so that workers never block on send after an early return
+	results := make(chan dirListing, workers)
+	// Listings that completed before those found earlier in the walk
+	pending := map[int]dirListing{}
+	next, started := 0, 0
+	for next < len(dirQueue) {
+		// Only list ahead of the next directory to emit by the number of workers, which
+		// bounds both concurrency and the number of buffered listings.
+		for ; started < len(dirQueue) && started < next+workers; started++ {
+			index, dirPath := started, dirQueue[started]
+			go func() {
+				l := listDir(ctx, api, dirPath)
+				l.index = index
+				results <- l
+			}()
+		}
+		l := <-results
+		if utils.IsInterrupted(ctx) {
+			return errors.New(utils.ErrInterrupted)
+		}
+		pending[l.index] = l
+		for l, ok := pending[next]; ok; l, ok = pending[next] {
+			delete(pending, next)
+			next++
+			if err := emit(l); err != nil {
 				return err
 			}
 		}
 	}
 	return nil
 }
+
+type dirListing struct {
+	index int
+	path  string
+	files []listedObject
+	dirs  []string
+	err   error
+}
+
+type listedObject struct {
+	path   string
+	object *storage.ObjectResponse
+}
+
+func listDir(ctx context.Context, api storage.StorageAPI, dirPath string) (result dirListing) {
+	result.path = dirPath
+	result.err = IterateStorageObjects(ctx, api, dirPath, func(objectName string, object *storage.ObjectResponse) error {
+		objectPath := dirPath + objectName
+		if strings.HasSuffix(objectName, "/") {
+			result.dirs = append(result.dirs, objectPath)
+		} else {
+			result.files = append(result.files, listedObject{path: objectPath, object: object})
+		}
+		return nil
+	})
+	return result
+}
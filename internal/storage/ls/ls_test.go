@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/h2non/gock"
 	"github.com/spf13/afero"
@@ -63,7 +64,7 @@ func TestStorageLS(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON([]storage.BucketResponse{})
 		// Run test
-		err := Run(context.Background(), "ss:///", false, fsys)
+		err := Run(context.Background(), "ss:///", false, false, Format{}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 	})
@@ -72,7 +73,7 @@ func TestStorageLS(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "", false, fsys)
+		err := Run(context.Background(), "", false, false, Format{}, nil, fsys)
 		// Check error
 		assert.ErrorIs(t, err, client.ErrInvalidURL)
 	})
@@ -103,10 +104,66 @@ func TestStorageLS(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON([]storage.ObjectResponse{})
 		// Run test
-		err := Run(context.Background(), "ss:///", true, fsys)
+		err := Run(context.Background(), "ss:///", true, true, Format{}, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 	})
+
+	t.Run("filters objects by tag", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		projectHost := "https://" + utils.GetSupabaseHost(flags.ProjectRef)
+		tagged := mockFile
+		tagged.Name = "tagged.pdf"
+		tagged.UserMetadata = map[string]any{"tags": map[string]any{"env": "staging"}}
+		gock.New(projectHost).
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockFile, tagged})
+		gock.New(projectHost).
+			Get("/storage/v1/object/info/private/abstract.pdf").
+			Reply(http.StatusOK).
+			JSON(mockFile)
+		// Run test
+		err := Run(context.Background(), "ss:///private/", false, false, Format{}, map[string]string{"env": "staging"}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("stops listing when interrupted", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{mockFile, {Name: "docs"}})
+		ctx, interrupt := utils.WithInterrupt(context.Background())
+		interrupt()
+		// Run test
+		err := Run(ctx, "ss:///private/", true, true, Format{}, nil, fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrInterrupted)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
 }
 
 func TestListStoragePaths(t *testing.T) {
@@ -281,6 +338,92 @@ func TestListStoragePathsAll(t *testing.T) {
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 
+	t.Run("walks breadth first in listing order", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		mockList := func(prefix string, objects ...storage.ObjectResponse) {
+			gock.New("http://127.0.0.1").
+				Post("/storage/v1/object/list/private").
+				JSON(storage.ListObjectsQuery{
+					Prefix: prefix,
+					Search: "",
+					Limit:  storage.PAGE_LIMIT,
+					Offset: 0,
+				}).
+				Reply(http.StatusOK).
+				JSON(objects)
+		}
+		mockList("", storage.ObjectResponse{Name: "a"}, storage.ObjectResponse{Name: "b"}, mockFile)
+		mockList("a/", storage.ObjectResponse{Name: "nested"}, mockFile)
+		mockList("b/", mockFile)
+		mockList("a/nested/", mockFile)
+		// Run test
+		paths, err := ListStoragePathsAll(context.Background(), mockApi, "private/")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"private/abstract.pdf",
+			"private/a/abstract.pdf",
+			"private/b/abstract.pdf",
+			"private/a/nested/abstract.pdf",
+		}, paths)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("merges concurrent listings in order", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		var folders []storage.ObjectResponse
+		var expected, nested []string
+		for i := 0; i < 20; i++ {
+			name := fmt.Sprintf("folder-%02d", i)
+			folders = append(folders, storage.ObjectResponse{Name: name})
+			expected = append(expected, "private/"+name+"/abstract.pdf")
+			nested = append(nested, "private/"+name+"/nested/abstract.pdf")
+		}
+		expected = append(expected, nested...)
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/list/private").
+			JSON(storage.ListObjectsQuery{
+				Prefix: "",
+				Search: "",
+				Limit:  storage.PAGE_LIMIT,
+				Offset: 0,
+			}).
+			Reply(http.StatusOK).
+			JSON(folders)
+		for i, f := range folders {
+			gock.New("http://127.0.0.1").
+				Post("/storage/v1/object/list/private").
+				JSON(storage.ListObjectsQuery{
+					Prefix: f.Name + "/",
+					Search: "",
+					Limit:  storage.PAGE_LIMIT,
+					Offset: 0,
+				}).
+				Reply(http.StatusOK).
+				// Earlier folders complete last
+				Delay(time.Duration(20-i) * time.Millisecond).
+				JSON([]storage.ObjectResponse{{Name: "nested"}, mockFile})
+			gock.New("http://127.0.0.1").
+				Post("/storage/v1/object/list/private").
+				JSON(storage.ListObjectsQuery{
+					Prefix: f.Name + "/nested/",
+					Search: "",
+					Limit:  storage.PAGE_LIMIT,
+					Offset: 0,
+				}).
+				Reply(http.StatusOK).
+				JSON([]storage.ObjectResponse{mockFile})
+		}
+		// Run test
+		paths, err := ListStoragePathsAll(context.Background(), mockApi, "private/")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, expected, paths)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
 	t.Run("returns partial result on error", func(t *testing.T) {
 		// Setup mock api
 		defer gock.OffAll()
@@ -0,0 +1,118 @@
+package ls
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+// Controls how object sizes are printed.
+type SizeFormat int
+
+const (
+	// Long listing prints bytes with separators, while summary prints binary units.
+	SizeDefault SizeFormat = iota
+	// Prints binary units, ie. B, KiB, MiB, GiB.
+	SizeHuman
+	// Prints raw numbers for scripting.
+	SizeBytes
+)
+
+type Format struct {
+	Long  bool
+	IDs   bool
+	Sizes SizeFormat
+}
+
+// Totals listed objects from their metadata, similar to `aws s3 ls --summarize`.
+type Summary struct {
+	Objects int
+	Bytes   int64
+}
+
+func (s *Summary) Add(object *storage.ObjectResponse) {
+	// Buckets and directories are not counted
+	if object == nil {
+		return
+	}
+	s.Objects++
+	if object.Metadata != nil {
+		s.Bytes += int64(object.Metadata.Size)
+	}
+}
+
+func (s Summary) String() string {
+	return s.Format(SizeDefault)
+}
+
+func (s Summary) Format(sizes SizeFormat) string {
+	noun := "objects"
+	if s.Objects == 1 {
+		noun = "object"
+	}
+	size := humanSize(s.Bytes)
+	if sizes == SizeBytes {
+		size = strconv.FormatInt(s.Bytes, 10) + " bytes"
+	}
+	return fmt.Sprintf("%s %s, %s", formatCount(int64(s.Objects)), noun, size)
+}
+
+// Formats a single line of long listing with size, last modified time and path.
+func formatLong(objectPath string, object *storage.ObjectResponse, sizes SizeFormat) string {
+	size, modified := "-", "-"
+	if object != nil {
+		var n int64
+		if object.Metadata != nil {
+			n = int64(object.Metadata.Size)
+		}
+		switch sizes {
+		case SizeHuman:
+			size = humanSize(n)
+		case SizeBytes:
+			size = strconv.FormatInt(n, 10)
+		default:
+			size = formatCount(n)
+		}
+		if t, err := object.LastModified(); err == nil {
+			modified = t.UTC().Format(time.DateTime)
+		}
+	}
+	return fmt.Sprintf("%12s  %-19s  %s", size, modified, objectPath)
+}
+
+func humanSize(n int64) string {
+	return units.CustomSize("%.1f %s", float64(n), 1024, []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"})
+}
+
+// Inserts thousands separators, ie. 1234 becomes 1,234. The separator is fixed
+// regardless of locale so that output is stable across machines.
+func formatCount(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	var result []byte
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			result = append(result, ',')
+		}
+		result = append(result, digits[i])
+	}
+	return string(result)
+}
+
+// Formats the object UUID and etag, which stay stable across renames of the listed
+// prefix, so that downstream tools can dedupe objects or invalidate caches.
+func formatIDs(object *storage.ObjectResponse) string {
+	id, etag := "-", "-"
+	if object != nil {
+		if object.Id != nil {
+			id = *object.Id
+		}
+		if object.Metadata != nil && len(object.Metadata.ETag) > 0 {
+			etag = strings.Trim(object.Metadata.ETag, `"`)
+		}
+	}
+	return fmt.Sprintf("%-36s  %-32s", id, etag)
+}
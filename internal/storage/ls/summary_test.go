@@ -0,0 +1,76 @@
+package ls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func TestSummary(t *testing.T) {
+	t.Run("totals objects and size", func(t *testing.T) {
+		var summary Summary
+		for i := 0; i < 1234; i++ {
+			summary.Add(&storage.ObjectResponse{Metadata: &storage.ObjectMetadata{Size: 5 * 1024 * 1024}})
+		}
+		// Directories are skipped
+		summary.Add(nil)
+		// Check output
+		assert.Equal(t, 1234, summary.Objects)
+		assert.Equal(t, "1,234 objects, 6.0 GiB", summary.String())
+	})
+
+	t.Run("formats single object", func(t *testing.T) {
+		summary := Summary{Objects: 1, Bytes: 82702}
+		assert.Equal(t, "1 object, 80.8 KiB", summary.String())
+	})
+
+	t.Run("formats empty listing", func(t *testing.T) {
+		assert.Equal(t, "0 objects, 0.0 B", Summary{}.String())
+	})
+
+	t.Run("formats raw bytes", func(t *testing.T) {
+		summary := Summary{Objects: 1234, Bytes: 6442450944}
+		assert.Equal(t, "1,234 objects, 6442450944 bytes", summary.Format(SizeBytes))
+	})
+}
+
+func TestFormatLong(t *testing.T) {
+	updated := "2023-10-13T18:08:22.068Z"
+	object := &storage.ObjectResponse{
+		Name:      "abstract.pdf",
+		UpdatedAt: &updated,
+		Metadata:  &storage.ObjectMetadata{Size: 1234567},
+	}
+
+	t.Run("separates thousands by default", func(t *testing.T) {
+		assert.Equal(t, "   1,234,567  2023-10-13 18:08:22  abstract.pdf", formatLong("abstract.pdf", object, SizeDefault))
+	})
+
+	t.Run("formats human readable size", func(t *testing.T) {
+		assert.Equal(t, "     1.2 MiB  2023-10-13 18:08:22  abstract.pdf", formatLong("abstract.pdf", object, SizeHuman))
+	})
+
+	t.Run("formats raw bytes", func(t *testing.T) {
+		assert.Equal(t, "     1234567  2023-10-13 18:08:22  abstract.pdf", formatLong("abstract.pdf", object, SizeBytes))
+	})
+
+	t.Run("formats directory", func(t *testing.T) {
+		assert.Equal(t, "           -  -                    docs/", formatLong("docs/", nil, SizeHuman))
+	})
+}
+
+func TestFormatIDs(t *testing.T) {
+	t.Run("formats id and unquoted etag", func(t *testing.T) {
+		id := "9b7f9f48-17a6-4ca8-b14a-39b0205a63e9"
+		object := &storage.ObjectResponse{
+			Id:       &id,
+			Metadata: &storage.ObjectMetadata{ETag: `"887ea9be3c68e6f2fca7fd2d7c77d8fe"`},
+		}
+		assert.Equal(t, id+"  887ea9be3c68e6f2fca7fd2d7c77d8fe", formatIDs(object))
+	})
+
+	t.Run("formats directory", func(t *testing.T) {
+		assert.Equal(t, "-                                     -                               ", formatIDs(nil))
+	})
+}
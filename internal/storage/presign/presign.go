@@ -0,0 +1,122 @@
+package presign
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var errMissingObject = errors.New("Path must point to an object, ie. ss:///bucket/readme.md")
+
+type SignedUpload struct {
+	Path  string `json:"path"`
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+// Run signs an upload for each object path, reading newline separated paths from stdin
+// when none are given.
+func Run(ctx context.Context, objectPaths []string, upsert bool) error {
+	if client.IsAnonymous() {
+		return errors.New("Signing uploads requires project credentials instead of an anon key.")
+	}
+	if len(objectPaths) == 0 {
+		var err error
+		if objectPaths, err = readPaths(os.Stdin); err != nil {
+			return err
+		}
+	}
+	remotePaths := make([]string, len(objectPaths))
+	for i, p := range objectPaths {
+		remotePath, err := client.ParseStorageURL(p)
+		if err != nil {
+			return err
+		}
+		if bucket, prefix := client.SplitBucketPrefix(remotePath); len(bucket) == 0 || len(prefix) == 0 || strings.HasSuffix(prefix, "/") {
+			return errors.Errorf("%w: %s", errMissingObject, p)
+		}
+		remotePaths[i] = remotePath
+	}
+	api, err := client.NewStorageAPI(ctx, flags.ProjectRef)
+	if err != nil {
+		return err
+	}
+	result, err := Presign(ctx, api, client.GetStorageURL(flags.ProjectRef), remotePaths, upsert)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, result)
+	}
+	for _, r := range result {
+		fmt.Println(formatCurl(r))
+	}
+	fmt.Fprintln(os.Stderr, "Signed upload URLs expire after 2 hours.")
+	return nil
+}
+
+func readPaths(r io.Reader) ([]string, error) {
+	var result []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); len(line) > 0 {
+			result = append(result, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Errorf("failed to read paths from stdin: %w", err)
+	}
+	if len(result) == 0 {
+		return nil, errors.New("No object paths given as arguments or on stdin.")
+	}
+	return result, nil
+}
+
+func Presign(ctx context.Context, api storage.StorageAPI, baseURL string, remotePaths []string, upsert bool) ([]SignedUpload, error) {
+	var result []SignedUpload
+	for _, remotePath := range remotePaths {
+		if utils.IsInterrupted(ctx) {
+			return nil, errors.New(utils.ErrInterrupted)
+		}
+		signedURL, err := api.CreateSignedUploadURL(ctx, remotePath, upsert)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := url.Parse(signedURL)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse signed url: %w", err)
+		}
+		result = append(result, SignedUpload{
+			Path:  strings.TrimPrefix(remotePath, "/"),
+			URL:   baseURL + "/storage/v1" + signedURL,
+			Token: parsed.Query().Get("token"),
+		})
+	}
+	return result, nil
+}
+
+// Uploads the file at the object path under its bucket, relative to the current directory.
+// Content type is set explicitly because curl would otherwise send binary data as form encoded.
+func formatCurl(upload SignedUpload) string {
+	_, name := client.SplitBucketPrefix(upload.Path)
+	contentType := storage.TypeByExtension(name)
+	if len(contentType) == 0 {
+		contentType = "application/octet-stream"
+	}
+	return fmt.Sprintf("curl -X PUT -H %s --data-binary %s %s", shellQuote("Content-Type: "+contentType), shellQuote("@"+name), shellQuote(upload.URL))
+}
+
+// Wraps value in single quotes so that the shell passes it through as is.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,92 @@
+package presign
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/pkg/fetcher"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var mockApi = storage.StorageAPI{Fetcher: fetcher.NewFetcher(
+	"http://127.0.0.1",
+	fetcher.WithExpectedStatus(http.StatusOK),
+)}
+
+func TestPresignUpload(t *testing.T) {
+	t.Run("signs each object path", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/upload/sign/private/builds/app.zip").
+			MatchHeader("x-upsert", "true").
+			Reply(http.StatusOK).
+			JSON(storage.SignUploadResponse{Url: "/object/upload/sign/private/builds/app.zip?token=abc"})
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/upload/sign/private/readme.md").
+			MatchHeader("x-upsert", "true").
+			Reply(http.StatusOK).
+			JSON(storage.SignUploadResponse{Url: "/object/upload/sign/private/readme.md?token=def"})
+		// Run test
+		result, err := Presign(context.Background(), mockApi, "https://test.supabase.co", []string{"/private/builds/app.zip", "/private/readme.md"}, true)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []SignedUpload{{
+			Path:  "private/builds/app.zip",
+			URL:   "https://test.supabase.co/storage/v1/object/upload/sign/private/builds/app.zip?token=abc",
+			Token: "abc",
+		}, {
+			Path:  "private/readme.md",
+			URL:   "https://test.supabase.co/storage/v1/object/upload/sign/private/readme.md?token=def",
+			Token: "def",
+		}}, result)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing bucket", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/upload/sign/missing/readme.md").
+			Reply(http.StatusNotFound).
+			JSON(map[string]string{"error": "Bucket not found"})
+		// Run test
+		_, err := Presign(context.Background(), mockApi, "", []string{"/missing/readme.md"}, false)
+		// Check error
+		assert.ErrorContains(t, err, "Bucket not found")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestReadPaths(t *testing.T) {
+	t.Run("skips blank lines", func(t *testing.T) {
+		paths, err := readPaths(strings.NewReader("ss:///a/1.txt\n\n  ss:///a/2.txt \r\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ss:///a/1.txt", "ss:///a/2.txt"}, paths)
+	})
+
+	t.Run("throws error on empty input", func(t *testing.T) {
+		_, err := readPaths(strings.NewReader("\n"))
+		assert.ErrorContains(t, err, "No object paths given")
+	})
+}
+
+func TestFormatCurl(t *testing.T) {
+	assert.Equal(t,
+		"curl -X PUT -H 'Content-Type: application/zip' --data-binary '@builds/app.zip' 'https://test.supabase.co/upload?token=abc'",
+		formatCurl(SignedUpload{Path: "private/builds/app.zip", URL: "https://test.supabase.co/upload?token=abc"}),
+	)
+	assert.Equal(t,
+		"curl -X PUT -H 'Content-Type: application/octet-stream' --data-binary '@LICENSE' 'https://test.supabase.co/upload'",
+		formatCurl(SignedUpload{Path: "private/LICENSE", URL: "https://test.supabase.co/upload"}),
+	)
+	assert.Equal(t,
+		`curl -X PUT -H 'Content-Type: application/octet-stream' --data-binary '@it'\''s $(here)' 'https://test.supabase.co/upload'`,
+		formatCurl(SignedUpload{Path: "private/it's $(here)", URL: "https://test.supabase.co/upload"}),
+	)
+}
@@ -0,0 +1,76 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var errMissingObject = errors.New("Path must point to an image object, ie. ss:///bucket/photo.jpg")
+
+// Limits enforced by the image transformation service
+const (
+	maxDimension = 2500
+	minQuality   = 20
+	maxQuality   = 100
+)
+
+func Run(ctx context.Context, src, dst string, opts storage.TransformOptions, fsys afero.Fs) error {
+	remotePath, err := client.ParseStorageURL(src)
+	if err != nil {
+		return err
+	}
+	if bucket, prefix := client.SplitBucketPrefix(remotePath); len(bucket) == 0 || len(prefix) == 0 {
+		return errors.New(errMissingObject)
+	}
+	if err := ValidateOptions(opts); err != nil {
+		return err
+	}
+	if len(flags.ProjectRef) == 0 && !client.IsAnonymous() && !utils.Config.Storage.ImageTransformation.Enabled {
+		utils.CmdSuggestion = fmt.Sprintf("Enable %s in %s and restart your local stack.", utils.Aqua("[storage.image_transformation]"), utils.Bold(utils.ConfigPath))
+		return errors.New("Image transformation is disabled on the local stack.")
+	}
+	if len(dst) == 0 {
+		dst = path.Base(remotePath)
+	}
+	localPath := dst
+	if !filepath.IsAbs(localPath) {
+		localPath = filepath.Join(utils.CurrentDirAbs, localPath)
+	}
+	api, err := client.NewStorageAPI(ctx, flags.ProjectRef)
+	if err != nil {
+		return err
+	}
+	f, err := fsys.OpenFile(localPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+	if err := api.RenderObjectStream(ctx, remotePath, opts, f); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Rendered image to:", utils.Bold(dst))
+	return nil
+}
+
+func ValidateOptions(opts storage.TransformOptions) error {
+	if opts.Width < 0 || opts.Width > maxDimension {
+		return errors.Errorf("width must be between 1 and %d: %d", maxDimension, opts.Width)
+	}
+	if opts.Height < 0 || opts.Height > maxDimension {
+		return errors.Errorf("height must be between 1 and %d: %d", maxDimension, opts.Height)
+	}
+	if opts.Quality != 0 && (opts.Quality < minQuality || opts.Quality > maxQuality) {
+		return errors.Errorf("quality must be between %d and %d: %d", minQuality, maxQuality, opts.Quality)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package render
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func TestStorageRender(t *testing.T) {
+	flags.ProjectRef = apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("renders transformed image", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New("https://"+utils.GetSupabaseHost(flags.ProjectRef)).
+			Get("/storage/v1/render/image/authenticated/private/photo.jpg").
+			MatchParam("width", "400").
+			MatchParam("quality", "70").
+			Reply(http.StatusOK).
+			BodyString("image")
+		// Run test
+		opts := storage.TransformOptions{Width: 400, Quality: 70}
+		err := Run(context.Background(), "ss:///private/photo.jpg", "/tmp/out.jpg", opts, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		data, err := afero.ReadFile(fsys, "/tmp/out.jpg")
+		assert.NoError(t, err)
+		assert.Equal(t, "image", string(data))
+	})
+
+	t.Run("defaults output to object name", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Get("/storage/v1/render/image/authenticated/private/docs/photo.jpg").
+			Reply(http.StatusOK).
+			BodyString("image")
+		// Run test
+		err := Run(context.Background(), "ss:///private/docs/photo.jpg", "", storage.TransformOptions{}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		exists, err := afero.Exists(fsys, filepath.Join(utils.CurrentDirAbs, "photo.jpg"))
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("throws error on missing object", func(t *testing.T) {
+		err := Run(context.Background(), "ss:///private", "", storage.TransformOptions{}, afero.NewMemMapFs())
+		assert.ErrorIs(t, err, errMissingObject)
+	})
+
+	t.Run("throws error on invalid quality", func(t *testing.T) {
+		opts := storage.TransformOptions{Quality: 10}
+		err := Run(context.Background(), "ss:///private/photo.jpg", "", opts, afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "quality must be between 20 and 100: 10")
+	})
+}
@@ -4,13 +4,18 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/render"
+	"github.com/supabase/cli/internal/storage/cache"
 	"github.com/supabase/cli/internal/storage/client"
 	"github.com/supabase/cli/internal/storage/cp"
+	"github.com/supabase/cli/internal/storage/find"
 	"github.com/supabase/cli/internal/storage/ls"
+	"github.com/supabase/cli/internal/storage/tag"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 	"github.com/supabase/cli/pkg/storage"
@@ -27,7 +32,7 @@ type PrefixGroup struct {
 	Prefixes []string
 }
 
-func Run(ctx context.Context, paths []string, recursive bool, fsys afero.Fs) error {
+func Run(ctx context.Context, paths []string, recursive bool, filter tag.Filter, fsys afero.Fs) error {
 	// Group paths by buckets
 	groups := map[string][]string{}
 	for _, objectPath := range paths {
@@ -49,6 +54,15 @@ func Run(ctx context.Context, paths []string, recursive bool, fsys afero.Fs) err
 	if err != nil {
 		return err
 	}
+	defer cache.Invalidate(flags.ProjectRef, fsys)
+	if len(filter) > 0 {
+		for bucket, prefixes := range groups {
+			if err := RemoveTagged(ctx, api, bucket, prefixes, recursive, filter); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	for bucket, prefixes := range groups {
 		confirm := fmt.Sprintf("Confirm deleting files in bucket %v?", utils.Bold(bucket))
 		if shouldDelete, err := utils.NewConsole().PromptYesNo(ctx, confirm, false); err != nil {
@@ -57,7 +71,7 @@ func Run(ctx context.Context, paths []string, recursive bool, fsys afero.Fs) err
 			continue
 		}
 		// Always try deleting first in case the paths resolve to extensionless files
-		fmt.Fprintln(os.Stderr, "Deleting objects:", prefixes)
+		fmt.Fprintln(os.Stderr, render.T("deleting_objects", prefixes))
 		removed, err := api.DeleteObjects(ctx, bucket, prefixes)
 		if err != nil {
 			return err
@@ -85,6 +99,38 @@ func Run(ctx context.Context, paths []string, recursive bool, fsys afero.Fs) err
 	return nil
 }
 
+// Deletes only the objects under prefixes whose tags match filter.
+func RemoveTagged(ctx context.Context, api storage.StorageAPI, bucket string, prefixes []string, recursive bool, filter tag.Filter) error {
+	var matches []string
+	match := func(objectName string, object *storage.ObjectResponse) error {
+		tags, err := tag.LoadTags(ctx, api, path.Join(bucket, objectName), object)
+		if err != nil {
+			return err
+		}
+		if filter.Match(tags) {
+			matches = append(matches, objectName)
+		}
+		return nil
+	}
+	for _, prefix := range prefixes {
+		if !recursive {
+			if err := match(prefix, nil); err != nil {
+				return err
+			}
+			continue
+		}
+		if len(prefix) > 0 && !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+		if err := find.WalkObjects(ctx, api, bucket, prefix, func(objectName string, object storage.ObjectResponse) error {
+			return match(objectName, &object)
+		}); err != nil {
+			return err
+		}
+	}
+	return find.RemoveObjects(ctx, api, bucket, matches)
+}
+
 // Expects prefix to be terminated by "/" or ""
 func RemoveStoragePathAll(ctx context.Context, api storage.StorageAPI, bucket, prefix string) error {
 	// We must remove one directory at a time to avoid breaking pagination result
@@ -110,7 +156,7 @@ func RemoveStoragePathAll(ctx context.Context, api storage.StorageAPI, bucket, p
 			}
 		}
 		if len(files) > 0 {
-			fmt.Fprintln(os.Stderr, "Deleting objects:", files)
+			fmt.Fprintln(os.Stderr, render.T("deleting_objects", files))
 			if _, err := api.DeleteObjects(ctx, bucket, files); err != nil {
 				return err
 			}
@@ -49,7 +49,7 @@ func TestStorageRM(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), []string{":"}, false, fsys)
+		err := Run(context.Background(), []string{":"}, false, nil, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "missing protocol scheme")
 	})
@@ -58,7 +58,7 @@ func TestStorageRM(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), []string{"ss:///"}, false, fsys)
+		err := Run(context.Background(), []string{"ss:///"}, false, nil, fsys)
 		// Check error
 		assert.ErrorIs(t, err, errMissingBucket)
 	})
@@ -67,7 +67,7 @@ func TestStorageRM(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), []string{"ss:///private/"}, false, fsys)
+		err := Run(context.Background(), []string{"ss:///private/"}, false, nil, fsys)
 		// Check error
 		assert.ErrorIs(t, err, errMissingFlag)
 	})
@@ -105,7 +105,7 @@ func TestStorageRM(t *testing.T) {
 		err := Run(context.Background(), []string{
 			"ss:///private/abstract.pdf",
 			"ss:///private/docs/readme.md",
-		}, false, fsys)
+		}, false, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -175,7 +175,7 @@ func TestStorageRM(t *testing.T) {
 		err := Run(context.Background(), []string{
 			"ss:///test",
 			"ss:///private/docs",
-		}, true, fsys)
+		}, true, nil, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -198,7 +198,7 @@ func TestStorageRM(t *testing.T) {
 			Delete("/storage/v1/object/private").
 			Reply(http.StatusServiceUnavailable)
 		// Run test
-		err := Run(context.Background(), []string{"ss:///private"}, true, fsys)
+		err := Run(context.Background(), []string{"ss:///private"}, true, nil, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Error status 503:")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
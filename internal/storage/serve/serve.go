@@ -0,0 +1,163 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var errMissingBucket = errors.New("Path must include a bucket, ie. ss:///bucket/prefix")
+
+const indexFile = "index.html"
+
+// Query parameters forwarded to the image transformation endpoint
+var transformParams = []string{"width", "height", "quality", "resize", "format"}
+
+// Response headers copied from storage so assets are cached as the CDN would serve them
+var passthroughHeaders = []string{
+	"Cache-Control",
+	"Content-Length",
+	"Content-Type",
+	"ETag",
+	"Last-Modified",
+}
+
+func Run(ctx context.Context, src string, port uint, transform bool) error {
+	remotePath, err := client.ParseStorageURL(src)
+	if err != nil {
+		return err
+	}
+	bucket, prefix := client.SplitBucketPrefix(remotePath)
+	if len(bucket) == 0 {
+		return errors.New(errMissingBucket)
+	}
+	if transform && len(flags.ProjectRef) == 0 && !client.IsAnonymous() && !utils.Config.Storage.ImageTransformation.Enabled {
+		utils.CmdSuggestion = fmt.Sprintf("Enable %s in %s and restart your local stack.", utils.Aqua("[storage.image_transformation]"), utils.Bold(utils.ConfigPath))
+		return errors.New("Image transformation is disabled on the local stack.")
+	}
+	api, err := client.NewStorageAPI(ctx, flags.ProjectRef)
+	if err != nil {
+		return err
+	}
+	server := http.Server{
+		Addr:    net.JoinHostPort("localhost", strconv.FormatUint(uint64(port), 10)),
+		Handler: NewHandler(api, bucket, prefix, transform),
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background())
+	}()
+	fmt.Fprintf(os.Stderr, "Serving %s at %s\n", utils.Bold(src), utils.Aqua("http://"+server.Addr))
+	fmt.Fprintln(os.Stderr, "Press Ctrl+C to stop.")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errors.Errorf("failed to serve storage: %w", err)
+	}
+	return nil
+}
+
+type handler struct {
+	api       storage.StorageAPI
+	bucket    string
+	prefix    string
+	transform bool
+}
+
+// Serves objects under bucket/prefix, mapping request paths to object keys.
+func NewHandler(api storage.StorageAPI, bucket, prefix string, transform bool) http.Handler {
+	return &handler{
+		api:       api,
+		bucket:    bucket,
+		prefix:    strings.Trim(prefix, "/"),
+		transform: transform,
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	objectPath := h.resolve(r.URL.Path)
+	status := h.proxy(w, r, objectPath)
+	fmt.Fprintln(os.Stderr, r.Method, r.URL.RequestURI(), status)
+}
+
+// Maps the request path to an object path, serving index.html for directories.
+func (h *handler) resolve(requestPath string) string {
+	key := strings.TrimPrefix(path.Clean("/"+requestPath), "/")
+	if len(key) == 0 || strings.HasSuffix(requestPath, "/") {
+		key = path.Join(key, indexFile)
+	}
+	return path.Join(h.bucket, h.prefix, key)
+}
+
+func (h *handler) proxy(w http.ResponseWriter, r *http.Request, objectPath string) int {
+	endpoint := "/storage/v1/object/"
+	if h.api.Public {
+		endpoint += "public/"
+	}
+	query := url.Values{}
+	if h.transform {
+		for _, name := range transformParams {
+			if value := r.URL.Query().Get(name); len(value) > 0 {
+				query.Set(name, value)
+			}
+		}
+	}
+	if len(query) > 0 {
+		endpoint = "/storage/v1/render/image/authenticated/"
+		if h.api.Public {
+			endpoint = "/storage/v1/render/image/public/"
+		}
+	}
+	remotePath := endpoint + (&url.URL{Path: objectPath}).EscapedPath()
+	if len(query) > 0 {
+		remotePath += "?" + query.Encode()
+	}
+	resp, err := h.api.Send(r.Context(), http.MethodGet, remotePath, nil)
+	if err != nil {
+		status := http.StatusBadGateway
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		// Storage reports missing objects as bad request
+		if status == http.StatusBadRequest && strings.Contains(err.Error(), "not_found") {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return status
+	}
+	defer resp.Body.Close()
+	for _, name := range passthroughHeaders {
+		if value := resp.Header.Get(name); len(value) > 0 {
+			w.Header().Set(name, value)
+		}
+	}
+	// Storage falls back to octet-stream when uploads did not set a content type
+	if contentType := w.Header().Get("Content-Type"); len(contentType) == 0 || contentType == "application/octet-stream" {
+		if detected := storage.TypeByExtension(objectPath); len(detected) > 0 {
+			w.Header().Set("Content-Type", detected)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	if r.Method != http.MethodHead {
+		if _, err := io.Copy(w, resp.Body); err != nil {
+			fmt.Fprintln(os.Stderr, "failed to write response:", err)
+		}
+	}
+	return resp.StatusCode
+}
@@ -0,0 +1,152 @@
+package serve
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/pkg/fetcher"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var mockApi = storage.StorageAPI{Fetcher: fetcher.NewFetcher(
+	"http://127.0.0.1",
+	fetcher.WithExpectedStatus(http.StatusOK),
+)}
+
+func TestServeCommand(t *testing.T) {
+	t.Run("throws error on invalid url", func(t *testing.T) {
+		err := Run(context.Background(), "s3://bucket", 8000, false)
+		assert.ErrorContains(t, err, "URL must match pattern ss:///bucket/[prefix]")
+	})
+
+	t.Run("throws error on missing bucket", func(t *testing.T) {
+		err := Run(context.Background(), "ss:///", 8000, false)
+		assert.ErrorIs(t, err, errMissingBucket)
+	})
+}
+
+func TestServeHandler(t *testing.T) {
+	t.Run("serves object under prefix", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/public/site/css/main.css").
+			Reply(http.StatusOK).
+			SetHeader("Content-Type", "text/css").
+			SetHeader("Cache-Control", "max-age=3600").
+			SetHeader("ETag", `"abc"`).
+			BodyString("body{}")
+		// Run test
+		req := httptest.NewRequest(http.MethodGet, "/css/main.css", nil)
+		w := httptest.NewRecorder()
+		NewHandler(mockApi, "public", "site/", false).ServeHTTP(w, req)
+		// Check response
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/css", w.Header().Get("Content-Type"))
+		assert.Equal(t, "max-age=3600", w.Header().Get("Cache-Control"))
+		assert.Equal(t, `"abc"`, w.Header().Get("ETag"))
+		assert.Equal(t, "body{}", w.Body.String())
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("serves index for directories", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/public/docs/index.html").
+			Reply(http.StatusOK).
+			BodyString("<html></html>")
+		// Run test
+		req := httptest.NewRequest(http.MethodGet, "/docs/", nil)
+		w := httptest.NewRecorder()
+		NewHandler(mockApi, "public", "", false).ServeHTTP(w, req)
+		// Check response
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("detects content type from extension", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/images/logo.svg").
+			Reply(http.StatusOK).
+			SetHeader("Content-Type", "application/octet-stream").
+			BodyString("<svg/>")
+		// Run test
+		req := httptest.NewRequest(http.MethodGet, "/logo.svg", nil)
+		w := httptest.NewRecorder()
+		NewHandler(mockApi, "images", "", false).ServeHTTP(w, req)
+		// Check response
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/svg+xml", w.Header().Get("Content-Type"))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("forwards transform parameters", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/render/image/authenticated/images/photo.jpg").
+			MatchParam("width", "400").
+			MatchParam("resize", "contain").
+			Reply(http.StatusOK).
+			SetHeader("Content-Type", "image/webp").
+			BodyString("image")
+		// Run test
+		req := httptest.NewRequest(http.MethodGet, "/photo.jpg?width=400&resize=contain&v=1", nil)
+		w := httptest.NewRecorder()
+		NewHandler(mockApi, "images", "", true).ServeHTTP(w, req)
+		// Check response
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/webp", w.Header().Get("Content-Type"))
+		assert.Equal(t, "image", w.Body.String())
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("ignores transform parameters when disabled", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/images/photo.jpg").
+			Reply(http.StatusOK).
+			BodyString("image")
+		// Run test
+		req := httptest.NewRequest(http.MethodGet, "/photo.jpg?width=400", nil)
+		w := httptest.NewRecorder()
+		NewHandler(mockApi, "images", "", false).ServeHTTP(w, req)
+		// Check response
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("returns not found for missing object", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/images/missing.png").
+			Reply(http.StatusBadRequest).
+			JSON(map[string]string{"statusCode": "404", "error": "not_found", "message": "Object not found"})
+		// Run test
+		req := httptest.NewRequest(http.MethodGet, "/missing.png", nil)
+		w := httptest.NewRecorder()
+		NewHandler(mockApi, "images", "", false).ServeHTTP(w, req)
+		// Check response
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("rejects unsupported methods", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/photo.jpg", nil)
+		w := httptest.NewRecorder()
+		NewHandler(mockApi, "images", "", false).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
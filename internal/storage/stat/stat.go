@@ -0,0 +1,146 @@
+package stat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var errMissingObject = errors.New("Path must point to an object, ie. ss:///bucket/readme.md")
+
+type ObjectStat struct {
+	Bucket         string         `json:"bucket"`
+	Name           string         `json:"name"`
+	Size           int            `json:"size"`
+	ETag           string         `json:"etag"`
+	ContentType    string         `json:"content_type"`
+	CacheControl   string         `json:"cache_control"`
+	CreatedAt      string         `json:"created_at"`
+	UpdatedAt      string         `json:"updated_at"`
+	LastModified   string         `json:"last_modified"`
+	LastAccessedAt string         `json:"last_accessed_at"`
+	UserMetadata   map[string]any `json:"user_metadata,omitempty"`
+	Public         bool           `json:"public"`
+	PublicURL      string         `json:"public_url,omitempty"`
+	SignedURL      string         `json:"signed_url,omitempty"`
+}
+
+func Run(ctx context.Context, objectPath string, expiresIn uint) error {
+	remotePath, err := client.ParseStorageURL(objectPath)
+	if err != nil {
+		return err
+	}
+	bucket, prefix := client.SplitBucketPrefix(remotePath)
+	if len(bucket) == 0 || len(prefix) == 0 || strings.HasSuffix(prefix, "/") {
+		return errors.New(errMissingObject)
+	}
+	api, err := client.NewStorageAPI(ctx, flags.ProjectRef)
+	if err != nil {
+		return err
+	}
+	result, err := Stat(ctx, api, client.GetStorageURL(flags.ProjectRef), bucket, prefix, expiresIn)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, result)
+	}
+	return list.RenderTable(toMarkdown(result))
+}
+
+// Collects object info and bucket visibility. Signed URLs are only created for private buckets
+// when expiresIn is positive.
+func Stat(ctx context.Context, api storage.StorageAPI, baseURL, bucket, name string, expiresIn uint) (ObjectStat, error) {
+	remotePath := bucket + "/" + name
+	info, err := api.GetObjectInfo(ctx, remotePath)
+	if err != nil {
+		return ObjectStat{}, err
+	}
+	result := ObjectStat{
+		Bucket:         bucket,
+		Name:           name,
+		UserMetadata:   info.UserMetadata,
+		CreatedAt:      deref(info.CreatedAt),
+		UpdatedAt:      deref(info.UpdatedAt),
+		LastAccessedAt: deref(info.LastAccessedAt),
+	}
+	if info.Metadata != nil {
+		result.Size = info.Metadata.Size
+		result.ETag = strings.Trim(info.Metadata.ETag, `"`)
+		result.ContentType = info.Metadata.Mimetype
+		result.CacheControl = info.Metadata.CacheControl
+		result.LastModified = info.Metadata.LastModified
+	}
+	// Anonymous clients can only read objects from public buckets
+	if api.Public {
+		result.Public = true
+	} else if b, err := api.GetBucket(ctx, bucket); err != nil {
+		return ObjectStat{}, err
+	} else {
+		result.Public = b.Public
+	}
+	if result.Public {
+		result.PublicURL = baseURL + "/storage/v1/object/public/" + remotePath
+	} else if expiresIn > 0 {
+		signedURL, err := api.CreateSignedURL(ctx, remotePath, int(expiresIn))
+		if err != nil {
+			return ObjectStat{}, err
+		}
+		result.SignedURL = baseURL + "/storage/v1" + signedURL
+	}
+	return result, nil
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func toMarkdown(stat ObjectStat) string {
+	access := "private"
+	if stat.Public {
+		access = "public"
+	}
+	metadata := "-"
+	if len(stat.UserMetadata) > 0 {
+		if data, err := json.Marshal(stat.UserMetadata); err == nil {
+			metadata = string(data)
+		}
+	}
+	rows := [][2]string{
+		{"PATH", fmt.Sprintf("/%s/%s", stat.Bucket, stat.Name)},
+		{"SIZE", fmt.Sprintf("%s (%d bytes)", units.BytesSize(float64(stat.Size)), stat.Size)},
+		{"ETAG", stat.ETag},
+		{"CONTENT TYPE", stat.ContentType},
+		{"CACHE CONTROL", stat.CacheControl},
+		{"CREATED AT (UTC)", utils.FormatTimestamp(stat.CreatedAt)},
+		{"UPDATED AT (UTC)", utils.FormatTimestamp(stat.UpdatedAt)},
+		{"LAST MODIFIED (UTC)", utils.FormatTimestamp(stat.LastModified)},
+		{"LAST ACCESSED AT (UTC)", utils.FormatTimestamp(stat.LastAccessedAt)},
+		{"METADATA", metadata},
+		{"BUCKET ACCESS", access},
+	}
+	if len(stat.PublicURL) > 0 {
+		rows = append(rows, [2]string{"PUBLIC URL", stat.PublicURL})
+	}
+	if len(stat.SignedURL) > 0 {
+		rows = append(rows, [2]string{"SIGNED URL", stat.SignedURL})
+	}
+	table := "|FIELD|VALUE|\n|-|-|\n"
+	for _, r := range rows {
+		table += fmt.Sprintf("|%s|`%s`|\n", r[0], r[1])
+	}
+	return table
+}
@@ -0,0 +1,117 @@
+package stat
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/fetcher"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var mockFile = storage.ObjectResponse{
+	Name:           "docs/abstract.pdf",
+	Id:             cast.Ptr("9b7f9f48-17a6-4ca8-b14a-39b0205a63e9"),
+	UpdatedAt:      cast.Ptr("2023-10-13T18:08:22.068Z"),
+	CreatedAt:      cast.Ptr("2023-10-13T18:08:22.068Z"),
+	LastAccessedAt: cast.Ptr("2023-10-13T18:08:22.068Z"),
+	Metadata: &storage.ObjectMetadata{
+		ETag:           `"887ea9be3c68e6f2fca7fd2d7c77d8fe"`,
+		Size:           82702,
+		Mimetype:       "application/pdf",
+		CacheControl:   "max-age=3600",
+		LastModified:   "2023-10-13T18:08:22.000Z",
+		ContentLength:  82702,
+		HttpStatusCode: 200,
+	},
+	UserMetadata: map[string]any{"tags": map[string]any{"env": "staging"}},
+}
+
+var mockApi = storage.StorageAPI{Fetcher: fetcher.NewFetcher(
+	"http://127.0.0.1",
+	fetcher.WithExpectedStatus(http.StatusOK),
+)}
+
+func TestStatCommand(t *testing.T) {
+	t.Run("throws error on invalid url", func(t *testing.T) {
+		err := Run(context.Background(), "", 3600)
+		assert.ErrorIs(t, err, client.ErrInvalidURL)
+	})
+
+	t.Run("throws error on missing object", func(t *testing.T) {
+		err := Run(context.Background(), "ss:///private/docs/", 3600)
+		assert.ErrorIs(t, err, errMissingObject)
+	})
+}
+
+func TestStatObject(t *testing.T) {
+	t.Run("signs object in private bucket", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/info/private/docs/abstract.pdf").
+			Reply(http.StatusOK).
+			JSON(mockFile)
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/bucket/private").
+			Reply(http.StatusOK).
+			JSON(storage.BucketResponse{Id: "private", Name: "private"})
+		gock.New("http://127.0.0.1").
+			Post("/storage/v1/object/sign/private/docs/abstract.pdf").
+			JSON(storage.SignObjectRequest{ExpiresIn: 600}).
+			Reply(http.StatusOK).
+			JSON(storage.SignObjectResponse{SignedURL: "/object/sign/private/docs/abstract.pdf?token=abc"})
+		// Run test
+		result, err := Stat(context.Background(), mockApi, "http://127.0.0.1:54321", "private", "docs/abstract.pdf", 600)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		assert.Equal(t, 82702, result.Size)
+		assert.Equal(t, "887ea9be3c68e6f2fca7fd2d7c77d8fe", result.ETag)
+		assert.Equal(t, "application/pdf", result.ContentType)
+		assert.Equal(t, mockFile.UserMetadata, result.UserMetadata)
+		assert.False(t, result.Public)
+		assert.Empty(t, result.PublicURL)
+		assert.Equal(t, "http://127.0.0.1:54321/storage/v1/object/sign/private/docs/abstract.pdf?token=abc", result.SignedURL)
+	})
+
+	t.Run("links object in public bucket", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/info/public/docs/abstract.pdf").
+			Reply(http.StatusOK).
+			JSON(mockFile)
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/bucket/public").
+			Reply(http.StatusOK).
+			JSON(storage.BucketResponse{Id: "public", Name: "public", Public: true})
+		// Run test
+		result, err := Stat(context.Background(), mockApi, "http://127.0.0.1:54321", "public", "docs/abstract.pdf", 600)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		assert.True(t, result.Public)
+		assert.Equal(t, "http://127.0.0.1:54321/storage/v1/object/public/public/docs/abstract.pdf", result.PublicURL)
+		assert.Empty(t, result.SignedURL)
+	})
+
+	t.Run("throws error on missing object", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1").
+			Get("/storage/v1/object/info/private/missing.pdf").
+			Reply(http.StatusBadRequest).
+			JSON(map[string]string{"error": "not_found", "message": "Object not found"})
+		// Run test
+		_, err := Stat(context.Background(), mockApi, "http://127.0.0.1:54321", "private", "missing.pdf", 600)
+		// Check error
+		assert.ErrorContains(t, err, "Object not found")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
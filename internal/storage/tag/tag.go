@@ -0,0 +1,160 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/storage/cache"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/storage/find"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var (
+	errMissingBucket = errors.New("You must specify a bucket to tag.")
+	errMissingTags   = errors.New("You must specify at least one tag to set or remove.")
+	errMissingFlag   = errors.New("You must specify -r flag to tag directories.")
+	tagKeyPattern    = regexp.MustCompile(`^[A-Za-z0-9_.:/-]+$`)
+)
+
+// Matches objects whose tags contain every key value pair. An empty filter matches all objects.
+type Filter map[string]string
+
+func (f Filter) Match(tags map[string]string) bool {
+	for k, v := range f {
+		if value, ok := tags[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Parses tags of the form key=value.
+func ParseTags(values []string) (map[string]string, error) {
+	result := make(map[string]string, len(values))
+	for _, kv := range values {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, errors.Errorf("Invalid tag %s: must be of the form key=value", kv)
+		}
+		if err := assertTagKey(key); err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+func assertTagKey(key string) error {
+	if !tagKeyPattern.MatchString(key) {
+		return errors.Errorf("Invalid tag key %q: must match pattern %s", key, tagKeyPattern.String())
+	}
+	return nil
+}
+
+// Returns the tags of a listed object, fetching object info when the listing omits user metadata.
+func LoadTags(ctx context.Context, api storage.StorageAPI, remotePath string, object *storage.ObjectResponse) (map[string]string, error) {
+	if object != nil && object.UserMetadata != nil {
+		return object.Tags(), nil
+	}
+	info, err := api.GetObjectInfo(ctx, remotePath)
+	if err != nil {
+		return nil, err
+	}
+	return info.Tags(), nil
+}
+
+func Run(ctx context.Context, objectPath string, set map[string]string, remove []string, recursive bool, fsys afero.Fs) error {
+	if len(set) == 0 && len(remove) == 0 {
+		return errors.New(errMissingTags)
+	}
+	for _, key := range remove {
+		if err := assertTagKey(key); err != nil {
+			return err
+		}
+	}
+	remotePath, err := client.ParseStorageURL(objectPath)
+	if err != nil {
+		return err
+	}
+	bucket, prefix := client.SplitBucketPrefix(remotePath)
+	if len(bucket) == 0 {
+		return errors.New(errMissingBucket)
+	}
+	if (len(prefix) == 0 || strings.HasSuffix(prefix, "/")) && !recursive {
+		return errors.New(errMissingFlag)
+	}
+	api, err := client.NewStorageAPI(ctx, flags.ProjectRef)
+	if err != nil {
+		return err
+	}
+	// Cached listings include object metadata
+	defer cache.Invalidate(flags.ProjectRef, fsys)
+	var names []string
+	if recursive {
+		dirPrefix := prefix
+		if len(dirPrefix) > 0 && !strings.HasSuffix(dirPrefix, "/") {
+			dirPrefix += "/"
+		}
+		// Collect names before updating to avoid breaking pagination result
+		if err := find.WalkObjects(ctx, api, bucket, dirPrefix, func(objectName string, _ storage.ObjectResponse) error {
+			names = append(names, objectName)
+			return nil
+		}); err != nil {
+			return err
+		}
+	} else {
+		names = append(names, prefix)
+	}
+	if len(names) == 0 {
+		fmt.Fprintln(os.Stderr, "No objects found under:", remotePath)
+		return nil
+	}
+	for _, name := range names {
+		if err := tagObject(ctx, api, bucket, name, set, remove); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Updated tags of %d objects.\n", len(names))
+	return nil
+}
+
+func tagObject(ctx context.Context, api storage.StorageAPI, bucket, name string, set map[string]string, remove []string) error {
+	info, err := api.GetObjectInfo(ctx, path.Join(bucket, name))
+	if err != nil {
+		return err
+	}
+	tags := info.Tags()
+	for k, v := range set {
+		tags[k] = v
+	}
+	for _, k := range remove {
+		delete(tags, k)
+	}
+	// Preserve other user metadata set by the application
+	metadata := make(map[string]any, len(info.UserMetadata)+1)
+	for k, v := range info.UserMetadata {
+		metadata[k] = v
+	}
+	metadata[storage.TagsMetadataKey] = tags
+	fmt.Fprintf(os.Stderr, "Tagging object: /%s/%s %s\n", bucket, name, formatTags(tags))
+	_, err = api.UpdateObjectMetadata(ctx, bucket, name, metadata)
+	return err
+}
+
+func formatTags(tags map[string]string) string {
+	result := make([]string, 0, len(tags))
+	for k, v := range tags {
+		result = append(result, k+"="+v)
+	}
+	sort.Strings(result)
+	return "[" + strings.Join(result, " ") + "]"
+}
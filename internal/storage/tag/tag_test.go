@@ -0,0 +1,115 @@
+package tag
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func TestParseTags(t *testing.T) {
+	t.Run("parses key value pairs", func(t *testing.T) {
+		tags, err := ParseTags([]string{"env=staging", "team=", "note=a=b"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"env": "staging", "team": "", "note": "a=b"}, tags)
+	})
+
+	t.Run("throws error on missing value", func(t *testing.T) {
+		_, err := ParseTags([]string{"env"})
+		assert.ErrorContains(t, err, "Invalid tag env: must be of the form key=value")
+	})
+
+	t.Run("throws error on invalid key", func(t *testing.T) {
+		_, err := ParseTags([]string{"my env=staging"})
+		assert.ErrorContains(t, err, `Invalid tag key "my env"`)
+	})
+}
+
+func TestFilterMatch(t *testing.T) {
+	tags := map[string]string{"env": "staging", "team": "web"}
+	assert.True(t, Filter{}.Match(tags))
+	assert.True(t, Filter{"env": "staging"}.Match(tags))
+	assert.False(t, Filter{"env": "prod"}.Match(tags))
+	assert.False(t, Filter{"env": "staging", "owner": "me"}.Match(tags))
+}
+
+func TestTagCommand(t *testing.T) {
+	flags.ProjectRef = apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("tags objects under prefix", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		projectHost := "https://" + utils.GetSupabaseHost(flags.ProjectRef)
+		gock.New(projectHost).
+			Post("/storage/v1/object/list/private").
+			JSON(storage.ListObjectsQuery{Prefix: "docs/", Limit: storage.PAGE_LIMIT}).
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{{
+				Name: "readme.md",
+				Id:   cast.Ptr("9b7f9f48-17a6-4ca8-b14a-39b0205a63e9"),
+			}})
+		gock.New(projectHost).
+			Get("/storage/v1/object/info/private/docs/readme.md").
+			Reply(http.StatusOK).
+			JSON(storage.ObjectResponse{
+				Name: "docs/readme.md",
+				UserMetadata: map[string]any{
+					"owner": "web",
+					"tags":  map[string]string{"env": "dev", "stale": "true"},
+				},
+			})
+		gock.New(projectHost).
+			Post("/storage/v1/object/copy").
+			MatchHeader("x-upsert", "true").
+			JSON(storage.UpdateObjectMetadataRequest{
+				BucketId:       "private",
+				SourceKey:      "docs/readme.md",
+				DestinationKey: "docs/readme.md",
+				Metadata: map[string]any{
+					"owner": "web",
+					"tags":  map[string]string{"env": "staging"},
+				},
+			}).
+			Reply(http.StatusOK).
+			JSON(storage.CopyObjectResponse{Key: "private/docs/readme.md"})
+		// Run test
+		err := Run(context.Background(), "ss:///private/docs", map[string]string{"env": "staging"}, []string{"stale"}, true, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing tags", func(t *testing.T) {
+		err := Run(context.Background(), "ss:///private/docs/readme.md", nil, nil, false, afero.NewMemMapFs())
+		assert.ErrorIs(t, err, errMissingTags)
+	})
+
+	t.Run("throws error on missing bucket", func(t *testing.T) {
+		err := Run(context.Background(), "ss:///", map[string]string{"env": "staging"}, nil, true, afero.NewMemMapFs())
+		assert.ErrorIs(t, err, errMissingBucket)
+	})
+
+	t.Run("throws error on directory without recursive flag", func(t *testing.T) {
+		err := Run(context.Background(), "ss:///private/docs/", map[string]string{"env": "staging"}, nil, false, afero.NewMemMapFs())
+		assert.ErrorIs(t, err, errMissingFlag)
+	})
+}
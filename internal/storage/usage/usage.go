@@ -0,0 +1,145 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/logs/query"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+// Sums response sizes of storage requests served by the API gateway.
+const BandwidthQuery = `select sum(safe_cast(h.content_length as int64)) as bytes from edge_logs
+cross join unnest(metadata) as m
+cross join unnest(m.request) as req
+cross join unnest(m.response) as resp
+cross join unnest(resp.headers) as h
+where starts_with(req.path, '/storage/')`
+
+// Aggregates object count and size per bucket in the database instead of listing every object.
+const USAGE_QUERY = `SELECT b.name, count(o.id), coalesce(sum((o.metadata->>'size')::bigint), 0)
+FROM storage.buckets b
+LEFT JOIN storage.objects o ON o.bucket_id = b.id
+GROUP BY b.name
+ORDER BY b.name`
+
+// Log analytics only retains a day of queryable range per request.
+const bandwidthWindow = 24 * time.Hour
+
+type BucketUsage struct {
+	Bucket  string `json:"bucket"`
+	Objects int    `json:"objects"`
+	Bytes   int64  `json:"bytes"`
+}
+
+type Report struct {
+	TotalBytes int64 `json:"total_bytes"`
+	// Egress of the last 24 hours, only available for linked projects
+	BandwidthBytes *int64        `json:"bandwidth_bytes,omitempty"`
+	LimitBytes     int64         `json:"limit_bytes,omitempty"`
+	Buckets        []BucketUsage `json:"buckets"`
+}
+
+func Run(ctx context.Context, limit int64, threshold uint, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	// Self-hosted environments only connect to the database when db_url is declared
+	if len(config.Host) == 0 {
+		return errors.New("Missing db_url to query storage usage of self-hosted environment.")
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	report, err := GetUsage(ctx, conn)
+	if err != nil {
+		return err
+	}
+	report.LimitBytes = limit
+	if len(flags.ProjectRef) > 0 {
+		end := time.Now().UTC()
+		if bytes, err := queryBandwidth(ctx, flags.ProjectRef, end.Add(-bandwidthWindow), end); err != nil {
+			fmt.Fprintln(os.Stderr, "Skipping bandwidth:", err)
+		} else {
+			report.BandwidthBytes = &bytes
+		}
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		if err := utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, report); err != nil {
+			return err
+		}
+	} else if err := list.RenderTable(toMarkdown(report)); err != nil {
+		return err
+	}
+	return CheckThreshold(report, threshold)
+}
+
+func GetUsage(ctx context.Context, conn *pgx.Conn) (Report, error) {
+	report := Report{Buckets: []BucketUsage{}}
+	rows, err := conn.Query(ctx, USAGE_QUERY)
+	if err != nil {
+		return report, errors.Errorf("failed to query storage usage: %w", err)
+	}
+	for rows.Next() {
+		var usage BucketUsage
+		if err := rows.Scan(&usage.Bucket, &usage.Objects, &usage.Bytes); err != nil {
+			return report, errors.Errorf("failed to scan storage usage: %w", err)
+		}
+		report.TotalBytes += usage.Bytes
+		report.Buckets = append(report.Buckets, usage)
+	}
+	if err := rows.Err(); err != nil {
+		return report, errors.Errorf("failed to query storage usage: %w", err)
+	}
+	return report, nil
+}
+
+func queryBandwidth(ctx context.Context, projectRef string, start, end time.Time) (int64, error) {
+	rows, err := query.QueryLogs(ctx, projectRef, BandwidthQuery, start, end)
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+	// Analytics returns numbers as json floats
+	if bytes, ok := rows[0]["bytes"].(float64); ok {
+		return int64(bytes), nil
+	}
+	return 0, nil
+}
+
+// CheckThreshold fails when total storage reaches the given percentage of the limit.
+func CheckThreshold(report Report, threshold uint) error {
+	if threshold == 0 || report.LimitBytes <= 0 {
+		return nil
+	}
+	if report.TotalBytes*100 >= report.LimitBytes*int64(threshold) {
+		return errors.Errorf("Storage usage %s exceeds %d%% of the %s limit.", formatBytes(report.TotalBytes), threshold, formatBytes(report.LimitBytes))
+	}
+	return nil
+}
+
+func formatBytes(n int64) string {
+	return units.BytesSize(float64(n))
+}
+
+func toMarkdown(report Report) string {
+	table := "|BUCKET|OBJECTS|SIZE|\n|-|-|-|\n"
+	for _, b := range report.Buckets {
+		table += fmt.Sprintf("|`%s`|%d|%s|\n", b.Bucket, b.Objects, formatBytes(b.Bytes))
+	}
+	table += fmt.Sprintf("|**Total**||**%s**|\n", formatBytes(report.TotalBytes))
+	if report.LimitBytes > 0 {
+		percent := float64(report.TotalBytes) * 100 / float64(report.LimitBytes)
+		table += fmt.Sprintf("|Limit||%s (%.1f%% used)|\n", formatBytes(report.LimitBytes), percent)
+	}
+	if report.BandwidthBytes != nil {
+		table += fmt.Sprintf("|Bandwidth (24h)||%s|\n", formatBytes(*report.BandwidthBytes))
+	}
+	return table
+}
@@ -0,0 +1,63 @@
+package usage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+func TestGetUsage(t *testing.T) {
+	t.Run("sums object sizes per bucket", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(USAGE_QUERY).
+			Reply("SELECT 2",
+				[]interface{}{"empty", int64(0), int64(0)},
+				[]interface{}{"images", int64(2), int64(150)},
+			)
+		// Run test
+		report, err := GetUsage(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, Report{
+			TotalBytes: 150,
+			Buckets: []BucketUsage{
+				{Bucket: "empty", Objects: 0, Bytes: 0},
+				{Bucket: "images", Objects: 2, Bytes: 150},
+			},
+		}, report)
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(USAGE_QUERY).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for schema storage")
+		// Run test
+		_, err := GetUsage(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.ErrorContains(t, err, "failed to query storage usage:")
+	})
+}
+
+func TestCheckThreshold(t *testing.T) {
+	report := Report{TotalBytes: 80, LimitBytes: 100}
+
+	t.Run("passes below threshold", func(t *testing.T) {
+		assert.NoError(t, CheckThreshold(report, 90))
+	})
+
+	t.Run("fails at threshold", func(t *testing.T) {
+		err := CheckThreshold(report, 80)
+		assert.ErrorContains(t, err, "Storage usage 80B exceeds 80% of the 100B limit.")
+	})
+
+	t.Run("skips without limit", func(t *testing.T) {
+		assert.NoError(t, CheckThreshold(Report{TotalBytes: 80}, 1))
+	})
+}
@@ -0,0 +1,238 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-errors/errors"
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/storage/cache"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/storage/find"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/queue"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+const (
+	// A row in storage.objects whose contents are missing from the storage backend
+	MissingObject = "missing object"
+	// Contents in the storage backend without a row in storage.objects
+	MissingRow = "missing row"
+)
+
+// Local storage uses the file backend, which lays out objects as <tenant>/<bucket>/<name>/<version>.
+const localStoragePath = "/mnt/stub"
+
+type Orphan struct {
+	Kind   string `json:"kind"`
+	Bucket string `json:"bucket"`
+	Name   string `json:"name"`
+	// Path to the orphaned file in the local storage container
+	file string
+}
+
+func Run(ctx context.Context, objectPath string, clean bool, maxJobs uint, fsys afero.Fs) error {
+	remotePath, err := client.ParseStorageURL(objectPath)
+	if err != nil {
+		return err
+	}
+	bucket, prefix := client.SplitBucketPrefix(remotePath)
+	if len(prefix) > 0 && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	api, err := client.NewStorageAPI(ctx, flags.ProjectRef)
+	if err != nil {
+		return err
+	}
+	buckets := []string{bucket}
+	if len(bucket) == 0 {
+		if buckets, err = listBuckets(ctx, api); err != nil {
+			return err
+		}
+	}
+	// Only the file backend of local storage can be listed independently of storage.objects
+	isLocal := len(flags.ProjectRef) == 0 && !client.IsAnonymous()
+	var orphans []Orphan
+	for _, b := range buckets {
+		fmt.Fprintln(os.Stderr, "Verifying bucket:", utils.Bold(b))
+		names, err := listRows(ctx, api, b, prefix)
+		if err != nil {
+			return err
+		}
+		missing, err := FindMissingObjects(ctx, api, b, names, maxJobs)
+		if err != nil {
+			return err
+		}
+		orphans = append(orphans, missing...)
+		if isLocal {
+			files, err := listLocalFiles(ctx, b)
+			if err != nil {
+				return err
+			}
+			orphans = append(orphans, FindMissingRows(b, prefix, files, names)...)
+		}
+	}
+	if !isLocal {
+		fmt.Fprintln(os.Stderr, "Skipped checking for objects without rows because the storage backend of linked projects is not accessible.")
+	}
+	if err := printOrphans(orphans); err != nil || !clean {
+		return err
+	}
+	defer cache.Invalidate(flags.ProjectRef, fsys)
+	return cleanOrphans(ctx, api, orphans)
+}
+
+func listBuckets(ctx context.Context, api storage.StorageAPI) ([]string, error) {
+	buckets, err := api.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, b := range buckets {
+		result = append(result, b.Name)
+	}
+	return result, nil
+}
+
+func listRows(ctx context.Context, api storage.StorageAPI, bucket, prefix string) ([]string, error) {
+	var names []string
+	err := find.WalkObjects(ctx, api, bucket, prefix, func(objectName string, object storage.ObjectResponse) error {
+		names = append(names, objectName)
+		return nil
+	})
+	return names, err
+}
+
+// FindMissingObjects checks that the contents of every row are present in the storage backend.
+func FindMissingObjects(ctx context.Context, api storage.StorageAPI, bucket string, names []string, maxJobs uint) ([]Orphan, error) {
+	var mu sync.Mutex
+	var result []Orphan
+	jq := queue.NewJobQueue(maxJobs)
+	for _, name := range names {
+		job := func() error {
+			exists, err := api.ObjectExists(ctx, path.Join(bucket, name))
+			if err != nil || exists {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			result = append(result, Orphan{Kind: MissingObject, Bucket: bucket, Name: name})
+			return nil
+		}
+		if err := jq.Put(job); err != nil {
+			return nil, errors.Join(err, jq.Collect())
+		}
+	}
+	if err := jq.Collect(); err != nil {
+		return nil, err
+	}
+	// Jobs may finish in any order
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func listLocalFiles(ctx context.Context, bucket string) ([]string, error) {
+	// Buckets without uploads have no directory in the file backend
+	out, err := utils.DockerExecOnce(ctx, utils.StorageId, nil, []string{
+		"sh", "-c", `find "$0" -type f 2>/dev/null || true`, path.Join(localStoragePath, bucket),
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to list storage files: %w", err)
+	}
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimRight(line, "\r"); len(line) > 0 {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// FindMissingRows returns files under prefix of the local storage bucket that have no
+// corresponding object name.
+func FindMissingRows(bucket, prefix string, files, names []string) []Orphan {
+	exists := make(map[string]bool, len(names))
+	for _, n := range names {
+		exists[n] = true
+	}
+	bucketDir := path.Join(localStoragePath, bucket) + "/"
+	var result []Orphan
+	for _, f := range files {
+		name, ok := strings.CutPrefix(f, bucketDir)
+		if !ok {
+			continue
+		}
+		// Objects uploaded by recent storage versions are stored as a versioned file
+		if err := uuid.Validate(path.Base(name)); err == nil {
+			name = path.Dir(name)
+		}
+		if strings.HasPrefix(name, prefix) && !exists[name] {
+			result = append(result, Orphan{Kind: MissingRow, Bucket: bucket, Name: name, file: f})
+		}
+	}
+	return result
+}
+
+func printOrphans(orphans []Orphan) error {
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		if orphans == nil {
+			orphans = []Orphan{}
+		}
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, orphans)
+	}
+	if len(orphans) == 0 {
+		fmt.Fprintln(os.Stderr, "No inconsistencies found.")
+		return nil
+	}
+	for _, o := range orphans {
+		fmt.Printf("%s: /%s/%s\n", o.Kind, o.Bucket, o.Name)
+	}
+	fmt.Fprintf(os.Stderr, "Found %d inconsistencies.\n", len(orphans))
+	return nil
+}
+
+func cleanOrphans(ctx context.Context, api storage.StorageAPI, orphans []Orphan) error {
+	if len(orphans) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf("Remove %d orphaned rows and files?", len(orphans))
+	if shouldClean, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldClean {
+		return errors.New(context.Canceled)
+	}
+	rows := map[string][]string{}
+	var files []string
+	for _, o := range orphans {
+		if o.Kind == MissingObject {
+			rows[o.Bucket] = append(rows[o.Bucket], o.Name)
+		} else {
+			files = append(files, o.file)
+		}
+	}
+	for bucket, names := range rows {
+		for start := 0; start < len(names); start += storage.PAGE_LIMIT {
+			end := min(start+storage.PAGE_LIMIT, len(names))
+			if _, err := api.DeleteObjects(ctx, bucket, names[start:end]); err != nil {
+				return err
+			}
+		}
+	}
+	if len(files) > 0 {
+		if _, err := utils.DockerExecOnce(ctx, utils.StorageId, nil, append([]string{"rm", "-f"}, files...)); err != nil {
+			return errors.Errorf("failed to remove storage files: %w", err)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Removed %d orphans.\n", len(orphans))
+	return nil
+}
@@ -0,0 +1,145 @@
+package verify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+func TestStorageVerify(t *testing.T) {
+	flags.ProjectRef = apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("removes rows with missing contents", func(t *testing.T) {
+		t.Cleanup(fstest.MockStdin(t, "y"))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Post("/storage/v1/object/list/private").
+			JSON(storage.ListObjectsQuery{Prefix: "docs/", Limit: storage.PAGE_LIMIT}).
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{{
+				Name: "readme.md",
+				Id:   cast.Ptr("9b7f9f48-17a6-4ca8-b14a-39b0205a63e9"),
+			}, {
+				Name: "lost.pdf",
+				Id:   cast.Ptr("cf5c5c53-ee73-4806-84e3-7d92c954b436"),
+			}})
+		gock.New("https://"+utils.GetSupabaseHost(flags.ProjectRef)).
+			Get("/storage/v1/object/private/docs/readme.md").
+			MatchHeader("Range", "bytes=0-0").
+			Reply(http.StatusPartialContent)
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Get("/storage/v1/object/private/docs/lost.pdf").
+			Reply(http.StatusBadRequest).
+			JSON(map[string]string{"statusCode": "404", "error": "not_found", "message": "Object not found"})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Delete("/storage/v1/object/private").
+			JSON(storage.DeleteObjectsRequest{Prefixes: []string{
+				"docs/lost.pdf",
+			}}).
+			Reply(http.StatusOK).
+			JSON([]storage.DeleteObjectsResponse{})
+		// Run test
+		err := Run(context.Background(), "ss:///private/docs", true, 1, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on other bad requests", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{{
+				Name: "readme.md",
+				Id:   cast.Ptr("9b7f9f48-17a6-4ca8-b14a-39b0205a63e9"),
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Get("/storage/v1/object/private/readme.md").
+			Reply(http.StatusBadRequest).
+			JSON(map[string]string{"statusCode": "403", "error": "Unauthorized", "message": "invalid signature"})
+		// Run test
+		err := Run(context.Background(), "ss:///private", true, 1, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Error status 400:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on service unavailable", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + flags.ProjectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Post("/storage/v1/object/list/private").
+			Reply(http.StatusOK).
+			JSON([]storage.ObjectResponse{{
+				Name: "readme.md",
+				Id:   cast.Ptr("9b7f9f48-17a6-4ca8-b14a-39b0205a63e9"),
+			}})
+		gock.New("https://" + utils.GetSupabaseHost(flags.ProjectRef)).
+			Get("/storage/v1/object/private/readme.md").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), "ss:///private", false, 1, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Error status 503:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestFindMissingRows(t *testing.T) {
+	files := []string{
+		"/mnt/stub/private/docs/readme.md/0e5f1c4e-3c7b-4bb4-a4a5-8a3b0c1d2e3f",
+		"/mnt/stub/private/docs/orphan.png/7f0b3c2a-1d4e-4f6a-9b8c-0d1e2f3a4b5c",
+		"/mnt/stub/private/legacy.txt",
+		"/mnt/stub/private/other/skipped.txt",
+	}
+	// Run test
+	orphans := FindMissingRows("private", "docs/", files, []string{"docs/readme.md"})
+	// Check output
+	assert.Equal(t, []Orphan{{
+		Kind:   MissingRow,
+		Bucket: "private",
+		Name:   "docs/orphan.png",
+		file:   files[1],
+	}}, orphans)
+	// Unversioned files are matched by their full path
+	orphans = FindMissingRows("private", "", files[2:3], nil)
+	assert.Equal(t, "legacy.txt", orphans[0].Name)
+}
@@ -4,15 +4,20 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/credentials"
 	"github.com/zalando/go-keyring"
 )
 
 func Run(ctx context.Context, fsys afero.Fs) error {
+	if name := utils.GetLinkName(); len(name) > 0 {
+		return unlinkNamed(name, fsys)
+	}
 	if projectRef, err := afero.ReadFile(fsys, utils.ProjectRefPath); errors.Is(err, os.ErrNotExist) {
 		return errors.New(utils.ErrNotLinked)
 	} else if err != nil {
@@ -20,17 +25,16 @@ func Run(ctx context.Context, fsys afero.Fs) error {
 	} else if err := Unlink(string(projectRef), fsys); err != nil {
 		return err
 	}
-	fmt.Fprintln(os.Stdout, "Finished "+utils.Aqua("supabase unlink")+".")
+	fmt.Fprintln(os.Stdout, render.T("finished", utils.Aqua("supabase unlink")))
 	return nil
 }
 
 func Unlink(projectRef string, fsys afero.Fs) error {
-	fmt.Fprintln(os.Stderr, "Unlinking project:", projectRef)
+	fmt.Fprintln(os.Stderr, render.T("unlinking_project", projectRef))
 	var allErrors []error
-	// Remove temp directory
-	if err := fsys.RemoveAll(utils.TempDir); err != nil {
-		wrapped := errors.Errorf("failed to remove temp directory: %w", err)
-		allErrors = append(allErrors, wrapped)
+	// Remove temp directory, preserving named links
+	if err := removeTempDir(fsys); err != nil {
+		allErrors = append(allErrors, err)
 	}
 	// Remove linked credentials
 	if err := credentials.StoreProvider.Delete(projectRef); err != nil &&
@@ -40,3 +44,67 @@ func Unlink(projectRef string, fsys afero.Fs) error {
 	}
 	return errors.Join(allErrors...)
 }
+
+func removeTempDir(fsys afero.Fs) error {
+	if _, err := fsys.Stat(utils.LinksDir); errors.Is(err, os.ErrNotExist) {
+		if err := fsys.RemoveAll(utils.TempDir); err != nil {
+			return errors.Errorf("failed to remove temp directory: %w", err)
+		}
+		return nil
+	}
+	entries, err := afero.ReadDir(fsys, utils.TempDir)
+	if err != nil {
+		return errors.Errorf("failed to read temp directory: %w", err)
+	}
+	for _, e := range entries {
+		if p := filepath.Join(utils.TempDir, e.Name()); p != utils.LinksDir {
+			if err := fsys.RemoveAll(p); err != nil {
+				return errors.Errorf("failed to remove temp directory: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Removes a named link without touching the default link or other environments.
+func unlinkNamed(name string, fsys afero.Fs) error {
+	if err := utils.AssertLinkNameIsValid(name); err != nil {
+		return err
+	}
+	projectRef, err := afero.ReadFile(fsys, utils.GetProjectRefPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return errors.New(utils.ErrNotLinked)
+	} else if err != nil {
+		return errors.Errorf("failed to load project ref: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, render.T("unlinking_project", string(projectRef)))
+	var allErrors []error
+	if err := fsys.RemoveAll(filepath.Join(utils.LinksDir, name)); err != nil {
+		wrapped := errors.Errorf("failed to remove link directory: %w", err)
+		allErrors = append(allErrors, wrapped)
+	}
+	// Credentials are keyed by project ref, so keep them if another link uses the same project
+	if !isLinkedElsewhere(string(projectRef), fsys) {
+		if err := credentials.StoreProvider.Delete(string(projectRef)); err != nil &&
+			!errors.Is(err, credentials.ErrNotSupported) &&
+			!errors.Is(err, keyring.ErrNotFound) {
+			allErrors = append(allErrors, err)
+		}
+	}
+	if err := errors.Join(allErrors...); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, render.T("finished_for", utils.Aqua("supabase unlink"), utils.Bold(name)))
+	return nil
+}
+
+func isLinkedElsewhere(projectRef string, fsys afero.Fs) bool {
+	paths, _ := afero.Glob(fsys, filepath.Join(utils.LinksDir, "*", "project-ref"))
+	paths = append(paths, utils.ProjectRefPath)
+	for _, p := range paths {
+		if match, _ := afero.FileContainsBytes(fsys, p, []byte(projectRef)); match {
+			return true
+		}
+	}
+	return false
+}
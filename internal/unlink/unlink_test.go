@@ -3,9 +3,11 @@ package unlink
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/afero"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/supabase/cli/internal/testing/apitest"
@@ -47,6 +49,48 @@ func TestUnlinkCommand(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("unlinks named environment only", func(t *testing.T) {
+		t.Cleanup(func() { viper.Set("LINK_NAME", "") })
+		viper.Set("LINK_NAME", "staging")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		staging := apitest.RandomProjectRef()
+		stagingPath := filepath.Join(utils.LinksDir, "staging", "project-ref")
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(project), 0644))
+		require.NoError(t, afero.WriteFile(fsys, stagingPath, []byte(staging), 0644))
+		require.NoError(t, credentials.StoreProvider.Set(staging, "test"))
+		// Run test
+		err := Run(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.Exists(fsys, stagingPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = afero.Exists(fsys, utils.ProjectRefPath)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		_, err = credentials.StoreProvider.Get(staging)
+		assert.ErrorIs(t, err, keyring.ErrNotFound)
+	})
+
+	t.Run("preserves named links on default unlink", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		stagingPath := filepath.Join(utils.LinksDir, "staging", "project-ref")
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(project), 0644))
+		require.NoError(t, afero.WriteFile(fsys, stagingPath, []byte(apitest.RandomProjectRef()), 0644))
+		// Run test
+		err := Run(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.Exists(fsys, utils.ProjectRefPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = afero.Exists(fsys, stagingPath)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
 	t.Run("throws error if not linked", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
@@ -0,0 +1,232 @@
+package upgrade
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/google/go-github/v62/github"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	ChannelStable = "stable"
+	ChannelBeta   = "beta"
+)
+
+func Run(ctx context.Context, channel string, check bool, fsys afero.Fs) error {
+	if err := utils.AssertOnline("upgrade the CLI"); err != nil {
+		return err
+	}
+	release, err := GetRelease(ctx, channel)
+	if err != nil {
+		return err
+	}
+	latest := release.GetTagName()
+	current := "v" + utils.Version
+	if semver.Compare(latest, current) <= 0 {
+		fmt.Fprintf(os.Stderr, "Supabase CLI is up to date on the %s channel: %s\n", channel, utils.Aqua(current))
+		return nil
+	}
+	if check {
+		fmt.Fprintf(os.Stderr, "A new version of Supabase CLI is available on the %s channel: %s (currently installed %s)\n", channel, utils.Yellow(latest), current)
+		utils.CmdSuggestion = fmt.Sprintf("Run %s to install it.", utils.Aqua("supabase upgrade --channel "+channel))
+		return nil
+	}
+	binary, err := downloadBinary(ctx, release)
+	if err != nil {
+		return err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return errors.Errorf("failed to find executable: %w", err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return errors.Errorf("failed to resolve executable: %w", err)
+	}
+	if err := replaceBinary(exe, binary, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Upgraded Supabase CLI to", utils.Aqua(latest))
+	return nil
+}
+
+// Stable releases are published as latest, while beta releases are marked as prerelease.
+func GetRelease(ctx context.Context, channel string) (*github.RepositoryRelease, error) {
+	client := utils.GetGitHubClient(ctx)
+	if channel == ChannelStable {
+		release, _, err := client.Repositories.GetLatestRelease(ctx, utils.CLI_OWNER, utils.CLI_REPO)
+		if err != nil {
+			return nil, errors.Errorf("failed to fetch latest release: %w", err)
+		}
+		return release, nil
+	}
+	releases, _, err := client.Repositories.ListReleases(ctx, utils.CLI_OWNER, utils.CLI_REPO, &github.ListOptions{PerPage: 10})
+	if err != nil {
+		return nil, errors.Errorf("failed to list releases: %w", err)
+	}
+	for _, r := range releases {
+		if !r.GetDraft() {
+			return r, nil
+		}
+	}
+	return nil, errors.Errorf("no release found on the %s channel", channel)
+}
+
+func GetAssetName() string {
+	return fmt.Sprintf("supabase_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+}
+
+func downloadBinary(ctx context.Context, release *github.RepositoryRelease) ([]byte, error) {
+	version := strings.TrimPrefix(release.GetTagName(), "v")
+	checksumName := fmt.Sprintf("supabase_%s_checksums.txt", version)
+	assets := map[string]string{}
+	for _, a := range release.Assets {
+		assets[a.GetName()] = a.GetBrowserDownloadURL()
+	}
+	// Verify the checksums file before trusting any hash it contains
+	checksums, err := downloadAsset(ctx, assets, checksumName)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := downloadAsset(ctx, assets, checksumName+".sig")
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifySignature(checksums, signature); err != nil {
+		return nil, err
+	}
+	assetName := GetAssetName()
+	expected, err := findChecksum(checksums, assetName)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := downloadAsset(ctx, assets, assetName)
+	if err != nil {
+		return nil, err
+	}
+	if actual := sha256.Sum256(archive); hex.EncodeToString(actual[:]) != expected {
+		return nil, errors.Errorf("checksum mismatch for %s: expected %s", assetName, expected)
+	}
+	return extractBinary(archive)
+}
+
+func downloadAsset(ctx context.Context, assets map[string]string, name string) ([]byte, error) {
+	assetUrl, ok := assets[name]
+	if !ok {
+		return nil, errors.Errorf("release asset not found: %s", name)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, assetUrl, nil)
+	if err != nil {
+		return nil, errors.Errorf("failed to initialise request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Errorf("failed to download %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %d downloading %s", resp.StatusCode, name)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Errorf("failed to read %s: %w", name, err)
+	}
+	return body, nil
+}
+
+// Checksums are signed with the release key using: openssl pkeyutl -sign -rawin
+func VerifySignature(data, signature []byte) error {
+	if len(utils.ReleasePublicKey) == 0 {
+		utils.CmdSuggestion = "Use the package manager that installed the CLI to upgrade instead."
+		return errors.New("Cannot verify release signature because this build has no release public key.")
+	}
+	key, err := base64.StdEncoding.DecodeString(utils.ReleasePublicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return errors.Errorf("invalid release public key: %s", utils.ReleasePublicKey)
+	}
+	if !ed25519.Verify(key, data, signature) {
+		return errors.New("failed to verify release signature")
+	}
+	return nil
+}
+
+// Parses the sha256sum format written by goreleaser.
+func findChecksum(checksums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == name {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", errors.Errorf("failed to parse checksums: %w", err)
+	}
+	return "", errors.Errorf("checksum not found: %s", name)
+}
+
+func extractBinary(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, errors.Errorf("failed to decompress archive: %w", err)
+	}
+	defer gz.Close()
+	binName := "supabase"
+	if runtime.GOOS == "windows" {
+		binName += ".exe"
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.Errorf("binary not found in archive: %s", binName)
+		} else if err != nil {
+			return nil, errors.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(hdr.Name) == binName {
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, errors.Errorf("failed to extract binary: %w", err)
+			}
+			return data, nil
+		}
+	}
+}
+
+// Writes the new binary next to the current one before renaming, so a failed write never
+// leaves a corrupted executable behind.
+func replaceBinary(exe string, binary []byte, fsys afero.Fs) error {
+	tmp := exe + ".new"
+	if err := afero.WriteFile(fsys, tmp, binary, 0755); err != nil {
+		return errors.Errorf("failed to write binary: %w", err)
+	}
+	// Windows does not allow replacing a running executable, but it can be renamed
+	if runtime.GOOS == "windows" {
+		old := exe + ".old"
+		_ = fsys.Remove(old)
+		if err := fsys.Rename(exe, old); err != nil {
+			return errors.Errorf("failed to move old binary: %w", err)
+		}
+	}
+	if err := fsys.Rename(tmp, exe); err != nil {
+		return errors.Errorf("failed to replace binary: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package upgrade
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-github/v62/github"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/cast"
+)
+
+const assetHost = "https://github.com"
+
+func newArchive(t *testing.T, binary []byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	name := "supabase"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(binary)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write(binary)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func newRelease(tag string, names ...string) *github.RepositoryRelease {
+	release := github.RepositoryRelease{TagName: cast.Ptr(tag)}
+	for _, n := range names {
+		release.Assets = append(release.Assets, &github.ReleaseAsset{
+			Name:               cast.Ptr(n),
+			BrowserDownloadURL: cast.Ptr(assetHost + "/supabase/cli/releases/download/" + tag + "/" + n),
+		})
+	}
+	return &release
+}
+
+func TestGetRelease(t *testing.T) {
+	t.Run("fetches latest stable release", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New("https://api.github.com").
+			Get("/repos/supabase/cli/releases/latest").
+			Reply(http.StatusOK).
+			JSON(newRelease("v2.0.0"))
+		// Run test
+		release, err := GetRelease(context.Background(), ChannelStable)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "v2.0.0", release.GetTagName())
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("fetches latest beta release", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New("https://api.github.com").
+			Get("/repos/supabase/cli/releases").
+			Reply(http.StatusOK).
+			JSON([]github.RepositoryRelease{
+				{TagName: cast.Ptr("v2.1.0"), Draft: cast.Ptr(true)},
+				{TagName: cast.Ptr("v2.1.0-beta.1"), Prerelease: cast.Ptr(true)},
+			})
+		// Run test
+		release, err := GetRelease(context.Background(), ChannelBeta)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "v2.1.0-beta.1", release.GetTagName())
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestDownloadBinary(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	utils.ReleasePublicKey = base64.StdEncoding.EncodeToString(pub)
+	defer func() { utils.ReleasePublicKey = "" }()
+
+	binary := []byte("new binary")
+	archive := newArchive(t, binary)
+	digest := sha256.Sum256(archive)
+	assetName := GetAssetName()
+	checksums := []byte(fmt.Sprintf("%s  %s\n", hex.EncodeToString(digest[:]), assetName))
+	release := newRelease("v2.0.0", assetName, "supabase_2.0.0_checksums.txt", "supabase_2.0.0_checksums.txt.sig")
+	prefix := "/supabase/cli/releases/download/v2.0.0/"
+
+	t.Run("downloads and verifies binary", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(assetHost).
+			Get(prefix + "supabase_2.0.0_checksums.txt").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(checksums))
+		gock.New(assetHost).
+			Get(prefix + "supabase_2.0.0_checksums.txt.sig").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(ed25519.Sign(priv, checksums)))
+		gock.New(assetHost).
+			Get(prefix + assetName).
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(archive))
+		// Run test
+		data, err := downloadBinary(context.Background(), release)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, binary, data)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid signature", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(assetHost).
+			Get(prefix + "supabase_2.0.0_checksums.txt").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(checksums))
+		gock.New(assetHost).
+			Get(prefix + "supabase_2.0.0_checksums.txt.sig").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(make([]byte, ed25519.SignatureSize)))
+		// Run test
+		_, err := downloadBinary(context.Background(), release)
+		// Check error
+		assert.ErrorContains(t, err, "failed to verify release signature")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on checksum mismatch", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(assetHost).
+			Get(prefix + "supabase_2.0.0_checksums.txt").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(checksums))
+		gock.New(assetHost).
+			Get(prefix + "supabase_2.0.0_checksums.txt.sig").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(ed25519.Sign(priv, checksums)))
+		gock.New(assetHost).
+			Get(prefix + assetName).
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(newArchive(t, []byte("tampered"))))
+		// Run test
+		_, err := downloadBinary(context.Background(), release)
+		// Check error
+		assert.ErrorContains(t, err, "checksum mismatch for "+assetName)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing asset", func(t *testing.T) {
+		// Run test
+		_, err := downloadBinary(context.Background(), newRelease("v2.0.0"))
+		// Check error
+		assert.ErrorContains(t, err, "release asset not found: supabase_2.0.0_checksums.txt")
+	})
+}
+
+func TestVerifySignature(t *testing.T) {
+	t.Run("throws error on missing public key", func(t *testing.T) {
+		err := VerifySignature([]byte("data"), nil)
+		assert.ErrorContains(t, err, "this build has no release public key")
+	})
+}
+
+func TestReplaceBinary(t *testing.T) {
+	t.Run("replaces binary in place", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/usr/local/bin/supabase", []byte("old"), 0755))
+		// Run test
+		err := replaceBinary("/usr/local/bin/supabase", []byte("new"), fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, "/usr/local/bin/supabase")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("new"), data)
+		exists, err := afero.Exists(fsys, "/usr/local/bin/supabase.new")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestCheckUpgrade(t *testing.T) {
+	utils.Version = "1.0.0"
+	defer func() { utils.Version = "" }()
+
+	t.Run("prints available version", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New("https://api.github.com").
+			Get("/repos/supabase/cli/releases/latest").
+			Reply(http.StatusOK).
+			JSON(newRelease("v2.0.0"))
+		// Run test
+		err := Run(context.Background(), ChannelStable, true, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, utils.CmdSuggestion, "supabase upgrade --channel stable")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips download when up to date", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New("https://api.github.com").
+			Get("/repos/supabase/cli/releases/latest").
+			Reply(http.StatusOK).
+			JSON(newRelease("v1.0.0"))
+		// Run test
+		err := Run(context.Background(), ChannelStable, false, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -1,9 +1,12 @@
 package utils
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
@@ -32,6 +35,14 @@ func LoadAccessTokenFS(fsys afero.Fs) (string, error) {
 	if !AccessTokenPattern.MatchString(accessToken) {
 		return "", errors.New(ErrInvalidToken)
 	}
+	// Tokens from device login expire and must be refreshed
+	if strings.HasPrefix(accessToken, oauthTokenPrefix) && len(os.Getenv("SUPABASE_ACCESS_TOKEN")) == 0 {
+		if refreshed, err := refreshOAuthToken(context.Background(), fsys); err != nil {
+			fmt.Fprintln(GetDebugLogger(), err)
+		} else if len(refreshed) > 0 {
+			return refreshed, nil
+		}
+	}
 	return accessToken, nil
 }
 
@@ -41,7 +52,7 @@ func loadAccessToken(fsys afero.Fs) (string, error) {
 		return accessToken, nil
 	}
 	// Load from native credentials store
-	if accessToken, err := credentials.StoreProvider.Get(AccessTokenKey); err == nil {
+	if accessToken, err := credentials.StoreProvider.Get(profileKey(AccessTokenKey)); err == nil {
 		return accessToken, nil
 	}
 	// Fallback to token file
@@ -68,7 +79,7 @@ func SaveAccessToken(accessToken string, fsys afero.Fs) error {
 		return errors.New(ErrInvalidToken)
 	}
 	// Save to native credentials store
-	if err := credentials.StoreProvider.Set(AccessTokenKey, accessToken); err == nil {
+	if err := credentials.StoreProvider.Set(profileKey(AccessTokenKey), accessToken); err == nil {
 		return nil
 	}
 	// Fallback to token file
@@ -90,17 +101,18 @@ func fallbackSaveToken(accessToken string, fsys afero.Fs) error {
 }
 
 func DeleteAccessToken(fsys afero.Fs) error {
+	deleteOAuthSession(fsys)
 	// Always delete the fallback token file to handle legacy CLI
 	if err := fallbackDeleteToken(fsys); err == nil {
 		// Typically user system should only have either token file or keyring.
 		// But we delete from both just in case.
-		_ = credentials.StoreProvider.Delete(AccessTokenKey)
+		_ = credentials.StoreProvider.Delete(profileKey(AccessTokenKey))
 		return nil
 	} else if !errors.Is(err, os.ErrNotExist) {
 		return err
 	}
 	// Fallback not found, delete from native credentials store
-	err := credentials.StoreProvider.Delete(AccessTokenKey)
+	err := credentials.StoreProvider.Delete(profileKey(AccessTokenKey))
 	if errors.Is(err, credentials.ErrNotSupported) || errors.Is(err, keyring.ErrNotFound) {
 		return errors.New(ErrNotLoggedIn)
 	} else if err != nil {
@@ -126,5 +138,5 @@ func getAccessTokenPath() (string, error) {
 		return "", errors.Errorf("failed to get $HOME directory: %w", err)
 	}
 	// TODO: fallback to workdir
-	return filepath.Join(home, ".supabase", AccessTokenKey), nil
+	return filepath.Join(home, ".supabase", profileKey(AccessTokenKey)), nil
 }
@@ -182,8 +182,12 @@ func GetSupabase() *supabase.ClientWithResponses {
 		apiClient, err = supabase.NewClientWithResponses(
 			GetSupabaseAPIHost(),
 			supabase.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+				if err := AssertOnline("call the Management API"); err != nil {
+					return err
+				}
 				req.Header.Set("Authorization", "Bearer "+token)
 				req.Header.Set("User-Agent", "SupabaseCLI/"+Version)
+				SetTraceHeader(req)
 				return nil
 			}),
 		)
@@ -2,7 +2,6 @@ package utils
 
 import (
 	_ "embed"
-	"fmt"
 	"io/fs"
 	"net"
 	"net/url"
@@ -104,7 +103,7 @@ var Config = config.NewConfig(config.WithHostname(GetHostname()))
 func LoadConfigFS(fsys afero.Fs) error {
 	if err := Config.Load("", NewRootFS(fsys)); err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			CmdSuggestion = fmt.Sprintf("Have you set up the project with %s?", Aqua("supabase init"))
+			SuggestProjectNotFound()
 		}
 		return err
 	}
@@ -136,6 +135,10 @@ type InitParams struct {
 	ProjectId   string
 	UseOrioleDB bool
 	Overwrite   bool
+	// Name of the starter template to scaffold, if any
+	Template string
+	// Fetches the template from the registry instead of using the bundled ones
+	RemoteTemplate bool
 }
 
 func InitConfig(params InitParams, fsys afero.Fs) error {
@@ -14,6 +14,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/viper"
 	"github.com/supabase/cli/internal/debug"
+	"github.com/supabase/cli/internal/render"
 	"github.com/supabase/cli/pkg/pgxv5"
 )
 
@@ -124,16 +125,24 @@ func ConnectByUrl(ctx context.Context, url string, options ...func(*pgx.ConnConf
 
 func ConnectByConfigStream(ctx context.Context, config pgconn.Config, w io.Writer, options ...func(*pgx.ConnConfig)) (*pgx.Conn, error) {
 	if IsLocalDatabase(config) {
-		fmt.Fprintln(w, "Connecting to local database...")
+		fmt.Fprintln(w, render.T("connecting_local"))
 		return ConnectLocalPostgres(ctx, config, options...)
 	}
-	fmt.Fprintln(w, "Connecting to remote database...")
-	opts := append(options, func(cc *pgx.ConnConfig) {
-		if DNSResolver.Value == DNS_OVER_HTTPS {
-			cc.LookupFunc = FallbackLookupIP
-		}
-	})
-	return ConnectByUrl(ctx, ToPostgresURL(config), opts...)
+	fmt.Fprintln(w, render.T("connecting_remote"))
+	// Prepended so that test options can still override the lookup func
+	opts := append([]func(*pgx.ConnConfig){func(cc *pgx.ConnConfig) {
+		cc.LookupFunc = LookupDatabaseHost
+	}}, options...)
+	conn, err := ConnectByUrl(ctx, ToPostgresURL(config), opts...)
+	if err != nil && IPFamily.Value == IP_FAMILY_AUTO && isUnreachable(err) {
+		CmdSuggestion = "Your network may not support IPv6. Try again with " + Aqua("--ip-family ipv4") + " or connect through the IPv4 compatible connection pooler."
+	}
+	return conn, err
+}
+
+func isUnreachable(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "network is unreachable") || strings.Contains(msg, "no route to host")
 }
 
 func ConnectByConfig(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) (*pgx.Conn, error) {
@@ -143,3 +152,9 @@ func ConnectByConfig(ctx context.Context, config pgconn.Config, options ...func(
 func IsLocalDatabase(config pgconn.Config) bool {
 	return config.Host == Config.Hostname && config.Port == Config.Db.Port
 }
+
+// Quotes a string constant for SQL statements, assuming standard_conforming_strings
+// is on so that backslashes are kept as is. Use pgx.Identifier to quote names.
+func QuoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
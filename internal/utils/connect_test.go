@@ -176,3 +176,8 @@ func TestPostgresURL(t *testing.T) {
 	})
 	assert.Equal(t, `postgresql://postgres:%21%40%23$%25%5E&%2A%28%29@[2406:da18:4fd:9b0d:80ec:9812:3e65:450b]:5432/?connect_timeout=10&options=test`, url)
 }
+
+func TestQuoteLiteral(t *testing.T) {
+	assert.Equal(t, `'it''s'`, QuoteLiteral("it's"))
+	assert.Equal(t, `'C:\temp'`, QuoteLiteral(`C:\temp`))
+}
@@ -14,6 +14,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 
@@ -290,3 +291,67 @@ func ToDockerPath(absHostPath string) string {
 	dockerPath := filepath.ToSlash(absHostPath)
 	return strings.TrimPrefix(dockerPath, prefix)
 }
+
+var importPattern = regexp.MustCompile(`(?:import|export)\s+(?:[^'"]*?\s+from\s+)?['"]([^'"]+)['"]|import\(\s*['"]([^'"]+)['"]\s*\)`)
+
+// Returns the entrypoint and all local modules it imports transitively, skipping remote imports.
+func ListLocalModules(entrypoint string, importMap *ImportMap, fsys afero.Fs) []string {
+	visited := map[string]struct{}{}
+	var result []string
+	queue := []string{entrypoint}
+	for len(queue) > 0 {
+		file := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if _, ok := visited[file]; ok {
+			continue
+		}
+		visited[file] = struct{}{}
+		result = append(result, file)
+		queue = append(queue, resolveImports(file, importMap, fsys)...)
+	}
+	return result
+}
+
+// Parses static and dynamic imports of a module that resolve to local files.
+func resolveImports(file string, importMap *ImportMap, fsys afero.Fs) []string {
+	data, err := afero.ReadFile(fsys, file)
+	if err != nil {
+		return nil
+	}
+	var result []string
+	for _, match := range importPattern.FindAllStringSubmatch(string(data), -1) {
+		specifier := match[1] + match[2]
+		if strings.HasPrefix(specifier, "./") || strings.HasPrefix(specifier, "../") {
+			result = append(result, filepath.Join(filepath.Dir(file), filepath.FromSlash(specifier)))
+		} else if resolved := resolveImportMap(specifier, importMap); isFile(resolved, fsys) {
+			result = append(result, resolved)
+		}
+	}
+	return result
+}
+
+func resolveImportMap(specifier string, importMap *ImportMap) string {
+	if importMap == nil {
+		return ""
+	}
+	if target, ok := importMap.Imports[specifier]; ok {
+		return target
+	}
+	// Longest prefix match for directory mappings
+	var prefix string
+	for key := range importMap.Imports {
+		if strings.HasSuffix(key, "/") && strings.HasPrefix(specifier, key) && len(key) > len(prefix) {
+			prefix = key
+		}
+	}
+	if len(prefix) > 0 {
+		return filepath.Join(importMap.Imports[prefix], filepath.FromSlash(strings.TrimPrefix(specifier, prefix)))
+	}
+	return ""
+}
+
+// Import map targets that don't exist on disk are left unresolved, ie. remote URLs.
+func isFile(path string, fsys afero.Fs) bool {
+	info, err := fsys.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
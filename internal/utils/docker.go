@@ -33,6 +33,7 @@ import (
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/go-errors/errors"
 	"github.com/spf13/viper"
+	"github.com/supabase/cli/pkg/config"
 	"go.opentelemetry.io/otel"
 )
 
@@ -57,6 +58,7 @@ func NewDocker() *client.Client {
 const (
 	DinDHost            = "host.docker.internal"
 	CliProjectLabel     = "com.supabase.cli.project"
+	CliWorkdirLabel     = "com.supabase.cli.workdir"
 	composeProjectLabel = "com.docker.compose.project"
 )
 
@@ -247,7 +249,34 @@ func DockerPullImageIfNotCached(ctx context.Context, imageName string) error {
 	return DockerImagePullWithRetry(ctx, imageUrl, 2)
 }
 
-var suggestDockerInstall = "Docker Desktop is a prerequisite for local development. Follow the official docs to install: https://docs.docker.com/desktop"
+var suggestDockerInstall = dockerRuntime{}.InstallSuggestion()
+
+// Maps local containers to the resource limits of their config section.
+func GetContainerResources(containerName string) config.ContainerResources {
+	switch containerName {
+	case DbId:
+		return Config.Db.Resources
+	case PoolerId:
+		return Config.Db.Pooler.Resources
+	case KongId, RestId:
+		return Config.Api.Resources
+	case RealtimeId:
+		return Config.Realtime.Resources
+	case StudioId, PgmetaId:
+		return Config.Studio.Resources
+	case InbucketId:
+		return Config.Inbucket.Resources
+	case StorageId, ImgProxyId:
+		return Config.Storage.Resources
+	case GotrueId:
+		return Config.Auth.Resources
+	case EdgeRuntimeId:
+		return Config.EdgeRuntime.Resources
+	case LogflareId, VectorId:
+		return Config.Analytics.Resources
+	}
+	return config.ContainerResources{}
+}
 
 func DockerStart(ctx context.Context, config container.Config, hostConfig container.HostConfig, networkingConfig network.NetworkingConfig, containerName string) (string, error) {
 	// Pull container image
@@ -263,7 +292,16 @@ func DockerStart(ctx context.Context, config container.Config, hostConfig contai
 		config.Labels = make(map[string]string, 2)
 	}
 	config.Labels[CliProjectLabel] = Config.ProjectId
+	if len(CurrentDirAbs) > 0 {
+		config.Labels[CliWorkdirLabel] = CurrentDirAbs
+	}
 	config.Labels[composeProjectLabel] = Config.ProjectId
+	// Apply resource limits from config unless set by caller
+	if hostConfig.NanoCPUs == 0 && hostConfig.Memory == 0 {
+		limits := GetContainerResources(containerName)
+		hostConfig.NanoCPUs = int64(limits.Cpus * 1e9)
+		hostConfig.Memory = int64(limits.Memory)
+	}
 	// Configure container network
 	hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, extraHosts...)
 	if networkId := viper.GetString("network-id"); len(networkId) > 0 {
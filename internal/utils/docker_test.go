@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/pkg/config"
 )
 
 const (
@@ -303,3 +304,25 @@ func TestExecOnce(t *testing.T) {
 
 	// TODO: mock tcp hijack
 }
+
+func TestGetContainerResources(t *testing.T) {
+	dbId, pgmetaId := DbId, PgmetaId
+	DbId = "test-db"
+	PgmetaId = "test-pgmeta"
+	Config.Db.Resources.Cpus = 2
+	Config.Studio.Resources.Memory = 1 << 30
+	defer func() {
+		DbId, PgmetaId = dbId, pgmetaId
+		Config.Db.Resources = config.ContainerResources{}
+		Config.Studio.Resources = config.ContainerResources{}
+	}()
+
+	t.Run("maps container to config section", func(t *testing.T) {
+		assert.Equal(t, 2.0, GetContainerResources(DbId).Cpus)
+		assert.EqualValues(t, 1<<30, GetContainerResources(PgmetaId).Memory)
+	})
+
+	t.Run("returns no limits for unknown container", func(t *testing.T) {
+		assert.Zero(t, GetContainerResources("test-shadow-db"))
+	})
+}
@@ -30,22 +30,9 @@ const (
 var DbConfig pgconn.Config
 
 func ParseDatabaseConfig(flagSet *pflag.FlagSet, fsys afero.Fs) error {
-	// Changed flags take precedence over default values
-	var connType connection
-	if flag := flagSet.Lookup("db-url"); flag != nil && flag.Changed {
-		connType = direct
-	} else if flag := flagSet.Lookup("local"); flag != nil && flag.Changed {
-		connType = local
-	} else if flag := flagSet.Lookup("linked"); flag != nil && flag.Changed {
-		connType = linked
-	} else if flag := flagSet.Lookup("proxy"); flag != nil && flag.Changed {
-		connType = proxy
-	} else if value, err := flagSet.GetBool("local"); err == nil && value {
-		connType = local
-	} else if value, err := flagSet.GetBool("linked"); err == nil && value {
-		connType = linked
-	} else if value, err := flagSet.GetBool("proxy"); err == nil && value {
-		connType = proxy
+	connType, err := parseConnectionType(flagSet)
+	if err != nil {
+		return err
 	}
 	// Update connection config
 	switch connType {
@@ -55,8 +42,15 @@ func ParseDatabaseConfig(flagSet *pflag.FlagSet, fsys afero.Fs) error {
 				return err
 			}
 		}
-		if flag := flagSet.Lookup("db-url"); flag != nil {
-			config, err := pgconn.ParseConfig(flag.Value.String())
+		flag := flagSet.Lookup("db-url")
+		if flag != nil || Environment != nil {
+			var dbUrl string
+			if flag != nil && flag.Changed {
+				dbUrl = flag.Value.String()
+			} else if Environment != nil {
+				dbUrl = Environment.DbUrl
+			}
+			config, err := pgconn.ParseConfig(dbUrl)
 			if err != nil {
 				return errors.Errorf("failed to parse connection string: %w", err)
 			}
@@ -73,15 +67,11 @@ func ParseDatabaseConfig(flagSet *pflag.FlagSet, fsys afero.Fs) error {
 		DbConfig.Password = utils.Config.Db.Password
 		DbConfig.Database = "postgres"
 	case linked:
-		if err := utils.LoadConfigFS(fsys); err != nil {
-			return err
-		}
-		projectRef, err := LoadProjectRef(fsys)
-		if err != nil {
+		return ParseLinkedConfig(fsys)
+	case proxy:
+		if err := utils.AssertOnline("connect to the linked project"); err != nil {
 			return err
 		}
-		DbConfig = NewDbConfigWithPassword(projectRef)
-	case proxy:
 		token, err := utils.LoadAccessTokenFS(fsys)
 		if err != nil {
 			return err
@@ -99,6 +89,69 @@ func ParseDatabaseConfig(flagSet *pflag.FlagSet, fsys afero.Fs) error {
 	return nil
 }
 
+func parseConnectionType(flagSet *pflag.FlagSet) (connection, error) {
+	// Changed flags take precedence over default values
+	var connType connection
+	if flag := flagSet.Lookup("db-url"); flag != nil && flag.Changed {
+		connType = direct
+	} else if flag := flagSet.Lookup("local"); flag != nil && flag.Changed {
+		connType = local
+	} else if flag := flagSet.Lookup("linked"); flag != nil && flag.Changed {
+		connType = linked
+	} else if flag := flagSet.Lookup("proxy"); flag != nil && flag.Changed {
+		connType = proxy
+	} else if env := Environment; env != nil && len(env.DbUrl) > 0 && (flagSet.Lookup("db-url") != nil || (env.IsSelfHosted() && flagSet.Lookup("linked") != nil)) {
+		connType = direct
+	} else if IsSelfHosted() && flagSet.Lookup("linked") != nil {
+		return unknown, errors.New("Missing db_url to connect to the database of self-hosted environment.")
+	} else if env := Environment; env != nil && len(env.ProjectId) > 0 && flagSet.Lookup("linked") != nil {
+		// Selected environment takes precedence over the default of targeting local db
+		connType = linked
+	} else if value, err := flagSet.GetBool("local"); err == nil && value {
+		connType = local
+	} else if value, err := flagSet.GetBool("linked"); err == nil && value {
+		connType = linked
+	} else if value, err := flagSet.GetBool("proxy"); err == nil && value {
+		connType = proxy
+	}
+	return connType, nil
+}
+
+// ParseApiConfig is like ParseDatabaseConfig, but loads the linked project without a database
+// password for commands that only call its APIs.
+func ParseApiConfig(flagSet *pflag.FlagSet, fsys afero.Fs) error {
+	connType, err := parseConnectionType(flagSet)
+	if err != nil {
+		return err
+	} else if connType != linked {
+		return ParseDatabaseConfig(flagSet, fsys)
+	}
+	if err := utils.AssertOnline("connect to the linked project"); err != nil {
+		return err
+	}
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	_, err = LoadProjectRef(fsys)
+	return err
+}
+
+// Sets DbConfig to connect to the linked project, prompting for password if necessary.
+func ParseLinkedConfig(fsys afero.Fs) error {
+	if err := utils.AssertOnline("connect to the linked project"); err != nil {
+		return err
+	}
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	projectRef, err := LoadProjectRef(fsys)
+	if err != nil {
+		return err
+	}
+	DbConfig = NewDbConfigWithPassword(projectRef)
+	return nil
+}
+
 func NewDbConfigWithPassword(projectRef string) pgconn.Config {
 	config := getDbConfig(projectRef)
 	config.Password = getPassword(projectRef)
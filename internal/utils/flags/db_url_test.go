@@ -0,0 +1,115 @@
+package flags
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestParseLinkedConfig(t *testing.T) {
+	t.Cleanup(func() {
+		ProjectRef = ""
+		DbConfig = pgconn.Config{}
+	})
+
+	t.Run("loads linked project with password", func(t *testing.T) {
+		viper.Set("DB_PASSWORD", "password")
+		t.Cleanup(func() { viper.Set("DB_PASSWORD", "") })
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		project := apitest.RandomProjectRef()
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(project), 0644))
+		// Run test
+		err := ParseLinkedConfig(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, utils.GetSupabaseDbHost(project), DbConfig.Host)
+		assert.Equal(t, "password", DbConfig.Password)
+	})
+
+	t.Run("throws error in offline mode", func(t *testing.T) {
+		viper.Set("OFFLINE", true)
+		t.Cleanup(func() { viper.Set("OFFLINE", false) })
+		// Run test
+		err := ParseLinkedConfig(afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Cannot connect to the linked project because the")
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Run test
+		err := ParseLinkedConfig(afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("throws error on unlinked project", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := ParseLinkedConfig(fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrNotLinked)
+	})
+
+	t.Run("throws error on invalid project ref", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte("invalid"), 0644))
+		// Run test
+		err := ParseLinkedConfig(fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrInvalidRef)
+	})
+}
+
+func TestParseApiConfig(t *testing.T) {
+	t.Cleanup(func() {
+		ProjectRef = ""
+		DbConfig = pgconn.Config{}
+	})
+
+	t.Run("loads linked project without password", func(t *testing.T) {
+		flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flagSet.Bool("linked", false, "")
+		require.NoError(t, flagSet.Parse([]string{"--linked"}))
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		project := apitest.RandomProjectRef()
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(project), 0644))
+		// Run test
+		err := ParseApiConfig(flagSet, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, project, ProjectRef)
+		assert.Empty(t, DbConfig.Password)
+	})
+
+	t.Run("loads local config", func(t *testing.T) {
+		ProjectRef = ""
+		flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flagSet.Bool("local", true, "")
+		flagSet.Bool("linked", false, "")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := ParseApiConfig(flagSet, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, ProjectRef)
+		assert.Equal(t, utils.Config.Db.Password, DbConfig.Password)
+	})
+}
@@ -0,0 +1,94 @@
+package flags
+
+import (
+	"net/url"
+	"os"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+// Environment is the target declared under [environments.<name>] in config.toml,
+// selected by --env flag or SUPABASE_ENV.
+var Environment *config.Environment
+
+// ParseEnvironment resolves the selected environment before the project ref and database
+// config are parsed, so that its targets apply to every command. Names from SUPABASE_ENV
+// that are not declared in config.toml are ignored, because the same variable also selects
+// which .env files are loaded.
+func ParseEnvironment(flagSet *pflag.FlagSet, fsys afero.Fs) error {
+	name := viper.GetString("ENV")
+	if len(name) == 0 {
+		return nil
+	}
+	explicit := flagSet.Changed("env")
+	// The env file must be set before config.toml is interpolated
+	raw, err := readEnvironments(fsys)
+	if err != nil {
+		if explicit {
+			return err
+		}
+		return nil
+	}
+	env, ok := raw[name]
+	if !ok {
+		if explicit {
+			return errors.Errorf("environment %s is not declared in %s", utils.Aqua(name), utils.Bold(utils.ConfigPath))
+		}
+		return nil
+	}
+	if len(env.EnvFile) > 0 && len(viper.GetString("config-env-file")) == 0 {
+		viper.Set("config-env-file", env.EnvFile)
+	}
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	// Use the interpolated values for connection strings read from env files
+	if env, err = utils.Config.Environments[name].Resolve(); err != nil {
+		return errors.Errorf("failed to resolve [environments.%s]: %w", name, err)
+	}
+	if env.IsSelfHosted() {
+		if len(env.ProjectId) > 0 {
+			return errors.Errorf("api_url and project_id cannot both be set for [environments.%s]", name)
+		}
+		if _, err := url.Parse(env.ApiUrl); err != nil {
+			return errors.Errorf("invalid api_url for [environments.%s]: %w", name, err)
+		}
+	} else if len(env.ProjectId) > 0 {
+		if err := utils.AssertProjectRefIsValid(env.ProjectId); err != nil {
+			return errors.Errorf("invalid project_id for [environments.%s]: %w", name, err)
+		}
+		if len(ProjectRef) == 0 {
+			viper.Set("PROJECT_ID", env.ProjectId)
+		}
+	}
+	Environment = &env
+	return nil
+}
+
+// IsSelfHosted returns true if the selected environment targets a self-hosted deployment.
+func IsSelfHosted() bool {
+	return Environment != nil && Environment.IsSelfHosted()
+}
+
+func readEnvironments(fsys afero.Fs) (map[string]config.Environment, error) {
+	data, err := afero.ReadFile(fsys, utils.ConfigPath)
+	if errors.Is(err, os.ErrNotExist) {
+		utils.SuggestProjectNotFound()
+		return nil, errors.Errorf("failed to read environments: %w", err)
+	} else if err != nil {
+		return nil, errors.Errorf("failed to read environments: %w", err)
+	}
+	var parsed struct {
+		Environments map[string]config.Environment `toml:"environments"`
+	}
+	if _, err := toml.Decode(string(data), &parsed); err != nil {
+		return nil, errors.Errorf("failed to parse environments: %w", err)
+	}
+	return parsed.Environments, nil
+}
@@ -28,6 +28,9 @@ func ParseProjectRef(ctx context.Context, fsys afero.Fs) error {
 	if term.IsTerminal(int(os.Stdin.Fd())) {
 		return PromptProjectRef(ctx, "Select a project:")
 	}
+	if exists, _ := afero.Exists(fsys, utils.ConfigPath); !exists {
+		utils.SuggestProjectNotFound()
+	}
 	return errors.New(utils.ErrNotLinked)
 }
 
@@ -58,8 +61,19 @@ func PromptProjectRef(ctx context.Context, title string) error {
 func LoadProjectRef(fsys afero.Fs) (string, error) {
 	// Env var takes precedence over ref file
 	ProjectRef = viper.GetString("PROJECT_ID")
+	// Followed by default ref of the selected profile
 	if len(ProjectRef) == 0 {
-		projectRefBytes, err := afero.ReadFile(fsys, utils.ProjectRefPath)
+		profile, err := utils.LoadProfile(fsys)
+		if err != nil {
+			return "", err
+		}
+		ProjectRef = profile.ProjectRef
+	}
+	if len(ProjectRef) == 0 {
+		if err := utils.AssertLinkNameIsValid(utils.GetLinkName()); err != nil {
+			return "", err
+		}
+		projectRefBytes, err := afero.ReadFile(fsys, utils.GetProjectRefPath())
 		if errors.Is(err, os.ErrNotExist) {
 			return "", errors.New(utils.ErrNotLinked)
 		} else if err != nil {
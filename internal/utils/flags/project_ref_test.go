@@ -4,11 +4,13 @@ import (
 	"context"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/go-errors/errors"
 	"github.com/h2non/gock"
 	"github.com/spf13/afero"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/supabase/cli/internal/testing/apitest"
@@ -42,6 +44,22 @@ func TestProjectRef(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("loads from named link", func(t *testing.T) {
+		ProjectRef = ""
+		t.Cleanup(func() { viper.Set("LINK_NAME", "") })
+		viper.Set("LINK_NAME", "staging")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		project := apitest.RandomProjectRef()
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(apitest.RandomProjectRef()), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.LinksDir, "staging", "project-ref"), []byte(project), 0644))
+		// Run test
+		err := ParseProjectRef(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, project, ProjectRef)
+	})
+
 	t.Run("throws error on read failure", func(t *testing.T) {
 		ProjectRef = ""
 		// Setup in-memory fs
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/pkg/config"
+)
+
+// HookEnv holds extra variables exposed to hook scripts, such as the migration versions
+// pushed by db push. Commands populate it before their after hook runs.
+var HookEnv = map[string]string{}
+
+// Environment variables available to every hook script.
+const (
+	HookEnvCommand    = "SUPABASE_HOOK_COMMAND"
+	HookEnvStage      = "SUPABASE_HOOK_STAGE"
+	HookEnvArgs       = "SUPABASE_HOOK_ARGS"
+	HookEnvProjectRef = "SUPABASE_PROJECT_REF"
+	HookEnvCliVersion = "SUPABASE_CLI_VERSION"
+)
+
+// RunHook executes the script configured in config.toml for command at the given stage.
+// The script runs through the system shell from the project directory, with its output
+// redirected to stderr so that machine readable output on stdout is not interleaved.
+func RunHook(ctx context.Context, command []string, stage string, args []string, fsys afero.Fs) error {
+	hooks, err := config.LoadHooks("", NewRootFS(fsys))
+	if err != nil {
+		return err
+	}
+	script := hooks.Get(command, stage)
+	if len(script) == 0 {
+		return nil
+	}
+	name := strings.Join(command, " ")
+	fmt.Fprintf(os.Stderr, "Running %s hook for %s: %s\n", stage, Aqua("supabase "+name), script)
+	cmd := newShellCommand(ctx, script)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		HookEnvCommand+"="+name,
+		HookEnvStage+"="+stage,
+		HookEnvArgs+"="+strings.Join(args, " "),
+		HookEnvCliVersion+"="+Version,
+	)
+	for k, v := range HookEnv {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	if err := cmd.Run(); err != nil {
+		return errors.Errorf("%s hook failed for supabase %s: %w", stage, name, err)
+	}
+	return nil
+}
+
+func newShellCommand(ctx context.Context, script string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", script)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", script)
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook scripts use posix shell")
+	}
+
+	t.Run("runs hook with environment", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "out.txt")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, WriteFile(ConfigPath, []byte(`[hooks.db.push]
+after = 'echo "$SUPABASE_HOOK_COMMAND $SUPABASE_HOOK_STAGE $SUPABASE_HOOK_ARGS" > `+out+`'
+`), fsys))
+		// Run test
+		err := RunHook(context.Background(), []string{"db", "push"}, "after", []string{"--linked"}, fsys)
+		// Check output
+		assert.NoError(t, err)
+		data, err := os.ReadFile(out)
+		assert.NoError(t, err)
+		assert.Equal(t, "db push after --linked\n", string(data))
+	})
+
+	t.Run("skips undefined hook", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, WriteFile(ConfigPath, []byte(`[hooks.db.push]
+after = "exit 1"
+`), fsys))
+		// Run test
+		err := RunHook(context.Background(), []string{"db", "push"}, "before", nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on failing hook", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, WriteFile(ConfigPath, []byte(`[hooks.db.push]
+before = "exit 1"
+`), fsys))
+		// Run test
+		err := RunHook(context.Background(), []string{"db", "push"}, "before", nil, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "before hook failed for supabase db push: exit status 1")
+	})
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-errors/errors"
+)
+
+// Exit code of commands stopped by Ctrl+C, following the shell convention of 128 + SIGINT.
+const ExitCodeInterrupted = 130
+
+var (
+	ErrInterrupted = errors.New("Interrupted by user.")
+	interrupted    atomic.Bool
+)
+
+type interruptKey struct{}
+
+// Returns a context that reports IsInterrupted after interrupt is called once, and is
+// cancelled after interrupt is called again.
+func WithInterrupt(ctx context.Context) (context.Context, func()) {
+	stop := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.WithValue(ctx, interruptKey{}, stop))
+	var once sync.Once
+	interrupt := func() {
+		select {
+		case <-stop:
+			cancel()
+		default:
+			once.Do(func() { close(stop) })
+		}
+	}
+	return ctx, interrupt
+}
+
+// Traps Ctrl+C so that long running commands can stop scheduling new requests and flush
+// partial results. Pressing Ctrl+C again aborts in-flight requests.
+func WithGracefulInterrupt(ctx context.Context) context.Context {
+	ctx, interrupt := WithInterrupt(ctx)
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	go func() {
+		defer signal.Stop(sig)
+		for i := 0; i < 2; i++ {
+			select {
+			case <-sig:
+			case <-ctx.Done():
+				return
+			}
+			if i == 0 {
+				fmt.Fprintln(os.Stderr, "Interrupted, finishing in-flight requests. Press Ctrl+C again to abort.")
+			}
+			interrupted.Store(true)
+			interrupt()
+		}
+	}()
+	return ctx
+}
+
+func IsInterrupted(ctx context.Context) bool {
+	stop, ok := ctx.Value(interruptKey{}).(chan struct{})
+	if !ok {
+		return false
+	}
+	select {
+	case <-stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// Returns true if the user pressed Ctrl+C while a graceful command was running.
+func WasInterrupted() bool {
+	return interrupted.Load()
+}
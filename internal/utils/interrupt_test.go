@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithInterrupt(t *testing.T) {
+	ctx, interrupt := WithInterrupt(context.Background())
+	assert.False(t, IsInterrupted(ctx))
+	// First interrupt lets in-flight requests finish
+	interrupt()
+	assert.True(t, IsInterrupted(ctx))
+	assert.NoError(t, ctx.Err())
+	// Second interrupt aborts
+	interrupt()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestIsInterrupted(t *testing.T) {
+	assert.False(t, IsInterrupted(context.Background()))
+}
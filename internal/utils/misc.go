@@ -21,6 +21,8 @@ import (
 var (
 	Version   string
 	SentryDsn string
+	// Base64 encoded ed25519 key for verifying release checksums
+	ReleasePublicKey string
 )
 
 func ShortContainerImageName(imageName string) string {
@@ -59,6 +61,7 @@ var (
 	ProjectHostPattern = regexp.MustCompile(`^(db\.)([a-z]{20})\.supabase\.(co|red)$`)
 	BranchNamePattern  = regexp.MustCompile(`[[:word:]-]+`)
 	FuncSlugPattern    = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+	LinkNamePattern    = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
 	ImageNamePattern   = regexp.MustCompile(`\/(.*):`)
 
 	// These schemas are ignored from db diff and db dump
@@ -131,6 +134,7 @@ var (
 	TempDir               = filepath.Join(SupabaseDirPath, ".temp")
 	ImportMapsDir         = filepath.Join(TempDir, "import_maps")
 	ProjectRefPath        = filepath.Join(TempDir, "project-ref")
+	LinksDir              = filepath.Join(TempDir, "links")
 	PoolerUrlPath         = filepath.Join(TempDir, "pooler-url")
 	PostgresVersionPath   = filepath.Join(TempDir, "postgres-version")
 	GotrueVersionPath     = filepath.Join(TempDir, "gotrue-version")
@@ -149,9 +153,11 @@ var (
 	FallbackEnvFilePath   = filepath.Join(FunctionsDir, ".env")
 	DbTestsDir            = filepath.Join(SupabaseDirPath, "tests")
 	CustomRolesPath       = filepath.Join(SupabaseDirPath, "roles.sql")
+	StorageLifecyclePath  = filepath.Join(SupabaseDirPath, "lifecycle.json")
 
 	ErrNotLinked   = errors.Errorf("Cannot find project ref. Have you run %s?", Aqua("supabase link"))
 	ErrInvalidRef  = errors.New("Invalid project ref format. Must be like `abcdefghijklmnopqrst`.")
+	ErrInvalidLink = errors.New("Invalid link name. Must only include alphanumeric characters, underscores, and hyphens.")
 	ErrInvalidSlug = errors.New("Invalid Function name. Must start with at least one letter, and only include alphanumeric characters, underscores, and hyphens. (^[A-Za-z][A-Za-z0-9_-]*$)")
 	ErrNotRunning  = errors.Errorf("%s is not running.", Aqua("supabase start"))
 )
@@ -218,6 +224,16 @@ func isRootDirectory(cleanPath string) bool {
 	return os.IsPathSeparator(cleanPath[len(cleanPath)-1])
 }
 
+// Sets a suggestion for commands run outside of a project, since config.toml is searched
+// in all parent directories of the original workdir.
+func SuggestProjectNotFound() {
+	cwd := CurrentDirAbs
+	if len(cwd) == 0 {
+		cwd = "current directory"
+	}
+	CmdSuggestion = fmt.Sprintf("Could not find %s in %s or any of its parents. Run %s to set up a project, or pass %s to point at an existing one.", Bold(ConfigPath), cwd, Aqua("supabase init"), Aqua("--workdir"))
+}
+
 func ChangeWorkDir(fsys afero.Fs) error {
 	// Track the original workdir before changing to project root
 	if !filepath.IsAbs(CurrentDirAbs) {
@@ -226,6 +242,10 @@ func ChangeWorkDir(fsys afero.Fs) error {
 			return errors.Errorf("failed to get current directory: %w", err)
 		}
 	}
+	// Env file is specified relative to the original workdir
+	if envFile := viper.GetString("config-env-file"); len(envFile) > 0 && !filepath.IsAbs(envFile) {
+		viper.Set("config-env-file", filepath.Join(CurrentDirAbs, envFile))
+	}
 	workdir := viper.GetString("WORKDIR")
 	if len(workdir) == 0 {
 		workdir = getProjectRoot(CurrentDirAbs, fsys)
@@ -287,6 +307,31 @@ func AssertProjectRefIsValid(projectRef string) error {
 	return nil
 }
 
+// Returns the name of the linked environment selected by --name or SUPABASE_LINK_NAME.
+func GetLinkName() string {
+	return viper.GetString("LINK_NAME")
+}
+
+func AssertLinkNameIsValid(name string) error {
+	if len(name) > 0 && !LinkNamePattern.MatchString(name) {
+		return errors.New(ErrInvalidLink)
+	}
+	return nil
+}
+
+// Named environments store their project ref separately from the default link.
+func GetProjectRefPath() string {
+	return GetLinkedPath(ProjectRefPath)
+}
+
+// Returns the path of a temp file written by link, which is kept separately for each named link.
+func GetLinkedPath(path string) string {
+	if name := GetLinkName(); len(name) > 0 {
+		return filepath.Join(LinksDir, name, filepath.Base(path))
+	}
+	return path
+}
+
 func ValidateFunctionSlug(slug string) error {
 	if !FuncSlugPattern.MatchString(slug) {
 		return errors.New(ErrInvalidSlug)
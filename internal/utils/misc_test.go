@@ -75,3 +75,16 @@ func TestProjectRoot(t *testing.T) {
 		assert.Equal(t, cwd, path)
 	})
 }
+
+func TestSuggestProjectNotFound(t *testing.T) {
+	CurrentDirAbs = filepath.Join(string(filepath.Separator), "home", "user")
+	t.Cleanup(func() {
+		CurrentDirAbs = ""
+		CmdSuggestion = ""
+	})
+	// Run test
+	SuggestProjectNotFound()
+	// Check output
+	assert.Contains(t, CmdSuggestion, "in "+CurrentDirAbs+" or any of its parents")
+	assert.Contains(t, CmdSuggestion, "--workdir")
+}
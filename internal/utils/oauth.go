@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils/credentials"
+)
+
+const (
+	OAuthClientId    = "supabase-cli"
+	OAuthSessionKey  = "oauth-session"
+	oauthTokenPath   = "/v1/oauth/token"
+	oauthTokenPrefix = "sbp_oauth_"
+)
+
+type OAuthToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// Error response defined by https://www.rfc-editor.org/rfc/rfc6749#section-5.2
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *OAuthError) Error() string {
+	if len(e.Description) > 0 {
+		return e.Code + ": " + e.Description
+	}
+	return e.Code
+}
+
+// Refresh tokens are stored separately so that access tokens remain
+// compatible with SUPABASE_ACCESS_TOKEN and older CLI versions.
+type oauthSession struct {
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func PostOAuthForm(ctx context.Context, path string, form url.Values) (*http.Response, error) {
+	form.Set("client_id", OAuthClientId)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, GetSupabaseAPIHost()+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.Errorf("failed to initialise http request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "SupabaseCLI/"+Version)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Errorf("failed to execute http request: %w", err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Errorf("Error status %d: %w", resp.StatusCode, err)
+	}
+	var oauthErr OAuthError
+	if err := json.Unmarshal(data, &oauthErr); err == nil && len(oauthErr.Code) > 0 {
+		return nil, errors.New(&oauthErr)
+	}
+	return nil, errors.Errorf("Error status %d: %s", resp.StatusCode, data)
+}
+
+func RequestOAuthToken(ctx context.Context, form url.Values) (OAuthToken, error) {
+	var token OAuthToken
+	resp, err := PostOAuthForm(ctx, oauthTokenPath, form)
+	if err != nil {
+		return token, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return token, errors.Errorf("failed to parse response body: %w", err)
+	}
+	return token, nil
+}
+
+func SaveOAuthToken(token OAuthToken, fsys afero.Fs) error {
+	if err := SaveAccessToken(token.AccessToken, fsys); err != nil {
+		return err
+	}
+	session := oauthSession{
+		RefreshToken: token.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		return errors.Errorf("failed to encode oauth session: %w", err)
+	}
+	if err := credentials.StoreProvider.Set(profileKey(OAuthSessionKey), string(data)); err == nil {
+		return nil
+	}
+	path, err := getOAuthSessionPath()
+	if err != nil {
+		return err
+	}
+	if err := afero.WriteFile(fsys, path, data, 0600); err != nil {
+		return errors.Errorf("failed to save oauth session file: %w", err)
+	}
+	return nil
+}
+
+func loadOAuthSession(fsys afero.Fs) (oauthSession, error) {
+	var session oauthSession
+	data, err := credentials.StoreProvider.Get(profileKey(OAuthSessionKey))
+	if err != nil {
+		path, err := getOAuthSessionPath()
+		if err != nil {
+			return session, err
+		}
+		contents, err := afero.ReadFile(fsys, path)
+		if err != nil {
+			return session, errors.Errorf("failed to read oauth session file: %w", err)
+		}
+		data = string(contents)
+	}
+	if err := json.Unmarshal([]byte(data), &session); err != nil {
+		return session, errors.Errorf("failed to parse oauth session: %w", err)
+	}
+	return session, nil
+}
+
+// Exchanges the stored refresh token for a new access token when the current
+// one is about to expire. Returns an empty string if no refresh is needed.
+func refreshOAuthToken(ctx context.Context, fsys afero.Fs) (string, error) {
+	session, err := loadOAuthSession(fsys)
+	if err != nil {
+		return "", err
+	}
+	if len(session.RefreshToken) == 0 || time.Until(session.ExpiresAt) > time.Minute {
+		return "", nil
+	}
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", session.RefreshToken)
+	token, err := RequestOAuthToken(ctx, form)
+	if err != nil {
+		return "", err
+	}
+	if err := SaveOAuthToken(token, fsys); err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+func deleteOAuthSession(fsys afero.Fs) {
+	_ = credentials.StoreProvider.Delete(profileKey(OAuthSessionKey))
+	if path, err := getOAuthSessionPath(); err == nil {
+		_ = fsys.Remove(path)
+	}
+}
+
+func getOAuthSessionPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Errorf("failed to get $HOME directory: %w", err)
+	}
+	return filepath.Join(home, ".supabase", profileKey(OAuthSessionKey)), nil
+}
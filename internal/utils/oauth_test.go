@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"github.com/zalando/go-keyring"
+)
+
+func TestRefreshOAuthToken(t *testing.T) {
+	keyring.MockInit()
+	token := OAuthToken{
+		AccessToken:  "sbp_oauth_0123456789abcdef0123456789abcdef01234567",
+		RefreshToken: "new-refresh-token",
+		ExpiresIn:    3600,
+	}
+
+	saveSession := func(t *testing.T, expiresAt time.Time) {
+		data, err := json.Marshal(oauthSession{RefreshToken: "refresh-token", ExpiresAt: expiresAt})
+		require.NoError(t, err)
+		require.NoError(t, credentials.StoreProvider.Set(OAuthSessionKey, string(data)))
+	}
+
+	t.Run("refreshes expired token", func(t *testing.T) {
+		saveSession(t, time.Now())
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(GetSupabaseAPIHost()).
+			Post(oauthTokenPath).
+			BodyString("grant_type=refresh_token").
+			Reply(http.StatusOK).
+			JSON(token)
+		// Run test
+		refreshed, err := refreshOAuthToken(context.Background(), afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, token.AccessToken, refreshed)
+		session, err := loadOAuthSession(afero.NewMemMapFs())
+		assert.NoError(t, err)
+		assert.Equal(t, token.RefreshToken, session.RefreshToken)
+		assert.Empty(t, gock.Pending())
+	})
+
+	t.Run("skips refresh before expiry", func(t *testing.T) {
+		saveSession(t, time.Now().Add(time.Hour))
+		// Run test
+		refreshed, err := refreshOAuthToken(context.Background(), afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, refreshed)
+	})
+
+	t.Run("throws error on invalid grant", func(t *testing.T) {
+		saveSession(t, time.Now())
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(GetSupabaseAPIHost()).
+			Post(oauthTokenPath).
+			Reply(http.StatusBadRequest).
+			JSON(OAuthError{Code: "invalid_grant", Description: "refresh token revoked"})
+		// Run test
+		refreshed, err := refreshOAuthToken(context.Background(), afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "invalid_grant: refresh token revoked")
+		assert.Empty(t, refreshed)
+		assert.Empty(t, gock.Pending())
+	})
+}
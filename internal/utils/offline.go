@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"github.com/go-errors/errors"
+	"github.com/spf13/viper"
+)
+
+func IsOffline() bool {
+	return viper.GetBool("OFFLINE")
+}
+
+// Fails early when an action requires network access but the --offline flag is set.
+func AssertOnline(action string) error {
+	if IsOffline() {
+		return errors.Errorf("Cannot %s because the %s flag is set.", action, Aqua("--offline"))
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssertOnline(t *testing.T) {
+	t.Run("allows network by default", func(t *testing.T) {
+		assert.NoError(t, AssertOnline("call the Management API"))
+	})
+
+	t.Run("throws error in offline mode", func(t *testing.T) {
+		viper.Set("OFFLINE", true)
+		defer viper.Set("OFFLINE", false)
+		// Run test
+		err := AssertOnline("call the Management API")
+		// Check error
+		assert.ErrorContains(t, err, "Cannot call the Management API because the")
+	})
+}
@@ -20,6 +20,8 @@ const (
 
 	// OutputMetadata is used with certain SSO commands only.
 	OutputMetadata = "metadata"
+	// OutputCsv is used with commands that print tabular data only.
+	OutputCsv = "csv"
 )
 
 var (
@@ -78,3 +80,16 @@ func EncodeOutput(format string, w io.Writer, value any) error {
 	}
 	return nil
 }
+
+// Encodes a list of rows, wrapping them in a table for formats that do not
+// support arrays at the top level, ie. toml.
+func EncodeRows[T any](format string, w io.Writer, rows []T) error {
+	if format == OutputToml {
+		return EncodeOutput(format, w, struct {
+			Rows []T `toml:"rows"`
+		}{
+			Rows: rows,
+		})
+	}
+	return EncodeOutput(format, w, rows)
+}
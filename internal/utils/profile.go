@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+)
+
+// Named profiles hold separate access tokens and default project refs, ie.
+// for work and personal accounts. The token of each profile is stored under
+// its own keyring entry.
+type Profile struct {
+	Name       string `json:"name"`
+	ProjectRef string `json:"project_ref,omitempty"`
+}
+
+const profilesFile = "profiles.json"
+
+var (
+	ProfileNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	ErrInvalidProfile  = errors.New("Invalid profile name. Must contain only alphanumeric characters, dashes or underscores.")
+)
+
+// Returns the profile selected by --profile flag or SUPABASE_PROFILE env.
+func GetProfileName() string {
+	return viper.GetString("PROFILE")
+}
+
+// Scopes a credentials key to the selected profile.
+func profileKey(key string) string {
+	if name := GetProfileName(); len(name) > 0 {
+		return key + "-" + name
+	}
+	return key
+}
+
+func LoadProfiles(fsys afero.Fs) ([]Profile, error) {
+	path, err := getProfilesPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := afero.ReadFile(fsys, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to read profiles file: %w", err)
+	}
+	var profiles []Profile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, errors.Errorf("failed to parse profiles file: %w", err)
+	}
+	return profiles, nil
+}
+
+func SaveProfiles(profiles []Profile, fsys afero.Fs) error {
+	path, err := getProfilesPath()
+	if err != nil {
+		return err
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+	data, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return errors.Errorf("failed to encode profiles: %w", err)
+	}
+	if err := MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := afero.WriteFile(fsys, path, data, 0600); err != nil {
+		return errors.Errorf("failed to save profiles file: %w", err)
+	}
+	return nil
+}
+
+// Loads the currently selected profile. Returns an empty profile if none is selected.
+func LoadProfile(fsys afero.Fs) (Profile, error) {
+	name := GetProfileName()
+	if len(name) == 0 {
+		return Profile{}, nil
+	}
+	profiles, err := LoadProfiles(fsys)
+	if err != nil {
+		return Profile{}, err
+	}
+	for _, p := range profiles {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	CmdSuggestion = "Create the profile by running " + Aqua("supabase profiles add "+name)
+	return Profile{}, errors.Errorf("profile not found: %s", name)
+}
+
+func getProfilesPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Errorf("failed to get $HOME directory: %w", err)
+	}
+	return filepath.Join(home, ".supabase", profilesFile), nil
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProfile(t *testing.T) {
+	t.Run("returns empty profile if none selected", func(t *testing.T) {
+		viper.Set("PROFILE", "")
+		// Run test
+		profile, err := LoadProfile(afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, profile)
+		assert.Equal(t, AccessTokenKey, profileKey(AccessTokenKey))
+	})
+
+	t.Run("loads selected profile", func(t *testing.T) {
+		t.Cleanup(func() { viper.Set("PROFILE", "") })
+		viper.Set("PROFILE", "work")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, SaveProfiles([]Profile{
+			{Name: "work", ProjectRef: "abcdefghijklmnopqrst"},
+			{Name: "personal"},
+		}, fsys))
+		// Run test
+		profile, err := LoadProfile(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "abcdefghijklmnopqrst", profile.ProjectRef)
+		assert.Equal(t, AccessTokenKey+"-work", profileKey(AccessTokenKey))
+	})
+
+	t.Run("throws error on missing profile", func(t *testing.T) {
+		t.Cleanup(func() { viper.Set("PROFILE", "") })
+		viper.Set("PROFILE", "missing")
+		// Run test
+		_, err := LoadProfile(afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "profile not found: missing")
+	})
+}
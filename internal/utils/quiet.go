@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// IsQuiet reports whether commands should print only their primary result on stdout.
+//
+// In quiet mode, spinners, progress and prompts are written to stderr. Only the commands
+// that call PrintResult have a stable output, printing their result as one value per line
+// without colors: init, link, projects create, branches create, db baseline, functions new,
+// functions deploy and migration new. Other commands may still write to stdout as before.
+func IsQuiet() bool {
+	return viper.GetBool("QUIET")
+}
+
+// GetHumanWriter returns the writer for messages intended for humans.
+func GetHumanWriter() io.Writer {
+	if IsQuiet() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// PrintResult prints the primary result of a command to stdout in quiet mode only, since
+// the human readable messages already include them otherwise.
+func PrintResult(values ...string) {
+	if !IsQuiet() {
+		return
+	}
+	for _, v := range values {
+		fmt.Fprintln(os.Stdout, v)
+	}
+}
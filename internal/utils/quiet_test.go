@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanWriter(t *testing.T) {
+	t.Run("writes to stdout by default", func(t *testing.T) {
+		assert.Equal(t, os.Stdout, GetHumanWriter())
+	})
+
+	t.Run("writes to stderr in quiet mode", func(t *testing.T) {
+		viper.Set("QUIET", true)
+		t.Cleanup(func() { viper.Set("QUIET", false) })
+		// Run test
+		assert.True(t, IsQuiet())
+		assert.Equal(t, os.Stderr, GetHumanWriter())
+	})
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	IP_FAMILY_AUTO = "auto"
+	IP_FAMILY_V4   = "ipv4"
+	IP_FAMILY_V6   = "ipv6"
+)
+
+var IPFamily = EnumFlag{
+	Allowed: []string{IP_FAMILY_AUTO, IP_FAMILY_V4, IP_FAMILY_V6},
+	Value:   IP_FAMILY_AUTO,
+}
+
+// Resolves the host of a remote database with the configured resolver, keeping only
+// addresses of the selected IP family.
+func LookupDatabaseHost(ctx context.Context, host string) ([]string, error) {
+	addrs, err := lookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	return FilterIPFamily(host, addrs, IPFamily.Value)
+}
+
+func lookupHost(ctx context.Context, host string) ([]string, error) {
+	if net.ParseIP(host) != nil {
+		return []string{host}, nil
+	}
+	if DNSResolver.Value == DNS_OVER_HTTPS {
+		return FallbackLookupIP(ctx, host)
+	}
+	addrs, err := newResolver(viper.GetString("dns-server")).LookupHost(ctx, host)
+	if err != nil {
+		return nil, errors.Errorf("failed to resolve database host: %w", err)
+	}
+	return addrs, nil
+}
+
+// Uses the system resolver unless a DNS server address is given, defaulting to port 53.
+func newResolver(server string) *net.Resolver {
+	if len(server) == 0 {
+		return net.DefaultResolver
+	}
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(strings.Trim(server, "[]"), "53")
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, server)
+		},
+	}
+}
+
+func FilterIPFamily(host string, addrs []string, family string) ([]string, error) {
+	if family == IP_FAMILY_AUTO {
+		return addrs, nil
+	}
+	var result []string
+	for _, a := range addrs {
+		if ip := net.ParseIP(a); ip != nil && (ip.To4() != nil) == (family == IP_FAMILY_V4) {
+			result = append(result, a)
+		}
+	}
+	if len(result) > 0 {
+		return result, nil
+	}
+	if family == IP_FAMILY_V4 {
+		CmdSuggestion = "Connect through the IPv4 compatible connection pooler by running " + Aqua("supabase link") + " or using the pooler connection string from your project's Dashboard."
+	}
+	return nil, errors.Errorf("%s has no %s address: %s", host, family, strings.Join(addrs, ", "))
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterIPFamily(t *testing.T) {
+	addrs := []string{"2600:1f18::1", "127.0.0.1", "2600:1f18::2"}
+
+	t.Run("keeps all addresses by default", func(t *testing.T) {
+		result, err := FilterIPFamily("db.supabase.co", addrs, IP_FAMILY_AUTO)
+		assert.NoError(t, err)
+		assert.Equal(t, addrs, result)
+	})
+
+	t.Run("selects IPv4 addresses", func(t *testing.T) {
+		result, err := FilterIPFamily("db.supabase.co", addrs, IP_FAMILY_V4)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"127.0.0.1"}, result)
+	})
+
+	t.Run("selects IPv6 addresses", func(t *testing.T) {
+		result, err := FilterIPFamily("db.supabase.co", addrs, IP_FAMILY_V6)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2600:1f18::1", "2600:1f18::2"}, result)
+	})
+
+	t.Run("throws error on missing IPv4 address", func(t *testing.T) {
+		t.Cleanup(func() { CmdSuggestion = "" })
+		_, err := FilterIPFamily("db.supabase.co", addrs[:1], IP_FAMILY_V4)
+		assert.ErrorContains(t, err, "db.supabase.co has no ipv4 address: 2600:1f18::1")
+		assert.Contains(t, CmdSuggestion, "connection pooler")
+	})
+}
+
+func TestLookupDatabaseHost(t *testing.T) {
+	t.Run("skips lookup of IP address", func(t *testing.T) {
+		IPFamily.Value = IP_FAMILY_V6
+		t.Cleanup(func() { IPFamily.Value = IP_FAMILY_AUTO })
+		// Run test
+		addrs, err := LookupDatabaseHost(context.Background(), "::1")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"::1"}, addrs)
+	})
+}
+
+func TestNewResolver(t *testing.T) {
+	assert.Equal(t, net.DefaultResolver, newResolver(""))
+	assert.True(t, newResolver("1.1.1.1").PreferGo)
+}
@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/sockets"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+)
+
+const (
+	RuntimeAuto   = "auto"
+	RuntimeDocker = "docker"
+	RuntimePodman = "podman"
+)
+
+var ContainerRuntime = EnumFlag{
+	Allowed: []string{RuntimeAuto, RuntimeDocker, RuntimePodman},
+	Value:   RuntimeAuto,
+}
+
+// Abstracts the differences between container engines. All engines must serve a Docker
+// compatible API, which rules out containerd based runtimes like nerdctl.
+type Runtime interface {
+	Name() string
+	// Returns the API socket to connect to, or empty string to use Docker's defaults.
+	Host(ctx context.Context) (string, error)
+	InstallSuggestion() string
+}
+
+type dockerRuntime struct{}
+
+func (dockerRuntime) Name() string {
+	return RuntimeDocker
+}
+
+func (dockerRuntime) Host(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+func (dockerRuntime) InstallSuggestion() string {
+	return "Docker Desktop is a prerequisite for local development. Follow the official docs to install: https://docs.docker.com/desktop"
+}
+
+type podmanRuntime struct {
+	fsys afero.Fs
+}
+
+func (podmanRuntime) Name() string {
+	return RuntimePodman
+}
+
+func (r podmanRuntime) Host(ctx context.Context) (string, error) {
+	// Same precedence as podman's own remote client
+	if host := os.Getenv("CONTAINER_HOST"); len(host) > 0 {
+		return host, nil
+	}
+	if runtime.GOOS == "linux" {
+		var candidates []string
+		if dir := os.Getenv("XDG_RUNTIME_DIR"); len(dir) > 0 {
+			candidates = append(candidates, filepath.Join(dir, "podman", "podman.sock"))
+		}
+		candidates = append(candidates, "/run/podman/podman.sock")
+		for _, socket := range candidates {
+			if _, err := r.fsys.Stat(socket); err == nil {
+				return "unix://" + socket, nil
+			}
+		}
+		return "", errors.Errorf("podman socket not found: %s", strings.Join(candidates, ", "))
+	}
+	socket, err := inspectMachineSocket(ctx)
+	if err != nil {
+		return "", err
+	}
+	if runtime.GOOS == "windows" {
+		return "npipe://" + filepath.ToSlash(socket), nil
+	}
+	return "unix://" + socket, nil
+}
+
+// Podman runs inside a VM on macOS and Windows, whose socket is only known by inspecting it.
+func usesPodmanMachine() bool {
+	return runtime.GOOS != "linux" && len(os.Getenv("CONTAINER_HOST")) == 0
+}
+
+func inspectMachineSocket(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, "podman", "machine", "inspect", "--format", "{{.ConnectionInfo.PodmanSocket.Path}}").Output()
+	if err != nil {
+		return "", errors.Errorf("failed to inspect podman machine: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Inspecting the podman machine spawns a process, so it's deferred until the first connection
+// instead of slowing down commands that don't use containers.
+var machineSocket = sync.OnceValues(func() (string, error) {
+	return inspectMachineSocket(context.Background())
+})
+
+func dialPodmanMachine(ctx context.Context, _, _ string) (net.Conn, error) {
+	socket, err := machineSocket()
+	if err != nil {
+		return nil, err
+	}
+	if runtime.GOOS == "windows" {
+		return sockets.DialPipe(socket, 32*time.Second)
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "unix", socket)
+}
+
+func (podmanRuntime) InstallSuggestion() string {
+	start := "podman machine start"
+	if runtime.GOOS == "linux" {
+		start = "systemctl --user start podman.socket"
+	}
+	return fmt.Sprintf("Podman is used as the container runtime. Make sure its API socket is running: %s", Aqua(start))
+}
+
+// Resolves the runtime by name, preferring Docker unless only Podman is installed.
+func NewRuntime(name string, fsys afero.Fs) (Runtime, error) {
+	switch name {
+	case RuntimeDocker:
+		return dockerRuntime{}, nil
+	case RuntimePodman:
+		return podmanRuntime{fsys: fsys}, nil
+	case RuntimeAuto, "":
+		if len(os.Getenv("DOCKER_HOST")) > 0 {
+			return dockerRuntime{}, nil
+		}
+		if _, err := exec.LookPath("docker"); err == nil {
+			return dockerRuntime{}, nil
+		}
+		if _, err := exec.LookPath("podman"); err == nil {
+			return podmanRuntime{fsys: fsys}, nil
+		}
+		return dockerRuntime{}, nil
+	}
+	return nil, errors.Errorf("unknown container runtime %q: must be one of %s", name, strings.Join(ContainerRuntime.Allowed, ", "))
+}
+
+// Points the shared Docker client at the selected runtime's API socket.
+func SetupContainerRuntime(ctx context.Context, fsys afero.Fs) error {
+	rt, err := NewRuntime(viper.GetString("container-runtime"), fsys)
+	if err != nil {
+		return err
+	}
+	suggestDockerInstall = rt.InstallSuggestion()
+	if rt.Name() == RuntimePodman && usesPodmanMachine() {
+		return client.WithDialContext(dialPodmanMachine)(Docker)
+	}
+	// Commands that don't start containers should not fail on a missing socket
+	host, err := rt.Host(ctx)
+	if err != nil {
+		fmt.Fprintln(GetDebugLogger(), err)
+		return nil
+	} else if len(host) == 0 {
+		return nil
+	}
+	if err := client.WithHost(host)(Docker); err != nil {
+		return errors.Errorf("failed to connect to %s: %w", rt.Name(), err)
+	}
+	return nil
+}
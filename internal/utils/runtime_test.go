@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRuntime(t *testing.T) {
+	t.Run("selects runtime by name", func(t *testing.T) {
+		rt, err := NewRuntime(RuntimePodman, afero.NewMemMapFs())
+		assert.NoError(t, err)
+		assert.Equal(t, RuntimePodman, rt.Name())
+		rt, err = NewRuntime(RuntimeDocker, afero.NewMemMapFs())
+		assert.NoError(t, err)
+		assert.Equal(t, RuntimeDocker, rt.Name())
+	})
+
+	t.Run("prefers docker host from env", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "unix:///var/run/docker.sock")
+		t.Setenv("PATH", "")
+		rt, err := NewRuntime(RuntimeAuto, afero.NewMemMapFs())
+		assert.NoError(t, err)
+		assert.Equal(t, RuntimeDocker, rt.Name())
+	})
+
+	t.Run("throws error on unknown runtime", func(t *testing.T) {
+		_, err := NewRuntime("nerdctl", afero.NewMemMapFs())
+		assert.ErrorContains(t, err, `unknown container runtime "nerdctl"`)
+	})
+}
+
+func TestPodmanHost(t *testing.T) {
+	t.Run("uses container host from env", func(t *testing.T) {
+		t.Setenv("CONTAINER_HOST", "ssh://core@localhost:53685/run/podman/podman.sock")
+		host, err := podmanRuntime{}.Host(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "ssh://core@localhost:53685/run/podman/podman.sock", host)
+	})
+
+	if runtime.GOOS != "linux" {
+		return
+	}
+
+	t.Run("finds rootless socket", func(t *testing.T) {
+		t.Setenv("CONTAINER_HOST", "")
+		t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+		socket := filepath.Join("/run/user/1000", "podman", "podman.sock")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, socket, nil, 0600))
+		require.NoError(t, afero.WriteFile(fsys, "/run/podman/podman.sock", nil, 0600))
+		// Run test
+		host, err := podmanRuntime{fsys: fsys}.Host(context.Background())
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "unix://"+socket, host)
+	})
+
+	t.Run("throws error on missing socket", func(t *testing.T) {
+		t.Setenv("CONTAINER_HOST", "")
+		t.Setenv("XDG_RUNTIME_DIR", "")
+		// Run test
+		_, err := podmanRuntime{fsys: afero.NewMemMapFs()}.Host(context.Background())
+		// Check error
+		assert.ErrorContains(t, err, "podman socket not found: /run/podman/podman.sock")
+	})
+}
+
+func TestUsesPodmanMachine(t *testing.T) {
+	t.Run("skips machine when container host is set", func(t *testing.T) {
+		t.Setenv("CONTAINER_HOST", "unix:///run/podman/podman.sock")
+		assert.False(t, usesPodmanMachine())
+	})
+
+	t.Run("uses machine outside linux", func(t *testing.T) {
+		t.Setenv("CONTAINER_HOST", "")
+		assert.Equal(t, runtime.GOOS != "linux", usesPodmanMachine())
+	})
+}
@@ -12,12 +12,19 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/reflow/wrap"
+	"github.com/supabase/cli/internal/render"
 	"golang.org/x/term"
 )
 
 func NewProgram(model tea.Model, opts ...tea.ProgramOption) Program {
 	var p Program
-	if term.IsTerminal(int(os.Stdin.Fd())) {
+	if render.SpinnerEnabled() {
+		// Spinners are drawn on stderr to keep stdout clean for scripts
+		opts = append(opts, tea.WithOutput(os.Stderr))
+		// Avoid consuming piped input, which is only read for key presses
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			opts = append(opts, tea.WithInput(nil))
+		}
 		p = tea.NewProgram(model, opts...)
 	} else {
 		p = newFakeProgram(model)
@@ -57,10 +64,10 @@ func (p *fakeProgram) Start() error {
 func (p *fakeProgram) Send(msg tea.Msg) {
 	switch msg := msg.(type) {
 	case StatusMsg:
-		fmt.Println(msg)
+		fmt.Fprintln(GetHumanWriter(), msg)
 	case PsqlMsg:
 		if msg != nil {
-			fmt.Println(*msg)
+			fmt.Fprintln(GetHumanWriter(), *msg)
 		}
 	}
 
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+)
+
+// Header carrying the correlation ID of each CLI invocation, logged by Supabase APIs.
+const TraceHeader = "X-Request-Id"
+
+var (
+	traceOnce sync.Once
+	traceId   string
+	traceSent atomic.Bool
+)
+
+// GetTraceId returns the ID passed via --trace-id, or a random one generated per invocation.
+func GetTraceId() string {
+	traceOnce.Do(func() {
+		if traceId = viper.GetString("trace-id"); len(traceId) == 0 {
+			traceId = uuid.NewString()
+		}
+	})
+	return traceId
+}
+
+// SetTraceHeader is a request editor that tags API requests with the trace ID.
+func SetTraceHeader(req *http.Request) {
+	req.Header.Set(TraceHeader, GetTraceId())
+	traceSent.Store(true)
+}
+
+// WasTraceSent reports whether any API request was tagged with the trace ID, so it is
+// only worth printing on errors when true.
+func WasTraceSent() bool {
+	return traceSent.Load()
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceHeader(t *testing.T) {
+	t.Run("generates random trace id", func(t *testing.T) {
+		traceOnce = sync.Once{}
+		req, err := http.NewRequest(http.MethodGet, DefaultApiHost, nil)
+		assert.NoError(t, err)
+		// Run test
+		SetTraceHeader(req)
+		// Check header
+		id := req.Header.Get(TraceHeader)
+		assert.NoError(t, uuid.Validate(id))
+		assert.Equal(t, id, GetTraceId())
+		assert.True(t, WasTraceSent())
+	})
+
+	t.Run("uses trace id from flag", func(t *testing.T) {
+		traceOnce = sync.Once{}
+		viper.Set("trace-id", "ci-run-42")
+		t.Cleanup(func() { viper.Set("trace-id", "") })
+		req, err := http.NewRequest(http.MethodGet, DefaultApiHost, nil)
+		assert.NoError(t, err)
+		// Run test
+		SetTraceHeader(req)
+		// Check header
+		assert.Equal(t, "ci-run-42", req.Header.Get(TraceHeader))
+	})
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+)
+
+// ConfigureTransport applies custom root certificates and TLS verification settings to the
+// default transport, which all HTTP clients of the CLI either share or clone. Proxies are
+// already resolved from HTTP_PROXY, HTTPS_PROXY and NO_PROXY by the default transport.
+func ConfigureTransport(fsys afero.Fs) error {
+	caCertPath := viper.GetString("ca-cert")
+	insecure := viper.GetBool("insecure-skip-verify")
+	if len(caCertPath) == 0 && !insecure {
+		return nil
+	}
+	t, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if t.TLSClientConfig != nil {
+		config = t.TLSClientConfig.Clone()
+	}
+	if len(caCertPath) > 0 {
+		pem, err := afero.ReadFile(fsys, caCertPath)
+		if err != nil {
+			return errors.Errorf("failed to read CA certificate: %w", err)
+		}
+		// Custom certificates are trusted in addition to the system ones
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			fmt.Fprintln(GetDebugLogger(), err)
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.Errorf("failed to parse CA certificate: %s", caCertPath)
+		}
+		config.RootCAs = pool
+	}
+	if insecure {
+		fmt.Fprintln(os.Stderr, Red("WARNING:"), "TLS certificate verification is disabled by the", Aqua("--insecure-skip-verify"), "flag. Your access token and database credentials may be intercepted.")
+		config.InsecureSkipVerify = true
+	}
+	t.TLSClientConfig = config
+	return nil
+}
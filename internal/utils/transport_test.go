@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	transport := http.DefaultTransport.(*http.Transport)
+	original := transport.TLSClientConfig
+	// Use a fresh client to avoid reusing connections across tests
+	newClient := func() *http.Client {
+		return &http.Client{Transport: transport.Clone()}
+	}
+
+	t.Run("trusts custom CA certificate", func(t *testing.T) {
+		t.Cleanup(func() { transport.TLSClientConfig = original })
+		fsys := afero.NewMemMapFs()
+		cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		require.NoError(t, afero.WriteFile(fsys, "ca.pem", cert, 0644))
+		viper.Set("ca-cert", "ca.pem")
+		t.Cleanup(func() { viper.Set("ca-cert", "") })
+		// Run test
+		err := ConfigureTransport(fsys)
+		// Check error
+		assert.NoError(t, err)
+		resp, err := newClient().Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("skips certificate verification", func(t *testing.T) {
+		t.Cleanup(func() { transport.TLSClientConfig = original })
+		viper.Set("insecure-skip-verify", true)
+		t.Cleanup(func() { viper.Set("insecure-skip-verify", false) })
+		// Run test
+		err := ConfigureTransport(afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		resp, err := newClient().Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("rejects unknown authority by default", func(t *testing.T) {
+		// Run test
+		err := ConfigureTransport(afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		_, err = newClient().Get(server.URL)
+		assert.ErrorContains(t, err, "certificate")
+	})
+
+	t.Run("throws error on invalid certificate", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "ca.pem", []byte("invalid"), 0644))
+		viper.Set("ca-cert", "ca.pem")
+		t.Cleanup(func() { viper.Set("ca-cert", "") })
+		// Run test
+		err := ConfigureTransport(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse CA certificate: ca.pem")
+	})
+}
@@ -0,0 +1,31 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/vault/secrets"
+)
+
+const createSecret = "SELECT vault.create_secret($1, $2, $3)"
+
+func Run(ctx context.Context, name, value, description string, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	var id string
+	if err := conn.QueryRow(ctx, createSecret, value, name, description).Scan(&id); err != nil {
+		return errors.Errorf("failed to create secret: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Created secret", utils.Aqua(name), "with id:", id)
+	fmt.Fprintln(os.Stderr, "Reference it in SQL with:")
+	fmt.Println(secrets.Reference(name))
+	return nil
+}
@@ -0,0 +1,45 @@
+package create
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestCreateSecret(t *testing.T) {
+	t.Run("creates secret in vault", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(createSecret, "s3cret", "stripe_key", "").
+			Reply("SELECT 1", []interface{}{"7f2c3f5e-8a3b-4c47-9d39-2b6e0d1d7c11"})
+		// Run test
+		err := Run(context.Background(), "stripe_key", "s3cret", "", dbConfig, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on duplicate name", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(createSecret, "s3cret", "stripe_key", "").
+			ReplyError(pgerrcode.UniqueViolation, `duplicate key value violates unique constraint "secrets_name_idx"`)
+		// Run test
+		err := Run(context.Background(), "stripe_key", "s3cret", "", dbConfig, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "failed to create secret:")
+	})
+}
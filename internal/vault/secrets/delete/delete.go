@@ -0,0 +1,34 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const deleteSecrets = "DELETE FROM vault.secrets WHERE name = ANY($1)"
+
+func Run(ctx context.Context, names []string, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	msg := fmt.Sprintf("Do you want to delete %d secret(s)?", len(names))
+	if shouldDelete, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldDelete {
+		return errors.New(context.Canceled)
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	tag, err := conn.Exec(ctx, deleteSecrets, names)
+	if err != nil {
+		return errors.Errorf("failed to delete secrets: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Deleted %d secret(s).\n", tag.RowsAffected())
+	return nil
+}
@@ -0,0 +1,67 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/vault/secrets"
+)
+
+type Secret struct {
+	Id          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Reference   string    `json:"reference"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Reads from vault.secrets so that decrypted values are never printed
+const listSecrets = "SELECT id::text, coalesce(name, ''), description, updated_at FROM vault.secrets ORDER BY name"
+
+func Run(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	result, err := ListSecrets(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeRows(utils.OutputFormat.Value, os.Stdout, result)
+	}
+	table := "|NAME|DESCRIPTION|UPDATED AT (UTC)|ID|\n|-|-|-|-|\n"
+	for _, s := range result {
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%s`|\n", s.Name, s.Description, s.UpdatedAt.UTC().Format("2006-01-02 15:04:05"), s.Id)
+	}
+	return list.RenderTable(table)
+}
+
+func ListSecrets(ctx context.Context, conn *pgx.Conn) ([]Secret, error) {
+	rows, err := conn.Query(ctx, listSecrets)
+	if err != nil {
+		return nil, errors.Errorf("failed to list secrets: %w", err)
+	}
+	defer rows.Close()
+	var result []Secret
+	for rows.Next() {
+		var s Secret
+		if err := rows.Scan(&s.Id, &s.Name, &s.Description, &s.UpdatedAt); err != nil {
+			return nil, errors.Errorf("failed to scan secret: %w", err)
+		}
+		s.Reference = secrets.Reference(s.Name)
+		result = append(result, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to list secrets: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,42 @@
+package list
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+func TestListSecrets(t *testing.T) {
+	t.Run("lists secrets with references", func(t *testing.T) {
+		updated := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listSecrets).
+			Reply("SELECT 1", []interface{}{"7f2c3f5e-8a3b-4c47-9d39-2b6e0d1d7c11", "stripe_key", "Stripe API key", updated})
+		// Run test
+		result, err := ListSecrets(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "stripe_key", result[0].Name)
+		assert.Contains(t, result[0].Reference, "name = 'stripe_key'")
+		assert.True(t, updated.Equal(result[0].UpdatedAt))
+	})
+
+	t.Run("throws error on missing extension", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listSecrets).
+			ReplyError(pgerrcode.UndefinedTable, `relation "vault.secrets" does not exist`)
+		// Run test
+		_, err := ListSecrets(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.ErrorContains(t, err, "failed to list secrets:")
+	})
+}
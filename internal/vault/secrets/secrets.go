@@ -0,0 +1,34 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils/credentials"
+)
+
+// Reads secret value from flag, file, or stdin in order of precedence.
+func ReadValue(value, file string, fsys afero.Fs) (string, error) {
+	if len(value) == 0 && len(file) > 0 {
+		data, err := afero.ReadFile(fsys, file)
+		if err != nil {
+			return "", errors.Errorf("failed to read secret file: %w", err)
+		}
+		value = strings.TrimRight(string(data), "\r\n")
+	} else if len(value) == 0 {
+		fmt.Fprint(os.Stderr, "Enter secret value: ")
+		value = strings.TrimRight(credentials.PromptMasked(os.Stdin), "\r\n")
+	}
+	if len(value) == 0 {
+		return "", errors.New("Secret value must not be empty.")
+	}
+	return value, nil
+}
+
+// Returns a SQL expression that reads the decrypted secret by name.
+func Reference(name string) string {
+	return fmt.Sprintf("(select decrypted_secret from vault.decrypted_secrets where name = '%s')", strings.ReplaceAll(name, "'", "''"))
+}
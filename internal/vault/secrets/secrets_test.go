@@ -0,0 +1,47 @@
+package secrets
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadValue(t *testing.T) {
+	t.Run("prefers value from flag", func(t *testing.T) {
+		value, err := ReadValue("s3cret", "missing.txt", afero.NewMemMapFs())
+		assert.NoError(t, err)
+		assert.Equal(t, "s3cret", value)
+	})
+
+	t.Run("reads value from file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "key.txt", []byte("s3cret\n"), 0644))
+		// Run test
+		value, err := ReadValue("", "key.txt", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "s3cret", value)
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		_, err := ReadValue("", "key.txt", afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "failed to read secret file:")
+	})
+
+	t.Run("throws error on empty file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "key.txt", []byte("\n"), 0644))
+		// Run test
+		_, err := ReadValue("", "key.txt", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Secret value must not be empty.")
+	})
+}
+
+func TestReference(t *testing.T) {
+	assert.Equal(t, "(select decrypted_secret from vault.decrypted_secrets where name = 'o''brien')", Reference("o'brien"))
+}
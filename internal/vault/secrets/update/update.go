@@ -0,0 +1,31 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Null arguments leave the existing values unchanged
+const updateSecret = "SELECT vault.update_secret(id, $2, NULL, $3) FROM vault.secrets WHERE name = $1"
+
+func Run(ctx context.Context, name, value string, description *string, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	tag, err := conn.Exec(ctx, updateSecret, name, value, description)
+	if err != nil {
+		return errors.Errorf("failed to update secret: %w", err)
+	} else if tag.RowsAffected() == 0 {
+		return errors.Errorf("secret not found: %s", name)
+	}
+	fmt.Fprintln(os.Stderr, "Updated secret:", utils.Aqua(name))
+	return nil
+}
@@ -5,6 +5,7 @@ package api
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/oapi-codegen/runtime"
 )
@@ -867,6 +868,11 @@ type GetProviderResponse struct {
 	UpdatedAt *string         `json:"updated_at,omitempty"`
 }
 
+// ListProjectAddonsResponse defines model for ListProjectAddonsResponse.
+type ListProjectAddonsResponse struct {
+	SelectedAddons []SelectedProjectAddon `json:"selected_addons"`
+}
+
 // ListProvidersResponse defines model for ListProvidersResponse.
 type ListProvidersResponse struct {
 	Items []Provider `json:"items"`
@@ -983,6 +989,12 @@ type PostgrestConfigWithJWTSecretResponse struct {
 	MaxRows   int     `json:"max_rows"`
 }
 
+// ProjectAddonVariant defines model for ProjectAddonVariant.
+type ProjectAddonVariant struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
 // ProjectUpgradeEligibilityResponse defines model for ProjectUpgradeEligibilityResponse.
 type ProjectUpgradeEligibilityResponse struct {
 	CurrentAppVersion               string           `json:"current_app_version"`
@@ -1026,6 +1038,15 @@ type ReadOnlyStatusResponse struct {
 	OverrideEnabled     bool   `json:"override_enabled"`
 }
 
+// ReadReplicaResponse defines model for ReadReplicaResponse.
+type ReadReplicaResponse struct {
+	DbHost     string  `json:"db_host"`
+	Identifier string  `json:"identifier"`
+	InsertedAt *string `json:"inserted_at,omitempty"`
+	Region     string  `json:"region"`
+	Status     string  `json:"status"`
+}
+
 // RealtimeHealthResponse defines model for RealtimeHealthResponse.
 type RealtimeHealthResponse struct {
 	ConnectedCluster int `json:"connected_cluster"`
@@ -1059,6 +1080,12 @@ type SecretResponse struct {
 	Value string `json:"value"`
 }
 
+// SelectedProjectAddon defines model for SelectedProjectAddon.
+type SelectedProjectAddon struct {
+	Type    string              `json:"type"`
+	Variant ProjectAddonVariant `json:"variant"`
+}
+
 // SetUpReadReplicaBody defines model for SetUpReadReplicaBody.
 type SetUpReadReplicaBody struct {
 	// ReadReplicaRegion Region you want your read replica to reside in
@@ -1518,6 +1545,16 @@ type UpgradeDatabaseBody struct {
 	TargetVersion  string         `json:"target_version"`
 }
 
+// V1AnalyticsResponse defines model for V1AnalyticsResponse.
+type V1AnalyticsResponse struct {
+	Error *struct {
+		Code    *float32 `json:"code,omitempty"`
+		Message *string  `json:"message,omitempty"`
+		Status  *string  `json:"status,omitempty"`
+	} `json:"error,omitempty"`
+	Result *[]map[string]interface{} `json:"result,omitempty"`
+}
+
 // V1Backup defines model for V1Backup.
 type V1Backup struct {
 	InsertedAt       string         `json:"inserted_at"`
@@ -1766,6 +1803,13 @@ type V1AuthorizeUserParamsResponseType string
 // V1AuthorizeUserParamsCodeChallengeMethod defines parameters for V1AuthorizeUser.
 type V1AuthorizeUserParamsCodeChallengeMethod string
 
+// V1GetProjectLogsParams defines parameters for V1GetProjectLogs.
+type V1GetProjectLogsParams struct {
+	Sql               *string    `form:"sql,omitempty" json:"sql,omitempty"`
+	IsoTimestampStart *time.Time `form:"iso_timestamp_start,omitempty" json:"iso_timestamp_start,omitempty"`
+	IsoTimestampEnd   *time.Time `form:"iso_timestamp_end,omitempty" json:"iso_timestamp_end,omitempty"`
+}
+
 // V1CreateAFunctionParams defines parameters for V1CreateAFunction.
 type V1CreateAFunctionParams struct {
 	Slug           *string `form:"slug,omitempty" json:"slug,omitempty"`
@@ -0,0 +1,9 @@
+package auth
+
+import "github.com/supabase/cli/pkg/fetcher"
+
+type AuthAdminAPI struct {
+	*fetcher.Fetcher
+}
+
+const PAGE_LIMIT = 50
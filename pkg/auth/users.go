@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+type AppMetadata struct {
+	Provider  string   `json:"provider,omitempty"`  // "email"
+	Providers []string `json:"providers,omitempty"` // ["email"]
+}
+
+type User struct {
+	Id               string         `json:"id"`                             // "1b1d2b1e-..."
+	Aud              string         `json:"aud"`                            // "authenticated"
+	Role             string         `json:"role"`                           // "authenticated"
+	Email            string         `json:"email"`                          // "user@example.com"
+	Phone            string         `json:"phone"`                          // ""
+	EmailConfirmedAt *time.Time     `json:"email_confirmed_at"`             // "2024-01-01T00:00:00Z"
+	InvitedAt        *time.Time     `json:"invited_at,omitempty"`           // null
+	LastSignInAt     *time.Time     `json:"last_sign_in_at"`                // null
+	BannedUntil      *time.Time     `json:"banned_until,omitempty"`         // null
+	AppMetadata      AppMetadata    `json:"app_metadata"`                   // {"provider": "email"}
+	UserMetadata     map[string]any `json:"user_metadata"`                  // {}
+	CreatedAt        time.Time      `json:"created_at"`                     // "2024-01-01T00:00:00Z"
+	UpdatedAt        time.Time      `json:"updated_at"`                     // "2024-01-01T00:00:00Z"
+	IsAnonymous      bool           `json:"is_anonymous,omitempty"`         // false
+	IsSSOUser        bool           `json:"is_sso_user,omitempty"`          // false
+	DeletedAt        *time.Time     `json:"deleted_at,omitempty"`           // null
+	ConfirmationSent *time.Time     `json:"confirmation_sent_at,omitempty"` // null
+}
+
+type ListUsersResponse struct {
+	Users []User `json:"users"`
+	// Parsed from X-Total-Count header
+	Total int `json:"-"`
+}
+
+func (s *AuthAdminAPI) ListUsers(ctx context.Context, page, perPage uint) (ListUsersResponse, error) {
+	path := fmt.Sprintf("/auth/v1/admin/users?page=%d&per_page=%d", page, perPage)
+	resp, err := s.Send(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return ListUsersResponse{}, err
+	}
+	total, _ := strconv.Atoi(resp.Header.Get("X-Total-Count"))
+	result, err := fetcher.ParseJSON[ListUsersResponse](resp.Body)
+	result.Total = total
+	return result, err
+}
+
+type CreateUserRequest struct {
+	Email        string         `json:"email,omitempty"`         // "user@example.com"
+	Phone        string         `json:"phone,omitempty"`         // "+15555555555"
+	Password     string         `json:"password,omitempty"`      // "secret"
+	EmailConfirm bool           `json:"email_confirm,omitempty"` // true
+	PhoneConfirm bool           `json:"phone_confirm,omitempty"` // true
+	UserMetadata map[string]any `json:"user_metadata,omitempty"` // {}
+	AppMetadata  map[string]any `json:"app_metadata,omitempty"`  // {}
+}
+
+func (s *AuthAdminAPI) CreateUser(ctx context.Context, body CreateUserRequest) (User, error) {
+	resp, err := s.Send(ctx, http.MethodPost, "/auth/v1/admin/users", body)
+	if err != nil {
+		return User{}, err
+	}
+	return fetcher.ParseJSON[User](resp.Body)
+}
+
+type UpdateUserRequest struct {
+	Id           string         `json:"-"`
+	Email        string         `json:"email,omitempty"`         // "user@example.com"
+	Phone        string         `json:"phone,omitempty"`         // "+15555555555"
+	Password     string         `json:"password,omitempty"`      // "secret"
+	EmailConfirm bool           `json:"email_confirm,omitempty"` // true
+	BanDuration  string         `json:"ban_duration,omitempty"`  // "24h" or "none"
+	UserMetadata map[string]any `json:"user_metadata,omitempty"` // {}
+	AppMetadata  map[string]any `json:"app_metadata,omitempty"`  // {}
+}
+
+func (s *AuthAdminAPI) UpdateUser(ctx context.Context, body UpdateUserRequest) (User, error) {
+	resp, err := s.Send(ctx, http.MethodPut, "/auth/v1/admin/users/"+body.Id, body)
+	if err != nil {
+		return User{}, err
+	}
+	return fetcher.ParseJSON[User](resp.Body)
+}
+
+type DeleteUserRequest struct {
+	ShouldSoftDelete bool `json:"should_soft_delete"` // false
+}
+
+func (s *AuthAdminAPI) DeleteUser(ctx context.Context, id string, softDelete bool) error {
+	resp, err := s.Send(ctx, http.MethodDelete, "/auth/v1/admin/users/"+id, DeleteUserRequest{ShouldSoftDelete: softDelete})
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+type InviteUserRequest struct {
+	Email      string         `json:"email"`          // "user@example.com"
+	Data       map[string]any `json:"data,omitempty"` // {}
+	RedirectTo string         `json:"-"`              // "http://localhost:3000"
+}
+
+func (s *AuthAdminAPI) InviteUser(ctx context.Context, body InviteUserRequest) (User, error) {
+	path := "/auth/v1/invite"
+	if len(body.RedirectTo) > 0 {
+		path += "?redirect_to=" + url.QueryEscape(body.RedirectTo)
+	}
+	resp, err := s.Send(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return User{}, err
+	}
+	return fetcher.ParseJSON[User](resp.Body)
+}
@@ -15,10 +15,11 @@ type (
 		ExtraSearchPath []string `toml:"extra_search_path"`
 		MaxRows         uint     `toml:"max_rows"`
 		// Local only config
-		Image     string  `toml:"-"`
-		KongImage string  `toml:"-"`
-		Port      uint16  `toml:"port"`
-		Tls       tlsKong `toml:"tls"`
+		Image     string             `toml:"-"`
+		KongImage string             `toml:"-"`
+		Port      uint16             `toml:"port"`
+		Tls       tlsKong            `toml:"tls"`
+		Resources ContainerResources `toml:"resources"`
 		// TODO: replace [auth|studio].api_url
 		ExternalUrl string `toml:"external_url"`
 	}
@@ -36,7 +36,8 @@ type (
 		AnonKey        string `toml:"-" mapstructure:"anon_key"`
 		ServiceRoleKey string `toml:"-" mapstructure:"service_role_key"`
 
-		ThirdParty thirdParty `toml:"third_party"`
+		ThirdParty thirdParty         `toml:"third_party"`
+		Resources  ContainerResources `toml:"resources"`
 	}
 
 	external map[string]provider
@@ -35,6 +35,9 @@ import (
 	"golang.org/x/mod/semver"
 )
 
+// Incremented whenever config.toml gains a breaking change, so wrapper tools can detect support.
+const SchemaVersion = 1
+
 // Type for turning human-friendly bytes string ("5MB", "32kB") into an int64 during toml decoding.
 type sizeInBytes int64
 
@@ -131,46 +134,82 @@ type (
 		Functions    FunctionConfig `toml:"functions"`
 		Analytics    analytics      `toml:"analytics"`
 		Experimental experimental   `toml:"experimental"`
+		// Pins the image tag of local services, keyed by service name
+		Images map[string]string `toml:"images"`
+		// Scripts to run before or after a command, keyed by command path
+		Hooks Hooks `toml:"hooks"`
 	}
 
 	config struct {
 		baseConfig `mapstructure:",squash"`
 		Overrides  map[string]interface{} `toml:"remotes"`
 		Remotes    map[string]baseConfig  `toml:"-"`
+		// Targets selected by the --env flag, keyed by environment name
+		Environments map[string]Environment `toml:"environments"`
+		// Refuses commands that change state in every environment, ie. for reporting jobs
+		Readonly bool `toml:"readonly"`
+	}
+
+	// Environment declares the remote targeted by commands when selected with --env,
+	// so that switching between staging and production does not require relinking.
+	Environment struct {
+		ProjectId string `toml:"project_id"`
+		DbUrl     string `toml:"db_url"`
+		// Loaded before interpolating config.toml, in addition to .env.<name> files
+		EnvFile string `toml:"env_file"`
+		// Refuses commands that change remote state, ie. for reporting jobs
+		Readonly bool `toml:"readonly"`
+		// Self-hosted deployments are targeted by the URL of their API gateway instead of
+		// project_id. Storage and auth URLs default to the gateway.
+		ApiUrl         string `toml:"api_url"`
+		StorageUrl     string `toml:"storage_url"`
+		AuthUrl        string `toml:"auth_url"`
+		AnonKey        string `toml:"anon_key"`
+		ServiceRoleKey string `toml:"service_role_key"`
+	}
+
+	// Limits the resources of local docker containers, ie. on small CI runners.
+	ContainerResources struct {
+		Cpus   float64     `toml:"cpus"`
+		Memory sizeInBytes `toml:"memory"`
 	}
 
 	realtime struct {
-		Enabled         bool          `toml:"enabled"`
-		Image           string        `toml:"-"`
-		IpVersion       AddressFamily `toml:"ip_version"`
-		MaxHeaderLength uint          `toml:"max_header_length"`
-		TenantId        string        `toml:"-"`
-		EncryptionKey   string        `toml:"-"`
-		SecretKeyBase   string        `toml:"-"`
+		Enabled         bool               `toml:"enabled"`
+		Image           string             `toml:"-"`
+		IpVersion       AddressFamily      `toml:"ip_version"`
+		MaxHeaderLength uint               `toml:"max_header_length"`
+		TenantId        string             `toml:"-"`
+		EncryptionKey   string             `toml:"-"`
+		SecretKeyBase   string             `toml:"-"`
+		Resources       ContainerResources `toml:"resources"`
 	}
 
 	studio struct {
-		Enabled      bool   `toml:"enabled"`
-		Image        string `toml:"-"`
-		Port         uint16 `toml:"port"`
-		ApiUrl       string `toml:"api_url"`
-		OpenaiApiKey string `toml:"openai_api_key"`
-		PgmetaImage  string `toml:"-"`
+		Enabled      bool               `toml:"enabled"`
+		Image        string             `toml:"-"`
+		Port         uint16             `toml:"port"`
+		ApiUrl       string             `toml:"api_url"`
+		OpenaiApiKey string             `toml:"openai_api_key"`
+		PgmetaImage  string             `toml:"-"`
+		Resources    ContainerResources `toml:"resources"`
 	}
 
 	inbucket struct {
-		Enabled  bool   `toml:"enabled"`
-		Image    string `toml:"-"`
-		Port     uint16 `toml:"port"`
-		SmtpPort uint16 `toml:"smtp_port"`
-		Pop3Port uint16 `toml:"pop3_port"`
+		Enabled   bool               `toml:"enabled"`
+		Image     string             `toml:"-"`
+		Port      uint16             `toml:"port"`
+		SmtpPort  uint16             `toml:"smtp_port"`
+		Pop3Port  uint16             `toml:"pop3_port"`
+		Resources ContainerResources `toml:"resources"`
 	}
 
 	edgeRuntime struct {
-		Enabled       bool          `toml:"enabled"`
-		Image         string        `toml:"-"`
-		Policy        RequestPolicy `toml:"policy"`
-		InspectorPort uint16        `toml:"inspector_port"`
+		Enabled       bool               `toml:"enabled"`
+		Image         string             `toml:"-"`
+		Policy        RequestPolicy      `toml:"policy"`
+		InspectorPort uint16             `toml:"inspector_port"`
+		Resources     ContainerResources `toml:"resources"`
 	}
 
 	FunctionConfig map[string]function
@@ -180,18 +219,23 @@ type (
 		VerifyJWT  *bool  `toml:"verify_jwt" json:"verifyJWT"`
 		ImportMap  string `toml:"import_map" json:"importMapPath,omitempty"`
 		Entrypoint string `toml:"entrypoint" json:"entrypointPath,omitempty"`
+		// Glob patterns of non-code assets bundled with the function, ie. templates or WASM
+		StaticFiles []string `toml:"static_files" json:"staticFiles,omitempty"`
+		// Names of secrets the function requires, checked before it is deployed
+		Secrets []string `toml:"secrets" json:"-"`
 	}
 
 	analytics struct {
-		Enabled          bool            `toml:"enabled"`
-		Image            string          `toml:"-"`
-		VectorImage      string          `toml:"-"`
-		Port             uint16          `toml:"port"`
-		Backend          LogflareBackend `toml:"backend"`
-		GcpProjectId     string          `toml:"gcp_project_id"`
-		GcpProjectNumber string          `toml:"gcp_project_number"`
-		GcpJwtPath       string          `toml:"gcp_jwt_path"`
-		ApiKey           string          `toml:"-" mapstructure:"api_key"`
+		Enabled          bool               `toml:"enabled"`
+		Image            string             `toml:"-"`
+		VectorImage      string             `toml:"-"`
+		Port             uint16             `toml:"port"`
+		Backend          LogflareBackend    `toml:"backend"`
+		GcpProjectId     string             `toml:"gcp_project_id"`
+		GcpProjectNumber string             `toml:"gcp_project_number"`
+		GcpJwtPath       string             `toml:"gcp_jwt_path"`
+		ApiKey           string             `toml:"-" mapstructure:"api_key"`
+		Resources        ContainerResources `toml:"resources"`
 		// Deprecated together with syslog
 		VectorPort uint16 `toml:"vector_port"`
 	}
@@ -210,6 +254,19 @@ type (
 	}
 )
 
+// Docker rejects memory limits below 6MB.
+const minMemory = 6 * units.MiB
+
+func (r ContainerResources) validate(name string) error {
+	if r.Cpus < 0 {
+		return errors.Errorf("Invalid config for %s.resources.cpus: must not be negative", name)
+	}
+	if r.Memory < 0 || (r.Memory > 0 && r.Memory < minMemory) {
+		return errors.Errorf("Invalid config for %s.resources.memory: must be at least %s", name, units.BytesSize(minMemory))
+	}
+	return nil
+}
+
 func (f function) IsEnabled() bool {
 	// If Enabled is not defined, or defined and set to true
 	return f.Enabled == nil || *f.Enabled
@@ -231,6 +288,8 @@ func (c *baseConfig) Clone() baseConfig {
 	copy := *c
 	copy.Storage.Buckets = maps.Clone(c.Storage.Buckets)
 	copy.Functions = maps.Clone(c.Functions)
+	copy.Images = maps.Clone(c.Images)
+	copy.Hooks = maps.Clone(c.Hooks)
 	copy.Auth = c.Auth.Clone()
 	if c.Experimental.Webhooks != nil {
 		webhooks := *c.Experimental.Webhooks
@@ -288,6 +347,11 @@ func NewConfig(editors ...ConfigEditor) config {
 				Enabled: true,
 				Image:   imageProxyImage,
 			},
+			Client: storageClient{
+				MaxConnsPerHost:       10,
+				IdleConnTimeout:       90 * time.Second,
+				ResponseHeaderTimeout: time.Minute,
+			},
 		},
 		Auth: auth{
 			Image: gotrueImage,
@@ -411,7 +475,23 @@ func (c *config) Load(path string, fsys fs.FS) error {
 	if _, err := dec.Decode(c); err != nil {
 		return errors.Errorf("failed to decode config template: %w", err)
 	}
-	if metadata, err := toml.DecodeFS(fsys, builder.ConfigPath, c); err != nil {
+	// Load secrets from .env file before interpolating config
+	if err := loadDefaultEnv(); err != nil {
+		return err
+	}
+	contents, err := fs.ReadFile(fsys, builder.ConfigPath)
+	if err != nil {
+		cwd, osErr := os.Getwd()
+		if osErr != nil {
+			cwd = "current directory"
+		}
+		return errors.Errorf("cannot read config in %s: %w", cwd, err)
+	}
+	interpolated, err := interpolateBareValues(string(contents))
+	if err != nil {
+		return err
+	}
+	if metadata, err := toml.Decode(interpolated, c); err != nil {
 		cwd, osErr := os.Getwd()
 		if osErr != nil {
 			cwd = "current directory"
@@ -424,10 +504,10 @@ func (c *config) Load(path string, fsys fs.FS) error {
 			}
 		}
 	}
-	// Load secrets from .env file
-	if err := loadDefaultEnv(); err != nil {
+	if err := interpolateStrings(c); err != nil {
 		return err
-	} else if err := c.loadFromEnv(); err != nil {
+	}
+	if err := c.loadFromEnv(); err != nil {
 		return err
 	}
 	// Generate JWT tokens
@@ -447,8 +527,9 @@ func (c *config) Load(path string, fsys fs.FS) error {
 			c.Auth.ServiceRoleKey = signed
 		}
 	}
+	linkName := viper.GetString("LINK_NAME")
 	// TODO: move linked pooler connection string elsewhere
-	if connString, err := fs.ReadFile(fsys, builder.PoolerUrlPath); err == nil && len(connString) > 0 {
+	if connString, err := fs.ReadFile(fsys, builder.LinkedPath(builder.PoolerUrlPath, linkName)); err == nil && len(connString) > 0 {
 		c.Db.Pooler.ConnectionString = string(connString)
 	}
 	// Update external api url
@@ -462,19 +543,19 @@ func (c *config) Load(path string, fsys fs.FS) error {
 	}
 	c.Api.ExternalUrl = apiUrl.String()
 	// Update image versions
-	if version, err := fs.ReadFile(fsys, builder.PostgresVersionPath); err == nil {
+	if version, err := fs.ReadFile(fsys, builder.LinkedPath(builder.PostgresVersionPath, linkName)); err == nil {
 		if strings.HasPrefix(string(version), "15.") && semver.Compare(string(version[3:]), "1.0.55") >= 0 {
 			c.Db.Image = replaceImageTag(Pg15Image, string(version))
 		}
 	}
 	if c.Db.MajorVersion > 14 {
-		if version, err := fs.ReadFile(fsys, builder.RestVersionPath); err == nil && len(version) > 0 {
+		if version, err := fs.ReadFile(fsys, builder.LinkedPath(builder.RestVersionPath, linkName)); err == nil && len(version) > 0 {
 			c.Api.Image = replaceImageTag(postgrestImage, string(version))
 		}
-		if version, err := fs.ReadFile(fsys, builder.StorageVersionPath); err == nil && len(version) > 0 {
+		if version, err := fs.ReadFile(fsys, builder.LinkedPath(builder.StorageVersionPath, linkName)); err == nil && len(version) > 0 {
 			c.Storage.Image = replaceImageTag(storageImage, string(version))
 		}
-		if version, err := fs.ReadFile(fsys, builder.GotrueVersionPath); err == nil && len(version) > 0 {
+		if version, err := fs.ReadFile(fsys, builder.LinkedPath(builder.GotrueVersionPath, linkName)); err == nil && len(version) > 0 {
 			c.Auth.Image = replaceImageTag(gotrueImage, string(version))
 		}
 	}
@@ -500,6 +581,9 @@ func (c *config) Load(path string, fsys fs.FS) error {
 		}
 		c.Storage.Buckets[name] = bucket
 	}
+	if p := c.Storage.Client.AuditLog; len(p) > 0 && !filepath.IsAbs(p) {
+		c.Storage.Client.AuditLog = filepath.Join(builder.SupabaseDirPath, p)
+	}
 	// Resolve functions config
 	for slug, function := range c.Functions {
 		if len(function.Entrypoint) == 0 {
@@ -521,6 +605,14 @@ func (c *config) Load(path string, fsys fs.FS) error {
 		} else if !filepath.IsAbs(function.ImportMap) {
 			function.ImportMap = filepath.Join(builder.SupabaseDirPath, function.ImportMap)
 		}
+		staticFiles := make([]string, len(function.StaticFiles))
+		for i, pattern := range function.StaticFiles {
+			if !filepath.IsAbs(pattern) {
+				pattern = filepath.Join(builder.SupabaseDirPath, pattern)
+			}
+			staticFiles[i] = pattern
+		}
+		function.StaticFiles = staticFiles
 		c.Functions[slug] = function
 	}
 	if err := c.Db.Seed.loadSeedPaths(builder.SupabaseDirPath, fsys); err != nil {
@@ -569,6 +661,9 @@ func (c *baseConfig) Validate(fsys fs.FS) error {
 		fmt.Fprintln(os.Stderr, "WARN: project_id field in config is invalid. Auto-fixing to", sanitized)
 		c.ProjectId = sanitized
 	}
+	if err := c.Hooks.validate(); err != nil {
+		return err
+	}
 	// Validate api config
 	if c.Api.Enabled {
 		if c.Api.Port == 0 {
@@ -585,6 +680,22 @@ func (c *baseConfig) Validate(fsys fs.FS) error {
 	if c.Db.Port == 0 {
 		return errors.New("Missing required field in config: db.port")
 	}
+	for name, r := range map[string]ContainerResources{
+		"api":          c.Api.Resources,
+		"db":           c.Db.Resources,
+		"db.pooler":    c.Db.Pooler.Resources,
+		"realtime":     c.Realtime.Resources,
+		"studio":       c.Studio.Resources,
+		"inbucket":     c.Inbucket.Resources,
+		"storage":      c.Storage.Resources,
+		"auth":         c.Auth.Resources,
+		"edge_runtime": c.EdgeRuntime.Resources,
+		"analytics":    c.Analytics.Resources,
+	} {
+		if err := r.validate(name); err != nil {
+			return err
+		}
+	}
 	switch c.Db.MajorVersion {
 	case 0:
 		return errors.New("Missing required field in config: db.major_version")
@@ -614,6 +725,9 @@ func (c *baseConfig) Validate(fsys fs.FS) error {
 	default:
 		return errors.Errorf("Failed reading config: Invalid %s: %v.", "db.major_version", c.Db.MajorVersion)
 	}
+	if err := c.applyImageOverrides(); err != nil {
+		return err
+	}
 	// Validate pooler config
 	if c.Db.Pooler.Enabled {
 		allowed := []PoolMode{TransactionMode, SessionMode}
@@ -634,6 +748,9 @@ func (c *baseConfig) Validate(fsys fs.FS) error {
 			return err
 		}
 	}
+	if err := c.Storage.Client.validate(); err != nil {
+		return err
+	}
 	// Validate studio config
 	if c.Studio.Enabled {
 		if c.Studio.Port == 0 {
@@ -723,6 +840,40 @@ func (c *baseConfig) Validate(fsys fs.FS) error {
 	return nil
 }
 
+// Resolve replaces env() references in the connection string, URLs and keys of an environment.
+// It is only called for the selected environment so that others may reference unset variables.
+func (e Environment) Resolve() (Environment, error) {
+	for _, field := range []*string{&e.DbUrl, &e.ApiUrl, &e.StorageUrl, &e.AuthUrl, &e.AnonKey, &e.ServiceRoleKey} {
+		value, err := maybeLoadEnv(*field)
+		if err != nil {
+			return e, err
+		}
+		*field = value
+	}
+	e.ApiUrl = strings.TrimSuffix(e.ApiUrl, "/")
+	e.StorageUrl = strings.TrimSuffix(e.StorageUrl, "/")
+	e.AuthUrl = strings.TrimSuffix(e.AuthUrl, "/")
+	return e, nil
+}
+
+func (e Environment) IsSelfHosted() bool {
+	return len(e.ApiUrl) > 0
+}
+
+func (e Environment) GetStorageUrl() string {
+	if len(e.StorageUrl) > 0 {
+		return e.StorageUrl
+	}
+	return e.ApiUrl
+}
+
+func (e Environment) GetAuthUrl() string {
+	if len(e.AuthUrl) > 0 {
+		return e.AuthUrl
+	}
+	return e.ApiUrl
+}
+
 func maybeLoadEnv(s string) (string, error) {
 	matches := envPattern.FindStringSubmatch(s)
 	if len(matches) == 0 {
@@ -761,6 +912,12 @@ func loadDefaultEnv() error {
 	if env == "" {
 		env = "development"
 	}
+	// Values from an explicit env file take precedence over the defaults
+	if path := viper.GetString("config-env-file"); len(path) > 0 {
+		if err := godotenv.Load(path); err != nil {
+			return errors.Errorf("failed to load %s: %w", path, err)
+		}
+	}
 	filenames := []string{".env." + env + ".local"}
 	if env != "test" {
 		filenames = append(filenames, ".env.local")
@@ -50,15 +50,16 @@ type (
 	}
 
 	db struct {
-		Image        string   `toml:"-"`
-		Port         uint16   `toml:"port"`
-		ShadowPort   uint16   `toml:"shadow_port"`
-		MajorVersion uint     `toml:"major_version"`
-		Password     string   `toml:"-"`
-		RootKey      string   `toml:"-" mapstructure:"root_key"`
-		Pooler       pooler   `toml:"pooler"`
-		Seed         seed     `toml:"seed"`
-		Settings     settings `toml:"settings"`
+		Image        string             `toml:"-"`
+		Port         uint16             `toml:"port"`
+		ShadowPort   uint16             `toml:"shadow_port"`
+		MajorVersion uint               `toml:"major_version"`
+		Password     string             `toml:"-"`
+		RootKey      string             `toml:"-" mapstructure:"root_key"`
+		Pooler       pooler             `toml:"pooler"`
+		Seed         seed               `toml:"seed"`
+		Settings     settings           `toml:"settings"`
+		Resources    ContainerResources `toml:"resources"`
 	}
 
 	seed struct {
@@ -68,16 +69,17 @@ type (
 	}
 
 	pooler struct {
-		Enabled          bool     `toml:"enabled"`
-		Image            string   `toml:"-"`
-		Port             uint16   `toml:"port"`
-		PoolMode         PoolMode `toml:"pool_mode"`
-		DefaultPoolSize  uint     `toml:"default_pool_size"`
-		MaxClientConn    uint     `toml:"max_client_conn"`
-		ConnectionString string   `toml:"-"`
-		TenantId         string   `toml:"-"`
-		EncryptionKey    string   `toml:"-"`
-		SecretKeyBase    string   `toml:"-"`
+		Enabled          bool               `toml:"enabled"`
+		Image            string             `toml:"-"`
+		Port             uint16             `toml:"port"`
+		PoolMode         PoolMode           `toml:"pool_mode"`
+		DefaultPoolSize  uint               `toml:"default_pool_size"`
+		MaxClientConn    uint               `toml:"max_client_conn"`
+		Resources        ContainerResources `toml:"resources"`
+		ConnectionString string             `toml:"-"`
+		TenantId         string             `toml:"-"`
+		EncryptionKey    string             `toml:"-"`
+		SecretKeyBase    string             `toml:"-"`
 	}
 )
 
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-errors/errors"
+)
+
+const (
+	HookBefore = "before"
+	HookAfter  = "after"
+)
+
+// Hooks are shell scripts keyed by command path, ie.
+//
+//	[hooks.db.push]
+//	before = "./scripts/backup.sh"
+type Hooks map[string]interface{}
+
+// Get returns the script to run at stage for the given command path, or empty if undefined.
+func (h Hooks) Get(command []string, stage string) string {
+	node := map[string]interface{}(h)
+	for _, name := range command {
+		child, ok := node[name].(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		node = child
+	}
+	script, _ := node[stage].(string)
+	return script
+}
+
+func (h Hooks) validate() error {
+	return validateHooks(h, nil)
+}
+
+func validateHooks(node map[string]interface{}, path []string) error {
+	keys := make([]string, 0, len(node))
+	for k := range node {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		name := strings.Join(append(path, k), ".")
+		switch v := node[k].(type) {
+		case map[string]interface{}:
+			if err := validateHooks(v, append(path, k)); err != nil {
+				return err
+			}
+		case string:
+			if k != HookBefore && k != HookAfter {
+				return errors.Errorf("Invalid config for hooks.%s: must be one of [ %s | %s ]", name, HookBefore, HookAfter)
+			} else if len(path) == 0 {
+				return errors.Errorf("Invalid config for hooks.%s: missing command name", name)
+			}
+		default:
+			return errors.Errorf("Invalid config for hooks.%s: must be a string", name)
+		}
+	}
+	return nil
+}
+
+// LoadHooks decodes only the hooks table from config.toml so that hooks can run
+// before a command loads the full config. Other tables are skipped, so that errors
+// in them are reported by the commands that use them. Returns nil if the config
+// does not exist.
+func LoadHooks(path string, fsys fs.FS) (Hooks, error) {
+	builder := NewPathBuilder(path)
+	contents, err := fs.ReadFile(fsys, builder.ConfigPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to read config: %w", err)
+	}
+	if err := loadDefaultEnv(); err != nil {
+		return nil, err
+	}
+	var partial struct {
+		Hooks Hooks `toml:"hooks"`
+	}
+	if _, err := toml.Decode(extractKeys(string(contents), isHooksKey), &partial); err != nil {
+		return nil, errors.Errorf("failed to decode config: %w", err)
+	}
+	if err := interpolateStrings(&partial); err != nil {
+		return nil, err
+	}
+	if err := partial.Hooks.validate(); err != nil {
+		return nil, err
+	}
+	return partial.Hooks, nil
+}
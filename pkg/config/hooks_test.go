@@ -0,0 +1,101 @@
+package config
+
+import (
+	"testing"
+	fs "testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadHooks(t *testing.T) {
+	t.Run("looks up hooks by command path", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"config.toml": &fs.MapFile{Data: []byte(`project_id = "test"
+[hooks.db.push]
+before = "./scripts/backup.sh"
+[hooks.functions.deploy]
+after = "echo $SUPABASE_FUNCTION_SLUGS"
+`)},
+		}
+		// Run test
+		hooks, err := LoadHooks("config.toml", fsys)
+		// Check output
+		require.NoError(t, err)
+		assert.Equal(t, "./scripts/backup.sh", hooks.Get([]string{"db", "push"}, HookBefore))
+		assert.Empty(t, hooks.Get([]string{"db", "push"}, HookAfter))
+		assert.Equal(t, "echo $SUPABASE_FUNCTION_SLUGS", hooks.Get([]string{"functions", "deploy"}, HookAfter))
+		assert.Empty(t, hooks.Get([]string{"db"}, HookBefore))
+		assert.Empty(t, hooks.Get([]string{"db", "reset"}, HookBefore))
+	})
+
+	t.Run("ignores errors outside hooks table", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"config.toml": &fs.MapFile{Data: []byte(`hooks.migration.new.after = "./scripts/lint.sh"
+[db]
+port = ${UNSET_DB_PORT}
+[db]
+major_version = 15
+[hooks.db.push]
+before = "./scripts/backup.sh"
+[auth]
+site_url = "${UNSET_SITE_URL}"
+`)},
+		}
+		// Run test
+		hooks, err := LoadHooks("config.toml", fsys)
+		// Check output
+		require.NoError(t, err)
+		assert.Equal(t, "./scripts/backup.sh", hooks.Get([]string{"db", "push"}, HookBefore))
+		assert.Equal(t, "./scripts/lint.sh", hooks.Get([]string{"migration", "new"}, HookAfter))
+	})
+
+	t.Run("throws error on unset variable in hooks", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"config.toml": &fs.MapFile{Data: []byte(`[hooks.db.push]
+before = "${UNSET_BACKUP_SCRIPT}"
+`)},
+		}
+		// Run test
+		_, err := LoadHooks("config.toml", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "environment variables are unset: UNSET_BACKUP_SCRIPT (hooks.db.push.before)")
+	})
+
+	t.Run("ignores missing config", func(t *testing.T) {
+		// Run test
+		hooks, err := LoadHooks("config.toml", fs.MapFS{})
+		// Check output
+		assert.NoError(t, err)
+		assert.Nil(t, hooks)
+	})
+
+	t.Run("throws error on invalid stage", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"config.toml": &fs.MapFile{Data: []byte(`[hooks.db.push]
+during = "./scripts/backup.sh"
+`)},
+		}
+		// Run test
+		_, err := LoadHooks("config.toml", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for hooks.db.push.during: must be one of [ before | after ]")
+	})
+
+	t.Run("throws error on non-string script", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"config.toml": &fs.MapFile{Data: []byte(`[hooks.db.push]
+before = ["./scripts/backup.sh"]
+`)},
+		}
+		// Run test
+		_, err := LoadHooks("config.toml", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for hooks.db.push.before: must be a string")
+	})
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+// A local service image, which may be pinned to a different version via [images] in config.toml.
+type ServiceImage struct {
+	Service string `json:"service"`
+	Image   string `json:"image"`
+	Default string `json:"default"`
+	Pinned  bool   `json:"pinned"`
+	Warning string `json:"warning,omitempty"`
+}
+
+type imageRef struct {
+	ref          *string
+	defaultImage string
+}
+
+// Maps each key under [images] to the image it overrides, together with its bundled default.
+func (c *baseConfig) imageRefs() map[string]imageRef {
+	pgImage := Pg15Image
+	switch c.Db.MajorVersion {
+	case 13:
+		pgImage = pg13Image
+	case 14:
+		pgImage = pg14Image
+	}
+	return map[string]imageRef{
+		"postgres":     {&c.Db.Image, pgImage},
+		"pooler":       {&c.Db.Pooler.Image, supavisorImage},
+		"auth":         {&c.Auth.Image, gotrueImage},
+		"rest":         {&c.Api.Image, postgrestImage},
+		"kong":         {&c.Api.KongImage, kongImage},
+		"realtime":     {&c.Realtime.Image, realtimeImage},
+		"storage":      {&c.Storage.Image, storageImage},
+		"imgproxy":     {&c.Storage.ImageTransformation.Image, imageProxyImage},
+		"edge_runtime": {&c.EdgeRuntime.Image, edgeRuntimeImage},
+		"studio":       {&c.Studio.Image, studioImage},
+		"pgmeta":       {&c.Studio.PgmetaImage, pgmetaImage},
+		"inbucket":     {&c.Inbucket.Image, inbucketImage},
+		"analytics":    {&c.Analytics.Image, logflareImage},
+		"vector":       {&c.Analytics.VectorImage, vectorImage},
+	}
+}
+
+// Replaces the tag of each pinned service image, warning when it is outside the tested range.
+func (c *baseConfig) applyImageOverrides() error {
+	refs := c.imageRefs()
+	for name, tag := range c.Images {
+		r, ok := refs[name]
+		if !ok {
+			return errors.Errorf("Invalid config for images.%s: unknown service. Must be one of: %v", name, c.ImageServices())
+		}
+		if len(strings.TrimSpace(tag)) == 0 {
+			return errors.Errorf("Invalid config for images.%s: version must not be empty", name)
+		}
+		*r.ref = replaceImageTag(*r.ref, tag)
+		if warning := checkTestedRange(imageTag(r.defaultImage), tag); len(warning) > 0 {
+			fmt.Fprintf(os.Stderr, "WARN: pinned %s version %s\n", name, warning)
+		}
+	}
+	return nil
+}
+
+// Returns the sorted keys accepted under [images].
+func (c *baseConfig) ImageServices() []string {
+	var result []string
+	for name := range c.imageRefs() {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Lists the resolved image of every local service, sorted by service name.
+func (c *baseConfig) ListServiceImages() []ServiceImage {
+	refs := c.imageRefs()
+	var result []ServiceImage
+	for _, name := range c.ImageServices() {
+		r := refs[name]
+		image := ServiceImage{
+			Service: name,
+			Image:   *r.ref,
+			Default: r.defaultImage,
+		}
+		if tag, ok := c.Images[name]; ok {
+			image.Pinned = true
+			image.Warning = checkTestedRange(imageTag(r.defaultImage), tag)
+		}
+		result = append(result, image)
+	}
+	return result
+}
+
+func imageTag(image string) string {
+	_, tag, _ := strings.Cut(image, ":")
+	return tag
+}
+
+// Versions within the same major release and no newer than the bundled default are tested
+// together with the CLI. Tags that are not numeric versions are not checked.
+func checkTestedRange(defaultTag, pinnedTag string) string {
+	want, ok := parseVersion(defaultTag)
+	if !ok {
+		return ""
+	}
+	got, ok := parseVersion(pinnedTag)
+	if !ok {
+		return ""
+	}
+	if len(want) > 1 && len(got) > 1 && got[0] != want[0] {
+		return fmt.Sprintf("%s has a different major version than the tested %s", pinnedTag, defaultTag)
+	}
+	if compareVersion(got, want) > 0 {
+		return fmt.Sprintf("%s is newer than the tested %s", pinnedTag, defaultTag)
+	}
+	return ""
+}
+
+// Parses tags like v1.2.3, 15.6.1.139, or 0.28.1-alpine into numeric parts.
+func parseVersion(tag string) ([]int, bool) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "v")
+	tag, _, _ = strings.Cut(tag, "-")
+	var result []int
+	for _, part := range strings.Split(tag, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		result = append(result, n)
+	}
+	return result, true
+}
+
+func compareVersion(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
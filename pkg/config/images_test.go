@@ -0,0 +1,70 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+	fs "testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageOverrides(t *testing.T) {
+	load := func(t *testing.T, extra string) (config, error) {
+		config := NewConfig()
+		var buf bytes.Buffer
+		require.NoError(t, config.Eject(&buf))
+		buf.WriteString(extra)
+		fsys := fs.MapFS{"config.toml": &fs.MapFile{Data: buf.Bytes()}}
+		return config, config.Load("config.toml", fsys)
+	}
+
+	t.Run("pins service image versions", func(t *testing.T) {
+		config, err := load(t, `
+[images]
+storage = "v1.10.0"
+studio = "20240101-abcdef0"
+`)
+		assert.NoError(t, err)
+		assert.Equal(t, "supabase/storage-api:v1.10.0", config.Storage.Image)
+		assert.Equal(t, "supabase/studio:20240101-abcdef0", config.Studio.Image)
+		assert.Equal(t, gotrueImage, config.Auth.Image)
+		images := config.ListServiceImages()
+		assert.Len(t, images, len(config.ImageServices()))
+		for _, image := range images {
+			assert.Equal(t, image.Service == "storage" || image.Service == "studio", image.Pinned, image.Service)
+			assert.Empty(t, image.Warning, image.Service)
+		}
+	})
+
+	t.Run("warns on version outside tested range", func(t *testing.T) {
+		config, err := load(t, `
+[images]
+storage = "v2.0.0"
+`)
+		assert.NoError(t, err)
+		for _, image := range config.ListServiceImages() {
+			if image.Service == "storage" {
+				assert.Equal(t, "v2.0.0 has a different major version than the tested v1.11.13", image.Warning)
+			}
+		}
+	})
+
+	t.Run("throws error on unknown service", func(t *testing.T) {
+		_, err := load(t, `
+[images]
+gotrue = "v2.0.0"
+`)
+		assert.ErrorContains(t, err, "Invalid config for images.gotrue: unknown service.")
+	})
+}
+
+func TestCheckTestedRange(t *testing.T) {
+	assert.Empty(t, checkTestedRange("v1.11.13", "v1.11.0"))
+	assert.Empty(t, checkTestedRange("15.6.1.139", "15.6.1.120"))
+	assert.Equal(t, "v1.12.0 is newer than the tested v1.11.13", checkTestedRange("v1.11.13", "v1.12.0"))
+	assert.Equal(t, "16.1.0 has a different major version than the tested 15.6.1.139", checkTestedRange("15.6.1.139", "16.1.0"))
+	assert.Equal(t, "0.29.0-alpine is newer than the tested 0.28.1-alpine", checkTestedRange("0.28.1-alpine", "0.29.0-alpine"))
+	// Non-numeric tags are not checked
+	assert.Empty(t, checkTestedRange("v1.11.13", "latest"))
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+var (
+	// Matches ${VAR} and ${VAR:-default}, or an escaped $${ that is kept as a literal ${.
+	// Only upper case names are substituted so that templates like ${name} are kept as is.
+	interpolatePattern = regexp.MustCompile(`\$\$\{|\$\{([A-Z_][A-Z0-9_]*)(?::-([^}]*))?\}`)
+	// Matches an unquoted value that is entirely a variable, ie. port = ${API_PORT}
+	bareValuePattern = regexp.MustCompile(`^(\s*[^#=\s][^=]*=\s*)(\$\{[A-Z_][A-Z0-9_]*(?::-[^}]*)?\})(\s*(?:#.*)?)$`)
+	scalarPattern    = regexp.MustCompile(`^([+-]?[0-9][0-9_]*(\.[0-9_]+)?([eE][+-]?[0-9]+)?|true|false)$`)
+)
+
+// Substitutes environment variables in config.toml, so the same file can be shared
+// across machines with different ports and credentials. All unset variables without
+// a default are reported together.
+type interpolator struct {
+	missing []string
+}
+
+func (ip *interpolator) expand(value, location string) string {
+	return interpolatePattern.ReplaceAllStringFunc(value, func(match string) string {
+		if match == "$${" {
+			return "${"
+		}
+		groups := interpolatePattern.FindStringSubmatch(match)
+		if value, ok := os.LookupEnv(groups[1]); ok && len(value) > 0 {
+			return value
+		}
+		if strings.Contains(match, ":-") {
+			return groups[2]
+		}
+		ip.missing = append(ip.missing, fmt.Sprintf("%s (%s)", groups[1], location))
+		return match
+	})
+}
+
+func (ip *interpolator) err() error {
+	if len(ip.missing) == 0 {
+		return nil
+	}
+	return errors.Errorf("failed to interpolate config: environment variables are unset: %s", strings.Join(ip.missing, ", "))
+}
+
+// TOML has no string form for numbers and booleans, so unquoted values are substituted
+// before decoding. Only numbers and booleans are accepted to keep the file well formed.
+func interpolateBareValues(data string) (string, error) {
+	var ip interpolator
+	lines := strings.Split(data, "\n")
+	for i, line := range lines {
+		groups := bareValuePattern.FindStringSubmatch(line)
+		if len(groups) == 0 {
+			continue
+		}
+		location := fmt.Sprintf("line %d", i+1)
+		value := ip.expand(groups[2], location)
+		if value == groups[2] {
+			continue
+		}
+		if !scalarPattern.MatchString(value) {
+			return "", errors.Errorf("failed to interpolate config: %s on %s must be a number or boolean, quote it to substitute a string", groups[2], location)
+		}
+		lines[i] = groups[1] + value + groups[3]
+	}
+	return strings.Join(lines, "\n"), ip.err()
+}
+
+// Strings are substituted after decoding, similar to env(), so that values containing
+// quotes, backslashes or newlines are kept verbatim.
+func interpolateStrings(v interface{}) error {
+	var ip interpolator
+	ip.walk(reflect.ValueOf(v), "")
+	return ip.err()
+}
+
+func (ip *interpolator) walk(v reflect.Value, path string) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			ip.walk(v.Elem(), path)
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		ip.walk(elem, path)
+		if v.CanSet() {
+			v.Set(elem)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+			if name == "-" {
+				continue
+			}
+			// Embedded structs are decoded inline even if their type is unexported
+			if field.Anonymous {
+				ip.walk(v.Field(i), path)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if len(name) == 0 {
+				name = field.Name
+			}
+			ip.walk(v.Field(i), joinPath(path, name))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			ip.walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			// Map values are not addressable, so update a copy instead
+			elem := reflect.New(iter.Value().Type()).Elem()
+			elem.Set(iter.Value())
+			ip.walk(elem, joinPath(path, fmt.Sprint(iter.Key().Interface())))
+			v.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.String:
+		if v.CanSet() && strings.Contains(v.String(), "${") {
+			v.SetString(ip.expand(v.String(), path))
+		}
+	}
+}
+
+func joinPath(path, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"testing"
+	fs "testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolateBareValues(t *testing.T) {
+	t.Run("substitutes numbers and booleans", func(t *testing.T) {
+		t.Setenv("API_PORT", "64321")
+		// Run test
+		result, err := interpolateBareValues(`port = ${API_PORT} # api
+enabled = ${API_ENABLED:-true}
+host = "${SMTP_HOST}"
+# port = ${UNSET_IN_COMMENT}`)
+		// Check output
+		assert.NoError(t, err)
+		assert.Equal(t, `port = 64321 # api
+enabled = true
+host = "${SMTP_HOST}"
+# port = ${UNSET_IN_COMMENT}`, result)
+	})
+
+	t.Run("throws error on unquoted string", func(t *testing.T) {
+		t.Setenv("SMTP_HOST", "smtp.example.com")
+		// Run test
+		_, err := interpolateBareValues(`host = ${SMTP_HOST}`)
+		// Check error
+		assert.ErrorContains(t, err, "${SMTP_HOST} on line 1 must be a number or boolean")
+	})
+
+	t.Run("throws error on unset variables", func(t *testing.T) {
+		// Run test
+		_, err := interpolateBareValues(`[api]
+port = ${API_PORT}`)
+		// Check error
+		assert.ErrorContains(t, err, "environment variables are unset: API_PORT (line 2)")
+	})
+}
+
+func TestInterpolateStrings(t *testing.T) {
+	type provider struct {
+		ClientId string `toml:"client_id"`
+		Secret   string `toml:"secret"`
+	}
+
+	t.Run("substitutes values verbatim", func(t *testing.T) {
+		t.Setenv("SMTP_PASS", `p"a\ss`+"\nword")
+		value := struct {
+			Pass      string              `toml:"pass"`
+			Template  string              `toml:"template"`
+			Literal   string              `toml:"literal"`
+			Redirects []string            `toml:"redirects"`
+			Providers map[string]provider `toml:"providers"`
+			Hooks     Hooks               `toml:"hooks"`
+		}{
+			Pass:      "${SMTP_PASS}",
+			Template:  "Hello ${name}",
+			Literal:   "$${SMTP_PASS}",
+			Redirects: []string{"${SITE_URL:-http://localhost:3000}/callback"},
+			Providers: map[string]provider{"github": {ClientId: "${GITHUB_ID:-abc}"}},
+			Hooks:     Hooks{"db": map[string]interface{}{"push": map[string]interface{}{"before": "${HOOK:-./backup.sh}"}}},
+		}
+		// Run test
+		assert.NoError(t, interpolateStrings(&value))
+		// Check output
+		assert.Equal(t, `p"a\ss`+"\nword", value.Pass)
+		assert.Equal(t, "Hello ${name}", value.Template)
+		assert.Equal(t, "${SMTP_PASS}", value.Literal)
+		assert.Equal(t, []string{"http://localhost:3000/callback"}, value.Redirects)
+		assert.Equal(t, "abc", value.Providers["github"].ClientId)
+		assert.Equal(t, "./backup.sh", value.Hooks.Get([]string{"db", "push"}, HookBefore))
+	})
+
+	t.Run("throws error on unset variables", func(t *testing.T) {
+		value := struct {
+			Providers map[string]provider `toml:"providers"`
+		}{
+			Providers: map[string]provider{"github": {ClientId: "${GITHUB_CLIENT_ID}", Secret: "${GITHUB_SECRET}"}},
+		}
+		// Run test
+		err := interpolateStrings(&value)
+		// Check error
+		assert.ErrorContains(t, err, "environment variables are unset: GITHUB_CLIENT_ID (providers.github.client_id), GITHUB_SECRET (providers.github.secret)")
+	})
+}
+
+func TestLoadInterpolatedConfig(t *testing.T) {
+	config := NewConfig()
+	// Setup in-memory fs
+	fsys := fs.MapFS{
+		"config.toml": &fs.MapFile{Data: []byte(`project_id = "test"
+[api]
+port = ${SUPABASE_TEST_API_PORT}
+[auth]
+site_url = "${SUPABASE_TEST_SITE_URL}"
+`)},
+	}
+	t.Setenv("SUPABASE_TEST_API_PORT", "64321")
+	t.Setenv("SUPABASE_TEST_SITE_URL", `http://localhost:3000/"quoted"`)
+	// Run test
+	require.NoError(t, config.Load("config.toml", fsys))
+	// Check output
+	assert.Equal(t, uint16(64321), config.Api.Port)
+	assert.Equal(t, `http://localhost:3000/"quoted"`, config.Auth.SiteUrl)
+}
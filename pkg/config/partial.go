@@ -0,0 +1,60 @@
+package config
+
+import (
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-errors/errors"
+)
+
+var tableHeaderPattern = regexp.MustCompile(`^\s*\[\[?\s*([^\]]*?)\s*\]\]?\s*(#.*)?$`)
+
+// Blanks out lines of tables and top level keys that don't match, so that settings needed
+// before loading the full config can be decoded without tripping over unrelated errors.
+// Line numbers are kept intact for reporting errors.
+func extractKeys(data string, match func(key string) bool) string {
+	lines := strings.Split(data, "\n")
+	table := ""
+	for i, line := range lines {
+		if groups := tableHeaderPattern.FindStringSubmatch(line); len(groups) > 0 {
+			table = groups[1]
+		}
+		if len(table) > 0 && match(table) {
+			continue
+		}
+		if key, _, found := strings.Cut(line, "="); found && len(table) == 0 && match(strings.TrimSpace(key)) {
+			continue
+		}
+		lines[i] = ""
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isHooksKey(key string) bool {
+	return key == "hooks" || strings.HasPrefix(key, "hooks.")
+}
+
+// LoadReadonly returns true if readonly is set at the top level of config.toml. Returns false
+// if the config does not exist.
+func LoadReadonly(path string, fsys fs.FS) (bool, error) {
+	builder := NewPathBuilder(path)
+	contents, err := fs.ReadFile(fsys, builder.ConfigPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Errorf("failed to read config: %w", err)
+	}
+	var partial struct {
+		Readonly bool `toml:"readonly"`
+	}
+	data := extractKeys(string(contents), func(key string) bool {
+		return key == "readonly"
+	})
+	if _, err := toml.Decode(data, &partial); err != nil {
+		return false, errors.Errorf("failed to decode config: %w", err)
+	}
+	return partial.Readonly, nil
+}
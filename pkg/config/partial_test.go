@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+	fs "testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadReadonly(t *testing.T) {
+	t.Run("loads top level readonly", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"config.toml": &fs.MapFile{Data: []byte(`project_id = "test"
+readonly = true
+[db]
+port = ${UNSET_DB_PORT}
+[db]
+readonly = false
+`)},
+		}
+		// Run test
+		readonly, err := LoadReadonly("config.toml", fsys)
+		// Check output
+		assert.NoError(t, err)
+		assert.True(t, readonly)
+	})
+
+	t.Run("ignores readonly of environments", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"config.toml": &fs.MapFile{Data: []byte(`[environments.production]
+readonly = true
+`)},
+		}
+		// Run test
+		readonly, err := LoadReadonly("config.toml", fsys)
+		// Check output
+		assert.NoError(t, err)
+		assert.False(t, readonly)
+	})
+
+	t.Run("ignores missing config", func(t *testing.T) {
+		// Run test
+		readonly, err := LoadReadonly("config.toml", fs.MapFS{})
+		// Check output
+		assert.NoError(t, err)
+		assert.False(t, readonly)
+	})
+
+	t.Run("throws error on invalid value", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"config.toml": &fs.MapFile{Data: []byte(`readonly = "yes"`)},
+		}
+		// Run test
+		_, err := LoadReadonly("config.toml", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to decode config")
+	})
+}
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"time"
+
+	"github.com/go-errors/errors"
 	v1API "github.com/supabase/cli/pkg/api"
 	"github.com/supabase/cli/pkg/cast"
 	"github.com/supabase/cli/pkg/diff"
@@ -14,6 +17,25 @@ type (
 		S3Credentials       storageS3Credentials `toml:"-"`
 		ImageTransformation imageTransformation  `toml:"image_transformation"`
 		Buckets             BucketConfig         `toml:"buckets"`
+		Client              storageClient        `toml:"client"`
+		Resources           ContainerResources   `toml:"resources"`
+	}
+
+	storageClient struct {
+		MaxConnsPerHost       int           `toml:"max_conns_per_host"`
+		IdleConnTimeout       time.Duration `toml:"idle_conn_timeout"`
+		ResponseHeaderTimeout time.Duration `toml:"response_header_timeout"`
+		// Appends mutating operations as JSON lines when set
+		AuditLog string `toml:"audit_log"`
+		// Tried in order to obtain a storage token before falling back to login
+		Credentials []CredentialProvider `toml:"credentials"`
+	}
+
+	CredentialProvider struct {
+		Type    CredentialType `toml:"type"`
+		Env     string         `toml:"env"`
+		Command string         `toml:"command"`
+		Key     string         `toml:"key"`
 	}
 
 	imageTransformation struct {
@@ -37,6 +59,41 @@ type (
 	}
 )
 
+type CredentialType string
+
+const (
+	CredentialEnv      CredentialType = "env"
+	CredentialCommand  CredentialType = "command"
+	CredentialKeychain CredentialType = "keychain"
+)
+
+func (c *storageClient) validate() error {
+	for i, p := range c.Credentials {
+		var missing string
+		switch p.Type {
+		case CredentialEnv:
+			if len(p.Env) == 0 {
+				missing = "env"
+			}
+		case CredentialCommand:
+			if len(p.Command) == 0 {
+				missing = "command"
+			}
+		case CredentialKeychain:
+			if len(p.Key) == 0 {
+				missing = "key"
+			}
+		default:
+			allowed := []CredentialType{CredentialEnv, CredentialCommand, CredentialKeychain}
+			return errors.Errorf("Invalid config for storage.client.credentials[%d].type. Must be one of: %v", i, allowed)
+		}
+		if len(missing) > 0 {
+			return errors.Errorf("Missing required field in config: storage.client.credentials[%d].%s", i, missing)
+		}
+	}
+	return nil
+}
+
 func (s *storage) ToUpdateStorageConfigBody() v1API.UpdateStorageConfigBody {
 	body := v1API.UpdateStorageConfigBody{Features: &v1API.StorageFeatures{}}
 	body.FileSizeLimit = cast.Ptr(int64(s.FileSizeLimit))
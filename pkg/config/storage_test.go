@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStorageCredentials(t *testing.T) {
+	t.Run("accepts provider chain", func(t *testing.T) {
+		c := storageClient{Credentials: []CredentialProvider{
+			{Type: CredentialEnv, Env: "STORAGE_TOKEN"},
+			{Type: CredentialCommand, Command: "pass show supabase/storage"},
+			{Type: CredentialKeychain, Key: "storage"},
+		}}
+		assert.NoError(t, c.validate())
+	})
+
+	t.Run("throws error on unknown type", func(t *testing.T) {
+		c := storageClient{Credentials: []CredentialProvider{{Type: "oidc"}}}
+		assert.ErrorContains(t, c.validate(), "Invalid config for storage.client.credentials[0].type.")
+	})
+
+	t.Run("throws error on missing field", func(t *testing.T) {
+		c := storageClient{Credentials: []CredentialProvider{{Type: CredentialCommand}}}
+		assert.EqualError(t, c.validate(), "Missing required field in config: storage.client.credentials[0].command")
+	})
+}
@@ -178,3 +178,42 @@ func (u *ConfigUpdater) UpdateExperimentalConfig(ctx context.Context, projectRef
 	}
 	return nil
 }
+
+// Returns a copy of the remote config with all services managed by push
+// overridden by their values on the hosted project.
+func (u *ConfigUpdater) GetRemoteConfig(ctx context.Context, remote baseConfig) (baseConfig, error) {
+	result := remote.Clone()
+	apiConfig, err := u.client.V1GetPostgrestServiceConfigWithResponse(ctx, remote.ProjectId)
+	if err != nil {
+		return result, errors.Errorf("failed to read API config: %w", err)
+	} else if apiConfig.JSON200 == nil {
+		return result, errors.Errorf("unexpected status %d: %s", apiConfig.StatusCode(), string(apiConfig.Body))
+	}
+	result.Api.FromRemoteApiConfig(*apiConfig.JSON200)
+	dbConfig, err := u.client.V1GetPostgresConfigWithResponse(ctx, remote.ProjectId)
+	if err != nil {
+		return result, errors.Errorf("failed to read DB config: %w", err)
+	} else if dbConfig.JSON200 == nil {
+		return result, errors.Errorf("unexpected status %d: %s", dbConfig.StatusCode(), string(dbConfig.Body))
+	}
+	result.Db.Settings.FromRemotePostgresConfig(*dbConfig.JSON200)
+	if result.Auth.Enabled {
+		authConfig, err := u.client.V1GetAuthServiceConfigWithResponse(ctx, remote.ProjectId)
+		if err != nil {
+			return result, errors.Errorf("failed to read Auth config: %w", err)
+		} else if authConfig.JSON200 == nil {
+			return result, errors.Errorf("unexpected status %d: %s", authConfig.StatusCode(), string(authConfig.Body))
+		}
+		result.Auth.FromRemoteAuthConfig(*authConfig.JSON200)
+	}
+	if result.Storage.Enabled {
+		storageConfig, err := u.client.V1GetStorageConfigWithResponse(ctx, remote.ProjectId)
+		if err != nil {
+			return result, errors.Errorf("failed to read Storage config: %w", err)
+		} else if storageConfig.JSON200 == nil {
+			return result, errors.Errorf("unexpected status %d: %s", storageConfig.StatusCode(), string(storageConfig.Body))
+		}
+		result.Storage.FromRemoteStorageConfig(*storageConfig.JSON200)
+	}
+	return result, nil
+}
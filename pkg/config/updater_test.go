@@ -2,6 +2,7 @@ package config
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -350,3 +351,60 @@ func TestUpdateRemoteConfig(t *testing.T) {
 		assert.True(t, gock.IsDone())
 	})
 }
+
+func TestGetRemoteConfig(t *testing.T) {
+	server := "http://localhost"
+	client, err := v1API.NewClientWithResponses(server)
+	require.NoError(t, err)
+
+	t.Run("fetches remote config", func(t *testing.T) {
+		updater := NewConfigUpdater(*client)
+		// Setup mock server
+		defer gock.Off()
+		gock.New(server).
+			Get("/v1/projects/test-project/postgrest").
+			Reply(http.StatusOK).
+			JSON(v1API.PostgrestConfigWithJWTSecretResponse{
+				DbSchema:          "public,graphql_public",
+				DbExtraSearchPath: "public,extensions",
+				MaxRows:           500,
+			})
+		gock.New(server).
+			Get("/v1/projects/test-project/config/database").
+			Reply(http.StatusOK).
+			JSON(v1API.PostgresConfigResponse{
+				MaxConnections: cast.Ptr(100),
+			})
+		gock.New(server).
+			Get("/v1/projects/test-project/config/storage").
+			Reply(http.StatusOK).
+			JSON(v1API.StorageConfigResponse{
+				FileSizeLimit: 100,
+			})
+		// Run test
+		remote := baseConfig{ProjectId: "test-project"}
+		remote.Storage.Enabled = true
+		result, err := updater.GetRemoteConfig(context.Background(), remote)
+		// Check result
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"public", "graphql_public"}, result.Api.Schemas)
+		assert.Equal(t, uint(500), result.Api.MaxRows)
+		assert.Equal(t, cast.Ptr(uint(100)), result.Db.Settings.MaxConnections)
+		assert.Equal(t, sizeInBytes(100), result.Storage.FileSizeLimit)
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("throws error on network failure", func(t *testing.T) {
+		updater := NewConfigUpdater(*client)
+		// Setup mock server
+		defer gock.Off()
+		gock.New(server).
+			Get("/v1/projects/test-project/postgrest").
+			ReplyError(errors.New("network error"))
+		// Run test
+		_, err := updater.GetRemoteConfig(context.Background(), baseConfig{ProjectId: "test-project"})
+		// Check result
+		assert.ErrorContains(t, err, "network error")
+		assert.True(t, gock.IsDone())
+	})
+}
@@ -36,6 +36,14 @@ type pathBuilder struct {
 	CustomRolesPath       string
 }
 
+// Returns the path of a temp file written by link, which is kept separately for each named link.
+func (b pathBuilder) LinkedPath(path, linkName string) string {
+	if len(linkName) == 0 {
+		return path
+	}
+	return filepath.Join(b.TempDir, "links", linkName, filepath.Base(path))
+}
+
 func NewPathBuilder(configPath string) pathBuilder {
 	if filepath.Base(configPath) == "." {
 		configPath = filepath.Join("supabase", "config.toml")
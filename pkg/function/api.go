@@ -17,6 +17,12 @@ type EszipBundler interface {
 	Bundle(ctx context.Context, entrypoint string, importMap string, output io.Writer) error
 }
 
+// StaticFilesBundler is implemented by bundlers that can embed static files, declared in
+// config as glob patterns, together with the function code.
+type StaticFilesBundler interface {
+	BundleWithStaticFiles(ctx context.Context, entrypoint string, importMap string, staticFiles []string, output io.Writer) error
+}
+
 func NewEdgeRuntimeAPI(project string, client api.ClientWithResponses, bundler EszipBundler) EdgeRuntimeAPI {
 	return EdgeRuntimeAPI{client: client, project: project, eszip: bundler}
 }
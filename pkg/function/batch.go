@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -45,7 +46,7 @@ func (s *EdgeRuntimeAPI) UpsertFunctions(ctx context.Context, functionConfig con
 			}
 		}
 		var body bytes.Buffer
-		if err := s.eszip.Bundle(ctx, function.Entrypoint, function.ImportMap, &body); err != nil {
+		if err := s.bundle(ctx, function.Entrypoint, function.ImportMap, function.StaticFiles, &body); err != nil {
 			return err
 		}
 		// Update if function already exists
@@ -101,3 +102,15 @@ func toUnixPath(absHostPath string) string {
 	unixPath := filepath.ToSlash(absHostPath)
 	return strings.TrimPrefix(unixPath, prefix)
 }
+
+// Falls back to bundling without static files when none are declared, so that bundlers
+// implementing only EszipBundler keep working.
+func (s *EdgeRuntimeAPI) bundle(ctx context.Context, entrypoint, importMap string, staticFiles []string, output io.Writer) error {
+	if len(staticFiles) == 0 {
+		return s.eszip.Bundle(ctx, entrypoint, importMap, output)
+	}
+	if b, ok := s.eszip.(StaticFilesBundler); ok {
+		return b.BundleWithStaticFiles(ctx, entrypoint, importMap, staticFiles, output)
+	}
+	return errors.Errorf("bundler does not support static files: %s", entrypoint)
+}
@@ -104,4 +104,18 @@ func TestUpsertFunctions(t *testing.T) {
 		// Check error
 		assert.NoError(t, err)
 	})
+	t.Run("throws error on unsupported static files", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockApiHost).
+			Get("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{})
+		// Run test
+		err := client.UpsertFunctions(context.Background(), config.FunctionConfig{
+			"test": {Entrypoint: "test/index.ts", StaticFiles: []string{"test/*.html"}},
+		})
+		// Check error
+		assert.ErrorContains(t, err, "bundler does not support static files: test/index.ts")
+	})
 }
@@ -29,6 +29,10 @@ func NewNativeBundler(tempDir string, fsys fs.FS) EszipBundler {
 var edgeRuntimeBin = "edge-runtime"
 
 func (b *nativeBundler) Bundle(ctx context.Context, entrypoint string, importMap string, output io.Writer) error {
+	return b.BundleWithStaticFiles(ctx, entrypoint, importMap, nil, output)
+}
+
+func (b *nativeBundler) BundleWithStaticFiles(ctx context.Context, entrypoint string, importMap string, staticFiles []string, output io.Writer) error {
 	slug := filepath.Base(filepath.Dir(entrypoint))
 	outputPath := filepath.Join(b.tempDir, slug+".eszip")
 	// TODO: make edge runtime write to stdout
@@ -36,6 +40,10 @@ func (b *nativeBundler) Bundle(ctx context.Context, entrypoint string, importMap
 	if len(importMap) > 0 {
 		args = append(args, "--import-map", importMap)
 	}
+	// Edge runtime expands glob patterns of static files
+	for _, pattern := range staticFiles {
+		args = append(args, "--static", pattern)
+	}
 	cmd := exec.CommandContext(ctx, edgeRuntimeBin, args...)
 	cmd.Stderr = os.Stderr
 	cmd.Stdout = os.Stdout
@@ -6,6 +6,8 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"time"
 
 	"github.com/go-errors/errors"
 	"github.com/jackc/pgx/v4"
@@ -53,20 +55,51 @@ func FindPendingMigrations(localMigrations, remoteMigrations []string) ([]string
 	return pending, nil
 }
 
-func ApplyMigrations(ctx context.Context, pending []string, conn *pgx.Conn, fsys fs.FS) error {
+type ApplyOptions struct {
+	// Versions of migrations applied statement by statement outside of a transaction
+	NoTransaction []string
+}
+
+// WithNoTransaction applies the given versions without a transaction, for statements like
+// CREATE INDEX CONCURRENTLY that cannot run inside a transaction block.
+func WithNoTransaction(versions ...string) func(*ApplyOptions) {
+	return func(o *ApplyOptions) {
+		o.NoTransaction = append(o.NoTransaction, versions...)
+	}
+}
+
+func ApplyMigrations(ctx context.Context, pending []string, conn *pgx.Conn, fsys fs.FS, options ...func(*ApplyOptions)) error {
+	var opts ApplyOptions
+	for _, apply := range options {
+		apply(&opts)
+	}
 	if len(pending) > 0 {
 		if err := CreateMigrationTable(ctx, conn); err != nil {
 			return err
 		}
 	}
+	start := time.Now()
 	for _, path := range pending {
 		filename := filepath.Base(path)
-		fmt.Fprintf(os.Stderr, "Applying migration %s...\n", filename)
-		if migration, err := NewMigrationFromFile(path, fsys); err != nil {
+		migration, err := NewMigrationFromFile(path, fsys)
+		if err != nil {
 			return err
-		} else if err := migration.ExecBatch(ctx, conn); err != nil {
+		}
+		begin := time.Now()
+		if slices.Contains(opts.NoTransaction, migration.Version) {
+			fmt.Fprintf(os.Stderr, "Applying migration %s without transaction...\n", filename)
+			err = migration.ExecEach(ctx, conn)
+		} else {
+			fmt.Fprintf(os.Stderr, "Applying migration %s...\n", filename)
+			err = migration.ExecBatch(ctx, conn)
+		}
+		if err != nil {
 			return err
 		}
+		fmt.Fprintf(os.Stderr, "Applied %d statements in %s\n", len(migration.Statements), time.Since(begin).Round(time.Millisecond))
+	}
+	if len(pending) > 1 {
+		fmt.Fprintf(os.Stderr, "Applied %d migrations in %s\n", len(pending), time.Since(start).Round(time.Millisecond))
 	}
 	return nil
 }
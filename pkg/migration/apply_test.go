@@ -118,6 +118,21 @@ func TestApplyMigrations(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("applies selected versions without transaction", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		mockMigrationHistory(conn).
+			Query(testSchema).
+			Reply("CREATE SCHEMA").
+			Query(INSERT_MIGRATION_VERSION, "0", "schema", []string{testSchema}).
+			Reply("INSERT 0 1")
+		// Run test
+		err := ApplyMigrations(context.Background(), pending, conn.MockClient(t), testMigrations, WithNoTransaction("0"))
+		// Check error
+		assert.NoError(t, err)
+	})
+
 	t.Run("throws error on history failure", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := fs.MapFS{}
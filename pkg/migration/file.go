@@ -8,6 +8,7 @@ import (
 	"io/fs"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/jackc/pgconn"
@@ -21,16 +22,24 @@ type MigrationFile struct {
 	Version    string
 	Name       string
 	Statements []string
+	// Reverts this migration, parsed from statements after the down marker. It is not
+	// recorded in the history table.
+	DownStatements []string `db:"-"`
 }
 
 var migrateFilePattern = regexp.MustCompile(`^([0-9]+)_(.*)\.sql$`)
 
+// Statements after a line with this comment are only run by `migration down`. The marker
+// is only recognised between statements, so it may still appear inside function bodies.
+const DownMarker = "-- migrate:down"
+
 func NewMigrationFromFile(path string, fsys fs.FS) (*MigrationFile, error) {
 	lines, err := parseFile(path, fsys)
 	if err != nil {
 		return nil, err
 	}
-	file := MigrationFile{Statements: lines}
+	up, down := splitDownSection(lines)
+	file := MigrationFile{Statements: up, DownStatements: down}
 	// Parse version from file name
 	filename := filepath.Base(path)
 	matches := migrateFilePattern.FindStringSubmatch(filename)
@@ -58,6 +67,29 @@ func parseFile(path string, fsys fs.FS) ([]string, error) {
 	return parser.SplitAndTrim(sql)
 }
 
+// Comments are kept by the parser, so a marker between statements is found among the
+// leading comments of the next statement. Lines after the start of a statement, ie. in
+// dollar quoted bodies, are never matched.
+func splitDownSection(stats []string) ([]string, []string) {
+	for i, stat := range stats {
+		lines := strings.Split(stat, "\n")
+		for j, line := range lines {
+			trimmed := strings.TrimSpace(line)
+			if strings.EqualFold(trimmed, DownMarker) {
+				var down []string
+				if after := strings.TrimSpace(strings.Join(lines[j+1:], "\n")); len(after) > 0 {
+					down = append(down, after)
+				}
+				return stats[:i:i], append(down, stats[i+1:]...)
+			}
+			if len(trimmed) > 0 && !strings.HasPrefix(trimmed, "--") {
+				break
+			}
+		}
+	}
+	return stats, nil
+}
+
 func NewMigrationFromReader(sql io.Reader) (*MigrationFile, error) {
 	lines, err := parser.SplitAndTrim(sql)
 	if err != nil {
@@ -91,10 +123,68 @@ func (m *MigrationFile) ExecBatch(ctx context.Context, conn *pgx.Conn) error {
 	return nil
 }
 
+// ExecEach runs every statement in its own implicit transaction before recording the version.
+// Statements that succeeded are not rolled back when a later statement fails.
+func (m *MigrationFile) ExecEach(ctx context.Context, conn *pgx.Conn) error {
+	for i, line := range m.Statements {
+		if _, err := conn.PgConn().ExecParams(ctx, line, nil, nil, nil, nil).Close(); err != nil {
+			return errors.Errorf("%w\nAt statement %d: %s", err, i, line)
+		}
+	}
+	if len(m.Version) == 0 {
+		return nil
+	}
+	batch := &pgconn.Batch{}
+	if err := m.insertVersionSQL(conn, batch); err != nil {
+		return err
+	}
+	if _, err := conn.PgConn().ExecBatch(ctx, batch).ReadAll(); err != nil {
+		return errors.Errorf("%w\nAt statement %d: %s", err, len(m.Statements), INSERT_MIGRATION_VERSION)
+	}
+	return nil
+}
+
+// ExecDownBatch reverts this migration and removes its version from history in a single transaction.
+func (m *MigrationFile) ExecDownBatch(ctx context.Context, conn *pgx.Conn) error {
+	batch := &pgconn.Batch{}
+	for _, line := range m.DownStatements {
+		batch.ExecParams(line, nil, nil, nil, nil)
+	}
+	encoded, valueFormat, err := encodeTextArray(conn, []string{m.Version})
+	if err != nil {
+		return err
+	}
+	batch.ExecParams(DELETE_MIGRATION_VERSION, [][]byte{encoded}, []uint32{pgtype.TextArrayOID}, []int16{valueFormat}, nil)
+	if result, err := conn.PgConn().ExecBatch(ctx, batch).ReadAll(); err != nil {
+		stat := DELETE_MIGRATION_VERSION
+		i := len(result)
+		if i < len(m.DownStatements) {
+			stat = m.DownStatements[i]
+		}
+		return errors.Errorf("%w\nAt statement %d: %s", err, i, stat)
+	}
+	return nil
+}
+
 func (m *MigrationFile) insertVersionSQL(conn *pgx.Conn, batch *pgconn.Batch) error {
+	encoded, valueFormat, err := encodeTextArray(conn, m.Statements)
+	if err != nil {
+		return err
+	}
+	batch.ExecParams(
+		INSERT_MIGRATION_VERSION,
+		[][]byte{[]byte(m.Version), []byte(m.Name), encoded},
+		[]uint32{pgtype.TextOID, pgtype.TextOID, pgtype.TextArrayOID},
+		[]int16{pgtype.TextFormatCode, pgtype.TextFormatCode, valueFormat},
+		nil,
+	)
+	return nil
+}
+
+func encodeTextArray(conn *pgx.Conn, values []string) ([]byte, int16, error) {
 	value := pgtype.TextArray{}
-	if err := value.Set(m.Statements); err != nil {
-		return errors.Errorf("failed to set text array: %w", err)
+	if err := value.Set(values); err != nil {
+		return nil, 0, errors.Errorf("failed to set text array: %w", err)
 	}
 	ci := conn.ConnInfo()
 	var err error
@@ -108,16 +198,9 @@ func (m *MigrationFile) insertVersionSQL(conn *pgx.Conn, batch *pgconn.Batch) er
 		valueFormat = pgtype.BinaryFormatCode
 	}
 	if err != nil {
-		return errors.Errorf("failed to encode binary: %w", err)
+		return nil, 0, errors.Errorf("failed to encode binary: %w", err)
 	}
-	batch.ExecParams(
-		INSERT_MIGRATION_VERSION,
-		[][]byte{[]byte(m.Version), []byte(m.Name), encoded},
-		[]uint32{pgtype.TextOID, pgtype.TextOID, pgtype.TextArrayOID},
-		[]int16{pgtype.TextFormatCode, pgtype.TextFormatCode, valueFormat},
-		nil,
-	)
-	return nil
+	return encoded, valueFormat, nil
 }
 
 type SeedFile struct {
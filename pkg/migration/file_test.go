@@ -77,4 +77,114 @@ func TestMigrationFile(t *testing.T) {
 		assert.ErrorContains(t, err, "ERROR: schema \"public\" already exists (SQLSTATE 42P06)")
 		assert.ErrorContains(t, err, "At statement 0: create schema public")
 	})
+
+	t.Run("executes statements without transaction", func(t *testing.T) {
+		migration := MigrationFile{
+			Statements: []string{"create table t (id int)", "create index concurrently on t (id)"},
+			Version:    "0",
+		}
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.Statements[0]).
+			Reply("CREATE TABLE").
+			Query(migration.Statements[1]).
+			Reply("CREATE INDEX").
+			Query(INSERT_MIGRATION_VERSION, "0", "", migration.Statements).
+			Reply("INSERT 0 1")
+		// Run test
+		err := migration.ExecEach(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("stops at failed statement without transaction", func(t *testing.T) {
+		migration := MigrationFile{
+			Statements: []string{"create table t (id int)", "create index concurrently on t (id)"},
+			Version:    "0",
+		}
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.Statements[0]).
+			Reply("CREATE TABLE").
+			Query(migration.Statements[1]).
+			ReplyError(pgerrcode.UndefinedTable, `relation "t" does not exist`)
+		// Run test
+		err := migration.ExecEach(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.ErrorContains(t, err, `ERROR: relation "t" does not exist (SQLSTATE 42P01)`)
+		assert.ErrorContains(t, err, "At statement 1: create index concurrently on t (id)")
+	})
+
+	t.Run("splits down section", func(t *testing.T) {
+		// Setup in-memory fs
+		path := "20220727064247_create_table.sql"
+		fsys := fs.MapFS{
+			path: &fs.MapFile{Data: []byte(`create table t (id int);
+create index on t (id);
+-- migrate:down
+drop table t;`)},
+		}
+		// Run test
+		migration, err := NewMigrationFromFile(path, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"create table t (id int)", "create index on t (id)"}, migration.Statements)
+		assert.Equal(t, []string{"drop table t"}, migration.DownStatements)
+	})
+
+	t.Run("ignores down marker inside function body", func(t *testing.T) {
+		// Setup in-memory fs
+		path := "20220727064247_create_function.sql"
+		body := `create function f() returns int as $$
+-- migrate:down
+-- down
+select 1;
+$$ language sql`
+		fsys := fs.MapFS{
+			path: &fs.MapFile{Data: []byte(body + `;
+-- revert below
+-- migrate:down
+drop function f;`)},
+		}
+		// Run test
+		migration, err := NewMigrationFromFile(path, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{body}, migration.Statements)
+		assert.Equal(t, []string{"drop function f"}, migration.DownStatements)
+	})
+
+	t.Run("keeps plain down comments", func(t *testing.T) {
+		// Setup in-memory fs
+		path := "20220727064247_create_table.sql"
+		fsys := fs.MapFS{
+			path: &fs.MapFile{Data: []byte("create table t (id int);\n-- down\ncreate index on t (id);")},
+		}
+		// Run test
+		migration, err := NewMigrationFromFile(path, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Len(t, migration.Statements, 2)
+		assert.Empty(t, migration.DownStatements)
+	})
+
+	t.Run("reverts migration and history", func(t *testing.T) {
+		migration := MigrationFile{
+			DownStatements: []string{"drop schema test"},
+			Version:        "0",
+		}
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.DownStatements[0]).
+			Reply("DROP SCHEMA").
+			Query(DELETE_MIGRATION_VERSION, []string{"0"}).
+			Reply("DELETE 1")
+		// Run test
+		err := migration.ExecDownBatch(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.NoError(t, err)
+	})
 }
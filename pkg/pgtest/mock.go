@@ -183,7 +183,7 @@ func (r *MockConn) Reply(tag string, rows ...interface{}) *MockConn {
 				}
 			}
 		} else if t := reflect.TypeOf(data); t.Kind() == reflect.Struct {
-			s := reflect.ValueOf(rows[0])
+			s := reflect.ValueOf(data)
 			for i := 0; i < s.NumField(); i++ {
 				if name := pgxv5.GetColumnName(t.Field(i)); len(name) == 0 {
 					continue
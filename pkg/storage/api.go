@@ -4,6 +4,8 @@ import "github.com/supabase/cli/pkg/fetcher"
 
 type StorageAPI struct {
 	*fetcher.Fetcher
+	// Downloads objects from public buckets without authorization
+	Public bool
 }
 
 const PAGE_LIMIT = 100
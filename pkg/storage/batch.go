@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 
 	"github.com/go-errors/errors"
 	"github.com/supabase/cli/pkg/config"
@@ -25,10 +26,10 @@ func (s *StorageAPI) UpsertBuckets(ctx context.Context, bucketConfig config.Buck
 	for name, bucket := range bucketConfig {
 		// Update bucket properties if already exists
 		if bucketId, ok := exists[name]; ok {
-			for _, keep := range filter {
-				if !keep(bucketId) {
-					continue
-				}
+			if slices.ContainsFunc(filter, func(keep func(string) bool) bool {
+				return !keep(bucketId)
+			}) {
+				continue
 			}
 			fmt.Fprintln(os.Stderr, "Updating Storage bucket:", bucketId)
 			body := UpdateBucketRequest{
@@ -26,6 +26,14 @@ func (s *StorageAPI) ListBuckets(ctx context.Context) ([]BucketResponse, error)
 	return fetcher.ParseJSON[[]BucketResponse](resp.Body)
 }
 
+func (s *StorageAPI) GetBucket(ctx context.Context, bucketId string) (BucketResponse, error) {
+	resp, err := s.Send(ctx, http.MethodGet, "/storage/v1/bucket/"+bucketId, nil)
+	if err != nil {
+		return BucketResponse{}, err
+	}
+	return fetcher.ParseJSON[BucketResponse](resp.Body)
+}
+
 type CreateBucketRequest struct {
 	Name             string   `json:"name"`                         // "string",
 	Id               string   `json:"id,omitempty"`                 // "string",
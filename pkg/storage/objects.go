@@ -2,12 +2,14 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"io/fs"
 	"net/http"
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
@@ -28,6 +30,7 @@ type ObjectResponse struct {
 	CreatedAt      *string         `json:"created_at"`       // "2023-10-13T18:08:22.068Z"
 	LastAccessedAt *string         `json:"last_accessed_at"` // "2023-10-13T18:08:22.068Z"
 	Metadata       *ObjectMetadata `json:"metadata"`         // null
+	UserMetadata   map[string]any  `json:"user_metadata"`    // {"tags":{"env":"staging"}}
 }
 
 type ObjectMetadata struct {
@@ -40,6 +43,22 @@ type ObjectMetadata struct {
 	HttpStatusCode int    `json:"httpStatusCode"` // 200
 }
 
+// Returns the time when object was last updated, falling back to created time.
+func (o ObjectResponse) LastModified() (time.Time, error) {
+	timestamp := o.UpdatedAt
+	if timestamp == nil {
+		timestamp = o.CreatedAt
+	}
+	if timestamp == nil {
+		return time.Time{}, errors.Errorf("missing timestamp: %s", o.Name)
+	}
+	t, err := time.Parse(time.RFC3339, *timestamp)
+	if err != nil {
+		return time.Time{}, errors.Errorf("failed to parse timestamp: %w", err)
+	}
+	return t, nil
+}
+
 func (s *StorageAPI) ListObjects(ctx context.Context, bucket, prefix string, page int) ([]ObjectResponse, error) {
 	dir, name := path.Split(prefix)
 	query := ListObjectsQuery{
@@ -71,6 +90,12 @@ func ParseFileOptions(f fs.File, opts ...func(*FileOptions)) (*FileOptions, erro
 	if len(fo.CacheControl) == 0 {
 		fo.CacheControl = "max-age=3600"
 	}
+	// Extensions take precedence because sniffing reports web assets like js and css as plain text
+	if len(fo.ContentType) == 0 {
+		if info, err := f.Stat(); err == nil {
+			fo.ContentType = TypeByExtension(info.Name())
+		}
+	}
 	// Decode mimetype
 	if len(fo.ContentType) == 0 {
 		header := io.LimitReader(f, 512)
@@ -134,7 +159,11 @@ func (s *StorageAPI) DownloadObject(ctx context.Context, remotePath, localPath s
 
 func (s *StorageAPI) DownloadObjectStream(ctx context.Context, remotePath string, localFile io.Writer) error {
 	remotePath = strings.TrimPrefix(remotePath, "/")
-	resp, err := s.Send(ctx, http.MethodGet, "/storage/v1/object/"+remotePath, nil)
+	endpoint := "/storage/v1/object/"
+	if s.Public {
+		endpoint += "public/"
+	}
+	resp, err := s.Send(ctx, http.MethodGet, endpoint+remotePath, nil)
 	if err != nil {
 		return err
 	}
@@ -143,6 +172,42 @@ func (s *StorageAPI) DownloadObjectStream(ctx context.Context, remotePath string
 	return err
 }
 
+// Returned by DownloadObjectRange when the object no longer matches the given etag.
+var ErrObjectChanged = errors.New("object changed since the download started")
+
+// DownloadObjectRange writes length bytes of the object starting at offset to localFile.
+// The range is only served if the object still matches etag, so that chunks of different
+// versions are never mixed.
+func (s *StorageAPI) DownloadObjectRange(ctx context.Context, remotePath string, offset, length int64, etag string, localFile io.Writer) error {
+	remotePath = strings.TrimPrefix(remotePath, "/")
+	endpoint := "/storage/v1/object/"
+	if s.Public {
+		endpoint += "public/"
+	}
+	resp, err := s.Send(ctx, http.MethodGet, endpoint+remotePath, nil, func(req *http.Request) {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+		if len(etag) > 0 {
+			req.Header.Set("If-Match", etag)
+		}
+	})
+	if resp != nil && resp.StatusCode == http.StatusPreconditionFailed {
+		return ErrObjectChanged
+	} else if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Servers ignoring the range header would resend the whole object
+	if resp.StatusCode != http.StatusPartialContent {
+		return errors.Errorf("unexpected status %d: range requests are not supported", resp.StatusCode)
+	}
+	if n, err := io.Copy(localFile, resp.Body); err != nil {
+		return errors.Errorf("failed to download range: %w", err)
+	} else if n != length {
+		return errors.Errorf("short range: expected %d bytes, got %d", length, n)
+	}
+	return nil
+}
+
 type MoveObjectRequest struct {
 	BucketId       string `json:"bucketId"`
 	SourceKey      string `json:"sourceKey"`
@@ -208,3 +273,79 @@ func (s *StorageAPI) DeleteObjects(ctx context.Context, bucket string, prefixes
 	}
 	return fetcher.ParseJSON[[]DeleteObjectsResponse](resp.Body)
 }
+
+type SignObjectRequest struct {
+	ExpiresIn int `json:"expiresIn"`
+}
+
+type SignObjectResponse struct {
+	SignedURL string `json:"signedURL"` // "/object/sign/private/abstract.pdf?token=..."
+}
+
+// Creates a signed download URL, relative to the storage API path, that expires in seconds.
+func (s *StorageAPI) CreateSignedURL(ctx context.Context, remotePath string, expiresIn int) (string, error) {
+	remotePath = strings.TrimPrefix(remotePath, "/")
+	body := SignObjectRequest{ExpiresIn: expiresIn}
+	resp, err := s.Send(ctx, http.MethodPost, "/storage/v1/object/sign/"+remotePath, body)
+	if err != nil {
+		return "", err
+	}
+	data, err := fetcher.ParseJSON[SignObjectResponse](resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return data.SignedURL, nil
+}
+
+type SignUploadResponse struct {
+	Url string `json:"url"` // "/object/upload/sign/private/abstract.pdf?token=..."
+}
+
+// Creates a signed upload URL, relative to the storage API path, that accepts a single PUT
+// of the object contents without further authorization.
+func (s *StorageAPI) CreateSignedUploadURL(ctx context.Context, remotePath string, upsert bool) (string, error) {
+	remotePath = strings.TrimPrefix(remotePath, "/")
+	resp, err := s.Send(ctx, http.MethodPost, "/storage/v1/object/upload/sign/"+remotePath, nil, func(req *http.Request) {
+		if upsert {
+			req.Header.Add("x-upsert", "true")
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	data, err := fetcher.ParseJSON[SignUploadResponse](resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return data.Url, nil
+}
+
+// Checks that the contents of an object are present in the storage backend. Rows in
+// storage.objects may outlive their contents, in which case storage responds with not found.
+// Only the first byte is requested because HEAD responses carry no error body to tell not
+// found apart from other bad requests.
+func (s *StorageAPI) ObjectExists(ctx context.Context, remotePath string) (bool, error) {
+	remotePath = strings.TrimPrefix(remotePath, "/")
+	endpoint := "/storage/v1/object/"
+	if s.Public {
+		endpoint += "public/"
+	}
+	resp, err := s.Send(ctx, http.MethodGet, endpoint+remotePath, nil, func(req *http.Request) {
+		req.Header.Add("Range", "bytes=0-0")
+	})
+	if resp != nil && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// Empty objects cannot satisfy any range
+		return true, nil
+	} else if resp != nil && isNotFound(resp.StatusCode, err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	return true, nil
+}
+
+// Storage reports missing objects as bad request with a not_found error code.
+func isNotFound(status int, err error) bool {
+	return status == http.StatusNotFound || (status == http.StatusBadRequest && err != nil && strings.Contains(err.Error(), "not_found"))
+}
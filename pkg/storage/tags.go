@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+// Object tags are stored as a string map under this key of user metadata.
+const TagsMetadataKey = "tags"
+
+// Returns the tags stored in user metadata, ignoring values that are not strings.
+func (o ObjectResponse) Tags() map[string]string {
+	result := map[string]string{}
+	if tags, ok := o.UserMetadata[TagsMetadataKey].(map[string]any); ok {
+		for k, v := range tags {
+			if value, ok := v.(string); ok {
+				result[k] = value
+			}
+		}
+	}
+	return result
+}
+
+func (s *StorageAPI) GetObjectInfo(ctx context.Context, remotePath string) (ObjectResponse, error) {
+	remotePath = strings.TrimPrefix(remotePath, "/")
+	endpoint := "/storage/v1/object/info/"
+	if s.Public {
+		endpoint += "public/"
+	}
+	resp, err := s.Send(ctx, http.MethodGet, endpoint+remotePath, nil)
+	if err != nil {
+		return ObjectResponse{}, err
+	}
+	return fetcher.ParseJSON[ObjectResponse](resp.Body)
+}
+
+type UpdateObjectMetadataRequest struct {
+	BucketId       string         `json:"bucketId"`
+	SourceKey      string         `json:"sourceKey"`
+	DestinationKey string         `json:"destinationKey"`
+	Metadata       map[string]any `json:"metadata"`
+}
+
+// Replaces user metadata by copying the object onto itself, since storage has no update endpoint.
+func (s *StorageAPI) UpdateObjectMetadata(ctx context.Context, bucketId, objectPath string, metadata map[string]any) (CopyObjectResponse, error) {
+	body := UpdateObjectMetadataRequest{
+		BucketId:       bucketId,
+		SourceKey:      objectPath,
+		DestinationKey: objectPath,
+		Metadata:       metadata,
+	}
+	resp, err := s.Send(ctx, http.MethodPost, "/storage/v1/object/copy", body, func(req *http.Request) {
+		req.Header.Add("x-upsert", "true")
+	})
+	if err != nil {
+		return CopyObjectResponse{}, err
+	}
+	return fetcher.ParseJSON[CopyObjectResponse](resp.Body)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	clicmd "github.com/supabase/cli/cmd"
+	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 	"github.com/supabase/cli/test/mocks/supabase"
 )
@@ -56,6 +57,7 @@ func (suite *SecretsTestSuite) TestList() {
 		"Authorization":   []string{fmt.Sprintf("Bearer %s", supabase.AccessToken)},
 		"Accept-Encoding": []string{"gzip"},
 		"User-Agent":      []string{"SupabaseCLI/"},
+		utils.TraceHeader: []string{utils.GetTraceId()},
 	})
 
 	contents, err := os.ReadFile(tmpfile.Name())